@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -29,6 +30,20 @@ var (
 
 	troubleshoot = app.Command("troubleshoot", "Identify potentially divergent transactions.")
 
+	snapshot            = app.Command("snapshot", "Verify or inspect a ledger snapshot offline.")
+	verify              = snapshot.Command("verify", "Verify the file hashes and the record formats of a ledger snapshot.")
+	verifySnapshotPath  = verify.Arg("snapshotPath", "Ledger snapshot directory.").Required().String()
+	inspect             = snapshot.Command("inspect", "Summarize the contents of a ledger snapshot in json.")
+	inspectSnapshotPath = inspect.Arg("snapshotPath", "Ledger snapshot directory.").Required().String()
+	export              = snapshot.Command("export", "Export the public state and the private data hashes of a ledger snapshot.")
+	exportSnapshotPath  = export.Arg("snapshotPath", "Ledger snapshot directory.").Required().String()
+	exportOutputFile    = export.Flag("outputFile", "Output file. Default is the standard output.").Short('f').String()
+	exportFormat        = export.Flag("format", "Output format, jsonl or csv.").Default(ledger.ExportFormatJSONLines).Enum(ledger.ExportFormatJSONLines, ledger.ExportFormatCSV)
+	exportNamespaces    = export.Flag("namespace", "Export only the given namespace. Can be repeated.").Short('n').Strings()
+	exportKeyPrefix     = export.Flag("keyPrefix", "Export only the public state keys that begin with the prefix. Private data hashes are not exported when specified.").String()
+	exportValueEncoding = export.Flag("valueEncoding", "Encoding of the public state values, utf8 or base64.").Default(ledger.ValueEncodingUTF8).Enum(ledger.ValueEncodingUTF8, ledger.ValueEncodingBase64)
+	exportPvtHashes     = export.Flag("pvtHashes", "Export the private data hashes. Use --no-pvtHashes to exclude them.").Default("true").Bool()
+
 	args = os.Args[1:]
 )
 
@@ -70,5 +85,60 @@ func main() {
 
 		fmt.Println("Command TBD")
 
+	case verify.FullCommand():
+
+		if err := ledger.VerifySnapshot(*verifySnapshotPath); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("\nSuccessfully verified snapshot %s\n", *verifySnapshotPath)
+
+	case inspect.FullCommand():
+
+		summary, err := ledger.InspectSnapshot(*inspectSnapshotPath)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		summaryJSON, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(string(summaryJSON))
+
+	case export.FullCommand():
+
+		output := os.Stdout
+		if *exportOutputFile != "" {
+			output, err = os.Create(*exportOutputFile)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		count, err := ledger.ExportSnapshot(*exportSnapshotPath, output, &ledger.ExportOptions{
+			Format:                *exportFormat,
+			ValueEncoding:         *exportValueEncoding,
+			Namespaces:            *exportNamespaces,
+			KeyPrefix:             *exportKeyPrefix,
+			ExcludePvtStateHashes: !*exportPvtHashes,
+		})
+		var closeErr error
+		if output != os.Stdout {
+			closeErr = output.Close()
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		// the exported records may not have been written to the file if it cannot be closed
+		if closeErr != nil {
+			fmt.Fprintf(os.Stderr, "failed to close the output file %s: %s\n", *exportOutputFile, closeErr)
+			os.Exit(1)
+		}
+		// the summary is written to the standard error so as not to mix it with the exported records
+		fmt.Fprintf(os.Stderr, "Successfully exported %d records from snapshot %s\n", count, *exportSnapshotPath)
+
 	}
 }
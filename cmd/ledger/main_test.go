@@ -40,6 +40,38 @@ func TestArguments(t *testing.T) {
 			exitCode: 1,
 			args:     []string{"compare, snapshotDir1"},
 		},
+		"snapshot-verify-help": {
+			exitCode: 0,
+			args:     []string{"snapshot", "verify", "--help"},
+		},
+		"snapshot-verify": {
+			exitCode: 1,
+			args:     []string{"snapshot", "verify"},
+		},
+		"snapshot-verify-missing-dir": {
+			exitCode: 1,
+			args:     []string{"snapshot", "verify", "nonExistentSnapshotDir"},
+		},
+		"snapshot-inspect-help": {
+			exitCode: 0,
+			args:     []string{"snapshot", "inspect", "--help"},
+		},
+		"snapshot-inspect": {
+			exitCode: 1,
+			args:     []string{"snapshot", "inspect"},
+		},
+		"snapshot-export-help": {
+			exitCode: 0,
+			args:     []string{"snapshot", "export", "--help"},
+		},
+		"snapshot-export-invalid-format": {
+			exitCode: 1,
+			args:     []string{"snapshot", "export", "snapshotDir", "--format", "xml"},
+		},
+		"snapshot-export-missing-dir": {
+			exitCode: 1,
+			args:     []string{"snapshot", "export", "nonExistentSnapshotDir"},
+		},
 	}
 
 	// Build ledger binary
@@ -87,6 +87,26 @@ func TestApplicationV20(t *testing.T) {
 	require.True(t, ap.PrivateChannelData())
 	require.True(t, ap.LifecycleV20())
 	require.True(t, ap.StorePvtDataOfInvalidTx())
+	require.False(t, ap.MultipleChaincodeEvents())
+}
+
+func TestApplicationV24(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV2_4: {},
+	})
+	require.NoError(t, ap.Supported())
+	require.True(t, ap.ForbidDuplicateTXIdInBlock())
+	require.True(t, ap.V1_1Validation())
+	require.True(t, ap.V1_2Validation())
+	require.True(t, ap.V1_3Validation())
+	require.True(t, ap.V2_0Validation())
+	require.True(t, ap.KeyLevelEndorsement())
+	require.True(t, ap.ACLs())
+	require.True(t, ap.CollectionUpgrade())
+	require.True(t, ap.PrivateChannelData())
+	require.True(t, ap.LifecycleV20())
+	require.True(t, ap.StorePvtDataOfInvalidTx())
+	require.True(t, ap.MultipleChaincodeEvents())
 }
 
 func TestApplicationPvtDataExperimental(t *testing.T) {
@@ -102,6 +122,7 @@ func TestHasCapability(t *testing.T) {
 	require.True(t, ap.HasCapability(ApplicationV1_2))
 	require.True(t, ap.HasCapability(ApplicationV1_3))
 	require.True(t, ap.HasCapability(ApplicationV2_0))
+	require.True(t, ap.HasCapability(ApplicationV2_4))
 	require.True(t, ap.HasCapability(ApplicationPvtDataExperimental))
 	require.True(t, ap.HasCapability(ApplicationResourcesTreeExperimental))
 	require.False(t, ap.HasCapability("default"))
@@ -191,6 +191,10 @@ type ApplicationCapabilities interface {
 	// MetadataLifecycle always returns false
 	MetadataLifecycle() bool
 
+	// MultipleChaincodeEvents returns true if this channel supports transactions
+	// which carry more than one chaincode event (as introduced in v2.4).
+	MultipleChaincodeEvents() bool
+
 	// KeyLevelEndorsement returns true if this channel supports endorsement
 	// policies expressible at a ledger key granularity, as described in FAB-8812
 	KeyLevelEndorsement() bool
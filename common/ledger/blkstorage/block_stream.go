@@ -28,6 +28,7 @@ type blockfileStream struct {
 	file          *os.File
 	reader        *bufio.Reader
 	currentOffset int64
+	compressed    bool
 }
 
 // blockStream reads blocks sequentially from multiple files.
@@ -46,6 +47,7 @@ type blockPlacementInfo struct {
 	fileNum          int
 	blockStartOffset int64
 	blockBytesOffset int64
+	compressed       bool
 }
 
 ///////////////////////////////////
@@ -59,6 +61,23 @@ func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockf
 	if file, err = os.OpenFile(filePath, os.O_RDONLY, 0o600); err != nil {
 		return nil, errors.Wrapf(err, "error opening block file %s", filePath)
 	}
+	compressed, err := isCompressedBlockfile(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if compressed && startOffset < int64(len(compressedBlockfileHeader)) {
+		// skip the header of the compressed block file
+		fileInfo, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, errors.Wrapf(err, "error getting block file stat")
+		}
+		startOffset = int64(len(compressedBlockfileHeader))
+		if fileInfo.Size() < startOffset {
+			startOffset = fileInfo.Size()
+		}
+	}
 	var newPosition int64
 	if newPosition, err = file.Seek(startOffset, 0); err != nil {
 		return nil, errors.Wrapf(err, "error seeking block file [%s] to startOffset [%d]", filePath, startOffset)
@@ -67,7 +86,7 @@ func newBlockfileStream(rootDir string, fileNum int, startOffset int64) (*blockf
 		panic(fmt.Sprintf("Could not seek block file [%s] to startOffset [%d]. New position = [%d]",
 			filePath, startOffset, newPosition))
 	}
-	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset}
+	s := &blockfileStream{fileNum, file, bufio.NewReader(file), startOffset, compressed}
 	return s, nil
 }
 
@@ -129,10 +148,16 @@ func (s *blockfileStream) nextBlockBytesAndPlacementInfo() ([]byte, *blockPlacem
 		logger.Errorf("Error reading [%d] bytes from file number [%d], error: %s", length, s.fileNum, err)
 		return nil, nil, errors.Wrapf(err, "error reading [%d] bytes from file number [%d]", length, s.fileNum)
 	}
+	if s.compressed {
+		if blockBytes, err = decompressBlockBytes(blockBytes); err != nil {
+			return nil, nil, errors.WithMessagef(err, "error reading block at offset [%d] from file number [%d]", s.currentOffset, s.fileNum)
+		}
+	}
 	blockPlacementInfo := &blockPlacementInfo{
 		fileNum:          s.fileNum,
 		blockStartOffset: s.currentOffset,
 		blockBytesOffset: s.currentOffset + int64(n),
+		compressed:       s.compressed,
 	}
 	s.currentOffset += int64(n) + int64(length)
 	logger.Debugf("Returning blockbytes - length=[%d], placementInfo={%s}", len(blockBytes), blockPlacementInfo)
@@ -195,6 +220,6 @@ func (s *blockStream) close() error {
 }
 
 func (i *blockPlacementInfo) String() string {
-	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d]",
-		i.fileNum, i.blockStartOffset, i.blockBytesOffset)
+	return fmt.Sprintf("fileNum=[%d], startOffset=[%d], bytesOffset=[%d], compressed=[%t]",
+		i.fileNum, i.blockStartOffset, i.blockBytesOffset, i.compressed)
 }
@@ -38,8 +38,10 @@ type blockfileMgr struct {
 	index                     *blockIndex
 	blockfilesInfo            *blockfilesInfo
 	bootstrappingSnapshotInfo *BootstrappingSnapshotInfo
+	blocksPruned              bool
 	blkfilesInfoCond          *sync.Cond
 	currentFileWriter         *blockfileWriter
+	currentFileCompressed     bool
 	bcInfo                    atomic.Value
 }
 
@@ -93,6 +95,10 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 	}
 	mgr := &blockfileMgr{rootDir: rootDir, conf: conf, db: indexStore}
 
+	if err := resumePrune(rootDir, indexConfig, indexStore); err != nil {
+		return nil, errors.WithMessage(err, "error while resuming the interrupted prune operation")
+	}
+
 	blockfilesInfo, err := mgr.loadBlkfilesInfo()
 	if err != nil {
 		panic(fmt.Sprintf("Could not get block file info for current block file from db: %s", err))
@@ -130,7 +136,20 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *IndexConfig, indexStore
 		return nil, err
 	}
 	mgr.bootstrappingSnapshotInfo = bsi
+	if mgr.blocksPruned, err = blocksPruned(rootDir); err != nil {
+		return nil, err
+	}
 	mgr.currentFileWriter = currentFileWriter
+	// an empty block file is written in the format as per the configuration, whereas a block file that already
+	// contains data continues to be written in its existing format
+	mgr.currentFileCompressed = conf.compressBlockfiles
+	if blockfilesInfo.latestFileSize > 0 {
+		if mgr.currentFileCompressed, err = isCompressedBlockfileAtPath(
+			deriveBlockfilePath(rootDir, blockfilesInfo.latestFileNumber),
+		); err != nil {
+			return nil, err
+		}
+	}
 	mgr.blkfilesInfoCond = sync.NewCond(&sync.Mutex{})
 
 	if err := mgr.syncIndex(); err != nil {
@@ -268,6 +287,7 @@ func (mgr *blockfileMgr) moveToNextFile() {
 		panic(fmt.Sprintf("Could not save next block file info to db: %s", err))
 	}
 	mgr.currentFileWriter = nextFileWriter
+	mgr.currentFileCompressed = mgr.conf.compressBlockfiles
 	mgr.updateBlockfilesInfo(blkfilesInfo)
 }
 
@@ -299,21 +319,38 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	txOffsets := info.txOffsets
 	currentOffset := mgr.blockfilesInfo.latestFileSize
 
-	blockBytesLen := len(blockBytes)
-	blockBytesEncodedLen := proto.EncodeVarint(uint64(blockBytesLen))
-	totalBytesToAppend := blockBytesLen + len(blockBytesEncodedLen)
+	blockRecordBytes, err := mgr.blockRecordBytes(blockBytes)
+	if err != nil {
+		return err
+	}
+	blockRecordEncodedLen := proto.EncodeVarint(uint64(len(blockRecordBytes)))
+	totalBytesToAppend := len(blockRecordBytes) + len(blockRecordEncodedLen)
 
 	// Determine if we need to start a new file since the size of this block
 	// exceeds the amount of space left in the current file
 	if currentOffset+totalBytesToAppend > mgr.conf.maxBlockfileSize {
 		mgr.moveToNextFile()
 		currentOffset = 0
-	}
-	// append blockBytesEncodedLen to the file
-	err = mgr.currentFileWriter.append(blockBytesEncodedLen, false)
+		// the next file may be in a different format than the previous one
+		if blockRecordBytes, err = mgr.blockRecordBytes(blockBytes); err != nil {
+			return err
+		}
+		blockRecordEncodedLen = proto.EncodeVarint(uint64(len(blockRecordBytes)))
+		totalBytesToAppend = len(blockRecordBytes) + len(blockRecordEncodedLen)
+	}
+	// a compressed block file begins with a header, which is written along with the first block.
+	// If the file contains a partially written header, only the remaining bytes of the header are written
+	if mgr.currentFileCompressed && currentOffset < len(compressedBlockfileHeader) {
+		fileHeader := compressedBlockfileHeader[currentOffset:]
+		blockRecordEncodedLen = append(append([]byte{}, fileHeader...), blockRecordEncodedLen...)
+		totalBytesToAppend += len(fileHeader)
+		currentOffset += len(fileHeader)
+	}
+	// append blockRecordEncodedLen to the file
+	err = mgr.currentFileWriter.append(blockRecordEncodedLen, false)
 	if err == nil {
 		// append the actual block bytes to the file
-		err = mgr.currentFileWriter.append(blockBytes, true)
+		err = mgr.currentFileWriter.append(blockRecordBytes, true)
 	}
 	if err != nil {
 		truncateErr := mgr.currentFileWriter.truncateFile(mgr.blockfilesInfo.latestFileSize)
@@ -343,14 +380,18 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	// Index block file location pointer updated with file suffex and offset for the new block
 	blockFLP := &fileLocPointer{fileSuffixNum: newBlkfilesInfo.latestFileNumber}
 	blockFLP.offset = currentOffset
-	// shift the txoffset because we prepend length of bytes before block bytes
-	for _, txOffset := range txOffsets {
-		txOffset.loc.offset += len(blockBytesEncodedLen)
+	if !mgr.currentFileCompressed {
+		// shift the txoffset because we prepend length of bytes before block bytes.
+		// For a compressed block, the txoffsets remain relative to the decompressed block bytes
+		for _, txOffset := range txOffsets {
+			txOffset.loc.offset += len(blockRecordEncodedLen)
+		}
 	}
 	// save the index in the database
 	if err = mgr.index.indexBlock(&blockIdxInfo{
 		blockNum: block.Header.Number, blockHash: blockHash,
 		flp: blockFLP, txOffsets: txOffsets, metadata: block.Metadata,
+		compressed: mgr.currentFileCompressed,
 	}); err != nil {
 		return err
 	}
@@ -361,6 +402,18 @@ func (mgr *blockfileMgr) addBlock(block *common.Block) error {
 	return nil
 }
 
+// blockRecordBytes returns the bytes that are stored in the current block file for the serialized block
+func (mgr *blockfileMgr) blockRecordBytes(blockBytes []byte) ([]byte, error) {
+	if !mgr.currentFileCompressed {
+		return blockBytes, nil
+	}
+	compressedBytes, err := compressBlockBytes(blockBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error compressing block")
+	}
+	return compressedBytes, nil
+}
+
 func (mgr *blockfileMgr) syncIndex() error {
 	nextIndexableBlock := uint64(0)
 	lastBlockIndexed, err := mgr.index.getLastBlockIndexed()
@@ -449,10 +502,13 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 
 		// The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
-		// therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
+		// therefore just shift by the difference between blockBytesOffset and blockStartOffset.
+		// For a compressed block, the txOffsets remain relative to the decompressed block bytes
+		if !blockPlacementInfo.compressed {
+			numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+			for _, offset := range info.txOffsets {
+				offset.loc.offset += numBytesToShift
+			}
 		}
 
 		// Update the blockIndexInfo with what was actually stored in file system
@@ -464,6 +520,7 @@ func (mgr *blockfileMgr) syncIndex() error {
 		}
 		blockIdxInfo.txOffsets = info.txOffsets
 		blockIdxInfo.metadata = info.metadata
+		blockIdxInfo.compressed = blockPlacementInfo.compressed
 
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
@@ -518,10 +575,7 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
 	if blockNum < mgr.firstPossibleBlockNumberInBlockFiles() {
-		return nil, errors.Errorf(
-			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
-			blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
-		)
+		return nil, mgr.blockUnavailableErr(blockNum)
 	}
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -534,9 +588,7 @@ func (mgr *blockfileMgr) retrieveBlockByTxID(txID string) (*common.Block, error)
 	logger.Debugf("retrieveBlockByTxID() - txID = [%s]", txID)
 	loc, err := mgr.index.getBlockLocByTxID(txID)
 	if err == errNilValue {
-		return nil, errors.Errorf(
-			"details for the TXID [%s] not available. Ledger bootstrapped from a snapshot. First available block = [%d]",
-			txID, mgr.firstPossibleBlockNumberInBlockFiles())
+		return nil, mgr.txIDDetailsUnavailableErr(txID)
 	}
 	if err != nil {
 		return nil, err
@@ -548,9 +600,7 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 	logger.Debugf("retrieveTxValidationCodeByTxID() - txID = [%s]", txID)
 	validationCode, blkNum, err := mgr.index.getTxValidationCodeByTxID(txID)
 	if err == errNilValue {
-		return peer.TxValidationCode(-1), 0, errors.Errorf(
-			"details for the TXID [%s] not available. Ledger bootstrapped from a snapshot. First available block = [%d]",
-			txID, mgr.firstPossibleBlockNumberInBlockFiles())
+		return peer.TxValidationCode(-1), 0, mgr.txIDDetailsUnavailableErr(txID)
 	}
 	return validationCode, blkNum, err
 }
@@ -558,10 +608,7 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
 	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
 	if blockNum < mgr.firstPossibleBlockNumberInBlockFiles() {
-		return nil, errors.Errorf(
-			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
-			blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
-		)
+		return nil, mgr.blockUnavailableErr(blockNum)
 	}
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
@@ -580,10 +627,7 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
 	if startNum < mgr.firstPossibleBlockNumberInBlockFiles() {
-		return nil, errors.Errorf(
-			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
-			startNum, mgr.firstPossibleBlockNumberInBlockFiles(),
-		)
+		return nil, mgr.blockUnavailableErr(startNum)
 	}
 	return newBlockItr(mgr, startNum), nil
 }
@@ -596,9 +640,7 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 	logger.Debugf("retrieveTransactionByID() - txId = [%s]", txID)
 	loc, err := mgr.index.getTxLoc(txID)
 	if err == errNilValue {
-		return nil, errors.Errorf(
-			"details for the TXID [%s] not available. Ledger bootstrapped from a snapshot. First available block = [%d]",
-			txID, mgr.firstPossibleBlockNumberInBlockFiles())
+		return nil, mgr.txIDDetailsUnavailableErr(txID)
 	}
 	if err != nil {
 		return nil, err
@@ -609,10 +651,7 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error) {
 	logger.Debugf("retrieveTransactionByBlockNumTranNum() - blockNum = [%d], tranNum = [%d]", blockNum, tranNum)
 	if blockNum < mgr.firstPossibleBlockNumberInBlockFiles() {
-		return nil, errors.Errorf(
-			"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
-			blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
-		)
+		return nil, mgr.blockUnavailableErr(blockNum)
 	}
 	loc, err := mgr.index.getTXLocByBlockNumTranNum(blockNum, tranNum)
 	if err != nil {
@@ -658,6 +697,19 @@ func (mgr *blockfileMgr) fetchBlockBytes(lp *fileLocPointer) ([]byte, error) {
 }
 
 func (mgr *blockfileMgr) fetchRawBytes(lp *fileLocPointer) ([]byte, error) {
+	if lp.isInCompressedBlock() {
+		// the raw bytes are located within the decompressed bytes of the block
+		blockBytes, err := mgr.fetchBlockBytes(
+			&fileLocPointer{fileSuffixNum: lp.fileSuffixNum, locPointer: locPointer{offset: lp.compressedBlockOffset}},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if lp.offset+lp.bytesLength > len(blockBytes) {
+			return nil, errors.Errorf("location [%s] is beyond the decompressed block bytes of length [%d]", lp, len(blockBytes))
+		}
+		return blockBytes[lp.offset : lp.offset+lp.bytesLength], nil
+	}
 	filePath := deriveBlockfilePath(mgr.rootDir, lp.fileSuffixNum)
 	reader, err := newBlockfileReader(filePath)
 	if err != nil {
@@ -708,6 +760,32 @@ func (mgr *blockfileMgr) bootstrappedFromSnapshot() bool {
 	return mgr.firstPossibleBlockNumberInBlockFiles() > 0
 }
 
+func (mgr *blockfileMgr) blockUnavailableErr(blockNum uint64) error {
+	if mgr.blocksPruned {
+		return errors.Errorf(
+			"cannot serve block [%d]. The block has been pruned from the ledger. First available block = [%d]",
+			blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
+		)
+	}
+	return errors.Errorf(
+		"cannot serve block [%d]. The ledger is bootstrapped from a snapshot. First available block = [%d]",
+		blockNum, mgr.firstPossibleBlockNumberInBlockFiles(),
+	)
+}
+
+func (mgr *blockfileMgr) txIDDetailsUnavailableErr(txID string) error {
+	if mgr.blocksPruned {
+		return errors.Errorf(
+			"details for the TXID [%s] not available. The block containing the transaction has been pruned from the ledger. First available block = [%d]",
+			txID, mgr.firstPossibleBlockNumberInBlockFiles(),
+		)
+	}
+	return errors.Errorf(
+		"details for the TXID [%s] not available. Ledger bootstrapped from a snapshot. First available block = [%d]",
+		txID, mgr.firstPossibleBlockNumberInBlockFiles(),
+	)
+}
+
 // scanForLastCompleteBlock scan a given block file and detects the last offset in the file
 // after which there may lie a block partially written (towards the end of the file in a crash scenario).
 func scanForLastCompleteBlock(rootDir string, fileNum int, startingOffset int64) ([]byte, int64, int, error) {
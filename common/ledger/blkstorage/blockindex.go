@@ -47,6 +47,9 @@ type blockIdxInfo struct {
 	flp       *fileLocPointer
 	txOffsets []*txindexInfo
 	metadata  *common.BlockMetadata
+	// compressed indicates that the block is stored compressed and hence, the txOffsets are relative
+	// to the decompressed block bytes
+	compressed bool
 }
 
 type blockIndex struct {
@@ -110,7 +113,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	// Index3 Used to find a transaction by its transaction id
 	if index.isAttributeIndexed(IndexableAttrTxID) {
 		for i, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := blockIdxInfo.txFileLocPointer(txoffset)
 			logger.Debugf("Adding txLoc [%s] for tx ID: [%s] to txid-index", txFlp, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -136,7 +139,7 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	// Index4 - Store BlockNumTranNum will be used to query history data
 	if index.isAttributeIndexed(IndexableAttrBlockNumTranNum) {
 		for i, txoffset := range txOffsets {
-			txFlp := newFileLocationPointer(flp.fileSuffixNum, flp.offset, txoffset.loc)
+			txFlp := blockIdxInfo.txFileLocPointer(txoffset)
 			logger.Debugf("Adding txLoc [%s] for tx number:[%d] ID: [%s] to blockNumTranNum index", txFlp, i, txoffset.txID)
 			txFlpBytes, marshalErr := txFlp.marshal()
 			if marshalErr != nil {
@@ -500,6 +503,11 @@ func (lp *locPointer) String() string {
 type fileLocPointer struct {
 	fileSuffixNum int
 	locPointer
+	// compressedBlockOffset is the offset of the compressed block in the file that contains the
+	// location pointed to. In this case, the offset in the locPointer is relative to the decompressed
+	// block bytes. A compressed block file begins with a header and hence, for a pointer to a location
+	// within a compressed block, this value is always greater than zero
+	compressedBlockOffset int
 }
 
 func newFileLocationPointer(fileSuffixNum int, beginningOffset int, relativeLP *locPointer) *fileLocPointer {
@@ -523,6 +531,13 @@ func (flp *fileLocPointer) marshal() ([]byte, error) {
 	if e != nil {
 		return nil, errors.Wrapf(e, "unexpected error while marshaling fileLocPointer [%s]", flp)
 	}
+	if flp.isInCompressedBlock() {
+		// for the locations in uncompressed files, the encoding remains the same as in the previous versions
+		e = buffer.EncodeVarint(uint64(flp.compressedBlockOffset))
+		if e != nil {
+			return nil, errors.Wrapf(e, "unexpected error while marshaling fileLocPointer [%s]", flp)
+		}
+	}
 	return buffer.Bytes(), nil
 }
 
@@ -544,13 +559,43 @@ func (flp *fileLocPointer) unmarshal(b []byte) error {
 		return errors.Wrapf(e, "unexpected error while unmarshalling bytes [%#v] into fileLocPointer", b)
 	}
 	flp.bytesLength = int(i)
+	// the compressedBlockOffset is present only for a location within a compressed block
+	numBytesDecoded := proto.SizeVarint(uint64(flp.fileSuffixNum)) +
+		proto.SizeVarint(uint64(flp.offset)) +
+		proto.SizeVarint(uint64(flp.bytesLength))
+	if len(b) == numBytesDecoded {
+		return nil
+	}
+	i, e = buffer.DecodeVarint()
+	if e != nil {
+		return errors.Wrapf(e, "unexpected error while unmarshalling bytes [%#v] into fileLocPointer", b)
+	}
+	flp.compressedBlockOffset = int(i)
 	return nil
 }
 
+func (flp *fileLocPointer) isInCompressedBlock() bool {
+	return flp.compressedBlockOffset > 0
+}
+
 func (flp *fileLocPointer) String() string {
+	if flp.isInCompressedBlock() {
+		return fmt.Sprintf("fileSuffixNum=%d, compressedBlockOffset=%d, %s",
+			flp.fileSuffixNum, flp.compressedBlockOffset, flp.locPointer.String())
+	}
 	return fmt.Sprintf("fileSuffixNum=%d, %s", flp.fileSuffixNum, flp.locPointer.String())
 }
 
+// txFileLocPointer returns the location of the transaction in the block file
+func (blockIdxInfo *blockIdxInfo) txFileLocPointer(txOffset *txindexInfo) *fileLocPointer {
+	if !blockIdxInfo.compressed {
+		return newFileLocationPointer(blockIdxInfo.flp.fileSuffixNum, blockIdxInfo.flp.offset, txOffset.loc)
+	}
+	txFlp := newFileLocationPointer(blockIdxInfo.flp.fileSuffixNum, 0, txOffset.loc)
+	txFlp.compressedBlockOffset = blockIdxInfo.flp.offset
+	return txFlp
+}
+
 func (blockIdxInfo *blockIdxInfo) String() string {
 	var buffer bytes.Buffer
 	for _, txOffset := range blockIdxInfo.txOffsets {
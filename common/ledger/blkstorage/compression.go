@@ -0,0 +1,212 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/DataDog/zstd"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
+)
+
+const compressTempBlockfile = "__compressTempBlockfile"
+
+// compressedBlockfileHeader is written at the beginning of a block file in which each block is stored as a
+// separate zstd frame. In an uncompressed block file, the first byte is the varint encoded length of the first block,
+// which is never zero. Hence, the leading zero byte makes it unambiguous to distinguish between the two formats.
+var compressedBlockfileHeader = []byte{0x00, 'z', 's', 't', 'd', 0x01}
+
+// isCompressedBlockfile returns true if the file begins with the header of a compressed block file.
+// A file that contains only a part of the header (possible if a crash happens while writing the header)
+// is also considered as a compressed block file that does not contain any block
+func isCompressedBlockfile(file *os.File) (bool, error) {
+	header := make([]byte, len(compressedBlockfileHeader))
+	n, err := file.ReadAt(header, 0)
+	if err != nil && err != io.EOF {
+		return false, errors.Wrapf(err, "error reading header of the block file [%s]", file.Name())
+	}
+	return n > 0 && bytes.Equal(header[:n], compressedBlockfileHeader[:n]), nil
+}
+
+func isCompressedBlockfileAtPath(filePath string) (bool, error) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error opening the block file [%s]", filePath)
+	}
+	defer file.Close()
+	return isCompressedBlockfile(file)
+}
+
+func compressBlockBytes(blockBytes []byte) ([]byte, error) {
+	compressedBytes, err := zstd.Compress(nil, blockBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error compressing block bytes")
+	}
+	return compressedBytes, nil
+}
+
+func decompressBlockBytes(compressedBytes []byte) ([]byte, error) {
+	blockBytes, err := zstd.Decompress(nil, compressedBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decompressing block bytes")
+	}
+	return blockBytes, nil
+}
+
+// CompressBlockfiles rewrites the uncompressed block files of all the ledgers in the compressed format.
+// The block files that are already compressed are left untouched. As the locations of the blocks change,
+// the index is marked for a rebuild, which takes place when the block store is opened next time.
+// If this function fails midway, it can safely be invoked again. This function is expected to be invoked
+// when the peer is offline.
+func CompressBlockfiles(blockStorageDir string, indexConfig *IndexConfig) error {
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	chainsDir := conf.getChainsDir()
+	chainsDirExists, err := pathExists(chainsDir)
+	if err != nil {
+		return err
+	}
+	if !chainsDirExists {
+		logger.Infof("Dir [%s] missing... exiting", chainsDir)
+		return nil
+	}
+	ledgerIDs, err := fileutil.ListSubdirs(chainsDir)
+	if err != nil {
+		return err
+	}
+	if len(ledgerIDs) == 0 {
+		logger.Info("No ledgers found.. exiting")
+		return nil
+	}
+
+	dbProvider, err := leveldbhelper.NewProvider(
+		&leveldbhelper.Conf{
+			DBPath:         conf.getIndexDir(),
+			ExpectedFormat: dataFormatVersion(indexConfig),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer dbProvider.Close()
+
+	logger.Infof("Found ledgers - %s", ledgerIDs)
+	for _, ledgerID := range ledgerIDs {
+		ledgerDir := conf.getLedgerBlockDir(ledgerID)
+		lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+		if err != nil {
+			return err
+		}
+		if lastFileNum < 0 {
+			logger.Infof("No block files found for ledger [%s]", ledgerID)
+			continue
+		}
+		// the index is marked for rebuild before rewriting any block file so that a crash midway
+		// does not leave the index pointing to the stale locations
+		if err := markBlockIndexForRebuild(ledgerDir, dbProvider.GetDBHandle(ledgerID)); err != nil {
+			return err
+		}
+		for fileNum := 0; fileNum <= lastFileNum; fileNum++ {
+			if err := compressBlockfile(ledgerDir, fileNum); err != nil {
+				return err
+			}
+		}
+		if err := fileutil.SyncDir(ledgerDir); err != nil {
+			return err
+		}
+		logger.Infof("Compressed the block files for ledger [%s]", ledgerID)
+	}
+	return nil
+}
+
+// markBlockIndexForRebuild resets the index savepoint such that all the blocks present in the block files are
+// indexed again and removes the blockfiles info such that it is constructed afresh from the block files
+func markBlockIndexForRebuild(ledgerDir string, indexDB *leveldbhelper.DBHandle) error {
+	bsi, err := loadBootstrappingSnapshotInfo(ledgerDir)
+	if err != nil {
+		return err
+	}
+	batch := indexDB.NewUpdateBatch()
+	batch.Delete(blkMgrInfoKey)
+	if bsi != nil {
+		batch.Put(indexSavePointKey, encodeBlockNum(bsi.LastBlockNum))
+	} else {
+		batch.Delete(indexSavePointKey)
+	}
+	return indexDB.WriteBatch(batch, true)
+}
+
+// compressBlockfile rewrites the given block file in the compressed format, unless the file is already
+// compressed or does not contain any block. A partially written block towards the end of the file, if any,
+// is discarded, as is done by the block store during its start up
+func compressBlockfile(ledgerDir string, fileNum int) error {
+	filePath := deriveBlockfilePath(ledgerDir, fileNum)
+	stream, err := newBlockfileStream(ledgerDir, fileNum, 0)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	if stream.compressed {
+		logger.Debugf("Block file [%s] is already compressed", filePath)
+		return nil
+	}
+
+	tempFilePath := filepath.Join(ledgerDir, compressTempBlockfile)
+	dest, err := os.OpenFile(tempFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating the file [%s]", tempFilePath)
+	}
+	defer dest.Close()
+	if _, err := dest.Write(compressedBlockfileHeader); err != nil {
+		return errors.Wrapf(err, "error writing the header to the file [%s]", tempFilePath)
+	}
+
+	numBlocks := 0
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err == ErrUnexpectedEndOfBlockfile {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		compressedBytes, err := compressBlockBytes(blockBytes)
+		if err != nil {
+			return err
+		}
+		if _, err := dest.Write(proto.EncodeVarint(uint64(len(compressedBytes)))); err != nil {
+			return errors.Wrapf(err, "error writing to the file [%s]", tempFilePath)
+		}
+		if _, err := dest.Write(compressedBytes); err != nil {
+			return errors.Wrapf(err, "error writing to the file [%s]", tempFilePath)
+		}
+		numBlocks++
+	}
+	if numBlocks == 0 {
+		logger.Debugf("Block file [%s] does not contain any block", filePath)
+		return os.Remove(tempFilePath)
+	}
+	if err := dest.Sync(); err != nil {
+		return errors.Wrapf(err, "error while synching the file [%s]", tempFilePath)
+	}
+	if err := os.Rename(tempFilePath, filePath); err != nil {
+		return errors.Wrapf(err, "error renaming the file [%s] to [%s]", tempFilePath, filePath)
+	}
+	logger.Infof("Compressed [%d] blocks in the block file [%s]", numBlocks, filePath)
+	return nil
+}
@@ -0,0 +1,286 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressedBlockfileReadWrite(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	env := newTestEnv(t, NewConfWithCompression(path, 0, true))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	for i, b := range blocks {
+		require.NoError(t, blkfileMgr.addBlock(b))
+		if i != 0 && i%10 == 0 {
+			blkfileMgr.moveToNextFile()
+		}
+	}
+
+	for fileNum := 0; fileNum <= 2; fileNum++ {
+		compressed, err := isCompressedBlockfileAtPath(deriveBlockfilePath(blkfileMgr.rootDir, fileNum))
+		require.NoError(t, err)
+		require.True(t, compressed)
+	}
+	verifyBlocksInBlockfileMgr(t, blkfileMgr, blocks)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// the blocks are retrieved after a restart with compression disabled
+	env = newTestEnv(t, NewConf(path, 0))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	verifyBlocksInBlockfileMgr(t, blkfileMgrWrapper.blockfileMgr, blocks)
+}
+
+func TestMixedBlockfileFormats(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[:10])
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// after enabling the compression, the current file continues in the uncompressed format
+	// and the next file is created in the compressed format
+	env = newTestEnv(t, NewConfWithCompression(path, 0, true))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	require.False(t, blkfileMgr.currentFileCompressed)
+	require.NoError(t, blkfileMgr.addBlock(blocks[10]))
+	blkfileMgr.moveToNextFile()
+	require.True(t, blkfileMgr.currentFileCompressed)
+	blkfileMgrWrapper.addBlocks(blocks[11:20])
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// after disabling the compression, the current file continues in the compressed format
+	env = newTestEnv(t, NewConf(path, 0))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr = blkfileMgrWrapper.blockfileMgr
+	require.True(t, blkfileMgr.currentFileCompressed)
+	blkfileMgrWrapper.addBlocks(blocks[20:])
+
+	compressed, err := isCompressedBlockfileAtPath(deriveBlockfilePath(blkfileMgr.rootDir, 0))
+	require.NoError(t, err)
+	require.False(t, compressed)
+	compressed, err = isCompressedBlockfileAtPath(deriveBlockfilePath(blkfileMgr.rootDir, 1))
+	require.NoError(t, err)
+	require.True(t, compressed)
+	verifyBlocksInBlockfileMgr(t, blkfileMgr, blocks)
+}
+
+func TestCompressedBlockfileIndexSync(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 20)
+	env := newTestEnv(t, NewConfWithCompression(path, 0, true))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	blkfileMgrWrapper.addBlocks(blocks[:10])
+	blkfileMgr.moveToNextFile()
+	blkfileMgrWrapper.addBlocks(blocks[10:])
+
+	// drop the index savepoint so that the index is built again from the block files
+	require.NoError(t, blkfileMgr.db.Delete(indexSavePointKey, true))
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	env = newTestEnv(t, NewConfWithCompression(path, 0, true))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	verifyBlocksInBlockfileMgr(t, blkfileMgrWrapper.blockfileMgr, blocks)
+}
+
+func TestCompressedBlockfileCrashDuringHeaderWrite(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	env := newTestEnv(t, NewConfWithCompression(path, 0, true))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks[:5])
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	blkfileMgr.moveToNextFile()
+	rootDir := blkfileMgr.rootDir
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// simulate a crash during writing the header of the next file
+	require.NoError(t, ioutil.WriteFile(deriveBlockfilePath(rootDir, 1), compressedBlockfileHeader[:3], 0o600))
+
+	env = newTestEnv(t, NewConfWithCompression(path, 0, true))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgrWrapper.addBlocks(blocks[5:])
+	verifyBlocksInBlockfileMgr(t, blkfileMgrWrapper.blockfileMgr, blocks)
+
+	compressed, err := isCompressedBlockfileAtPath(deriveBlockfilePath(rootDir, 1))
+	require.NoError(t, err)
+	require.True(t, compressed)
+}
+
+func TestCompressBlockfiles(t *testing.T) {
+	path := testPath()
+	allBlocks := testutil.ConstructTestBlocks(t, 32)
+	blocks := allBlocks[:30]
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	for i, b := range blocks {
+		require.NoError(t, blkfileMgr.addBlock(b))
+		if i != 0 && i%10 == 0 {
+			blkfileMgr.moveToNextFile()
+		}
+	}
+	rootDir := blkfileMgr.rootDir
+	uncompressedFileSize := blkfileMgr.blockfilesInfo.latestFileSize
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	indexConfig := &IndexConfig{AttrsToIndex: attrsToIndex}
+	require.NoError(t, CompressBlockfiles(path, indexConfig))
+	// invoking again leaves the already compressed files untouched
+	require.NoError(t, CompressBlockfiles(path, indexConfig))
+
+	for fileNum := 0; fileNum <= 2; fileNum++ {
+		compressed, err := isCompressedBlockfileAtPath(deriveBlockfilePath(rootDir, fileNum))
+		require.NoError(t, err)
+		require.True(t, compressed)
+	}
+	fileInfo, err := os.Stat(deriveBlockfilePath(rootDir, 2))
+	require.NoError(t, err)
+	require.Less(t, fileInfo.Size(), int64(uncompressedFileSize))
+
+	env = newTestEnv(t, NewConf(path, 0))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr = blkfileMgrWrapper.blockfileMgr
+	require.True(t, blkfileMgr.currentFileCompressed)
+	require.Equal(t, int(fileInfo.Size()), blkfileMgr.blockfilesInfo.latestFileSize)
+	verifyBlocksInBlockfileMgr(t, blkfileMgr, blocks)
+
+	// the blocks added after the migration continue in the compressed format
+	blkfileMgrWrapper.addBlocks(allBlocks[30:])
+	verifyBlocksInBlockfileMgr(t, blkfileMgr, allBlocks)
+}
+
+func TestCompressBlockfilesNoLedgers(t *testing.T) {
+	path := testPath()
+	defer os.RemoveAll(path)
+	require.NoError(t, CompressBlockfiles(path, &IndexConfig{AttrsToIndex: attrsToIndex}))
+}
+
+func TestPruneCompressedBlockfiles(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 30)
+	env := newTestEnv(t, NewConfWithCompression(path, 0, true))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	for i, b := range blocks {
+		require.NoError(t, blkfileMgr.addBlock(b))
+		if i != 0 && i%10 == 0 {
+			blkfileMgr.moveToNextFile()
+		}
+	}
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	require.NoError(t, Prune(path, "testLedger", 15, &IndexConfig{AttrsToIndex: attrsToIndex}))
+
+	env = newTestEnv(t, NewConfWithCompression(path, 0, true))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr = blkfileMgrWrapper.blockfileMgr
+	compressed, err := isCompressedBlockfileAtPath(deriveBlockfilePath(blkfileMgr.rootDir, 0))
+	require.NoError(t, err)
+	require.True(t, compressed)
+	verifyBlocksInBlockfileMgr(t, blkfileMgr, blocks[16:])
+}
+
+func TestFileLocPointerInCompressedBlock(t *testing.T) {
+	flp := &fileLocPointer{
+		fileSuffixNum:         2,
+		locPointer:            locPointer{offset: 150, bytesLength: 40},
+		compressedBlockOffset: 1024,
+	}
+	b, err := flp.marshal()
+	require.NoError(t, err)
+	unmarshalledFLP := &fileLocPointer{}
+	require.NoError(t, unmarshalledFLP.unmarshal(b))
+	require.Equal(t, flp, unmarshalledFLP)
+	require.True(t, unmarshalledFLP.isInCompressedBlock())
+
+	// the encoding of a location in an uncompressed file is same as that of the previous versions
+	flp = &fileLocPointer{
+		fileSuffixNum: 2,
+		locPointer:    locPointer{offset: 100, bytesLength: 40},
+	}
+	b, err = flp.marshal()
+	require.NoError(t, err)
+	require.Len(t, b, 3)
+	unmarshalledFLP = &fileLocPointer{}
+	require.NoError(t, unmarshalledFLP.unmarshal(b))
+	require.Equal(t, flp, unmarshalledFLP)
+	require.False(t, unmarshalledFLP.isInCompressedBlock())
+}
+
+func verifyBlocksInBlockfileMgr(t *testing.T, blkfileMgr *blockfileMgr, blocks []*common.Block) {
+	for _, b := range blocks {
+		blockNum := b.Header.Number
+		retrievedBlock, err := blkfileMgr.retrieveBlockByNumber(blockNum)
+		require.NoError(t, err)
+		require.Equal(t, b, retrievedBlock)
+
+		retrievedBlock, err = blkfileMgr.retrieveBlockByHash(protoutil.BlockHeaderHash(b.Header))
+		require.NoError(t, err)
+		require.Equal(t, b, retrievedBlock)
+
+		for tranNum, envBytes := range b.Data.Data {
+			txID, err := protoutil.GetOrComputeTxIDFromEnvelope(envBytes)
+			require.NoError(t, err)
+			expectedEnvelope, err := protoutil.GetEnvelopeFromBlock(envBytes)
+			require.NoError(t, err)
+
+			envelope, err := blkfileMgr.retrieveTransactionByID(txID)
+			require.NoError(t, err)
+			require.Equal(t, expectedEnvelope, envelope)
+
+			envelope, err = blkfileMgr.retrieveTransactionByBlockNumTranNum(blockNum, uint64(tranNum))
+			require.NoError(t, err)
+			require.Equal(t, expectedEnvelope, envelope)
+
+			retrievedBlock, err = blkfileMgr.retrieveBlockByTxID(txID)
+			require.NoError(t, err)
+			require.Equal(t, b, retrievedBlock)
+		}
+	}
+
+	itr, err := blkfileMgr.retrieveBlocks(blocks[0].Header.Number)
+	require.NoError(t, err)
+	defer itr.Close()
+	for _, b := range blocks {
+		retrievedBlock, err := itr.Next()
+		require.NoError(t, err)
+		require.Equal(t, b, retrievedBlock)
+	}
+}
@@ -18,8 +18,9 @@ const (
 
 // Conf encapsulates all the configurations for `BlockStore`
 type Conf struct {
-	blockStorageDir  string
-	maxBlockfileSize int
+	blockStorageDir    string
+	maxBlockfileSize   int
+	compressBlockfiles bool
 }
 
 // NewConf constructs new `Conf`.
@@ -28,7 +29,16 @@ func NewConf(blockStorageDir string, maxBlockfileSize int) *Conf {
 	if maxBlockfileSize <= 0 {
 		maxBlockfileSize = defaultMaxBlockfileSize
 	}
-	return &Conf{blockStorageDir, maxBlockfileSize}
+	return &Conf{blockStorageDir: blockStorageDir, maxBlockfileSize: maxBlockfileSize}
+}
+
+// NewConfWithCompression constructs new `Conf` and, if compressBlockfiles is true, enables compression
+// of the blocks for the block files that are created hereafter. The existing block files remain in their
+// original format and both formats can be read in the same ledger.
+func NewConfWithCompression(blockStorageDir string, maxBlockfileSize int, compressBlockfiles bool) *Conf {
+	conf := NewConf(blockStorageDir, maxBlockfileSize)
+	conf.compressBlockfiles = compressBlockfiles
+	return conf
 }
 
 func (conf *Conf) getIndexDir() string {
@@ -0,0 +1,488 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	blocksPrunedMarkerFile = "blocksPruned.info"
+	pruneTempBlockfile     = "__pruneTempBlockfile"
+	pruneInfoFile          = "pruneInProgress.info"
+	pruneInfoTempFile      = "pruneInProgressTemp.info"
+)
+
+// The phases of the prune operation. The phase that is about to run is recorded in the prune info file, so that
+// an interrupted prune operation is resumed from the phase that did not complete. Each phase can be run again
+// from the beginning if it was interrupted midway.
+const (
+	pruneIndexPhase uint32 = iota
+	pruneTrimPhase
+	pruneRemovePhase
+	pruneRenumberPhase
+	pruneRecordPhase
+	pruneDonePhase
+)
+
+type pruneMgr struct {
+	ledgerDir     string
+	indexStore    *blockIndex
+	info          *PruneInfo
+	reusableBatch *leveldbhelper.UpdateBatch
+}
+
+// Prune removes from the block store all the blocks up to and including the given block number, which is
+// expected to be the last block of a snapshot of the ledger. The block files are rewritten such that the
+// first block present in the block files is `lastBlockInSnapshot+1` and the index entries for the pruned
+// blocks are removed, except for the TxIDs, which are retained (without locations) for the duplicate
+// TxID detection. After pruning, the block store is in the same state as that of a block store bootstrapped
+// from a snapshot at the given block number. This function is expected to be invoked when the peer is offline.
+//
+// Before the block store is modified, the intent to prune is recorded in the ledger directory. If the prune
+// operation is interrupted, it is completed when the block store is opened next time.
+func Prune(blockStorageDir, ledgerID string, lastBlockInSnapshot uint64, indexConfig *IndexConfig) error {
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	dbProvider, err := leveldbhelper.NewProvider(
+		&leveldbhelper.Conf{
+			DBPath:         conf.getIndexDir(),
+			ExpectedFormat: dataFormatVersion(indexConfig),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer dbProvider.Close()
+
+	p, err := newPruneMgr(conf.getLedgerBlockDir(ledgerID), indexConfig, dbProvider.GetDBHandle(ledgerID))
+	if err != nil {
+		return err
+	}
+	if err := p.recordPruneInfo(lastBlockInSnapshot); err != nil {
+		return err
+	}
+	return p.prune()
+}
+
+// resumePrune completes the prune operation that was interrupted, if any, for the ledger in the given directory
+func resumePrune(ledgerDir string, indexConfig *IndexConfig, indexDB *leveldbhelper.DBHandle) error {
+	info, err := loadPruneInfo(ledgerDir)
+	if err != nil || info == nil {
+		return err
+	}
+	logger.Warningf("Resuming the interrupted prune operation up to block number [%d]", info.BootstrappingSnapshotInfo.LastBlockNum)
+	p, err := newPruneMgr(ledgerDir, indexConfig, indexDB)
+	if err != nil {
+		return err
+	}
+	p.info = info
+	return p.prune()
+}
+
+func newPruneMgr(ledgerDir string, indexConfig *IndexConfig, indexDB *leveldbhelper.DBHandle) (*pruneMgr, error) {
+	indexStore, err := newBlockIndex(indexConfig, indexDB)
+	if err != nil {
+		return nil, err
+	}
+	return &pruneMgr{
+		ledgerDir:     ledgerDir,
+		indexStore:    indexStore,
+		reusableBatch: indexDB.NewUpdateBatch(),
+	}, nil
+}
+
+// recordPruneInfo collects, before the block store is modified, all the information that is needed to complete
+// the prune operation and persists it in the prune info file
+func (p *pruneMgr) recordPruneInfo(lastBlockToPrune uint64) error {
+	lastPrunedFileNum, err := binarySearchFileNumForBlock(p.ledgerDir, lastBlockToPrune)
+	if err != nil {
+		return err
+	}
+	lastPrunedBlockInfo, _, err := locateBlock(p.ledgerDir, lastPrunedFileNum, lastBlockToPrune)
+	if err != nil {
+		return err
+	}
+	firstRemainingFileNum, err := binarySearchFileNumForBlock(p.ledgerDir, lastBlockToPrune+1)
+	if err != nil {
+		return err
+	}
+	lastFileNum, err := retrieveLastFileSuffix(p.ledgerDir)
+	if err != nil {
+		return err
+	}
+
+	p.info = &PruneInfo{
+		BootstrappingSnapshotInfo: &BootstrappingSnapshotInfo{
+			LastBlockNum:      lastBlockToPrune,
+			LastBlockHash:     protoutil.BlockHeaderHash(lastPrunedBlockInfo.blockHeader),
+			PreviousBlockHash: lastPrunedBlockInfo.blockHeader.PreviousHash,
+		},
+		FirstRemainingFileNum: int32(firstRemainingFileNum),
+		LastFileNum:           int32(lastFileNum),
+		Phase:                 pruneIndexPhase,
+	}
+	return p.savePruneInfo()
+}
+
+func (p *pruneMgr) savePruneInfo() error {
+	infoBytes, err := proto.Marshal(p.info)
+	if err != nil {
+		return err
+	}
+	// a temp file may have been left behind by an interrupted prune operation
+	if err := removeIfExists(filepath.Join(p.ledgerDir, pruneInfoTempFile)); err != nil {
+		return err
+	}
+	if err := fileutil.CreateAndSyncFileAtomically(p.ledgerDir, pruneInfoTempFile, pruneInfoFile, infoBytes, 0o644); err != nil {
+		return err
+	}
+	return fileutil.SyncDir(p.ledgerDir)
+}
+
+func loadPruneInfo(ledgerDir string) (*PruneInfo, error) {
+	infoBytes, err := ioutil.ReadFile(filepath.Join(ledgerDir, pruneInfoFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "error while reading the prune info file")
+	}
+	info := &PruneInfo{}
+	if err := proto.Unmarshal(infoBytes, info); err != nil {
+		return nil, errors.Wrap(err, "error while unmarshalling the prune info")
+	}
+	return info, nil
+}
+
+// prune runs the phases of the prune operation, starting from the phase recorded in the prune info,
+// and removes the prune info file once all of them are complete
+func (p *pruneMgr) prune() error {
+	for p.info.Phase < pruneDonePhase {
+		if err := p.runPhase(); err != nil {
+			return err
+		}
+		p.info.Phase++
+		if err := p.savePruneInfo(); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(filepath.Join(p.ledgerDir, pruneInfoFile)); err != nil {
+		return errors.Wrap(err, "error while removing the prune info file")
+	}
+	return fileutil.SyncDir(p.ledgerDir)
+}
+
+func (p *pruneMgr) runPhase() error {
+	lastBlockToPrune := p.info.BootstrappingSnapshotInfo.LastBlockNum
+	switch p.info.Phase {
+	case pruneIndexPhase:
+		logger.Infof("Pruning block index up to block number [%d]", lastBlockToPrune)
+		return p.pruneBlockIndex()
+	case pruneTrimPhase:
+		logger.Infof("Trimming block files up to block number [%d]", lastBlockToPrune)
+		return p.trimBlockFile()
+	case pruneRemovePhase:
+		logger.Infof("Removing block files up to block number [%d]", lastBlockToPrune)
+		return p.removeBlockFiles()
+	case pruneRenumberPhase:
+		logger.Infof("Renumbering the remaining block files")
+		return p.renumberBlockFiles()
+	case pruneRecordPhase:
+		logger.Infof("Recording the bootstrapping snapshot info at block number [%d]", lastBlockToPrune)
+		return p.recordBootstrappingSnapshotInfo()
+	default:
+		return errors.Errorf("unknown prune phase [%d]", p.info.Phase)
+	}
+}
+
+// pruneBlockIndex walks through the blocks to be pruned and removes their index entries. The entries for the
+// TxIDs are retained with an empty value, in the same way as the TxIDs imported from a snapshot. Finally, the index
+// savepoint is set to the last pruned block so that the index for the remaining blocks is rebuilt from the rewritten
+// block files when the block store is opened next time.
+func (p *pruneMgr) pruneBlockIndex() error {
+	lastBlockToPrune := p.info.BootstrappingSnapshotInfo.LastBlockNum
+	firstBlockInFiles, err := retrieveFirstBlockNumFromFile(p.ledgerDir, 0)
+	if err != nil {
+		return err
+	}
+	stream, err := newBlockStream(p.ledgerDir, 0, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+
+	// similar to rollback, we process the index associated with only 10 blocks
+	// at a time to avoid overuse of memory occupied by the leveldb batch.
+	batchLimit := uint64(10)
+	p.reusableBatch.Reset()
+	for blockNum := firstBlockInFiles; blockNum <= lastBlockToPrune; blockNum++ {
+		blockBytes, _, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			return errors.Errorf("block number [%d] not found in the block files", blockNum)
+		}
+		blockInfo, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		addIndexEntriesToBePruned(p.reusableBatch, blockInfo, p.indexStore)
+
+		if (blockNum+1)%batchLimit == 0 {
+			logger.Infof("Pruned index associated with blocks up to block number [%d]", blockNum)
+			if err := p.indexStore.db.WriteBatch(p.reusableBatch, true); err != nil {
+				return err
+			}
+			p.reusableBatch.Reset()
+		}
+	}
+	p.reusableBatch.Put(indexSavePointKey, encodeBlockNum(lastBlockToPrune))
+	p.reusableBatch.Delete(blkMgrInfoKey)
+	return p.indexStore.db.WriteBatch(p.reusableBatch, true)
+}
+
+func addIndexEntriesToBePruned(batch *leveldbhelper.UpdateBatch, blockInfo *serializedBlockInfo, indexStore *blockIndex) {
+	if indexStore.isAttributeIndexed(IndexableAttrBlockHash) {
+		batch.Delete(constructBlockHashKey(protoutil.BlockHeaderHash(blockInfo.blockHeader)))
+	}
+
+	if indexStore.isAttributeIndexed(IndexableAttrBlockNum) {
+		batch.Delete(constructBlockNumKey(blockInfo.blockHeader.Number))
+	}
+
+	if indexStore.isAttributeIndexed(IndexableAttrBlockNumTranNum) {
+		for txIndex := range blockInfo.txOffsets {
+			batch.Delete(constructBlockNumTranNumKey(blockInfo.blockHeader.Number, uint64(txIndex)))
+		}
+	}
+
+	if indexStore.isAttributeIndexed(IndexableAttrTxID) {
+		for i, txOffset := range blockInfo.txOffsets {
+			batch.Put(constructTxIDKey(txOffset.txID, blockInfo.blockHeader.Number, uint64(i)), []byte{})
+		}
+	}
+}
+
+// trimBlockFile trims the pruned blocks from the beginning of the block file that contains the first remaining block.
+// Once trimmed, the first remaining block is found at the beginning of the file and the file is not trimmed again.
+func (p *pruneMgr) trimBlockFile() error {
+	firstRemainingBlock := p.info.BootstrappingSnapshotInfo.LastBlockNum + 1
+	firstRemainingFileNum := int(p.info.FirstRemainingFileNum)
+	_, startOffset, err := locateBlock(p.ledgerDir, firstRemainingFileNum, firstRemainingBlock)
+	if err != nil {
+		return err
+	}
+	compressed, err := isCompressedBlockfileAtPath(deriveBlockfilePath(p.ledgerDir, firstRemainingFileNum))
+	if err != nil {
+		return err
+	}
+	// in a compressed block file, the first block starts after the header
+	firstBlockOffset := int64(0)
+	if compressed {
+		firstBlockOffset = int64(len(compressedBlockfileHeader))
+	}
+	if startOffset > firstBlockOffset {
+		logger.Infof("Trimming block file [%d] to the start boundary of block number [%d]", firstRemainingFileNum, firstRemainingBlock)
+		if err := trimBlockfileHead(p.ledgerDir, firstRemainingFileNum, startOffset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeBlockFiles removes the block files that contain only the pruned blocks, skipping the ones already removed
+func (p *pruneMgr) removeBlockFiles() error {
+	firstRemainingFileNum := int(p.info.FirstRemainingFileNum)
+	logger.Infof("Removing all block files with suffixNum in the range [0] to [%d]", firstRemainingFileNum-1)
+	for n := 0; n < firstRemainingFileNum; n++ {
+		if err := removeIfExists(deriveBlockfilePath(p.ledgerDir, n)); err != nil {
+			return err
+		}
+	}
+	return fileutil.SyncDir(p.ledgerDir)
+}
+
+// renumberBlockFiles renumbers the remaining block files so that they begin with suffix 0. The files are renamed
+// in increasing order, so a file that still exists under its original number while its new number is free has not
+// been renamed yet.
+func (p *pruneMgr) renumberBlockFiles() error {
+	firstRemainingFileNum := int(p.info.FirstRemainingFileNum)
+	lastFileNum := int(p.info.LastFileNum)
+	logger.Infof("Renumbering block files with suffixNum in the range [%d] to [%d]", firstRemainingFileNum, lastFileNum)
+	for n := firstRemainingFileNum; n <= lastFileNum && firstRemainingFileNum > 0; n++ {
+		from := deriveBlockfilePath(p.ledgerDir, n)
+		to := deriveBlockfilePath(p.ledgerDir, n-firstRemainingFileNum)
+		fromExists, err := pathExists(from)
+		if err != nil {
+			return err
+		}
+		toExists, err := pathExists(to)
+		if err != nil {
+			return err
+		}
+		if !fromExists || toExists {
+			continue
+		}
+		if err := os.Rename(from, to); err != nil {
+			return errors.Wrapf(err, "error renaming the block file [%s] to [%s]", from, to)
+		}
+	}
+	return fileutil.SyncDir(p.ledgerDir)
+}
+
+func (p *pruneMgr) recordBootstrappingSnapshotInfo() error {
+	bsiBytes, err := proto.Marshal(p.info.BootstrappingSnapshotInfo)
+	if err != nil {
+		return err
+	}
+	if err := removeIfExists(filepath.Join(p.ledgerDir, bootstrappingSnapshotInfoTempFile)); err != nil {
+		return err
+	}
+	if err := fileutil.CreateAndSyncFileAtomically(
+		p.ledgerDir,
+		bootstrappingSnapshotInfoTempFile,
+		bootstrappingSnapshotInfoFile,
+		bsiBytes,
+		0o644,
+	); err != nil {
+		return err
+	}
+	markerPath := filepath.Join(p.ledgerDir, blocksPrunedMarkerFile)
+	markerExists, err := pathExists(markerPath)
+	if err != nil {
+		return err
+	}
+	if !markerExists {
+		if err := fileutil.CreateAndSyncFile(markerPath, []byte{}, 0o644); err != nil {
+			return err
+		}
+	}
+	return fileutil.SyncDir(p.ledgerDir)
+}
+
+func removeIfExists(filePath string) error {
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error removing the file [%s]", filePath)
+	}
+	return nil
+}
+
+// locateBlock returns the info and the start offset of the given block in the given block file
+func locateBlock(ledgerDir string, blkFileNum int, blockNum uint64) (*serializedBlockInfo, int64, error) {
+	stream, err := newBlockfileStream(ledgerDir, blkFileNum, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer stream.close()
+	for {
+		blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return nil, 0, err
+		}
+		if blockBytes == nil {
+			return nil, 0, errors.Errorf("block number [%d] not found in the block file [%d]", blockNum, blkFileNum)
+		}
+		blockInfo, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return nil, 0, err
+		}
+		if blockInfo.blockHeader.Number == blockNum {
+			return blockInfo, placementInfo.blockStartOffset, nil
+		}
+	}
+}
+
+// trimBlockfileHead rewrites the given block file such that it contains only the bytes beyond the given offset.
+// The header of a compressed block file is retained
+func trimBlockfileHead(ledgerDir string, blkFileNum int, offset int64) error {
+	filePath := deriveBlockfilePath(ledgerDir, blkFileNum)
+	tempFilePath := filepath.Join(ledgerDir, pruneTempBlockfile)
+
+	src, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "error opening the block file [%s]", filePath)
+	}
+	defer src.Close()
+	if _, err := src.Seek(offset, io.SeekStart); err != nil {
+		return errors.Wrapf(err, "error seeking the block file [%s] to offset [%d]", filePath, offset)
+	}
+
+	dest, err := os.OpenFile(tempFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "error creating the file [%s]", tempFilePath)
+	}
+	defer dest.Close()
+	compressed, err := isCompressedBlockfile(src)
+	if err != nil {
+		return err
+	}
+	if compressed {
+		if _, err := dest.Write(compressedBlockfileHeader); err != nil {
+			return errors.Wrapf(err, "error writing the header to the file [%s]", tempFilePath)
+		}
+	}
+	if _, err := io.Copy(dest, src); err != nil {
+		return errors.Wrapf(err, "error copying the block file [%s] to [%s]", filePath, tempFilePath)
+	}
+	if err := dest.Sync(); err != nil {
+		return errors.Wrapf(err, "error while synching the file [%s]", tempFilePath)
+	}
+	if err := os.Rename(tempFilePath, filePath); err != nil {
+		return errors.Wrapf(err, "error renaming the file [%s] to [%s]", tempFilePath, filePath)
+	}
+	return nil
+}
+
+// ValidatePruneParams performs necessary validation on the input given for the prune operation.
+func ValidatePruneParams(blockStorageDir, ledgerID string, lastBlockInSnapshot uint64) error {
+	logger.Infof("Validating the prune parameters: ledgerID [%s], block number [%d]",
+		ledgerID, lastBlockInSnapshot)
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	ledgerDir := conf.getLedgerBlockDir(ledgerID)
+	if err := validateLedgerID(ledgerDir, ledgerID); err != nil {
+		return err
+	}
+	info, err := loadPruneInfo(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if info != nil {
+		return errors.Errorf("an interrupted prune operation up to block number [%d] is pending, it is completed when the ledger is opened",
+			info.BootstrappingSnapshotInfo.LastBlockNum)
+	}
+	bsi, err := loadBootstrappingSnapshotInfo(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if bsi != nil && lastBlockInSnapshot <= bsi.LastBlockNum {
+		return errors.Errorf("block number [%d] should be greater than the last block number [%d] already pruned or in the bootstrapping snapshot",
+			lastBlockInSnapshot, bsi.LastBlockNum)
+	}
+	blkfilesInfo, err := constructBlockfilesInfo(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if blkfilesInfo.noBlockFiles || blkfilesInfo.lastPersistedBlock <= lastBlockInSnapshot {
+		return errors.Errorf("block number [%d] should be less than the biggest block number [%d]",
+			lastBlockInSnapshot, blkfilesInfo.lastPersistedBlock)
+	}
+	return nil
+}
+
+func blocksPruned(rootDir string) (bool, error) {
+	return pathExists(filepath.Join(rootDir, blocksPrunedMarkerFile))
+}
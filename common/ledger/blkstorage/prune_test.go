@@ -0,0 +1,262 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blkstorage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	testcases := []struct {
+		name                string
+		lastBlockInSnapshot uint64
+	}{
+		{name: "snapshot-in-middle-of-file", lastBlockInSnapshot: 25},
+		{name: "snapshot-at-end-of-file", lastBlockInSnapshot: 30},
+		{name: "snapshot-in-first-file", lastBlockInSnapshot: 4},
+		{name: "snapshot-in-last-file", lastBlockInSnapshot: 45},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			testPrune(t, tc.lastBlockInSnapshot)
+		})
+	}
+}
+
+func testPrune(t *testing.T, lastBlockInSnapshot uint64) {
+	path := testPath()
+	defer os.RemoveAll(path)
+	blocks := createBlockfilesToPrune(t, path)
+
+	require.NoError(t, ValidatePruneParams(path, "testLedger", lastBlockInSnapshot))
+	indexConfig := &IndexConfig{AttrsToIndex: attrsToIndex}
+	require.NoError(t, Prune(path, "testLedger", lastBlockInSnapshot, indexConfig))
+
+	verifyPrunedBlockStore(t, path, blocks, lastBlockInSnapshot)
+}
+
+// createBlockfilesToPrune adds 50 blocks to the block store of the ledger "testLedger",
+// in the ranges [(0, 10):file0, (11,20):file1, (21,30):file2, (31, 40):file3, (41,49):file4]
+func createBlockfilesToPrune(t *testing.T, path string) []*common.Block {
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	blocksPerFile := 50 / 5
+	env := newTestEnv(t, NewConf(path, 0))
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	for i, b := range blocks {
+		require.NoError(t, blkfileMgr.addBlock(b))
+		if i != 0 && i%blocksPerFile == 0 {
+			blkfileMgr.moveToNextFile()
+		}
+	}
+	env.provider.Close()
+	blkfileMgrWrapper.close()
+	return blocks
+}
+
+func verifyPrunedBlockStore(t *testing.T, path string, blocks []*common.Block, lastBlockInSnapshot uint64) {
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.provider.Close()
+	store, err := env.provider.Open("testLedger")
+	require.NoError(t, err)
+	defer store.Shutdown()
+
+	exists, err := pathExists(filepath.Join(store.fileMgr.rootDir, pruneInfoFile))
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	firstBlockNumInFiles, err := retrieveFirstBlockNumFromFile(store.fileMgr.rootDir, 0)
+	require.NoError(t, err)
+	require.Equal(t, lastBlockInSnapshot+1, firstBlockNumInFiles)
+
+	bcInfo, err := store.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t,
+		&common.BlockchainInfo{
+			Height:            50,
+			CurrentBlockHash:  protoutil.BlockHeaderHash(blocks[49].Header),
+			PreviousBlockHash: protoutil.BlockHeaderHash(blocks[48].Header),
+			BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{
+				LastBlockInSnapshot: lastBlockInSnapshot,
+			},
+		},
+		bcInfo,
+	)
+
+	for _, b := range blocks[:lastBlockInSnapshot+1] {
+		blockNum := b.Header.Number
+		expectedErrStr := fmt.Sprintf(
+			"cannot serve block [%d]. The block has been pruned from the ledger. First available block = [%d]",
+			blockNum, lastBlockInSnapshot+1,
+		)
+		_, err := store.RetrieveBlockByNumber(blockNum)
+		require.EqualError(t, err, expectedErrStr)
+
+		_, err = store.RetrieveBlocks(blockNum)
+		require.EqualError(t, err, expectedErrStr)
+
+		blockHash := protoutil.BlockHeaderHash(b.Header)
+		_, err = store.RetrieveBlockByHash(blockHash)
+		require.EqualError(t, err, fmt.Sprintf("no such block hash [%x] in index", blockHash))
+
+		txID, err := protoutil.GetOrComputeTxIDFromEnvelope(b.Data.Data[0])
+		require.NoError(t, err)
+		_, err = store.RetrieveTxByID(txID)
+		require.EqualError(t, err, fmt.Sprintf(
+			"details for the TXID [%s] not available. The block containing the transaction has been pruned from the ledger. First available block = [%d]",
+			txID, lastBlockInSnapshot+1,
+		))
+		exists, err := store.TxIDExists(txID)
+		require.NoError(t, err)
+		require.True(t, exists)
+	}
+
+	for _, b := range blocks[lastBlockInSnapshot+1:] {
+		retrievedBlock, err := store.RetrieveBlockByNumber(b.Header.Number)
+		require.NoError(t, err)
+		require.Equal(t, b, retrievedBlock)
+
+		retrievedBlock, err = store.RetrieveBlockByHash(protoutil.BlockHeaderHash(b.Header))
+		require.NoError(t, err)
+		require.Equal(t, b, retrievedBlock)
+
+		txID, err := protoutil.GetOrComputeTxIDFromEnvelope(b.Data.Data[0])
+		require.NoError(t, err)
+		retrievedBlock, err = store.RetrieveBlockByTxID(txID)
+		require.NoError(t, err)
+		require.Equal(t, b, retrievedBlock)
+	}
+
+	// blocks can be added after pruning
+	nextBlock := testutil.ConstructBlock(t, 50, protoutil.BlockHeaderHash(blocks[49].Header), [][]byte{{1}}, true)
+	require.NoError(t, store.AddBlock(nextBlock))
+	retrievedBlock, err := store.RetrieveBlockByNumber(50)
+	require.NoError(t, err)
+	require.Equal(t, nextBlock, retrievedBlock)
+
+	// the prune operation is not allowed again on the same or a lower block number
+	err = ValidatePruneParams(path, "testLedger", lastBlockInSnapshot)
+	require.EqualError(t, err, fmt.Sprintf(
+		"block number [%d] should be greater than the last block number [%d] already pruned or in the bootstrapping snapshot",
+		lastBlockInSnapshot, lastBlockInSnapshot,
+	))
+}
+
+func TestPruneResume(t *testing.T) {
+	// Scenario: the prune operation is interrupted after running a phase but before recording its
+	// completion, so that the phase is run again when the block store is opened
+	for phase := pruneIndexPhase; phase < pruneDonePhase; phase++ {
+		for _, lastBlockInSnapshot := range []uint64{4, 25, 45} {
+			t.Run(fmt.Sprintf("phase-%d-block-%d", phase, lastBlockInSnapshot), func(t *testing.T) {
+				path := testPath()
+				defer os.RemoveAll(path)
+				blocks := createBlockfilesToPrune(t, path)
+
+				p, dbProvider := newTestPruneMgr(t, path)
+				require.NoError(t, p.recordPruneInfo(lastBlockInSnapshot))
+				for p.info.Phase < phase {
+					require.NoError(t, p.runPhase())
+					p.info.Phase++
+					require.NoError(t, p.savePruneInfo())
+				}
+				require.NoError(t, p.runPhase())
+				dbProvider.Close()
+
+				err := ValidatePruneParams(path, "testLedger", lastBlockInSnapshot+1)
+				require.EqualError(t, err, fmt.Sprintf(
+					"an interrupted prune operation up to block number [%d] is pending, it is completed when the ledger is opened",
+					lastBlockInSnapshot,
+				))
+				verifyPrunedBlockStore(t, path, blocks, lastBlockInSnapshot)
+			})
+		}
+	}
+
+	t.Run("partially-renumbered", func(t *testing.T) {
+		path := testPath()
+		defer os.RemoveAll(path)
+		blocks := createBlockfilesToPrune(t, path)
+
+		p, dbProvider := newTestPruneMgr(t, path)
+		require.NoError(t, p.recordPruneInfo(25))
+		for p.info.Phase < pruneRenumberPhase {
+			require.NoError(t, p.runPhase())
+			p.info.Phase++
+			require.NoError(t, p.savePruneInfo())
+		}
+		// only the first of the remaining files is renumbered
+		require.NoError(t, os.Rename(deriveBlockfilePath(p.ledgerDir, 2), deriveBlockfilePath(p.ledgerDir, 0)))
+		dbProvider.Close()
+
+		verifyPrunedBlockStore(t, path, blocks, 25)
+	})
+}
+
+func newTestPruneMgr(t *testing.T, path string) (*pruneMgr, *leveldbhelper.Provider) {
+	indexConfig := &IndexConfig{AttrsToIndex: attrsToIndex}
+	conf := &Conf{blockStorageDir: path}
+	dbProvider, err := leveldbhelper.NewProvider(
+		&leveldbhelper.Conf{
+			DBPath:         conf.getIndexDir(),
+			ExpectedFormat: dataFormatVersion(indexConfig),
+		},
+	)
+	require.NoError(t, err)
+	p, err := newPruneMgr(conf.getLedgerBlockDir("testLedger"), indexConfig, dbProvider.GetDBHandle("testLedger"))
+	require.NoError(t, err)
+	return p, dbProvider
+}
+
+func TestValidatePruneParams(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.close()
+
+	err := ValidatePruneParams(path, "nonExistingLedger", 5)
+	require.EqualError(t, err, "ledgerID [nonExistingLedger] does not exist")
+
+	err = ValidatePruneParams(path, "testLedger", 9)
+	require.EqualError(t, err, "block number [9] should be less than the biggest block number [9]")
+
+	require.NoError(t, ValidatePruneParams(path, "testLedger", 8))
+}
+
+func TestTrimBlockfileHead(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	ledgerDir := blkfileMgrWrapper.blockfileMgr.rootDir
+	blkfileMgrWrapper.close()
+
+	_, offset, err := locateBlock(ledgerDir, 0, 3)
+	require.NoError(t, err)
+	require.NoError(t, trimBlockfileHead(ledgerDir, 0, offset))
+
+	n, err := retrieveFirstBlockNumFromFile(ledgerDir, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), n)
+
+	_, _, err = locateBlock(ledgerDir, 0, 1)
+	require.EqualError(t, err, "block number [1] not found in the block file [0]")
+}
@@ -130,30 +130,99 @@ func (m *BootstrappingSnapshotInfo) GetPreviousBlockHash() []byte {
 	return nil
 }
 
+type PruneInfo struct {
+	BootstrappingSnapshotInfo *BootstrappingSnapshotInfo `protobuf:"bytes,1,opt,name=bootstrappingSnapshotInfo,proto3" json:"bootstrappingSnapshotInfo,omitempty"`
+	FirstRemainingFileNum     int32                      `protobuf:"varint,2,opt,name=firstRemainingFileNum,proto3" json:"firstRemainingFileNum,omitempty"`
+	LastFileNum               int32                      `protobuf:"varint,3,opt,name=lastFileNum,proto3" json:"lastFileNum,omitempty"`
+	Phase                     uint32                     `protobuf:"varint,4,opt,name=phase,proto3" json:"phase,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{}                   `json:"-"`
+	XXX_unrecognized          []byte                     `json:"-"`
+	XXX_sizecache             int32                      `json:"-"`
+}
+
+func (m *PruneInfo) Reset()         { *m = PruneInfo{} }
+func (m *PruneInfo) String() string { return proto.CompactTextString(m) }
+func (*PruneInfo) ProtoMessage()    {}
+func (*PruneInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{2}
+}
+
+func (m *PruneInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneInfo.Unmarshal(m, b)
+}
+func (m *PruneInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneInfo.Marshal(b, m, deterministic)
+}
+func (m *PruneInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneInfo.Merge(m, src)
+}
+func (m *PruneInfo) XXX_Size() int {
+	return xxx_messageInfo_PruneInfo.Size(m)
+}
+func (m *PruneInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneInfo proto.InternalMessageInfo
+
+func (m *PruneInfo) GetBootstrappingSnapshotInfo() *BootstrappingSnapshotInfo {
+	if m != nil {
+		return m.BootstrappingSnapshotInfo
+	}
+	return nil
+}
+
+func (m *PruneInfo) GetFirstRemainingFileNum() int32 {
+	if m != nil {
+		return m.FirstRemainingFileNum
+	}
+	return 0
+}
+
+func (m *PruneInfo) GetLastFileNum() int32 {
+	if m != nil {
+		return m.LastFileNum
+	}
+	return 0
+}
+
+func (m *PruneInfo) GetPhase() uint32 {
+	if m != nil {
+		return m.Phase
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*TxIDIndexValue)(nil), "msgs.txIDIndexValue")
 	proto.RegisterType((*BootstrappingSnapshotInfo)(nil), "msgs.bootstrappingSnapshotInfo")
+	proto.RegisterType((*PruneInfo)(nil), "msgs.pruneInfo")
 }
 
 func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
 
 var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 271 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0x41, 0x4b, 0xf3, 0x40,
-	0x10, 0x86, 0xc9, 0xd7, 0x7e, 0x1e, 0xa6, 0xa9, 0xe8, 0x9e, 0xea, 0xc9, 0x1a, 0x3c, 0xf4, 0x50,
-	0x9a, 0x83, 0x20, 0x9e, 0xab, 0x07, 0x03, 0xe2, 0x21, 0x82, 0x07, 0x2f, 0x61, 0x77, 0xb3, 0x4d,
-	0x96, 0x6c, 0x76, 0x96, 0xdd, 0x49, 0x89, 0x57, 0xff, 0x82, 0x7f, 0x58, 0x0c, 0xa1, 0xa5, 0x78,
-	0x9c, 0xe7, 0x7d, 0x60, 0x5e, 0x5e, 0x98, 0x07, 0x42, 0xcf, 0x2b, 0xb5, 0x71, 0x1e, 0x09, 0xd9,
-	0xb4, 0x0d, 0x55, 0x48, 0xbe, 0x22, 0x38, 0xa7, 0x3e, 0x7b, 0xca, 0x6c, 0xa9, 0xfa, 0x77, 0x6e,
-	0x3a, 0xc5, 0x6e, 0x20, 0x16, 0xa6, 0x29, 0x0c, 0x4a, 0x4e, 0x1a, 0xed, 0x22, 0x5a, 0x46, 0xab,
-	0x38, 0x9f, 0x09, 0xd3, 0xbc, 0x8c, 0x88, 0x5d, 0xc3, 0x8c, 0xfa, 0xa3, 0xf1, 0x6f, 0x30, 0x80,
-	0xfa, 0x83, 0xb0, 0x06, 0x46, 0x7d, 0xb1, 0xe7, 0x46, 0x97, 0x03, 0x28, 0x24, 0x96, 0x6a, 0x31,
-	0x59, 0x46, 0xab, 0xff, 0xf9, 0x05, 0xfd, 0x3e, 0x1a, 0x83, 0x47, 0x2c, 0x55, 0xf2, 0x1d, 0xc1,
-	0x95, 0x40, 0xa4, 0x40, 0x9e, 0x3b, 0xa7, 0x6d, 0xf5, 0x66, 0xb9, 0x0b, 0x35, 0x52, 0x66, 0x77,
-	0xc8, 0x12, 0x88, 0x0d, 0x0f, 0xb4, 0x35, 0x28, 0x9b, 0xd7, 0xae, 0x1d, 0xfa, 0x4c, 0xf3, 0x13,
-	0xc6, 0x6e, 0x61, 0x7e, 0xb8, 0x9f, 0x79, 0xa8, 0xc7, 0x4a, 0xa7, 0x90, 0xad, 0xe1, 0xd2, 0x79,
-	0xb5, 0xd7, 0xd8, 0x85, 0xa3, 0x39, 0x19, 0xcc, 0xbf, 0xc1, 0xf6, 0xe1, 0xe3, 0xbe, 0xd2, 0x54,
-	0x77, 0x62, 0x23, 0xb1, 0x4d, 0xeb, 0x4f, 0xa7, 0xbc, 0x51, 0x65, 0xa5, 0x7c, 0xba, 0xe3, 0xc2,
-	0x6b, 0x99, 0x4a, 0x6c, 0x5b, 0xb4, 0xe9, 0x08, 0x85, 0x69, 0xc6, 0x81, 0xc5, 0xd9, 0xb0, 0xf0,
-	0xdd, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd8, 0x44, 0x15, 0xf9, 0x72, 0x01, 0x00, 0x00,
+	// 349 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xcf, 0x4a, 0xeb, 0x40,
+	0x14, 0xc6, 0x49, 0xff, 0x5c, 0xb8, 0x93, 0xf6, 0x72, 0x1d, 0x14, 0xea, 0xaa, 0x31, 0xb8, 0xe8,
+	0xa2, 0x24, 0xa0, 0x22, 0xae, 0xab, 0x88, 0x05, 0x71, 0x11, 0xa1, 0x0b, 0x41, 0xca, 0x24, 0x99,
+	0x26, 0x43, 0x26, 0x73, 0x86, 0x99, 0x49, 0x89, 0x5b, 0x5f, 0xc1, 0x97, 0xf3, 0x71, 0xa4, 0xd3,
+	0xd8, 0x5a, 0xd4, 0x2e, 0xcf, 0xef, 0xfc, 0x16, 0xdf, 0xf9, 0x38, 0xa8, 0xaf, 0x0d, 0x28, 0x92,
+	0xd1, 0x40, 0x2a, 0x30, 0x80, 0x3b, 0xa5, 0xce, 0xb4, 0xff, 0xea, 0xa0, 0x7f, 0xa6, 0x9e, 0xde,
+	0x4c, 0x45, 0x4a, 0xeb, 0x19, 0xe1, 0x15, 0xc5, 0x27, 0xa8, 0x17, 0xf3, 0x62, 0xce, 0x21, 0x21,
+	0x86, 0x81, 0x18, 0x38, 0x9e, 0x33, 0xea, 0x45, 0x6e, 0xcc, 0x8b, 0xfb, 0x06, 0xe1, 0x21, 0x72,
+	0x4d, 0xbd, 0x35, 0x5a, 0xd6, 0x40, 0xa6, 0xde, 0x08, 0x63, 0x84, 0x4d, 0x3d, 0x5f, 0x12, 0xce,
+	0x52, 0x0b, 0xe6, 0x09, 0xa4, 0x74, 0xd0, 0xf6, 0x9c, 0x51, 0x37, 0xfa, 0x6f, 0xea, 0xd9, 0x66,
+	0x71, 0x0d, 0x29, 0xf5, 0xdf, 0x1c, 0x74, 0x1c, 0x03, 0x18, 0x6d, 0x14, 0x91, 0x92, 0x89, 0xec,
+	0x51, 0x10, 0xa9, 0x73, 0x30, 0x53, 0xb1, 0x00, 0xec, 0xa3, 0x1e, 0x27, 0xda, 0x4c, 0x38, 0x24,
+	0xc5, 0x43, 0x55, 0xda, 0x3c, 0x9d, 0x68, 0x87, 0xe1, 0x53, 0xd4, 0xdf, 0xcc, 0x77, 0x44, 0xe7,
+	0x4d, 0xa4, 0x5d, 0x88, 0xc7, 0xe8, 0x40, 0x2a, 0xba, 0x64, 0x50, 0xe9, 0xad, 0xd9, 0xb6, 0xe6,
+	0xf7, 0x85, 0xff, 0xee, 0xa0, 0xbf, 0x52, 0x55, 0x82, 0xda, 0x14, 0xcf, 0x7b, 0x22, 0xda, 0x48,
+	0xee, 0xd9, 0x30, 0x58, 0x55, 0x1a, 0xfc, 0xaa, 0x45, 0x7b, 0x8e, 0xbc, 0x40, 0x47, 0x0b, 0xa6,
+	0xb4, 0x89, 0x68, 0x49, 0x98, 0x60, 0x22, 0xbb, 0x65, 0x9c, 0xae, 0xae, 0x6d, 0xd9, 0xce, 0x7e,
+	0x5e, 0x62, 0x0f, 0xb9, 0xab, 0x0b, 0x3f, 0xdd, 0x75, 0xbf, 0x5f, 0x11, 0x3e, 0x44, 0x5d, 0x99,
+	0x13, 0x4d, 0x07, 0x1d, 0xcf, 0x19, 0xf5, 0xa3, 0xf5, 0x30, 0xb9, 0x7a, 0xba, 0xcc, 0x98, 0xc9,
+	0xab, 0x38, 0x48, 0xa0, 0x0c, 0xf3, 0x17, 0x49, 0x15, 0xa7, 0x69, 0x46, 0x55, 0xb8, 0x20, 0xb1,
+	0x62, 0x49, 0x98, 0x40, 0x59, 0x82, 0x08, 0x1b, 0x18, 0xf3, 0xa2, 0xf9, 0x9d, 0xf8, 0x8f, 0x7d,
+	0x9e, 0xf3, 0x8f, 0x01, 0x00, 0x24, 0x56, 0x97, 0xe9, 0x4d, 0x02, 0x00, 0x00,
 }
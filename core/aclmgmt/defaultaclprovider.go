@@ -48,6 +48,7 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 
 	//-------------- _lifecycle --------------
 	d.pResourcePolicyMap[resources.Lifecycle_InstallChaincode] = policy.Admins
+	d.pResourcePolicyMap[resources.Lifecycle_UninstallChaincode] = policy.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_QueryInstalledChaincode] = policy.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_GetInstalledChaincodePackage] = policy.Admins
 	d.pResourcePolicyMap[resources.Lifecycle_QueryInstalledChaincodes] = policy.Admins
@@ -57,12 +58,14 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Lifecycle_CommitChaincodeDefinition] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Lifecycle_QueryChaincodeDefinition] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Lifecycle_QueryChaincodeDefinitions] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Lifecycle_QueryChaincodeDefinitionHistory] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Lifecycle_CheckCommitReadiness] = CHANNELWRITERS
 
 	//-------------- snapshot ---------------
 	d.pResourcePolicyMap[resources.Snapshot_submitrequest] = policy.Admins
 	d.pResourcePolicyMap[resources.Snapshot_cancelrequest] = policy.Admins
 	d.pResourcePolicyMap[resources.Snapshot_listpending] = policy.Admins
+	d.pResourcePolicyMap[resources.Snapshot_fetch] = policy.Peers
 
 	//-------------- LSCC --------------
 	//p resources (implemented by the chaincode currently)
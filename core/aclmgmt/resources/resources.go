@@ -13,6 +13,7 @@ package resources
 const (
 	// _lifecycle resources
 	Lifecycle_InstallChaincode                   = "_lifecycle/InstallChaincode"
+	Lifecycle_UninstallChaincode                 = "_lifecycle/UninstallChaincode"
 	Lifecycle_QueryInstalledChaincode            = "_lifecycle/QueryInstalledChaincode"
 	Lifecycle_GetInstalledChaincodePackage       = "_lifecycle/GetInstalledChaincodePackage"
 	Lifecycle_QueryInstalledChaincodes           = "_lifecycle/QueryInstalledChaincodes"
@@ -21,12 +22,14 @@ const (
 	Lifecycle_CommitChaincodeDefinition          = "_lifecycle/CommitChaincodeDefinition"
 	Lifecycle_QueryChaincodeDefinition           = "_lifecycle/QueryChaincodeDefinition"
 	Lifecycle_QueryChaincodeDefinitions          = "_lifecycle/QueryChaincodeDefinitions"
+	Lifecycle_QueryChaincodeDefinitionHistory    = "_lifecycle/QueryChaincodeDefinitionHistory"
 	Lifecycle_CheckCommitReadiness               = "_lifecycle/CheckCommitReadiness"
 
 	// snapshot resources
 	Snapshot_submitrequest = "snapshot/submitrequest"
 	Snapshot_cancelrequest = "snapshot/cancelrequest"
 	Snapshot_listpending   = "snapshot/listpending"
+	Snapshot_fetch         = "snapshot/fetch"
 
 	// Lscc resources
 	Lscc_Install                   = "lscc/Install"
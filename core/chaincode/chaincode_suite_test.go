@@ -90,6 +90,16 @@ type peerLedger interface {
 	ledger.PeerLedger
 }
 
+//go:generate counterfeiter -o mock/idle_registry.go --fake-name IdleRegistry . idleRegistry
+type idleRegistry interface {
+	chaincode.IdleRegistry
+}
+
+//go:generate counterfeiter -o mock/runtime_stopper.go --fake-name RuntimeStopper . runtimeStopper
+type runtimeStopper interface {
+	chaincode.RuntimeStopper
+}
+
 // NOTE: These are getting generated into the "fake" package to avoid import cycles. We need to revisit this.
 
 //go:generate counterfeiter -o fake/launch_registry.go --fake-name LaunchRegistry . launchRegistry
@@ -23,6 +23,8 @@ import (
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/pkg/ccevents"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
@@ -38,9 +40,9 @@ const (
 // Runtime is used to manage chaincode runtime instances.
 type Runtime interface {
 	Build(ccid string) (*ccintf.ChaincodeServerInfo, error)
-	Start(ccid string, ccinfo *ccintf.PeerConnection) error
+	Start(ccid string, instance int, ccinfo *ccintf.PeerConnection) error
 	Stop(ccid string) error
-	Wait(ccid string) (int, error)
+	Wait(ccid string, instance int) (int, error)
 }
 
 // Launcher is used to launch chaincode runtimes.
@@ -68,8 +70,10 @@ type ChaincodeSupport struct {
 	HandlerRegistry        *HandlerRegistry
 	Keepalive              time.Duration
 	Launcher               Launcher
+	LaunchMetrics          *LaunchMetrics
 	Lifecycle              Lifecycle
 	Peer                   *peer.Peer
+	ResourceLimits         *ResourceLimitsConfig
 	Runtime                Runtime
 	TotalQueryLimit        int
 	UserRunsCC             bool
@@ -83,6 +87,7 @@ func (cs *ChaincodeSupport) Launch(ccid string) (*Handler, error) {
 		return h, nil
 	}
 
+	startTime := time.Now()
 	if err := cs.Launcher.Launch(ccid, cs); err != nil {
 		return nil, errors.Wrapf(err, "could not launch chaincode %s", ccid)
 	}
@@ -92,12 +97,14 @@ func (cs *ChaincodeSupport) Launch(ccid string) (*Handler, error) {
 		return nil, errors.Errorf("claimed to start chaincode container for %s but could not find handler", ccid)
 	}
 
+	cs.LaunchMetrics.ColdStartDuration.With("chaincode", ccid).Observe(time.Since(startTime).Seconds())
+
 	return h, nil
 }
 
 // LaunchInProc is a stopgap solution to be called by the inproccontroller to allow system chaincodes to register
 func (cs *ChaincodeSupport) LaunchInProc(ccid string) <-chan struct{} {
-	launchStatus, ok := cs.HandlerRegistry.Launching(ccid)
+	launchStatus, ok := cs.HandlerRegistry.LaunchingInProc(ccid)
 	if ok {
 		chaincodeLogger.Panicf("attempted to launch a system chaincode which has already been launched")
 	}
@@ -126,6 +133,7 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 		AppConfig:              cs.AppConfig,
 		Metrics:                cs.HandlerMetrics,
 		TotalQueryLimit:        cs.TotalQueryLimit,
+		ResourceLimits:         cs.ResourceLimits,
 	}
 
 	return handler.ProcessStream(stream)
@@ -171,8 +179,17 @@ func processChaincodeExecutionResult(txid, ccName string, resp *pb.ChaincodeMess
 	}
 
 	if resp.ChaincodeEvent != nil {
-		resp.ChaincodeEvent.ChaincodeId = ccName
-		resp.ChaincodeEvent.TxId = txid
+		events, err := ccevents.Unpack(resp.ChaincodeEvent)
+		if err != nil {
+			return nil, nil, errors.WithMessagef(err, "invalid chaincode events for transaction %s", txid)
+		}
+		for _, event := range events {
+			event.ChaincodeId = ccName
+			event.TxId = txid
+		}
+		if resp.ChaincodeEvent, err = protoutil.NewChaincodeEventList(events); err != nil {
+			return nil, nil, errors.WithMessagef(err, "invalid chaincode events for transaction %s", txid)
+		}
 	}
 
 	switch resp.Type {
@@ -56,6 +56,7 @@ import (
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/pkg/ccevents"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protoutil"
@@ -249,8 +250,9 @@ func initMockPeer(channelIDs ...string) (*peer.Peer, *ChaincodeSupport, func(),
 	userRunsCC := true
 	metricsProviders := &disabled.Provider{}
 	chaincodeHandlerRegistry := NewHandlerRegistry(userRunsCC)
+	launchMetrics := NewLaunchMetrics(metricsProviders)
 	chaincodeLauncher := &RuntimeLauncher{
-		Metrics:        NewLaunchMetrics(metricsProviders),
+		Metrics:        launchMetrics,
 		Runtime:        containerRuntime,
 		Registry:       chaincodeHandlerRegistry,
 		StartupTimeout: globalConfig.StartupTimeout,
@@ -270,6 +272,7 @@ func initMockPeer(channelIDs ...string) (*peer.Peer, *ChaincodeSupport, func(),
 		HandlerRegistry:        chaincodeHandlerRegistry,
 		Keepalive:              globalConfig.Keepalive,
 		Launcher:               chaincodeLauncher,
+		LaunchMetrics:          launchMetrics,
 		Lifecycle:              ml,
 		Peer:                   peerInstance,
 		Runtime:                containerRuntime,
@@ -920,7 +923,7 @@ func getHistory(t *testing.T, chainID, ccname string, ccSide *mock.MockCCComm, c
 func TestStartAndWaitSuccess(t *testing.T) {
 	handlerRegistry := NewHandlerRegistry(false)
 	fakeRuntime := &mock.Runtime{}
-	fakeRuntime.StartStub = func(_ string, _ *ccintf.PeerConnection) error {
+	fakeRuntime.StartStub = func(_ string, _ int, _ *ccintf.PeerConnection) error {
 		handlerRegistry.Ready("testcc:0")
 		return nil
 	}
@@ -943,7 +946,7 @@ func TestStartAndWaitSuccess(t *testing.T) {
 // test timeout error
 func TestStartAndWaitTimeout(t *testing.T) {
 	fakeRuntime := &mock.Runtime{}
-	fakeRuntime.StartStub = func(_ string, _ *ccintf.PeerConnection) error {
+	fakeRuntime.StartStub = func(_ string, _ int, _ *ccintf.PeerConnection) error {
 		time.Sleep(time.Second)
 		return nil
 	}
@@ -966,7 +969,7 @@ func TestStartAndWaitTimeout(t *testing.T) {
 // test container return error
 func TestStartAndWaitLaunchError(t *testing.T) {
 	fakeRuntime := &mock.Runtime{}
-	fakeRuntime.StartStub = func(_ string, _ *ccintf.PeerConnection) error {
+	fakeRuntime.StartStub = func(_ string, _ int, _ *ccintf.PeerConnection) error {
 		return errors.New("Bad lunch; upset stomach")
 	}
 
@@ -1288,6 +1291,48 @@ func TestExecuteTimeout(t *testing.T) {
 	}
 }
 
+func TestProcessChaincodeExecutionResultEvents(t *testing.T) {
+	stub := &eventStub{}
+	emitter := ccevents.NewEmitter(stub)
+	require.NoError(t, emitter.Emit("first", nil))
+	require.NoError(t, emitter.Emit("second", nil))
+	stub.event.ChaincodeId = "spoofed"
+	resp := &pb.ChaincodeMessage{
+		Type:           pb.ChaincodeMessage_COMPLETED,
+		Payload:        protoutil.MarshalOrPanic(&pb.Response{Status: shim.OK}),
+		ChaincodeEvent: stub.event,
+	}
+
+	_, ccevent, err := processChaincodeExecutionResult("txid", "mycc", resp, nil)
+	require.NoError(t, err)
+	require.Equal(t, "second", ccevent.EventName)
+	require.Equal(t, "mycc", ccevent.ChaincodeId)
+	require.Equal(t, "txid", ccevent.TxId)
+	events, err := protoutil.GetChaincodeEventList(ccevent)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	for i, name := range []string{"first", "second"} {
+		require.Equal(t, name, events[i].EventName)
+		require.Equal(t, "mycc", events[i].ChaincodeId)
+		require.Equal(t, "txid", events[i].TxId)
+	}
+
+	resp.ChaincodeEvent = &pb.ChaincodeEvent{EventName: ccevents.ListEventName}
+	_, _, err = processChaincodeExecutionResult("txid", "mycc", resp, nil)
+	require.EqualError(t, err, "invalid chaincode events for transaction txid: empty list of events")
+}
+
+// eventStub keeps the last event set, as the shim does
+type eventStub struct {
+	shim.ChaincodeStubInterface
+	event *pb.ChaincodeEvent
+}
+
+func (s *eventStub) SetEvent(name string, payload []byte) error {
+	s.event = &pb.ChaincodeEvent{EventName: name, Payload: payload}
+	return nil
+}
+
 func TestMaxDuration(t *testing.T) {
 	tests := []struct {
 		durations []time.Duration
@@ -16,8 +16,11 @@ import (
 )
 
 const (
-	defaultExecutionTimeout = 30 * time.Second
-	minimumStartupTimeout   = 5 * time.Second
+	defaultExecutionTimeout      = 30 * time.Second
+	minimumStartupTimeout        = 5 * time.Second
+	defaultRestartInitialBackoff = time.Second
+	defaultRestartMaxBackoff     = 2 * time.Minute
+	defaultLogCaptureMaxLines    = 1000
 )
 
 type Config struct {
@@ -27,12 +30,31 @@ type Config struct {
 	ExecuteTimeout  time.Duration
 	InstallTimeout  time.Duration
 	StartupTimeout  time.Duration
+	IdleTimeout     time.Duration
+	Restart         RestartConfig
+	ResourceLimits  ResourceLimitsConfig
+	Instances       map[string]int
+	LogCapture      LogCaptureConfig
 	LogFormat       string
 	LogLevel        string
 	ShimLogLevel    string
 	SCCAllowlist    map[string]bool
 }
 
+// RestartConfig configures relaunching chaincodes which exit unexpectedly.
+type RestartConfig struct {
+	Enabled        bool
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// LogCaptureConfig configures capturing the output of running chaincodes.
+type LogCaptureConfig struct {
+	Enabled  bool
+	MaxLines int
+	MaxBytes int
+}
+
 func GlobalConfig() *Config {
 	c := &Config{}
 	c.load()
@@ -58,6 +80,58 @@ func (c *Config) load() {
 		c.StartupTimeout = minimumStartupTimeout
 	}
 
+	c.IdleTimeout = viper.GetDuration("chaincode.idleTimeout")
+	if c.IdleTimeout < 0 {
+		c.IdleTimeout = 0
+	}
+
+	c.Restart.Enabled = viper.GetBool("chaincode.restart.enabled")
+	c.Restart.InitialBackoff = viper.GetDuration("chaincode.restart.initialBackoff")
+	if c.Restart.InitialBackoff <= 0 {
+		c.Restart.InitialBackoff = defaultRestartInitialBackoff
+	}
+	c.Restart.MaxBackoff = viper.GetDuration("chaincode.restart.maxBackoff")
+	if c.Restart.MaxBackoff <= 0 {
+		c.Restart.MaxBackoff = defaultRestartMaxBackoff
+	}
+	if c.Restart.MaxBackoff < c.Restart.InitialBackoff {
+		c.Restart.MaxBackoff = c.Restart.InitialBackoff
+	}
+
+	if err := viper.UnmarshalKey("chaincode.resourceLimits", &c.ResourceLimits); err != nil {
+		chaincodeLogger.Errorf("Failed to parse chaincode.resourceLimits, chaincode resources will not be limited: %s", err)
+		c.ResourceLimits = ResourceLimitsConfig{}
+	}
+	chaincodeLimits := map[string]ResourceLimits{}
+	for name, limits := range c.ResourceLimits.Chaincodes {
+		chaincodeLimits[strings.ToLower(name)] = limits
+	}
+	c.ResourceLimits.Chaincodes = chaincodeLimits
+
+	var instances map[string]int
+	if err := viper.UnmarshalKey("chaincode.instances", &instances); err != nil {
+		chaincodeLogger.Errorf("Failed to parse chaincode.instances, a single instance of each chaincode will be started: %s", err)
+		instances = nil
+	}
+	c.Instances = map[string]int{}
+	for label, count := range instances {
+		if count < 1 {
+			chaincodeLogger.Warningf("Ignoring chaincode.instances for %s, at least one instance is required but %d were configured", label, count)
+			continue
+		}
+		c.Instances[strings.ToLower(label)] = count
+	}
+
+	c.LogCapture.Enabled = viper.GetBool("chaincode.logCapture.enabled")
+	c.LogCapture.MaxLines = viper.GetInt("chaincode.logCapture.maxLines")
+	if c.LogCapture.MaxLines <= 0 {
+		c.LogCapture.MaxLines = defaultLogCaptureMaxLines
+	}
+	c.LogCapture.MaxBytes = viper.GetInt("chaincode.logCapture.maxBytes")
+	if c.LogCapture.MaxBytes < 0 {
+		c.LogCapture.MaxBytes = 0
+	}
+
 	c.SCCAllowlist = map[string]bool{}
 	for k, v := range viper.GetStringMapString("chaincode.system") {
 		c.SCCAllowlist[k] = parseBool(v)
@@ -33,6 +33,13 @@ var _ = Describe("Config", func() {
 			viper.Set("chaincode.executetimeout", "20h")
 			viper.Set("chaincode.installTimeout", "30m")
 			viper.Set("chaincode.startuptimeout", "30h")
+			viper.Set("chaincode.idleTimeout", "15m")
+			viper.Set("chaincode.restart.enabled", true)
+			viper.Set("chaincode.restart.initialBackoff", "2s")
+			viper.Set("chaincode.restart.maxBackoff", "1m")
+			viper.Set("chaincode.logCapture.enabled", true)
+			viper.Set("chaincode.logCapture.maxLines", 50)
+			viper.Set("chaincode.logCapture.maxBytes", 4096)
 			viper.Set("chaincode.logging.format", "test-chaincode-logging-format")
 			viper.Set("chaincode.logging.level", "warning")
 			viper.Set("chaincode.logging.shim", "warning")
@@ -44,6 +51,17 @@ var _ = Describe("Config", func() {
 			Expect(config.ExecuteTimeout).To(Equal(20 * time.Hour))
 			Expect(config.InstallTimeout).To(Equal(30 * time.Minute))
 			Expect(config.StartupTimeout).To(Equal(30 * time.Hour))
+			Expect(config.IdleTimeout).To(Equal(15 * time.Minute))
+			Expect(config.Restart).To(Equal(chaincode.RestartConfig{
+				Enabled:        true,
+				InitialBackoff: 2 * time.Second,
+				MaxBackoff:     time.Minute,
+			}))
+			Expect(config.LogCapture).To(Equal(chaincode.LogCaptureConfig{
+				Enabled:  true,
+				MaxLines: 50,
+				MaxBytes: 4096,
+			}))
 			Expect(config.LogFormat).To(Equal("test-chaincode-logging-format"))
 			Expect(config.LogLevel).To(Equal("warn"))
 			Expect(config.ShimLogLevel).To(Equal("warn"))
@@ -72,6 +90,116 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Context("when a negative idle timeout is configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.idleTimeout", "-10m")
+			})
+
+			It("disables stopping idle chaincodes", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.IdleTimeout).To(Equal(time.Duration(0)))
+			})
+		})
+
+		Context("when the restart backoffs are not set", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.restart.initialBackoff", "")
+				viper.Set("chaincode.restart.maxBackoff", "")
+			})
+
+			It("uses the defaults", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.Restart.InitialBackoff).To(Equal(time.Second))
+				Expect(config.Restart.MaxBackoff).To(Equal(2 * time.Minute))
+			})
+		})
+
+		Context("when the maximum restart backoff is less than the initial backoff", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.restart.initialBackoff", "10s")
+				viper.Set("chaincode.restart.maxBackoff", "5s")
+			})
+
+			It("uses the initial backoff as the maximum", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.Restart.MaxBackoff).To(Equal(10 * time.Second))
+			})
+		})
+
+		Context("when the log capture limits are not set", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.logCapture.maxLines", "")
+				viper.Set("chaincode.logCapture.maxBytes", -1)
+			})
+
+			It("uses the defaults", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.LogCapture.MaxLines).To(Equal(1000))
+				Expect(config.LogCapture.MaxBytes).To(Equal(0))
+			})
+		})
+
+		Context("when resource limits are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.resourceLimits.maxStateReads", 100)
+				viper.Set("chaincode.resourceLimits.maxWrites", 10)
+				viper.Set("chaincode.resourceLimits.chaincodes", map[string]interface{}{
+					"BigCC": map[string]interface{}{
+						"maxStateReads": 1000,
+						"maxCallDepth":  2,
+					},
+				})
+			})
+
+			It("captures the peer-wide and per-chaincode limits", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.ResourceLimits.ForChaincode("othercc")).To(Equal(chaincode.ResourceLimits{
+					MaxStateReads: 100,
+					MaxWrites:     10,
+				}))
+				Expect(config.ResourceLimits.ForChaincode("BigCC")).To(Equal(chaincode.ResourceLimits{
+					MaxStateReads: 1000,
+					MaxCallDepth:  2,
+				}))
+			})
+		})
+
+		Context("when the resource limits are invalid", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.resourceLimits.maxStateReads", "lots")
+			})
+
+			It("does not limit resources", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.ResourceLimits.ForChaincode("mycc")).To(Equal(chaincode.ResourceLimits{}))
+			})
+		})
+
+		Context("when chaincode instances are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.instances", map[string]interface{}{
+					"BigCC":  3,
+					"nonecc": 0,
+				})
+			})
+
+			It("captures the instance counts by lower-cased label", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.Instances).To(Equal(map[string]int{"bigcc": 3}))
+			})
+		})
+
+		Context("when the chaincode instances are invalid", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.instances", "lots")
+			})
+
+			It("does not configure any instances", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.Instances).To(BeEmpty())
+			})
+		})
+
 		Context("when the startup timeout is less than the minimum", func() {
 			BeforeEach(func() {
 				viper.Set("chaincode.startuptimeout", "15")
@@ -116,13 +244,17 @@ func capture() (restore func()) {
 	viper.SetEnvPrefix("CORE")
 	viper.AutomaticEnv()
 	config := map[string]string{
-		"peer.tls.enabled":         viper.GetString("peer.tls.enabled"),
-		"chaincode.keepalive":      viper.GetString("chaincode.keepalive"),
-		"chaincode.executetimeout": viper.GetString("chaincode.executetimeout"),
-		"chaincode.startuptimeout": viper.GetString("chaincode.startuptimeout"),
-		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
-		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
-		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+		"peer.tls.enabled":                 viper.GetString("peer.tls.enabled"),
+		"chaincode.keepalive":              viper.GetString("chaincode.keepalive"),
+		"chaincode.executetimeout":         viper.GetString("chaincode.executetimeout"),
+		"chaincode.startuptimeout":         viper.GetString("chaincode.startuptimeout"),
+		"chaincode.idleTimeout":            viper.GetString("chaincode.idleTimeout"),
+		"chaincode.restart.enabled":        viper.GetString("chaincode.restart.enabled"),
+		"chaincode.restart.initialBackoff": viper.GetString("chaincode.restart.initialBackoff"),
+		"chaincode.restart.maxBackoff":     viper.GetString("chaincode.restart.maxBackoff"),
+		"chaincode.logging.format":         viper.GetString("chaincode.logging.format"),
+		"chaincode.logging.level":          viper.GetString("chaincode.logging.level"),
+		"chaincode.logging.shim":           viper.GetString("chaincode.logging.shim"),
 	}
 
 	return func() {
@@ -20,9 +20,9 @@ import (
 type ContainerRouter interface {
 	Build(ccid string) error
 	ChaincodeServerInfo(ccid string) (*ccintf.ChaincodeServerInfo, error)
-	Start(ccid string, peerConnection *ccintf.PeerConnection) error
+	Start(ccid string, instance int, peerConnection *ccintf.PeerConnection) error
 	Stop(ccid string) error
-	Wait(ccid string) (int, error)
+	Wait(ccid string, instance int) (int, error)
 }
 
 // ContainerRuntime is responsible for managing containerized chaincode.
@@ -48,18 +48,19 @@ func (c *ContainerRuntime) Build(ccid string) (*ccintf.ChaincodeServerInfo, erro
 	return c.ContainerRouter.ChaincodeServerInfo(ccid)
 }
 
-// Start launches chaincode in a runtime environment.
-func (c *ContainerRuntime) Start(ccid string, ccinfo *ccintf.PeerConnection) error {
-	chaincodeLogger.Debugf("start container: %s", ccid)
+// Start launches an instance of chaincode in a runtime environment. Instances
+// are numbered from zero.
+func (c *ContainerRuntime) Start(ccid string, instance int, ccinfo *ccintf.PeerConnection) error {
+	chaincodeLogger.Debugf("start container: %s (instance: %d)", ccid, instance)
 
-	if err := c.ContainerRouter.Start(ccid, ccinfo); err != nil {
+	if err := c.ContainerRouter.Start(ccid, instance, ccinfo); err != nil {
 		return errors.WithMessage(err, "error starting container")
 	}
 
 	return nil
 }
 
-// Stop terminates chaincode and its container runtime environment.
+// Stop terminates every instance of chaincode and its container runtime environment.
 func (c *ContainerRuntime) Stop(ccid string) error {
 	if err := c.ContainerRouter.Stop(ccid); err != nil {
 		return errors.WithMessage(err, "error stopping container")
@@ -68,7 +69,7 @@ func (c *ContainerRuntime) Stop(ccid string) error {
 	return nil
 }
 
-// Wait waits for the container runtime to terminate.
-func (c *ContainerRuntime) Wait(ccid string) (int, error) {
-	return c.ContainerRouter.Wait(ccid)
+// Wait waits for the container runtime of an instance of chaincode to terminate.
+func (c *ContainerRuntime) Wait(ccid string, instance int) (int, error) {
+	return c.ContainerRouter.Wait(ccid, instance)
 }
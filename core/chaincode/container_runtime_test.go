@@ -44,20 +44,28 @@ func TestContainerRuntimeStart(t *testing.T) {
 		BuildRegistry:   &container.BuildRegistry{},
 	}
 
-	err := cr.Start("chaincode-name:chaincode-version", &ccintf.PeerConnection{Address: "peer-address"})
+	err := cr.Start("chaincode-name:chaincode-version", 0, &ccintf.PeerConnection{Address: "peer-address"})
 	require.NoError(t, err)
 
 	require.Equal(t, 1, fakeRouter.StartCallCount())
-	ccid, peerConnection := fakeRouter.StartArgsForCall(0)
+	ccid, instance, peerConnection := fakeRouter.StartArgsForCall(0)
 	require.Equal(t, "chaincode-name:chaincode-version", ccid)
+	require.Equal(t, 0, instance)
 	require.Equal(t, "peer-address", peerConnection.Address)
 	require.Nil(t, peerConnection.TLSConfig)
 
 	// Try starting a second time, to ensure build is not invoked again
 	// as the BuildRegistry already holds it
-	err = cr.Start("chaincode-name:chaincode-version", &ccintf.PeerConnection{Address: "fake-address"})
+	err = cr.Start("chaincode-name:chaincode-version", 0, &ccintf.PeerConnection{Address: "fake-address"})
 	require.NoError(t, err)
 	require.Equal(t, 2, fakeRouter.StartCallCount())
+
+	// Start another instance of the chaincode
+	err = cr.Start("chaincode-name:chaincode-version", 1, &ccintf.PeerConnection{Address: "peer-address"})
+	require.NoError(t, err)
+	require.Equal(t, 3, fakeRouter.StartCallCount())
+	_, instance, _ = fakeRouter.StartArgsForCall(2)
+	require.Equal(t, 1, instance)
 }
 
 func TestContainerRuntimeStartErrors(t *testing.T) {
@@ -78,7 +86,7 @@ func TestContainerRuntimeStartErrors(t *testing.T) {
 			BuildRegistry:   &container.BuildRegistry{},
 		}
 
-		err := cr.Start("ccid", &ccintf.PeerConnection{Address: "fake-address"})
+		err := cr.Start("ccid", 0, &ccintf.PeerConnection{Address: "fake-address"})
 		require.EqualError(t, err, tc.errValue)
 	}
 }
@@ -125,14 +133,16 @@ func TestContainerRuntimeWait(t *testing.T) {
 		ContainerRouter: fakeRouter,
 	}
 
-	exitCode, err := cr.Wait("chaincode-id-name:chaincode-version")
+	exitCode, err := cr.Wait("chaincode-id-name:chaincode-version", 1)
 	require.NoError(t, err)
 	require.Equal(t, 0, exitCode)
 	require.Equal(t, 1, fakeRouter.WaitCallCount())
-	require.Equal(t, "chaincode-id-name:chaincode-version", fakeRouter.WaitArgsForCall(0))
+	ccid, instance := fakeRouter.WaitArgsForCall(0)
+	require.Equal(t, "chaincode-id-name:chaincode-version", ccid)
+	require.Equal(t, 1, instance)
 
 	fakeRouter.WaitReturns(3, errors.New("moles-and-trolls"))
-	code, err := cr.Wait("chaincode-id-name:chaincode-version")
+	code, err := cr.Wait("chaincode-id-name:chaincode-version", 0)
 	require.EqualError(t, err, "moles-and-trolls")
 	require.Equal(t, code, 3)
 }
@@ -19,6 +19,13 @@ type LaunchRegistry struct {
 	deregisterReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ExitedStub        func(string, *chaincode.LaunchState, error)
+	exitedMutex       sync.RWMutex
+	exitedArgsForCall []struct {
+		arg1 string
+		arg2 *chaincode.LaunchState
+		arg3 error
+	}
 	LaunchingStub        func(string) (*chaincode.LaunchState, bool)
 	launchingMutex       sync.RWMutex
 	launchingArgsForCall []struct {
@@ -32,6 +39,18 @@ type LaunchRegistry struct {
 		result1 *chaincode.LaunchState
 		result2 bool
 	}
+	RunningStub        func(string, *chaincode.LaunchState) bool
+	runningMutex       sync.RWMutex
+	runningArgsForCall []struct {
+		arg1 string
+		arg2 *chaincode.LaunchState
+	}
+	runningReturns struct {
+		result1 bool
+	}
+	runningReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -96,6 +115,39 @@ func (fake *LaunchRegistry) DeregisterReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *LaunchRegistry) Exited(arg1 string, arg2 *chaincode.LaunchState, arg3 error) {
+	fake.exitedMutex.Lock()
+	fake.exitedArgsForCall = append(fake.exitedArgsForCall, struct {
+		arg1 string
+		arg2 *chaincode.LaunchState
+		arg3 error
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Exited", []interface{}{arg1, arg2, arg3})
+	fake.exitedMutex.Unlock()
+	if fake.ExitedStub != nil {
+		fake.ExitedStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *LaunchRegistry) ExitedCallCount() int {
+	fake.exitedMutex.RLock()
+	defer fake.exitedMutex.RUnlock()
+	return len(fake.exitedArgsForCall)
+}
+
+func (fake *LaunchRegistry) ExitedCalls(stub func(string, *chaincode.LaunchState, error)) {
+	fake.exitedMutex.Lock()
+	defer fake.exitedMutex.Unlock()
+	fake.ExitedStub = stub
+}
+
+func (fake *LaunchRegistry) ExitedArgsForCall(i int) (string, *chaincode.LaunchState, error) {
+	fake.exitedMutex.RLock()
+	defer fake.exitedMutex.RUnlock()
+	argsForCall := fake.exitedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
 func (fake *LaunchRegistry) Launching(arg1 string) (*chaincode.LaunchState, bool) {
 	fake.launchingMutex.Lock()
 	ret, specificReturn := fake.launchingReturnsOnCall[len(fake.launchingArgsForCall)]
@@ -159,13 +211,78 @@ func (fake *LaunchRegistry) LaunchingReturnsOnCall(i int, result1 *chaincode.Lau
 	}{result1, result2}
 }
 
+func (fake *LaunchRegistry) Running(arg1 string, arg2 *chaincode.LaunchState) bool {
+	fake.runningMutex.Lock()
+	ret, specificReturn := fake.runningReturnsOnCall[len(fake.runningArgsForCall)]
+	fake.runningArgsForCall = append(fake.runningArgsForCall, struct {
+		arg1 string
+		arg2 *chaincode.LaunchState
+	}{arg1, arg2})
+	fake.recordInvocation("Running", []interface{}{arg1, arg2})
+	fake.runningMutex.Unlock()
+	if fake.RunningStub != nil {
+		return fake.RunningStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.runningReturns
+	return fakeReturns.result1
+}
+
+func (fake *LaunchRegistry) RunningCallCount() int {
+	fake.runningMutex.RLock()
+	defer fake.runningMutex.RUnlock()
+	return len(fake.runningArgsForCall)
+}
+
+func (fake *LaunchRegistry) RunningCalls(stub func(string, *chaincode.LaunchState) bool) {
+	fake.runningMutex.Lock()
+	defer fake.runningMutex.Unlock()
+	fake.RunningStub = stub
+}
+
+func (fake *LaunchRegistry) RunningArgsForCall(i int) (string, *chaincode.LaunchState) {
+	fake.runningMutex.RLock()
+	defer fake.runningMutex.RUnlock()
+	argsForCall := fake.runningArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *LaunchRegistry) RunningReturns(result1 bool) {
+	fake.runningMutex.Lock()
+	defer fake.runningMutex.Unlock()
+	fake.RunningStub = nil
+	fake.runningReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *LaunchRegistry) RunningReturnsOnCall(i int, result1 bool) {
+	fake.runningMutex.Lock()
+	defer fake.runningMutex.Unlock()
+	fake.RunningStub = nil
+	if fake.runningReturnsOnCall == nil {
+		fake.runningReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.runningReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *LaunchRegistry) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.deregisterMutex.RLock()
 	defer fake.deregisterMutex.RUnlock()
+	fake.exitedMutex.RLock()
+	defer fake.exitedMutex.RUnlock()
 	fake.launchingMutex.RLock()
 	defer fake.launchingMutex.RUnlock()
+	fake.runningMutex.RLock()
+	defer fake.runningMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
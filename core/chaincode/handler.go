@@ -42,7 +42,7 @@ type Registry interface {
 	Register(*Handler) error
 	Ready(string)
 	Failed(string, error)
-	Deregister(string) error
+	DeregisterHandler(*Handler) error
 }
 
 // An Invoker invokes chaincode.
@@ -98,6 +98,9 @@ type Handler struct {
 	// TotalQueryLimit specifies the maximum number of results to return for
 	// chaincode queries.
 	TotalQueryLimit int
+	// ResourceLimits bounds the resources chaincode may consume while a
+	// transaction is simulated.
+	ResourceLimits *ResourceLimitsConfig
 	// Invoker is used to invoke chaincode.
 	Invoker Invoker
 	// Registry is used to track active handlers.
@@ -139,10 +142,23 @@ type Handler struct {
 	chatStream ccintf.ChaincodeStream
 	// errChan is used to communicate errors from the async send to the receive loop
 	errChan chan error
-	// mutex is used to serialze the stream closed chan.
+	// mutex is used to serialze the stream closed chan and the activity
+	// tracking fields below.
 	mutex sync.Mutex
 	// streamDoneChan is closed when the chaincode stream terminates.
 	streamDoneChan chan struct{}
+	// activeExecutions is the number of transactions currently being executed
+	// by the chaincode.
+	activeExecutions int
+	// lastActive holds the time at which the handler was last used.
+	lastActive time.Time
+	// closed is set once the handler has been closed by the registry.
+	closed bool
+	// closedChan is closed when the handler is closed.
+	closedChan chan struct{}
+	// exitErr holds the reason the chaincode terminated, if it terminated
+	// unexpectedly.
+	exitErr error
 }
 
 // handleMessage is called by ProcessStream to dispatch messages.
@@ -345,7 +361,85 @@ func (h *Handler) checkACL(signedProp *pb.SignedProposal, proposal *pb.Proposal,
 }
 
 func (h *Handler) deregister() {
-	h.Registry.Deregister(h.chaincodeID)
+	h.mutex.Lock()
+	closed := h.closed
+	h.mutex.Unlock()
+
+	// A handler which has already been closed, for example because the
+	// chaincode was stopped while idle, is no longer registered and the
+	// registry may hold a new handler for the same chaincode.
+	if closed {
+		return
+	}
+
+	h.Registry.DeregisterHandler(h)
+}
+
+// markActive records that the handler is in use.
+func (h *Handler) markActive() {
+	h.mutex.Lock()
+	h.lastActive = time.Now()
+	h.mutex.Unlock()
+}
+
+// inFlight returns the number of transactions the chaincode is executing.
+func (h *Handler) inFlight() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.activeExecutions
+}
+
+func (h *Handler) executionStarted() {
+	h.mutex.Lock()
+	h.activeExecutions++
+	h.lastActive = time.Now()
+	h.mutex.Unlock()
+}
+
+func (h *Handler) executionCompleted() {
+	h.mutex.Lock()
+	h.activeExecutions--
+	h.lastActive = time.Now()
+	h.mutex.Unlock()
+}
+
+// handlerClosed returns a channel which is closed when the handler is closed.
+func (h *Handler) handlerClosed() <-chan struct{} {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.closedChan == nil {
+		h.closedChan = make(chan struct{})
+		if h.closed {
+			close(h.closedChan)
+		}
+	}
+	return h.closedChan
+}
+
+// terminate closes the handler of a chaincode which exited unexpectedly.
+func (h *Handler) terminate(exitErr error) {
+	h.mutex.Lock()
+	h.exitErr = exitErr
+	h.mutex.Unlock()
+
+	h.Close()
+}
+
+func (h *Handler) terminationError() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.exitErr != nil {
+		return errors.WithMessagef(h.exitErr, "chaincode %s terminated unexpectedly", h.chaincodeID)
+	}
+	return errors.Errorf("chaincode %s is no longer registered", h.chaincodeID)
+}
+
+// idleSince returns the time since which the handler has not been used. The
+// bool is false if the handler is executing transactions.
+func (h *Handler) idleSince() (time.Time, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.lastActive, h.activeExecutions == 0
 }
 
 func (h *Handler) streamDone() <-chan struct{} {
@@ -619,6 +713,9 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	if err := h.chargeRead(txContext, len(res)); err != nil {
+		return nil, err
+	}
 	if res == nil {
 		chaincodeLogger.Debugf("[%s] No state associated with key: %s. Sending %s with an empty payload", shorttxid(msg.Txid), getState.Key, pb.ChaincodeMessage_RESPONSE)
 	}
@@ -645,6 +742,9 @@ func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	if err := h.chargeRead(txContext, len(res)); err != nil {
+		return nil, err
+	}
 	if res == nil {
 		chaincodeLogger.Debugf("[%s] No state associated with key: %s. Sending %s with an empty payload", shorttxid(msg.Txid), getState.Key, pb.ChaincodeMessage_RESPONSE)
 	}
@@ -693,6 +793,9 @@ func (h *Handler) HandleGetStateMetadata(msg *pb.ChaincodeMessage, txContext *Tr
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	if err := h.chargeRead(txContext, len(res)); err != nil {
+		return nil, err
+	}
 
 	// Send response msg back to chaincode. GetState will not trigger event
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
@@ -749,6 +852,10 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
 	}
+	if err := h.chargeQueryResponse(txContext, payload); err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, err
+	}
 
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -780,6 +887,10 @@ func (h *Handler) HandleQueryStateNext(msg *pb.ChaincodeMessage, txContext *Tran
 		txContext.CleanupQueryContext(queryStateNext.Id)
 		return nil, errors.WithStack(err)
 	}
+	if err := h.chargeQueryResponse(txContext, payload); err != nil {
+		txContext.CleanupQueryContext(queryStateNext.Id)
+		return nil, err
+	}
 
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -859,6 +970,10 @@ func (h *Handler) HandleGetQueryResult(msg *pb.ChaincodeMessage, txContext *Tran
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
 	}
+	if err := h.chargeQueryResponse(txContext, payload); err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, err
+	}
 
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -897,6 +1012,10 @@ func (h *Handler) HandleGetHistoryForKey(msg *pb.ChaincodeMessage, txContext *Tr
 		txContext.CleanupQueryContext(iterID)
 		return nil, errors.WithStack(err)
 	}
+	if err := h.chargeQueryResponse(txContext, payload); err != nil {
+		txContext.CleanupQueryContext(iterID)
+		return nil, err
+	}
 
 	payloadBytes, err := proto.Marshal(payload)
 	if err != nil {
@@ -989,6 +1108,10 @@ func (h *Handler) HandlePutState(msg *pb.ChaincodeMessage, txContext *Transactio
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
+	if err := h.chargeWrite(txContext, len(putState.Value)); err != nil {
+		return nil, err
+	}
+
 	namespaceID := txContext.NamespaceID
 	collection := putState.Collection
 	if isCollectionSet(collection) {
@@ -1024,6 +1147,10 @@ func (h *Handler) HandlePutStateMetadata(msg *pb.ChaincodeMessage, txContext *Tr
 	metadata := make(map[string][]byte)
 	metadata[putStateMetadata.Metadata.Metakey] = putStateMetadata.Metadata.Value
 
+	if err := h.chargeWrite(txContext, len(putStateMetadata.Metadata.Value)); err != nil {
+		return nil, err
+	}
+
 	namespaceID := txContext.NamespaceID
 	collection := putStateMetadata.Collection
 	if isCollectionSet(collection) {
@@ -1051,6 +1178,10 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
+	if err := h.chargeWrite(txContext, 0); err != nil {
+		return nil, err
+	}
+
 	namespaceID := txContext.NamespaceID
 	collection := delState.Collection
 	if isCollectionSet(collection) {
@@ -1106,6 +1237,17 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 		return nil, errors.WithStack(err)
 	}
 
+	if usage := txContext.ResourceUsage; usage != nil {
+		if err := usage.Err(); err != nil {
+			return nil, err
+		}
+		depth := usage.EnterCall()
+		defer usage.ExitCall()
+		if err := h.checkResourceLimit(txContext, ccprovider.CallDepth, depth); err != nil {
+			return nil, err
+		}
+	}
+
 	// Set up a new context for the called chaincode if on a different channel
 	// We grab the called channel's ledger simulator to hold the new state
 	txParams := &ccprovider.TransactionParams{
@@ -1115,6 +1257,7 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 		Proposal:             txContext.Proposal,
 		TXSimulator:          txContext.TXSimulator,
 		HistoryQueryExecutor: txContext.HistoryQueryExecutor,
+		ResourceUsage:        txContext.ResourceUsage,
 	}
 
 	if targetInstance.ChannelID != txContext.ChannelID {
@@ -1162,6 +1305,21 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, namespace stri
 	txParams.IsInitTransaction = (msg.Type == pb.ChaincodeMessage_INIT)
 	txParams.NamespaceID = namespace
 
+	// The resources consumed by chaincode invoked from chaincode are charged
+	// to the transaction which invoked it.
+	usage := txParams.ResourceUsage
+	if usage == nil {
+		usage = &ccprovider.ResourceUsage{}
+		txParams.ResourceUsage = usage
+		defer func() {
+			txParams.ResourceUsage = nil
+			h.observeResourceUsage(msg.ChannelId, namespace, usage)
+		}()
+	}
+
+	h.executionStarted()
+	defer h.executionCompleted()
+
 	txctx, err := h.TXContexts.Create(txParams)
 	if err != nil {
 		return nil, err
@@ -1184,11 +1342,83 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, namespace stri
 		h.Metrics.ExecuteTimeouts.With("chaincode", h.chaincodeID).Add(1)
 	case <-h.streamDone():
 		err = errors.New("chaincode stream terminated")
+	case <-h.handlerClosed():
+		err = h.terminationError()
+	}
+
+	if err == nil && usage.Err() != nil {
+		return nil, errors.WithMessage(usage.Err(), "transaction aborted")
 	}
 
 	return ccresp, err
 }
 
+// chargeRead records a state read returning size bytes.
+func (h *Handler) chargeRead(txContext *TransactionContext, size int) error {
+	if err := h.chargeResource(txContext, ccprovider.StateReads, 1); err != nil {
+		return err
+	}
+	return h.chargeResource(txContext, ccprovider.BytesRead, uint64(size))
+}
+
+// chargeWrite records a state write of size bytes.
+func (h *Handler) chargeWrite(txContext *TransactionContext, size int) error {
+	if err := h.chargeResource(txContext, ccprovider.Writes, 1); err != nil {
+		return err
+	}
+	return h.chargeResource(txContext, ccprovider.BytesWritten, uint64(size))
+}
+
+// chargeQueryResponse records the keys and bytes returned by a query.
+func (h *Handler) chargeQueryResponse(txContext *TransactionContext, resp *pb.QueryResponse) error {
+	var size int
+	for _, result := range resp.GetResults() {
+		size += len(result.ResultBytes)
+	}
+	if err := h.chargeResource(txContext, ccprovider.KeysScanned, uint64(len(resp.GetResults()))); err != nil {
+		return err
+	}
+	return h.chargeResource(txContext, ccprovider.BytesRead, uint64(size))
+}
+
+// chargeResource records that the transaction consumed n units of the
+// resource. Once a limit is exceeded, every subsequent request made while
+// simulating the transaction fails.
+func (h *Handler) chargeResource(txContext *TransactionContext, r ccprovider.Resource, n uint64) error {
+	usage := txContext.ResourceUsage
+	if usage == nil {
+		return nil
+	}
+	if err := usage.Err(); err != nil {
+		return err
+	}
+	return h.checkResourceLimit(txContext, r, usage.Add(r, n))
+}
+
+// checkResourceLimit returns an error, and aborts the simulation of the
+// transaction, when used exceeds the limit configured for the chaincode.
+// System chaincodes are not limited.
+func (h *Handler) checkResourceLimit(txContext *TransactionContext, r ccprovider.Resource, used uint64) error {
+	if h.BuiltinSCCs.IsSysCC(txContext.NamespaceID) {
+		return nil
+	}
+	limit := h.ResourceLimits.ForChaincode(txContext.NamespaceID).Limit(r)
+	if limit == 0 || used <= limit {
+		return nil
+	}
+
+	err := errors.Errorf("transaction exceeded the %s limit of %d for chaincode %s", r, limit, txContext.NamespaceID)
+	txContext.ResourceUsage.Abort(err)
+	h.Metrics.ResourceLimitsExceeded.With("chaincode", txContext.NamespaceID, "resource", r.String()).Add(1)
+	return err
+}
+
+func (h *Handler) observeResourceUsage(channelID, namespace string, usage *ccprovider.ResourceUsage) {
+	for _, r := range ccprovider.Resources {
+		h.Metrics.resourceHistogram(r).With("channel", channelID, "chaincode", namespace).Observe(float64(usage.Used(r)))
+	}
+}
+
 func (h *Handler) setChaincodeProposal(signedProp *pb.SignedProposal, prop *pb.Proposal, msg *pb.ChaincodeMessage) error {
 	if prop != nil && signedProp == nil {
 		return errors.New("failed getting proposal context. Signed proposal is nil")
@@ -1210,7 +1440,19 @@ func (h *Handler) getCollectionStore(channelID string) privdata.CollectionStore
 }
 
 func (h *Handler) State() State { return h.state }
-func (h *Handler) Close()       { h.TXContexts.Close() }
+
+func (h *Handler) Close() {
+	h.mutex.Lock()
+	if !h.closed {
+		h.closed = true
+		if h.closedChan != nil {
+			close(h.closedChan)
+		}
+	}
+	h.mutex.Unlock()
+
+	h.TXContexts.Close()
+}
 
 type State int
 
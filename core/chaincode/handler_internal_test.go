@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/core/container/ccintf"
 )
 
@@ -27,3 +29,15 @@ func StreamDone(h *Handler) <-chan struct{} {
 func SetStreamDoneChan(h *Handler, ch chan struct{}) {
 	h.streamDoneChan = ch
 }
+
+func SetHandlerLastActive(h *Handler, lastActive time.Time) {
+	h.lastActive = lastActive
+}
+
+func HandlerExecutionStarted(h *Handler) {
+	h.executionStarted()
+}
+
+func TerminateHandler(h *Handler, exitErr error) {
+	h.terminate(exitErr)
+}
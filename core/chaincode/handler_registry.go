@@ -8,6 +8,7 @@ package chaincode
 
 import (
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/pkg/errors"
@@ -17,16 +18,20 @@ import (
 type HandlerRegistry struct {
 	allowUnsolicitedRegistration bool // from cs.userRunsCC
 
-	mutex     sync.Mutex              // lock covering handlers and launching
-	handlers  map[string]*Handler     // chaincode cname to associated handler
-	launching map[string]*LaunchState // launching chaincodes to LaunchState
+	mutex     sync.Mutex               // lock covering handlers, launching, inProc, stopping and rotation
+	handlers  map[string][]*Handler    // chaincode cname to the handlers of its registered instances
+	launching map[string]*LaunchState  // launching chaincodes to LaunchState
+	inProc    map[string]bool          // in-process chaincodes, which are never stopped when idle
+	stopping  map[string]chan struct{} // idle chaincodes to a channel closed once they are stopped
+	rotation  int                      // used to spread transactions across equally loaded instances
 }
 
 type LaunchState struct {
-	mutex    sync.Mutex
-	notified bool
-	done     chan struct{}
-	err      error
+	mutex     sync.Mutex
+	notified  bool
+	done      chan struct{}
+	err       error
+	instances int
 }
 
 func NewLaunchState() *LaunchState {
@@ -46,6 +51,28 @@ func (l *LaunchState) Err() error {
 	return err
 }
 
+// SetInstances sets the number of instances of the chaincode which are
+// expected to register. Each instance registers its own handler.
+func (l *LaunchState) SetInstances(instances int) {
+	l.mutex.Lock()
+	l.instances = instances
+	l.mutex.Unlock()
+}
+
+// Instances returns the number of instances of the chaincode which may
+// register. It is at least one.
+func (l *LaunchState) Instances() int {
+	if l == nil {
+		return 1
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.instances < 1 {
+		return 1
+	}
+	return l.instances
+}
+
 func (l *LaunchState) Notify(err error) {
 	l.mutex.Lock()
 	if !l.notified {
@@ -59,8 +86,10 @@ func (l *LaunchState) Notify(err error) {
 // NewHandlerRegistry constructs a HandlerRegistry.
 func NewHandlerRegistry(allowUnsolicitedRegistration bool) *HandlerRegistry {
 	return &HandlerRegistry{
-		handlers:                     map[string]*Handler{},
+		handlers:                     map[string][]*Handler{},
 		launching:                    map[string]*LaunchState{},
+		inProc:                       map[string]bool{},
+		stopping:                     map[string]chan struct{}{},
 		allowUnsolicitedRegistration: allowUnsolicitedRegistration,
 	}
 }
@@ -68,18 +97,26 @@ func NewHandlerRegistry(allowUnsolicitedRegistration bool) *HandlerRegistry {
 // Launching indicates that chaincode is being launched. The LaunchState that
 // is returned provides mechanisms to determine when the operation has
 // completed and whether or not it failed. The bool indicates whether or not
-// the chaincode has already been started.
+// the chaincode has already been started. If the chaincode is being stopped
+// because it was idle, Launching blocks until it has stopped.
 func (r *HandlerRegistry) Launching(ccid string) (*LaunchState, bool) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	for r.stopping[ccid] != nil {
+		stopped := r.stopping[ccid]
+		r.mutex.Unlock()
+		<-stopped
+		r.mutex.Lock()
+	}
+
 	// launch happened or already happening
 	if launchState, ok := r.launching[ccid]; ok {
 		return launchState, true
 	}
 
 	// handler registered without going through launch
-	if _, ok := r.handlers[ccid]; ok {
+	if len(r.handlers[ccid]) > 0 {
 		launchState := NewLaunchState()
 		launchState.Notify(nil)
 		return launchState, true
@@ -91,6 +128,19 @@ func (r *HandlerRegistry) Launching(ccid string) (*LaunchState, bool) {
 	return launchState, false
 }
 
+// LaunchingInProc indicates that an in-process chaincode is being launched.
+// It behaves like Launching, but the chaincode is never stopped when idle as
+// in-process chaincodes cannot be launched again.
+func (r *HandlerRegistry) LaunchingInProc(ccid string) (*LaunchState, bool) {
+	launchState, started := r.Launching(ccid)
+
+	r.mutex.Lock()
+	r.inProc[ccid] = true
+	r.mutex.Unlock()
+
+	return launchState, started
+}
+
 // Ready indicates that the chaincode registration has completed and the
 // READY response has been sent to the chaincode.
 func (r *HandlerRegistry) Ready(ccid string) {
@@ -114,74 +164,238 @@ func (r *HandlerRegistry) Failed(ccid string, err error) {
 	}
 }
 
-// Handler retrieves the handler for a chaincode instance.
+// Handler retrieves a handler for a chaincode. When several instances of
+// the chaincode are registered, the handler of the instance with the fewest
+// transactions in flight is returned. Retrieving a handler marks it as
+// active so that it is not stopped when idle before it has been used.
 func (r *HandlerRegistry) Handler(ccid string) *Handler {
 	r.mutex.Lock()
-	h := r.handlers[ccid]
-	r.mutex.Unlock()
-	return h
+	defer r.mutex.Unlock()
+
+	handlers := r.handlers[ccid]
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	// start from a different instance each time so that transactions are
+	// spread across instances which are equally loaded
+	r.rotation++
+	var selected *Handler
+	var selectedInFlight int
+	for i := range handlers {
+		h := handlers[(r.rotation+i)%len(handlers)]
+		inFlight := h.inFlight()
+		if selected == nil || inFlight < selectedInFlight {
+			selected, selectedInFlight = h, inFlight
+		}
+	}
+
+	selected.markActive()
+	return selected
+}
+
+// Handlers returns the handlers of all registered instances of a chaincode.
+func (r *HandlerRegistry) Handlers(ccid string) []*Handler {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]*Handler(nil), r.handlers[ccid]...)
 }
 
 // Register adds a chaincode handler to the registry.
-// An error will be returned if a handler is already registered for the
-// chaincode. An error will also be returned if the chaincode has not already
-// been "launched", and unsolicited registration is not allowed.
+// An error will be returned if the handlers of all instances of the
+// chaincode expected by its launch are already registered. An error will
+// also be returned if the chaincode has not already been "launched", and
+// unsolicited registration is not allowed.
 func (r *HandlerRegistry) Register(h *Handler) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if r.handlers[h.chaincodeID] != nil {
+	handlers := r.handlers[h.chaincodeID]
+	launchState := r.launching[h.chaincodeID]
+	if len(handlers) > 0 && len(handlers) >= launchState.Instances() {
 		chaincodeLogger.Debugf("duplicate registered handler(key:%s) return error", h.chaincodeID)
 		return errors.Errorf("duplicate chaincodeID: %s", h.chaincodeID)
 	}
 
 	// This chaincode was not launched by the peer but is attempting
 	// to register. Only allowed in development mode.
-	if r.launching[h.chaincodeID] == nil && !r.allowUnsolicitedRegistration {
+	if launchState == nil && !r.allowUnsolicitedRegistration {
 		return errors.Errorf("peer will not accept external chaincode connection %s (except in dev mode)", h.chaincodeID)
 	}
 
-	r.handlers[h.chaincodeID] = h
+	r.handlers[h.chaincodeID] = append(handlers, h)
+	h.markActive()
 
-	chaincodeLogger.Debugf("registered handler complete for chaincode %s", h.chaincodeID)
+	chaincodeLogger.Debugf("registered handler complete for chaincode %s (instances: %d)", h.chaincodeID, len(handlers)+1)
 	return nil
 }
 
 // Deregister clears references to state associated specified chaincode.
-// As part of the cleanup, it closes the handler so it can cleanup any state.
-// If the registry does not contain the provided handler, an error is returned.
+// As part of the cleanup, it closes the handlers of all of its instances so
+// they can cleanup any state. If the registry does not contain a handler for
+// the chaincode, an error is returned.
 func (r *HandlerRegistry) Deregister(ccid string) error {
 	chaincodeLogger.Debugf("deregister handler: %s", ccid)
 
 	r.mutex.Lock()
-	handler := r.handlers[ccid]
+	handlers := r.handlers[ccid]
 	delete(r.handlers, ccid)
 	delete(r.launching, ccid)
 	r.mutex.Unlock()
 
-	if handler == nil {
+	if len(handlers) == 0 {
 		return errors.Errorf("could not find handler: %s", ccid)
 	}
 
-	handler.Close()
+	for _, h := range handlers {
+		h.Close()
+	}
 
 	chaincodeLogger.Debugf("deregistered handler with key: %s", ccid)
 	return nil
 }
 
+// DeregisterHandler removes the handler of a single instance of a chaincode
+// from the registry and closes it. Other instances of the chaincode remain
+// registered; once the last one has been removed, the chaincode must be
+// launched again. If the registry does not contain the handler, an error is
+// returned.
+func (r *HandlerRegistry) DeregisterHandler(h *Handler) error {
+	ccid := h.chaincodeID
+	chaincodeLogger.Debugf("deregister handler instance: %s", ccid)
+
+	r.mutex.Lock()
+	handlers := r.handlers[ccid]
+	remaining := make([]*Handler, 0, len(handlers))
+	for _, registered := range handlers {
+		if registered != h {
+			remaining = append(remaining, registered)
+		}
+	}
+	found := len(remaining) != len(handlers)
+	switch {
+	case !found:
+	case len(remaining) == 0:
+		delete(r.handlers, ccid)
+		delete(r.launching, ccid)
+	default:
+		r.handlers[ccid] = remaining
+	}
+	r.mutex.Unlock()
+
+	if !found {
+		return errors.Errorf("could not find handler: %s", ccid)
+	}
+
+	h.Close()
+
+	chaincodeLogger.Debugf("deregistered handler instance with key: %s (remaining instances: %d)", ccid, len(remaining))
+	return nil
+}
+
+// Exited indicates that the runtime of a chaincode which was launched with
+// the provided LaunchState has exited. If the chaincode has not been
+// deregistered or launched again in the meantime, the handlers of its
+// instances are deregistered and terminated so that transactions in flight
+// fail immediately with the exit error.
+func (r *HandlerRegistry) Exited(ccid string, launchState *LaunchState, exitErr error) {
+	r.mutex.Lock()
+	if r.launching[ccid] != launchState {
+		r.mutex.Unlock()
+		return
+	}
+	handlers := r.handlers[ccid]
+	delete(r.handlers, ccid)
+	delete(r.launching, ccid)
+	r.mutex.Unlock()
+
+	for _, h := range handlers {
+		h.terminate(exitErr)
+	}
+	if len(handlers) > 0 {
+		chaincodeLogger.Debugf("deregistered handlers of exited chaincode %s", ccid)
+	}
+}
+
+// Running reports whether the chaincode launched with the provided
+// LaunchState is still registered, that is, it has neither exited nor been
+// deregistered or launched again since.
+func (r *HandlerRegistry) Running(ccid string, launchState *LaunchState) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.launching[ccid] == launchState
+}
+
+// DeregisterIdle deregisters the handlers of chaincodes launched by the peer
+// whose instances have no transactions in flight and have not been used for
+// at least idleTimeout. The handlers are closed and the IDs of their
+// chaincodes are returned. A deregistered chaincode cannot be launched again
+// until Stopped has been called for it.
+func (r *HandlerRegistry) DeregisterIdle(idleTimeout time.Duration) []string {
+	var ccids []string
+	var idle []*Handler
+
+	r.mutex.Lock()
+	for ccid, handlers := range r.handlers {
+		// chaincodes which registered without being launched by the
+		// peer cannot be launched again once they are stopped
+		if r.inProc[ccid] || r.launching[ccid] == nil {
+			continue
+		}
+		if !allIdle(handlers, idleTimeout) {
+			continue
+		}
+
+		delete(r.handlers, ccid)
+		delete(r.launching, ccid)
+		r.stopping[ccid] = make(chan struct{})
+		ccids = append(ccids, ccid)
+		idle = append(idle, handlers...)
+	}
+	r.mutex.Unlock()
+
+	for _, h := range idle {
+		h.Close()
+		chaincodeLogger.Debugf("deregistered idle handler with key: %s", h.chaincodeID)
+	}
+
+	return ccids
+}
+
+func allIdle(handlers []*Handler, idleTimeout time.Duration) bool {
+	for _, h := range handlers {
+		lastActive, ok := h.idleSince()
+		if !ok || time.Since(lastActive) < idleTimeout {
+			return false
+		}
+	}
+	return true
+}
+
+// Stopped indicates that a chaincode deregistered by DeregisterIdle has been
+// stopped and may be launched again.
+func (r *HandlerRegistry) Stopped(ccid string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if stopped, ok := r.stopping[ccid]; ok {
+		close(stopped)
+		delete(r.stopping, ccid)
+	}
+}
+
 type TxQueryExecutorGetter struct {
 	HandlerRegistry *HandlerRegistry
 	CCID            string
 }
 
 func (g *TxQueryExecutorGetter) TxQueryExecutor(chainID, txID string) ledger.SimpleQueryExecutor {
-	handler := g.HandlerRegistry.Handler(g.CCID)
-	if handler == nil {
-		return nil
+	for _, handler := range g.HandlerRegistry.Handlers(g.CCID) {
+		if txContext := handler.TXContexts.Get(chainID, txID); txContext != nil {
+			return txContext.TXSimulator
+		}
 	}
-	txContext := handler.TXContexts.Get(chainID, txID)
-	if txContext == nil {
-		return nil
-	}
-	return txContext.TXSimulator
+	return nil
 }
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode_test
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -144,6 +146,48 @@ var _ = Describe("HandlerRegistry", func() {
 				Expect(h).To(BeNil())
 			})
 		})
+
+		Context("when several instances of the chaincode are registered", func() {
+			var handler2, handler3 *chaincode.Handler
+
+			BeforeEach(func() {
+				hr = chaincode.NewHandlerRegistry(false)
+				launchState, _ := hr.Launching("chaincode-id")
+				launchState.SetInstances(3)
+
+				handler2 = &chaincode.Handler{}
+				chaincode.SetHandlerChaincodeID(handler2, "chaincode-id")
+				handler3 = &chaincode.Handler{}
+				chaincode.SetHandlerChaincodeID(handler3, "chaincode-id")
+				for _, h := range []*chaincode.Handler{handler, handler2, handler3} {
+					Expect(hr.Register(h)).To(Succeed())
+				}
+			})
+
+			It("returns the instance with the fewest transactions in flight", func() {
+				chaincode.HandlerExecutionStarted(handler)
+				chaincode.HandlerExecutionStarted(handler)
+				chaincode.HandlerExecutionStarted(handler2)
+				chaincode.HandlerExecutionStarted(handler2)
+				chaincode.HandlerExecutionStarted(handler3)
+
+				for i := 0; i < 3; i++ {
+					Expect(hr.Handler("chaincode-id")).To(BeIdenticalTo(handler3))
+				}
+			})
+
+			It("spreads transactions across equally loaded instances", func() {
+				selected := map[*chaincode.Handler]bool{}
+				for i := 0; i < 3; i++ {
+					selected[hr.Handler("chaincode-id")] = true
+				}
+				Expect(selected).To(HaveLen(3))
+			})
+
+			It("returns all instances from Handlers", func() {
+				Expect(hr.Handlers("chaincode-id")).To(Equal([]*chaincode.Handler{handler, handler2, handler3}))
+			})
+		})
 	})
 
 	Describe("Register", func() {
@@ -198,6 +242,28 @@ var _ = Describe("HandlerRegistry", func() {
 				Expect(err).To(MatchError("duplicate chaincodeID: chaincode-id"))
 			})
 		})
+
+		Context("when the launch expects several instances", func() {
+			BeforeEach(func() {
+				hr = chaincode.NewHandlerRegistry(false)
+				launchState, _ := hr.Launching("chaincode-id")
+				launchState.SetInstances(2)
+			})
+
+			It("allows each instance to register", func() {
+				handler2 := &chaincode.Handler{}
+				chaincode.SetHandlerChaincodeID(handler2, "chaincode-id")
+
+				Expect(hr.Register(handler)).To(Succeed())
+				Expect(hr.Register(handler2)).To(Succeed())
+				Expect(hr.Handlers("chaincode-id")).To(HaveLen(2))
+
+				extra := &chaincode.Handler{}
+				chaincode.SetHandlerChaincodeID(extra, "chaincode-id")
+				err := hr.Register(extra)
+				Expect(err).To(MatchError("duplicate chaincodeID: chaincode-id"))
+			})
+		})
 	})
 
 	Describe("Deregister", func() {
@@ -240,6 +306,229 @@ var _ = Describe("HandlerRegistry", func() {
 			Expect(fakeResultsIterator.CloseCallCount()).To(Equal(1))
 		})
 	})
+
+	Describe("DeregisterHandler", func() {
+		var handler2 *chaincode.Handler
+
+		BeforeEach(func() {
+			handler.TXContexts = chaincode.NewTransactionContexts()
+			handler2 = &chaincode.Handler{TXContexts: chaincode.NewTransactionContexts()}
+			chaincode.SetHandlerChaincodeID(handler2, "chaincode-id")
+
+			launchState, _ := hr.Launching("chaincode-id")
+			launchState.SetInstances(2)
+			Expect(hr.Register(handler)).To(Succeed())
+			Expect(hr.Register(handler2)).To(Succeed())
+		})
+
+		It("removes only the deregistered instance", func() {
+			err := hr.DeregisterHandler(handler)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(hr.Handlers("chaincode-id")).To(Equal([]*chaincode.Handler{handler2}))
+			Expect(hr.Handler("chaincode-id")).To(BeIdenticalTo(handler2))
+			_, started := hr.Launching("chaincode-id")
+			Expect(started).To(BeTrue())
+		})
+
+		It("requires a new launch once the last instance is deregistered", func() {
+			Expect(hr.DeregisterHandler(handler)).To(Succeed())
+			Expect(hr.DeregisterHandler(handler2)).To(Succeed())
+
+			Expect(hr.Handler("chaincode-id")).To(BeNil())
+			_, started := hr.Launching("chaincode-id")
+			Expect(started).To(BeFalse())
+		})
+
+		Context("when the handler is not registered", func() {
+			BeforeEach(func() {
+				Expect(hr.DeregisterHandler(handler)).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				err := hr.DeregisterHandler(handler)
+				Expect(err).To(MatchError("could not find handler: chaincode-id"))
+				Expect(hr.Handlers("chaincode-id")).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("Exited", func() {
+		var (
+			launchState         *chaincode.LaunchState
+			fakeResultsIterator *mock.QueryResultsIterator
+		)
+
+		BeforeEach(func() {
+			fakeResultsIterator = &mock.QueryResultsIterator{}
+			transactionContexts := chaincode.NewTransactionContexts()
+			txContext, err := transactionContexts.Create(&ccprovider.TransactionParams{
+				ChannelID: "chain-id",
+				TxID:      "transaction-id",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			txContext.InitializeQueryContext("query-id", fakeResultsIterator)
+			handler.TXContexts = transactionContexts
+
+			var started bool
+			launchState, started = hr.Launching("chaincode-id")
+			Expect(started).To(BeFalse())
+
+			err = hr.Register(handler)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deregisters and closes the handler", func() {
+			hr.Exited("chaincode-id", launchState, errors.New("container exited with 1"))
+
+			Expect(hr.Handler("chaincode-id")).To(BeNil())
+			_, started := hr.Launching("chaincode-id")
+			Expect(started).To(BeFalse())
+			Expect(fakeResultsIterator.CloseCallCount()).To(Equal(1))
+		})
+
+		Context("when the chaincode has been launched again", func() {
+			BeforeEach(func() {
+				err := hr.Deregister("chaincode-id")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, started := hr.Launching("chaincode-id")
+				Expect(started).To(BeFalse())
+				err = hr.Register(handler)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not deregister the new handler", func() {
+				hr.Exited("chaincode-id", launchState, errors.New("container exited with 1"))
+				Expect(hr.Handler("chaincode-id")).To(Equal(handler))
+			})
+		})
+	})
+
+	Describe("Running", func() {
+		var launchState *chaincode.LaunchState
+
+		BeforeEach(func() {
+			var started bool
+			launchState, started = hr.Launching("chaincode-id")
+			Expect(started).To(BeFalse())
+		})
+
+		It("reports whether the launch is still registered", func() {
+			Expect(hr.Running("chaincode-id", launchState)).To(BeTrue())
+			Expect(hr.Running("chaincode-id", chaincode.NewLaunchState())).To(BeFalse())
+
+			hr.Exited("chaincode-id", launchState, errors.New("container exited with 1"))
+			Expect(hr.Running("chaincode-id", launchState)).To(BeFalse())
+		})
+	})
+
+	Describe("DeregisterIdle", func() {
+		BeforeEach(func() {
+			handler.TXContexts = chaincode.NewTransactionContexts()
+
+			_, started := hr.Launching("chaincode-id")
+			Expect(started).To(BeFalse())
+
+			err := hr.Register(handler)
+			Expect(err).NotTo(HaveOccurred())
+			chaincode.SetHandlerLastActive(handler, time.Now().Add(-time.Hour))
+		})
+
+		It("deregisters handlers which have been idle for the timeout", func() {
+			ccids := hr.DeregisterIdle(time.Minute)
+			Expect(ccids).To(ConsistOf("chaincode-id"))
+			Expect(hr.Handler("chaincode-id")).To(BeNil())
+		})
+
+		It("prevents the chaincode from launching until it has stopped", func() {
+			hr.DeregisterIdle(time.Minute)
+
+			launched := make(chan bool, 1)
+			go func() {
+				_, started := hr.Launching("chaincode-id")
+				launched <- started
+			}()
+			Consistently(launched).ShouldNot(Receive())
+
+			hr.Stopped("chaincode-id")
+			Eventually(launched).Should(Receive(BeFalse()))
+		})
+
+		Context("when the handler has been used within the timeout", func() {
+			BeforeEach(func() {
+				Expect(hr.Handler("chaincode-id")).To(Equal(handler))
+			})
+
+			It("does not deregister the handler", func() {
+				Expect(hr.DeregisterIdle(time.Minute)).To(BeEmpty())
+				Expect(hr.Handler("chaincode-id")).To(Equal(handler))
+			})
+		})
+
+		Context("when the handler is executing a transaction", func() {
+			BeforeEach(func() {
+				chaincode.HandlerExecutionStarted(handler)
+				chaincode.SetHandlerLastActive(handler, time.Now().Add(-time.Hour))
+			})
+
+			It("does not deregister the handler", func() {
+				Expect(hr.DeregisterIdle(time.Minute)).To(BeEmpty())
+			})
+		})
+
+		Context("when another instance of the chaincode is executing a transaction", func() {
+			BeforeEach(func() {
+				hr.Deregister("chaincode-id")
+				launchState, _ := hr.Launching("chaincode-id")
+				launchState.SetInstances(2)
+				handler = &chaincode.Handler{TXContexts: chaincode.NewTransactionContexts()}
+				chaincode.SetHandlerChaincodeID(handler, "chaincode-id")
+				busyHandler := &chaincode.Handler{TXContexts: chaincode.NewTransactionContexts()}
+				chaincode.SetHandlerChaincodeID(busyHandler, "chaincode-id")
+				Expect(hr.Register(handler)).To(Succeed())
+				Expect(hr.Register(busyHandler)).To(Succeed())
+
+				chaincode.HandlerExecutionStarted(busyHandler)
+				chaincode.SetHandlerLastActive(handler, time.Now().Add(-time.Hour))
+				chaincode.SetHandlerLastActive(busyHandler, time.Now().Add(-time.Hour))
+			})
+
+			It("does not deregister any instance", func() {
+				Expect(hr.DeregisterIdle(time.Minute)).To(BeEmpty())
+				Expect(hr.Handlers("chaincode-id")).To(HaveLen(2))
+			})
+		})
+
+		Context("when the chaincode was launched in process", func() {
+			BeforeEach(func() {
+				inProcHandler := &chaincode.Handler{TXContexts: chaincode.NewTransactionContexts()}
+				chaincode.SetHandlerChaincodeID(inProcHandler, "inproc-id")
+				hr.LaunchingInProc("inproc-id")
+				err := hr.Register(inProcHandler)
+				Expect(err).NotTo(HaveOccurred())
+				chaincode.SetHandlerLastActive(inProcHandler, time.Now().Add(-time.Hour))
+			})
+
+			It("does not deregister the handler", func() {
+				Expect(hr.DeregisterIdle(time.Minute)).To(ConsistOf("chaincode-id"))
+			})
+		})
+
+		Context("when the chaincode registered without being launched", func() {
+			BeforeEach(func() {
+				unsolicitedHandler := &chaincode.Handler{TXContexts: chaincode.NewTransactionContexts()}
+				chaincode.SetHandlerChaincodeID(unsolicitedHandler, "unsolicited-id")
+				err := hr.Register(unsolicitedHandler)
+				Expect(err).NotTo(HaveOccurred())
+				chaincode.SetHandlerLastActive(unsolicitedHandler, time.Now().Add(-time.Hour))
+			})
+
+			It("does not deregister the handler", func() {
+				Expect(hr.DeregisterIdle(time.Minute)).To(ConsistOf("chaincode-id"))
+			})
+		})
+	})
 })
 
 var _ = Describe("LaunchState", func() {
@@ -258,6 +547,14 @@ var _ = Describe("LaunchState", func() {
 		Expect(launchState.Err()).To(MatchError("jelly"))
 	})
 
+	It("expects a single instance unless told otherwise", func() {
+		Expect(launchState.Instances()).To(Equal(1))
+		launchState.SetInstances(3)
+		Expect(launchState.Instances()).To(Equal(3))
+		launchState.SetInstances(0)
+		Expect(launchState.Instances()).To(Equal(1))
+	})
+
 	It("can notify with a nil error", func() {
 		Expect(launchState.Done()).NotTo(BeNil())
 		Consistently(launchState.Done()).ShouldNot(BeClosed())
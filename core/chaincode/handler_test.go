@@ -46,6 +46,8 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestsCompleted      *metricsfakes.Counter
 		fakeShimRequestDuration        *metricsfakes.Histogram
 		fakeExecuteTimeouts            *metricsfakes.Counter
+		fakeTxResourceUsage            *metricsfakes.Histogram
+		fakeResourceLimitsExceeded     *metricsfakes.Counter
 		fakeCapabilites                *mock.ApplicationCapabilities
 
 		responseNotifier chan *pb.ChaincodeMessage
@@ -100,14 +102,25 @@ var _ = Describe("Handler", func() {
 		fakeShimRequestDuration.WithReturns(fakeShimRequestDuration)
 		fakeExecuteTimeouts = &metricsfakes.Counter{}
 		fakeExecuteTimeouts.WithReturns(fakeExecuteTimeouts)
+		fakeTxResourceUsage = &metricsfakes.Histogram{}
+		fakeTxResourceUsage.WithReturns(fakeTxResourceUsage)
+		fakeResourceLimitsExceeded = &metricsfakes.Counter{}
+		fakeResourceLimitsExceeded.WithReturns(fakeResourceLimitsExceeded)
 
 		builtinSCCs = map[string]struct{}{}
 
 		chaincodeMetrics := &chaincode.HandlerMetrics{
-			ShimRequestsReceived:  fakeShimRequestsReceived,
-			ShimRequestsCompleted: fakeShimRequestsCompleted,
-			ShimRequestDuration:   fakeShimRequestDuration,
-			ExecuteTimeouts:       fakeExecuteTimeouts,
+			ShimRequestsReceived:   fakeShimRequestsReceived,
+			ShimRequestsCompleted:  fakeShimRequestsCompleted,
+			ShimRequestDuration:    fakeShimRequestDuration,
+			ExecuteTimeouts:        fakeExecuteTimeouts,
+			TxStateReads:           fakeTxResourceUsage,
+			TxKeysScanned:          fakeTxResourceUsage,
+			TxBytesRead:            fakeTxResourceUsage,
+			TxBytesWritten:         fakeTxResourceUsage,
+			TxWrites:               fakeTxResourceUsage,
+			TxCallDepth:            fakeTxResourceUsage,
+			ResourceLimitsExceeded: fakeResourceLimitsExceeded,
 		}
 
 		handler = &chaincode.Handler{
@@ -533,6 +546,43 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the transaction exceeds the write limit", func() {
+			BeforeEach(func() {
+				txContext.ResourceUsage = &ccprovider.ResourceUsage{}
+				handler.ResourceLimits = &chaincode.ResourceLimitsConfig{
+					ResourceLimits: chaincode.ResourceLimits{MaxWrites: 1},
+				}
+			})
+
+			It("aborts the transaction", func() {
+				_, err := handler.HandlePutState(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = handler.HandlePutState(incomingMessage, txContext)
+				Expect(err).To(MatchError("transaction exceeded the writes limit of 1 for chaincode cc-instance-name"))
+				Expect(txContext.ResourceUsage.Err()).To(Equal(err))
+				Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(1))
+
+				Expect(fakeResourceLimitsExceeded.WithCallCount()).To(Equal(1))
+				Expect(fakeResourceLimitsExceeded.WithArgsForCall(0)).To(Equal([]string{"chaincode", "cc-instance-name", "resource", "writes"}))
+				Expect(fakeResourceLimitsExceeded.AddCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the transaction exceeds the bytes written limit", func() {
+			BeforeEach(func() {
+				txContext.ResourceUsage = &ccprovider.ResourceUsage{}
+				handler.ResourceLimits = &chaincode.ResourceLimitsConfig{
+					ResourceLimits: chaincode.ResourceLimits{MaxBytesWritten: 10},
+				}
+			})
+
+			It("returns an error without writing", func() {
+				_, err := handler.HandlePutState(incomingMessage, txContext)
+				Expect(err).To(MatchError("transaction exceeded the bytes_written limit of 10 for chaincode cc-instance-name"))
+				Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when the collection is not provided", func() {
 			It("calls SetState on the transaction simulator", func() {
 				_, err := handler.HandlePutState(incomingMessage, txContext)
@@ -1075,6 +1125,80 @@ var _ = Describe("Handler", func() {
 				})
 			})
 
+			Context("when the transaction is tracking resource usage", func() {
+				BeforeEach(func() {
+					txContext.ResourceUsage = &ccprovider.ResourceUsage{}
+				})
+
+				It("records the state read", func() {
+					_, err := handler.HandleGetState(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(txContext.ResourceUsage.Used(ccprovider.StateReads)).To(Equal(uint64(1)))
+					Expect(txContext.ResourceUsage.Used(ccprovider.BytesRead)).To(Equal(uint64(len("get-state-response"))))
+				})
+
+				Context("and the bytes read limit is exceeded", func() {
+					BeforeEach(func() {
+						handler.ResourceLimits = &chaincode.ResourceLimitsConfig{
+							ResourceLimits: chaincode.ResourceLimits{MaxBytesRead: 5},
+						}
+					})
+
+					It("returns an error", func() {
+						_, err := handler.HandleGetState(incomingMessage, txContext)
+						Expect(err).To(MatchError("transaction exceeded the bytes_read limit of 5 for chaincode cc-instance-name"))
+					})
+				})
+
+				Context("and a limit is configured for a different chaincode", func() {
+					BeforeEach(func() {
+						handler.ResourceLimits = &chaincode.ResourceLimitsConfig{
+							ResourceLimits: chaincode.ResourceLimits{MaxStateReads: 1},
+							Chaincodes: map[string]chaincode.ResourceLimits{
+								"cc-instance-name": {MaxStateReads: 2},
+							},
+						}
+					})
+
+					It("applies the limit for the chaincode", func() {
+						_, err := handler.HandleGetState(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+						_, err = handler.HandleGetState(incomingMessage, txContext)
+						Expect(err).NotTo(HaveOccurred())
+						_, err = handler.HandleGetState(incomingMessage, txContext)
+						Expect(err).To(MatchError("transaction exceeded the state_reads limit of 2 for chaincode cc-instance-name"))
+					})
+				})
+
+				Context("and the chaincode is a system chaincode", func() {
+					BeforeEach(func() {
+						builtinSCCs["cc-instance-name"] = struct{}{}
+						handler.ResourceLimits = &chaincode.ResourceLimitsConfig{
+							ResourceLimits: chaincode.ResourceLimits{MaxStateReads: 1},
+						}
+					})
+
+					It("does not limit the chaincode", func() {
+						for i := 0; i < 3; i++ {
+							_, err := handler.HandleGetState(incomingMessage, txContext)
+							Expect(err).NotTo(HaveOccurred())
+						}
+						Expect(txContext.ResourceUsage.Used(ccprovider.StateReads)).To(Equal(uint64(3)))
+					})
+				})
+
+				Context("and the transaction has been aborted", func() {
+					BeforeEach(func() {
+						txContext.ResourceUsage.Abort(errors.New("limit-exceeded"))
+					})
+
+					It("returns the error which aborted the transaction", func() {
+						_, err := handler.HandleGetState(incomingMessage, txContext)
+						Expect(err).To(MatchError("limit-exceeded"))
+					})
+				})
+			})
+
 			It("returns the response from GetState", func() {
 				resp, err := handler.HandleGetState(incomingMessage, txContext)
 				Expect(err).NotTo(HaveOccurred())
@@ -1541,6 +1665,25 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the transaction exceeds the keys scanned limit", func() {
+			BeforeEach(func() {
+				txContext.ResourceUsage = &ccprovider.ResourceUsage{}
+				handler.ResourceLimits = &chaincode.ResourceLimitsConfig{
+					ResourceLimits: chaincode.ResourceLimits{MaxKeysScanned: 1},
+				}
+				expectedQueryResponse.Results = []*pb.QueryResultBytes{
+					{ResultBytes: []byte("result-1")},
+					{ResultBytes: []byte("result-2")},
+				}
+			})
+
+			It("returns an error and cleans up the query context", func() {
+				_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("transaction exceeded the keys_scanned limit of 1 for chaincode cc-instance-name"))
+				Expect(txContext.GetQueryIterator("generated-query-id")).To(BeNil())
+			})
+		})
+
 		Context("when building the query response fails", func() {
 			BeforeEach(func() {
 				fakeQueryResponseBuilder.BuildQueryResponseReturns(nil, errors.New("garbanzo"))
@@ -2181,6 +2324,42 @@ var _ = Describe("Handler", func() {
 			Expect(proposal).To(Equal(expectedSignedProp))
 		})
 
+		Context("when the transaction is tracking resource usage", func() {
+			BeforeEach(func() {
+				txContext.ResourceUsage = &ccprovider.ResourceUsage{}
+			})
+
+			It("charges the invoked chaincode to the same transaction", func() {
+				fakeInvoker.InvokeStub = func(txParams *ccprovider.TransactionParams, _ string, _ *pb.ChaincodeInput) (*pb.ChaincodeMessage, error) {
+					Expect(txParams.ResourceUsage).To(BeIdenticalTo(txContext.ResourceUsage))
+					Expect(txParams.ResourceUsage.EnterCall()).To(Equal(uint64(2)))
+					txParams.ResourceUsage.ExitCall()
+					return responseMessage, nil
+				}
+				_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeInvoker.InvokeCallCount()).To(Equal(1))
+				Expect(txContext.ResourceUsage.Used(ccprovider.CallDepth)).To(Equal(uint64(2)))
+				Expect(txContext.ResourceUsage.EnterCall()).To(Equal(uint64(1)))
+			})
+
+			Context("and the call depth limit is exceeded", func() {
+				BeforeEach(func() {
+					txContext.ResourceUsage.EnterCall()
+					handler.ResourceLimits = &chaincode.ResourceLimitsConfig{
+						ResourceLimits: chaincode.ResourceLimits{MaxCallDepth: 1},
+					}
+				})
+
+				It("returns an error without invoking the chaincode", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).To(MatchError("transaction exceeded the call_depth limit of 1 for chaincode cc-instance-name"))
+					Expect(fakeInvoker.InvokeCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the target channel is different from the context", func() {
 			BeforeEach(func() {
 				request = &pb.ChaincodeSpec{
@@ -2449,6 +2628,55 @@ var _ = Describe("Handler", func() {
 			Expect(resp).To(Equal(&pb.ChaincodeMessage{Txid: "a-transaction-id"}))
 		})
 
+		It("tracks the resources used by the transaction", func() {
+			fakeContextRegistry.CreateStub = func(txParams *ccprovider.TransactionParams) (*chaincode.TransactionContext, error) {
+				Expect(txParams.ResourceUsage).NotTo(BeNil())
+				txParams.ResourceUsage.Add(ccprovider.StateReads, 3)
+				return txContext, nil
+			}
+			close(responseNotifier)
+			_, err := handler.Execute(txParams, "chaincode-name", incomingMessage, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(txParams.ResourceUsage).To(BeNil())
+			Expect(fakeTxResourceUsage.WithCallCount()).To(Equal(6))
+			Expect(fakeTxResourceUsage.WithArgsForCall(0)).To(Equal([]string{"channel", "channel-id", "chaincode", "chaincode-name"}))
+			Expect(fakeTxResourceUsage.ObserveCallCount()).To(Equal(6))
+			Expect(fakeTxResourceUsage.ObserveArgsForCall(0)).To(Equal(3.0))
+		})
+
+		Context("when the transaction was invoked by chaincode", func() {
+			BeforeEach(func() {
+				txParams.ResourceUsage = &ccprovider.ResourceUsage{}
+			})
+
+			It("leaves the resource usage to the invoking transaction", func() {
+				close(responseNotifier)
+				_, err := handler.Execute(txParams, "chaincode-name", incomingMessage, time.Second)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(txParams.ResourceUsage).NotTo(BeNil())
+				Expect(fakeTxResourceUsage.ObserveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a resource limit aborts the transaction", func() {
+			BeforeEach(func() {
+				fakeContextRegistry.CreateStub = func(txParams *ccprovider.TransactionParams) (*chaincode.TransactionContext, error) {
+					txParams.ResourceUsage.Abort(errors.New("limit-exceeded"))
+					return txContext, nil
+				}
+			})
+
+			It("returns an error instead of the chaincode response", func() {
+				Eventually(responseNotifier).Should(BeSent(&pb.ChaincodeMessage{Txid: "a-transaction-id"}))
+
+				resp, err := handler.Execute(txParams, "chaincode-name", incomingMessage, time.Second)
+				Expect(err).To(MatchError("transaction aborted: limit-exceeded"))
+				Expect(resp).To(BeNil())
+			})
+		})
+
 		It("deletes the transaction context", func() {
 			close(responseNotifier)
 			handler.Execute(txParams, "chaincode-name", incomingMessage, time.Second)
@@ -2556,6 +2784,34 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the chaincode exits unexpectedly", func() {
+			It("returns an error", func() {
+				errCh := make(chan error, 1)
+				go func() {
+					_, err := handler.Execute(txParams, "chaincode-name", incomingMessage, time.Hour)
+					errCh <- err
+				}()
+				Consistently(errCh).ShouldNot(Receive())
+
+				chaincode.TerminateHandler(handler, errors.New("container exited with 137"))
+				Eventually(errCh).Should(Receive(MatchError("chaincode test-handler-name:1.0 terminated unexpectedly: container exited with 137")))
+			})
+		})
+
+		Context("when the handler is closed", func() {
+			It("returns an error", func() {
+				errCh := make(chan error, 1)
+				go func() {
+					_, err := handler.Execute(txParams, "chaincode-name", incomingMessage, time.Hour)
+					errCh <- err
+				}()
+				Consistently(errCh).ShouldNot(Receive())
+
+				handler.Close()
+				Eventually(errCh).Should(Receive(MatchError("chaincode test-handler-name:1.0 is no longer registered")))
+			})
+		})
+
 		Context("when execute times out", func() {
 			It("returns an error", func() {
 				errCh := make(chan error, 1)
@@ -2749,6 +3005,26 @@ var _ = Describe("Handler", func() {
 			Eventually(streamDoneChan).Should(BeClosed())
 		})
 
+		It("deregisters the handler when the stream ends", func() {
+			fakeChatStream.RecvReturns(nil, io.EOF)
+			handler.ProcessStream(fakeChatStream)
+
+			Expect(fakeHandlerRegistry.DeregisterHandlerCallCount()).To(Equal(1))
+			Expect(fakeHandlerRegistry.DeregisterHandlerArgsForCall(0)).To(BeIdenticalTo(handler))
+		})
+
+		Context("when the handler has already been closed", func() {
+			BeforeEach(func() {
+				handler.Close()
+				fakeChatStream.RecvReturns(nil, io.EOF)
+			})
+
+			It("does not deregister the handler", func() {
+				handler.ProcessStream(fakeChatStream)
+				Expect(fakeHandlerRegistry.DeregisterHandlerCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when receive fails with an io.EOF", func() {
 			BeforeEach(func() {
 				fakeChatStream.RecvReturns(nil, io.EOF)
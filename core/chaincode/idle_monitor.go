@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"time"
+)
+
+// IdleRegistry tracks the chaincode handlers which have not been used recently.
+type IdleRegistry interface {
+	DeregisterIdle(idleTimeout time.Duration) []string
+	Stopped(ccid string)
+}
+
+// RuntimeStopper stops chaincode runtimes.
+type RuntimeStopper interface {
+	Stop(ccid string) error
+}
+
+// IdleMonitor stops the runtimes of chaincodes which have not been used
+// for the configured idle timeout. A stopped chaincode is launched again
+// the next time it is invoked.
+type IdleMonitor struct {
+	Registry    IdleRegistry
+	Stopper     RuntimeStopper
+	IdleTimeout time.Duration
+	Metrics     *LaunchMetrics
+}
+
+// Run periodically stops idle chaincodes. It never returns.
+func (m *IdleMonitor) Run() {
+	ticker := time.NewTicker(m.checkInterval())
+	defer ticker.Stop()
+
+	for range ticker.C {
+		m.StopIdle()
+	}
+}
+
+// StopIdle deregisters the handlers of all idle chaincodes and stops their
+// runtimes.
+func (m *IdleMonitor) StopIdle() {
+	for _, ccid := range m.Registry.DeregisterIdle(m.IdleTimeout) {
+		chaincodeLogger.Infof("stopping chaincode %s as it has been idle for at least %s", ccid, m.IdleTimeout)
+		if err := m.Stopper.Stop(ccid); err != nil {
+			chaincodeLogger.Warningf("failed to stop idle chaincode %s: %s", ccid, err)
+		}
+		m.Registry.Stopped(ccid)
+		m.Metrics.IdleStops.With("chaincode", ccid).Add(1)
+	}
+}
+
+// checkInterval returns how often to check for idle chaincodes so that an
+// idle chaincode is stopped no later than half the idle timeout after it
+// became idle.
+func (m *IdleMonitor) checkInterval() time.Duration {
+	interval := m.IdleTimeout / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("IdleMonitor", func() {
+	var (
+		fakeRegistry  *mock.IdleRegistry
+		fakeStopper   *mock.RuntimeStopper
+		fakeIdleStops *metricsfakes.Counter
+
+		idleMonitor *chaincode.IdleMonitor
+	)
+
+	BeforeEach(func() {
+		fakeRegistry = &mock.IdleRegistry{}
+		fakeRegistry.DeregisterIdleReturns([]string{"ccid1", "ccid2"})
+		fakeStopper = &mock.RuntimeStopper{}
+		fakeIdleStops = &metricsfakes.Counter{}
+		fakeIdleStops.WithReturns(fakeIdleStops)
+
+		idleMonitor = &chaincode.IdleMonitor{
+			Registry:    fakeRegistry,
+			Stopper:     fakeStopper,
+			IdleTimeout: 10 * time.Minute,
+			Metrics: &chaincode.LaunchMetrics{
+				IdleStops: fakeIdleStops,
+			},
+		}
+	})
+
+	Describe("StopIdle", func() {
+		It("stops the idle chaincodes", func() {
+			idleMonitor.StopIdle()
+
+			Expect(fakeRegistry.DeregisterIdleCallCount()).To(Equal(1))
+			Expect(fakeRegistry.DeregisterIdleArgsForCall(0)).To(Equal(10 * time.Minute))
+
+			Expect(fakeStopper.StopCallCount()).To(Equal(2))
+			Expect(fakeStopper.StopArgsForCall(0)).To(Equal("ccid1"))
+			Expect(fakeStopper.StopArgsForCall(1)).To(Equal("ccid2"))
+
+			Expect(fakeRegistry.StoppedCallCount()).To(Equal(2))
+			Expect(fakeRegistry.StoppedArgsForCall(0)).To(Equal("ccid1"))
+			Expect(fakeRegistry.StoppedArgsForCall(1)).To(Equal("ccid2"))
+		})
+
+		It("records the idle stops", func() {
+			idleMonitor.StopIdle()
+
+			Expect(fakeIdleStops.WithCallCount()).To(Equal(2))
+			Expect(fakeIdleStops.WithArgsForCall(0)).To(Equal([]string{"chaincode", "ccid1"}))
+			Expect(fakeIdleStops.WithArgsForCall(1)).To(Equal([]string{"chaincode", "ccid2"}))
+			Expect(fakeIdleStops.AddCallCount()).To(Equal(2))
+		})
+
+		Context("when stopping a chaincode fails", func() {
+			BeforeEach(func() {
+				fakeStopper.StopReturnsOnCall(0, errors.New("boom"))
+			})
+
+			It("still allows the chaincode to be launched again", func() {
+				idleMonitor.StopIdle()
+
+				Expect(fakeStopper.StopCallCount()).To(Equal(2))
+				Expect(fakeRegistry.StoppedCallCount()).To(Equal(2))
+				Expect(fakeRegistry.StoppedArgsForCall(0)).To(Equal("ccid1"))
+			})
+		})
+
+		Context("when no chaincodes are idle", func() {
+			BeforeEach(func() {
+				fakeRegistry.DeregisterIdleReturns(nil)
+			})
+
+			It("does not stop any chaincode", func() {
+				idleMonitor.StopIdle()
+				Expect(fakeStopper.StopCallCount()).To(Equal(0))
+				Expect(fakeIdleStops.AddCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
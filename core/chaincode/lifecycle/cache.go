@@ -225,12 +225,7 @@ func (c *Cache) HandleChaincodeInstalled(md *persistence.ChaincodePackageMetadat
 }
 
 func (c *Cache) handleChaincodeInstalledWhileLocked(initializing bool, md *persistence.ChaincodePackageMetadata, packageID string) {
-	// it would be nice to get this value from the serialization package, but it was not obvious
-	// how to expose this in a nice way, so we manually compute it.
-	encodedCCHash := protoutil.MarshalOrPanic(&lb.StateData{
-		Type: &lb.StateData_String_{String_: packageID},
-	})
-	hashOfCCHash := string(util.ComputeSHA256(encodedCCHash))
+	hashOfCCHash := hashOfPackageIDHash(packageID)
 	localChaincode, ok := c.localChaincodes[hashOfCCHash]
 	if !ok {
 		localChaincode = &LocalChaincode{
@@ -259,6 +254,44 @@ func (c *Cache) handleChaincodeInstalledWhileLocked(initializing bool, md *persi
 	}
 }
 
+// HandleChaincodeUninstalled should be invoked whenever a chaincode is uninstalled. The
+// chaincode definitions which referenced the chaincode are no longer considered installed.
+func (c *Cache) HandleChaincodeUninstalled(packageID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	hashOfCCHash := hashOfPackageIDHash(packageID)
+	localChaincode, ok := c.localChaincodes[hashOfCCHash]
+	if !ok || localChaincode.Info == nil {
+		return
+	}
+
+	localChaincode.Info = nil
+	for channelID, channelCache := range localChaincode.References {
+		for chaincodeName, cachedChaincode := range channelCache {
+			cachedChaincode.InstallInfo = nil
+			logger.Infof("Uninstalled chaincode with package ID '%s' no longer available on channel %s for chaincode definition %s:%s", packageID, channelID, chaincodeName, cachedChaincode.Definition.EndorsementInfo.Version)
+		}
+	}
+
+	if len(localChaincode.References) == 0 {
+		delete(c.localChaincodes, hashOfCCHash)
+	}
+
+	c.handleMetadataUpdates(localChaincode)
+}
+
+// hashOfPackageIDHash returns the key of the local chaincode with the given package ID
+// in the cache, which is the hash of the proto encoded package ID. It would be nice to
+// get this value from the serialization package, but it was not obvious how to expose
+// this in a nice way, so we manually compute it.
+func hashOfPackageIDHash(packageID string) string {
+	encodedCCHash := protoutil.MarshalOrPanic(&lb.StateData{
+		Type: &lb.StateData_String_{String_: packageID},
+	})
+	return string(util.ComputeSHA256(encodedCCHash))
+}
+
 // HandleStateUpdates is required to implement the ledger state listener interface.  It applies
 // any state updates to the cache.
 func (c *Cache) HandleStateUpdates(trigger *ledger.StateUpdateTrigger) error {
@@ -337,6 +337,87 @@ var _ = Describe("Cache", func() {
 		})
 	})
 
+	Describe("HandleChaincodeUninstalled", func() {
+		BeforeEach(func() {
+			channelCache.Chaincodes["chaincode-name"].InstallInfo = &lifecycle.ChaincodeInstallInfo{
+				Label:     "chaincode-label",
+				PackageID: "packageID",
+			}
+		})
+
+		It("marks the referencing chaincode definitions as not installed", func() {
+			c.HandleChaincodeUninstalled("packageID")
+			Expect(channelCache.Chaincodes["chaincode-name"].InstallInfo).To(BeNil())
+
+			_, err := c.GetInstalledChaincode("packageID")
+			Expect(err).To(MatchError("could not find chaincode with package id 'packageID'"))
+			Expect(c.ListInstalledChaincodes()).To(BeEmpty())
+
+			localInfo, err := c.ChaincodeInfo("channel-id", "chaincode-name")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(localInfo.InstallInfo).To(BeNil())
+		})
+
+		It("updates the metadata", func() {
+			c.HandleChaincodeUninstalled("packageID")
+			Expect(fakeMetadataHandler.UpdateMetadataCallCount()).To(Equal(1))
+			channel, metadata := fakeMetadataHandler.UpdateMetadataArgsForCall(0)
+			Expect(channel).To(Equal("channel-id"))
+			Expect(metadata).To(ContainElement(chaincode.Metadata{
+				Name:              "chaincode-name",
+				Version:           "3",
+				Policy:            []byte("validation-parameter"),
+				CollectionsConfig: &pb.CollectionConfigPackage{},
+				Approved:          true,
+				Installed:         false,
+			}))
+		})
+
+		It("allows the chaincode to be installed again", func() {
+			c.HandleChaincodeUninstalled("packageID")
+			c.HandleChaincodeInstalled(&persistence.ChaincodePackageMetadata{
+				Type:  "cc-type",
+				Path:  "cc-path",
+				Label: "chaincode-label",
+			}, "packageID")
+
+			installedChaincode, err := c.GetInstalledChaincode("packageID")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(installedChaincode.References).To(HaveLen(2))
+			Expect(channelCache.Chaincodes["chaincode-name"].InstallInfo).To(Equal(&lifecycle.ChaincodeInstallInfo{
+				Type:      "cc-type",
+				Path:      "cc-path",
+				Label:     "chaincode-label",
+				PackageID: "packageID",
+			}))
+		})
+
+		Context("when the chaincode is not referenced by any chaincode definition", func() {
+			BeforeEach(func() {
+				c.HandleChaincodeInstalled(&persistence.ChaincodePackageMetadata{
+					Type:  "cc-type",
+					Path:  "cc-path",
+					Label: "other-label",
+				}, "other-packageID")
+			})
+
+			It("removes the chaincode from the cache", func() {
+				c.HandleChaincodeUninstalled("other-packageID")
+				_, err := c.GetInstalledChaincode("other-packageID")
+				Expect(err).To(MatchError("could not find chaincode with package id 'other-packageID'"))
+				Expect(c.ListInstalledChaincodes()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the chaincode is not installed", func() {
+			It("does nothing", func() {
+				c.HandleChaincodeUninstalled("notinstalled-packageID")
+				Expect(c.ListInstalledChaincodes()).To(HaveLen(1))
+				Expect(fakeMetadataHandler.UpdateMetadataCallCount()).To(Equal(0))
+			})
+		})
+	})
+
 	Describe("InitializeLocalChaincodes", func() {
 		It("loads the already installed chaincodes into the cache", func() {
 			Expect(channelCache.Chaincodes["chaincode-name"].InstallInfo).To(BeNil())
@@ -9,7 +9,9 @@ package lifecycle
 import (
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/chaincode/implicitcollection"
 	validatorstate "github.com/hyperledger/fabric/core/handlers/validation/api/state"
 	"github.com/hyperledger/fabric/core/ledger"
 
@@ -54,6 +56,26 @@ func (cls *ChaincodePublicLedgerShim) GetStateRange(prefix string) (map[string][
 	return StateIteratorToMap(&ChaincodeResultIteratorShim{ResultsIterator: itr})
 }
 
+// GetStateHistory returns the modifications of the key recorded by the history
+// database of the ledger, newest first.
+func (cls *ChaincodePublicLedgerShim) GetStateHistory(key string) ([]*queryresult.KeyModification, error) {
+	itr, err := cls.GetHistoryForKey(key)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not get history iterator")
+	}
+	defer itr.Close()
+
+	var result []*queryresult.KeyModification
+	for itr.HasNext() {
+		entry, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not iterate over history")
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
 type ChaincodeResultIteratorShim struct {
 	ResultsIterator shim.StateQueryIteratorInterface
 }
@@ -180,6 +202,74 @@ func (pqes *PrivateQueryExecutorShim) CollectionName() string {
 	return pqes.Collection
 }
 
+// PrivateDataQueryExecutor reads the private data of the collections of a ledger
+type PrivateDataQueryExecutor interface {
+	GetPrivateData(namespace, collection, key string) ([]byte, error)
+	GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey string) (commonledger.ResultsIterator, error)
+}
+
+// PrivateDataQueryExecutorShim implements the ReadableState and RangeableState interfaces
+// for the private data of a collection, based on an underlying PrivateDataQueryExecutor
+type PrivateDataQueryExecutorShim struct {
+	Namespace     string
+	Collection    string
+	QueryExecutor PrivateDataQueryExecutor
+}
+
+func (pdqes *PrivateDataQueryExecutorShim) GetState(key string) ([]byte, error) {
+	return pdqes.QueryExecutor.GetPrivateData(pdqes.Namespace, pdqes.Collection, key)
+}
+
+func (pdqes *PrivateDataQueryExecutorShim) GetStateRange(prefix string) (map[string][]byte, error) {
+	itr, err := pdqes.QueryExecutor.GetPrivateDataRangeScanIterator(pdqes.Namespace, pdqes.Collection, prefix, prefix+"\x7f")
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not get private data iterator")
+	}
+	return StateIteratorToMap(&ResultsIteratorShim{ResultsIterator: itr})
+}
+
+// ChannelLedgers provides the ledgers of the channels joined by the peer
+type ChannelLedgers interface {
+	GetChannelsInfo() []*pb.ChannelInfo
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// LedgerChannelStates implements the ChannelStates interface based on the
+// ledgers of the channels joined by the peer
+type LedgerChannelStates struct {
+	ChannelLedgers ChannelLedgers
+	OrgMSPID       string
+}
+
+func (lcs *LedgerChannelStates) ChannelIDs() []string {
+	var channelIDs []string
+	for _, info := range lcs.ChannelLedgers.GetChannelsInfo() {
+		channelIDs = append(channelIDs, info.ChannelId)
+	}
+	return channelIDs
+}
+
+func (lcs *LedgerChannelStates) LifecycleStates(channelID string) (ReadableState, RangeableReadableState, func(), error) {
+	l := lcs.ChannelLedgers.GetLedger(channelID)
+	if l == nil {
+		return nil, nil, nil, errors.Errorf("could not find ledger for channel '%s'", channelID)
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return nil, nil, nil, errors.WithMessage(err, "could not get query executor")
+	}
+	publicState := &SimpleQueryExecutorShim{
+		Namespace:           LifecycleNamespace,
+		SimpleQueryExecutor: qe,
+	}
+	orgState := &PrivateDataQueryExecutorShim{
+		Namespace:     LifecycleNamespace,
+		Collection:    implicitcollection.NameForOrg(lcs.OrgMSPID),
+		QueryExecutor: qe,
+	}
+	return publicState, orgState, qe.Done, nil
+}
+
 // DummyQueryExecutorShim implements the ReadableState interface. It is
 // used to ensure channel-less system chaincode calls don't panic and return
 // and error when an invalid operation is attempted (i.e. an InstallChaincode
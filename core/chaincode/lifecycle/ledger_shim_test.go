@@ -262,6 +262,77 @@ var _ = Describe("LedgerShims", func() {
 		})
 	})
 
+	Describe("PrivateDataQueryExecutorShim", func() {
+		var (
+			pdqes                        *lifecycle.PrivateDataQueryExecutorShim
+			fakePrivateDataQueryExecutor *mock.PrivateDataQueryExecutor
+		)
+
+		BeforeEach(func() {
+			fakePrivateDataQueryExecutor = &mock.PrivateDataQueryExecutor{}
+			pdqes = &lifecycle.PrivateDataQueryExecutorShim{
+				Namespace:     "cc-namespace",
+				Collection:    "collection",
+				QueryExecutor: fakePrivateDataQueryExecutor,
+			}
+		})
+
+		Describe("GetState", func() {
+			BeforeEach(func() {
+				fakePrivateDataQueryExecutor.GetPrivateDataReturns([]byte("fake-state"), fmt.Errorf("fake-error"))
+			})
+
+			It("passes through to the query executor", func() {
+				res, err := pdqes.GetState("fake-key")
+				Expect(res).To(Equal([]byte("fake-state")))
+				Expect(err).To(MatchError("fake-error"))
+				namespace, collection, key := fakePrivateDataQueryExecutor.GetPrivateDataArgsForCall(0)
+				Expect(namespace).To(Equal("cc-namespace"))
+				Expect(collection).To(Equal("collection"))
+				Expect(key).To(Equal("fake-key"))
+			})
+		})
+
+		Describe("GetStateRange", func() {
+			var resItr *mock.ResultsIterator
+
+			BeforeEach(func() {
+				resItr = &mock.ResultsIterator{}
+				resItr.NextReturnsOnCall(0, &queryresult.KV{
+					Key:   "fake-key",
+					Value: []byte("key-value"),
+				}, nil)
+				fakePrivateDataQueryExecutor.GetPrivateDataRangeScanIteratorReturns(resItr, nil)
+			})
+
+			It("passes through to the query executor", func() {
+				res, err := pdqes.GetStateRange("fake-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(map[string][]byte{
+					"fake-key": []byte("key-value"),
+				}))
+
+				Expect(fakePrivateDataQueryExecutor.GetPrivateDataRangeScanIteratorCallCount()).To(Equal(1))
+				namespace, collection, start, end := fakePrivateDataQueryExecutor.GetPrivateDataRangeScanIteratorArgsForCall(0)
+				Expect(namespace).To(Equal("cc-namespace"))
+				Expect(collection).To(Equal("collection"))
+				Expect(start).To(Equal("fake-key"))
+				Expect(end).To(Equal("fake-key\x7f"))
+			})
+
+			Context("when getting the private data iterator fails", func() {
+				BeforeEach(func() {
+					fakePrivateDataQueryExecutor.GetPrivateDataRangeScanIteratorReturns(nil, fmt.Errorf("fake-range-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := pdqes.GetStateRange("fake-key")
+					Expect(err).To(MatchError("could not get private data iterator: fake-range-error"))
+				})
+			})
+		})
+	})
+
 	Describe("PrivateQueryExecutorShim", func() {
 		var (
 			pqes                    *lifecycle.PrivateQueryExecutorShim
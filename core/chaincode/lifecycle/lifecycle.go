@@ -9,9 +9,13 @@ package lifecycle
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/hyperledger/fabric-protos-go/msp"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
@@ -24,6 +28,7 @@ import (
 	"github.com/hyperledger/fabric/protoutil"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/pkg/errors"
 )
 
@@ -150,6 +155,16 @@ type ChaincodeDefinition struct {
 	Collections     *pb.CollectionConfigPackage
 }
 
+// CommittedChaincodeDefinition is a chaincode definition along with the
+// transaction which committed it, and the package ID approved for it by the
+// peer's org, if any.
+type CommittedChaincodeDefinition struct {
+	Definition *ChaincodeDefinition
+	PackageID  string
+	TxID       string
+	Timestamp  *timestamp.Timestamp
+}
+
 type ApprovedChaincodeDefinition struct {
 	Sequence        int64
 	EndorsementInfo *lb.ChaincodeEndorsementInfo
@@ -216,6 +231,36 @@ type InstallListener interface {
 	HandleChaincodeInstalled(md *persistence.ChaincodePackageMetadata, packageID string)
 }
 
+//go:generate counterfeiter -o mock/uninstall_listener.go --fake-name UninstallListener . UninstallListener
+type UninstallListener interface {
+	HandleChaincodeUninstalled(packageID string)
+}
+
+//go:generate counterfeiter -o mock/build_remover.go --fake-name BuildRemover . BuildRemover
+
+// BuildRemover removes the build output of a chaincode
+type BuildRemover interface {
+	RemoveBuild(ccid string) error
+}
+
+//go:generate counterfeiter -o mock/channel_states.go --fake-name ChannelStates . ChannelStates
+
+// ChannelStates provides the lifecycle states of the channels joined by the peer
+type ChannelStates interface {
+	// ChannelIDs returns the IDs of the channels joined by the peer
+	ChannelIDs() []string
+	// LifecycleStates returns the public lifecycle state of the given channel and the lifecycle
+	// state in the implicit collection of the org of the peer, along with a function which must
+	// be called once the states are no longer used
+	LifecycleStates(channelID string) (publicState ReadableState, orgState RangeableReadableState, done func(), err error)
+}
+
+// RangeableReadableState is a state which supports both reading keys and range queries
+type RangeableReadableState interface {
+	ReadableState
+	RangeableState
+}
+
 //go:generate counterfeiter -o mock/installed_chaincodes_lister.go --fake-name InstalledChaincodesLister . InstalledChaincodesLister
 type InstalledChaincodesLister interface {
 	ListInstalledChaincodes() []*chaincode.InstalledChaincode
@@ -305,11 +350,16 @@ func (r *Resources) LifecycleEndorsementPolicyAsBytes(channelID string) ([]byte,
 type ExternalFunctions struct {
 	Resources                 *Resources
 	InstallListener           InstallListener
+	UninstallListener         UninstallListener
 	InstalledChaincodesLister InstalledChaincodesLister
+	ChannelStates             ChannelStates
 	ChaincodeBuilder          ChaincodeBuilder
+	BuildRemover              BuildRemover
+	ChaincodeLauncher         ChaincodeLauncher
+	PackageVerifier           PackageVerifier
 	BuildRegistry             *container.BuildRegistry
 	mutex                     sync.Mutex
-	BuildLocks                map[string]sync.Mutex
+	BuildLocks                map[string]*sync.Mutex
 }
 
 // CheckCommitReadiness takes a chaincode definition, checks that
@@ -638,6 +688,112 @@ func (ef *ExternalFunctions) QueryChaincodeDefinition(name string, publicState R
 	return definedChaincode, nil
 }
 
+// QueryChaincodeDefinitionHistory returns every definition committed for the
+// chaincode, in order of sequence, as recorded by the history database of the
+// ledger. Only the fields of a definition are recorded when they change, so
+// the definition committed at each sequence is rebuilt from the fields written
+// up to and including the transaction which committed that sequence. Note that
+// definitions committed before the block the ledger was bootstrapped from are
+// not available.
+func (ef *ExternalFunctions) QueryChaincodeDefinitionHistory(name string, publicState HistoricalState, orgState ReadableState) ([]*CommittedChaincodeDefinition, error) {
+	_, fields, err := ef.Resources.Serializer.SerializableChecks(&ChaincodeDefinition{})
+	if err != nil {
+		return nil, err
+	}
+
+	// the values written to each field, indexed by the writing transaction
+	fieldValues := map[string]map[string][]byte{}
+	var sequenceHistory []*queryresult.KeyModification
+	for _, field := range fields {
+		history, err := publicState.GetStateHistory(FieldKey(NamespacesName, name, field))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not get history of field %s for namespace %s", field, name)
+		}
+		if field == "Sequence" {
+			sequenceHistory = history
+		}
+
+		fieldValues[field] = map[string][]byte{}
+		for _, modification := range history {
+			if !modification.IsDelete {
+				fieldValues[field][modification.TxId] = modification.Value
+			}
+		}
+	}
+
+	if len(sequenceHistory) == 0 {
+		return nil, ErrNamespaceNotDefined{Namespace: name}
+	}
+
+	metadata := &lb.StateMetadata{
+		Datatype: ChaincodeDefinitionType,
+		Fields:   fields,
+	}
+	state := stateSnapshot{}
+	var definitions []*CommittedChaincodeDefinition
+	// the history is ordered newest first
+	for i := len(sequenceHistory) - 1; i >= 0; i-- {
+		modification := sequenceHistory[i]
+		if modification.IsDelete {
+			continue
+		}
+		for _, field := range fields {
+			if value, ok := fieldValues[field][modification.TxId]; ok {
+				state[FieldKey(NamespacesName, name, field)] = value
+			}
+		}
+
+		definition := &ChaincodeDefinition{}
+		if err := ef.Resources.Serializer.Deserialize(NamespacesName, name, metadata, definition, state); err != nil {
+			return nil, errors.WithMessagef(err, "could not deserialize namespace %s as chaincode at transaction %s", name, modification.TxId)
+		}
+
+		packageID, err := ef.approvedPackageID(name, definition.Sequence, orgState)
+		if err != nil {
+			return nil, err
+		}
+
+		definitions = append(definitions, &CommittedChaincodeDefinition{
+			Definition: definition,
+			PackageID:  packageID,
+			TxID:       modification.TxId,
+			Timestamp:  modification.Timestamp,
+		})
+	}
+
+	logger.Debugf("Successfully queried the history of chaincode name '%s' with %d definitions", name, len(definitions))
+
+	return definitions, nil
+}
+
+// approvedPackageID returns the package ID approved by the org for the
+// chaincode definition with the given sequence, or an empty package ID if
+// the org has not approved a package for it.
+func (ef *ExternalFunctions) approvedPackageID(name string, sequence int64, orgState ReadableState) (string, error) {
+	privateName := fmt.Sprintf("%s#%d", name, sequence)
+	metadata, ok, err := ef.Resources.Serializer.DeserializeMetadata(ChaincodeSourcesName, privateName, orgState)
+	if err != nil {
+		return "", errors.WithMessagef(err, "could not deserialize chaincode-source metadata for %s", privateName)
+	}
+	if !ok || metadata.Datatype != ChaincodeLocalPackageType {
+		return "", nil
+	}
+
+	ccLocalPackage := &ChaincodeLocalPackage{}
+	if err := ef.Resources.Serializer.Deserialize(ChaincodeSourcesName, privateName, metadata, ccLocalPackage, orgState); err != nil {
+		return "", errors.WithMessagef(err, "could not deserialize chaincode package for %s", privateName)
+	}
+	return ccLocalPackage.PackageID, nil
+}
+
+// stateSnapshot is a ReadableState holding the values of keys at some point
+// in the history of the ledger.
+type stateSnapshot map[string][]byte
+
+func (s stateSnapshot) GetState(key string) ([]byte, error) {
+	return s[key], nil
+}
+
 // QueryOrgApprovals returns a map containing the orgs whose orgStates were
 // provided and whether or not they have approved a chaincode definition with
 // the specified parameters.
@@ -658,8 +814,22 @@ func (ef *ExternalFunctions) QueryOrgApprovals(name string, cd *ChaincodeDefinit
 }
 
 // InstallChaincode installs a given chaincode to the peer's chaincode store.
-// It returns the hash to reference the chaincode by or an error on failure.
+// A signed chaincode package is verified and the chaincode package it wraps
+// is installed. It returns the hash to reference the chaincode by or an error
+// on failure.
 func (ef *ExternalFunctions) InstallChaincode(chaincodeInstallPackage []byte) (*chaincode.InstalledChaincode, error) {
+	signedPkg, err := persistence.ParseSignedChaincodePackage(chaincodeInstallPackage)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not parse as a chaincode install package")
+	}
+
+	if ef.PackageVerifier != nil {
+		if err := ef.PackageVerifier.Verify(signedPkg); err != nil {
+			return nil, errors.WithMessage(err, "could not verify chaincode install package")
+		}
+	}
+	chaincodeInstallPackage = signedPkg.Package
+
 	// Let's validate that the chaincodeInstallPackage is at least well formed before writing it
 	pkg, err := ef.Resources.PackageParser.Parse(chaincodeInstallPackage)
 	if err != nil {
@@ -709,20 +879,150 @@ func (ef *ExternalFunctions) InstallChaincode(chaincodeInstallPackage []byte) (*
 	}, nil
 }
 
+// UninstallChaincode removes the installed chaincode with the given package ID from the
+// peer's chaincode store along with its build output, after stopping any running instance
+// of it. Unless forced, a chaincode which is referenced by a chaincode definition on any of
+// the channels joined by the peer, whether committed or only approved by the org of the peer,
+// is not uninstalled. It returns the installed chaincode, including the references to it at
+// the time it was uninstalled.
+func (ef *ExternalFunctions) UninstallChaincode(packageID string, force bool) (*chaincode.InstalledChaincode, error) {
+	installedCC, err := ef.InstalledChaincodesLister.GetInstalledChaincode(packageID)
+	if err != nil {
+		return nil, persistence.CodePackageNotFoundErr{PackageID: packageID}
+	}
+
+	if !force {
+		referencingChannels := map[string]struct{}{}
+		for channel := range installedCC.References {
+			referencingChannels[channel] = struct{}{}
+		}
+		approvingChannels, err := ef.channelsApprovingPackage(packageID)
+		if err != nil {
+			return nil, err
+		}
+		for _, channel := range approvingChannels {
+			referencingChannels[channel] = struct{}{}
+		}
+		if len(referencingChannels) != 0 {
+			channels := make([]string, 0, len(referencingChannels))
+			for channel := range referencingChannels {
+				channels = append(channels, channel)
+			}
+			sort.Strings(channels)
+			return nil, errors.Errorf("chaincode with package ID '%s' is referenced by chaincode definitions on channels [%s], it must be forced to be uninstalled", packageID, strings.Join(channels, ", "))
+		}
+	}
+
+	buildLock := ef.getBuildLock(packageID)
+	buildLock.Lock()
+	defer buildLock.Unlock()
+
+	if buildStatus, ok := ef.BuildRegistry.BuildStatus(packageID); ok {
+		// wait for any in-flight build of the chaincode to complete
+		// before removing its output
+		<-buildStatus.Done()
+	}
+	ef.BuildRegistry.RemoveBuildStatus(packageID)
+
+	if err := ef.ChaincodeLauncher.Stop(packageID); err != nil {
+		logger.Debugf("could not stop chaincode with package ID '%s', it was likely not running: %s", packageID, err)
+	}
+
+	if err := ef.BuildRemover.RemoveBuild(packageID); err != nil {
+		return nil, errors.WithMessage(err, "could not remove chaincode build output")
+	}
+
+	if err := ef.Resources.ChaincodeStore.Delete(packageID); err != nil {
+		return nil, errors.WithMessage(err, "could not delete cc install package")
+	}
+
+	if ef.UninstallListener != nil {
+		ef.UninstallListener.HandleChaincodeUninstalled(packageID)
+	}
+
+	logger.Infof("Successfully uninstalled chaincode with package ID '%s'", packageID)
+
+	return installedCC, nil
+}
+
+// channelsApprovingPackage returns the channels on which the org of the peer approved a chaincode
+// definition with the given package ID, for the committed sequence of the chaincode or a later one
+func (ef *ExternalFunctions) channelsApprovingPackage(packageID string) ([]string, error) {
+	if ef.ChannelStates == nil {
+		return nil, nil
+	}
+
+	var channels []string
+	for _, channelID := range ef.ChannelStates.ChannelIDs() {
+		approved, err := ef.isPackageApproved(channelID, packageID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not check the chaincode definitions approved on channel '%s'", channelID)
+		}
+		if approved {
+			channels = append(channels, channelID)
+		}
+	}
+	return channels, nil
+}
+
+func (ef *ExternalFunctions) isPackageApproved(channelID, packageID string) (bool, error) {
+	publicState, orgState, done, err := ef.ChannelStates.LifecycleStates(channelID)
+	if err != nil {
+		return false, err
+	}
+	defer done()
+
+	metadatas, err := ef.Resources.Serializer.DeserializeAllMetadata(ChaincodeSourcesName, orgState)
+	if err != nil {
+		return false, err
+	}
+	for privateName, metadata := range metadatas {
+		if metadata.Datatype != ChaincodeLocalPackageType {
+			continue
+		}
+		ccLocalPackage := &ChaincodeLocalPackage{}
+		if err := ef.Resources.Serializer.Deserialize(ChaincodeSourcesName, privateName, metadata, ccLocalPackage, orgState); err != nil {
+			return false, errors.WithMessagef(err, "could not deserialize chaincode-source metadata for %s", privateName)
+		}
+		if ccLocalPackage.PackageID != packageID {
+			continue
+		}
+
+		// the approvals of the sequences superseded by the committed definition are ignored
+		sep := strings.LastIndex(privateName, "#")
+		if sep < 0 {
+			continue
+		}
+		sequence, err := strconv.ParseInt(privateName[sep+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+		exists, definition, err := ef.Resources.ChaincodeDefinitionIfDefined(privateName[:sep], publicState)
+		if err != nil {
+			return false, err
+		}
+		if !exists || sequence >= definition.Sequence {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (ef *ExternalFunctions) getBuildLock(packageID string) *sync.Mutex {
 	ef.mutex.Lock()
 	defer ef.mutex.Unlock()
 
 	if ef.BuildLocks == nil {
-		ef.BuildLocks = map[string]sync.Mutex{}
+		ef.BuildLocks = map[string]*sync.Mutex{}
 	}
 
 	buildLock, ok := ef.BuildLocks[packageID]
 	if !ok {
-		ef.BuildLocks[packageID] = sync.Mutex{}
+		buildLock = &sync.Mutex{}
+		ef.BuildLocks[packageID] = buildLock
 	}
 
-	return &buildLock
+	return buildLock
 }
 
 // GetInstalledChaincodePackage retrieves the installed chaincode with the given package ID
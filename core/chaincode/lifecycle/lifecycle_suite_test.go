@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/policies"
@@ -75,11 +76,21 @@ type readWritableState interface {
 	lifecycle.RangeableState
 }
 
+//go:generate counterfeiter -o mock/historical_state.go --fake-name HistoricalState . historicalState
+type historicalState interface {
+	lifecycle.HistoricalState
+}
+
 //go:generate counterfeiter -o mock/query_executor.go --fake-name SimpleQueryExecutor . simpleQueryExecutor
 type simpleQueryExecutor interface {
 	ledger.SimpleQueryExecutor
 }
 
+//go:generate counterfeiter -o mock/private_data_query_executor.go --fake-name PrivateDataQueryExecutor . privateDataQueryExecutor
+type privateDataQueryExecutor interface {
+	lifecycle.PrivateDataQueryExecutor
+}
+
 //go:generate counterfeiter -o mock/results_iterator.go --fake-name ResultsIterator . resultsIterator
 type resultsIterator interface {
 	commonledger.ResultsIterator
@@ -184,3 +195,20 @@ func (m MapLedgerShim) GetStateRange(prefix string) (map[string][]byte, error) {
 	}
 	return result, nil
 }
+
+// HistoryLedgerShim records the writes to a MapLedgerShim by the transaction
+// currently set, to serve them as the history of the keys.
+type HistoryLedgerShim struct {
+	MapLedgerShim
+	TxID    string
+	History map[string][]*queryresult.KeyModification
+}
+
+func (h *HistoryLedgerShim) PutState(key string, value []byte) error {
+	h.History[key] = append([]*queryresult.KeyModification{{TxId: h.TxID, Value: value}}, h.History[key]...)
+	return h.MapLedgerShim.PutState(key, value)
+}
+
+func (h *HistoryLedgerShim) GetStateHistory(key string) ([]*queryresult.KeyModification, error) {
+	return h.History[key], nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/common/chaincode"
@@ -272,7 +273,10 @@ var _ = Describe("ExternalFunctions", func() {
 		fakeChaincodeBuilder    *mock.ChaincodeBuilder
 		fakeParser              *mock.PackageParser
 		fakeListener            *mock.InstallListener
+		fakeUninstallListener   *mock.UninstallListener
 		fakeLister              *mock.InstalledChaincodesLister
+		fakeBuildRemover        *mock.BuildRemover
+		fakeLauncher            *mock.ChaincodeLauncher
 		fakeChannelConfigSource *mock.ChannelConfigSource
 		fakeChannelConfig       *mock.ChannelConfig
 		fakeApplicationConfig   *mock.ApplicationConfig
@@ -285,7 +289,10 @@ var _ = Describe("ExternalFunctions", func() {
 		fakeChaincodeBuilder = &mock.ChaincodeBuilder{}
 		fakeParser = &mock.PackageParser{}
 		fakeListener = &mock.InstallListener{}
+		fakeUninstallListener = &mock.UninstallListener{}
 		fakeLister = &mock.InstalledChaincodesLister{}
+		fakeBuildRemover = &mock.BuildRemover{}
+		fakeLauncher = &mock.ChaincodeLauncher{}
 		fakeChannelConfigSource = &mock.ChannelConfigSource{}
 		fakeChannelConfig = &mock.ChannelConfig{}
 		fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
@@ -313,8 +320,11 @@ var _ = Describe("ExternalFunctions", func() {
 		ef = &lifecycle.ExternalFunctions{
 			Resources:                 resources,
 			InstallListener:           fakeListener,
+			UninstallListener:         fakeUninstallListener,
 			InstalledChaincodesLister: fakeLister,
 			ChaincodeBuilder:          fakeChaincodeBuilder,
+			BuildRemover:              fakeBuildRemover,
+			ChaincodeLauncher:         fakeLauncher,
 			BuildRegistry:             &container.BuildRegistry{},
 		}
 	})
@@ -446,6 +456,284 @@ var _ = Describe("ExternalFunctions", func() {
 				Expect(err).To(MatchError("could not parse as a chaincode install package: parse-error"))
 			})
 		})
+
+		Context("when the package is signed", func() {
+			var (
+				signedPkg    *persistence.SignedChaincodePackage
+				signedBytes  []byte
+				fakeVerifier *mock.PackageVerifier
+			)
+
+			BeforeEach(func() {
+				signedPkg = &persistence.SignedChaincodePackage{
+					Package: []byte("cc-package"),
+					Signatures: []*persistence.PackageSignature{
+						{Identity: []byte("identity"), Signature: []byte("signature")},
+					},
+				}
+				var err error
+				signedBytes, err = signedPkg.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeVerifier = &mock.PackageVerifier{}
+				ef.PackageVerifier = fakeVerifier
+			})
+
+			It("verifies the package and installs the package it wraps", func() {
+				_, err := ef.InstallChaincode(signedBytes)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeVerifier.VerifyCallCount()).To(Equal(1))
+				Expect(fakeVerifier.VerifyArgsForCall(0)).To(Equal(signedPkg))
+
+				Expect(fakeParser.ParseCallCount()).To(Equal(1))
+				Expect(fakeParser.ParseArgsForCall(0)).To(Equal([]byte("cc-package")))
+				Expect(fakeCCStore.SaveCallCount()).To(Equal(1))
+				_, msg := fakeCCStore.SaveArgsForCall(0)
+				Expect(msg).To(Equal([]byte("cc-package")))
+			})
+
+			Context("when the package verifier is not set", func() {
+				BeforeEach(func() {
+					ef.PackageVerifier = nil
+				})
+
+				It("installs the package it wraps", func() {
+					_, err := ef.InstallChaincode(signedBytes)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeParser.ParseArgsForCall(0)).To(Equal([]byte("cc-package")))
+				})
+			})
+
+			Context("when verifying the package fails", func() {
+				BeforeEach(func() {
+					fakeVerifier.VerifyReturns(fmt.Errorf("verify-error"))
+				})
+
+				It("wraps and returns the error without installing the package", func() {
+					cc, err := ef.InstallChaincode(signedBytes)
+					Expect(cc).To(BeNil())
+					Expect(err).To(MatchError("could not verify chaincode install package: verify-error"))
+					Expect(fakeParser.ParseCallCount()).To(Equal(0))
+					Expect(fakeCCStore.SaveCallCount()).To(Equal(0))
+				})
+			})
+		})
+	})
+
+	Describe("UninstallChaincode", func() {
+		var installedChaincode *chaincode.InstalledChaincode
+
+		BeforeEach(func() {
+			installedChaincode = &chaincode.InstalledChaincode{
+				Label:     "cc-label",
+				PackageID: "package-id",
+			}
+			fakeLister.GetInstalledChaincodeReturns(installedChaincode, nil)
+		})
+
+		It("stops the chaincode and removes its build output and package", func() {
+			cc, err := ef.UninstallChaincode("package-id", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cc).To(Equal(installedChaincode))
+
+			Expect(fakeLister.GetInstalledChaincodeCallCount()).To(Equal(1))
+			Expect(fakeLister.GetInstalledChaincodeArgsForCall(0)).To(Equal("package-id"))
+
+			Expect(fakeLauncher.StopCallCount()).To(Equal(1))
+			Expect(fakeLauncher.StopArgsForCall(0)).To(Equal("package-id"))
+
+			Expect(fakeBuildRemover.RemoveBuildCallCount()).To(Equal(1))
+			Expect(fakeBuildRemover.RemoveBuildArgsForCall(0)).To(Equal("package-id"))
+
+			Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+			Expect(fakeCCStore.DeleteArgsForCall(0)).To(Equal("package-id"))
+
+			Expect(fakeUninstallListener.HandleChaincodeUninstalledCallCount()).To(Equal(1))
+			Expect(fakeUninstallListener.HandleChaincodeUninstalledArgsForCall(0)).To(Equal("package-id"))
+		})
+
+		It("resets the build status so the chaincode may be built again when reinstalled", func() {
+			bs, _ := ef.BuildRegistry.BuildStatus("package-id")
+			bs.Notify(nil)
+
+			_, err := ef.UninstallChaincode("package-id", false)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok := ef.BuildRegistry.BuildStatus("package-id")
+			Expect(ok).To(BeFalse())
+		})
+
+		Context("when the chaincode is not installed", func() {
+			BeforeEach(func() {
+				fakeLister.GetInstalledChaincodeReturns(nil, fmt.Errorf("could not find chaincode with package id 'package-id'"))
+			})
+
+			It("returns a package not found error", func() {
+				_, err := ef.UninstallChaincode("package-id", false)
+				Expect(err).To(Equal(persistence.CodePackageNotFoundErr{PackageID: "package-id"}))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the chaincode is referenced by chaincode definitions", func() {
+			BeforeEach(func() {
+				installedChaincode.References = map[string][]*chaincode.Metadata{
+					"test-channel": {
+						{Name: "test-chaincode", Version: "test-version"},
+					},
+					"another-channel": {
+						{Name: "another-chaincode", Version: "another-version"},
+					},
+				}
+			})
+
+			It("does not uninstall the chaincode", func() {
+				_, err := ef.UninstallChaincode("package-id", false)
+				Expect(err).To(MatchError("chaincode with package ID 'package-id' is referenced by chaincode definitions on channels [another-channel, test-channel], it must be forced to be uninstalled"))
+				Expect(fakeLauncher.StopCallCount()).To(Equal(0))
+				Expect(fakeBuildRemover.RemoveBuildCallCount()).To(Equal(0))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeUninstallListener.HandleChaincodeUninstalledCallCount()).To(Equal(0))
+			})
+
+			It("uninstalls the chaincode when forced", func() {
+				cc, err := ef.UninstallChaincode("package-id", true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cc).To(Equal(installedChaincode))
+				Expect(fakeLauncher.StopCallCount()).To(Equal(1))
+				Expect(fakeBuildRemover.RemoveBuildCallCount()).To(Equal(1))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+				Expect(fakeUninstallListener.HandleChaincodeUninstalledCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the org approved chaincode definitions for the package", func() {
+			var (
+				fakeChannelStates *mock.ChannelStates
+				publicState       MapLedgerShim
+				orgState          MapLedgerShim
+			)
+
+			BeforeEach(func() {
+				publicState = MapLedgerShim(map[string][]byte{})
+				orgState = MapLedgerShim(map[string][]byte{})
+
+				fakeChannelStates = &mock.ChannelStates{}
+				fakeChannelStates.ChannelIDsReturns([]string{"test-channel"})
+				fakeChannelStates.LifecycleStatesReturns(publicState, orgState, func() {}, nil)
+				ef.ChannelStates = fakeChannelStates
+
+				err := resources.Serializer.Serialize(lifecycle.NamespacesName, "cc-name", &lifecycle.ChaincodeDefinition{
+					EndorsementInfo: &lb.ChaincodeEndorsementInfo{},
+					ValidationInfo:  &lb.ChaincodeValidationInfo{},
+					Collections:     &pb.CollectionConfigPackage{},
+					Sequence:        2,
+				}, publicState)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when the approved chaincode was never committed", func() {
+				BeforeEach(func() {
+					err := resources.Serializer.Serialize(lifecycle.ChaincodeSourcesName, "uncommitted-cc#1", &lifecycle.ChaincodeLocalPackage{PackageID: "package-id"}, orgState)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not uninstall the chaincode", func() {
+					_, err := ef.UninstallChaincode("package-id", false)
+					Expect(err).To(MatchError("chaincode with package ID 'package-id' is referenced by chaincode definitions on channels [test-channel], it must be forced to be uninstalled"))
+					Expect(fakeChannelStates.LifecycleStatesCallCount()).To(Equal(1))
+					Expect(fakeChannelStates.LifecycleStatesArgsForCall(0)).To(Equal("test-channel"))
+					Expect(fakeLauncher.StopCallCount()).To(Equal(0))
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+				})
+
+				It("uninstalls the chaincode when forced", func() {
+					_, err := ef.UninstallChaincode("package-id", true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeChannelStates.LifecycleStatesCallCount()).To(Equal(0))
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the approved sequence is the next one of a committed chaincode", func() {
+				BeforeEach(func() {
+					err := resources.Serializer.Serialize(lifecycle.ChaincodeSourcesName, "cc-name#3", &lifecycle.ChaincodeLocalPackage{PackageID: "package-id"}, orgState)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not uninstall the chaincode", func() {
+					_, err := ef.UninstallChaincode("package-id", false)
+					Expect(err).To(MatchError("chaincode with package ID 'package-id' is referenced by chaincode definitions on channels [test-channel], it must be forced to be uninstalled"))
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the approved sequence was superseded by the committed definition", func() {
+				BeforeEach(func() {
+					err := resources.Serializer.Serialize(lifecycle.ChaincodeSourcesName, "cc-name#1", &lifecycle.ChaincodeLocalPackage{PackageID: "package-id"}, orgState)
+					Expect(err).NotTo(HaveOccurred())
+					err = resources.Serializer.Serialize(lifecycle.ChaincodeSourcesName, "cc-name#2", &lifecycle.ChaincodeLocalPackage{PackageID: "another-package-id"}, orgState)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("uninstalls the chaincode", func() {
+					_, err := ef.UninstallChaincode("package-id", false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the lifecycle states of a channel cannot be retrieved", func() {
+				BeforeEach(func() {
+					fakeChannelStates.LifecycleStatesReturns(nil, nil, nil, fmt.Errorf("fake-states-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					_, err := ef.UninstallChaincode("package-id", false)
+					Expect(err).To(MatchError("could not check the chaincode definitions approved on channel 'test-channel': fake-states-error"))
+					Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the chaincode is not running", func() {
+			BeforeEach(func() {
+				fakeLauncher.StopReturns(fmt.Errorf("fake-stop-error"))
+			})
+
+			It("uninstalls the chaincode", func() {
+				_, err := ef.UninstallChaincode("package-id", false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when removing the build output fails", func() {
+			BeforeEach(func() {
+				fakeBuildRemover.RemoveBuildReturns(fmt.Errorf("fake-remove-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := ef.UninstallChaincode("package-id", false)
+				Expect(err).To(MatchError("could not remove chaincode build output: fake-remove-error"))
+				Expect(fakeCCStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeUninstallListener.HandleChaincodeUninstalledCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when deleting the chaincode package fails", func() {
+			BeforeEach(func() {
+				fakeCCStore.DeleteReturns(fmt.Errorf("fake-delete-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := ef.UninstallChaincode("package-id", false)
+				Expect(err).To(MatchError("could not delete cc install package: fake-delete-error"))
+				Expect(fakeUninstallListener.HandleChaincodeUninstalledCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("GetInstalledChaincodePackage", func() {
@@ -1693,6 +1981,107 @@ var _ = Describe("ExternalFunctions", func() {
 		})
 	})
 
+	Describe("QueryChaincodeDefinitionHistory", func() {
+		var (
+			publicState *HistoryLedgerShim
+			orgKVS      MapLedgerShim
+		)
+
+		BeforeEach(func() {
+			publicState = &HistoryLedgerShim{
+				MapLedgerShim: MapLedgerShim(map[string][]byte{}),
+				History:       map[string][]*queryresult.KeyModification{},
+			}
+
+			definition := &lifecycle.ChaincodeDefinition{
+				Sequence: 1,
+				EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+					Version:           "version-1",
+					EndorsementPlugin: "endorsement-plugin",
+				},
+				ValidationInfo: &lb.ChaincodeValidationInfo{
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter-1"),
+				},
+				Collections: &pb.CollectionConfigPackage{},
+			}
+			publicState.TxID = "tx-1"
+			resources.Serializer.Serialize("namespaces", "cc-name", definition, publicState)
+
+			definition.Sequence = 2
+			definition.EndorsementInfo = &lb.ChaincodeEndorsementInfo{
+				Version:           "version-2",
+				EndorsementPlugin: "endorsement-plugin",
+			}
+			publicState.TxID = "tx-2"
+			resources.Serializer.Serialize("namespaces", "cc-name", definition, publicState)
+
+			definition.Sequence = 3
+			definition.ValidationInfo = &lb.ChaincodeValidationInfo{
+				ValidationPlugin:    "validation-plugin",
+				ValidationParameter: []byte("validation-parameter-3"),
+			}
+			publicState.TxID = "tx-3"
+			resources.Serializer.Serialize("namespaces", "cc-name", definition, publicState)
+
+			orgKVS = MapLedgerShim(map[string][]byte{})
+			resources.Serializer.Serialize("chaincode-sources", "cc-name#2", &lifecycle.ChaincodeLocalPackage{PackageID: "hash"}, orgKVS)
+		})
+
+		It("returns every committed definition in order of sequence", func() {
+			history, err := ef.QueryChaincodeDefinitionHistory("cc-name", publicState, orgKVS)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(history).To(HaveLen(3))
+
+			for i, committed := range history {
+				Expect(committed.Definition.Sequence).To(Equal(int64(i + 1)))
+				Expect(committed.TxID).To(Equal(fmt.Sprintf("tx-%d", i+1)))
+				Expect(committed.Definition.EndorsementInfo.EndorsementPlugin).To(Equal("endorsement-plugin"))
+				Expect(committed.Definition.ValidationInfo.ValidationPlugin).To(Equal("validation-plugin"))
+				Expect(proto.Equal(committed.Definition.Collections, &pb.CollectionConfigPackage{})).To(BeTrue())
+			}
+			Expect(history[0].Definition.EndorsementInfo.Version).To(Equal("version-1"))
+			Expect(history[1].Definition.EndorsementInfo.Version).To(Equal("version-2"))
+			Expect(history[2].Definition.EndorsementInfo.Version).To(Equal("version-2"))
+			Expect(history[0].Definition.ValidationInfo.ValidationParameter).To(Equal([]byte("validation-parameter-1")))
+			Expect(history[1].Definition.ValidationInfo.ValidationParameter).To(Equal([]byte("validation-parameter-1")))
+			Expect(history[2].Definition.ValidationInfo.ValidationParameter).To(Equal([]byte("validation-parameter-3")))
+			Expect(history[0].PackageID).To(BeEmpty())
+			Expect(history[1].PackageID).To(Equal("hash"))
+			Expect(history[2].PackageID).To(BeEmpty())
+		})
+
+		Context("when the chaincode has never been defined", func() {
+			It("returns an error", func() {
+				history, err := ef.QueryChaincodeDefinitionHistory("other-name", publicState, orgKVS)
+				Expect(err).To(MatchError("namespace other-name is not defined"))
+				Expect(history).To(BeNil())
+			})
+		})
+
+		Context("when getting the history fails", func() {
+			It("returns an error", func() {
+				fakePublicState := &mock.HistoricalState{}
+				fakePublicState.GetStateHistoryReturns(nil, fmt.Errorf("history-error"))
+				history, err := ef.QueryChaincodeDefinitionHistory("cc-name", fakePublicState, orgKVS)
+				Expect(err).To(MatchError("could not get history of field Sequence for namespace cc-name: history-error"))
+				Expect(history).To(BeNil())
+			})
+		})
+
+		Context("when deserializing a definition fails", func() {
+			BeforeEach(func() {
+				publicState.History["namespaces/fields/cc-name/EndorsementInfo"][0].Value = []byte("garbage")
+			})
+
+			It("returns an error", func() {
+				history, err := ef.QueryChaincodeDefinitionHistory("cc-name", publicState, orgKVS)
+				Expect(err).To(MatchError("could not deserialize namespace cc-name as chaincode at transaction tx-2: could not unmarshal state for key namespaces/fields/cc-name/EndorsementInfo: proto: can't skip unknown wire type 7"))
+				Expect(history).To(BeNil())
+			})
+		})
+	})
+
 	Describe("QueryOrgApprovals", func() {
 		var (
 			fakeOrgStates []*mock.ReadWritableState
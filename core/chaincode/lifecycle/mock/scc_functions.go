@@ -116,6 +116,21 @@ type SCCFunctions struct {
 		result1 *lifecycle.ChaincodeDefinition
 		result2 error
 	}
+	QueryChaincodeDefinitionHistoryStub        func(string, lifecycle.HistoricalState, lifecycle.ReadableState) ([]*lifecycle.CommittedChaincodeDefinition, error)
+	queryChaincodeDefinitionHistoryMutex       sync.RWMutex
+	queryChaincodeDefinitionHistoryArgsForCall []struct {
+		arg1 string
+		arg2 lifecycle.HistoricalState
+		arg3 lifecycle.ReadableState
+	}
+	queryChaincodeDefinitionHistoryReturns struct {
+		result1 []*lifecycle.CommittedChaincodeDefinition
+		result2 error
+	}
+	queryChaincodeDefinitionHistoryReturnsOnCall map[int]struct {
+		result1 []*lifecycle.CommittedChaincodeDefinition
+		result2 error
+	}
 	QueryInstalledChaincodeStub        func(string) (*chaincode.InstalledChaincode, error)
 	queryInstalledChaincodeMutex       sync.RWMutex
 	queryInstalledChaincodeArgsForCall []struct {
@@ -167,6 +182,20 @@ type SCCFunctions struct {
 		result1 map[string]bool
 		result2 error
 	}
+	UninstallChaincodeStub        func(string, bool) (*chaincode.InstalledChaincode, error)
+	uninstallChaincodeMutex       sync.RWMutex
+	uninstallChaincodeArgsForCall []struct {
+		arg1 string
+		arg2 bool
+	}
+	uninstallChaincodeReturns struct {
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}
+	uninstallChaincodeReturnsOnCall map[int]struct {
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -642,6 +671,71 @@ func (fake *SCCFunctions) QueryChaincodeDefinitionReturnsOnCall(i int, result1 *
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistory(arg1 string, arg2 lifecycle.HistoricalState, arg3 lifecycle.ReadableState) ([]*lifecycle.CommittedChaincodeDefinition, error) {
+	fake.queryChaincodeDefinitionHistoryMutex.Lock()
+	ret, specificReturn := fake.queryChaincodeDefinitionHistoryReturnsOnCall[len(fake.queryChaincodeDefinitionHistoryArgsForCall)]
+	fake.queryChaincodeDefinitionHistoryArgsForCall = append(fake.queryChaincodeDefinitionHistoryArgsForCall, struct {
+		arg1 string
+		arg2 lifecycle.HistoricalState
+		arg3 lifecycle.ReadableState
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("QueryChaincodeDefinitionHistory", []interface{}{arg1, arg2, arg3})
+	fake.queryChaincodeDefinitionHistoryMutex.Unlock()
+	if fake.QueryChaincodeDefinitionHistoryStub != nil {
+		return fake.QueryChaincodeDefinitionHistoryStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryChaincodeDefinitionHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryCallCount() int {
+	fake.queryChaincodeDefinitionHistoryMutex.RLock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.RUnlock()
+	return len(fake.queryChaincodeDefinitionHistoryArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryCalls(stub func(string, lifecycle.HistoricalState, lifecycle.ReadableState) ([]*lifecycle.CommittedChaincodeDefinition, error)) {
+	fake.queryChaincodeDefinitionHistoryMutex.Lock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.Unlock()
+	fake.QueryChaincodeDefinitionHistoryStub = stub
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryArgsForCall(i int) (string, lifecycle.HistoricalState, lifecycle.ReadableState) {
+	fake.queryChaincodeDefinitionHistoryMutex.RLock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.RUnlock()
+	argsForCall := fake.queryChaincodeDefinitionHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryReturns(result1 []*lifecycle.CommittedChaincodeDefinition, result2 error) {
+	fake.queryChaincodeDefinitionHistoryMutex.Lock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.Unlock()
+	fake.QueryChaincodeDefinitionHistoryStub = nil
+	fake.queryChaincodeDefinitionHistoryReturns = struct {
+		result1 []*lifecycle.CommittedChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryChaincodeDefinitionHistoryReturnsOnCall(i int, result1 []*lifecycle.CommittedChaincodeDefinition, result2 error) {
+	fake.queryChaincodeDefinitionHistoryMutex.Lock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.Unlock()
+	fake.QueryChaincodeDefinitionHistoryStub = nil
+	if fake.queryChaincodeDefinitionHistoryReturnsOnCall == nil {
+		fake.queryChaincodeDefinitionHistoryReturnsOnCall = make(map[int]struct {
+			result1 []*lifecycle.CommittedChaincodeDefinition
+			result2 error
+		})
+	}
+	fake.queryChaincodeDefinitionHistoryReturnsOnCall[i] = struct {
+		result1 []*lifecycle.CommittedChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) QueryInstalledChaincode(arg1 string) (*chaincode.InstalledChaincode, error) {
 	fake.queryInstalledChaincodeMutex.Lock()
 	ret, specificReturn := fake.queryInstalledChaincodeReturnsOnCall[len(fake.queryInstalledChaincodeArgsForCall)]
@@ -890,6 +984,70 @@ func (fake *SCCFunctions) QueryOrgApprovalsReturnsOnCall(i int, result1 map[stri
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) UninstallChaincode(arg1 string, arg2 bool) (*chaincode.InstalledChaincode, error) {
+	fake.uninstallChaincodeMutex.Lock()
+	ret, specificReturn := fake.uninstallChaincodeReturnsOnCall[len(fake.uninstallChaincodeArgsForCall)]
+	fake.uninstallChaincodeArgsForCall = append(fake.uninstallChaincodeArgsForCall, struct {
+		arg1 string
+		arg2 bool
+	}{arg1, arg2})
+	fake.recordInvocation("UninstallChaincode", []interface{}{arg1, arg2})
+	fake.uninstallChaincodeMutex.Unlock()
+	if fake.UninstallChaincodeStub != nil {
+		return fake.UninstallChaincodeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.uninstallChaincodeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) UninstallChaincodeCallCount() int {
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
+	return len(fake.uninstallChaincodeArgsForCall)
+}
+
+func (fake *SCCFunctions) UninstallChaincodeCalls(stub func(string, bool) (*chaincode.InstalledChaincode, error)) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = stub
+}
+
+func (fake *SCCFunctions) UninstallChaincodeArgsForCall(i int) (string, bool) {
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
+	argsForCall := fake.uninstallChaincodeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SCCFunctions) UninstallChaincodeReturns(result1 *chaincode.InstalledChaincode, result2 error) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = nil
+	fake.uninstallChaincodeReturns = struct {
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) UninstallChaincodeReturnsOnCall(i int, result1 *chaincode.InstalledChaincode, result2 error) {
+	fake.uninstallChaincodeMutex.Lock()
+	defer fake.uninstallChaincodeMutex.Unlock()
+	fake.UninstallChaincodeStub = nil
+	if fake.uninstallChaincodeReturnsOnCall == nil {
+		fake.uninstallChaincodeReturnsOnCall = make(map[int]struct {
+			result1 *chaincode.InstalledChaincode
+			result2 error
+		})
+	}
+	fake.uninstallChaincodeReturnsOnCall[i] = struct {
+		result1 *chaincode.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -907,6 +1065,8 @@ func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	defer fake.queryApprovedChaincodeDefinitionMutex.RUnlock()
 	fake.queryChaincodeDefinitionMutex.RLock()
 	defer fake.queryChaincodeDefinitionMutex.RUnlock()
+	fake.queryChaincodeDefinitionHistoryMutex.RLock()
+	defer fake.queryChaincodeDefinitionHistoryMutex.RUnlock()
 	fake.queryInstalledChaincodeMutex.RLock()
 	defer fake.queryInstalledChaincodeMutex.RUnlock()
 	fake.queryInstalledChaincodesMutex.RLock()
@@ -915,6 +1075,8 @@ func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	defer fake.queryNamespaceDefinitionsMutex.RUnlock()
 	fake.queryOrgApprovalsMutex.RLock()
 	defer fake.queryOrgApprovalsMutex.RUnlock()
+	fake.uninstallChaincodeMutex.RLock()
+	defer fake.uninstallChaincodeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,474 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: lifecycle.proto
+
+package msgs
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// UninstallChaincodeArgs is the message used as arguments to
+// `_lifecycle.UninstallChaincode`.
+type UninstallChaincodeArgs struct {
+	PackageId            string   `protobuf:"bytes,1,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UninstallChaincodeArgs) Reset()         { *m = UninstallChaincodeArgs{} }
+func (m *UninstallChaincodeArgs) String() string { return proto.CompactTextString(m) }
+func (*UninstallChaincodeArgs) ProtoMessage()    {}
+func (*UninstallChaincodeArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84f7c7eee8484930, []int{0}
+}
+
+func (m *UninstallChaincodeArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallChaincodeArgs.Unmarshal(m, b)
+}
+func (m *UninstallChaincodeArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallChaincodeArgs.Marshal(b, m, deterministic)
+}
+func (m *UninstallChaincodeArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallChaincodeArgs.Merge(m, src)
+}
+func (m *UninstallChaincodeArgs) XXX_Size() int {
+	return xxx_messageInfo_UninstallChaincodeArgs.Size(m)
+}
+func (m *UninstallChaincodeArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallChaincodeArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallChaincodeArgs proto.InternalMessageInfo
+
+func (m *UninstallChaincodeArgs) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+func (m *UninstallChaincodeArgs) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+// UninstallChaincodeResult is the message returned by
+// `_lifecycle.UninstallChaincode`. It returns the channels and chaincode
+// definitions which referenced the uninstalled package, if any.
+type UninstallChaincodeResult struct {
+	References           map[string]*UninstallChaincodeResult_References `protobuf:"bytes,1,rep,name=references,proto3" json:"references,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                                        `json:"-"`
+	XXX_unrecognized     []byte                                          `json:"-"`
+	XXX_sizecache        int32                                           `json:"-"`
+}
+
+func (m *UninstallChaincodeResult) Reset()         { *m = UninstallChaincodeResult{} }
+func (m *UninstallChaincodeResult) String() string { return proto.CompactTextString(m) }
+func (*UninstallChaincodeResult) ProtoMessage()    {}
+func (*UninstallChaincodeResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84f7c7eee8484930, []int{1}
+}
+
+func (m *UninstallChaincodeResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallChaincodeResult.Unmarshal(m, b)
+}
+func (m *UninstallChaincodeResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallChaincodeResult.Marshal(b, m, deterministic)
+}
+func (m *UninstallChaincodeResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallChaincodeResult.Merge(m, src)
+}
+func (m *UninstallChaincodeResult) XXX_Size() int {
+	return xxx_messageInfo_UninstallChaincodeResult.Size(m)
+}
+func (m *UninstallChaincodeResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallChaincodeResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallChaincodeResult proto.InternalMessageInfo
+
+func (m *UninstallChaincodeResult) GetReferences() map[string]*UninstallChaincodeResult_References {
+	if m != nil {
+		return m.References
+	}
+	return nil
+}
+
+type UninstallChaincodeResult_Chaincode struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UninstallChaincodeResult_Chaincode) Reset()         { *m = UninstallChaincodeResult_Chaincode{} }
+func (m *UninstallChaincodeResult_Chaincode) String() string { return proto.CompactTextString(m) }
+func (*UninstallChaincodeResult_Chaincode) ProtoMessage()    {}
+func (*UninstallChaincodeResult_Chaincode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84f7c7eee8484930, []int{1, 0}
+}
+
+func (m *UninstallChaincodeResult_Chaincode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallChaincodeResult_Chaincode.Unmarshal(m, b)
+}
+func (m *UninstallChaincodeResult_Chaincode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallChaincodeResult_Chaincode.Marshal(b, m, deterministic)
+}
+func (m *UninstallChaincodeResult_Chaincode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallChaincodeResult_Chaincode.Merge(m, src)
+}
+func (m *UninstallChaincodeResult_Chaincode) XXX_Size() int {
+	return xxx_messageInfo_UninstallChaincodeResult_Chaincode.Size(m)
+}
+func (m *UninstallChaincodeResult_Chaincode) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallChaincodeResult_Chaincode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallChaincodeResult_Chaincode proto.InternalMessageInfo
+
+func (m *UninstallChaincodeResult_Chaincode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UninstallChaincodeResult_Chaincode) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+type UninstallChaincodeResult_References struct {
+	Chaincodes           []*UninstallChaincodeResult_Chaincode `protobuf:"bytes,1,rep,name=chaincodes,proto3" json:"chaincodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                              `json:"-"`
+	XXX_unrecognized     []byte                                `json:"-"`
+	XXX_sizecache        int32                                 `json:"-"`
+}
+
+func (m *UninstallChaincodeResult_References) Reset()         { *m = UninstallChaincodeResult_References{} }
+func (m *UninstallChaincodeResult_References) String() string { return proto.CompactTextString(m) }
+func (*UninstallChaincodeResult_References) ProtoMessage()    {}
+func (*UninstallChaincodeResult_References) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84f7c7eee8484930, []int{1, 1}
+}
+
+func (m *UninstallChaincodeResult_References) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UninstallChaincodeResult_References.Unmarshal(m, b)
+}
+func (m *UninstallChaincodeResult_References) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UninstallChaincodeResult_References.Marshal(b, m, deterministic)
+}
+func (m *UninstallChaincodeResult_References) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UninstallChaincodeResult_References.Merge(m, src)
+}
+func (m *UninstallChaincodeResult_References) XXX_Size() int {
+	return xxx_messageInfo_UninstallChaincodeResult_References.Size(m)
+}
+func (m *UninstallChaincodeResult_References) XXX_DiscardUnknown() {
+	xxx_messageInfo_UninstallChaincodeResult_References.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UninstallChaincodeResult_References proto.InternalMessageInfo
+
+func (m *UninstallChaincodeResult_References) GetChaincodes() []*UninstallChaincodeResult_Chaincode {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+// QueryChaincodeDefinitionHistoryArgs is the message used as arguments to
+// `_lifecycle.QueryChaincodeDefinitionHistory`.
+type QueryChaincodeDefinitionHistoryArgs struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionHistoryArgs) Reset()         { *m = QueryChaincodeDefinitionHistoryArgs{} }
+func (m *QueryChaincodeDefinitionHistoryArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionHistoryArgs) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionHistoryArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84f7c7eee8484930, []int{2}
+}
+
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.Marshal(b, m, deterministic)
+}
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.Merge(m, src)
+}
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.Size(m)
+}
+func (m *QueryChaincodeDefinitionHistoryArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionHistoryArgs proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionHistoryArgs) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// QueryChaincodeDefinitionHistoryResult is the message returned by
+// `_lifecycle.QueryChaincodeDefinitionHistory`. It returns every definition
+// committed for the chaincode, in order of sequence, along with the
+// transaction which committed it.
+type QueryChaincodeDefinitionHistoryResult struct {
+	ChaincodeDefinitions []*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition `protobuf:"bytes,1,rep,name=chaincode_definitions,json=chaincodeDefinitions,proto3" json:"chaincode_definitions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                                     `json:"-"`
+	XXX_unrecognized     []byte                                                       `json:"-"`
+	XXX_sizecache        int32                                                        `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult) Reset()         { *m = QueryChaincodeDefinitionHistoryResult{} }
+func (m *QueryChaincodeDefinitionHistoryResult) String() string { return proto.CompactTextString(m) }
+func (*QueryChaincodeDefinitionHistoryResult) ProtoMessage()    {}
+func (*QueryChaincodeDefinitionHistoryResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84f7c7eee8484930, []int{3}
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.Marshal(b, m, deterministic)
+}
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.Merge(m, src)
+}
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.Size(m)
+}
+func (m *QueryChaincodeDefinitionHistoryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionHistoryResult proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionHistoryResult) GetChaincodeDefinitions() []*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition {
+	if m != nil {
+		return m.ChaincodeDefinitions
+	}
+	return nil
+}
+
+type QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition struct {
+	Sequence             int64                         `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Version              string                        `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	EndorsementPlugin    string                        `protobuf:"bytes,3,opt,name=endorsement_plugin,json=endorsementPlugin,proto3" json:"endorsement_plugin,omitempty"`
+	ValidationPlugin     string                        `protobuf:"bytes,4,opt,name=validation_plugin,json=validationPlugin,proto3" json:"validation_plugin,omitempty"`
+	ValidationParameter  []byte                        `protobuf:"bytes,5,opt,name=validation_parameter,json=validationParameter,proto3" json:"validation_parameter,omitempty"`
+	Collections          *peer.CollectionConfigPackage `protobuf:"bytes,6,opt,name=collections,proto3" json:"collections,omitempty"`
+	InitRequired         bool                          `protobuf:"varint,7,opt,name=init_required,json=initRequired,proto3" json:"init_required,omitempty"`
+	Approvals            map[string]bool               `protobuf:"bytes,8,rep,name=approvals,proto3" json:"approvals,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	PackageId            string                        `protobuf:"bytes,9,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	BlockNumber          uint64                        `protobuf:"varint,10,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	TxId                 string                        `protobuf:"bytes,11,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Timestamp            *timestamp.Timestamp          `protobuf:"bytes,12,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) Reset() {
+	*m = QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{}
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) String() string {
+	return proto.CompactTextString(m)
+}
+func (*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) ProtoMessage() {}
+func (*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_84f7c7eee8484930, []int{3, 0}
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.Unmarshal(m, b)
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.Marshal(b, m, deterministic)
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.Merge(m, src)
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_Size() int {
+	return xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.Size(m)
+}
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition proto.InternalMessageInfo
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetEndorsementPlugin() string {
+	if m != nil {
+		return m.EndorsementPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetValidationPlugin() string {
+	if m != nil {
+		return m.ValidationPlugin
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetValidationParameter() []byte {
+	if m != nil {
+		return m.ValidationParameter
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetCollections() *peer.CollectionConfigPackage {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetApprovals() map[string]bool {
+	if m != nil {
+		return m.Approvals
+	}
+	return nil
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*UninstallChaincodeArgs)(nil), "lifecycle.msgs.UninstallChaincodeArgs")
+	proto.RegisterType((*UninstallChaincodeResult)(nil), "lifecycle.msgs.UninstallChaincodeResult")
+	proto.RegisterMapType((map[string]*UninstallChaincodeResult_References)(nil), "lifecycle.msgs.UninstallChaincodeResult.ReferencesEntry")
+	proto.RegisterType((*UninstallChaincodeResult_Chaincode)(nil), "lifecycle.msgs.UninstallChaincodeResult.Chaincode")
+	proto.RegisterType((*UninstallChaincodeResult_References)(nil), "lifecycle.msgs.UninstallChaincodeResult.References")
+	proto.RegisterType((*QueryChaincodeDefinitionHistoryArgs)(nil), "lifecycle.msgs.QueryChaincodeDefinitionHistoryArgs")
+	proto.RegisterType((*QueryChaincodeDefinitionHistoryResult)(nil), "lifecycle.msgs.QueryChaincodeDefinitionHistoryResult")
+	proto.RegisterType((*QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition)(nil), "lifecycle.msgs.QueryChaincodeDefinitionHistoryResult.ChaincodeDefinition")
+	proto.RegisterMapType((map[string]bool)(nil), "lifecycle.msgs.QueryChaincodeDefinitionHistoryResult.ChaincodeDefinition.ApprovalsEntry")
+}
+
+func init() { proto.RegisterFile("lifecycle.proto", fileDescriptor_84f7c7eee8484930) }
+
+var fileDescriptor_84f7c7eee8484930 = []byte{
+	// 654 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x6b, 0xdb, 0x4a,
+	0x14, 0xc5, 0x71, 0x9c, 0xd8, 0xd7, 0x7e, 0xf9, 0x98, 0x38, 0x0f, 0x21, 0x78, 0xc4, 0xcf, 0xe1,
+	0x81, 0xe1, 0x51, 0x89, 0x26, 0x9b, 0xa4, 0x1f, 0x8b, 0x34, 0x2d, 0xd4, 0x8b, 0x96, 0x74, 0x68,
+	0x21, 0x74, 0xe3, 0xca, 0xa3, 0x6b, 0x65, 0x88, 0x34, 0xa3, 0xcc, 0x48, 0x26, 0xde, 0x74, 0xd7,
+	0x45, 0x7f, 0x4f, 0xff, 0x60, 0xf1, 0xc8, 0xfa, 0x88, 0xe3, 0xd0, 0x50, 0xba, 0xd3, 0xdc, 0x7b,
+	0xee, 0xd5, 0x99, 0x73, 0xe7, 0x5c, 0xd8, 0x0e, 0xf9, 0x04, 0xd9, 0x8c, 0x85, 0xe8, 0xc4, 0x4a,
+	0x26, 0x92, 0x6c, 0x95, 0x81, 0x48, 0x07, 0xda, 0x3e, 0x08, 0xa4, 0x0c, 0x42, 0x74, 0x4d, 0x76,
+	0x9c, 0x4e, 0xdc, 0x84, 0x47, 0xa8, 0x13, 0x2f, 0x8a, 0xb3, 0x02, 0x7b, 0x3f, 0x46, 0x54, 0x2e,
+	0x93, 0x61, 0x88, 0x2c, 0xe1, 0x52, 0x64, 0xe1, 0xfe, 0x3b, 0xf8, 0xfb, 0x93, 0xe0, 0x42, 0x27,
+	0x5e, 0x18, 0x9e, 0x5f, 0x79, 0x5c, 0x30, 0xe9, 0xe3, 0x99, 0x0a, 0x34, 0xf9, 0x07, 0x20, 0xf6,
+	0xd8, 0xb5, 0x17, 0xe0, 0x88, 0xfb, 0x56, 0xad, 0x57, 0x1b, 0xb4, 0x68, 0x6b, 0x11, 0x19, 0xfa,
+	0xa4, 0x0b, 0x8d, 0x89, 0x54, 0x0c, 0xad, 0xb5, 0x5e, 0x6d, 0xd0, 0xa4, 0xd9, 0xa1, 0xff, 0xad,
+	0x0e, 0xd6, 0xfd, 0x7e, 0x14, 0x75, 0x1a, 0x26, 0xe4, 0x12, 0x40, 0xe1, 0x04, 0x15, 0x0a, 0x86,
+	0xda, 0xaa, 0xf5, 0xea, 0x83, 0xf6, 0xd1, 0x89, 0x73, 0xf7, 0x22, 0xce, 0x43, 0xd5, 0x0e, 0x2d,
+	0x4a, 0xdf, 0x88, 0x44, 0xcd, 0x68, 0xa5, 0x97, 0x7d, 0x0a, 0xad, 0x02, 0x4e, 0x08, 0xac, 0x0b,
+	0x2f, 0xc2, 0x05, 0x65, 0xf3, 0x4d, 0x2c, 0xd8, 0x9c, 0xa2, 0xd2, 0x5c, 0x0a, 0xc3, 0xb7, 0x45,
+	0xf3, 0xa3, 0xfd, 0x05, 0xa0, 0xec, 0x4c, 0x28, 0x00, 0xcb, 0x1b, 0xe5, 0x14, 0x8f, 0x1e, 0x4d,
+	0xb1, 0x3c, 0x57, 0xba, 0xd8, 0x0a, 0xb6, 0x97, 0xb8, 0x93, 0x1d, 0xa8, 0x5f, 0xe3, 0x6c, 0xc1,
+	0x70, 0xfe, 0x49, 0x86, 0xd0, 0x98, 0x7a, 0x61, 0x9a, 0xc9, 0xd9, 0x3e, 0x3a, 0xfe, 0x0d, 0x59,
+	0x68, 0xd6, 0xe1, 0xd9, 0xda, 0x49, 0xad, 0x7f, 0x0a, 0x87, 0x1f, 0x52, 0x54, 0xb3, 0x02, 0xfd,
+	0x1a, 0x27, 0x5c, 0xf0, 0xf9, 0xe0, 0xdf, 0x72, 0x9d, 0x48, 0x35, 0x33, 0x33, 0x5e, 0x21, 0x55,
+	0xff, 0xc7, 0x06, 0xfc, 0xf7, 0x8b, 0xda, 0xc5, 0x3c, 0xbf, 0xc2, 0x7e, 0x71, 0xcd, 0x91, 0x5f,
+	0x80, 0x72, 0xdd, 0x86, 0xcb, 0x77, 0x78, 0x54, 0x57, 0x67, 0x05, 0x80, 0x76, 0xd9, 0xfd, 0xa0,
+	0xb6, 0xbf, 0x37, 0x60, 0x6f, 0x05, 0x9a, 0xd8, 0xd0, 0xd4, 0x78, 0x93, 0xa2, 0x60, 0xd9, 0xcd,
+	0xea, 0xb4, 0x38, 0x3f, 0xfc, 0x10, 0xc8, 0x13, 0x20, 0x28, 0x7c, 0xa9, 0x34, 0x46, 0x28, 0x92,
+	0x51, 0x1c, 0xa6, 0x01, 0x17, 0x56, 0xdd, 0x80, 0x76, 0x2b, 0x99, 0x0b, 0x93, 0x20, 0xff, 0xc3,
+	0xee, 0xd4, 0x0b, 0xb9, 0xef, 0xcd, 0x7f, 0x99, 0xa3, 0xd7, 0x0d, 0x7a, 0xa7, 0x4c, 0x2c, 0xc0,
+	0x4f, 0xa1, 0x5b, 0x05, 0x7b, 0xca, 0x8b, 0x30, 0x41, 0x65, 0x35, 0x7a, 0xb5, 0x41, 0x87, 0xee,
+	0x55, 0xf0, 0x79, 0x8a, 0x9c, 0x41, 0xbb, 0x34, 0xab, 0xb6, 0x36, 0xcc, 0xb3, 0x38, 0xc8, 0x5c,
+	0xab, 0x9d, 0xf3, 0x22, 0x75, 0x2e, 0xc5, 0x84, 0x07, 0x17, 0x99, 0x2f, 0x69, 0xb5, 0x86, 0x1c,
+	0xc2, 0x5f, 0x73, 0x49, 0x46, 0x0a, 0x6f, 0x52, 0xae, 0xd0, 0xb7, 0x36, 0x8d, 0x55, 0x3b, 0xf3,
+	0x20, 0x5d, 0xc4, 0xc8, 0x14, 0x5a, 0x5e, 0x1c, 0x2b, 0x39, 0xf5, 0x42, 0x6d, 0x35, 0xcd, 0xe0,
+	0x2e, 0xff, 0xd8, 0xe0, 0x9c, 0xb3, 0xbc, 0x75, 0xe6, 0xd9, 0xf2, 0x57, 0x4b, 0xeb, 0xa5, 0xb5,
+	0xbc, 0x5e, 0xfe, 0x85, 0xce, 0x38, 0x94, 0xec, 0x7a, 0x24, 0xd2, 0x68, 0x8c, 0xca, 0x82, 0x5e,
+	0x6d, 0xb0, 0x4e, 0xdb, 0x26, 0xf6, 0xde, 0x84, 0xc8, 0x1e, 0x34, 0x92, 0xdb, 0x79, 0x71, 0x3b,
+	0x7b, 0xbd, 0xc9, 0xed, 0xd0, 0x27, 0x27, 0xd0, 0x2a, 0x36, 0x9f, 0xd5, 0x31, 0xa2, 0xd9, 0x4e,
+	0xb6, 0x1b, 0x9d, 0x7c, 0x37, 0x3a, 0x1f, 0x73, 0x04, 0x2d, 0xc1, 0xf6, 0x0b, 0xd8, 0xba, 0xcb,
+	0x76, 0x85, 0x4b, 0xbb, 0x55, 0x97, 0x36, 0x2b, 0x86, 0x7b, 0xf5, 0xf2, 0xf3, 0xf3, 0x80, 0x27,
+	0x57, 0xe9, 0xd8, 0x61, 0x32, 0x72, 0xaf, 0x66, 0x31, 0xaa, 0x10, 0xfd, 0x00, 0x95, 0x3b, 0xf1,
+	0xc6, 0x8a, 0x33, 0x97, 0x49, 0x85, 0x6e, 0xf1, 0x8c, 0xdd, 0x42, 0x61, 0x77, 0xae, 0xf0, 0x78,
+	0xc3, 0x70, 0x3b, 0xfe, 0x39, 0x00, 0x50, 0xa3, 0xe3, 0xf2, 0xe8, 0x05, 0x00, 0x00,
+}
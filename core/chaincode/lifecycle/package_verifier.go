@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+//go:generate counterfeiter -o mock/package_verifier.go --fake-name PackageVerifier . PackageVerifier
+
+// PackageVerifier verifies a chaincode package before it is installed.
+type PackageVerifier interface {
+	Verify(pkg *persistence.SignedChaincodePackage) error
+}
+
+// PackageSignatureVerifier verifies that the signatures over a chaincode
+// package satisfy the peer's chaincode install policy.
+type PackageSignatureVerifier struct {
+	Policy policies.Policy
+}
+
+// NewPackageSignatureVerifier creates a PackageSignatureVerifier for the
+// signature policy. Only identities which can be deserialized by the
+// deserializer can satisfy the policy.
+func NewPackageSignatureVerifier(policy string, deserializer msp.IdentityDeserializer) (*PackageSignatureVerifier, error) {
+	signaturePolicy, err := policydsl.FromString(policy)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid chaincode install policy '%s'", policy)
+	}
+
+	pp := &cauthdsl.EnvelopeBasedPolicyProvider{Deserializer: deserializer}
+	p, err := pp.NewPolicy(signaturePolicy)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not create chaincode install policy '%s'", policy)
+	}
+
+	return &PackageSignatureVerifier{Policy: p}, nil
+}
+
+// Verify returns an error if the chaincode package is not signed or if its
+// signatures do not satisfy the chaincode install policy.
+func (v *PackageSignatureVerifier) Verify(pkg *persistence.SignedChaincodePackage) error {
+	if len(pkg.Signatures) == 0 {
+		return errors.New("chaincode package is not signed")
+	}
+
+	hash := pkg.Hash()
+	signedData := make([]*protoutil.SignedData, 0, len(pkg.Signatures))
+	for _, signature := range pkg.Signatures {
+		signedData = append(signedData, &protoutil.SignedData{
+			Data:      hash,
+			Identity:  signature.Identity,
+			Signature: signature.Signature,
+		})
+	}
+
+	if err := v.Policy.EvaluateSignedData(signedData); err != nil {
+		return errors.WithMessage(err, "chaincode package signatures do not satisfy the chaincode install policy")
+	}
+
+	return nil
+}
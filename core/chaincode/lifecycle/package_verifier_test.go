@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/protoutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PackageSignatureVerifier", func() {
+	var (
+		fakePolicy *mock.InconvertiblePolicy
+		verifier   *lifecycle.PackageSignatureVerifier
+		pkg        *persistence.SignedChaincodePackage
+	)
+
+	BeforeEach(func() {
+		fakePolicy = &mock.InconvertiblePolicy{}
+		verifier = &lifecycle.PackageSignatureVerifier{Policy: fakePolicy}
+		pkg = &persistence.SignedChaincodePackage{
+			Package: []byte("cc-package"),
+			Signatures: []*persistence.PackageSignature{
+				{Identity: []byte("identity1"), Signature: []byte("signature1")},
+				{Identity: []byte("identity2"), Signature: []byte("signature2")},
+			},
+		}
+	})
+
+	It("evaluates the signatures over the package hash against the policy", func() {
+		err := verifier.Verify(pkg)
+		Expect(err).NotTo(HaveOccurred())
+
+		hash := util.ComputeSHA256([]byte("cc-package"))
+		Expect(fakePolicy.EvaluateSignedDataCallCount()).To(Equal(1))
+		Expect(fakePolicy.EvaluateSignedDataArgsForCall(0)).To(Equal([]*protoutil.SignedData{
+			{Data: hash, Identity: []byte("identity1"), Signature: []byte("signature1")},
+			{Data: hash, Identity: []byte("identity2"), Signature: []byte("signature2")},
+		}))
+	})
+
+	Context("when the package is not signed", func() {
+		BeforeEach(func() {
+			pkg.Signatures = nil
+		})
+
+		It("returns an error", func() {
+			err := verifier.Verify(pkg)
+			Expect(err).To(MatchError("chaincode package is not signed"))
+			Expect(fakePolicy.EvaluateSignedDataCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the signatures do not satisfy the policy", func() {
+		BeforeEach(func() {
+			fakePolicy.EvaluateSignedDataReturns(fmt.Errorf("policy-error"))
+		})
+
+		It("wraps and returns the error", func() {
+			err := verifier.Verify(pkg)
+			Expect(err).To(MatchError("chaincode package signatures do not satisfy the chaincode install policy: policy-error"))
+		})
+	})
+
+	Describe("NewPackageSignatureVerifier", func() {
+		It("creates a verifier for the policy", func() {
+			verifier, err := lifecycle.NewPackageSignatureVerifier("OR('ReleaseMSP.member')", &mock.MSPManager{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier.Policy).NotTo(BeNil())
+		})
+
+		Context("when the policy cannot be parsed", func() {
+			It("wraps and returns the error", func() {
+				_, err := lifecycle.NewPackageSignatureVerifier("bad-policy", &mock.MSPManager{})
+				Expect(err).To(MatchError(ContainSubstring("invalid chaincode install policy 'bad-policy'")))
+			})
+		})
+	})
+})
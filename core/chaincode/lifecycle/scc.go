@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/implicitcollection"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/msgs"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -39,6 +40,10 @@ const (
 	// a chaincode
 	InstallChaincodeFuncName = "InstallChaincode"
 
+	// UninstallChaincodeFuncName is the chaincode function name used to
+	// uninstall a chaincode
+	UninstallChaincodeFuncName = "UninstallChaincode"
+
 	// QueryInstalledChaincodeFuncName is the chaincode function name used to
 	// query an installed chaincode
 	QueryInstalledChaincodeFuncName = "QueryInstalledChaincode"
@@ -71,6 +76,11 @@ const (
 	// QueryChaincodeDefinitionsFuncName is the chaincode function name used to
 	// query the committed chaincode definitions in a channel.
 	QueryChaincodeDefinitionsFuncName = "QueryChaincodeDefinitions"
+
+	// QueryChaincodeDefinitionHistoryFuncName is the chaincode function name
+	// used to query the history of the committed definitions of a chaincode
+	// in a channel.
+	QueryChaincodeDefinitionHistoryFuncName = "QueryChaincodeDefinitionHistory"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
@@ -79,6 +89,9 @@ type SCCFunctions interface {
 	// InstallChaincode persists a chaincode definition to disk
 	InstallChaincode([]byte) (*chaincode.InstalledChaincode, error)
 
+	// UninstallChaincode removes a chaincode package and its build output from disk
+	UninstallChaincode(packageID string, force bool) (*chaincode.InstalledChaincode, error)
+
 	// QueryInstalledChaincode returns metadata for the chaincode with the supplied package ID.
 	QueryInstalledChaincode(packageID string) (*chaincode.InstalledChaincode, error)
 
@@ -109,6 +122,10 @@ type SCCFunctions interface {
 	// state.
 	QueryChaincodeDefinition(name string, publicState ReadableState) (*ChaincodeDefinition, error)
 
+	// QueryChaincodeDefinitionHistory returns every chaincode definition
+	// committed for a chaincode from the history of the public state.
+	QueryChaincodeDefinitionHistory(name string, publicState HistoricalState, orgState ReadableState) ([]*CommittedChaincodeDefinition, error)
+
 	// QueryOrgApprovals returns a map containing the orgs whose orgStates were
 	// supplied and whether or not they have approved a chaincode definition with
 	// the specified parameters.
@@ -136,6 +153,13 @@ type QueryExecutorProvider interface {
 	TxQueryExecutor(channelID, txID string) ledger.SimpleQueryExecutor
 }
 
+//go:generate counterfeiter -o mock/tx_block_finder.go --fake-name TxBlockFinder . TxBlockFinder
+
+// TxBlockFinder provides a way to find the block which committed a transaction.
+type TxBlockFinder interface {
+	TxBlockNumber(channelID, txID string) (uint64, error)
+}
+
 // SCC implements the required methods to satisfy the chaincode interface.
 // It routes the invocation calls to the backing implementations.
 type SCC struct {
@@ -147,6 +171,7 @@ type SCC struct {
 
 	DeployedCCInfoProvider ledger.DeployedChaincodeInfoProvider
 	QueryExecutorProvider  QueryExecutorProvider
+	TxBlockFinder          TxBlockFinder
 
 	// Functions provides the backing implementation of lifecycle.
 	Functions SCCFunctions
@@ -274,6 +299,39 @@ func (i *Invocation) InstallChaincode(input *lb.InstallChaincodeArgs) (proto.Mes
 	}, nil
 }
 
+// UninstallChaincode is a SCC function that may be dispatched to which routes
+// to the underlying lifecycle implementation.
+func (i *Invocation) UninstallChaincode(input *msgs.UninstallChaincodeArgs) (proto.Message, error) {
+	logger.Debugf("received invocation of UninstallChaincode for install package ID '%s' (force: %t)",
+		input.PackageId,
+		input.Force,
+	)
+
+	chaincode, err := i.SCC.Functions.UninstallChaincode(input.PackageId, input.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	references := map[string]*msgs.UninstallChaincodeResult_References{}
+	for channel, chaincodeMetadata := range chaincode.References {
+		chaincodes := make([]*msgs.UninstallChaincodeResult_Chaincode, len(chaincodeMetadata))
+		for i, metadata := range chaincodeMetadata {
+			chaincodes[i] = &msgs.UninstallChaincodeResult_Chaincode{
+				Name:    metadata.Name,
+				Version: metadata.Version,
+			}
+		}
+
+		references[channel] = &msgs.UninstallChaincodeResult_References{
+			Chaincodes: chaincodes,
+		}
+	}
+
+	return &msgs.UninstallChaincodeResult{
+		References: references,
+	}, nil
+}
+
 // QueryInstalledChaincode is a SCC function that may be dispatched to which
 // routes to the underlying lifecycle implementation.
 func (i *Invocation) QueryInstalledChaincode(input *lb.QueryInstalledChaincodeArgs) (proto.Message, error) {
@@ -635,6 +693,65 @@ func (i *Invocation) QueryChaincodeDefinitions(input *lb.QueryChaincodeDefinitio
 	}, nil
 }
 
+// QueryChaincodeDefinitionHistory is a SCC function that may be dispatched
+// to which routes to the underlying lifecycle implementation.
+func (i *Invocation) QueryChaincodeDefinitionHistory(input *msgs.QueryChaincodeDefinitionHistoryArgs) (proto.Message, error) {
+	logger.Debugf("received invocation of QueryChaincodeDefinitionHistory on channel '%s' for chaincode '%s'",
+		i.Stub.GetChannelID(),
+		input.Name,
+	)
+
+	history, err := i.SCC.Functions.QueryChaincodeDefinitionHistory(
+		input.Name,
+		&ChaincodePublicLedgerShim{ChaincodeStubInterface: i.Stub},
+		&ChaincodePrivateLedgerShim{
+			Collection: implicitcollection.NameForOrg(i.SCC.OrgMSPID),
+			Stub:       i.Stub,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opaqueStates, err := i.createOpaqueStates()
+	if err != nil {
+		return nil, err
+	}
+
+	chaincodeDefinitions := make([]*msgs.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition, 0, len(history))
+	for _, committed := range history {
+		approvals, err := i.SCC.Functions.QueryOrgApprovals(input.Name, committed.Definition, opaqueStates)
+		if err != nil {
+			return nil, err
+		}
+
+		blockNumber, err := i.SCC.TxBlockFinder.TxBlockNumber(i.Stub.GetChannelID(), committed.TxID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not find block for transaction %s", committed.TxID)
+		}
+
+		definedChaincode := committed.Definition
+		chaincodeDefinitions = append(chaincodeDefinitions, &msgs.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{
+			Sequence:            definedChaincode.Sequence,
+			Version:             definedChaincode.EndorsementInfo.Version,
+			EndorsementPlugin:   definedChaincode.EndorsementInfo.EndorsementPlugin,
+			ValidationPlugin:    definedChaincode.ValidationInfo.ValidationPlugin,
+			ValidationParameter: definedChaincode.ValidationInfo.ValidationParameter,
+			InitRequired:        definedChaincode.EndorsementInfo.InitRequired,
+			Collections:         definedChaincode.Collections,
+			Approvals:           approvals,
+			PackageId:           committed.PackageID,
+			BlockNumber:         blockNumber,
+			TxId:                committed.TxID,
+			Timestamp:           committed.Timestamp,
+		})
+	}
+
+	return &msgs.QueryChaincodeDefinitionHistoryResult{
+		ChaincodeDefinitions: chaincodeDefinitions,
+	}, nil
+}
+
 var (
 	// NOTE the chaincode name/version regular expressions should stay in sync
 	// with those defined in core/scc/lscc/lscc.go until LSCC has been removed.
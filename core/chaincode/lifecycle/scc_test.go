@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/msgs"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/msp"
@@ -45,6 +46,7 @@ var _ = Describe("SCC", func() {
 		fakeQueryExecutorProvider  *mock.QueryExecutorProvider
 		fakeQueryExecutor          *mock.SimpleQueryExecutor
 		fakeDeployedCCInfoProvider *mock.LegacyDeployedCCInfoProvider
+		fakeTxBlockFinder          *mock.TxBlockFinder
 		fakeStub                   *mock.ChaincodeStub
 	)
 
@@ -66,6 +68,7 @@ var _ = Describe("SCC", func() {
 		fakeQueryExecutorProvider.TxQueryExecutorReturns(fakeQueryExecutor)
 		fakeStub = &mock.ChaincodeStub{}
 		fakeDeployedCCInfoProvider = &mock.LegacyDeployedCCInfoProvider{}
+		fakeTxBlockFinder = &mock.TxBlockFinder{}
 
 		scc = &lifecycle.SCC{
 			Dispatcher: &dispatcher.Dispatcher{
@@ -77,6 +80,7 @@ var _ = Describe("SCC", func() {
 			ACLProvider:            fakeACLProvider,
 			QueryExecutorProvider:  fakeQueryExecutorProvider,
 			DeployedCCInfoProvider: fakeDeployedCCInfoProvider,
+			TxBlockFinder:          fakeTxBlockFinder,
 		}
 	})
 
@@ -199,6 +203,85 @@ var _ = Describe("SCC", func() {
 			})
 		})
 
+		Describe("UninstallChaincode", func() {
+			var (
+				arg          *msgs.UninstallChaincodeArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &msgs.UninstallChaincodeArgs{
+					PackageId: "awesome_package",
+					Force:     true,
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("UninstallChaincode"), marshaledArg})
+
+				fakeSCCFuncs.UninstallChaincodeReturns(&chaincode.InstalledChaincode{
+					PackageID: "awesome_package",
+					Label:     "awesome_package_label",
+					References: map[string][]*chaincode.Metadata{
+						"test-channel": {
+							&chaincode.Metadata{
+								Name:    "cc0",
+								Version: "cc0-version",
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &msgs.UninstallChaincodeResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload.References).To(HaveLen(1))
+				Expect(proto.Equal(payload.References["test-channel"], &msgs.UninstallChaincodeResult_References{
+					Chaincodes: []*msgs.UninstallChaincodeResult_Chaincode{
+						{
+							Name:    "cc0",
+							Version: "cc0-version",
+						},
+					},
+				})).To(BeTrue())
+
+				Expect(fakeSCCFuncs.UninstallChaincodeCallCount()).To(Equal(1))
+				packageID, force := fakeSCCFuncs.UninstallChaincodeArgsForCall(0)
+				Expect(packageID).To(Equal("awesome_package"))
+				Expect(force).To(BeTrue())
+			})
+
+			Context("when the code package cannot be found", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.UninstallChaincodeReturns(nil, persistence.CodePackageNotFoundErr{PackageID: "less_awesome_package"})
+				})
+
+				It("returns 404 Not Found", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(404)))
+					Expect(res.Message).To(Equal("chaincode install package 'less_awesome_package' not found"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.UninstallChaincodeReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'UninstallChaincode': underlying-error"))
+				})
+			})
+		})
+
 		Describe("QueryInstalledChaincode", func() {
 			var (
 				arg          *lb.QueryInstalledChaincodeArgs
@@ -1553,6 +1636,191 @@ var _ = Describe("SCC", func() {
 			})
 		})
 
+		Describe("QueryChaincodeDefinitionHistory", func() {
+			var (
+				arg            *msgs.QueryChaincodeDefinitionHistoryArgs
+				marshaledArg   []byte
+				fakeOrgConfigs []*mock.ApplicationOrgConfig
+			)
+
+			BeforeEach(func() {
+				arg = &msgs.QueryChaincodeDefinitionHistoryArgs{
+					Name: "cc-name",
+				}
+
+				var err error
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeOrgConfigs = []*mock.ApplicationOrgConfig{{}, {}}
+				fakeOrgConfigs[0].MSPIDReturns("fake-mspid")
+				fakeOrgConfigs[1].MSPIDReturns("other-mspid")
+
+				fakeApplicationConfig.OrganizationsReturns(map[string]channelconfig.ApplicationOrg{
+					"org0": fakeOrgConfigs[0],
+					"org1": fakeOrgConfigs[1],
+				})
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryChaincodeDefinitionHistory"), marshaledArg})
+				fakeStub.GetChannelIDReturns("test-channel")
+				fakeSCCFuncs.QueryChaincodeDefinitionHistoryReturns(
+					[]*lifecycle.CommittedChaincodeDefinition{
+						{
+							Definition: &lifecycle.ChaincodeDefinition{
+								Sequence: 1,
+								EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+									Version:           "version-1",
+									EndorsementPlugin: "endorsement-plugin",
+								},
+								ValidationInfo: &lb.ChaincodeValidationInfo{
+									ValidationPlugin:    "validation-plugin",
+									ValidationParameter: []byte("validation-parameter"),
+								},
+								Collections: &pb.CollectionConfigPackage{},
+							},
+							TxID: "tx-1",
+						},
+						{
+							Definition: &lifecycle.ChaincodeDefinition{
+								Sequence: 2,
+								EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+									Version:           "version-2",
+									EndorsementPlugin: "endorsement-plugin",
+								},
+								ValidationInfo: &lb.ChaincodeValidationInfo{
+									ValidationPlugin:    "validation-plugin",
+									ValidationParameter: []byte("validation-parameter"),
+								},
+								Collections: &pb.CollectionConfigPackage{},
+							},
+							PackageID: "hash",
+							TxID:      "tx-2",
+						},
+					},
+					nil,
+				)
+
+				fakeSCCFuncs.QueryOrgApprovalsReturnsOnCall(0, map[string]bool{"fake-mspid": true, "other-mspid": false}, nil)
+				fakeSCCFuncs.QueryOrgApprovalsReturnsOnCall(1, map[string]bool{"fake-mspid": true, "other-mspid": true}, nil)
+				fakeTxBlockFinder.TxBlockNumberReturnsOnCall(0, 5, nil)
+				fakeTxBlockFinder.TxBlockNumberReturnsOnCall(1, 9, nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &msgs.QueryChaincodeDefinitionHistoryResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(payload, &msgs.QueryChaincodeDefinitionHistoryResult{
+					ChaincodeDefinitions: []*msgs.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{
+						{
+							Sequence:            1,
+							Version:             "version-1",
+							EndorsementPlugin:   "endorsement-plugin",
+							ValidationPlugin:    "validation-plugin",
+							ValidationParameter: []byte("validation-parameter"),
+							Collections:         &pb.CollectionConfigPackage{},
+							Approvals:           map[string]bool{"fake-mspid": true, "other-mspid": false},
+							BlockNumber:         5,
+							TxId:                "tx-1",
+						},
+						{
+							Sequence:            2,
+							Version:             "version-2",
+							EndorsementPlugin:   "endorsement-plugin",
+							ValidationPlugin:    "validation-plugin",
+							ValidationParameter: []byte("validation-parameter"),
+							Collections:         &pb.CollectionConfigPackage{},
+							Approvals:           map[string]bool{"fake-mspid": true, "other-mspid": true},
+							PackageId:           "hash",
+							BlockNumber:         9,
+							TxId:                "tx-2",
+						},
+					},
+				})).To(BeTrue())
+
+				Expect(fakeSCCFuncs.QueryChaincodeDefinitionHistoryCallCount()).To(Equal(1))
+				name, pubState, orgState := fakeSCCFuncs.QueryChaincodeDefinitionHistoryArgsForCall(0)
+				Expect(name).To(Equal("cc-name"))
+				Expect(pubState).To(Equal(&lifecycle.ChaincodePublicLedgerShim{ChaincodeStubInterface: fakeStub}))
+				Expect(orgState).To(Equal(&lifecycle.ChaincodePrivateLedgerShim{
+					Collection: "_implicit_org_fake-mspid",
+					Stub:       fakeStub,
+				}))
+
+				Expect(fakeSCCFuncs.QueryOrgApprovalsCallCount()).To(Equal(2))
+				_, cd, orgStates := fakeSCCFuncs.QueryOrgApprovalsArgsForCall(1)
+				Expect(cd.Sequence).To(Equal(int64(2)))
+				Expect(orgStates).To(HaveLen(2))
+
+				Expect(fakeTxBlockFinder.TxBlockNumberCallCount()).To(Equal(2))
+				channelID, txID := fakeTxBlockFinder.TxBlockNumberArgsForCall(1)
+				Expect(channelID).To(Equal("test-channel"))
+				Expect(txID).To(Equal("tx-2"))
+			})
+
+			Context("when the underlying QueryChaincodeDefinitionHistory function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryChaincodeDefinitionHistoryReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'QueryChaincodeDefinitionHistory': underlying-error"))
+				})
+			})
+
+			Context("when the namespace cannot be found", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryChaincodeDefinitionHistoryReturns(nil, lifecycle.ErrNamespaceNotDefined{Namespace: "nicetry"})
+				})
+
+				It("returns 404 Not Found", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(404)))
+					Expect(res.Message).To(Equal("namespace nicetry is not defined"))
+				})
+			})
+
+			Context("when the underlying QueryOrgApprovals function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryOrgApprovalsReturnsOnCall(1, nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'QueryChaincodeDefinitionHistory': underlying-error"))
+				})
+			})
+
+			Context("when the block of a transaction cannot be found", func() {
+				BeforeEach(func() {
+					fakeTxBlockFinder.TxBlockNumberReturnsOnCall(1, 0, fmt.Errorf("block-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'QueryChaincodeDefinitionHistory': could not find block for transaction tx-2: block-error"))
+				})
+			})
+
+			Context("when there is no application config because there is no channel", func() {
+				BeforeEach(func() {
+					fakeStub.GetChannelIDReturns("")
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'QueryChaincodeDefinitionHistory': no application config for channel ''"))
+				})
+			})
+		})
+
 		Describe("QueryChaincodeDefinitions", func() {
 			var (
 				arg          *lb.QueryChaincodeDefinitionsArgs
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/common/util"
 
@@ -42,6 +43,10 @@ type RangeableState interface {
 	GetStateRange(prefix string) (map[string][]byte, error)
 }
 
+type HistoricalState interface {
+	GetStateHistory(key string) ([]*queryresult.KeyModification, error)
+}
+
 type Marshaler func(proto.Message) ([]byte, error)
 
 func (m Marshaler) Marshal(msg proto.Message) ([]byte, error) {
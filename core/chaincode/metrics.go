@@ -6,7 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package chaincode
 
-import "github.com/hyperledger/fabric/common/metrics"
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+)
 
 var (
 	launchDuration = metrics.HistogramOpts{
@@ -30,6 +33,34 @@ var (
 		LabelNames:   []string{"chaincode"},
 		StatsdFormat: "%{#fqname}.%{chaincode}",
 	}
+	launches = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "launches",
+		Help:         "The number of chaincode runtime launches that have been started.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
+	idleStops = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "idle_stops",
+		Help:         "The number of chaincode runtimes stopped after being idle.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
+	coldStartDuration = metrics.HistogramOpts{
+		Namespace:    "chaincode",
+		Name:         "cold_start_duration",
+		Help:         "The time an invocation waited for a chaincode which was not running to be launched.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
+	restarts = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "restarts",
+		Help:         "The number of attempts to relaunch a chaincode which exited unexpectedly.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
 
 	shimRequestsReceived = metrics.CounterOpts{
 		Namespace:    "chaincode",
@@ -59,34 +90,133 @@ var (
 		LabelNames:   []string{"chaincode"},
 		StatsdFormat: "%{#fqname}.%{chaincode}",
 	}
+
+	txStateReads = metrics.HistogramOpts{
+		Namespace:    "chaincode",
+		Name:         "tx_state_reads",
+		Help:         "The number of state reads performed while simulating a transaction.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+		Buckets:      []float64{1, 10, 100, 1000, 10000, 100000},
+	}
+	txKeysScanned = metrics.HistogramOpts{
+		Namespace:    "chaincode",
+		Name:         "tx_keys_scanned",
+		Help:         "The number of keys returned by range and rich queries while simulating a transaction.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+		Buckets:      []float64{1, 10, 100, 1000, 10000, 100000},
+	}
+	txBytesRead = metrics.HistogramOpts{
+		Namespace:    "chaincode",
+		Name:         "tx_bytes_read",
+		Help:         "The number of bytes read from the ledger while simulating a transaction.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+		Buckets:      []float64{1024, 16384, 131072, 1048576, 8388608, 67108864},
+	}
+	txBytesWritten = metrics.HistogramOpts{
+		Namespace:    "chaincode",
+		Name:         "tx_bytes_written",
+		Help:         "The number of bytes written while simulating a transaction.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+		Buckets:      []float64{1024, 16384, 131072, 1048576, 8388608, 67108864},
+	}
+	txWrites = metrics.HistogramOpts{
+		Namespace:    "chaincode",
+		Name:         "tx_writes",
+		Help:         "The number of writes and deletes performed while simulating a transaction.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+		Buckets:      []float64{1, 10, 100, 1000, 10000, 100000},
+	}
+	txCallDepth = metrics.HistogramOpts{
+		Namespace:    "chaincode",
+		Name:         "tx_call_depth",
+		Help:         "The deepest chaincode-to-chaincode invocation made while simulating a transaction.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+		Buckets:      []float64{0, 1, 2, 4, 8, 16},
+	}
+	resourceLimitsExceeded = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "resource_limits_exceeded",
+		Help:         "The number of transactions aborted because chaincode exceeded a resource limit.",
+		LabelNames:   []string{"chaincode", "resource"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{resource}",
+	}
 )
 
 type HandlerMetrics struct {
-	ShimRequestsReceived  metrics.Counter
-	ShimRequestsCompleted metrics.Counter
-	ShimRequestDuration   metrics.Histogram
-	ExecuteTimeouts       metrics.Counter
+	ShimRequestsReceived   metrics.Counter
+	ShimRequestsCompleted  metrics.Counter
+	ShimRequestDuration    metrics.Histogram
+	ExecuteTimeouts        metrics.Counter
+	TxStateReads           metrics.Histogram
+	TxKeysScanned          metrics.Histogram
+	TxBytesRead            metrics.Histogram
+	TxBytesWritten         metrics.Histogram
+	TxWrites               metrics.Histogram
+	TxCallDepth            metrics.Histogram
+	ResourceLimitsExceeded metrics.Counter
 }
 
 func NewHandlerMetrics(p metrics.Provider) *HandlerMetrics {
 	return &HandlerMetrics{
-		ShimRequestsReceived:  p.NewCounter(shimRequestsReceived),
-		ShimRequestsCompleted: p.NewCounter(shimRequestsCompleted),
-		ShimRequestDuration:   p.NewHistogram(shimRequestDuration),
-		ExecuteTimeouts:       p.NewCounter(executeTimeouts),
+		ShimRequestsReceived:   p.NewCounter(shimRequestsReceived),
+		ShimRequestsCompleted:  p.NewCounter(shimRequestsCompleted),
+		ShimRequestDuration:    p.NewHistogram(shimRequestDuration),
+		ExecuteTimeouts:        p.NewCounter(executeTimeouts),
+		TxStateReads:           p.NewHistogram(txStateReads),
+		TxKeysScanned:          p.NewHistogram(txKeysScanned),
+		TxBytesRead:            p.NewHistogram(txBytesRead),
+		TxBytesWritten:         p.NewHistogram(txBytesWritten),
+		TxWrites:               p.NewHistogram(txWrites),
+		TxCallDepth:            p.NewHistogram(txCallDepth),
+		ResourceLimitsExceeded: p.NewCounter(resourceLimitsExceeded),
+	}
+}
+
+// resourceHistogram returns the histogram which records the usage of the
+// resource per transaction.
+func (m *HandlerMetrics) resourceHistogram(r ccprovider.Resource) metrics.Histogram {
+	switch r {
+	case ccprovider.StateReads:
+		return m.TxStateReads
+	case ccprovider.KeysScanned:
+		return m.TxKeysScanned
+	case ccprovider.BytesRead:
+		return m.TxBytesRead
+	case ccprovider.BytesWritten:
+		return m.TxBytesWritten
+	case ccprovider.Writes:
+		return m.TxWrites
+	case ccprovider.CallDepth:
+		return m.TxCallDepth
+	default:
+		return nil
 	}
 }
 
 type LaunchMetrics struct {
-	LaunchDuration metrics.Histogram
-	LaunchFailures metrics.Counter
-	LaunchTimeouts metrics.Counter
+	LaunchDuration    metrics.Histogram
+	LaunchFailures    metrics.Counter
+	LaunchTimeouts    metrics.Counter
+	Launches          metrics.Counter
+	IdleStops         metrics.Counter
+	ColdStartDuration metrics.Histogram
+	Restarts          metrics.Counter
 }
 
 func NewLaunchMetrics(p metrics.Provider) *LaunchMetrics {
 	return &LaunchMetrics{
-		LaunchDuration: p.NewHistogram(launchDuration),
-		LaunchFailures: p.NewCounter(launchFailures),
-		LaunchTimeouts: p.NewCounter(launchTimeouts),
+		LaunchDuration:    p.NewHistogram(launchDuration),
+		LaunchFailures:    p.NewCounter(launchFailures),
+		LaunchTimeouts:    p.NewCounter(launchTimeouts),
+		Launches:          p.NewCounter(launches),
+		IdleStops:         p.NewCounter(idleStops),
+		ColdStartDuration: p.NewHistogram(coldStartDuration),
+		Restarts:          p.NewCounter(restarts),
 	}
 }
@@ -66,6 +66,16 @@ type ApplicationCapabilities struct {
 	metadataLifecycleReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	MultipleChaincodeEventsStub        func() bool
+	multipleChaincodeEventsMutex       sync.RWMutex
+	multipleChaincodeEventsArgsForCall []struct {
+	}
+	multipleChaincodeEventsReturns struct {
+		result1 bool
+	}
+	multipleChaincodeEventsReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	PrivateChannelDataStub        func() bool
 	privateChannelDataMutex       sync.RWMutex
 	privateChannelDataArgsForCall []struct {
@@ -452,6 +462,58 @@ func (fake *ApplicationCapabilities) MetadataLifecycleReturnsOnCall(i int, resul
 	}{result1}
 }
 
+func (fake *ApplicationCapabilities) MultipleChaincodeEvents() bool {
+	fake.multipleChaincodeEventsMutex.Lock()
+	ret, specificReturn := fake.multipleChaincodeEventsReturnsOnCall[len(fake.multipleChaincodeEventsArgsForCall)]
+	fake.multipleChaincodeEventsArgsForCall = append(fake.multipleChaincodeEventsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MultipleChaincodeEvents", []interface{}{})
+	fake.multipleChaincodeEventsMutex.Unlock()
+	if fake.MultipleChaincodeEventsStub != nil {
+		return fake.MultipleChaincodeEventsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.multipleChaincodeEventsReturns
+	return fakeReturns.result1
+}
+
+func (fake *ApplicationCapabilities) MultipleChaincodeEventsCallCount() int {
+	fake.multipleChaincodeEventsMutex.RLock()
+	defer fake.multipleChaincodeEventsMutex.RUnlock()
+	return len(fake.multipleChaincodeEventsArgsForCall)
+}
+
+func (fake *ApplicationCapabilities) MultipleChaincodeEventsCalls(stub func() bool) {
+	fake.multipleChaincodeEventsMutex.Lock()
+	defer fake.multipleChaincodeEventsMutex.Unlock()
+	fake.MultipleChaincodeEventsStub = stub
+}
+
+func (fake *ApplicationCapabilities) MultipleChaincodeEventsReturns(result1 bool) {
+	fake.multipleChaincodeEventsMutex.Lock()
+	defer fake.multipleChaincodeEventsMutex.Unlock()
+	fake.MultipleChaincodeEventsStub = nil
+	fake.multipleChaincodeEventsReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ApplicationCapabilities) MultipleChaincodeEventsReturnsOnCall(i int, result1 bool) {
+	fake.multipleChaincodeEventsMutex.Lock()
+	defer fake.multipleChaincodeEventsMutex.Unlock()
+	fake.MultipleChaincodeEventsStub = nil
+	if fake.multipleChaincodeEventsReturnsOnCall == nil {
+		fake.multipleChaincodeEventsReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.multipleChaincodeEventsReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *ApplicationCapabilities) PrivateChannelData() bool {
 	fake.privateChannelDataMutex.Lock()
 	ret, specificReturn := fake.privateChannelDataReturnsOnCall[len(fake.privateChannelDataArgsForCall)]
@@ -831,6 +893,8 @@ func (fake *ApplicationCapabilities) Invocations() map[string][][]interface{} {
 	defer fake.lifecycleV20Mutex.RUnlock()
 	fake.metadataLifecycleMutex.RLock()
 	defer fake.metadataLifecycleMutex.RUnlock()
+	fake.multipleChaincodeEventsMutex.RLock()
+	defer fake.multipleChaincodeEventsMutex.RUnlock()
 	fake.privateChannelDataMutex.RLock()
 	defer fake.privateChannelDataMutex.RUnlock()
 	fake.storePvtDataOfInvalidTxMutex.RLock()
@@ -21,11 +21,12 @@ type Runtime struct {
 		result1 *ccintf.ChaincodeServerInfo
 		result2 error
 	}
-	StartStub        func(string, *ccintf.PeerConnection) error
+	StartStub        func(string, int, *ccintf.PeerConnection) error
 	startMutex       sync.RWMutex
 	startArgsForCall []struct {
 		arg1 string
-		arg2 *ccintf.PeerConnection
+		arg2 int
+		arg3 *ccintf.PeerConnection
 	}
 	startReturns struct {
 		result1 error
@@ -44,10 +45,11 @@ type Runtime struct {
 	stopReturnsOnCall map[int]struct {
 		result1 error
 	}
-	WaitStub        func(string) (int, error)
+	WaitStub        func(string, int) (int, error)
 	waitMutex       sync.RWMutex
 	waitArgsForCall []struct {
 		arg1 string
+		arg2 int
 	}
 	waitReturns struct {
 		result1 int
@@ -124,17 +126,18 @@ func (fake *Runtime) BuildReturnsOnCall(i int, result1 *ccintf.ChaincodeServerIn
 	}{result1, result2}
 }
 
-func (fake *Runtime) Start(arg1 string, arg2 *ccintf.PeerConnection) error {
+func (fake *Runtime) Start(arg1 string, arg2 int, arg3 *ccintf.PeerConnection) error {
 	fake.startMutex.Lock()
 	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
 	fake.startArgsForCall = append(fake.startArgsForCall, struct {
 		arg1 string
-		arg2 *ccintf.PeerConnection
-	}{arg1, arg2})
-	fake.recordInvocation("Start", []interface{}{arg1, arg2})
+		arg2 int
+		arg3 *ccintf.PeerConnection
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Start", []interface{}{arg1, arg2, arg3})
 	fake.startMutex.Unlock()
 	if fake.StartStub != nil {
-		return fake.StartStub(arg1, arg2)
+		return fake.StartStub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -149,17 +152,17 @@ func (fake *Runtime) StartCallCount() int {
 	return len(fake.startArgsForCall)
 }
 
-func (fake *Runtime) StartCalls(stub func(string, *ccintf.PeerConnection) error) {
+func (fake *Runtime) StartCalls(stub func(string, int, *ccintf.PeerConnection) error) {
 	fake.startMutex.Lock()
 	defer fake.startMutex.Unlock()
 	fake.StartStub = stub
 }
 
-func (fake *Runtime) StartArgsForCall(i int) (string, *ccintf.PeerConnection) {
+func (fake *Runtime) StartArgsForCall(i int) (string, int, *ccintf.PeerConnection) {
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
 	argsForCall := fake.startArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *Runtime) StartReturns(result1 error) {
@@ -245,16 +248,17 @@ func (fake *Runtime) StopReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *Runtime) Wait(arg1 string) (int, error) {
+func (fake *Runtime) Wait(arg1 string, arg2 int) (int, error) {
 	fake.waitMutex.Lock()
 	ret, specificReturn := fake.waitReturnsOnCall[len(fake.waitArgsForCall)]
 	fake.waitArgsForCall = append(fake.waitArgsForCall, struct {
 		arg1 string
-	}{arg1})
-	fake.recordInvocation("Wait", []interface{}{arg1})
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("Wait", []interface{}{arg1, arg2})
 	fake.waitMutex.Unlock()
 	if fake.WaitStub != nil {
-		return fake.WaitStub(arg1)
+		return fake.WaitStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -269,17 +273,17 @@ func (fake *Runtime) WaitCallCount() int {
 	return len(fake.waitArgsForCall)
 }
 
-func (fake *Runtime) WaitCalls(stub func(string) (int, error)) {
+func (fake *Runtime) WaitCalls(stub func(string, int) (int, error)) {
 	fake.waitMutex.Lock()
 	defer fake.waitMutex.Unlock()
 	fake.WaitStub = stub
 }
 
-func (fake *Runtime) WaitArgsForCall(i int) string {
+func (fake *Runtime) WaitArgsForCall(i int) (string, int) {
 	fake.waitMutex.RLock()
 	defer fake.waitMutex.RUnlock()
 	argsForCall := fake.waitArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *Runtime) WaitReturns(result1 int, result2 error) {
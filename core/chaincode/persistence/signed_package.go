@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package persistence
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/pkg/errors"
+)
+
+// A signed chaincode package is a .tar.gz file which wraps an unmodified
+// chaincode package along with signatures over its hash. Signing a package
+// therefore does not change its package ID, and a package may be signed by
+// several identities one after the other.
+
+const (
+	// SignedPackageFile is the expected location of the chaincode package
+	// in the top level of a signed chaincode package.
+	SignedPackageFile = "package.tar.gz"
+
+	// SignaturesFile is the expected location of the signatures json
+	// document in the top level of a signed chaincode package.
+	SignaturesFile = "signatures.json"
+)
+
+// PackageSignature is a signature over the hash of a chaincode package
+// along with the serialized identity which created it.
+type PackageSignature struct {
+	Identity  []byte `json:"identity"`
+	Signature []byte `json:"signature"`
+}
+
+// SignedChaincodePackage is a chaincode package along with the signatures
+// over its hash.
+type SignedChaincodePackage struct {
+	Package    []byte
+	Signatures []*PackageSignature
+}
+
+// Hash returns the hash of the chaincode package, which is the message
+// signed by the package signatures.
+func (s *SignedChaincodePackage) Hash() []byte {
+	return util.ComputeSHA256(s.Package)
+}
+
+// Bytes returns the signed chaincode package as a .tar.gz file. A package
+// without any signatures is returned unmodified.
+func (s *SignedChaincodePackage) Bytes() ([]byte, error) {
+	if len(s.Signatures) == 0 {
+		return s.Package, nil
+	}
+
+	signaturesBytes, err := json.Marshal(s.Signatures)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal package signatures")
+	}
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{
+		{name: SignaturesFile, content: signaturesBytes},
+		{name: SignedPackageFile, content: s.Package},
+	} {
+		err := tw.WriteHeader(&tar.Header{
+			Name: file.name,
+			Size: int64(len(file.content)),
+			Mode: 0o100644,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not write %s to tar", file.name)
+		}
+		if _, err := tw.Write(file.content); err != nil {
+			return nil, errors.Wrapf(err, "could not write %s to tar", file.name)
+		}
+	}
+
+	err = tw.Close()
+	if err == nil {
+		err = gw.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create signed chaincode package")
+	}
+
+	return payload.Bytes(), nil
+}
+
+// ParseSignedChaincodePackage parses a set of bytes as a signed chaincode
+// package. Any other set of bytes, such as a chaincode package which is not
+// signed, is returned as a signed chaincode package without any signatures
+// and is left to the chaincode package parser to validate.
+func ParseSignedChaincodePackage(source []byte) (*SignedChaincodePackage, error) {
+	unsigned := &SignedChaincodePackage{Package: source}
+
+	gzReader, err := gzip.NewReader(bytes.NewBuffer(source))
+	if err != nil {
+		return unsigned, nil
+	}
+
+	tarReader := tar.NewReader(gzReader)
+
+	var pkg, signaturesBytes []byte
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			if pkg == nil && signaturesBytes == nil {
+				return unsigned, nil
+			}
+			return nil, errors.Wrapf(err, "error inspecting next tar header")
+		}
+
+		if header.Name != SignedPackageFile && header.Name != SignaturesFile {
+			continue
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			return nil, errors.Errorf("tar entry %s is not a regular file, type %v", header.Name, header.Typeflag)
+		}
+
+		fileBytes, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s from tar", header.Name)
+		}
+
+		if header.Name == SignedPackageFile {
+			pkg = fileBytes
+		} else {
+			signaturesBytes = fileBytes
+		}
+	}
+
+	if pkg == nil {
+		if signaturesBytes != nil {
+			return nil, errors.Errorf("did not find a chaincode package inside the signed package (missing %s)", SignedPackageFile)
+		}
+		return unsigned, nil
+	}
+
+	var signatures []*PackageSignature
+	if signaturesBytes != nil {
+		if err := json.Unmarshal(signaturesBytes, &signatures); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal %s as json", SignaturesFile)
+		}
+	}
+
+	return &SignedChaincodePackage{
+		Package:    pkg,
+		Signatures: signatures,
+	}, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package persistence_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SignedChaincodePackage", func() {
+	var pkgBytes []byte
+
+	BeforeEach(func() {
+		var err error
+		pkgBytes, err = ioutil.ReadFile("testdata/good-package.tar.gz")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("round trips the package and its signatures", func() {
+		signed := &persistence.SignedChaincodePackage{
+			Package: pkgBytes,
+			Signatures: []*persistence.PackageSignature{
+				{Identity: []byte("identity1"), Signature: []byte("signature1")},
+				{Identity: []byte("identity2"), Signature: []byte("signature2")},
+			},
+		}
+
+		signedBytes, err := signed.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signedBytes).NotTo(Equal(pkgBytes))
+
+		parsed, err := persistence.ParseSignedChaincodePackage(signedBytes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(Equal(signed))
+		Expect(parsed.Hash()).To(Equal(util.ComputeSHA256(pkgBytes)))
+	})
+
+	It("parses an unsigned package as a package without signatures", func() {
+		parsed, err := persistence.ParseSignedChaincodePackage(pkgBytes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.Package).To(Equal(pkgBytes))
+		Expect(parsed.Signatures).To(BeEmpty())
+
+		unsignedBytes, err := parsed.Bytes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unsignedBytes).To(Equal(pkgBytes))
+	})
+
+	Context("when the package is not a gzip stream", func() {
+		It("leaves the package to the chaincode package parser", func() {
+			parsed, err := persistence.ParseSignedChaincodePackage([]byte("bad-package"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed.Package).To(Equal([]byte("bad-package")))
+			Expect(parsed.Signatures).To(BeEmpty())
+		})
+	})
+
+	Context("when the signed package is missing the chaincode package", func() {
+		It("returns an error", func() {
+			_, err := persistence.ParseSignedChaincodePackage(tarGz(map[string][]byte{
+				persistence.SignaturesFile: []byte("[]"),
+			}))
+			Expect(err).To(MatchError("did not find a chaincode package inside the signed package (missing package.tar.gz)"))
+		})
+	})
+
+	Context("when the signatures are not json", func() {
+		It("returns an error", func() {
+			_, err := persistence.ParseSignedChaincodePackage(tarGz(map[string][]byte{
+				persistence.SignedPackageFile: pkgBytes,
+				persistence.SignaturesFile:    []byte("bad-json"),
+			}))
+			Expect(err).To(MatchError(ContainSubstring("could not unmarshal signatures.json as json")))
+		})
+	})
+})
+
+func tarGz(files map[string][]byte) []byte {
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Size: int64(len(content)),
+			Mode: 0o100644,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tw.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(gw.Close()).To(Succeed())
+	return payload.Bytes()
+}
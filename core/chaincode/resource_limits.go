@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+)
+
+// ResourceLimits bounds the resources chaincode may consume while a single
+// transaction is simulated. A limit of zero means the resource is not
+// limited.
+type ResourceLimits struct {
+	MaxStateReads   uint64
+	MaxKeysScanned  uint64
+	MaxBytesRead    uint64
+	MaxBytesWritten uint64
+	MaxWrites       uint64
+	MaxCallDepth    uint64
+}
+
+// Limit returns the limit for the resource.
+func (l ResourceLimits) Limit(r ccprovider.Resource) uint64 {
+	switch r {
+	case ccprovider.StateReads:
+		return l.MaxStateReads
+	case ccprovider.KeysScanned:
+		return l.MaxKeysScanned
+	case ccprovider.BytesRead:
+		return l.MaxBytesRead
+	case ccprovider.BytesWritten:
+		return l.MaxBytesWritten
+	case ccprovider.Writes:
+		return l.MaxWrites
+	case ccprovider.CallDepth:
+		return l.MaxCallDepth
+	default:
+		return 0
+	}
+}
+
+// ResourceLimitsConfig holds the peer-wide resource limits along with the
+// limits configured for specific chaincodes.
+type ResourceLimitsConfig struct {
+	ResourceLimits `mapstructure:",squash"`
+	// Chaincodes holds the limits for specific chaincodes keyed by
+	// chaincode name. They replace the peer-wide limits.
+	Chaincodes map[string]ResourceLimits
+}
+
+// ForChaincode returns the limits which apply to the named chaincode.
+func (c *ResourceLimitsConfig) ForChaincode(name string) ResourceLimits {
+	if c == nil {
+		return ResourceLimits{}
+	}
+	if l, ok := c.Chaincodes[strings.ToLower(name)]; ok {
+		return l
+	}
+	return c.ResourceLimits
+}
@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RestartTracker decides when chaincodes which exited unexpectedly are
+// relaunched. The delay before a relaunch starts at InitialBackoff and
+// doubles after every failed relaunch, up to MaxBackoff. The backoff is
+// reset once a chaincode has run for longer than MaxBackoff.
+type RestartTracker struct {
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	mutex      sync.Mutex
+	chaincodes map[string]*restartState
+}
+
+type restartState struct {
+	stopped  bool          // the chaincode was stopped deliberately
+	pending  bool          // a relaunch of the chaincode is in progress
+	restarts int           // the number of relaunch attempts
+	backoff  time.Duration // the delay before the last relaunch attempt
+	launched time.Time     // the time the chaincode was last launched
+}
+
+func (t *RestartTracker) state(ccid string) *restartState {
+	if t.chaincodes == nil {
+		t.chaincodes = map[string]*restartState{}
+	}
+	s, ok := t.chaincodes[ccid]
+	if !ok {
+		s = &restartState{}
+		t.chaincodes[ccid] = s
+	}
+	return s
+}
+
+// Launched records that the chaincode has been launched successfully.
+func (t *RestartTracker) Launched(ccid string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s := t.state(ccid)
+	s.stopped = false
+	s.pending = false
+	s.launched = time.Now()
+}
+
+// Stopping records that the chaincode is being stopped deliberately and
+// must not be relaunched when it exits.
+func (t *RestartTracker) Stopping(ccid string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.state(ccid).stopped = true
+}
+
+// Exited records that the chaincode has exited. It returns the delay after
+// which the chaincode should be relaunched, or false if it must not be
+// relaunched because it was stopped deliberately or is already being
+// relaunched.
+func (t *RestartTracker) Exited(ccid string) (time.Duration, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s := t.state(ccid)
+	if s.stopped || s.pending {
+		return 0, false
+	}
+
+	if s.backoff == 0 || time.Since(s.launched) > t.MaxBackoff {
+		s.backoff = t.InitialBackoff
+	} else {
+		s.backoff = t.nextBackoff(s.backoff)
+	}
+	s.pending = true
+	s.restarts++
+
+	return s.backoff, true
+}
+
+// Relaunching reports whether a pending relaunch of the chaincode should
+// proceed. A relaunch is abandoned if the chaincode has been stopped
+// deliberately in the meantime.
+func (t *RestartTracker) Relaunching(ccid string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s := t.state(ccid)
+	if s.stopped {
+		s.pending = false
+	}
+	return s.pending
+}
+
+// RelaunchFailed records that a relaunch of the chaincode has failed and
+// returns the delay before the next attempt.
+func (t *RestartTracker) RelaunchFailed(ccid string) time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	s := t.state(ccid)
+	s.backoff = t.nextBackoff(s.backoff)
+	s.restarts++
+
+	return s.backoff
+}
+
+// HealthCheck returns an error while any chaincode which exited
+// unexpectedly has not yet been relaunched successfully.
+func (t *RestartTracker) HealthCheck(ctx context.Context) error {
+	t.mutex.Lock()
+	var failing []string
+	for ccid, s := range t.chaincodes {
+		if s.pending {
+			failing = append(failing, fmt.Sprintf("%s (restarts: %d)", ccid, s.restarts))
+		}
+	}
+	t.mutex.Unlock()
+
+	if len(failing) == 0 {
+		return nil
+	}
+
+	sort.Strings(failing)
+	return fmt.Errorf("chaincodes exited unexpectedly and are being relaunched: %s", strings.Join(failing, ", "))
+}
+
+func (t *RestartTracker) nextBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff < t.InitialBackoff {
+		backoff = t.InitialBackoff
+	}
+	if backoff > t.MaxBackoff {
+		backoff = t.MaxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RestartTracker", func() {
+	var restartTracker *chaincode.RestartTracker
+
+	BeforeEach(func() {
+		restartTracker = &chaincode.RestartTracker{
+			InitialBackoff: time.Second,
+			MaxBackoff:     5 * time.Second,
+		}
+		restartTracker.Launched("ccid")
+	})
+
+	It("relaunches an exited chaincode after the initial backoff", func() {
+		backoff, ok := restartTracker.Exited("ccid")
+		Expect(ok).To(BeTrue())
+		Expect(backoff).To(Equal(time.Second))
+		Expect(restartTracker.Relaunching("ccid")).To(BeTrue())
+	})
+
+	It("doubles the backoff up to the maximum when relaunches fail", func() {
+		restartTracker.Exited("ccid")
+		Expect(restartTracker.RelaunchFailed("ccid")).To(Equal(2 * time.Second))
+		Expect(restartTracker.RelaunchFailed("ccid")).To(Equal(4 * time.Second))
+		Expect(restartTracker.RelaunchFailed("ccid")).To(Equal(5 * time.Second))
+		Expect(restartTracker.RelaunchFailed("ccid")).To(Equal(5 * time.Second))
+	})
+
+	It("increases the backoff when a relaunched chaincode exits again quickly", func() {
+		restartTracker.Exited("ccid")
+		restartTracker.Launched("ccid")
+
+		backoff, ok := restartTracker.Exited("ccid")
+		Expect(ok).To(BeTrue())
+		Expect(backoff).To(Equal(2 * time.Second))
+	})
+
+	It("does not schedule a second relaunch while one is pending", func() {
+		restartTracker.Exited("ccid")
+		_, ok := restartTracker.Exited("ccid")
+		Expect(ok).To(BeFalse())
+	})
+
+	Context("when the chaincode was stopped deliberately", func() {
+		BeforeEach(func() {
+			restartTracker.Stopping("ccid")
+		})
+
+		It("does not relaunch the chaincode", func() {
+			_, ok := restartTracker.Exited("ccid")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("relaunches the chaincode once it has been launched again", func() {
+			restartTracker.Launched("ccid")
+			_, ok := restartTracker.Exited("ccid")
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Context("when the chaincode is stopped while a relaunch is pending", func() {
+		BeforeEach(func() {
+			restartTracker.Exited("ccid")
+			restartTracker.Stopping("ccid")
+		})
+
+		It("abandons the relaunch", func() {
+			Expect(restartTracker.Relaunching("ccid")).To(BeFalse())
+			Expect(restartTracker.HealthCheck(context.Background())).To(Succeed())
+		})
+	})
+
+	Describe("HealthCheck", func() {
+		It("succeeds when no chaincode is being relaunched", func() {
+			Expect(restartTracker.HealthCheck(context.Background())).To(Succeed())
+		})
+
+		It("reports the chaincodes being relaunched with their restart counts", func() {
+			restartTracker.Exited("ccid")
+			restartTracker.RelaunchFailed("ccid")
+			restartTracker.Launched("other-ccid")
+			restartTracker.Exited("other-ccid")
+
+			err := restartTracker.HealthCheck(context.Background())
+			Expect(err).To(MatchError("chaincodes exited unexpectedly and are being relaunched: ccid (restarts: 2), other-ccid (restarts: 1)"))
+		})
+
+		It("succeeds once the chaincode has been relaunched", func() {
+			restartTracker.Exited("ccid")
+			restartTracker.Launched("ccid")
+			Expect(restartTracker.HealthCheck(context.Background())).To(Succeed())
+		})
+	})
+})
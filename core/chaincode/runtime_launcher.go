@@ -8,6 +8,8 @@ package chaincode
 
 import (
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
@@ -20,6 +22,8 @@ import (
 type LaunchRegistry interface {
 	Launching(ccid string) (launchState *LaunchState, started bool)
 	Deregister(ccid string) error
+	Exited(ccid string, launchState *LaunchState, exitErr error)
+	Running(ccid string, launchState *LaunchState) bool
 }
 
 // ConnectionHandler handles the `Chaincode` client connection
@@ -37,6 +41,15 @@ type RuntimeLauncher struct {
 	CACert            []byte
 	CertGenerator     CertGenerator
 	ConnectionHandler ConnectionHandler
+	// RestartTracker is used to relaunch chaincodes which exit unexpectedly.
+	// Chaincodes are not relaunched when it is nil.
+	RestartTracker *RestartTracker
+	// Instances holds the number of instances started for specific
+	// chaincodes, keyed by lower case package label or legacy chaincode
+	// name. A single instance is started for other chaincodes. It does not
+	// apply to chaincode servers, whose instances are listed in their
+	// connection information.
+	Instances map[string]int
 }
 
 // CertGenerator generates client certificates for chaincode.
@@ -74,6 +87,7 @@ func (r *RuntimeLauncher) Launch(ccid string, streamHandler extcc.StreamHandler)
 	startTime := time.Now()
 	launchState, alreadyStarted := r.Registry.Launching(ccid)
 	if !alreadyStarted {
+		r.Metrics.Launches.With("chaincode", ccid).Add(1)
 		startFailCh = make(chan error, 1)
 		timeoutCh = time.NewTimer(r.StartupTimeout).C
 
@@ -87,35 +101,13 @@ func (r *RuntimeLauncher) Launch(ccid string, streamHandler extcc.StreamHandler)
 
 			// chaincode server model indicated... proceed to connect to CC
 			if ccservinfo != nil {
-				if err = r.ConnectionHandler.Stream(ccid, ccservinfo, streamHandler); err != nil {
-					startFailCh <- errors.WithMessagef(err, "connection to %s failed", ccid)
-					return
-				}
-
-				launchState.Notify(errors.Errorf("connection to %s terminated", ccid))
+				r.connect(ccid, launchState, ccservinfo, streamHandler, startFailCh)
 				return
 			}
 
-			// default peer-as-server model... compute connection information for CC callback
-			// and proceed to launch chaincode
-			ccinfo, err := r.ChaincodeClientInfo(ccid)
-			if err != nil {
-				startFailCh <- errors.WithMessage(err, "could not get connection info")
-				return
-			}
-			if ccinfo == nil {
-				startFailCh <- errors.New("could not get connection info")
-				return
-			}
-			if err = r.Runtime.Start(ccid, ccinfo); err != nil {
-				startFailCh <- errors.WithMessage(err, "error starting container")
-				return
-			}
-			exitCode, err := r.Runtime.Wait(ccid)
-			if err != nil {
-				launchState.Notify(errors.Wrap(err, "failed to wait on container exit"))
-			}
-			launchState.Notify(errors.Errorf("container exited with %d", exitCode))
+			// default peer-as-server model... proceed to launch the instances of the
+			// chaincode, which connect back to the peer
+			r.start(ccid, launchState, streamHandler, startFailCh)
 		}()
 	}
 
@@ -139,6 +131,10 @@ func (r *RuntimeLauncher) Launch(ccid string, streamHandler extcc.StreamHandler)
 		defer r.Registry.Deregister(ccid)
 	}
 
+	if success && !alreadyStarted && r.RestartTracker != nil {
+		r.RestartTracker.Launched(ccid)
+	}
+
 	r.Metrics.LaunchDuration.With(
 		"chaincode", ccid,
 		"success", strconv.FormatBool(success),
@@ -148,7 +144,301 @@ func (r *RuntimeLauncher) Launch(ccid string, streamHandler extcc.StreamHandler)
 	return err
 }
 
+// instances returns the number of instances to start for a chaincode.
+// Like the docker host configuration overrides, it is keyed by the part of
+// the package ID which precedes the hash.
+func (r *RuntimeLauncher) instances(ccid string) int {
+	label := ccid
+	if i := strings.LastIndex(ccid, ":"); i != -1 {
+		label = ccid[:i]
+	}
+	if instances := r.Instances[strings.ToLower(label)]; instances > 1 {
+		return instances
+	}
+	return 1
+}
+
+// start starts every instance of a chaincode which connects back to the
+// peer. Each instance registers its own handler. The launch fails only if no
+// instance can be started. When a restart tracker is configured and the
+// chaincode has several instances, an instance which exits, or could not be
+// started, while other instances keep the chaincode running is restarted
+// after a backoff. The chaincode is considered to have exited once every
+// instance has exited.
+func (r *RuntimeLauncher) start(ccid string, launchState *LaunchState, streamHandler extcc.StreamHandler, startFailCh chan<- error) {
+	instances := r.instances(ccid)
+	launchState.SetInstances(instances)
+
+	c := &instanceLaunches{
+		instances:   instances,
+		startFailCh: startFailCh,
+	}
+	exitErrs := make(chan error, instances)
+	for instance := 0; instance < instances; instance++ {
+		go func(instance int) {
+			exitErrs <- r.runInstance(ccid, instance, launchState, c)
+		}(instance)
+	}
+	var exitErr error
+	for i := 0; i < instances; i++ {
+		exitErr = <-exitErrs
+	}
+
+	r.exited(ccid, launchState, streamHandler, exitErr)
+}
+
+// runInstance starts an instance of a chaincode and waits for it to exit,
+// restarting it until the chaincode stops running. It returns the error the
+// instance last exited with.
+func (r *RuntimeLauncher) runInstance(ccid string, instance int, launchState *LaunchState, c *instanceLaunches) error {
+	var backoff time.Duration
+	launched := false
+	for {
+		started := time.Now()
+		startErr := r.startInstance(ccid, instance)
+		exitErr := startErr
+		if startErr == nil {
+			exitErr = r.waitInstance(ccid, instance)
+		} else if c.instances > 1 {
+			chaincodeLogger.Warningf("failed to start instance %d of chaincode %s: %s", instance, ccid, startErr)
+		}
+
+		if !launched {
+			select {
+			case <-launchState.Done():
+			default:
+				c.terminatedBeforeLaunch(launchState, startErr, exitErr)
+			}
+			<-launchState.Done()
+			if launchState.Err() != nil {
+				return exitErr
+			}
+			launched = true
+		}
+
+		// a chaincode with a single instance is relaunched as a whole
+		if r.RestartTracker == nil || c.instances == 1 || !r.Registry.Running(ccid, launchState) {
+			return exitErr
+		}
+
+		if time.Since(started) > r.RestartTracker.MaxBackoff {
+			backoff = 0
+		}
+		backoff = r.RestartTracker.nextBackoff(backoff)
+		chaincodeLogger.Warningf("restarting instance %d of chaincode %s in %s: %s", instance, ccid, backoff, exitErr)
+		time.Sleep(backoff)
+		if !r.Registry.Running(ccid, launchState) {
+			return exitErr
+		}
+	}
+}
+
+// startInstance computes the connection information for the callback of an
+// instance of a chaincode and starts it.
+func (r *RuntimeLauncher) startInstance(ccid string, instance int) error {
+	ccinfo, err := r.ChaincodeClientInfo(ccid)
+	if err != nil {
+		return errors.WithMessage(err, "could not get connection info")
+	}
+	if ccinfo == nil {
+		return errors.New("could not get connection info")
+	}
+	if err := r.Runtime.Start(ccid, instance, ccinfo); err != nil {
+		return errors.WithMessage(err, "error starting container")
+	}
+	return nil
+}
+
+// waitInstance waits for an instance of a chaincode to exit and returns the
+// reason it exited.
+func (r *RuntimeLauncher) waitInstance(ccid string, instance int) error {
+	exitCode, err := r.Runtime.Wait(ccid, instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to wait on container exit")
+	}
+	return errors.Errorf("container exited with %d", exitCode)
+}
+
+// connect establishes a stream to every instance of a chaincode server.
+// Each stream registers a handler for one instance of the chaincode. The
+// launch fails only if no stream can be established. When a restart tracker
+// is configured, an instance whose stream terminates while other instances
+// keep the chaincode running is reconnected after a backoff. The chaincode
+// is considered to have exited once every stream has terminated.
+func (r *RuntimeLauncher) connect(ccid string, launchState *LaunchState, ccservinfo *ccintf.ChaincodeServerInfo, streamHandler extcc.StreamHandler, startFailCh chan<- error) {
+	addresses := ccservinfo.Addresses
+	if len(addresses) == 0 {
+		addresses = []string{ccservinfo.Address}
+	}
+	launchState.SetInstances(len(addresses))
+
+	c := &instanceLaunches{
+		instances:   len(addresses),
+		startFailCh: startFailCh,
+	}
+	var wg sync.WaitGroup
+	wg.Add(len(addresses))
+	for _, address := range addresses {
+		instance := *ccservinfo
+		instance.Address = address
+		instance.Addresses = nil
+		go func() {
+			defer wg.Done()
+			r.connectInstance(ccid, launchState, &instance, streamHandler, c)
+		}()
+	}
+	wg.Wait()
+
+	r.exited(ccid, launchState, streamHandler, errors.Errorf("connection to %s terminated", ccid))
+}
+
+// instanceLaunches tracks the instances of a chaincode which terminated
+// before the chaincode completed registration.
+type instanceLaunches struct {
+	mutex       sync.Mutex
+	instances   int
+	terminated  int
+	failures    int
+	failErr     error
+	exitErr     error
+	startFailCh chan<- error
+}
+
+// terminatedBeforeLaunch records that an instance terminated before the
+// chaincode completed registration, either because it could not be started
+// or connected to, with failErr, or because it exited, with exitErr. Once
+// every instance has terminated, the launch fails with the first failure if
+// none of them could be started, and the launch state is notified with the
+// last exit otherwise.
+func (c *instanceLaunches) terminatedBeforeLaunch(launchState *LaunchState, failErr, exitErr error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.terminated++
+	if failErr != nil {
+		c.failures++
+		if c.failErr == nil {
+			c.failErr = failErr
+		}
+	} else {
+		c.exitErr = exitErr
+	}
+	if c.terminated < c.instances {
+		return
+	}
+	if c.failures == c.instances {
+		c.startFailCh <- c.failErr
+		return
+	}
+	launchState.Notify(c.exitErr)
+}
+
+// connectInstance maintains the stream to one instance of a chaincode server
+// until the chaincode stops running.
+func (r *RuntimeLauncher) connectInstance(ccid string, launchState *LaunchState, instance *ccintf.ChaincodeServerInfo, streamHandler extcc.StreamHandler, c *instanceLaunches) {
+	var backoff time.Duration
+	launched := false
+	for {
+		connected := time.Now()
+		err := r.ConnectionHandler.Stream(ccid, instance, streamHandler)
+		if err != nil {
+			chaincodeLogger.Warningf("connection to instance of chaincode %s at %s failed: %s", ccid, instance.Address, err)
+		}
+
+		if !launched {
+			select {
+			case <-launchState.Done():
+			default:
+				var failErr error
+				if err != nil {
+					failErr = errors.WithMessagef(err, "connection to %s failed", ccid)
+				}
+				c.terminatedBeforeLaunch(launchState, failErr, errors.Errorf("connection to %s terminated", ccid))
+			}
+			<-launchState.Done()
+			if launchState.Err() != nil {
+				return
+			}
+			launched = true
+		}
+
+		if r.RestartTracker == nil || !r.Registry.Running(ccid, launchState) {
+			return
+		}
+
+		if time.Since(connected) > r.RestartTracker.MaxBackoff {
+			backoff = 0
+		}
+		backoff = r.RestartTracker.nextBackoff(backoff)
+		chaincodeLogger.Warningf("reconnecting to instance of chaincode %s at %s in %s", ccid, instance.Address, backoff)
+		time.Sleep(backoff)
+		if !r.Registry.Running(ccid, launchState) {
+			return
+		}
+	}
+}
+
+// exited is called when the runtime of a chaincode exits. If the chaincode
+// had been launched successfully, its handler is terminated and, unless the
+// chaincode was stopped deliberately, it is relaunched after a backoff.
+func (r *RuntimeLauncher) exited(ccid string, launchState *LaunchState, streamHandler extcc.StreamHandler, exitErr error) {
+	select {
+	case <-launchState.Done():
+		if launchState.Err() != nil {
+			return
+		}
+	default:
+		// the chaincode exited before it completed registration
+		launchState.Notify(exitErr)
+		return
+	}
+
+	r.Registry.Exited(ccid, launchState, exitErr)
+
+	if r.RestartTracker == nil {
+		return
+	}
+
+	backoff, ok := r.RestartTracker.Exited(ccid)
+	if !ok {
+		chaincodeLogger.Debugf("chaincode %s exited: %s", ccid, exitErr)
+		return
+	}
+
+	chaincodeLogger.Warningf("chaincode %s exited unexpectedly, relaunching in %s: %s", ccid, backoff, exitErr)
+	go r.relaunch(ccid, streamHandler, backoff)
+}
+
+// relaunch launches a chaincode which exited unexpectedly, retrying with
+// an increasing backoff until it succeeds or the chaincode is stopped.
+func (r *RuntimeLauncher) relaunch(ccid string, streamHandler extcc.StreamHandler, backoff time.Duration) {
+	for {
+		time.Sleep(backoff)
+		if !r.RestartTracker.Relaunching(ccid) {
+			chaincodeLogger.Infof("abandoning relaunch of chaincode %s as it has been stopped", ccid)
+			return
+		}
+
+		r.Metrics.Restarts.With("chaincode", ccid).Add(1)
+		err := r.Launch(ccid, streamHandler)
+		if err == nil {
+			// Launch records the relaunch when it starts the chaincode, but
+			// the chaincode may also have been launched by an invocation
+			r.RestartTracker.Launched(ccid)
+			chaincodeLogger.Infof("relaunched chaincode %s", ccid)
+			return
+		}
+
+		backoff = r.RestartTracker.RelaunchFailed(ccid)
+		chaincodeLogger.Warningf("failed to relaunch chaincode %s, retrying in %s: %s", ccid, backoff, err)
+	}
+}
+
 func (r *RuntimeLauncher) Stop(ccid string) error {
+	if r.RestartTracker != nil {
+		r.RestartTracker.Stopping(ccid)
+	}
+
 	err := r.Runtime.Stop(ccid)
 	if err != nil {
 		return errors.WithMessagef(err, "failed to stop chaincode %s", ccid)
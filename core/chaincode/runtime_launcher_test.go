@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode_test
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
@@ -30,6 +31,8 @@ var _ = Describe("RuntimeLauncher", func() {
 		fakeLaunchDuration *metricsfakes.Histogram
 		fakeLaunchFailures *metricsfakes.Counter
 		fakeLaunchTimeouts *metricsfakes.Counter
+		fakeLaunches       *metricsfakes.Counter
+		fakeRestarts       *metricsfakes.Counter
 		fakeCertGenerator  *mock.CertGenerator
 		exitedCh           chan int
 		extCCConnExited    chan struct{}
@@ -45,13 +48,14 @@ var _ = Describe("RuntimeLauncher", func() {
 		fakeRegistry.LaunchingReturns(launchState, false)
 
 		fakeRuntime = &mock.Runtime{}
-		fakeRuntime.StartStub = func(string, *ccintf.PeerConnection) error {
-			launchState.Notify(nil)
+		ls := launchState // shadow to avoid race
+		fakeRuntime.StartStub = func(string, int, *ccintf.PeerConnection) error {
+			ls.Notify(nil)
 			return nil
 		}
 		exitedCh = make(chan int)
 		waitExitCh := exitedCh // shadow to avoid race
-		fakeRuntime.WaitStub = func(string) (int, error) {
+		fakeRuntime.WaitStub = func(string, int) (int, error) {
 			return <-waitExitCh, nil
 		}
 
@@ -72,11 +76,17 @@ var _ = Describe("RuntimeLauncher", func() {
 		fakeLaunchFailures.WithReturns(fakeLaunchFailures)
 		fakeLaunchTimeouts = &metricsfakes.Counter{}
 		fakeLaunchTimeouts.WithReturns(fakeLaunchTimeouts)
+		fakeLaunches = &metricsfakes.Counter{}
+		fakeLaunches.WithReturns(fakeLaunches)
+		fakeRestarts = &metricsfakes.Counter{}
+		fakeRestarts.WithReturns(fakeRestarts)
 
 		launchMetrics := &chaincode.LaunchMetrics{
 			LaunchDuration: fakeLaunchDuration,
 			LaunchFailures: fakeLaunchFailures,
 			LaunchTimeouts: fakeLaunchTimeouts,
+			Launches:       fakeLaunches,
+			Restarts:       fakeRestarts,
 		}
 		fakeCertGenerator = &mock.CertGenerator{}
 		fakeCertGenerator.GenerateReturns(&accesscontrol.CertAndPrivKeyPair{Cert: []byte("cert"), Key: []byte("key")}, nil)
@@ -147,8 +157,9 @@ var _ = Describe("RuntimeLauncher", func() {
 		ccciArg := fakeRuntime.BuildArgsForCall(0)
 		Expect(ccciArg).To(Equal("chaincode-name:chaincode-version"))
 		Expect(fakeRuntime.StartCallCount()).To(Equal(1))
-		ccciArg, ccinfoArg := fakeRuntime.StartArgsForCall(0)
+		ccciArg, instanceArg, ccinfoArg := fakeRuntime.StartArgsForCall(0)
 		Expect(ccciArg).To(Equal("chaincode-name:chaincode-version"))
+		Expect(instanceArg).To(Equal(0))
 
 		Expect(ccinfoArg).To(Equal(&ccintf.PeerConnection{Address: "peer-address", TLSConfig: &ccintf.TLSConfig{ClientCert: []byte("cert"), ClientKey: []byte("key"), RootCert: nil}}))
 	})
@@ -166,7 +177,7 @@ var _ = Describe("RuntimeLauncher", func() {
 			ccciArg := fakeRuntime.BuildArgsForCall(0)
 			Expect(ccciArg).To(Equal("chaincode-name:chaincode-version"))
 			Expect(fakeRuntime.StartCallCount()).To(Equal(1))
-			ccciArg, ccinfoArg := fakeRuntime.StartArgsForCall(0)
+			ccciArg, _, ccinfoArg := fakeRuntime.StartArgsForCall(0)
 			Expect(ccciArg).To(Equal("chaincode-name:chaincode-version"))
 
 			Expect(ccinfoArg).To(Equal(&ccintf.PeerConnection{Address: "peer-address"}))
@@ -205,6 +216,224 @@ var _ = Describe("RuntimeLauncher", func() {
 		Expect(fakeLaunchDuration.ObserveArgsForCall(0)).To(BeNumerically("<", 1.0))
 	})
 
+	It("records the launch", func() {
+		err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeLaunches.WithCallCount()).To(Equal(1))
+		Expect(fakeLaunches.WithArgsForCall(0)).To(Equal([]string{"chaincode", "chaincode-name:chaincode-version"}))
+		Expect(fakeLaunches.AddCallCount()).To(Equal(1))
+		Expect(fakeLaunches.AddArgsForCall(0)).To(Equal(1.0))
+	})
+
+	Context("when the chaincode exits after it has been launched", func() {
+		BeforeEach(func() {
+			err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("notifies the registry that the chaincode exited", func() {
+			exitedCh <- 7
+
+			Eventually(fakeRegistry.ExitedCallCount).Should(Equal(1))
+			ccid, ls, exitErr := fakeRegistry.ExitedArgsForCall(0)
+			Expect(ccid).To(Equal("chaincode-name:chaincode-version"))
+			Expect(ls).To(Equal(launchState))
+			Expect(exitErr).To(MatchError("container exited with 7"))
+		})
+
+		It("does not relaunch the chaincode", func() {
+			exitedCh <- 7
+
+			Eventually(fakeRegistry.ExitedCallCount).Should(Equal(1))
+			Consistently(fakeRegistry.LaunchingCallCount).Should(Equal(1))
+		})
+	})
+
+	Context("when several instances are configured for the chaincode", func() {
+		var instanceExited []chan int
+
+		BeforeEach(func() {
+			runtimeLauncher.Instances = map[string]int{"chaincode-name": 2}
+
+			instanceExited = []chan int{make(chan int), make(chan int)}
+			exited := instanceExited // shadow to avoid race
+			fakeRuntime.WaitStub = func(_ string, instance int) (int, error) {
+				return <-exited[instance], nil
+			}
+		})
+
+		AfterEach(func() {
+			for _, ch := range instanceExited {
+				close(ch)
+			}
+		})
+
+		It("starts every instance", func() {
+			err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(launchState.Instances()).To(Equal(2))
+
+			Eventually(fakeRuntime.StartCallCount).Should(Equal(2))
+			var instances []int
+			for i := 0; i < 2; i++ {
+				ccid, instance, ccinfo := fakeRuntime.StartArgsForCall(i)
+				Expect(ccid).To(Equal("chaincode-name:chaincode-version"))
+				Expect(ccinfo.Address).To(Equal("peer-address"))
+				instances = append(instances, instance)
+			}
+			Expect(instances).To(ConsistOf(0, 1))
+			Expect(fakeCertGenerator.GenerateCallCount()).To(Equal(2))
+		})
+
+		It("starts a single instance of other chaincodes", func() {
+			err := runtimeLauncher.Launch("other-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(launchState.Instances()).To(Equal(1))
+			Consistently(fakeRuntime.StartCallCount).Should(Equal(1))
+		})
+
+		It("treats the chaincode as exited once every instance has exited", func() {
+			err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+
+			instanceExited[0] <- 3
+			Consistently(fakeRegistry.ExitedCallCount).Should(Equal(0))
+
+			instanceExited[1] <- 7
+			Eventually(fakeRegistry.ExitedCallCount).Should(Equal(1))
+			_, _, exitErr := fakeRegistry.ExitedArgsForCall(0)
+			Expect(exitErr).To(MatchError("container exited with 7"))
+		})
+
+		Context("when a restart tracker is configured", func() {
+			var running int32
+
+			BeforeEach(func() {
+				runtimeLauncher.RestartTracker = &chaincode.RestartTracker{
+					InitialBackoff: time.Millisecond,
+					MaxBackoff:     10 * time.Millisecond,
+				}
+				atomic.StoreInt32(&running, 1)
+				fakeRegistry.RunningStub = func(string, *chaincode.LaunchState) bool {
+					return atomic.LoadInt32(&running) == 1
+				}
+			})
+
+			AfterEach(func() {
+				runtimeLauncher.Stop("chaincode-name:chaincode-version")
+				atomic.StoreInt32(&running, 0)
+			})
+
+			It("restarts an instance which exited", func() {
+				err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(fakeRuntime.StartCallCount).Should(Equal(2))
+
+				instanceExited[1] <- 7
+				Eventually(fakeRuntime.StartCallCount).Should(Equal(3))
+				_, instance, _ := fakeRuntime.StartArgsForCall(2)
+				Expect(instance).To(Equal(1))
+				Expect(fakeRegistry.ExitedCallCount()).To(Equal(0))
+				Expect(fakeRegistry.LaunchingCallCount()).To(Equal(1))
+			})
+
+			It("stops restarting instances once the chaincode is no longer running", func() {
+				err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(fakeRuntime.StartCallCount).Should(Equal(2))
+
+				runtimeLauncher.Stop("chaincode-name:chaincode-version")
+				atomic.StoreInt32(&running, 0)
+				instanceExited[0] <- 0
+				instanceExited[1] <- 0
+				Eventually(fakeRegistry.ExitedCallCount).Should(Equal(1))
+				Expect(fakeRuntime.StartCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when starting some of the instances fails", func() {
+			BeforeEach(func() {
+				stub := fakeRuntime.StartStub
+				fakeRuntime.StartStub = func(ccid string, instance int, ccinfo *ccintf.PeerConnection) error {
+					if instance == 1 {
+						return errors.New("banana")
+					}
+					return stub(ccid, instance, ccinfo)
+				}
+			})
+
+			It("launches the chaincode", func() {
+				err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRegistry.DeregisterCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when starting every instance fails", func() {
+			BeforeEach(func() {
+				fakeRuntime.StartStub = nil
+				fakeRuntime.StartReturns(errors.New("banana"))
+			})
+
+			It("fails the launch", func() {
+				err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+				Expect(err).To(MatchError("error starting container: banana"))
+				Expect(fakeRegistry.DeregisterCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when a restart tracker is configured", func() {
+		BeforeEach(func() {
+			runtimeLauncher.RestartTracker = &chaincode.RestartTracker{
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     10 * time.Millisecond,
+			}
+
+			err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			runtimeLauncher.Stop("chaincode-name:chaincode-version")
+		})
+
+		It("relaunches the chaincode when it exits unexpectedly", func() {
+			exitedCh <- 7
+
+			Eventually(fakeRegistry.LaunchingCallCount).Should(Equal(2))
+			Expect(fakeRegistry.LaunchingArgsForCall(1)).To(Equal("chaincode-name:chaincode-version"))
+			Expect(fakeRestarts.WithCallCount()).To(Equal(1))
+			Expect(fakeRestarts.WithArgsForCall(0)).To(Equal([]string{"chaincode", "chaincode-name:chaincode-version"}))
+			Expect(fakeRestarts.AddCallCount()).To(Equal(1))
+		})
+
+		It("does not relaunch the chaincode when it was stopped", func() {
+			err := runtimeLauncher.Stop("chaincode-name:chaincode-version")
+			Expect(err).NotTo(HaveOccurred())
+			exitedCh <- 0
+
+			Eventually(fakeRegistry.ExitedCallCount).Should(Equal(1))
+			Consistently(fakeRegistry.LaunchingCallCount).Should(Equal(1))
+			Expect(fakeRestarts.AddCallCount()).To(Equal(0))
+		})
+
+		Context("when relaunching the chaincode fails", func() {
+			BeforeEach(func() {
+				fakeRegistry.LaunchingReturnsOnCall(1, chaincode.NewLaunchState(), false)
+				fakeRuntime.BuildReturnsOnCall(1, nil, errors.New("no-build"))
+			})
+
+			It("retries the relaunch", func() {
+				exitedCh <- 7
+
+				Eventually(fakeRegistry.LaunchingCallCount).Should(Equal(3))
+				Expect(fakeRestarts.AddCallCount()).To(Equal(2))
+			})
+		})
+	})
+
 	Context("when starting connection to external chaincode", func() {
 		BeforeEach(func() {
 			fakeRuntime.BuildReturns(&ccintf.ChaincodeServerInfo{Address: "peer-address"}, nil)
@@ -289,6 +518,131 @@ var _ = Describe("RuntimeLauncher", func() {
 				Expect(cname).To(Equal("chaincode-name:chaincode-version"))
 			})
 		})
+
+		Context("when the chaincode server has several instances", func() {
+			var connExited map[string]chan struct{}
+
+			BeforeEach(func() {
+				fakeRuntime.BuildReturns(&ccintf.ChaincodeServerInfo{
+					Address:   "instance-1",
+					Addresses: []string{"instance-1", "instance-2"},
+				}, nil)
+
+				connExited = map[string]chan struct{}{
+					"instance-1": make(chan struct{}),
+					"instance-2": make(chan struct{}),
+				}
+				exited, ls := connExited, launchState // shadow to avoid race
+				fakeConnHandler.StreamStub = func(_ string, ccinfo *ccintf.ChaincodeServerInfo, _ extcc.StreamHandler) error {
+					ls.Notify(nil)
+					<-exited[ccinfo.Address]
+					return nil
+				}
+			})
+
+			It("connects to every instance", func() {
+				err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(launchState.Instances()).To(Equal(2))
+
+				Eventually(fakeConnHandler.StreamCallCount).Should(Equal(2))
+				var addresses []string
+				for i := 0; i < 2; i++ {
+					_, ccinfo, _ := fakeConnHandler.StreamArgsForCall(i)
+					Expect(ccinfo.Addresses).To(BeNil())
+					addresses = append(addresses, ccinfo.Address)
+				}
+				Expect(addresses).To(ConsistOf("instance-1", "instance-2"))
+			})
+
+			It("treats the chaincode as exited once every connection has terminated", func() {
+				err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+				Expect(err).NotTo(HaveOccurred())
+
+				close(connExited["instance-1"])
+				Consistently(fakeRegistry.ExitedCallCount).Should(Equal(0))
+
+				close(connExited["instance-2"])
+				Eventually(fakeRegistry.ExitedCallCount).Should(Equal(1))
+			})
+
+			Context("when a restart tracker is configured", func() {
+				var running int32
+
+				BeforeEach(func() {
+					runtimeLauncher.RestartTracker = &chaincode.RestartTracker{
+						InitialBackoff: time.Millisecond,
+						MaxBackoff:     10 * time.Millisecond,
+					}
+					atomic.StoreInt32(&running, 1)
+					fakeRegistry.RunningStub = func(string, *chaincode.LaunchState) bool {
+						return atomic.LoadInt32(&running) == 1
+					}
+				})
+
+				AfterEach(func() {
+					runtimeLauncher.Stop("chaincode-name:chaincode-version")
+					atomic.StoreInt32(&running, 0)
+				})
+
+				It("reconnects to an instance whose connection terminated", func() {
+					err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(fakeConnHandler.StreamCallCount).Should(Equal(2))
+
+					close(connExited["instance-1"])
+					Eventually(fakeConnHandler.StreamCallCount).Should(BeNumerically(">", 3))
+					for i := 2; i < fakeConnHandler.StreamCallCount(); i++ {
+						_, ccinfo, _ := fakeConnHandler.StreamArgsForCall(i)
+						Expect(ccinfo.Address).To(Equal("instance-1"))
+					}
+					Expect(fakeRegistry.ExitedCallCount()).To(Equal(0))
+				})
+
+				It("stops reconnecting once the chaincode is no longer running", func() {
+					err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+					Expect(err).NotTo(HaveOccurred())
+
+					runtimeLauncher.Stop("chaincode-name:chaincode-version")
+					atomic.StoreInt32(&running, 0)
+					close(connExited["instance-1"])
+					close(connExited["instance-2"])
+					Eventually(fakeRegistry.ExitedCallCount).Should(Equal(1))
+					Expect(fakeConnHandler.StreamCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when connecting to some of the instances fails", func() {
+				BeforeEach(func() {
+					stub := fakeConnHandler.StreamStub
+					fakeConnHandler.StreamStub = func(ccid string, ccinfo *ccintf.ChaincodeServerInfo, sHandler extcc.StreamHandler) error {
+						if ccinfo.Address == "instance-1" {
+							return errors.New("banana")
+						}
+						return stub(ccid, ccinfo, sHandler)
+					}
+				})
+
+				It("launches the chaincode", func() {
+					err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeRegistry.DeregisterCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when connecting to every instance fails", func() {
+				BeforeEach(func() {
+					fakeConnHandler.StreamStub = nil
+					fakeConnHandler.StreamReturns(errors.New("banana"))
+				})
+
+				It("fails the launch", func() {
+					err := runtimeLauncher.Launch("chaincode-name:chaincode-version", fakeStreamHandler)
+					Expect(err).To(MatchError("connection to chaincode-name:chaincode-version failed: banana"))
+					Expect(fakeRegistry.DeregisterCallCount()).To(Equal(1))
+				})
+			})
+		})
 	})
 
 	Context("when starting the runtime fails", func() {
@@ -349,7 +703,7 @@ var _ = Describe("RuntimeLauncher", func() {
 
 	Context("when handler registration fails", func() {
 		BeforeEach(func() {
-			fakeRuntime.StartStub = func(string, *ccintf.PeerConnection) error {
+			fakeRuntime.StartStub = func(string, int, *ccintf.PeerConnection) error {
 				launchState.Notify(errors.New("papaya"))
 				return nil
 			}
@@ -418,6 +772,7 @@ var _ = Describe("RuntimeLauncher", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeRuntime.StartCallCount()).To(Equal(0))
+			Expect(fakeLaunches.AddCallCount()).To(Equal(0))
 		})
 
 		It("waits for the launch to complete", func() {
@@ -55,6 +55,7 @@ func (c *TransactionContexts) Create(txParams *ccprovider.TransactionParams) (*T
 		HistoryQueryExecutor: txParams.HistoryQueryExecutor,
 		CollectionStore:      txParams.CollectionStore,
 		IsInitTransaction:    txParams.IsInitTransaction,
+		ResourceUsage:        txParams.ResourceUsage,
 
 		queryIteratorMap:    map[string]commonledger.ResultsIterator{},
 		pendingQueryResults: map[string]*PendingQueryResult{},
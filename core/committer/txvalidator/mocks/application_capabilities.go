@@ -93,6 +93,20 @@ func (_m *ApplicationCapabilities) MetadataLifecycle() bool {
 	return r0
 }
 
+// MultipleChaincodeEvents provides a mock function with given fields:
+func (_m *ApplicationCapabilities) MultipleChaincodeEvents() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // PrivateChannelData provides a mock function with given fields:
 func (_m *ApplicationCapabilities) PrivateChannelData() bool {
 	ret := _m.Called()
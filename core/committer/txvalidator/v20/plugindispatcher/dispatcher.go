@@ -12,6 +12,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
@@ -28,6 +29,9 @@ type ChannelResources interface {
 	// GetMSPIDs returns the IDs for the application MSPs
 	// that have been defined in the channel
 	GetMSPIDs() []string
+
+	// Capabilities defines the capabilities for the application portion of this channel
+	Capabilities() channelconfig.ApplicationCapabilities
 }
 
 // LedgerResources provides access to ledger artefacts or
@@ -166,6 +170,17 @@ func (v *dispatcherImpl) Dispatch(seq int, payload *common.Payload, envBytes []b
 		if ccEvent.ChaincodeId != ccID {
 			return peer.TxValidationCode_INVALID_OTHER_REASON, errors.Errorf("chaincode event chaincode id does not match chaincode action chaincode id")
 		}
+		if v.cr.Capabilities().MultipleChaincodeEvents() {
+			ccEvents, err := protoutil.GetChaincodeEventList(ccEvent)
+			if err != nil {
+				return peer.TxValidationCode_INVALID_OTHER_REASON, errors.WithMessage(err, "invalid chaincode event")
+			}
+			for _, e := range ccEvents {
+				if e.ChaincodeId != ccID || e.TxId != ccEvent.TxId {
+					return peer.TxValidationCode_INVALID_OTHER_REASON, errors.Errorf("chaincode event %s does not belong to the chaincode action", e.EventName)
+				}
+			}
+		}
 	}
 
 	namespaces := make(map[string]struct{})
@@ -57,6 +57,18 @@ func v20Capabilities() *tmocks.ApplicationCapabilities {
 	ac.On("V2_0Validation").Return(true)
 	ac.On("PrivateChannelData").Return(true)
 	ac.On("KeyLevelEndorsement").Return(true)
+	ac.On("MultipleChaincodeEvents").Return(false)
+	return ac
+}
+
+func v24Capabilities() *tmocks.ApplicationCapabilities {
+	ac := &tmocks.ApplicationCapabilities{}
+	ac.On("V1_2Validation").Return(true)
+	ac.On("V1_3Validation").Return(true)
+	ac.On("V2_0Validation").Return(true)
+	ac.On("PrivateChannelData").Return(true)
+	ac.On("KeyLevelEndorsement").Return(true)
+	ac.On("MultipleChaincodeEvents").Return(true)
 	return ac
 }
 
@@ -626,6 +638,46 @@ func TestChaincodeEvent(t *testing.T) {
 
 		testCCEventGoodPath(t, v, ccID)
 	})
+
+	for _, tt := range []struct {
+		name         string
+		capabilities *tmocks.ApplicationCapabilities
+		listedID     string
+		valid        bool
+	}{
+		{"MultipleEvents", v24Capabilities(), ccID, true},
+		{"MultipleEventsMisMatchedName", v24Capabilities(), "wrong", false},
+		{"MultipleEventsNotSupported", v20Capabilities(), "wrong", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			v, mockQE, _, _ := setupValidator()
+			v.ChannelResources.(*mocktxvalidator.Support).ACVal = tt.capabilities
+
+			mockQE.On("GetState", "lscc", ccID).Return(protoutil.MarshalOrPanic(&ccp.ChaincodeData{
+				Name:    ccID,
+				Version: ccVersion,
+				Vscc:    "vscc",
+				Policy:  signedByAnyMember([]string{"SampleOrg"}),
+			}), nil)
+			mockQE.On("GetStateMetadata", ccID, "key").Return(nil, nil)
+
+			ccEvent, err := protoutil.NewChaincodeEventList([]*peer.ChaincodeEvent{
+				{ChaincodeId: tt.listedID, EventName: "first"},
+				{ChaincodeId: ccID, EventName: "second"},
+			})
+			require.NoError(t, err)
+			tx := getEnv(ccID, protoutil.MarshalOrPanic(ccEvent), createRWset(t), t)
+			b := &common.Block{Data: &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+			err = v.Validate(b)
+			require.NoError(t, err)
+			if tt.valid {
+				assertValid(b, t)
+			} else {
+				assertInvalid(b, t, peer.TxValidationCode_INVALID_OTHER_REASON)
+			}
+		})
+	}
 }
 
 func testCCEventMismatchedName(t *testing.T, v txvalidator.Validator, ccID string) {
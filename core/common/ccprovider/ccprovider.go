@@ -413,6 +413,10 @@ type TransactionParams struct {
 	CollectionStore      privdata.CollectionStore
 	IsInitTransaction    bool
 
+	// ResourceUsage accumulates the resources consumed by the transaction
+	// across chaincode-to-chaincode invocations.
+	ResourceUsage *ResourceUsage
+
 	// this is additional data passed to the chaincode
 	ProposalDecorations map[string][]byte
 }
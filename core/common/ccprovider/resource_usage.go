@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccprovider
+
+import "sync"
+
+// Resource identifies a resource consumed by chaincode while a transaction
+// is simulated.
+type Resource int
+
+const (
+	StateReads Resource = iota
+	KeysScanned
+	BytesRead
+	BytesWritten
+	Writes
+	CallDepth
+	resourceCount
+)
+
+// Resources lists every resource tracked by ResourceUsage.
+var Resources = []Resource{StateReads, KeysScanned, BytesRead, BytesWritten, Writes, CallDepth}
+
+func (r Resource) String() string {
+	switch r {
+	case StateReads:
+		return "state_reads"
+	case KeysScanned:
+		return "keys_scanned"
+	case BytesRead:
+		return "bytes_read"
+	case BytesWritten:
+		return "bytes_written"
+	case Writes:
+		return "writes"
+	case CallDepth:
+		return "call_depth"
+	default:
+		return "unknown"
+	}
+}
+
+// ResourceUsage accumulates the resources consumed while simulating a
+// transaction, including the resources consumed by any chaincode invoked
+// from chaincode. It is safe for concurrent use.
+type ResourceUsage struct {
+	mutex sync.Mutex
+	used  [resourceCount]uint64
+	depth uint64
+	err   error
+}
+
+// Add records that n units of the resource have been consumed and returns
+// the total consumed by the transaction. The call depth is tracked with
+// EnterCall and ExitCall instead.
+func (u *ResourceUsage) Add(r Resource, n uint64) uint64 {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if r < 0 || r >= resourceCount || r == CallDepth {
+		return 0
+	}
+	u.used[r] += n
+	return u.used[r]
+}
+
+// EnterCall records that chaincode has invoked another chaincode and returns
+// the resulting depth of chaincode-to-chaincode invocations.
+func (u *ResourceUsage) EnterCall() uint64 {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.depth++
+	if u.depth > u.used[CallDepth] {
+		u.used[CallDepth] = u.depth
+	}
+	return u.depth
+}
+
+// ExitCall records that a chaincode-to-chaincode invocation has completed.
+func (u *ResourceUsage) ExitCall() {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if u.depth > 0 {
+		u.depth--
+	}
+}
+
+// Used returns the amount of the resource consumed by the transaction. For
+// CallDepth it returns the deepest chaincode-to-chaincode invocation.
+func (u *ResourceUsage) Used(r Resource) uint64 {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if r < 0 || r >= resourceCount {
+		return 0
+	}
+	return u.used[r]
+}
+
+// Abort records the error which aborts the simulation of the transaction.
+// Only the first error is retained.
+func (u *ResourceUsage) Abort(err error) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if u.err == nil {
+		u.err = err
+	}
+}
+
+// Err returns the error which aborted the simulation of the transaction, if
+// any.
+func (u *ResourceUsage) Err() error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return u.err
+}
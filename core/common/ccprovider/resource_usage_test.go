@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccprovider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceUsage(t *testing.T) {
+	usage := &ResourceUsage{}
+
+	require.Equal(t, uint64(3), usage.Add(StateReads, 3))
+	require.Equal(t, uint64(5), usage.Add(StateReads, 2))
+	require.Equal(t, uint64(10), usage.Add(BytesRead, 10))
+	require.Equal(t, uint64(0), usage.Add(CallDepth, 1))
+	require.Equal(t, uint64(5), usage.Used(StateReads))
+	require.Equal(t, uint64(0), usage.Used(Writes))
+
+	require.Equal(t, uint64(1), usage.EnterCall())
+	require.Equal(t, uint64(2), usage.EnterCall())
+	usage.ExitCall()
+	usage.ExitCall()
+	require.Equal(t, uint64(1), usage.EnterCall())
+	require.Equal(t, uint64(2), usage.Used(CallDepth))
+
+	require.NoError(t, usage.Err())
+	usage.Abort(errors.New("first"))
+	usage.Abort(errors.New("second"))
+	require.EqualError(t, usage.Err(), "first")
+}
+
+func TestResourceString(t *testing.T) {
+	var names []string
+	for _, r := range Resources {
+		names = append(names, r.String())
+	}
+	require.Equal(t, []string{"state_reads", "keys_scanned", "bytes_read", "bytes_written", "writes", "call_depth"}, names)
+	require.Equal(t, "unknown", Resource(99).String())
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package privdata
 
 import (
+	"sync"
+
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/chaincode/implicitcollection"
@@ -19,6 +21,7 @@ var logger = flogging.MustGetLogger("common.privdata")
 // MembershipProvider can be used to check whether a peer is eligible to a collection or not
 type MembershipProvider struct {
 	mspID                       string
+	lock                        sync.RWMutex
 	selfSignedData              protoutil.SignedData
 	IdentityDeserializerFactory func(chainID string) msp.IdentityDeserializer
 	myImplicitCollectionName    string
@@ -57,13 +60,28 @@ func (m *MembershipProvider) AmMemberOf(channelName string, collectionPolicyConf
 		logger.Errorf("Reject all due to error getting policy: %s", err)
 		return false, nil
 	}
-	if err := accessPolicy.EvaluateSignedData([]*protoutil.SignedData{&m.selfSignedData}); err != nil {
+	selfSignedData := m.currentSelfSignedData()
+	if err := accessPolicy.EvaluateSignedData([]*protoutil.SignedData{&selfSignedData}); err != nil {
 		return false, nil
 	}
 
 	return true, nil
 }
 
+// SetSelfSignedData replaces the data signed by the peer, which is evaluated against
+// the collection access policies, when the signing identity of the peer is replaced
+func (m *MembershipProvider) SetSelfSignedData(selfSignedData protoutil.SignedData) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.selfSignedData = selfSignedData
+}
+
+func (m *MembershipProvider) currentSelfSignedData() protoutil.SignedData {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.selfSignedData
+}
+
 func (m *MembershipProvider) MyImplicitCollectionName() string {
 	return m.myImplicitCollectionName
 }
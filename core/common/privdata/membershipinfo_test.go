@@ -62,6 +62,16 @@ func TestMembershipInfoProvider(t *testing.T) {
 	res, err = membershipProvider.AmMemberOf("test1", getAccessPolicy([]string{"peer2", "peer3"}))
 	require.False(t, res)
 	require.Nil(t, err)
+
+	// verify membership provider evaluates the replaced self signed data
+	membershipProvider.SetSelfSignedData(protoutil.SignedData{
+		Identity:  []byte("peer2"),
+		Signature: []byte{1, 2, 3},
+		Data:      []byte{4, 5, 6},
+	})
+	res, err = membershipProvider.AmMemberOf("test1", getAccessPolicy([]string{"peer2", "peer3"}))
+	require.True(t, res)
+	require.Nil(t, err)
 }
 
 func TestMyImplicitCollectionName(t *testing.T) {
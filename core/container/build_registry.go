@@ -49,6 +49,16 @@ func (br *BuildRegistry) ResetBuildStatus(ccid string) *BuildStatus {
 	return bs
 }
 
+// RemoveBuildStatus removes the BuildStatus for the ccid, so that the next
+// call to BuildStatus returns a new build status. The caller must use external
+// locking to ensure that the build status is not being waited upon.
+func (br *BuildRegistry) RemoveBuildStatus(ccid string) {
+	br.mutex.Lock()
+	defer br.mutex.Unlock()
+
+	delete(br.builds, ccid)
+}
+
 type BuildStatus struct {
 	mutex sync.Mutex
 	doneC chan struct{}
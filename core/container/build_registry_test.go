@@ -62,6 +62,21 @@ var _ = Describe("BuildRegistry", func() {
 			Expect(bs.Err()).To(BeNil())
 		})
 	})
+
+	When("a build status is removed", func() {
+		BeforeEach(func() {
+			bs, ok := br.BuildStatus("ccid")
+			Expect(ok).To(BeFalse())
+			bs.Notify(nil)
+			br.RemoveBuildStatus("ccid")
+		})
+
+		It("returns a new build status", func() {
+			bs, ok := br.BuildStatus("ccid")
+			Expect(ok).To(BeFalse())
+			Expect(bs.Done()).NotTo(BeClosed())
+		})
+	})
 })
 
 var _ = Describe("BuildStatus", func() {
@@ -35,6 +35,9 @@ type TLSConfig struct {
 
 // ChaincodeServerInfo provides chaincode connection information
 type ChaincodeServerInfo struct {
-	Address      string
+	Address string
+	// Addresses, when set, lists the addresses of every instance of the
+	// chaincode server. Address is the first of them.
+	Addresses    []string
 	ClientConfig comm.ClientConfig
 }
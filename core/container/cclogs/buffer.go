@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cclogs
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Line is a line of output written by a chaincode. Lines are numbered in
+// the order they are written, starting at one.
+type Line struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
+}
+
+// Buffer is a ring buffer which holds the most recent lines of output
+// written by a chaincode. The oldest lines are discarded when the buffer
+// holds more than its maximum number of lines or bytes.
+type Buffer struct {
+	maxLines int
+	maxBytes int
+
+	mutex    sync.Mutex
+	ring     []Line
+	head     int
+	count    int
+	bytes    int
+	lastSeq  uint64
+	appended chan struct{}
+}
+
+// NewBuffer creates a Buffer which holds at most maxLines lines and at most
+// maxBytes bytes of text. A maxBytes of zero does not limit the number of
+// bytes.
+func NewBuffer(maxLines, maxBytes int) *Buffer {
+	if maxLines < 1 {
+		maxLines = 1
+	}
+	return &Buffer{
+		maxLines: maxLines,
+		maxBytes: maxBytes,
+		ring:     make([]Line, maxLines),
+		appended: make(chan struct{}),
+	}
+}
+
+// Append adds a line of output to the buffer. Trailing new lines are
+// removed and a line longer than the maximum number of bytes is truncated.
+func (b *Buffer) Append(text string) {
+	text = strings.TrimRight(text, "\r\n")
+	if b.maxBytes > 0 && len(text) > b.maxBytes {
+		text = text[:b.maxBytes]
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for b.count > 0 && (b.count == b.maxLines || (b.maxBytes > 0 && b.bytes+len(text) > b.maxBytes)) {
+		b.bytes -= len(b.ring[b.head].Text)
+		b.ring[b.head] = Line{}
+		b.head = (b.head + 1) % b.maxLines
+		b.count--
+	}
+
+	b.lastSeq++
+	b.ring[(b.head+b.count)%b.maxLines] = Line{
+		Seq:  b.lastSeq,
+		Time: time.Now(),
+		Text: text,
+	}
+	b.count++
+	b.bytes += len(text)
+
+	close(b.appended)
+	b.appended = make(chan struct{})
+}
+
+// Since returns the lines held by the buffer which were written after the
+// line with the given sequence number, along with a channel which is closed
+// when the next line is appended.
+func (b *Buffer) Since(seq uint64) ([]Line, <-chan struct{}) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var lines []Line
+	for i := 0; i < b.count; i++ {
+		line := b.ring[(b.head+i)%b.maxLines]
+		if line.Seq > seq {
+			lines = append(lines, line)
+		}
+	}
+	return lines, b.appended
+}
+
+// Store holds the output buffers of chaincodes keyed by package ID.
+type Store struct {
+	maxLines int
+	maxBytes int
+
+	mutex   sync.Mutex
+	buffers map[string]*Buffer
+	created chan struct{}
+}
+
+// NewStore creates a Store whose buffers hold at most maxLines lines and
+// maxBytes bytes of output per chaincode.
+func NewStore(maxLines, maxBytes int) *Store {
+	return &Store{
+		maxLines: maxLines,
+		maxBytes: maxBytes,
+		buffers:  map[string]*Buffer{},
+		created:  make(chan struct{}),
+	}
+}
+
+// Append adds a line of output written by the chaincode.
+func (s *Store) Append(ccid, text string) {
+	s.Buffer(ccid).Append(text)
+}
+
+// Buffer returns the output buffer for the chaincode, creating it if it
+// does not exist.
+func (s *Store) Buffer(ccid string) *Buffer {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	b, ok := s.buffers[ccid]
+	if !ok {
+		b = NewBuffer(s.maxLines, s.maxBytes)
+		s.buffers[ccid] = b
+		close(s.created)
+		s.created = make(chan struct{})
+	}
+	return b
+}
+
+// Lookup returns the output buffer for the chaincode if any output has been
+// captured for it.
+func (s *Store) Lookup(ccid string) (*Buffer, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	b, ok := s.buffers[ccid]
+	return b, ok
+}
+
+// Watch returns the output buffer for the chaincode if any output has been
+// captured for it. Otherwise, it returns a channel which is closed when a
+// buffer is created for any chaincode.
+func (s *Store) Watch(ccid string) (*Buffer, <-chan struct{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.buffers[ccid], s.created
+}
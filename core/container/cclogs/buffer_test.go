@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cclogs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func texts(lines []Line) []string {
+	var result []string
+	for _, line := range lines {
+		result = append(result, line.Text)
+	}
+	return result
+}
+
+func seqs(lines []Line) []uint64 {
+	var result []uint64
+	for _, line := range lines {
+		result = append(result, line.Seq)
+	}
+	return result
+}
+
+func TestBufferMaxLines(t *testing.T) {
+	b := NewBuffer(3, 0)
+
+	lines, _ := b.Since(0)
+	require.Empty(t, lines)
+
+	for _, text := range []string{"one\n", "two\r\n", "three", "four", "five"} {
+		b.Append(text)
+	}
+
+	lines, _ = b.Since(0)
+	require.Equal(t, []string{"three", "four", "five"}, texts(lines))
+	require.Equal(t, []uint64{3, 4, 5}, seqs(lines))
+
+	lines, _ = b.Since(4)
+	require.Equal(t, []string{"five"}, texts(lines))
+
+	lines, _ = b.Since(5)
+	require.Empty(t, lines)
+}
+
+func TestBufferMaxBytes(t *testing.T) {
+	b := NewBuffer(10, 8)
+
+	b.Append("abc")
+	b.Append("def")
+	lines, _ := b.Since(0)
+	require.Equal(t, []string{"abc", "def"}, texts(lines))
+
+	b.Append("ghi")
+	lines, _ = b.Since(0)
+	require.Equal(t, []string{"def", "ghi"}, texts(lines))
+
+	b.Append("0123456789")
+	lines, _ = b.Since(0)
+	require.Equal(t, []string{"01234567"}, texts(lines))
+	require.Equal(t, []uint64{4}, seqs(lines))
+}
+
+func TestBufferAppended(t *testing.T) {
+	b := NewBuffer(10, 0)
+
+	_, appended := b.Since(0)
+	select {
+	case <-appended:
+		t.Fatal("appended closed before a line was appended")
+	default:
+	}
+
+	b.Append("line")
+	select {
+	case <-appended:
+	default:
+		t.Fatal("appended not closed after a line was appended")
+	}
+
+	lines, appended := b.Since(1)
+	require.Empty(t, lines)
+	select {
+	case <-appended:
+		t.Fatal("appended closed before the next line was appended")
+	default:
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := NewStore(2, 0)
+
+	_, ok := s.Lookup("cc:1")
+	require.False(t, ok)
+	b, created := s.Watch("cc:1")
+	require.Nil(t, b)
+	require.NotNil(t, created)
+
+	s.Append("cc:1", "one")
+	select {
+	case <-created:
+	default:
+		t.Fatal("created channel should be closed")
+	}
+	s.Append("cc:1", "two")
+	s.Append("cc:1", "three")
+	s.Append("cc:2", "other")
+
+	b, ok = s.Lookup("cc:1")
+	require.True(t, ok)
+	watched, _ := s.Watch("cc:1")
+	require.Same(t, b, watched)
+	lines, _ := b.Since(0)
+	require.Equal(t, []string{"two", "three"}, texts(lines))
+
+	require.Same(t, b, s.Buffer("cc:1"))
+	lines, _ = s.Buffer("cc:2").Since(0)
+	require.Equal(t, []string{"other"}, texts(lines))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cclogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler serves the output captured for a chaincode. The package ID of the
+// chaincode is provided by the packageID query parameter. Lines written after
+// the line numbered by the optional since parameter are written to the
+// response as a stream of JSON objects. When the follow parameter is true,
+// lines are streamed as they are written until the request is cancelled,
+// including when no output has been captured for the chaincode yet.
+type Handler struct {
+	Store  *Store
+	Logger *flogging.FabricLogger
+}
+
+func NewHandler(store *Store) *Handler {
+	return &Handler{
+		Store:  store,
+		Logger: flogging.MustGetLogger("cclogs"),
+	}
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendError(resp, http.StatusBadRequest, fmt.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	query := req.URL.Query()
+	packageID := query.Get("packageID")
+	if packageID == "" {
+		h.sendError(resp, http.StatusBadRequest, fmt.Errorf("packageID must be specified"))
+		return
+	}
+
+	var since uint64
+	if s := query.Get("since"); s != "" {
+		var err error
+		since, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			h.sendError(resp, http.StatusBadRequest, fmt.Errorf("invalid since '%s'", s))
+			return
+		}
+	}
+
+	var follow bool
+	if f := query.Get("follow"); f != "" {
+		var err error
+		follow, err = strconv.ParseBool(f)
+		if err != nil {
+			h.sendError(resp, http.StatusBadRequest, fmt.Errorf("invalid follow '%s'", f))
+			return
+		}
+	}
+
+	buffer, ok := h.Store.Lookup(packageID)
+	if !ok && !follow {
+		h.sendError(resp, http.StatusNotFound, fmt.Errorf("no output captured for chaincode '%s'", packageID))
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	flusher, _ := resp.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	// Buffers are only created when output is captured, so that requests
+	// for unknown package IDs do not hold buffers
+	for buffer == nil {
+		var created <-chan struct{}
+		if buffer, created = h.Store.Watch(packageID); buffer != nil {
+			break
+		}
+		select {
+		case <-created:
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	encoder := json.NewEncoder(resp)
+	for {
+		lines, appended := buffer.Since(since)
+		for _, line := range lines {
+			if err := encoder.Encode(line); err != nil {
+				h.Logger.Debugw("failed to write chaincode output", "packageID", packageID, "error", err)
+				return
+			}
+			since = line.Seq
+		}
+		if !follow {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		select {
+		case <-appended:
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+func (h *Handler) sendError(resp http.ResponseWriter, code int, err error) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := json.NewEncoder(resp).Encode(&ErrorResponse{Error: err.Error()}); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cclogs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decodeLines(t *testing.T, body string) []Line {
+	var lines []Line
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		var line Line
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestHandler(t *testing.T) {
+	store := NewStore(10, 0)
+	store.Append("cc:1", "one")
+	store.Append("cc:1", "two")
+	handler := NewHandler(store)
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/chaincode/logs?packageID=cc:1", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	lines := decodeLines(t, resp.Body.String())
+	require.Equal(t, []string{"one", "two"}, texts(lines))
+	require.Equal(t, []uint64{1, 2}, seqs(lines))
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/chaincode/logs?packageID=cc:1&since=1", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, []string{"two"}, texts(decodeLines(t, resp.Body.String())))
+}
+
+func TestHandlerErrors(t *testing.T) {
+	handler := NewHandler(NewStore(10, 0))
+
+	tests := []struct {
+		method string
+		target string
+		code   int
+		err    string
+	}{
+		{http.MethodPut, "/chaincode/logs?packageID=cc:1", http.StatusBadRequest, "invalid request method: PUT"},
+		{http.MethodGet, "/chaincode/logs", http.StatusBadRequest, "packageID must be specified"},
+		{http.MethodGet, "/chaincode/logs?packageID=cc:1&since=bad", http.StatusBadRequest, "invalid since 'bad'"},
+		{http.MethodGet, "/chaincode/logs?packageID=cc:1&follow=bad", http.StatusBadRequest, "invalid follow 'bad'"},
+		{http.MethodGet, "/chaincode/logs?packageID=cc:1", http.StatusNotFound, "no output captured for chaincode 'cc:1'"},
+	}
+
+	for _, tt := range tests {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(tt.method, tt.target, nil))
+		require.Equal(t, tt.code, resp.Code, tt.target)
+		require.JSONEq(t, `{"error":"`+tt.err+`"}`, resp.Body.String())
+	}
+}
+
+func TestHandlerFollow(t *testing.T) {
+	store := NewStore(10, 0)
+	server := httptest.NewServer(NewHandler(store))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?packageID=cc:1&follow=true", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	// no buffer is created for the chaincode until its output is captured
+	_, ok := store.Lookup("cc:1")
+	require.False(t, ok)
+
+	decoder := json.NewDecoder(resp.Body)
+	for _, text := range []string{"one", "two"} {
+		store.Append("cc:1", text)
+
+		var line Line
+		done := make(chan error, 1)
+		go func() { done <- decoder.Decode(&line) }()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for followed output")
+		}
+		require.Equal(t, text, line.Text)
+	}
+}
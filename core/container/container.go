@@ -25,6 +25,7 @@ var vmLogger = flogging.MustGetLogger("container")
 // DockerBuilder is what is exposed by the dockercontroller
 type DockerBuilder interface {
 	Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackageStream io.Reader) (Instance, error)
+	RemoveBuild(ccid string) error
 }
 
 //go:generate counterfeiter -o mock/external_builder.go --fake-name ExternalBuilder . ExternalBuilder
@@ -32,6 +33,7 @@ type DockerBuilder interface {
 // ExternalBuilder is what is exposed by the dockercontroller
 type ExternalBuilder interface {
 	Build(ccid string, metadata []byte, codePackageStream io.Reader) (Instance, error)
+	RemoveBuild(ccid string) error
 }
 
 //go:generate counterfeiter -o mock/instance.go --fake-name Instance . Instance
@@ -46,6 +48,16 @@ type Instance interface {
 	Wait() (int, error)
 }
 
+//go:generate counterfeiter -o mock/replicable_instance.go --fake-name ReplicableInstance . ReplicableInstance
+
+// ReplicableInstance is an Instance of which several processes may be started.
+type ReplicableInstance interface {
+	Instance
+	// Replica returns an instance which starts the process of the chaincode
+	// with the given index, independently of the processes already started.
+	Replica(index int) Instance
+}
+
 type UninitializedInstance struct{}
 
 func (UninitializedInstance) Start(peerConnection *ccintf.PeerConnection) error {
@@ -75,6 +87,7 @@ type Router struct {
 	ExternalBuilder ExternalBuilder
 	DockerBuilder   DockerBuilder
 	containers      map[string]Instance
+	replicas        map[string]map[int]Instance // instances of the processes of a chaincode after the first one
 	PackageProvider PackageProvider
 	mutex           sync.Mutex
 }
@@ -83,9 +96,9 @@ func (r *Router) getInstance(ccid string) Instance {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	// Note, to resolve the locking problem which existed in the previous code, we never delete
-	// references from the map.  In this way, it is safe to release the lock and operate
-	// on the returned reference
+	// Note, to resolve the locking problem which existed in the previous code, we never modify
+	// the instances referenced by the map.  In this way, it is safe to release the lock and
+	// operate on the returned reference
 	vm, ok := r.containers[ccid]
 	if !ok {
 		return UninitializedInstance{}
@@ -94,6 +107,52 @@ func (r *Router) getInstance(ccid string) Instance {
 	return vm
 }
 
+// getReplica returns the instance which starts the process of the chaincode
+// with the given index. The first process is started by the built instance.
+func (r *Router) getReplica(ccid string, index int) (Instance, error) {
+	if index == 0 {
+		return r.getInstance(ccid), nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	vm, ok := r.containers[ccid]
+	if !ok {
+		return UninitializedInstance{}, nil
+	}
+	if replica, ok := r.replicas[ccid][index]; ok {
+		return replica, nil
+	}
+	replicable, ok := vm.(ReplicableInstance)
+	if !ok {
+		return nil, errors.Errorf("chaincode %s does not support starting more than one instance", ccid)
+	}
+
+	replica := replicable.Replica(index)
+	if r.replicas == nil {
+		r.replicas = map[string]map[int]Instance{}
+	}
+	if r.replicas[ccid] == nil {
+		r.replicas[ccid] = map[int]Instance{}
+	}
+	r.replicas[ccid][index] = replica
+	return replica, nil
+}
+
+// getReplicas returns the instances of the processes of the chaincode which
+// were started after the first one.
+func (r *Router) getReplicas(ccid string) []Instance {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var replicas []Instance
+	for _, replica := range r.replicas[ccid] {
+		replicas = append(replicas, replica)
+	}
+	return replicas
+}
+
 func (r *Router) Build(ccid string) error {
 	var instance Instance
 
@@ -134,7 +193,30 @@ func (r *Router) Build(ccid string) error {
 		r.containers = map[string]Instance{}
 	}
 	r.containers[ccid] = instance
+	delete(r.replicas, ccid)
+
+	return nil
+}
+
+// RemoveBuild discards the built instance of the chaincode and removes the build
+// output persisted by the external builders and the image built by docker, if
+// any. The chaincode should be stopped before its build is removed.
+func (r *Router) RemoveBuild(ccid string) error {
+	r.mutex.Lock()
+	delete(r.containers, ccid)
+	delete(r.replicas, ccid)
+	r.mutex.Unlock()
 
+	if r.ExternalBuilder != nil {
+		if err := r.ExternalBuilder.RemoveBuild(ccid); err != nil {
+			return errors.WithMessage(err, "external builder failed to remove build")
+		}
+	}
+	if r.DockerBuilder != nil {
+		if err := r.DockerBuilder.RemoveBuild(ccid); err != nil {
+			return errors.WithMessage(err, "docker builder failed to remove build")
+		}
+	}
 	return nil
 }
 
@@ -142,16 +224,43 @@ func (r *Router) ChaincodeServerInfo(ccid string) (*ccintf.ChaincodeServerInfo,
 	return r.getInstance(ccid).ChaincodeServerInfo()
 }
 
-func (r *Router) Start(ccid string, peerConnection *ccintf.PeerConnection) error {
-	return r.getInstance(ccid).Start(peerConnection)
+// Start starts the process of the chaincode with the given index. Processes
+// other than the first one may only be started for instances which are
+// replicable.
+func (r *Router) Start(ccid string, index int, peerConnection *ccintf.PeerConnection) error {
+	instance, err := r.getReplica(ccid, index)
+	if err != nil {
+		return err
+	}
+	err = instance.Start(peerConnection)
+	if err != nil && index != 0 {
+		// a process which could not be started is not stopped
+		r.mutex.Lock()
+		delete(r.replicas[ccid], index)
+		r.mutex.Unlock()
+	}
+	return err
 }
 
+// Stop stops every process of the chaincode. The first error encountered
+// is returned.
 func (r *Router) Stop(ccid string) error {
-	return r.getInstance(ccid).Stop()
+	err := r.getInstance(ccid).Stop()
+	for _, replica := range r.getReplicas(ccid) {
+		if replicaErr := replica.Stop(); replicaErr != nil && err == nil {
+			err = replicaErr
+		}
+	}
+	return err
 }
 
-func (r *Router) Wait(ccid string) (int, error) {
-	return r.getInstance(ccid).Wait()
+// Wait waits for the process of the chaincode with the given index to exit.
+func (r *Router) Wait(ccid string, index int) (int, error) {
+	instance, err := r.getReplica(ccid, index)
+	if err != nil {
+		return 0, err
+	}
+	return instance.Wait()
 }
 
 func (r *Router) Shutdown(timeout time.Duration) {
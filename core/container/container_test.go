@@ -181,6 +181,7 @@ var _ = Describe("Router", func() {
 			It("passes through to the docker impl", func() {
 				err := router.Start(
 					"fake-id",
+					0,
 					&ccintf.PeerConnection{
 						Address: "peer-address",
 						TLSConfig: &ccintf.TLSConfig{
@@ -207,6 +208,7 @@ var _ = Describe("Router", func() {
 				It("returns an error", func() {
 					err := router.Start(
 						"missing-name",
+						0,
 						&ccintf.PeerConnection{
 							Address: "peer-address",
 						},
@@ -216,6 +218,79 @@ var _ = Describe("Router", func() {
 			})
 		})
 
+		Describe("Start with an index other than zero", func() {
+			It("returns an error when the instance cannot be replicated", func() {
+				err := router.Start("fake-id", 1, &ccintf.PeerConnection{Address: "peer-address"})
+				Expect(err).To(MatchError("chaincode fake-id does not support starting more than one instance"))
+				Expect(fakeInstance.StartCallCount()).To(Equal(0))
+			})
+
+			Context("when the instance is replicable", func() {
+				var (
+					fakeReplicableInstance *mock.ReplicableInstance
+					fakeReplicas           []*mock.Instance
+				)
+
+				BeforeEach(func() {
+					fakeReplicableInstance = &mock.ReplicableInstance{}
+					fakeReplicas = []*mock.Instance{{}, {}}
+					fakeReplicableInstance.ReplicaReturnsOnCall(0, fakeReplicas[0])
+					fakeReplicableInstance.ReplicaReturnsOnCall(1, fakeReplicas[1])
+					fakeExternalBuilder.BuildReturns(fakeReplicableInstance, nil)
+					err := router.Build("fake-id")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("starts a replica of the instance", func() {
+					err := router.Start("fake-id", 1, &ccintf.PeerConnection{Address: "peer-address"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeReplicableInstance.ReplicaCallCount()).To(Equal(1))
+					Expect(fakeReplicableInstance.ReplicaArgsForCall(0)).To(Equal(1))
+					Expect(fakeReplicableInstance.StartCallCount()).To(Equal(0))
+					Expect(fakeReplicas[0].StartCallCount()).To(Equal(1))
+					Expect(fakeReplicas[0].StartArgsForCall(0)).To(Equal(&ccintf.PeerConnection{Address: "peer-address"}))
+				})
+
+				It("waits on the replica", func() {
+					fakeReplicas[0].WaitReturns(3, nil)
+					err := router.Start("fake-id", 1, &ccintf.PeerConnection{Address: "peer-address"})
+					Expect(err).NotTo(HaveOccurred())
+
+					res, err := router.Wait("fake-id", 1)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(res).To(Equal(3))
+					Expect(fakeReplicableInstance.ReplicaCallCount()).To(Equal(1))
+					Expect(fakeReplicableInstance.WaitCallCount()).To(Equal(0))
+				})
+
+				It("stops every started process", func() {
+					err := router.Start("fake-id", 1, &ccintf.PeerConnection{Address: "peer-address"})
+					Expect(err).NotTo(HaveOccurred())
+					fakeReplicas[0].StopReturns(errors.New("fake-stop-error"))
+
+					err = router.Stop("fake-id")
+					Expect(err).To(MatchError("fake-stop-error"))
+					Expect(fakeReplicableInstance.StopCallCount()).To(Equal(1))
+					Expect(fakeReplicas[0].StopCallCount()).To(Equal(1))
+				})
+
+				Context("when the replica fails to start", func() {
+					BeforeEach(func() {
+						fakeReplicas[0].StartReturns(errors.New("fake-start-error"))
+					})
+
+					It("does not stop the replica", func() {
+						err := router.Start("fake-id", 1, &ccintf.PeerConnection{Address: "peer-address"})
+						Expect(err).To(MatchError("fake-start-error"))
+
+						err = router.Stop("fake-id")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeReplicas[0].StopCallCount()).To(Equal(0))
+					})
+				})
+			})
+		})
+
 		Describe("Stop", func() {
 			BeforeEach(func() {
 				fakeInstance.StopReturns(errors.New("Boo"))
@@ -235,6 +310,55 @@ var _ = Describe("Router", func() {
 			})
 		})
 
+		Describe("RemoveBuild", func() {
+			It("discards the instance and removes the external build output", func() {
+				err := router.RemoveBuild("fake-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeExternalBuilder.RemoveBuildCallCount()).To(Equal(1))
+				Expect(fakeExternalBuilder.RemoveBuildArgsForCall(0)).To(Equal("fake-id"))
+				Expect(fakeDockerBuilder.RemoveBuildCallCount()).To(Equal(1))
+				Expect(fakeDockerBuilder.RemoveBuildArgsForCall(0)).To(Equal("fake-id"))
+
+				err = router.Stop("fake-id")
+				Expect(err).To(MatchError("instance has not yet been built, cannot be stopped"))
+			})
+
+			Context("when the external builder fails to remove the build output", func() {
+				BeforeEach(func() {
+					fakeExternalBuilder.RemoveBuildReturns(errors.New("fake-remove-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.RemoveBuild("fake-id")
+					Expect(err).To(MatchError("external builder failed to remove build: fake-remove-error"))
+				})
+			})
+
+			Context("when the docker builder fails to remove the build", func() {
+				BeforeEach(func() {
+					fakeDockerBuilder.RemoveBuildReturns(errors.New("fake-remove-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.RemoveBuild("fake-id")
+					Expect(err).To(MatchError("docker builder failed to remove build: fake-remove-error"))
+				})
+			})
+
+			Context("when an external builder is not provided", func() {
+				BeforeEach(func() {
+					router.ExternalBuilder = nil
+				})
+
+				It("discards the instance", func() {
+					err := router.RemoveBuild("fake-id")
+					Expect(err).NotTo(HaveOccurred())
+					err = router.Stop("fake-id")
+					Expect(err).To(MatchError("instance has not yet been built, cannot be stopped"))
+				})
+			})
+		})
+
 		Describe("Wait", func() {
 			BeforeEach(func() {
 				fakeInstance.WaitReturns(7, errors.New("fake-wait-error"))
@@ -243,6 +367,7 @@ var _ = Describe("Router", func() {
 			It("passes through to the docker impl", func() {
 				res, err := router.Wait(
 					"fake-id",
+					0,
 				)
 				Expect(res).To(Equal(7))
 				Expect(err).To(MatchError("fake-wait-error"))
@@ -251,7 +376,7 @@ var _ = Describe("Router", func() {
 
 			Context("when the chaincode has not yet been built", func() {
 				It("returns an error", func() {
-					_, err := router.Wait("missing-name")
+					_, err := router.Wait("missing-name", 0)
 					Expect(err).To(MatchError("instance has not yet been built, cannot wait"))
 				})
 			})
@@ -70,6 +70,8 @@ type dockerClient interface {
 	WaitContainer(containerID string) (int, error)
 	// InspectImage returns an image by its name or ID.
 	InspectImage(imageName string) (*docker.Image, error)
+	// RemoveImage removes an image by its name or ID.
+	RemoveImage(imageName string) error
 }
 
 type PlatformBuilder interface {
@@ -80,10 +82,24 @@ type ContainerInstance struct {
 	CCID     string
 	Type     string
 	DockerVM *DockerVM
+	// Index distinguishes the containers of the chaincode when several
+	// of them are started.
+	Index int
 }
 
 func (ci *ContainerInstance) Start(peerConnection *ccintf.PeerConnection) error {
-	return ci.DockerVM.Start(ci.CCID, ci.Type, peerConnection)
+	return ci.DockerVM.start(ci.CCID, ci.Index, ci.Type, peerConnection)
+}
+
+// Replica returns an instance which starts another container of the
+// chaincode from the same image.
+func (ci *ContainerInstance) Replica(index int) container.Instance {
+	return &ContainerInstance{
+		CCID:     ci.CCID,
+		Type:     ci.Type,
+		DockerVM: ci.DockerVM,
+		Index:    index,
+	}
 }
 
 func (ci *ContainerInstance) ChaincodeServerInfo() (*ccintf.ChaincodeServerInfo, error) {
@@ -91,11 +107,11 @@ func (ci *ContainerInstance) ChaincodeServerInfo() (*ccintf.ChaincodeServerInfo,
 }
 
 func (ci *ContainerInstance) Stop() error {
-	return ci.DockerVM.Stop(ci.CCID)
+	return ci.DockerVM.stop(ci.CCID, ci.Index)
 }
 
 func (ci *ContainerInstance) Wait() (int, error) {
-	return ci.DockerVM.Wait(ci.CCID)
+	return ci.DockerVM.wait(ci.CCID, ci.Index)
 }
 
 // DockerVM is a vm. It is identified by an image id
@@ -111,6 +127,18 @@ type DockerVM struct {
 	PlatformBuilder PlatformBuilder
 	LoggingEnv      []string
 	MSPID           string
+
+	// ChaincodeHostConfigs holds the host config overrides for specific
+	// chaincodes keyed by lower case package label or legacy chaincode name.
+	ChaincodeHostConfigs map[string]HostConfigOverride
+
+	// ChaincodeLogs, when set, captures the output of chaincode containers.
+	ChaincodeLogs ChaincodeLogs
+}
+
+// ChaincodeLogs captures the output written by running chaincode.
+type ChaincodeLogs interface {
+	Append(ccid, line string)
 }
 
 // HealthCheck checks if the DockerVM is able to communicate with the Docker
@@ -122,7 +150,7 @@ func (vm *DockerVM) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
-func (vm *DockerVM) createContainer(imageID, containerID string, args, env []string) error {
+func (vm *DockerVM) createContainer(imageID, containerID string, args, env []string, hostConfig *docker.HostConfig) error {
 	logger := dockerLogger.With("imageID", imageID, "containerID", containerID)
 	logger.Debugw("create container")
 	_, err := vm.Client.CreateContainer(docker.CreateContainerOptions{
@@ -134,7 +162,7 @@ func (vm *DockerVM) createContainer(imageID, containerID string, args, env []str
 			AttachStdout: vm.AttachStdOut,
 			AttachStderr: vm.AttachStdOut,
 		},
-		HostConfig: vm.HostConfig,
+		HostConfig: hostConfig,
 	})
 	if err != nil {
 		return err
@@ -211,6 +239,26 @@ func (vm *DockerVM) Build(ccid string, metadata *persistence.ChaincodePackageMet
 	}, nil
 }
 
+// RemoveBuild removes the image built for the chaincode, if any. The chaincode
+// container should be stopped before its image is removed.
+func (vm *DockerVM) RemoveBuild(ccid string) error {
+	imageName, err := vm.GetVMNameForDocker(ccid)
+	if err != nil {
+		return err
+	}
+
+	err = vm.Client.RemoveImage(imageName)
+	switch err {
+	case nil:
+		dockerLogger.Debugf("Removed image: %s", imageName)
+		return nil
+	case docker.ErrNoSuchImage:
+		return nil
+	default:
+		return errors.Wrap(err, "docker image removal failed")
+	}
+}
+
 // In order to support starting chaincode containers built with Fabric v1.4 and earlier,
 // we must check for the precense of the start.sh script for Node.js chaincode before
 // attempting to call it.
@@ -277,12 +325,17 @@ func (vm *DockerVM) GetEnv(ccid string, tlsConfig *ccintf.TLSConfig) []string {
 
 // Start starts a container using a previously created docker image
 func (vm *DockerVM) Start(ccid string, ccType string, peerConnection *ccintf.PeerConnection) error {
+	return vm.start(ccid, 0, ccType, peerConnection)
+}
+
+// start starts the container of the chaincode with the given index
+func (vm *DockerVM) start(ccid string, index int, ccType string, peerConnection *ccintf.PeerConnection) error {
 	imageName, err := vm.GetVMNameForDocker(ccid)
 	if err != nil {
 		return err
 	}
 
-	containerName := vm.GetVMName(ccid)
+	containerName := vm.containerName(ccid, index)
 	logger := dockerLogger.With("imageName", imageName, "containerName", containerName)
 
 	vm.stopInternal(containerName)
@@ -296,16 +349,23 @@ func (vm *DockerVM) Start(ccid string, ccType string, peerConnection *ccintf.Pee
 	env := vm.GetEnv(ccid, peerConnection.TLSConfig)
 	dockerLogger.Debugf("start container with env:\n\t%s", strings.Join(env, "\n\t"))
 
-	err = vm.createContainer(imageName, containerName, args, env)
+	err = vm.createContainer(imageName, containerName, args, env, vm.hostConfig(ccid))
 	if err != nil {
 		logger.Errorf("create container failed: %s", err)
 		return err
 	}
 
-	// stream stdout and stderr to chaincode logger
-	if vm.AttachStdOut {
-		containerLogger := flogging.MustGetLogger("peer.chaincode." + containerName)
-		streamOutput(dockerLogger, vm.Client, containerName, containerLogger)
+	// stream stdout and stderr to chaincode logger and log capture
+	if vm.AttachStdOut || vm.ChaincodeLogs != nil {
+		var containerLogger *flogging.FabricLogger
+		if vm.AttachStdOut {
+			containerLogger = flogging.MustGetLogger("peer.chaincode." + containerName)
+		}
+		var capture func(string)
+		if vm.ChaincodeLogs != nil {
+			capture = func(line string) { vm.ChaincodeLogs.Append(ccid, line) }
+		}
+		streamOutput(dockerLogger, vm.Client, containerName, containerLogger, capture)
 	}
 
 	// upload TLS files to the container before starting it if needed
@@ -376,8 +436,9 @@ func addFiles(tw *tar.Writer, contents map[string][]byte) error {
 	return nil
 }
 
-// streamOutput mirrors output from the named container to a fabric logger.
-func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerName string, containerLogger *flogging.FabricLogger) {
+// streamOutput mirrors output from the named container to a fabric logger
+// and to the capture function when they are not nil.
+func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerName string, containerLogger *flogging.FabricLogger, capture func(string)) {
 	// Launch a few go routines to manage output streams from the container.
 	// They will be automatically destroyed when the container exits
 	attached := make(chan struct{})
@@ -423,7 +484,12 @@ func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerN
 			// until the pipe is closed
 			line, err := is.ReadString('\n')
 			if len(line) > 0 {
-				containerLogger.Info(line)
+				if containerLogger != nil {
+					containerLogger.Info(line)
+				}
+				if capture != nil {
+					capture(line)
+				}
 			}
 			switch err {
 			case nil:
@@ -440,18 +506,36 @@ func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerN
 
 // Stop stops a running chaincode
 func (vm *DockerVM) Stop(ccid string) error {
-	id := vm.ccidToContainerID(ccid)
+	return vm.stop(ccid, 0)
+}
+
+func (vm *DockerVM) stop(ccid string, index int) error {
+	id := vm.ccidToContainerID(ccid, index)
 	return vm.stopInternal(id)
 }
 
 // Wait blocks until the container stops and returns the exit code of the container.
 func (vm *DockerVM) Wait(ccid string) (int, error) {
-	id := vm.ccidToContainerID(ccid)
+	return vm.wait(ccid, 0)
+}
+
+func (vm *DockerVM) wait(ccid string, index int) (int, error) {
+	id := vm.ccidToContainerID(ccid, index)
 	return vm.Client.WaitContainer(id)
 }
 
-func (vm *DockerVM) ccidToContainerID(ccid string) string {
-	return strings.Replace(vm.GetVMName(ccid), ":", "_", -1)
+func (vm *DockerVM) ccidToContainerID(ccid string, index int) string {
+	return strings.Replace(vm.containerName(ccid, index), ":", "_", -1)
+}
+
+// containerName returns the name of the container of the chaincode with the
+// given index. The first container is named after the chaincode alone.
+func (vm *DockerVM) containerName(ccid string, index int) string {
+	name := vm.GetVMName(ccid)
+	if index != 0 {
+		name = fmt.Sprintf("%s-%d", name, index)
+	}
+	return name
 }
 
 func (vm *DockerVM) stopInternal(id string) error {
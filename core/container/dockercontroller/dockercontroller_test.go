@@ -202,6 +202,31 @@ func Test_Start(t *testing.T) {
 	gt.Expect(err).NotTo(HaveOccurred())
 }
 
+type chaincodeLogs chan [2]string
+
+func (c chaincodeLogs) Append(ccid, line string) { c <- [2]string{ccid, line} }
+
+func Test_StartCapturesOutput(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	dockerClient := &mock.DockerClient{}
+	dockerClient.AttachToContainerStub = func(opts docker.AttachToContainerOptions) error {
+		opts.Success <- struct{}{}
+		<-opts.Success
+		fmt.Fprintf(opts.OutputStream, "chaincode-output\n")
+		return nil
+	}
+	logs := make(chaincodeLogs, 1)
+	dvm := DockerVM{
+		BuildMetrics:  NewBuildMetrics(&disabled.Provider{}),
+		Client:        dockerClient,
+		ChaincodeLogs: logs,
+	}
+
+	err := dvm.Start("simple:1.0", "GOLANG", &ccintf.PeerConnection{Address: "peer-address"})
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Eventually(logs).Should(Receive(Equal([2]string{"simple:1.0", "chaincode-output\n"})))
+}
+
 func Test_streamOutput(t *testing.T) {
 	gt := NewGomegaWithT(t)
 
@@ -216,7 +241,8 @@ func Test_streamOutput(t *testing.T) {
 		return <-errCh
 	}
 
-	streamOutput(logger, client, "container-name", containerLogger)
+	captured := make(chan string, 2)
+	streamOutput(logger, client, "container-name", containerLogger, func(line string) { captured <- line })
 
 	var opts docker.AttachToContainerOptions
 	gt.Eventually(optsCh).Should(Receive(&opts))
@@ -234,6 +260,8 @@ func Test_streamOutput(t *testing.T) {
 	gt.Consistently(recorder.Entries).Should(HaveLen(1))
 	gt.Eventually(containerRecorder).Should(gbytes.Say("message-two"))
 	gt.Consistently(containerRecorder.Entries).Should(HaveLen(2))
+	gt.Eventually(captured).Should(Receive(Equal("message-one\n")))
+	gt.Eventually(captured).Should(Receive(Equal("message-two")))
 }
 
 func Test_BuildMetric(t *testing.T) {
@@ -301,6 +329,57 @@ func Test_Wait(t *testing.T) {
 	require.EqualError(t, err, "no-wait-for-you")
 }
 
+func TestContainerInstanceReplica(t *testing.T) {
+	client := &mock.DockerClient{}
+	dvm := &DockerVM{
+		BuildMetrics: NewBuildMetrics(&disabled.Provider{}),
+		Client:       client,
+	}
+	instance := &ContainerInstance{CCID: "the-name:the-version", Type: "GOLANG", DockerVM: dvm}
+
+	replica := instance.Replica(2)
+	require.Equal(t, &ContainerInstance{CCID: "the-name:the-version", Type: "GOLANG", DockerVM: dvm, Index: 2}, replica)
+
+	err := replica.Start(&ccintf.PeerConnection{Address: "peer-address"})
+	require.NoError(t, err)
+	require.Equal(t, "the-name-the-version-2", client.CreateContainerArgsForCall(0).Name)
+	startedName, _ := client.StartContainerArgsForCall(0)
+	require.Equal(t, "the-name-the-version-2", startedName)
+
+	client.WaitContainerReturns(3, nil)
+	exitCode, err := replica.Wait()
+	require.NoError(t, err)
+	require.Equal(t, 3, exitCode)
+	require.Equal(t, "the-name-the-version-2", client.WaitContainerArgsForCall(0))
+
+	err = replica.Stop()
+	require.NoError(t, err)
+	stoppedName, _ := client.StopContainerArgsForCall(0)
+	require.Equal(t, "the-name-the-version-2", stoppedName)
+}
+
+func TestRemoveBuild(t *testing.T) {
+	client := &mock.DockerClient{}
+	dvm := &DockerVM{Client: client}
+	imageName, err := dvm.GetVMNameForDocker("the-name:the-version")
+	require.NoError(t, err)
+
+	err = dvm.RemoveBuild("the-name:the-version")
+	require.NoError(t, err)
+	require.Equal(t, 1, client.RemoveImageCallCount())
+	require.Equal(t, imageName, client.RemoveImageArgsForCall(0))
+
+	// the image does not exist
+	client.RemoveImageReturns(docker.ErrNoSuchImage)
+	err = dvm.RemoveBuild("the-name:the-version")
+	require.NoError(t, err)
+
+	// removal fails
+	client.RemoveImageReturns(errors.New("image-in-use"))
+	err = dvm.RemoveBuild("the-name:the-version")
+	require.EqualError(t, err, "docker image removal failed: image-in-use")
+}
+
 func TestHealthCheck(t *testing.T) {
 	client := &mock.DockerClient{}
 	vm := &DockerVM{Client: client}
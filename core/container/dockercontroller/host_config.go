@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"regexp"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/pkg/errors"
+)
+
+const (
+	// minMemory is the smallest memory limit accepted by the Docker daemon.
+	minMemory = 6 * 1024 * 1024
+	// minCPUQuota and minCPUPeriod are the smallest CFS quota and period,
+	// in microseconds, accepted by the Docker daemon.
+	minCPUQuota  = 1000
+	minCPUPeriod = 1000
+	// maxCPUPeriod is the largest CFS period, in microseconds, accepted by
+	// the Docker daemon.
+	maxCPUPeriod = 1000000
+)
+
+var capabilityRegExp = regexp.MustCompile("^[A-Za-z_]+$")
+
+// HostConfigOverride holds the container resource settings which replace
+// the peer-wide docker host configuration for a specific chaincode. Only the
+// settings which are set are replaced.
+type HostConfigOverride struct {
+	Memory         *int64
+	CPUQuota       *int64 `mapstructure:"CpuQuota"`
+	CPUPeriod      *int64 `mapstructure:"CpuPeriod"`
+	PidsLimit      *int64
+	ReadonlyRootfs *bool
+	CapDrop        []string
+}
+
+// apply returns a copy of the host configuration with the override applied.
+func (o HostConfigOverride) apply(hc *docker.HostConfig) *docker.HostConfig {
+	result := &docker.HostConfig{}
+	if hc != nil {
+		*result = *hc
+	}
+	if o.Memory != nil {
+		result.Memory = *o.Memory
+	}
+	if o.CPUQuota != nil {
+		result.CPUQuota = *o.CPUQuota
+	}
+	if o.CPUPeriod != nil {
+		result.CPUPeriod = *o.CPUPeriod
+	}
+	if o.PidsLimit != nil {
+		pidsLimit := *o.PidsLimit
+		result.PidsLimit = &pidsLimit
+	}
+	if o.ReadonlyRootfs != nil {
+		result.ReadonlyRootfs = *o.ReadonlyRootfs
+	}
+	if o.CapDrop != nil {
+		result.CapDrop = o.CapDrop
+	}
+	return result
+}
+
+// hostConfig returns the host configuration used to create the container
+// for the chaincode. Overrides are keyed by the part of the package ID which
+// precedes the hash: the package label for chaincode installed through
+// _lifecycle and the chaincode name for legacy chaincode.
+func (vm *DockerVM) hostConfig(ccid string) *docker.HostConfig {
+	label := ccid
+	if i := strings.LastIndex(ccid, ":"); i != -1 {
+		label = ccid[:i]
+	}
+	override, ok := vm.ChaincodeHostConfigs[strings.ToLower(label)]
+	if !ok {
+		return vm.HostConfig
+	}
+	return override.apply(vm.HostConfig)
+}
+
+// Validate checks that the container resource settings of the host
+// configuration and of every chaincode override are acceptable to the
+// Docker daemon.
+func (vm *DockerVM) Validate() error {
+	if err := validateHostConfig(vm.HostConfig); err != nil {
+		return errors.WithMessage(err, "invalid host config")
+	}
+	for label, override := range vm.ChaincodeHostConfigs {
+		if err := persistence.ValidateLabel(label); err != nil {
+			return errors.WithMessage(err, "invalid chaincode host config")
+		}
+		if err := validateHostConfig(override.apply(vm.HostConfig)); err != nil {
+			return errors.WithMessagef(err, "invalid host config for chaincode '%s'", label)
+		}
+	}
+	return nil
+}
+
+func validateHostConfig(hc *docker.HostConfig) error {
+	if hc == nil {
+		return nil
+	}
+	if hc.Memory < 0 || (hc.Memory != 0 && hc.Memory < minMemory) {
+		return errors.Errorf("Memory must be 0 or at least %d bytes, got %d", minMemory, hc.Memory)
+	}
+	if hc.CPUQuota != 0 && hc.CPUQuota != -1 && hc.CPUQuota < minCPUQuota {
+		return errors.Errorf("CpuQuota must be 0, -1 or at least %d, got %d", minCPUQuota, hc.CPUQuota)
+	}
+	if hc.CPUPeriod != 0 && (hc.CPUPeriod < minCPUPeriod || hc.CPUPeriod > maxCPUPeriod) {
+		return errors.Errorf("CpuPeriod must be 0 or between %d and %d, got %d", minCPUPeriod, maxCPUPeriod, hc.CPUPeriod)
+	}
+	if hc.PidsLimit != nil && *hc.PidsLimit < -1 {
+		return errors.Errorf("PidsLimit must be -1 or greater, got %d", *hc.PidsLimit)
+	}
+	for _, capability := range hc.CapDrop {
+		if !capabilityRegExp.MatchString(capability) {
+			return errors.Errorf("CapDrop contains invalid capability '%s'", capability)
+		}
+	}
+	return nil
+}
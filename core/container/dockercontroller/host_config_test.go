@@ -0,0 +1,153 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/dockercontroller/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestHostConfigOverride(t *testing.T) {
+	dvm := &DockerVM{
+		HostConfig: &docker.HostConfig{
+			NetworkMode: "host",
+			Memory:      2147483648,
+			CPUQuota:    100000,
+			CPUPeriod:   100000,
+			CapDrop:     []string{"NET_RAW"},
+		},
+		ChaincodeHostConfigs: map[string]HostConfigOverride{
+			"mycc": {
+				Memory:         int64Ptr(536870912),
+				PidsLimit:      int64Ptr(256),
+				ReadonlyRootfs: boolPtr(true),
+				CapDrop:        []string{"ALL"},
+			},
+		},
+	}
+
+	hc := dvm.hostConfig("MyCC:0123456789abcdef")
+	require.Equal(t, &docker.HostConfig{
+		NetworkMode:    "host",
+		Memory:         536870912,
+		CPUQuota:       100000,
+		CPUPeriod:      100000,
+		PidsLimit:      int64Ptr(256),
+		ReadonlyRootfs: true,
+		CapDrop:        []string{"ALL"},
+	}, hc)
+	require.Equal(t, int64(2147483648), dvm.HostConfig.Memory, "the peer-wide host config must not be modified")
+
+	require.Same(t, dvm.HostConfig, dvm.hostConfig("othercc:0123456789abcdef"))
+	require.Equal(t, int64(536870912), dvm.hostConfig("mycc:1.0").Memory)
+}
+
+func TestStartAppliesHostConfigOverride(t *testing.T) {
+	dockerClient := &mock.DockerClient{}
+	dockerClient.CreateContainerReturns(&docker.Container{}, nil)
+	dvm := &DockerVM{
+		BuildMetrics: NewBuildMetrics(&disabled.Provider{}),
+		Client:       dockerClient,
+		HostConfig:   &docker.HostConfig{NetworkMode: "host"},
+		ChaincodeHostConfigs: map[string]HostConfigOverride{
+			"mycc": {CPUQuota: int64Ptr(50000)},
+		},
+	}
+
+	err := dvm.Start("mycc:1.0", "GOLANG", &ccintf.PeerConnection{Address: "peer-address"})
+	require.NoError(t, err)
+	require.Equal(t, 1, dockerClient.CreateContainerCallCount())
+	opts := dockerClient.CreateContainerArgsForCall(0)
+	require.Equal(t, int64(50000), opts.HostConfig.CPUQuota)
+	require.Equal(t, "host", opts.HostConfig.NetworkMode)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		hc        *docker.HostConfig
+		overrides map[string]HostConfigOverride
+		errMsg    string
+	}{
+		{name: "nil host config"},
+		{
+			name: "valid",
+			hc: &docker.HostConfig{
+				Memory:    2147483648,
+				CPUQuota:  50000,
+				CPUPeriod: 100000,
+				PidsLimit: int64Ptr(-1),
+				CapDrop:   []string{"ALL", "CAP_NET_RAW"},
+			},
+			overrides: map[string]HostConfigOverride{
+				"mycc": {Memory: int64Ptr(0), PidsLimit: int64Ptr(256)},
+			},
+		},
+		{
+			name:   "memory too small",
+			hc:     &docker.HostConfig{Memory: 1024},
+			errMsg: "invalid host config: Memory must be 0 or at least 6291456 bytes, got 1024",
+		},
+		{
+			name:   "cpu quota too small",
+			hc:     &docker.HostConfig{CPUQuota: 10},
+			errMsg: "invalid host config: CpuQuota must be 0, -1 or at least 1000, got 10",
+		},
+		{
+			name:   "cpu period too large",
+			hc:     &docker.HostConfig{CPUPeriod: 2000000},
+			errMsg: "invalid host config: CpuPeriod must be 0 or between 1000 and 1000000, got 2000000",
+		},
+		{
+			name:   "invalid pids limit",
+			hc:     &docker.HostConfig{PidsLimit: int64Ptr(-2)},
+			errMsg: "invalid host config: PidsLimit must be -1 or greater, got -2",
+		},
+		{
+			name:   "invalid capability",
+			hc:     &docker.HostConfig{CapDrop: []string{"NET RAW"}},
+			errMsg: "invalid host config: CapDrop contains invalid capability 'NET RAW'",
+		},
+		{
+			name: "invalid override",
+			hc:   &docker.HostConfig{},
+			overrides: map[string]HostConfigOverride{
+				"mycc": {Memory: int64Ptr(-1)},
+			},
+			errMsg: "invalid host config for chaincode 'mycc': Memory must be 0 or at least 6291456 bytes, got -1",
+		},
+		{
+			name: "invalid label",
+			hc:   &docker.HostConfig{},
+			overrides: map[string]HostConfigOverride{
+				"my cc": {},
+			},
+			errMsg: "invalid chaincode host config: invalid label 'my cc'. Label must be non-empty, can only consist of alphanumerics, symbols from '.+-_', and can only begin with alphanumerics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dvm := &DockerVM{HostConfig: tt.hc, ChaincodeHostConfigs: tt.overrides}
+			err := dvm.Validate()
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
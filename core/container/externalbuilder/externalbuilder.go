@@ -171,6 +171,16 @@ func (d *Detector) Build(ccid string, mdBytes []byte, codeStream io.Reader) (*In
 	}, nil
 }
 
+// RemoveBuild removes the build output persisted in the durable path for the
+// provided package, if any.
+func (d *Detector) RemoveBuild(ccid string) error {
+	durablePath := filepath.Join(d.DurablePath, SanitizeCCIDPath(ccid))
+	if err := os.RemoveAll(durablePath); err != nil {
+		return errors.WithMessagef(err, "could not remove build output at '%s'", durablePath)
+	}
+	return nil
+}
+
 func (d *Detector) detect(buildContext *BuildContext) *Builder {
 	for _, builder := range d.Builders {
 		if builder.Detect(buildContext) {
@@ -268,6 +278,13 @@ type Builder struct {
 	Logger               *flogging.FabricLogger
 	Name                 string
 	MSPID                string
+	// ChaincodeLogs, when set, captures the output of run sessions.
+	ChaincodeLogs ChaincodeLogs
+}
+
+// ChaincodeLogs captures the output written by running chaincode.
+type ChaincodeLogs interface {
+	Append(ccid, line string)
 }
 
 // CreateBuilders will construct builders from the peer configuration.
@@ -377,7 +394,11 @@ func (b *Builder) Run(ccid, bldDir string, peerConnection *ccintf.PeerConnection
 
 	run := filepath.Join(b.Location, "bin", "run")
 	cmd := b.NewCommand(run, bldDir, launchDir)
-	sess, err := Start(b.Logger, cmd, func(error) { os.RemoveAll(launchDir) })
+	var output func(string)
+	if b.ChaincodeLogs != nil {
+		output = func(line string) { b.ChaincodeLogs.Append(ccid, line) }
+	}
+	sess, err := StartWithOutput(b.Logger, cmd, output, func(error) { os.RemoveAll(launchDir) })
 	if err != nil {
 		os.RemoveAll(launchDir)
 		return nil, errors.Wrapf(err, "builder '%s' run failed to start", b.Name)
@@ -211,6 +211,28 @@ var _ = Describe("externalbuilder", func() {
 				})
 			})
 		})
+
+		Describe("RemoveBuild", func() {
+			BeforeEach(func() {
+				_, err := detector.Build("fake-package-id", md, codePackage)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("removes the persisted build output", func() {
+				err := detector.RemoveBuild("fake-package-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(filepath.Join(durablePath, "fake-package-id")).NotTo(BeAnExistingFile())
+
+				i, err := detector.CachedBuild("fake-package-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(i).To(BeNil())
+			})
+
+			It("succeeds when there is no build output", func() {
+				err := detector.RemoveBuild("missing-package-id")
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("Builders", func() {
@@ -339,6 +361,24 @@ var _ = Describe("externalbuilder", func() {
 				go func() { errCh <- sess.Wait() }()
 				Eventually(errCh).Should(Receive(BeNil()))
 			})
+
+			It("captures the output of the run when chaincode logs are configured", func() {
+				chaincodeLogs := &fakeChaincodeLogs{}
+				builder.ChaincodeLogs = chaincodeLogs
+
+				sess, err := builder.Run("other-ccid", bldDir, fakeConnection)
+				Expect(err).NotTo(HaveOccurred())
+
+				errCh := make(chan error)
+				go func() { errCh <- sess.Wait() }()
+				Eventually(errCh).Should(Receive(MatchError("exit status 1")))
+
+				Expect(chaincodeLogs.ccids).NotTo(BeEmpty())
+				for _, ccid := range chaincodeLogs.ccids {
+					Expect(ccid).To(Equal("other-ccid"))
+				}
+				Expect(chaincodeLogs.lines).To(ContainElement(HavePrefix("got {")))
+			})
 		})
 
 		Describe("NewCommand", func() {
@@ -368,3 +408,13 @@ var _ = Describe("externalbuilder", func() {
 		})
 	})
 })
+
+type fakeChaincodeLogs struct {
+	ccids []string
+	lines []string
+}
+
+func (f *fakeChaincodeLogs) Append(ccid, line string) {
+	f.ccids = append(f.ccids, ccid)
+	f.lines = append(f.lines, line)
+}
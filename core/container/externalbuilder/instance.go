@@ -15,6 +15,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/pkg/errors"
@@ -66,6 +67,7 @@ func (d *Duration) UnmarshalJSON(b []byte) error {
 // ChaincodeServerUserData holds "connection.json" information
 type ChaincodeServerUserData struct {
 	Address            string   `json:"address"`
+	Addresses          []string `json:"addresses"` // addresses of additional chaincode server instances
 	DialTimeout        Duration `json:"dial_timeout"`
 	TLSRequired        bool     `json:"tls_required"`
 	ClientAuthRequired bool     `json:"client_auth_required"`
@@ -76,10 +78,14 @@ type ChaincodeServerUserData struct {
 }
 
 func (c *ChaincodeServerUserData) ChaincodeServerInfo(cryptoDir string) (*ccintf.ChaincodeServerInfo, error) {
-	if c.Address == "" {
+	addresses := c.addresses()
+	if len(addresses) == 0 {
 		return nil, errors.New("chaincode address not provided")
 	}
-	connInfo := &ccintf.ChaincodeServerInfo{Address: c.Address}
+	connInfo := &ccintf.ChaincodeServerInfo{Address: addresses[0]}
+	if len(c.Addresses) != 0 {
+		connInfo.Addresses = addresses
+	}
 
 	connInfo.ClientConfig.DialTimeout = time.Duration(c.DialTimeout)
 	if connInfo.ClientConfig.DialTimeout == 0 {
@@ -115,6 +121,21 @@ func (c *ChaincodeServerUserData) ChaincodeServerInfo(cryptoDir string) (*ccintf
 	return connInfo, nil
 }
 
+// addresses returns the distinct addresses of the chaincode server
+// instances, starting with Address.
+func (c *ChaincodeServerUserData) addresses() []string {
+	var addresses []string
+	seen := map[string]bool{}
+	for _, address := range append([]string{c.Address}, c.Addresses...) {
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
 func (i *Instance) ChaincodeServerReleaseDir() string {
 	return filepath.Join(i.ReleaseDir, CCServerReleaseDir)
 }
@@ -144,6 +165,18 @@ func (i *Instance) ChaincodeServerInfo() (*ccintf.ChaincodeServerInfo, error) {
 	return ccdata.ChaincodeServerInfo(i.ChaincodeServerReleaseDir())
 }
 
+// Replica returns an instance which runs another session of the chaincode
+// from the same build output.
+func (i *Instance) Replica(index int) container.Instance {
+	return &Instance{
+		PackageID:   i.PackageID,
+		BldDir:      i.BldDir,
+		ReleaseDir:  i.ReleaseDir,
+		Builder:     i.Builder,
+		TermTimeout: i.TermTimeout,
+	}
+}
+
 func (i *Instance) Start(peerConnection *ccintf.PeerConnection) error {
 	sess, err := i.Builder.Run(i.PackageID, i.BldDir, peerConnection)
 	if err != nil {
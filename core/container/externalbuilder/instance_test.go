@@ -201,6 +201,29 @@ var _ = Describe("Instance", func() {
 				})
 			})
 
+			Context("several addresses are provided", func() {
+				It("returns the addresses of every instance", func() {
+					ccuserdata.TLSRequired = false
+					ccuserdata.Addresses = []string{"ccaddress:12346", "ccaddress:12345", "ccaddress:12347"}
+
+					ccinfo, err := ccuserdata.ChaincodeServerInfo(releaseDir)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ccinfo.Address).To(Equal("ccaddress:12345"))
+					Expect(ccinfo.Addresses).To(Equal([]string{"ccaddress:12345", "ccaddress:12346", "ccaddress:12347"}))
+				})
+
+				It("does not require the address", func() {
+					ccuserdata.TLSRequired = false
+					ccuserdata.Address = ""
+					ccuserdata.Addresses = []string{"ccaddress:12346", "ccaddress:12347"}
+
+					ccinfo, err := ccuserdata.ChaincodeServerInfo(releaseDir)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ccinfo.Address).To(Equal("ccaddress:12346"))
+					Expect(ccinfo.Addresses).To(Equal([]string{"ccaddress:12346", "ccaddress:12347"}))
+				})
+			})
+
 			Context("address is not provided", func() {
 				It("returns missing address error", func() {
 					ccuserdata.Address = ""
@@ -289,6 +312,34 @@ var _ = Describe("Instance", func() {
 		})
 	})
 
+	Describe("Replica", func() {
+		It("runs another session from the same build output", func() {
+			peerConnection := &ccintf.PeerConnection{
+				Address: "fake-peer-address",
+				TLSConfig: &ccintf.TLSConfig{
+					ClientCert: []byte("fake-client-cert"),
+					ClientKey:  []byte("fake-client-key"),
+					RootCert:   []byte("fake-root-cert"),
+				},
+			}
+			err := instance.Start(peerConnection)
+			Expect(err).NotTo(HaveOccurred())
+
+			replica, ok := instance.Replica(1).(*externalbuilder.Instance)
+			Expect(ok).To(BeTrue())
+			Expect(replica.PackageID).To(Equal("test-ccid"))
+			Expect(replica.Builder).To(Equal(instance.Builder))
+			Expect(replica.Session).To(BeNil())
+
+			err = replica.Start(peerConnection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replica.Session).NotTo(BeIdenticalTo(instance.Session))
+
+			Expect(instance.Wait()).To(Equal(0))
+			Expect(replica.Wait()).To(Equal(0))
+		})
+	})
+
 	Describe("Stop", func() {
 		It("terminates the process", func() {
 			cmd := exec.Command("sleep", "90")
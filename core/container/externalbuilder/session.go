@@ -34,6 +34,12 @@ type Session struct {
 //
 // The provided logger is used log stderr from the running process.
 func Start(logger *flogging.FabricLogger, cmd *exec.Cmd, exitFuncs ...ExitFunc) (*Session, error) {
+	return StartWithOutput(logger, cmd, nil, exitFuncs...)
+}
+
+// StartWithOutput is like Start but also passes each line written to stderr
+// by the running process to the provided output function when it is not nil.
+func StartWithOutput(logger *flogging.FabricLogger, cmd *exec.Cmd, output func(string), exitFuncs ...ExitFunc) (*Session, error) {
 	logger = logger.With("command", filepath.Base(cmd.Path))
 
 	stderr, err := cmd.StderrPipe()
@@ -51,16 +57,19 @@ func Start(logger *flogging.FabricLogger, cmd *exec.Cmd, exitFuncs ...ExitFunc)
 		exitFuncs: exitFuncs,
 		exited:    make(chan struct{}),
 	}
-	go sess.waitForExit(logger, stderr)
+	go sess.waitForExit(logger, stderr, output)
 
 	return sess, nil
 }
 
-func (s *Session) waitForExit(logger *flogging.FabricLogger, stderr io.Reader) {
+func (s *Session) waitForExit(logger *flogging.FabricLogger, stderr io.Reader, output func(string)) {
 	// copy stderr to the logger until stderr is closed
 	scanner := bufio.NewScanner(stderr)
 	for scanner.Scan() {
 		logger.Info(scanner.Text())
+		if output != nil {
+			output(scanner.Text())
+		}
 	}
 	if err := scanner.Err(); err != nil {
 		logger.Errorf("command output scanning failed: %s", err)
@@ -54,6 +54,20 @@ var _ = Describe("Session", func() {
 		Expect(logbuf).To(gbytes.Say("this is a message to stderr"))
 	})
 
+	It("passes stderr lines to the output function", func() {
+		var lines []string
+		cmd := exec.Command("sh", "-c", "echo 'first line' >&2; echo 'second line' >&2")
+		sess, err := externalbuilder.StartWithOutput(logger, cmd, func(line string) {
+			lines = append(lines, line)
+		})
+		Expect(err).NotTo(HaveOccurred())
+		err = sess.Wait()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(lines).To(Equal([]string{"first line", "second line"}))
+		Expect(logbuf).To(gbytes.Say("first line"))
+	})
+
 	It("delivers signals to started commands", func() {
 		cmd := exec.Command("cat")
 		stdin, err := cmd.StdinPipe()
@@ -25,6 +25,17 @@ type DockerBuilder struct {
 		result1 container.Instance
 		result2 error
 	}
+	RemoveBuildStub        func(string) error
+	removeBuildMutex       sync.RWMutex
+	removeBuildArgsForCall []struct {
+		arg1 string
+	}
+	removeBuildReturns struct {
+		result1 error
+	}
+	removeBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -52,6 +63,8 @@ func (fake *DockerBuilder) Build(arg1 string, arg2 *persistence.ChaincodePackage
 func (fake *DockerBuilder) BuildCallCount() int {
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.removeBuildMutex.RLock()
+	defer fake.removeBuildMutex.RUnlock()
 	return len(fake.buildArgsForCall)
 }
 
@@ -94,6 +107,66 @@ func (fake *DockerBuilder) BuildReturnsOnCall(i int, result1 container.Instance,
 	}{result1, result2}
 }
 
+func (fake *DockerBuilder) RemoveBuild(arg1 string) error {
+	fake.removeBuildMutex.Lock()
+	ret, specificReturn := fake.removeBuildReturnsOnCall[len(fake.removeBuildArgsForCall)]
+	fake.removeBuildArgsForCall = append(fake.removeBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RemoveBuild", []interface{}{arg1})
+	fake.removeBuildMutex.Unlock()
+	if fake.RemoveBuildStub != nil {
+		return fake.RemoveBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.removeBuildReturns
+	return fakeReturns.result1
+}
+
+func (fake *DockerBuilder) RemoveBuildCallCount() int {
+	fake.removeBuildMutex.RLock()
+	defer fake.removeBuildMutex.RUnlock()
+	return len(fake.removeBuildArgsForCall)
+}
+
+func (fake *DockerBuilder) RemoveBuildCalls(stub func(string) error) {
+	fake.removeBuildMutex.Lock()
+	defer fake.removeBuildMutex.Unlock()
+	fake.RemoveBuildStub = stub
+}
+
+func (fake *DockerBuilder) RemoveBuildArgsForCall(i int) string {
+	fake.removeBuildMutex.RLock()
+	defer fake.removeBuildMutex.RUnlock()
+	argsForCall := fake.removeBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DockerBuilder) RemoveBuildReturns(result1 error) {
+	fake.removeBuildMutex.Lock()
+	defer fake.removeBuildMutex.Unlock()
+	fake.RemoveBuildStub = nil
+	fake.removeBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DockerBuilder) RemoveBuildReturnsOnCall(i int, result1 error) {
+	fake.removeBuildMutex.Lock()
+	defer fake.removeBuildMutex.Unlock()
+	fake.RemoveBuildStub = nil
+	if fake.removeBuildReturnsOnCall == nil {
+		fake.removeBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *DockerBuilder) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
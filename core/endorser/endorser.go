@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric-protos-go/transientstore"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
@@ -79,6 +80,9 @@ type Support interface {
 
 	// GetDeployedCCInfoProvider returns ledger.DeployedChaincodeInfoProvider
 	GetDeployedCCInfoProvider() ledger.DeployedChaincodeInfoProvider
+
+	// GetApplicationConfig returns the application config for the channel
+	GetApplicationConfig(cid string) (channelconfig.Application, bool)
 }
 
 //go:generate counterfeiter -o fake/channel_fetcher.go --fake-name ChannelFetcher . ChannelFetcher
@@ -399,7 +403,7 @@ func (e *Endorser) ProcessProposalSuccessfullyOrError(up *UnpackedProposal) (*pb
 		return nil, errors.WithMessage(err, "error in simulation")
 	}
 
-	cceventBytes, err := CreateCCEventBytes(ccevent)
+	cceventBytes, err := CreateCCEventBytes(e.chaincodeEvent(up.ChannelID(), ccevent))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal chaincode event")
 	}
@@ -489,6 +493,24 @@ func shorttxid(txid string) string {
 	return txid[0:8]
 }
 
+// chaincodeEvent returns the chaincode event to include in the proposal
+// response. Unless the channel supports multiple chaincode events per
+// transaction, only the last event set by the chaincode is included.
+func (e *Endorser) chaincodeEvent(channelID string, ccevent *pb.ChaincodeEvent) *pb.ChaincodeEvent {
+	if ccevent == nil {
+		return nil
+	}
+	if ac, ok := e.Support.GetApplicationConfig(channelID); ok && ac.Capabilities().MultipleChaincodeEvents() {
+		return ccevent
+	}
+	return &pb.ChaincodeEvent{
+		ChaincodeId: ccevent.ChaincodeId,
+		TxId:        ccevent.TxId,
+		EventName:   ccevent.EventName,
+		Payload:     ccevent.Payload,
+	}
+}
+
 func CreateCCEventBytes(ccevent *pb.ChaincodeEvent) ([]byte, error) {
 	if ccevent == nil {
 		return nil, nil
@@ -9,6 +9,7 @@ package endorser_test
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/endorser"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
@@ -42,6 +43,16 @@ type historyQueryExecutor interface {
 	ledger.HistoryQueryExecutor
 }
 
+//go:generate counterfeiter -o fake/application_config.go --fake-name ApplicationConfig . applicationConfig
+type applicationConfig interface {
+	channelconfig.Application
+}
+
+//go:generate counterfeiter -o fake/application_capabilities.go --fake-name ApplicationCapabilities . applicationCapabilities
+type applicationCapabilities interface {
+	channelconfig.ApplicationCapabilities
+}
+
 func TestEndorser(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Endorser Suite")
@@ -251,6 +251,59 @@ var _ = Describe("Endorser", func() {
 		Expect(ledgerName).To(Equal("channel-id"))
 	})
 
+	Context("when the chaincode sets multiple events", func() {
+		var fakeCapabilities *fake.ApplicationCapabilities
+
+		BeforeEach(func() {
+			var err error
+			chaincodeEvent, err = protoutil.NewChaincodeEventList([]*pb.ChaincodeEvent{
+				{ChaincodeId: "chaincode-id", TxId: "event-txid", EventName: "first-event"},
+				{ChaincodeId: "chaincode-id", TxId: "event-txid", EventName: "event-name", Payload: []byte("event-payload")},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			fakeSupport.ExecuteReturns(chaincodeResponse, chaincodeEvent, nil)
+
+			fakeCapabilities = &fake.ApplicationCapabilities{}
+			fakeApplicationConfig := &fake.ApplicationConfig{}
+			fakeApplicationConfig.CapabilitiesReturns(fakeCapabilities)
+			fakeSupport.GetApplicationConfigReturns(fakeApplicationConfig, true)
+		})
+
+		endorsedEvents := func() []byte {
+			_, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSupport.EndorseWithPluginCallCount()).To(Equal(1))
+			_, _, propRespPayloadBytes, _ := fakeSupport.EndorseWithPluginArgsForCall(0)
+
+			prp := &pb.ProposalResponsePayload{}
+			Expect(proto.Unmarshal(propRespPayloadBytes, prp)).To(Succeed())
+			ccAct := &pb.ChaincodeAction{}
+			Expect(proto.Unmarshal(prp.Extension, ccAct)).To(Succeed())
+			return ccAct.Events
+		}
+
+		It("includes only the last event", func() {
+			Expect(endorsedEvents()).To(Equal(protoutil.MarshalOrPanic(&pb.ChaincodeEvent{
+				ChaincodeId: "chaincode-id",
+				TxId:        "event-txid",
+				EventName:   "event-name",
+				Payload:     []byte("event-payload"),
+			})))
+			Expect(fakeSupport.GetApplicationConfigCallCount()).To(Equal(1))
+			Expect(fakeSupport.GetApplicationConfigArgsForCall(0)).To(Equal("channel-id"))
+		})
+
+		Context("when the channel supports multiple chaincode events", func() {
+			BeforeEach(func() {
+				fakeCapabilities.MultipleChaincodeEventsReturns(true)
+			})
+
+			It("includes all of the events", func() {
+				Expect(endorsedEvents()).To(Equal(protoutil.MarshalOrPanic(chaincodeEvent)))
+			})
+		})
+	})
+
 	Context("when the chaincode endorsement fails", func() {
 		BeforeEach(func() {
 			fakeSupport.EndorseWithPluginReturns(nil, nil, fmt.Errorf("fake-endorserment-error"))
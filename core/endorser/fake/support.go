@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -91,6 +92,19 @@ type Support struct {
 		result2 *peer.ChaincodeEvent
 		result3 error
 	}
+	GetApplicationConfigStub        func(string) (channelconfig.Application, bool)
+	getApplicationConfigMutex       sync.RWMutex
+	getApplicationConfigArgsForCall []struct {
+		arg1 string
+	}
+	getApplicationConfigReturns struct {
+		result1 channelconfig.Application
+		result2 bool
+	}
+	getApplicationConfigReturnsOnCall map[int]struct {
+		result1 channelconfig.Application
+		result2 bool
+	}
 	GetDeployedCCInfoProviderStub        func() ledger.DeployedChaincodeInfoProvider
 	getDeployedCCInfoProviderMutex       sync.RWMutex
 	getDeployedCCInfoProviderArgsForCall []struct {
@@ -532,6 +546,69 @@ func (fake *Support) ExecuteLegacyInitReturnsOnCall(i int, result1 *peer.Respons
 	}{result1, result2, result3}
 }
 
+func (fake *Support) GetApplicationConfig(arg1 string) (channelconfig.Application, bool) {
+	fake.getApplicationConfigMutex.Lock()
+	ret, specificReturn := fake.getApplicationConfigReturnsOnCall[len(fake.getApplicationConfigArgsForCall)]
+	fake.getApplicationConfigArgsForCall = append(fake.getApplicationConfigArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetApplicationConfig", []interface{}{arg1})
+	fake.getApplicationConfigMutex.Unlock()
+	if fake.GetApplicationConfigStub != nil {
+		return fake.GetApplicationConfigStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getApplicationConfigReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Support) GetApplicationConfigCallCount() int {
+	fake.getApplicationConfigMutex.RLock()
+	defer fake.getApplicationConfigMutex.RUnlock()
+	return len(fake.getApplicationConfigArgsForCall)
+}
+
+func (fake *Support) GetApplicationConfigCalls(stub func(string) (channelconfig.Application, bool)) {
+	fake.getApplicationConfigMutex.Lock()
+	defer fake.getApplicationConfigMutex.Unlock()
+	fake.GetApplicationConfigStub = stub
+}
+
+func (fake *Support) GetApplicationConfigArgsForCall(i int) string {
+	fake.getApplicationConfigMutex.RLock()
+	defer fake.getApplicationConfigMutex.RUnlock()
+	argsForCall := fake.getApplicationConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Support) GetApplicationConfigReturns(result1 channelconfig.Application, result2 bool) {
+	fake.getApplicationConfigMutex.Lock()
+	defer fake.getApplicationConfigMutex.Unlock()
+	fake.GetApplicationConfigStub = nil
+	fake.getApplicationConfigReturns = struct {
+		result1 channelconfig.Application
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *Support) GetApplicationConfigReturnsOnCall(i int, result1 channelconfig.Application, result2 bool) {
+	fake.getApplicationConfigMutex.Lock()
+	defer fake.getApplicationConfigMutex.Unlock()
+	fake.GetApplicationConfigStub = nil
+	if fake.getApplicationConfigReturnsOnCall == nil {
+		fake.getApplicationConfigReturnsOnCall = make(map[int]struct {
+			result1 channelconfig.Application
+			result2 bool
+		})
+	}
+	fake.getApplicationConfigReturnsOnCall[i] = struct {
+		result1 channelconfig.Application
+		result2 bool
+	}{result1, result2}
+}
+
 func (fake *Support) GetDeployedCCInfoProvider() ledger.DeployedChaincodeInfoProvider {
 	fake.getDeployedCCInfoProviderMutex.Lock()
 	ret, specificReturn := fake.getDeployedCCInfoProviderReturnsOnCall[len(fake.getDeployedCCInfoProviderArgsForCall)]
@@ -1034,6 +1111,8 @@ func (fake *Support) Invocations() map[string][][]interface{} {
 	defer fake.executeMutex.RUnlock()
 	fake.executeLegacyInitMutex.RLock()
 	defer fake.executeLegacyInitMutex.RUnlock()
+	fake.getApplicationConfigMutex.RLock()
+	defer fake.getApplicationConfigMutex.RUnlock()
 	fake.getDeployedCCInfoProviderMutex.RLock()
 	defer fake.getDeployedCCInfoProviderMutex.RUnlock()
 	fake.getHistoryQueryExecutorMutex.RLock()
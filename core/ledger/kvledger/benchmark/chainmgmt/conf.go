@@ -12,6 +12,8 @@ type ChainMgrConf struct {
 	DataDir string
 	// NumChains field specifies the number of chains to instantiate
 	NumChains int
+	// CompressBlockFiles field specifies whether the blocks are stored compressed in the block files
+	CompressBlockFiles bool
 }
 
 // BatchConf captures the batch related configurations
@@ -63,6 +63,7 @@ func confFromTestParams(testParams []string) *configuration {
 	// chainMgrConf
 	dataDir := flags.String("DataDir", conf.chainMgrConf.DataDir, "Dir for ledger data")
 	numChains := flags.Int("NumChains", conf.chainMgrConf.NumChains, "Number of chains")
+	compressBlockFiles := flags.Bool("CompressBlockFiles", conf.chainMgrConf.CompressBlockFiles, "should the blocks be compressed in the block files")
 
 	// txConf
 	numParallelTxsPerChain := flags.Int("NumParallelTxPerChain",
@@ -94,6 +95,7 @@ func confFromTestParams(testParams []string) *configuration {
 
 	conf.chainMgrConf.DataDir = *dataDir
 	conf.chainMgrConf.NumChains = *numChains
+	conf.chainMgrConf.CompressBlockFiles = *compressBlockFiles
 	conf.txConf.numParallelTxsPerChain = *numParallelTxsPerChain
 	conf.txConf.numTotalTxs = *numTotalTxs
 	conf.txConf.numWritesPerTx = *numWritesPerTx
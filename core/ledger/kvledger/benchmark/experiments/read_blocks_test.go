@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package experiments
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/benchmark/chainmgmt"
+)
+
+// BenchmarkReadBlocks opens the existing chains and reads all the blocks sequentially from each of the chains.
+// The blocks of different chains are read in parallel. This test assumes the pre-populated chains by previously
+// running BenchmarkInsertTxs (and optionally, BenchmarkReadWriteTxs). This benchmark is primarily intended for
+// measuring the cost of reading the blocks from the block files, for instance, with and without the compression
+// of the block files (see parameter -CompressBlockFiles)
+func BenchmarkReadBlocks(b *testing.B) {
+	if b.N != 1 {
+		panic(fmt.Errorf(`This benchmark should be called with N=1 only. Run this with more volume of data`))
+	}
+	testEnv := chainmgmt.InitTestEnv(conf.chainMgrConf, conf.batchConf, chainmgmt.ChainInitOpOpen)
+	for _, chain := range testEnv.Chains() {
+		go runReadBlocksForChain(chain)
+	}
+	testEnv.WaitForTestCompletion()
+}
+
+func runReadBlocksForChain(chain *chainmgmt.Chain) {
+	defer chain.Done()
+	bcInfo, err := chain.GetBlockchainInfo()
+	panicOnError(err)
+	itr, err := chain.GetBlocksIterator(0)
+	panicOnError(err)
+	defer itr.Close()
+	for blockNum := uint64(0); blockNum < bcInfo.Height; blockNum++ {
+		res, err := itr.Next()
+		panicOnError(err)
+		block := res.(*common.Block)
+		if block.Header.Number != blockNum {
+			panic(fmt.Errorf("Expected block number %d but got %d", blockNum, block.Header.Number))
+		}
+	}
+}
@@ -8,7 +8,7 @@
 source ./common.sh
 
 #######################################################################################################
-# This shell script contains two functions that can be invoked to execute specific tests
+# This shell script contains three functions that can be invoked to execute specific tests
 #
 # runInsertTxs - This function sets the environment variables and runs the benchmark function
 # 'BenchmarkInsertTxs' in package 'github.com/hyperledger/fabric/core/ledger/kvledger/benchmark/experiments'
@@ -16,13 +16,16 @@ source ./common.sh
 # runReadWriteTxs - This function sets the environment variables and runs the benchmark function
 # 'BenchmarkReadWriteTxs' in package 'github.com/hyperledger/fabric/core/ledger/kvledger/benchmark/experiments'
 #
+# runReadBlocks - This function sets the environment variables and runs the benchmark function
+# 'BenchmarkReadBlocks' in package 'github.com/hyperledger/fabric/core/ledger/kvledger/benchmark/experiments'
+#
 # For the details of test specific parameters, refer to the documentation in 'go' files for the tests
 #######################################################################################################
 
 PKG_NAME="github.com/hyperledger/fabric/core/ledger/kvledger/benchmark/experiments"
 
 function setCommonTestParams {
-  TEST_PARAMS="-DataDir=$DataDir, -NumChains=$NumChains, -NumParallelTxPerChain=$NumParallelTxPerChain, -NumWritesPerTx=$NumWritesPerTx, -NumReadsPerTx=$NumReadsPerTx, -BatchSize=$BatchSize, -NumKVs=$NumKVs, -KVSize=$KVSize, -UseJSONFormat=$UseJSONFormat"
+  TEST_PARAMS="-DataDir=$DataDir, -NumChains=$NumChains, -NumParallelTxPerChain=$NumParallelTxPerChain, -NumWritesPerTx=$NumWritesPerTx, -NumReadsPerTx=$NumReadsPerTx, -BatchSize=$BatchSize, -NumKVs=$NumKVs, -KVSize=$KVSize, -UseJSONFormat=$UseJSONFormat, -CompressBlockFiles=$CompressBlockFiles"
   RESULTANT_DIRS="$DataDir/ledgersData/chains/chains $DataDir/ledgersData/chains/index $DataDir/ledgersData/stateLeveldb $DataDir/ledgersData/historyLeveldb"
 }
 
@@ -40,4 +43,13 @@ function runReadWriteTxs {
   setCommonTestParams
   TEST_PARAMS="$TEST_PARAMS, -NumTotalTx=$NumTotalTx"
   executeTest
-}
\ No newline at end of file
+}
+
+function runReadBlocks {
+  FUNCTION_NAME="BenchmarkReadBlocks"
+  if [ "$CLEAR_OS_CACHE" == "true" ]; then
+    clearOSCache
+  fi
+  setCommonTestParams
+  executeTest
+}
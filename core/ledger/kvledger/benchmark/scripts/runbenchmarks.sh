@@ -81,6 +81,15 @@ function varyNumTxs {
     done
 }
 
+function varyCompressBlockFiles {
+    source $PARAM_FILE
+    for v in "${ArrayCompressBlockFiles[@]}"
+    do
+        CompressBlockFiles=$v
+        rm -rf $DataDir;upCouchDB;runInsertTxs;runReadWriteTxs;runReadBlocks
+    done
+}
+
 function runLargeDataExperiment {
   source $PARAM_FILE
   if [[ $RunLargeDataExperiment = "true" ]]
@@ -125,4 +134,5 @@ fi
   varyKVSize
   varyBatchSize
   varyNumTxs
+  varyCompressBlockFiles
   runLargeDataExperiment
@@ -19,6 +19,7 @@ NumWritesPerTx=4
 NumReadsPerTx=4
 BatchSize=50
 KVSize=200
+CompressBlockFiles="false"
 
 #####################################################################################################################
 # Following variables controls what experiments to run. Typically, you would wish to run only selected experiments. 
@@ -41,5 +42,7 @@ ArrayKVSize=(100 200 500 1000 2000)
 ArrayBatchSize=(10 20 100 500)
 # Run experiments with varying "NumTotalTx" (keeping remaining params as default - see function 'varyNumTxs' in file runbenchmarks.sh)
 ArrayNumTxs=(100000 200000 500000 1000000)
+# Run experiments with and without the compression of block files, including reading back all the blocks (see function 'varyCompressBlockFiles' in file runbenchmarks.sh)
+ArrayCompressBlockFiles=(false true)
 # Whether to run experiment with large amount of data (see function 'runLargeDataExperiment' in file runbenchmarks.sh)
 RunLargeDataExperiment=true
\ No newline at end of file
@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+// CompressBlockStore rewrites the uncompressed block files of all the channels in the compressed format.
+// The block store index is rebuilt upon peer restart. This function is expected to be invoked when the peer is offline.
+func CompressBlockStore(rootFSPath string) error {
+	fileLockPath := fileLockPath(rootFSPath)
+	fileLock := leveldbhelper.NewFileLock(fileLockPath)
+	if err := fileLock.Lock(); err != nil {
+		return errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	logger.Info("Compressing the block files of all channel ledgers")
+	logger.Infof("Ledger data folder from config = [%s]", rootFSPath)
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	if err := blkstorage.CompressBlockfiles(BlockStorePath(rootFSPath), indexConfig); err != nil {
+		return err
+	}
+	logger.Info("The block files of all channel ledgers have been successfully compressed")
+	return nil
+}
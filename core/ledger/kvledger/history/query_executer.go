@@ -67,6 +67,16 @@ func (scanner *historyScanner) Next() (commonledger.QueryResult, error) {
 	logger.Debugf("Found history record for namespace:%s key:%s at blockNumTranNum %v:%v\n",
 		scanner.namespace, scanner.key, blockNum, tranNum)
 
+	// The history records older than the first available block in the block store belong to the blocks
+	// that have been pruned. Since the records are returned from newest to oldest, stop here.
+	bcInfo, err := scanner.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if bcInfo.BootstrappingSnapshotInfo != nil && blockNum <= bcInfo.BootstrappingSnapshotInfo.LastBlockInSnapshot {
+		return nil, nil
+	}
+
 	// Get the transaction from block storage that is associated with this history record
 	tranEnvelope, err := scanner.blockStore.RetrieveTxByBlockNumTranNum(blockNum, tranNum)
 	if err != nil {
@@ -170,11 +170,10 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		ledger:                        l,
 	}
 
+	l.stats = initializer.stats
 	if err := l.initSnapshotMgr(initializer); err != nil {
 		return nil, err
 	}
-
-	l.stats = initializer.stats
 	return l, nil
 }
 
@@ -278,8 +277,17 @@ func (l *kvLedger) initSnapshotMgr(initializer *lgrInitializer) error {
 		return err
 	}
 
+	lastSnapshotTime, found, err := latestSnapshotTime(l.config.SnapshotsConfig.RootDir, l.ledgerID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		lastSnapshotTime = time.Now()
+	}
+
 	l.snapshotMgr = &snapshotMgr{
 		snapshotRequestBookkeeper: bookkeeper,
+		autoSnapshotScheduler:     newAutoSnapshotScheduler(snapshotPolicyForLedger(l.config.SnapshotsConfig, l.ledgerID), lastSnapshotTime),
 		events:                    make(chan *event),
 		commitProceed:             make(chan struct{}),
 		requestResponses:          make(chan *requestResponse),
@@ -769,6 +777,17 @@ func (l *kvLedger) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledge
 	return l.pvtdataStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// GetMissingPvtDataInfoForBlockRange returns the missing private data information for the most
+// recent `maxBlock` blocks within [startBlock, endBlock] which miss at least a private data of
+// a eligible collection, including the missing data that has been deprioritized.
+func (l *kvLedger) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	// see GetMissingPvtDataInfoForMostRecentBlocks
+	if l.isPvtstoreAheadOfBlkstore.Load().(bool) {
+		return nil, nil
+	}
+	return l.pvtdataStore.GetMissingPvtDataInfoForBlockRange(startBlock, endBlock, maxBlock)
+}
+
 func (l *kvLedger) addBlockCommitHash(block *common.Block, updateBatchBytes []byte) {
 	var valueBytes []byte
 
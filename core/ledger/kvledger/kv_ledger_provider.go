@@ -140,9 +140,10 @@ func (p *Provider) initLedgerIDInventory() error {
 func (p *Provider) initBlockStoreProvider() error {
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
 	blkStoreProvider, err := blkstorage.NewProvider(
-		blkstorage.NewConf(
+		blkstorage.NewConfWithCompression(
 			BlockStorePath(p.initializer.Config.RootFSPath),
 			maxBlockFileSize,
+			p.initializer.Config.BlockStoreConfig != nil && p.initializer.Config.BlockStoreConfig.CompressBlockFiles,
 		),
 		indexConfig,
 		p.initializer.MetricsProvider,
@@ -336,6 +337,11 @@ func (p *Provider) open(ledgerID string, bootSnapshotMetadata *SnapshotMetadata,
 	if err != nil {
 		return nil, err
 	}
+	if !initializingFromSnapshot {
+		if bootSnapshotMetadata, err = p.completePrune(ledgerID, blockStore, bootSnapshotMetadata); err != nil {
+			return nil, err
+		}
+	}
 	pvtdataStore, err := p.pvtdataStoreProvider.OpenStore(ledgerID)
 	if err != nil {
 		return nil, err
@@ -650,6 +656,22 @@ func (s *idStore) updateLedgerStatus(ledgerID string, newStatus msgs.Status) err
 	return s.db.Put(key, metadataBytes, true)
 }
 
+func (s *idStore) updateBootSnapshotMetadata(ledgerID string, bootSnapshotMetadata *msgs.BootSnapshotMetadata) error {
+	metadata, err := s.getLedgerMetadata(ledgerID)
+	if err != nil {
+		return err
+	}
+	if metadata == nil {
+		return errors.Errorf("cannot update boot snapshot metadata, ledger [%s] does not exist", ledgerID)
+	}
+	metadata.BootSnapshotMetadata = bootSnapshotMetadata
+	metadataBytes, err := proto.Marshal(metadata)
+	if err != nil {
+		return errors.Wrapf(err, "error marshalling ledger metadata")
+	}
+	return s.db.Put(metadataKey(ledgerID), metadataBytes, true)
+}
+
 func (s *idStore) getLedgerMetadata(ledgerID string) (*msgs.LedgerMetadata, error) {
 	val, err := s.db.Get(metadataKey(ledgerID))
 	if val == nil || err != nil {
@@ -710,6 +710,10 @@ func TestPvtDataAPIs(t *testing.T) {
 	missingDataInfo, err := lgr.(*kvLedger).GetMissingPvtDataInfoForMostRecentBlocks(1)
 	require.NoError(t, err)
 	require.Equal(t, expectedMissingDataInfo, missingDataInfo)
+
+	missingDataInfo, err = lgr.(*kvLedger).GetMissingPvtDataInfoForBlockRange(6, 6, 1)
+	require.NoError(t, err)
+	require.Equal(t, expectedMissingDataInfo, missingDataInfo)
 }
 
 func TestCrashAfterPvtdataStoreCommit(t *testing.T) {
@@ -18,6 +18,8 @@ type stats struct {
 	blockAndPvtdataStoreCommitTime metrics.Histogram
 	statedbCommitTime              metrics.Histogram
 	transactionsCount              metrics.Counter
+	snapshotGenerationTime         metrics.Histogram
+	lastSnapshotBlockNumber        metrics.Gauge
 }
 
 func newStats(metricsProvider metrics.Provider) *stats {
@@ -26,6 +28,8 @@ func newStats(metricsProvider metrics.Provider) *stats {
 	stats.blockAndPvtdataStoreCommitTime = metricsProvider.NewHistogram(blockAndPvtdataStoreCommitTimeOpts)
 	stats.statedbCommitTime = metricsProvider.NewHistogram(statedbCommitTimeOpts)
 	stats.transactionsCount = metricsProvider.NewCounter(transactionCountOpts)
+	stats.snapshotGenerationTime = metricsProvider.NewHistogram(snapshotGenerationTimeOpts)
+	stats.lastSnapshotBlockNumber = metricsProvider.NewGauge(lastSnapshotBlockNumberOpts)
 	return stats
 }
 
@@ -75,6 +79,11 @@ func (s *ledgerStats) updateTransactionsStats(
 	}
 }
 
+func (s *ledgerStats) updateSnapshotStats(blockNumber uint64, timeTaken time.Duration) {
+	s.stats.snapshotGenerationTime.With("channel", s.ledgerid).Observe(timeTaken.Seconds())
+	s.stats.lastSnapshotBlockNumber.With("channel", s.ledgerid).Set(float64(blockNumber))
+}
+
 var (
 	blockProcessingTimeOpts = metrics.HistogramOpts{
 		Namespace:    "ledger",
@@ -114,4 +123,23 @@ var (
 		LabelNames:   []string{"channel", "transaction_type", "chaincode", "validation_code"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{transaction_type}.%{chaincode}.%{validation_code}",
 	}
+
+	snapshotGenerationTimeOpts = metrics.HistogramOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "snapshot_generation_time",
+		Help:         "Time taken in seconds for generating a snapshot.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+		Buckets:      []float64{1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+	}
+
+	lastSnapshotBlockNumberOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "last_snapshot_block_number",
+		Help:         "Block number of the last snapshot generated.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
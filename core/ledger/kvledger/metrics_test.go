@@ -142,6 +142,33 @@ func TestStatsBlockCommit(t *testing.T) {
 	)
 }
 
+func TestStatsSnapshot(t *testing.T) {
+	fakeProvider := &metricsfakes.Provider{}
+	fakeSnapshotGenerationTimeHist := testutilConstructHist()
+	fakeLastSnapshotBlockNumberGauge := testutilConstructGauge()
+	fakeProvider.NewHistogramStub = func(opts metrics.HistogramOpts) metrics.Histogram {
+		if opts.Name == snapshotGenerationTimeOpts.Name {
+			return fakeSnapshotGenerationTimeHist
+		}
+		return testutilConstructHist()
+	}
+	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
+		if opts.Name == lastSnapshotBlockNumberOpts.Name {
+			return fakeLastSnapshotBlockNumberGauge
+		}
+		return testutilConstructGauge()
+	}
+	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
+		return testutilConstructCounter()
+	}
+
+	newStats(fakeProvider).ledgerStats("ledger1").updateSnapshotStats(10, 2*time.Second)
+	require.Equal(t, []string{"channel", "ledger1"}, fakeSnapshotGenerationTimeHist.WithArgsForCall(0))
+	require.Equal(t, float64(2), fakeSnapshotGenerationTimeHist.ObserveArgsForCall(0))
+	require.Equal(t, []string{"channel", "ledger1"}, fakeLastSnapshotBlockNumberGauge.WithArgsForCall(0))
+	require.Equal(t, float64(10), fakeLastSnapshotBlockNumberGauge.SetArgsForCall(0))
+}
+
 type testMetricProvider struct {
 	fakeProvider                              *metricsfakes.Provider
 	fakeBlockProcessingTimeHist               *metricsfakes.Histogram
@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/msgs"
+	"github.com/pkg/errors"
+)
+
+// PruneBlockStore prunes the blocks of a ledger up to and including the last block of a snapshot that has been
+// generated by this peer for the ledger. After pruning, the ledger is in the same state as that of a ledger
+// bootstrapped from the snapshot. This function is expected to be invoked when the peer is offline.
+func PruneBlockStore(rootFSPath, snapshotsRootDir, ledgerID string, lastBlockInSnapshot uint64) error {
+	fileLockPath := fileLockPath(rootFSPath)
+	fileLock := leveldbhelper.NewFileLock(fileLockPath)
+	if err := fileLock.Lock(); err != nil {
+		return errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	bootSnapshotMetadata, err := loadBootSnapshotMetadata(snapshotsRootDir, ledgerID, lastBlockInSnapshot)
+	if err != nil {
+		return err
+	}
+
+	blockstorePath := BlockStorePath(rootFSPath)
+	if err := blkstorage.ValidatePruneParams(blockstorePath, ledgerID, lastBlockInSnapshot); err != nil {
+		return err
+	}
+
+	idStore, err := openIDStore(LedgerProviderPath(rootFSPath))
+	if err != nil {
+		return err
+	}
+	defer idStore.close()
+	ledgerMetadata, err := idStore.getLedgerMetadata(ledgerID)
+	if err != nil {
+		return err
+	}
+	if ledgerMetadata == nil || ledgerMetadata.Status != msgs.Status_ACTIVE {
+		return errors.Errorf("cannot prune channel [%s], the ledger does not exist or is not active", ledgerID)
+	}
+
+	logger.Info("Pruning block store")
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	if err := blkstorage.Prune(blockstorePath, ledgerID, lastBlockInSnapshot, indexConfig); err != nil {
+		return err
+	}
+
+	if err := idStore.updateBootSnapshotMetadata(ledgerID, bootSnapshotMetadata); err != nil {
+		return err
+	}
+	logger.Infof("The channel [%s] has been successfully pruned up to the block number [%d]", ledgerID, lastBlockInSnapshot)
+	return nil
+}
+
+// loadBootSnapshotMetadata loads the metadata of the snapshot generated by this peer for the given ledger at the given block
+// number, to be recorded as the boot snapshot metadata of the ledger once its block store is pruned up to that block
+func loadBootSnapshotMetadata(snapshotsRootDir, ledgerID string, lastBlockInSnapshot uint64) (*msgs.BootSnapshotMetadata, error) {
+	snapshotDir := SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID, lastBlockInSnapshot)
+	metadataJSONs, err := loadSnapshotMetadataJSONs(snapshotDir)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while loading metadata of the snapshot at block number [%d] for channel [%s]",
+			lastBlockInSnapshot, ledgerID)
+	}
+	metadata, err := metadataJSONs.ToMetadata()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error while unmarshalling snapshot metadata")
+	}
+	if metadata.ChannelName != ledgerID || metadata.LastBlockNumber != lastBlockInSnapshot {
+		return nil, errors.Errorf("snapshot metadata (channel [%s], block number [%d]) does not match the channel [%s] and block number [%d]",
+			metadata.ChannelName, metadata.LastBlockNumber, ledgerID, lastBlockInSnapshot)
+	}
+	return &msgs.BootSnapshotMetadata{
+		SingableMetadata:   metadataJSONs.signableMetadata,
+		AdditionalMetadata: metadataJSONs.additionalMetadata,
+	}, nil
+}
+
+// completePrune records the boot snapshot metadata of a ledger whose block store has been pruned, when the prune
+// operation was interrupted before the metadata of the snapshot was recorded in the ID store. The block store
+// itself completes an interrupted prune operation when it is opened.
+func (p *Provider) completePrune(ledgerID string, blockStore *blkstorage.BlockStore, bootSnapshotMetadata *SnapshotMetadata) (*SnapshotMetadata, error) {
+	bcInfo, err := blockStore.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if bcInfo.BootstrappingSnapshotInfo == nil {
+		return bootSnapshotMetadata, nil
+	}
+	lastBlockInSnapshot := bcInfo.BootstrappingSnapshotInfo.LastBlockInSnapshot
+	if bootSnapshotMetadata != nil && bootSnapshotMetadata.LastBlockNumber == lastBlockInSnapshot {
+		return bootSnapshotMetadata, nil
+	}
+
+	logger.Warningf("Recording the boot snapshot metadata of the interrupted prune operation of channel [%s] up to block number [%d]",
+		ledgerID, lastBlockInSnapshot)
+	metadata, err := loadBootSnapshotMetadata(p.initializer.Config.SnapshotsConfig.RootDir, ledgerID, lastBlockInSnapshot)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while completing the interrupted prune operation of channel [%s]", ledgerID)
+	}
+	if err := p.idStore.updateBootSnapshotMetadata(ledgerID, metadata); err != nil {
+		return nil, err
+	}
+	return snapshotMetadataFromProto(metadata)
+}
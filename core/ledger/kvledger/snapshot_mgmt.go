@@ -7,13 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/pkg/errors"
 )
 
@@ -38,6 +45,7 @@ const (
 
 type snapshotMgr struct {
 	snapshotRequestBookkeeper *snapshotRequestBookkeeper
+	autoSnapshotScheduler     *autoSnapshotScheduler
 	events                    chan *event
 	commitProceed             chan struct{}
 	requestResponses          chan *requestResponse
@@ -92,7 +100,7 @@ func (l *kvLedger) PendingSnapshotRequests() ([]uint64, error) {
 // - commitProceed: a channel indicating if commit can be proceeded. Commit is blocked if a snapshot generation is in progress.
 // - requestResponses: a channel returning the response for snapshot request submission/cancellation.
 // The 5 events are:
-// - commitStart: sent before committing a block
+// - commitStart: sent before committing a block, which also adds a snapshot request for the block if due as per the snapshot policy
 // - commitDone: sent after a block is committed
 // - snapshotDone: sent when a snapshot generation is finished, regardless of success or failure
 // - requestAdd: sent when a snapshot request is submitted
@@ -118,6 +126,9 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 
 		switch e.typ {
 		case commitStart:
+			if err := l.addAutoSnapshotRequest(e.blockNumber); err != nil {
+				logger.Errorw("Failed to add automatic snapshot request", "channelID", l.ledgerID, "blockNumber", e.blockNumber, "error", err)
+			}
 			committerStatus = blocked
 			if snapshotInProgress {
 				logger.Infow("Blocking the commit till snapshot generation completes", "channelID", l.ledgerID, "blockNumber", e.blockNumber)
@@ -134,15 +145,7 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 				continue
 			}
 			snapshotInProgress = true
-			go func() {
-				logger.Infow("Generating snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber)
-				if err := l.generateSnapshot(); err != nil {
-					logger.Errorw("Failed to generate snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber, "error", err)
-				} else {
-					logger.Infow("Generated snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber)
-				}
-				events <- &event{snapshotDone, lastCommittedBlockNumber}
-			}()
+			go l.generateSnapshotAndNotify(lastCommittedBlockNumber, lastCommittedBlockNumber)
 
 		case snapshotDone:
 			requestedBlockNum := e.blockNumber
@@ -194,15 +197,7 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 
 			if committerStatus == idle && requestedBlockNum == lastCommittedBlockNumber {
 				snapshotInProgress = true
-				go func() {
-					logger.Infow("Generating snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber)
-					if err := l.generateSnapshot(); err != nil {
-						logger.Errorw("Failed to generate snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber, "error", err)
-					} else {
-						logger.Infow("Generated snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber)
-					}
-					events <- &event{snapshotDone, requestedBlockNum}
-				}()
+				go l.generateSnapshotAndNotify(lastCommittedBlockNumber, requestedBlockNum)
 			}
 			requestResponses <- &requestResponse{}
 
@@ -220,6 +215,241 @@ func (l *kvLedger) processSnapshotMgmtEvents(lastCommittedBlockNumber uint64) {
 	}
 }
 
+// generateSnapshotAndNotify generates a snapshot for the last committed block and sends the snapshotDone event
+// for the requested block number, regardless of success or failure. On success, it updates the snapshot metrics
+// and removes the old snapshots that are not to be retained as per the snapshot policy for the ledger
+func (l *kvLedger) generateSnapshotAndNotify(lastCommittedBlockNumber, requestedBlockNum uint64) {
+	logger.Infow("Generating snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber)
+	startTime := time.Now()
+	if err := l.generateSnapshot(); err != nil {
+		logger.Errorw("Failed to generate snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber, "error", err)
+	} else {
+		logger.Infow("Generated snapshot", "channelID", l.ledgerID, "lastCommittedBlockNumber", lastCommittedBlockNumber)
+		l.stats.updateSnapshotStats(lastCommittedBlockNumber, time.Since(startTime))
+		l.snapshotMgr.autoSnapshotScheduler.snapshotGenerated(time.Now())
+		if err := l.snapshotMgr.snapshotRequestBookkeeper.snapshotGenerated(requestedBlockNum); err != nil {
+			logger.Errorw("Failed to record the generated snapshot, the snapshot will be retained", "channelID", l.ledgerID, "blockNumber", requestedBlockNum, "error", err)
+		}
+		if err := l.removeExpiredSnapshots(); err != nil {
+			logger.Errorw("Failed to remove old snapshots", "channelID", l.ledgerID, "error", err)
+		}
+	}
+	l.snapshotMgr.events <- &event{snapshotDone, requestedBlockNum}
+}
+
+// addAutoSnapshotRequest adds a snapshot request for the given block number if the block is due for a snapshot
+// as per the snapshot policy for the ledger and a request for the block does not exist already
+func (l *kvLedger) addAutoSnapshotRequest(blockNumber uint64) error {
+	scheduler := l.snapshotMgr.autoSnapshotScheduler
+	now := time.Now()
+	if !scheduler.isDue(blockNumber, now) {
+		return nil
+	}
+	bookkeeper := l.snapshotMgr.snapshotRequestBookkeeper
+	exists, err := bookkeeper.exist(blockNumber)
+	if err != nil || exists {
+		return err
+	}
+	logger.Infow("Adding automatic snapshot request as per the snapshot policy", "channelID", l.ledgerID, "blockNumber", blockNumber)
+	if err := bookkeeper.addAutomatic(blockNumber); err != nil {
+		return err
+	}
+	scheduler.requestAdded(now)
+	return nil
+}
+
+// removeExpiredSnapshots removes the snapshots generated as per the snapshot policy for the ledger, except the
+// most recent ones that are to be retained. The snapshots generated on request and the snapshots in use are not removed
+func (l *kvLedger) removeExpiredSnapshots() error {
+	maxRetained := l.snapshotMgr.autoSnapshotScheduler.policy.MaxRetained
+	if maxRetained <= 0 {
+		return nil
+	}
+	bookkeeper := l.snapshotMgr.snapshotRequestBookkeeper
+	recordedBlockNums, err := bookkeeper.listAutomatic()
+	if err != nil {
+		return err
+	}
+	var snapshotBlockNums []uint64
+	for _, blockNum := range recordedBlockNums {
+		exists, err := l.snapshotExists(blockNum)
+		if err != nil {
+			return err
+		}
+		if exists {
+			snapshotBlockNums = append(snapshotBlockNums, blockNum)
+			continue
+		}
+		// the snapshot has been removed by other means
+		if err := bookkeeper.deleteAutomatic(blockNum); err != nil {
+			return err
+		}
+	}
+	if len(snapshotBlockNums) <= maxRetained {
+		return nil
+	}
+	for _, blockNum := range snapshotBlockNums[:len(snapshotBlockNums)-maxRetained] {
+		snapshotDir := SnapshotDirForLedgerBlockNum(l.config.SnapshotsConfig.RootDir, l.ledgerID, blockNum)
+		removed, err := snapshotsInUse.removeUnlessInUse(snapshotDir)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			logger.Infow("Retaining old snapshot as it is in use", "channelID", l.ledgerID, "blockNumber", blockNum)
+			continue
+		}
+		if err := bookkeeper.deleteAutomatic(blockNum); err != nil {
+			return err
+		}
+		logger.Infow("Removed old snapshot", "channelID", l.ledgerID, "blockNumber", blockNum)
+	}
+	return nil
+}
+
+// snapshotsInUse keeps track of the snapshots being read, such as the snapshots streamed to other peers
+var snapshotsInUse = &snapshotReaders{
+	readers: map[string]int{},
+}
+
+// AcquireSnapshot marks the given snapshot dir as in use, so that it is not removed as per the snapshot
+// policy for the ledger until the returned function is called
+func AcquireSnapshot(snapshotDir string) (release func()) {
+	return snapshotsInUse.acquire(snapshotDir)
+}
+
+// snapshotReaders counts the readers of each snapshot dir
+type snapshotReaders struct {
+	lock    sync.Mutex
+	readers map[string]int
+}
+
+func (r *snapshotReaders) acquire(snapshotDir string) func() {
+	snapshotDir = filepath.Clean(snapshotDir)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.readers[snapshotDir]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.lock.Lock()
+			defer r.lock.Unlock()
+			if r.readers[snapshotDir]--; r.readers[snapshotDir] == 0 {
+				delete(r.readers, snapshotDir)
+			}
+		})
+	}
+}
+
+// removeUnlessInUse removes the given snapshot dir and returns true, or returns false if the dir is in use
+func (r *snapshotReaders) removeUnlessInUse(snapshotDir string) (bool, error) {
+	snapshotDir = filepath.Clean(snapshotDir)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.readers[snapshotDir] > 0 {
+		return false, nil
+	}
+	if err := os.RemoveAll(snapshotDir); err != nil {
+		return false, errors.Wrapf(err, "error while deleting the snapshot dir [%s]", snapshotDir)
+	}
+	return true, nil
+}
+
+// listSnapshots returns the block numbers of the completed snapshots of the ledger in increasing order
+func listSnapshots(snapshotsRootDir, ledgerID string) ([]uint64, error) {
+	snapshotsDir := SnapshotsDirForLedger(snapshotsRootDir, ledgerID)
+	dirEntries, err := ioutil.ReadDir(snapshotsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error while reading the snapshots dir [%s]", snapshotsDir)
+	}
+	var blockNums []uint64
+	for _, d := range dirEntries {
+		if !d.IsDir() {
+			continue
+		}
+		blockNum, err := strconv.ParseUint(d.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		blockNums = append(blockNums, blockNum)
+	}
+	sort.Slice(blockNums, func(i, j int) bool { return blockNums[i] < blockNums[j] })
+	return blockNums, nil
+}
+
+// latestSnapshotTime returns the modification time of the most recent snapshot of the ledger.
+// The returned boolean is false if the ledger does not have any snapshot
+func latestSnapshotTime(snapshotsRootDir, ledgerID string) (time.Time, bool, error) {
+	snapshotBlockNums, err := listSnapshots(snapshotsRootDir, ledgerID)
+	if err != nil || len(snapshotBlockNums) == 0 {
+		return time.Time{}, false, err
+	}
+	snapshotDir := SnapshotDirForLedgerBlockNum(snapshotsRootDir, ledgerID, snapshotBlockNums[len(snapshotBlockNums)-1])
+	stat, err := os.Stat(snapshotDir)
+	if err != nil {
+		return time.Time{}, false, errors.Wrapf(err, "error while reading the snapshot dir [%s]", snapshotDir)
+	}
+	return stat.ModTime(), true, nil
+}
+
+// autoSnapshotScheduler decides whether a block is due for a snapshot as per the snapshot policy for a ledger
+type autoSnapshotScheduler struct {
+	policy           ledger.SnapshotPolicy
+	lock             sync.Mutex
+	lastSnapshotTime time.Time
+}
+
+func newAutoSnapshotScheduler(policy *ledger.SnapshotPolicy, lastSnapshotTime time.Time) *autoSnapshotScheduler {
+	s := &autoSnapshotScheduler{
+		lastSnapshotTime: lastSnapshotTime,
+	}
+	if policy != nil {
+		s.policy = *policy
+	}
+	return s
+}
+
+// isDue returns true if a snapshot is to be generated for the given block number, which is about to be committed at the given time
+func (s *autoSnapshotScheduler) isDue(blockNumber uint64, now time.Time) bool {
+	if s.policy.BlockInterval > 0 && blockNumber > 0 && blockNumber%s.policy.BlockInterval == 0 {
+		return true
+	}
+	if s.policy.TimeInterval <= 0 {
+		return false
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return now.Sub(s.lastSnapshotTime) >= s.policy.TimeInterval
+}
+
+// requestAdded records the time at which a request was added for a block that was due, so that a single
+// request is added for an elapsed time interval, even if the snapshot generation for the request completes
+// after the commit of a few more blocks
+func (s *autoSnapshotScheduler) requestAdded(t time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastSnapshotTime = t
+}
+
+// snapshotGenerated records the time at which the most recent snapshot was generated
+func (s *autoSnapshotScheduler) snapshotGenerated(t time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastSnapshotTime = t
+}
+
+// snapshotPolicyForLedger returns the channel specific snapshot policy for the ledger, if configured,
+// or the default snapshot policy otherwise
+func snapshotPolicyForLedger(conf *ledger.SnapshotsConfig, ledgerID string) *ledger.SnapshotPolicy {
+	if policy, ok := conf.ChannelPolicies[ledgerID]; ok {
+		return policy
+	}
+	return conf.DefaultPolicy
+}
+
 func (l *kvLedger) regenrateMissedSnapshot(blockNumber uint64) error {
 	if blockNumber != l.snapshotMgr.snapshotRequestBookkeeper.smallestRequestBlockNum {
 		return nil
@@ -267,7 +497,9 @@ func (m *snapshotMgr) shutdown() {
 	close(m.requestResponses)
 }
 
-// snapshotRequestBookkeeper manages snapshot requests in a leveldb and maintains smallest block number for pending snapshot requests
+// snapshotRequestBookkeeper manages snapshot requests in a leveldb and maintains smallest block number for pending snapshot requests.
+// In addition, it records the snapshots generated for the requests added as per the snapshot policy for the ledger, which are
+// the only snapshots subject to the retention of the policy
 type snapshotRequestBookkeeper struct {
 	ledgerID                string
 	dbHandle                *leveldbhelper.DBHandle
@@ -290,6 +522,16 @@ func newSnapshotRequestBookkeeper(ledgerID string, dbHandle *leveldbhelper.DBHan
 
 // add adds the given block number to the bookkeeper db and returns an error if the block number already exists
 func (k *snapshotRequestBookkeeper) add(blockNumber uint64) error {
+	return k.put(blockNumber, []byte{})
+}
+
+// addAutomatic adds the given block number to the bookkeeper db as a request added as per the snapshot policy
+// for the ledger and returns an error if the block number already exists
+func (k *snapshotRequestBookkeeper) addAutomatic(blockNumber uint64) error {
+	return k.put(blockNumber, automaticRequestMarker)
+}
+
+func (k *snapshotRequestBookkeeper) put(blockNumber uint64, value []byte) error {
 	logger.Infow("Adding new request for snapshot", "channelID", k.ledgerID, "blockNumber", blockNumber)
 	key := encodeSnapshotRequestKey(blockNumber)
 
@@ -301,7 +543,7 @@ func (k *snapshotRequestBookkeeper) add(blockNumber uint64) error {
 		return errors.Errorf("duplicate snapshot request for block number %d", blockNumber)
 	}
 
-	if err := k.dbHandle.Put(key, []byte{}, true); err != nil {
+	if err := k.dbHandle.Put(key, value, true); err != nil {
 		return err
 	}
 
@@ -340,7 +582,7 @@ func (k *snapshotRequestBookkeeper) delete(blockNumber uint64) error {
 
 func (k *snapshotRequestBookkeeper) list() ([]uint64, error) {
 	requestedBlockNumbers := []uint64{}
-	itr, err := k.dbHandle.GetIterator(nil, nil)
+	itr, err := k.dbHandle.GetIterator(snapshotRequestKeyPrefix, snapshotRequestKeyRangeEnd)
 	if err != nil {
 		return nil, err
 	}
@@ -373,10 +615,51 @@ func (k *snapshotRequestBookkeeper) exist(blockNumber uint64) (bool, error) {
 	return exists, nil
 }
 
+// snapshotGenerated records the snapshot generated for the request for the given block number,
+// if the request was added as per the snapshot policy for the ledger
+func (k *snapshotRequestBookkeeper) snapshotGenerated(blockNumber uint64) error {
+	val, err := k.dbHandle.Get(encodeSnapshotRequestKey(blockNumber))
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(val, automaticRequestMarker) {
+		return nil
+	}
+	return k.dbHandle.Put(encodeAutomaticSnapshotKey(blockNumber), []byte{}, true)
+}
+
+// listAutomatic returns the block numbers of the recorded snapshots generated as per the snapshot policy
+// for the ledger in increasing order
+func (k *snapshotRequestBookkeeper) listAutomatic() ([]uint64, error) {
+	itr, err := k.dbHandle.GetIterator(automaticSnapshotKeyPrefix, automaticSnapshotKeyRangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Release()
+
+	var blockNumbers []uint64
+	for itr.Next() {
+		blockNumber, _, err := decodeAutomaticSnapshotKey(itr.Key())
+		if err != nil {
+			return nil, err
+		}
+		blockNumbers = append(blockNumbers, blockNumber)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, errors.Wrapf(err, "internal leveldb error while iterating for automatic snapshots")
+	}
+	return blockNumbers, nil
+}
+
+// deleteAutomatic deletes the record of the snapshot generated as per the snapshot policy for the given block number
+func (k *snapshotRequestBookkeeper) deleteAutomatic(blockNumber uint64) error {
+	return k.dbHandle.Delete(encodeAutomaticSnapshotKey(blockNumber), true)
+}
+
 const defaultSmallestBlockNumber uint64 = math.MaxUint64
 
 func (k *snapshotRequestBookkeeper) smallestRequest() (uint64, error) {
-	itr, err := k.dbHandle.GetIterator(nil, nil)
+	itr, err := k.dbHandle.GetIterator(snapshotRequestKeyPrefix, snapshotRequestKeyRangeEnd)
 	if err != nil {
 		return 0, err
 	}
@@ -396,7 +679,15 @@ func (k *snapshotRequestBookkeeper) smallestRequest() (uint64, error) {
 	return smallestBlockNumber, nil
 }
 
-var snapshotRequestKeyPrefix = []byte("s")
+var (
+	snapshotRequestKeyPrefix     = []byte("s")
+	snapshotRequestKeyRangeEnd   = []byte("t")
+	automaticSnapshotKeyPrefix   = []byte("a")
+	automaticSnapshotKeyRangeEnd = []byte("b")
+
+	// automaticRequestMarker is the value of the requests added as per the snapshot policy for the ledger
+	automaticRequestMarker = []byte{1}
+)
 
 func encodeSnapshotRequestKey(blockNumber uint64) []byte {
 	return append(snapshotRequestKeyPrefix, util.EncodeOrderPreservingVarUint64(blockNumber)...)
@@ -405,3 +696,11 @@ func encodeSnapshotRequestKey(blockNumber uint64) []byte {
 func decodeSnapshotRequestKey(key []byte) (uint64, int, error) {
 	return util.DecodeOrderPreservingVarUint64(key[len(snapshotRequestKeyPrefix):])
 }
+
+func encodeAutomaticSnapshotKey(blockNumber uint64) []byte {
+	return append(automaticSnapshotKeyPrefix, util.EncodeOrderPreservingVarUint64(blockNumber)...)
+}
+
+func decodeAutomaticSnapshotKey(key []byte) (uint64, int, error) {
+	return util.DecodeOrderPreservingVarUint64(key[len(automaticSnapshotKeyPrefix):])
+}
@@ -8,6 +8,8 @@ package kvledger
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
@@ -348,6 +350,178 @@ func TestSnapshotRequestsErrorPaths(t *testing.T) {
 
 	_, err = l.PendingSnapshotRequests()
 	require.EqualError(t, err, "internal leveldb error while obtaining db iterator: leveldb: closed")
+
+	// a block due as per the snapshot policy remains due if the request cannot be added
+	kvledger.snapshotMgr.autoSnapshotScheduler = newAutoSnapshotScheduler(&ledger.SnapshotPolicy{TimeInterval: time.Hour}, time.Time{})
+	err = kvledger.addAutoSnapshotRequest(21)
+	require.Contains(t, err.Error(), "leveldb: closed")
+	require.True(t, kvledger.snapshotMgr.autoSnapshotScheduler.isDue(22, time.Now()))
+}
+
+func TestAutoSnapshots(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	ledgerID := "testautosnapshots"
+	conf.SnapshotsConfig.DefaultPolicy = &ledger.SnapshotPolicy{BlockInterval: 2}
+	conf.SnapshotsConfig.ChannelPolicies = map[string]*ledger.SnapshotPolicy{
+		ledgerID: {BlockInterval: 5, MaxRetained: 2},
+	}
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	l, err := provider.CreateFromGenesisBlock(gb)
+	require.NoError(t, err)
+	defer l.Close()
+	kvledger := l.(*kvLedger)
+
+	snapshotsRetained := func(expected ...uint64) func() bool {
+		return func() bool {
+			snapshots, err := listSnapshots(conf.SnapshotsConfig.RootDir, ledgerID)
+			require.NoError(t, err)
+			return equal(snapshots, expected)
+		}
+	}
+	requestsUpdated := func() bool {
+		requests, err := l.PendingSnapshotRequests()
+		require.NoError(t, err)
+		return len(requests) == 0
+	}
+
+	// commit blocks upto block number 15 and verify that snapshots are generated for the block numbers
+	// 5, 10, and 15 as per the channel specific policy and only the last two of these snapshots are
+	// retained, along with the snapshot requested for the block number 3
+	require.NoError(t, l.SubmitSnapshotRequest(3))
+	lastBlock := testutilCommitBlocks(t, l, bg, 15, protoutil.BlockHeaderHash(gb.Header))
+	require.Eventually(t, snapshotsRetained(3, 10, 15), time.Minute, 100*time.Millisecond)
+	require.Eventually(t, requestsUpdated, time.Minute, 100*time.Millisecond)
+
+	// a snapshot in use is retained until it is released
+	release := AcquireSnapshot(SnapshotDirForLedgerBlockNum(conf.SnapshotsConfig.RootDir, ledgerID, 10))
+	lastBlock = testutilCommitBlocks(t, l, bg, 20, protoutil.BlockHeaderHash(lastBlock.Header))
+	require.Eventually(t, snapshotsRetained(3, 10, 15, 20), time.Minute, 100*time.Millisecond)
+	require.Eventually(t, requestsUpdated, time.Minute, 100*time.Millisecond)
+	release()
+	testutilCommitBlocks(t, l, bg, 25, protoutil.BlockHeaderHash(lastBlock.Header))
+	require.Eventually(t, snapshotsRetained(3, 20, 25), time.Minute, 100*time.Millisecond)
+	require.Eventually(t, requestsUpdated, time.Minute, 100*time.Millisecond)
+
+	// a request submitted explicitly for a block that is due as per the policy is not duplicated
+	require.NoError(t, l.SubmitSnapshotRequest(30))
+	require.NoError(t, kvledger.addAutoSnapshotRequest(30))
+	requests, err := l.PendingSnapshotRequests()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{30}, requests)
+}
+
+func TestAutomaticSnapshotsBookkeeping(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	dbHandle := provider.bookkeepingProvider.GetDBHandle("testautomaticsnapshots", bookkeeping.SnapshotRequest)
+	bookkeeper, err := newSnapshotRequestBookkeeper("test-ledger", dbHandle)
+	require.NoError(t, err)
+
+	require.NoError(t, bookkeeper.add(10))
+	require.NoError(t, bookkeeper.addAutomatic(20))
+	require.EqualError(t, bookkeeper.addAutomatic(10), "duplicate snapshot request for block number 10")
+	require.NoError(t, bookkeeper.addAutomatic(30))
+
+	// only the snapshots generated for the automatic requests are recorded
+	for _, blockNumber := range []uint64{10, 20, 30} {
+		require.NoError(t, bookkeeper.snapshotGenerated(blockNumber))
+		require.NoError(t, bookkeeper.delete(blockNumber))
+	}
+	requests, err := bookkeeper.list()
+	require.NoError(t, err)
+	require.Empty(t, requests)
+	require.Equal(t, defaultSmallestBlockNumber, bookkeeper.smallestRequestBlockNum)
+
+	snapshots, err := bookkeeper.listAutomatic()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{20, 30}, snapshots)
+
+	require.NoError(t, bookkeeper.deleteAutomatic(20))
+	snapshots, err = bookkeeper.listAutomatic()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{30}, snapshots)
+}
+
+func TestSnapshotReaders(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshotreaders")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	readers := &snapshotReaders{readers: map[string]int{}}
+	release1 := readers.acquire(dir)
+	release2 := readers.acquire(dir + "/")
+
+	removed, err := readers.removeUnlessInUse(dir)
+	require.NoError(t, err)
+	require.False(t, removed)
+
+	release1()
+	// releasing more than once has no effect
+	release1()
+	removed, err = readers.removeUnlessInUse(dir)
+	require.NoError(t, err)
+	require.False(t, removed)
+	require.DirExists(t, dir)
+
+	release2()
+	removed, err = readers.removeUnlessInUse(dir)
+	require.NoError(t, err)
+	require.True(t, removed)
+	require.NoDirExists(t, dir)
+	require.Empty(t, readers.readers)
+}
+
+func TestAutoSnapshotScheduler(t *testing.T) {
+	now := time.Now()
+
+	t.Run("no-policy", func(t *testing.T) {
+		s := newAutoSnapshotScheduler(nil, now)
+		require.False(t, s.isDue(10, now.Add(24*time.Hour)))
+	})
+
+	t.Run("block-interval", func(t *testing.T) {
+		s := newAutoSnapshotScheduler(&ledger.SnapshotPolicy{BlockInterval: 10}, now)
+		require.False(t, s.isDue(0, now))
+		require.False(t, s.isDue(5, now))
+		require.True(t, s.isDue(10, now))
+		require.False(t, s.isDue(11, now))
+		require.True(t, s.isDue(20, now))
+	})
+
+	t.Run("time-interval", func(t *testing.T) {
+		s := newAutoSnapshotScheduler(&ledger.SnapshotPolicy{TimeInterval: time.Hour}, now)
+		require.False(t, s.isDue(1, now.Add(30*time.Minute)))
+		require.True(t, s.isDue(2, now.Add(time.Hour)))
+		// the next block is still due if no request was added
+		require.True(t, s.isDue(3, now.Add(70*time.Minute)))
+		// the interval restarts from the time a request was added for a block
+		s.requestAdded(now.Add(70 * time.Minute))
+		require.False(t, s.isDue(4, now.Add(90*time.Minute)))
+		require.True(t, s.isDue(4, now.Add(130*time.Minute)))
+		// the interval restarts from the time the snapshot is generated
+		s.snapshotGenerated(now.Add(150 * time.Minute))
+		require.False(t, s.isDue(5, now.Add(3*time.Hour)))
+		require.True(t, s.isDue(6, now.Add(210*time.Minute)))
+	})
+}
+
+func TestSnapshotPolicyForLedger(t *testing.T) {
+	defaultPolicy := &ledger.SnapshotPolicy{BlockInterval: 100}
+	channelPolicy := &ledger.SnapshotPolicy{TimeInterval: time.Hour, MaxRetained: 3}
+	conf := &ledger.SnapshotsConfig{
+		DefaultPolicy:   defaultPolicy,
+		ChannelPolicies: map[string]*ledger.SnapshotPolicy{"ch1": channelPolicy},
+	}
+	require.Equal(t, channelPolicy, snapshotPolicyForLedger(conf, "ch1"))
+	require.Equal(t, defaultPolicy, snapshotPolicyForLedger(conf, "ch2"))
+	require.Nil(t, snapshotPolicyForLedger(&ledger.SnapshotsConfig{}, "ch1"))
 }
 
 func equal(slice1 []uint64, slice2 []uint64) bool {
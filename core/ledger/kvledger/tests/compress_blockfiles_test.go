@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressBlockStore(t *testing.T) {
+	env := newEnv(t)
+	defer env.cleanup()
+	env.initLedgerMgmt()
+	l := env.createTestLedgerFromGenesisBlk("ledger1")
+
+	// block-1
+	l.simulateDataTx("txid-1", func(s *simulator) {
+		s.setState("cc1", "key1", "value1")
+	})
+	blk1 := l.cutBlockAndCommitLegacy()
+
+	// block-2
+	l.simulateDataTx("txid-2", func(s *simulator) {
+		s.setState("cc1", "key1", "value2")
+	})
+	blk2 := l.cutBlockAndCommitLegacy()
+	env.closeLedgerMgmt()
+
+	require.NoError(t, kvledger.CompressBlockStore(env.initializer.Config.RootFSPath))
+
+	env.initializer.Config.BlockStoreConfig = &ledger.BlockStoreConfig{CompressBlockFiles: true}
+	env.initLedgerMgmt()
+	l = env.openTestLedger("ledger1")
+	l.verifyLedgerHeight(3)
+	l.verifyBlockAndPvtDataSameAs(1, blk1)
+	l.verifyBlockAndPvtDataSameAs(2, blk2)
+	l.verifyTXIDExists("txid-1", "txid-2")
+	l.verifyHistory("cc1", "key1", []string{"value2", "value1"})
+
+	// block-3, committed after the block files have been compressed
+	l.simulateDataTx("txid-3", func(s *simulator) {
+		s.setState("cc1", "key1", "value3")
+	})
+	blk3 := l.cutBlockAndCommitLegacy()
+	l.verifyBlockAndPvtDataSameAs(3, blk3)
+	l.verifyPubState("cc1", "key1", "value3")
+	l.verifyHistory("cc1", "key1", []string{"value3", "value2", "value1"})
+}
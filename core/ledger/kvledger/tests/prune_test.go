@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tests
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneBlockStore(t *testing.T) {
+	env := newEnv(t)
+	defer env.cleanup()
+	env.initLedgerMgmt()
+	l := env.createTestLedgerFromGenesisBlk("ledger1")
+
+	// block-1
+	l.simulateDataTx("txid-1", func(s *simulator) {
+		s.setState("cc1", "key1", "value1")
+	})
+	l.cutBlockAndCommitLegacy()
+
+	// block-2
+	l.simulateDataTx("txid-2", func(s *simulator) {
+		s.setState("cc1", "key1", "value2")
+	})
+	l.cutBlockAndCommitLegacy()
+	snapshotDir := l.generateSnapshot()
+	require.DirExists(t, snapshotDir)
+
+	// block-3
+	l.simulateDataTx("txid-3", func(s *simulator) {
+		s.setState("cc1", "key1", "value3")
+	})
+	l.cutBlockAndCommitLegacy()
+	bcInfo, err := l.lgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	env.closeLedgerMgmt()
+
+	rootFSPath := env.initializer.Config.RootFSPath
+	snapshotsRootDir := env.initializer.Config.SnapshotsConfig.RootDir
+	err = kvledger.PruneBlockStore(rootFSPath, snapshotsRootDir, "ledger1", 1)
+	require.Contains(t, err.Error(), "error while loading metadata of the snapshot at block number [1] for channel [ledger1]")
+
+	require.NoError(t, kvledger.PruneBlockStore(rootFSPath, snapshotsRootDir, "ledger1", 2))
+
+	env.initLedgerMgmt()
+	l = env.openTestLedger("ledger1")
+	l.verifyBlockchainInfo(
+		&common.BlockchainInfo{
+			Height:            bcInfo.Height,
+			CurrentBlockHash:  bcInfo.CurrentBlockHash,
+			PreviousBlockHash: bcInfo.PreviousBlockHash,
+			BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{
+				LastBlockInSnapshot: 2,
+			},
+		},
+	)
+	_, err = l.lgr.GetBlockByNumber(2)
+	require.EqualError(t, err, "cannot serve block [2]. The block has been pruned from the ledger. First available block = [3]")
+	_, err = l.lgr.GetTransactionByID("txid-2")
+	require.EqualError(t, err, "details for the TXID [txid-2] not available. The block containing the transaction has been pruned from the ledger. First available block = [3]")
+	l.verifyTXIDExists("txid-1", "txid-2", "txid-3")
+
+	// history for the key includes only the modifications from the blocks that are not pruned
+	l.verifyHistory("cc1", "key1", []string{"value3"})
+
+	// the ledger continues to commit blocks after pruning
+	l.simulateDataTx("txid-4", func(s *simulator) {
+		s.setState("cc1", "key1", "value4")
+	})
+	l.cutBlockAndCommitLegacy()
+	l.verifyPubState("cc1", "key1", "value4")
+}
+
+func TestPruneBlockStoreInterrupted(t *testing.T) {
+	env := newEnv(t)
+	defer env.cleanup()
+	env.initLedgerMgmt()
+	l := env.createTestLedgerFromGenesisBlk("ledger1")
+
+	for i := 1; i <= 3; i++ {
+		l.simulateDataTx(fmt.Sprintf("txid-%d", i), func(s *simulator) {
+			s.setState("cc1", "key1", fmt.Sprintf("value%d", i))
+		})
+		l.cutBlockAndCommitLegacy()
+		if i == 2 {
+			require.DirExists(t, l.generateSnapshot())
+		}
+	}
+	bcInfo, err := l.lgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	env.closeLedgerMgmt()
+
+	// the block store is pruned but the peer stops before the boot snapshot
+	// metadata is recorded, which is completed when the ledger is opened
+	blockstorePath := kvledger.BlockStorePath(env.initializer.Config.RootFSPath)
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockHash,
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrTxID,
+		blkstorage.IndexableAttrBlockNumTranNum,
+	}}
+	require.NoError(t, blkstorage.Prune(blockstorePath, "ledger1", 2, indexConfig))
+
+	snapshotDir := kvledger.SnapshotDirForLedgerBlockNum(env.initializer.Config.SnapshotsConfig.RootDir, "ledger1", 2)
+	snapshotBackupDir := snapshotDir + "-backup"
+	require.NoError(t, os.Rename(snapshotDir, snapshotBackupDir))
+	env.initLedgerMgmt()
+	_, err = env.ledgerMgr.OpenLedger("ledger1")
+	require.Contains(t, err.Error(), "error while completing the interrupted prune operation of channel [ledger1]")
+	env.closeLedgerMgmt()
+	require.NoError(t, os.Rename(snapshotBackupDir, snapshotDir))
+
+	env.initLedgerMgmt()
+	l = env.openTestLedger("ledger1")
+	l.verifyBlockchainInfo(
+		&common.BlockchainInfo{
+			Height:            bcInfo.Height,
+			CurrentBlockHash:  bcInfo.CurrentBlockHash,
+			PreviousBlockHash: bcInfo.PreviousBlockHash,
+			BootstrappingSnapshotInfo: &common.BootstrappingSnapshotInfo{
+				LastBlockInSnapshot: 2,
+			},
+		},
+	)
+	env.closeLedgerMgmt()
+
+	// the boot snapshot metadata has been recorded, so the snapshot is not needed anymore
+	require.NoError(t, os.RemoveAll(snapshotDir))
+	env.initLedgerMgmt()
+	l = env.openTestLedger("ledger1")
+	l.verifyTXIDExists("txid-1", "txid-2", "txid-3")
+}
@@ -52,6 +52,8 @@ type Config struct {
 	HistoryDBConfig *HistoryDBConfig
 	// SnapshotsConfig holds the configuration parameters for the snapshots.
 	SnapshotsConfig *SnapshotsConfig
+	// BlockStoreConfig holds the configuration parameters for the block store.
+	BlockStoreConfig *BlockStoreConfig
 }
 
 // StateDBConfig is a structure used to configure the state parameters for the ledger.
@@ -125,10 +127,37 @@ type HistoryDBConfig struct {
 	Enabled bool
 }
 
+// BlockStoreConfig is a structure used to configure the block store
+type BlockStoreConfig struct {
+	// CompressBlockFiles enables the compression of the blocks that are stored in the block files
+	// created hereafter. The existing block files remain in their original format.
+	CompressBlockFiles bool
+}
+
 // SnapshotsConfig is a structure used to configure snapshot function
 type SnapshotsConfig struct {
 	// RootDir is the top-level directory for the snapshots.
 	RootDir string
+	// DefaultPolicy is the policy for the automatic generation of snapshots for the channels
+	// that do not have a channel specific policy in ChannelPolicies.
+	DefaultPolicy *SnapshotPolicy
+	// ChannelPolicies holds the channel specific policies for the automatic generation of snapshots.
+	ChannelPolicies map[string]*SnapshotPolicy
+}
+
+// SnapshotPolicy is a structure used to configure the automatic generation of snapshots for a channel
+// and the retention of the generated snapshots.
+type SnapshotPolicy struct {
+	// BlockInterval causes a snapshot to be generated at every block number that is a multiple of BlockInterval.
+	// A value of zero disables the block based generation.
+	BlockInterval uint64
+	// TimeInterval causes a snapshot to be generated at the first block committed after TimeInterval has
+	// elapsed since the previous snapshot. A value of zero disables the time based generation.
+	TimeInterval time.Duration
+	// MaxRetained is the number of the most recent snapshots generated as per the policy that are retained for
+	// the channel. The older ones are removed after a new snapshot is generated, except the ones in use. The snapshots
+	// generated on request are not removed. A value of zero retains all the snapshots.
+	MaxRetained int
 }
 
 // PeerLedgerProvider provides handle to ledger instances
@@ -540,6 +569,9 @@ type ConfigHistoryRetriever interface {
 // MissingPvtDataTracker allows getting information about the private data that is not missing on the peer
 type MissingPvtDataTracker interface {
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlocks int) (MissingPvtDataInfo, error)
+	// GetMissingPvtDataInfoForBlockRange returns the missing private data information, regardless of
+	// its reconciliation priority, for the most recent `maxBlocks` blocks within [startBlock, endBlock]
+	GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlocks int) (MissingPvtDataInfo, error)
 }
 
 // MissingPvtDataInfo is a map of block number to MissingBlockPvtdataInfo
@@ -9,6 +9,8 @@ package ledgermgmt
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"sync"
 
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -19,7 +21,9 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/msgs"
 	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
@@ -35,11 +39,13 @@ var ErrLedgerMgmtNotInitialized = errors.New("ledger mgmt should be initialized
 type LedgerMgr struct {
 	creationLock         sync.Mutex
 	joinBySnapshotStatus *pb.JoinBySnapshotStatus
+	joinBySnapshotErr    string // the reason the last joinbysnapshot operation failed
 
 	lock           sync.Mutex
 	openedLedgers  map[string]ledger.PeerLedger
 	ledgerProvider ledger.PeerLedgerProvider
 
+	snapshotsRootDir   string
 	ebMetadataProvider MetadataProvider
 }
 
@@ -88,6 +94,7 @@ func NewLedgerMgr(initializer *Initializer) *LedgerMgr {
 		joinBySnapshotStatus: &pb.JoinBySnapshotStatus{},
 		openedLedgers:        make(map[string]ledger.PeerLedger),
 		ledgerProvider:       provider,
+		snapshotsRootDir:     initializer.Config.SnapshotsConfig.RootDir,
 		ebMetadataProvider:   initializer.EbMetadataProvider,
 	}
 	// TODO remove the following package level init
@@ -151,6 +158,47 @@ func (m *LedgerMgr) CreateLedgerFromSnapshot(snapshotDir string, channelCallback
 		ledger, cid, err := m.createFromSnapshot(snapshotDir)
 		if err != nil {
 			logger.Errorw("Error creating ledger from snapshot", "snapshotDir", snapshotDir, "error", err)
+			m.failJoinBySnapshot(errors.WithMessage(err, "error creating ledger from snapshot"))
+			return
+		}
+
+		channelCallback(ledger, cid)
+	}()
+
+	return nil
+}
+
+// CreateLedgerFromFetchedSnapshot creates a new ledger with a snapshot that is fetched by the given fetch
+// function and executes the callback function after the ledger is created. The fetch function is passed an
+// empty dir, under the temporary snapshots dir, into which it is expected to place the snapshot files. Like
+// CreateLedgerFromSnapshot, this function launches a goroutine to fetch the snapshot, create the ledger and
+// call the callback func, and returns an error if another ledger is being created from a snapshot.
+// The fetched snapshot is removed once the ledger is created or the creation fails.
+func (m *LedgerMgr) CreateLedgerFromFetchedSnapshot(fetch func(snapshotDir string) error, channelCallback func(ledger.PeerLedger, string)) error {
+	snapshotDir, err := ioutil.TempDir(kvledger.SnapshotsTempDirPath(m.snapshotsRootDir), "fetched-")
+	if err != nil {
+		return errors.Wrap(err, "failed to create dir for the fetched snapshot")
+	}
+
+	if err := m.setJoinBySnapshotStatus(snapshotDir); err != nil {
+		os.RemoveAll(snapshotDir)
+		return err
+	}
+
+	go func() {
+		defer m.resetJoinBySnapshotStatus()
+		defer os.RemoveAll(snapshotDir)
+
+		if err := fetch(snapshotDir); err != nil {
+			logger.Errorw("Error fetching snapshot", "snapshotDir", snapshotDir, "error", err)
+			m.failJoinBySnapshot(errors.WithMessage(err, "error fetching snapshot"))
+			return
+		}
+
+		ledger, cid, err := m.createFromSnapshot(snapshotDir)
+		if err != nil {
+			logger.Errorw("Error creating ledger from fetched snapshot", "snapshotDir", snapshotDir, "error", err)
+			m.failJoinBySnapshot(errors.WithMessage(err, "error creating ledger from fetched snapshot"))
 			return
 		}
 
@@ -187,9 +235,18 @@ func (m *LedgerMgr) setJoinBySnapshotStatus(snapshotDir string) error {
 	}
 	m.joinBySnapshotStatus.InProgress = true
 	m.joinBySnapshotStatus.BootstrappingSnapshotDir = snapshotDir
+	m.joinBySnapshotErr = ""
 	return nil
 }
 
+// failJoinBySnapshot records the reason the joinbysnapshot operation in progress failed,
+// so that it is reported by JoinBySnapshotStatus until another operation starts.
+func (m *LedgerMgr) failJoinBySnapshot(err error) {
+	m.creationLock.Lock()
+	defer m.creationLock.Unlock()
+	m.joinBySnapshotErr = err.Error()
+}
+
 // resetJoinBySnapshotStatus resets joinBySnapshotStatus to indicate no CreateLedgerFromSnapshot is in-progress
 // so that other CreateLedger or CreateLedgerFromSnapshot calls will be allowed.
 func (m *LedgerMgr) resetJoinBySnapshotStatus() {
@@ -229,15 +286,29 @@ func (m *LedgerMgr) GetLedgerIDs() ([]string, error) {
 }
 
 // JoinBySnapshotStatus returns the status of joinbysnapshot which includes
-// ledger creation and channel callback.
+// ledger creation and channel callback. The reason the last operation failed,
+// if it did, is carried by the status as the last_error field of
+// msgs.JoinBySnapshotStatus.
 func (m *LedgerMgr) JoinBySnapshotStatus() *pb.JoinBySnapshotStatus {
 	m.creationLock.Lock()
 	defer m.creationLock.Unlock()
 	// return a copy of joinBySnapshotStatus to the caller
-	return &pb.JoinBySnapshotStatus{
+	status := &pb.JoinBySnapshotStatus{
 		InProgress:               m.joinBySnapshotStatus.InProgress,
 		BootstrappingSnapshotDir: m.joinBySnapshotStatus.BootstrappingSnapshotDir,
 	}
+	if m.joinBySnapshotErr == "" {
+		return status
+	}
+	withErr := &msgs.JoinBySnapshotStatus{
+		InProgress:               status.InProgress,
+		BootstrappingSnapshotDir: status.BootstrappingSnapshotDir,
+		LastError:                m.joinBySnapshotErr,
+	}
+	if err := protoutil.ConvertMessage(withErr, status); err != nil {
+		logger.Warnw("Failed to add the last error to the joinbysnapshot status", "error", err)
+	}
+	return status
 }
 
 // Close closes all the opened ledgers and any resources held for ledger management
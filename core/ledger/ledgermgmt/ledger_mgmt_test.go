@@ -22,8 +22,10 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/msgs"
 	"github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -264,6 +266,92 @@ func TestConcurrentCreateLedgerFromSnapshot(t *testing.T) {
 	require.ElementsMatch(t, ledgerIDs, []string{channelID1, channelID2, channelID3})
 }
 
+func TestCreateLedgerFromFetchedSnapshot(t *testing.T) {
+	initializer, lgrMgr, cleanup := setup(t, "createledgerfromfetchedsnapshot")
+	defer cleanup()
+
+	channelID := "testcreatefromfetchedsnapshot"
+	snapshotDir, _ := generateSnapshot(t, lgrMgr, initializer, channelID)
+
+	ledgerCreated := func(ledgerMgr *LedgerMgr) func() bool {
+		return func() bool {
+			status := ledgerMgr.JoinBySnapshotStatus()
+			return !status.InProgress && status.BootstrappingSnapshotDir == ""
+		}
+	}
+
+	t.Run("create_ledger_from_fetched_snapshot", func(t *testing.T) {
+		initializer, ledgerMgr, cleanup := setup(t, "createledgerfromfetchedsnapshot_success")
+		defer cleanup()
+
+		var fetchedSnapshotDir string
+		fetch := func(dir string) error {
+			fetchedSnapshotDir = dir
+			return testutil.CopyDir(snapshotDir, dir, true)
+		}
+
+		waitCh := make(chan struct{})
+		callbackCounter := 0
+		callback := func(l ledger.PeerLedger, cid string) {
+			<-waitCh
+			callbackCounter++
+		}
+		require.NoError(t, ledgerMgr.CreateLedgerFromFetchedSnapshot(fetch, callback))
+
+		// the snapshot is fetched into the temporary snapshots dir
+		status := ledgerMgr.JoinBySnapshotStatus()
+		require.True(t, status.InProgress)
+		require.Equal(t, kvledger.SnapshotsTempDirPath(initializer.Config.SnapshotsConfig.RootDir), filepath.Dir(status.BootstrappingSnapshotDir))
+
+		// concurrent CreateLedgerFromFetchedSnapshot call should fail
+		err := ledgerMgr.CreateLedgerFromFetchedSnapshot(fetch, callback)
+		require.EqualError(t, err, fmt.Sprintf("a ledger is being created from a snapshot at %s. Call ledger creation again after it is done.", status.BootstrappingSnapshotDir))
+
+		waitCh <- struct{}{}
+		require.Eventually(t, ledgerCreated(ledgerMgr), time.Minute, time.Second)
+		require.Equal(t, 1, callbackCounter)
+		require.Equal(t, status.BootstrappingSnapshotDir, fetchedSnapshotDir)
+		require.NoDirExists(t, fetchedSnapshotDir)
+		require.Empty(t, ledgerMgr.JoinBySnapshotStatus().XXX_unrecognized)
+
+		ledgerIDs, err := ledgerMgr.GetLedgerIDs()
+		require.NoError(t, err)
+		require.Equal(t, []string{channelID}, ledgerIDs)
+	})
+
+	t.Run("callback_func_is_not_called_if_fetch_failed", func(t *testing.T) {
+		_, ledgerMgr, cleanup := setup(t, "createledgerfromfetchedsnapshot_fetchfailed")
+		defer cleanup()
+
+		var fetchedSnapshotDir string
+		fetch := func(dir string) error {
+			fetchedSnapshotDir = dir
+			return errors.New("fetch-error")
+		}
+
+		callbackCounter := 0
+		callback := func(l ledger.PeerLedger, cid string) { callbackCounter++ }
+		require.NoError(t, ledgerMgr.CreateLedgerFromFetchedSnapshot(fetch, callback))
+
+		require.Eventually(t, ledgerCreated(ledgerMgr), time.Minute, time.Second)
+		require.Equal(t, 0, callbackCounter)
+		require.NoDirExists(t, fetchedSnapshotDir)
+
+		// the failure is reported by the status until another operation starts
+		status := &msgs.JoinBySnapshotStatus{}
+		require.NoError(t, protoutil.ConvertMessage(ledgerMgr.JoinBySnapshotStatus(), status))
+		require.Equal(t, "error fetching snapshot: fetch-error", status.LastError)
+		require.NoError(t, ledgerMgr.CreateLedgerFromFetchedSnapshot(fetch, callback))
+		require.NoError(t, protoutil.ConvertMessage(ledgerMgr.JoinBySnapshotStatus(), status))
+		require.Empty(t, status.LastError)
+		require.Eventually(t, ledgerCreated(ledgerMgr), time.Minute, time.Second)
+
+		ledgerIDs, err := ledgerMgr.GetLedgerIDs()
+		require.NoError(t, err)
+		require.Empty(t, ledgerIDs)
+	})
+}
+
 func TestChaincodeInfoProvider(t *testing.T) {
 	_, ledgerMgr, cleanup := setup(t, "chaincodeinfoprovider")
 	defer cleanup()
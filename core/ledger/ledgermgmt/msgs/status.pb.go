@@ -0,0 +1,106 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: status.proto
+
+package msgs
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// JoinBySnapshotStatus extends protos.JoinBySnapshotStatus with the outcome of
+// the last joinbysnapshot operation. It has the fields of
+// protos.JoinBySnapshotStatus, so that the two can be converted to each other.
+// The added field is numbered away from the fields of
+// protos.JoinBySnapshotStatus.
+type JoinBySnapshotStatus struct {
+	InProgress               bool   `protobuf:"varint,1,opt,name=in_progress,json=inProgress,proto3" json:"in_progress,omitempty"`
+	BootstrappingSnapshotDir string `protobuf:"bytes,2,opt,name=bootstrapping_snapshot_dir,json=bootstrappingSnapshotDir,proto3" json:"bootstrapping_snapshot_dir,omitempty"`
+	// last_error is the reason the last joinbysnapshot operation failed, if
+	// it failed. It is cleared when a new operation starts.
+	LastError            string   `protobuf:"bytes,105,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinBySnapshotStatus) Reset()         { *m = JoinBySnapshotStatus{} }
+func (m *JoinBySnapshotStatus) String() string { return proto.CompactTextString(m) }
+func (*JoinBySnapshotStatus) ProtoMessage()    {}
+func (*JoinBySnapshotStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_dfe4fce6682daf5b, []int{0}
+}
+
+func (m *JoinBySnapshotStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinBySnapshotStatus.Unmarshal(m, b)
+}
+func (m *JoinBySnapshotStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinBySnapshotStatus.Marshal(b, m, deterministic)
+}
+func (m *JoinBySnapshotStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinBySnapshotStatus.Merge(m, src)
+}
+func (m *JoinBySnapshotStatus) XXX_Size() int {
+	return xxx_messageInfo_JoinBySnapshotStatus.Size(m)
+}
+func (m *JoinBySnapshotStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinBySnapshotStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinBySnapshotStatus proto.InternalMessageInfo
+
+func (m *JoinBySnapshotStatus) GetInProgress() bool {
+	if m != nil {
+		return m.InProgress
+	}
+	return false
+}
+
+func (m *JoinBySnapshotStatus) GetBootstrappingSnapshotDir() string {
+	if m != nil {
+		return m.BootstrappingSnapshotDir
+	}
+	return ""
+}
+
+func (m *JoinBySnapshotStatus) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*JoinBySnapshotStatus)(nil), "ledgermgmt.msgs.JoinBySnapshotStatus")
+}
+
+func init() { proto.RegisterFile("status.proto", fileDescriptor_dfe4fce6682daf5b) }
+
+var fileDescriptor_dfe4fce6682daf5b = []byte{
+	// 207 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x8f, 0x31, 0x4b, 0x04, 0x31,
+	0x10, 0x46, 0x89, 0x85, 0x78, 0x51, 0x10, 0x82, 0x45, 0x10, 0xc4, 0xc3, 0xea, 0xaa, 0x4d, 0x61,
+	0x25, 0x5a, 0x1d, 0xda, 0x58, 0xc9, 0x5d, 0x67, 0x13, 0x92, 0xbd, 0x98, 0x1d, 0xb8, 0x64, 0xc2,
+	0xcc, 0x5c, 0x71, 0xff, 0xc3, 0x1f, 0x2c, 0xbb, 0xae, 0xc8, 0xb5, 0x8f, 0xc7, 0xcc, 0xf7, 0xf4,
+	0x15, 0x4b, 0x90, 0x03, 0x77, 0x8d, 0x50, 0xd0, 0x5c, 0xef, 0xd3, 0x2e, 0x27, 0x2a, 0xb9, 0x48,
+	0x57, 0x38, 0xf3, 0xc3, 0xb7, 0xd2, 0x37, 0xef, 0x08, 0x75, 0x7d, 0xdc, 0xd6, 0xd0, 0x78, 0x40,
+	0xd9, 0x4e, 0xbe, 0xb9, 0xd7, 0x97, 0x50, 0x7d, 0x23, 0xcc, 0x94, 0x98, 0xad, 0x5a, 0xaa, 0xd5,
+	0xc5, 0x46, 0x43, 0xfd, 0x98, 0x89, 0x79, 0xd1, 0xb7, 0x11, 0x51, 0x58, 0x28, 0xb4, 0x06, 0x35,
+	0x7b, 0x9e, 0x0f, 0xf8, 0x1d, 0x90, 0x3d, 0x5b, 0xaa, 0xd5, 0x62, 0x63, 0x4f, 0x8c, 0xbf, 0x0f,
+	0xaf, 0x40, 0xe6, 0x4e, 0xeb, 0x7d, 0x60, 0xf1, 0x89, 0x08, 0xc9, 0xc2, 0x64, 0x2f, 0x46, 0xf2,
+	0x36, 0x82, 0xf5, 0xf3, 0xe7, 0x53, 0x06, 0x19, 0x0e, 0xb1, 0xeb, 0xb1, 0xb8, 0xe1, 0xd8, 0x12,
+	0xfd, 0x2e, 0x77, 0x5f, 0x21, 0x12, 0xf4, 0xae, 0x47, 0x4a, 0x6e, 0x46, 0xff, 0x4d, 0x6e, 0x6c,
+	0x8a, 0xe7, 0x53, 0xeb, 0xe3, 0xcf, 0x00, 0x59, 0x91, 0x8f, 0x0b, 0xfb, 0x00, 0x00, 0x00,
+}
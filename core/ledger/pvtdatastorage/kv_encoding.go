@@ -234,6 +234,16 @@ func createRangeScanKeysForElgMissingData(blkNum uint64, group []byte) ([]byte,
 	return startKey, endKey
 }
 
+func createRangeScanKeysForElgMissingDataInRange(startBlkNum, endBlkNum uint64, group []byte) ([]byte, []byte) {
+	if startBlkNum == 0 {
+		return createRangeScanKeysForElgMissingData(endBlkNum, group)
+	}
+	startKey := append(group, encodeReverseOrderVarUint64(endBlkNum)...)
+	endKey := append(group, encodeReverseOrderVarUint64(startBlkNum-1)...)
+
+	return startKey, endKey
+}
+
 func createRangeScanKeysForInelgMissingData(maxBlkNum uint64, ns, coll string) ([]byte, []byte) {
 	startKey := encodeInelgMissingDataKey(
 		&missingDataKey{
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package pvtdatastorage
 
 import (
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -474,18 +475,65 @@ func (s *Store) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledger.M
 	return s.getMissingData(elgPrioritizedMissingDataGroup, maxBlock)
 }
 
-func (s *Store) getMissingData(group []byte, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+// GetMissingPvtDataInfoForBlockRange returns the missing private data information for the most
+// recent `maxBlock` blocks, within the range [startBlock, endBlock], which miss at least a private
+// data of a eligible collection. Unlike GetMissingPvtDataInfoForMostRecentBlocks, this function
+// returns the entries of both the prioritized and the deprioritized missing data, so that the
+// missing data of specific blocks can be reconciled on demand.
+func (s *Store) GetMissingPvtDataInfoForBlockRange(startBlock, endBlock uint64, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	if lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock); endBlock > lastCommittedBlock {
+		endBlock = lastCommittedBlock
+	}
+	if maxBlock < 1 || startBlock > endBlock {
+		return nil, nil
+	}
+
 	missingPvtDataInfo := make(ledger.MissingPvtDataInfo)
-	numberOfBlockProcessed := 0
-	lastProcessedBlock := uint64(0)
-	isMaxBlockLimitReached := false
+	for _, group := range [][]byte{elgPrioritizedMissingDataGroup, elgDeprioritizedMissingDataGroup} {
+		startKey, endKey := createRangeScanKeysForElgMissingDataInRange(startBlock, endBlock, group)
+		groupMissingPvtDataInfo, err := s.getMissingDataInRange(startKey, endKey, maxBlock)
+		if err != nil {
+			return nil, err
+		}
+		for blkNum, blkMissingPvtDataInfo := range groupMissingPvtDataInfo {
+			for txNum, collsMissingPvtDataInfo := range blkMissingPvtDataInfo {
+				for _, collMissingPvtDataInfo := range collsMissingPvtDataInfo {
+					missingPvtDataInfo.Add(blkNum, txNum, collMissingPvtDataInfo.Namespace, collMissingPvtDataInfo.Collection)
+				}
+			}
+		}
+	}
 
+	// each group contributes up to maxBlock blocks, retain only the most recent maxBlock blocks
+	if len(missingPvtDataInfo) > maxBlock {
+		blkNums := make([]uint64, 0, len(missingPvtDataInfo))
+		for blkNum := range missingPvtDataInfo {
+			blkNums = append(blkNums, blkNum)
+		}
+		sort.Slice(blkNums, func(i, j int) bool { return blkNums[i] > blkNums[j] })
+		for _, blkNum := range blkNums[maxBlock:] {
+			delete(missingPvtDataInfo, blkNum)
+		}
+	}
+	return missingPvtDataInfo, nil
+}
+
+func (s *Store) getMissingData(group []byte, maxBlock int) (ledger.MissingPvtDataInfo, error) {
 	// as we are not acquiring a read lock, new blocks can get committed while we
 	// construct the MissingPvtDataInfo. As a result, lastCommittedBlock can get
 	// changed. To ensure consistency, we atomically load the lastCommittedBlock value
 	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
 
 	startKey, endKey := createRangeScanKeysForElgMissingData(lastCommittedBlock, group)
+	return s.getMissingDataInRange(startKey, endKey, maxBlock)
+}
+
+func (s *Store) getMissingDataInRange(startKey, endKey []byte, maxBlock int) (ledger.MissingPvtDataInfo, error) {
+	missingPvtDataInfo := make(ledger.MissingPvtDataInfo)
+	numberOfBlockProcessed := 0
+	lastProcessedBlock := uint64(0)
+	isMaxBlockLimitReached := false
+
 	dbItr, err := s.db.GetIterator(startKey, endKey)
 	if err != nil {
 		return nil, err
@@ -510,7 +558,7 @@ func (s *Store) getMissingData(group []byte, maxBlock int) (ledger.MissingPvtDat
 		// data (less possibility of expiring now), such scenario would be rare. In the
 		// best case, we can load the latest lastCommittedBlock value here atomically to
 		// make this scenario very rare.
-		lastCommittedBlock = atomic.LoadUint64(&s.lastCommittedBlock)
+		lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
 		expired, err := isExpired(missingDataKey.nsCollBlk, s.btlPolicy, lastCommittedBlock)
 		if err != nil {
 			return nil, err
@@ -295,6 +295,81 @@ func TestGetMissingDataInfo(t *testing.T) {
 	})
 }
 
+func TestGetMissingPvtDataInfoForBlockRange(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestGetMissingPvtDataInfoForBlockRange", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	store := env.TestStore
+
+	// blocks 1 to 4 miss the data of coll-1 in tx 1 and of coll-2 in tx 2,
+	// block 5 does not miss any data and block 6 only misses ineligible data
+	require.NoError(t, store.Commit(0, nil, nil))
+	for blkNum := uint64(1); blkNum <= 4; blkNum++ {
+		missingData := make(ledger.TxMissingPvtData)
+		missingData.Add(1, "ns-1", "coll-1", true)
+		missingData.Add(2, "ns-1", "coll-2", true)
+		require.NoError(t, store.Commit(blkNum, nil, missingData))
+	}
+	require.NoError(t, store.Commit(5, nil, nil))
+	ineligibleMissingData := make(ledger.TxMissingPvtData)
+	ineligibleMissingData.Add(1, "ns-1", "coll-1", false)
+	require.NoError(t, store.Commit(6, nil, ineligibleMissingData))
+
+	// the missing data of coll-2 in blocks 2 and 3 moves to the deprioritized list
+	deprioritizedList := ledger.MissingPvtDataInfo{}
+	deprioritizedList.Add(2, 2, "ns-1", "coll-2")
+	deprioritizedList.Add(3, 2, "ns-1", "coll-2")
+	require.NoError(t, store.CommitPvtDataOfOldBlocks(nil, deprioritizedList))
+
+	expectedMissingDataInfo := func(blkNums ...uint64) ledger.MissingPvtDataInfo {
+		missingDataInfo := ledger.MissingPvtDataInfo{}
+		for _, blkNum := range blkNums {
+			missingDataInfo.Add(blkNum, 1, "ns-1", "coll-1")
+			missingDataInfo.Add(blkNum, 2, "ns-1", "coll-2")
+		}
+		return missingDataInfo
+	}
+
+	tests := []struct {
+		name                   string
+		startBlock, endBlock   uint64
+		maxBlock               int
+		expectedMissingBlkNums []uint64
+	}{
+		{name: "all blocks", startBlock: 0, endBlock: 10, maxBlock: 10, expectedMissingBlkNums: []uint64{1, 2, 3, 4}},
+		{name: "range", startBlock: 2, endBlock: 3, maxBlock: 10, expectedMissingBlkNums: []uint64{2, 3}},
+		{name: "single block", startBlock: 3, endBlock: 3, maxBlock: 10, expectedMissingBlkNums: []uint64{3}},
+		{name: "max blocks", startBlock: 1, endBlock: 6, maxBlock: 2, expectedMissingBlkNums: []uint64{3, 4}},
+		{name: "max blocks within range", startBlock: 1, endBlock: 3, maxBlock: 2, expectedMissingBlkNums: []uint64{2, 3}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			missingDataInfo, err := store.GetMissingPvtDataInfoForBlockRange(test.startBlock, test.endBlock, test.maxBlock)
+			require.NoError(t, err)
+			require.Equal(t, expectedMissingDataInfo(test.expectedMissingBlkNums...), missingDataInfo)
+		})
+	}
+
+	t.Run("no missing data", func(t *testing.T) {
+		missingDataInfo, err := store.GetMissingPvtDataInfoForBlockRange(5, 6, 10)
+		require.NoError(t, err)
+		require.Empty(t, missingDataInfo)
+
+		missingDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(7, 10, 10)
+		require.NoError(t, err)
+		require.Nil(t, missingDataInfo)
+
+		missingDataInfo, err = store.GetMissingPvtDataInfoForBlockRange(1, 4, 0)
+		require.NoError(t, err)
+		require.Nil(t, missingDataInfo)
+	})
+}
+
 func TestExpiryDataNotIncluded(t *testing.T) {
 	ledgerid := "TestExpiryDataNotIncluded"
 	btlPolicy := btltestutil.SampleBTLPolicy(
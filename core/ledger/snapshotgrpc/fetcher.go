@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/msgs"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// OrgMembership provides the peers of the organization of the peer
+type OrgMembership interface {
+	// OrgPeerEndpoints returns the endpoints of the peers of the organization
+	// of the peer which are known to be alive
+	OrgPeerEndpoints() []string
+}
+
+// Fetcher fetches snapshots from the peers of the organization of the peer
+// through their TransferService.
+type Fetcher struct {
+	Signer        identity.SignerSerializer
+	HashProvider  ledger.HashProvider
+	OrgMembership OrgMembership
+	DialOptions   func() []grpc.DialOption
+}
+
+// CheckPeer returns an error if the endpoint is not the endpoint of a peer
+// of the organization of the peer known to be alive.
+func (f *Fetcher) CheckPeer(peerEndpoint string) error {
+	for _, endpoint := range f.OrgMembership.OrgPeerEndpoints() {
+		if endpoint == peerEndpoint {
+			return nil
+		}
+	}
+	return errors.Errorf("peer %s is not an alive peer of the organization", peerEndpoint)
+}
+
+// Fetch fetches the snapshot of the channel whose signable metadata file has the
+// given hash from the peer, and stores its files in the snapshotDir. The signable
+// metadata file lists the hashes of the other files of the snapshot, which are
+// verified when a ledger is created from the snapshot.
+func (f *Fetcher) Fetch(ctx context.Context, peerEndpoint, channelID string, snapshotHash []byte, snapshotDir string) error {
+	if err := f.CheckPeer(peerEndpoint); err != nil {
+		return err
+	}
+
+	signedRequest, err := f.signedRequest(channelID, snapshotHash)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.DialContext(ctx, peerEndpoint, f.DialOptions()...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to peer %s", peerEndpoint)
+	}
+	defer conn.Close()
+
+	stream, err := msgs.NewSnapshotTransferClient(conn).Fetch(ctx, signedRequest)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch snapshot from peer %s", peerEndpoint)
+	}
+	if err := receiveSnapshotFiles(stream, snapshotDir); err != nil {
+		return errors.WithMessagef(err, "failed to fetch snapshot from peer %s", peerEndpoint)
+	}
+
+	return f.verifySnapshot(snapshotDir, channelID, snapshotHash)
+}
+
+func (f *Fetcher) signedRequest(channelID string, snapshotHash []byte) (*msgs.SignedFetchSnapshotRequest, error) {
+	signatureHdr, err := protoutil.NewSignatureHeader(f.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create signature header")
+	}
+	request, err := proto.Marshal(&msgs.FetchSnapshotRequest{
+		SignatureHeader: signatureHdr,
+		ChannelId:       channelID,
+		SnapshotHash:    snapshotHash,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal fetch snapshot request")
+	}
+	signature, err := f.Signer.Sign(request)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to sign fetch snapshot request")
+	}
+	return &msgs.SignedFetchSnapshotRequest{
+		Request:   request,
+		Signature: signature,
+	}, nil
+}
+
+// verifySnapshot verifies that the signable metadata file of the snapshot has the
+// expected hash, and that the snapshot belongs to the channel
+func (f *Fetcher) verifySnapshot(snapshotDir, channelID string, snapshotHash []byte) error {
+	hash, err := signableMetadataHash(snapshotDir, f.HashProvider)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute the hash of the fetched snapshot")
+	}
+	if !bytes.Equal(hash, snapshotHash) {
+		return errors.Errorf("hash mismatch for the fetched snapshot. Expected hash = [%x], Actual hash = [%x]", snapshotHash, hash)
+	}
+
+	signableMetadataBytes, err := ioutil.ReadFile(filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName))
+	if err != nil {
+		return errors.Wrap(err, "failed to read the signable metadata of the fetched snapshot")
+	}
+	signableMetadata := &kvledger.SnapshotSignableMetadata{}
+	if err := json.Unmarshal(signableMetadataBytes, signableMetadata); err != nil {
+		return errors.Wrap(err, "failed to unmarshal the signable metadata of the fetched snapshot")
+	}
+	if signableMetadata.ChannelName != channelID {
+		return errors.Errorf("the fetched snapshot belongs to channel %s instead of channel %s", signableMetadata.ChannelName, channelID)
+	}
+	return nil
+}
+
+func receiveSnapshotFiles(stream msgs.SnapshotTransfer_FetchClient, snapshotDir string) error {
+	received := map[string]struct{}{}
+	var current *os.File
+	closeCurrent := func() error {
+		if current == nil {
+			return nil
+		}
+		defer func() { current = nil }()
+		if err := current.Sync(); err != nil {
+			current.Close()
+			return errors.Wrapf(err, "failed to write snapshot file %s", current.Name())
+		}
+		return errors.Wrapf(current.Close(), "failed to write snapshot file %s", current.Name())
+	}
+	defer closeCurrent()
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return closeCurrent()
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to receive snapshot file")
+		}
+
+		fileName := chunk.FileName
+		if current == nil || filepath.Base(current.Name()) != fileName {
+			if fileName == "" || fileName == "." || fileName == ".." || filepath.Base(fileName) != fileName {
+				return errors.Errorf("invalid snapshot file name %q", fileName)
+			}
+			if _, exists := received[fileName]; exists {
+				return errors.Errorf("snapshot file %s received more than once", fileName)
+			}
+			received[fileName] = struct{}{}
+			if err := closeCurrent(); err != nil {
+				return err
+			}
+			current, err = os.OpenFile(filepath.Join(snapshotDir, fileName), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create snapshot file %s", fileName)
+			}
+		}
+
+		if _, err := current.Write(chunk.Data); err != nil {
+			return errors.Wrapf(err, "failed to write snapshot file %s", fileName)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: snapshot_transfer.proto
+
+package msgs
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	common "github.com/hyperledger/fabric-protos-go/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// FetchSnapshotRequest requests the files of a snapshot generated by a peer.
+// The snapshot is identified by the hash of its signable metadata file.
+type FetchSnapshotRequest struct {
+	SignatureHeader      *common.SignatureHeader `protobuf:"bytes,1,opt,name=signature_header,json=signatureHeader,proto3" json:"signature_header,omitempty"`
+	ChannelId            string                  `protobuf:"bytes,2,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	SnapshotHash         []byte                  `protobuf:"bytes,3,opt,name=snapshot_hash,json=snapshotHash,proto3" json:"snapshot_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *FetchSnapshotRequest) Reset()         { *m = FetchSnapshotRequest{} }
+func (m *FetchSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchSnapshotRequest) ProtoMessage()    {}
+func (*FetchSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d79237daa74c615f, []int{0}
+}
+
+func (m *FetchSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchSnapshotRequest.Unmarshal(m, b)
+}
+func (m *FetchSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchSnapshotRequest.Merge(m, src)
+}
+func (m *FetchSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchSnapshotRequest.Size(m)
+}
+func (m *FetchSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchSnapshotRequest proto.InternalMessageInfo
+
+func (m *FetchSnapshotRequest) GetSignatureHeader() *common.SignatureHeader {
+	if m != nil {
+		return m.SignatureHeader
+	}
+	return nil
+}
+
+func (m *FetchSnapshotRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *FetchSnapshotRequest) GetSnapshotHash() []byte {
+	if m != nil {
+		return m.SnapshotHash
+	}
+	return nil
+}
+
+// SignedFetchSnapshotRequest is a FetchSnapshotRequest signed by the
+// requesting peer.
+type SignedFetchSnapshotRequest struct {
+	Request              []byte   `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignedFetchSnapshotRequest) Reset()         { *m = SignedFetchSnapshotRequest{} }
+func (m *SignedFetchSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*SignedFetchSnapshotRequest) ProtoMessage()    {}
+func (*SignedFetchSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d79237daa74c615f, []int{1}
+}
+
+func (m *SignedFetchSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignedFetchSnapshotRequest.Unmarshal(m, b)
+}
+func (m *SignedFetchSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignedFetchSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *SignedFetchSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignedFetchSnapshotRequest.Merge(m, src)
+}
+func (m *SignedFetchSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_SignedFetchSnapshotRequest.Size(m)
+}
+func (m *SignedFetchSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignedFetchSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignedFetchSnapshotRequest proto.InternalMessageInfo
+
+func (m *SignedFetchSnapshotRequest) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *SignedFetchSnapshotRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// SnapshotFileChunk is a part of a file of a snapshot. The chunks of a file
+// are sent in order, and a file is sent entirely before the next file.
+type SnapshotFileChunk struct {
+	FileName             string   `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotFileChunk) Reset()         { *m = SnapshotFileChunk{} }
+func (m *SnapshotFileChunk) String() string { return proto.CompactTextString(m) }
+func (*SnapshotFileChunk) ProtoMessage()    {}
+func (*SnapshotFileChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d79237daa74c615f, []int{2}
+}
+
+func (m *SnapshotFileChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotFileChunk.Unmarshal(m, b)
+}
+func (m *SnapshotFileChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotFileChunk.Marshal(b, m, deterministic)
+}
+func (m *SnapshotFileChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotFileChunk.Merge(m, src)
+}
+func (m *SnapshotFileChunk) XXX_Size() int {
+	return xxx_messageInfo_SnapshotFileChunk.Size(m)
+}
+func (m *SnapshotFileChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotFileChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotFileChunk proto.InternalMessageInfo
+
+func (m *SnapshotFileChunk) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *SnapshotFileChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*FetchSnapshotRequest)(nil), "snapshotgrpc.msgs.FetchSnapshotRequest")
+	proto.RegisterType((*SignedFetchSnapshotRequest)(nil), "snapshotgrpc.msgs.SignedFetchSnapshotRequest")
+	proto.RegisterType((*SnapshotFileChunk)(nil), "snapshotgrpc.msgs.SnapshotFileChunk")
+}
+
+func init() { proto.RegisterFile("snapshot_transfer.proto", fileDescriptor_d79237daa74c615f) }
+
+var fileDescriptor_d79237daa74c615f = []byte{
+	// 333 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x3d, 0x4f, 0xfb, 0x30,
+	0x10, 0xc6, 0x95, 0xff, 0x9f, 0xb7, 0x1c, 0x41, 0xb4, 0x06, 0xa9, 0x51, 0x01, 0xa9, 0x2a, 0x0c,
+	0x5d, 0x48, 0x50, 0x19, 0x11, 0x4b, 0x41, 0x55, 0x59, 0x18, 0xdc, 0x4e, 0x0c, 0x44, 0x6e, 0x72,
+	0x8d, 0x23, 0x12, 0x27, 0xd8, 0xce, 0xc0, 0x97, 0xe1, 0xb3, 0xa2, 0x38, 0x49, 0x79, 0x2b, 0x93,
+	0xcf, 0xcf, 0x9d, 0x7e, 0xf7, 0xd8, 0x0f, 0xf4, 0x94, 0x60, 0x85, 0xe2, 0xb9, 0x0e, 0xb4, 0x64,
+	0x42, 0xad, 0x50, 0x7a, 0x85, 0xcc, 0x75, 0x4e, 0xba, 0x6d, 0x23, 0x96, 0x45, 0xe8, 0x65, 0x2a,
+	0x56, 0xfd, 0xa3, 0x30, 0xcf, 0xb2, 0x5c, 0xf8, 0xf5, 0x51, 0xcf, 0x0d, 0xdf, 0x2d, 0x38, 0x9e,
+	0xa2, 0x0e, 0xf9, 0xbc, 0x99, 0xa7, 0xf8, 0x5a, 0xa2, 0xd2, 0x64, 0x02, 0x1d, 0x95, 0xc4, 0x82,
+	0xe9, 0x52, 0x62, 0xc0, 0x91, 0x45, 0x28, 0x5d, 0x6b, 0x60, 0x8d, 0xf6, 0xc7, 0x3d, 0xaf, 0x21,
+	0xcc, 0xdb, 0xfe, 0xcc, 0xb4, 0xe9, 0xa1, 0xfa, 0x2e, 0x90, 0x33, 0x80, 0x90, 0x33, 0x21, 0x30,
+	0x0d, 0x92, 0xc8, 0xfd, 0x37, 0xb0, 0x46, 0x36, 0xb5, 0x1b, 0xe5, 0x21, 0x22, 0xe7, 0x70, 0xb0,
+	0xb6, 0xcf, 0x99, 0xe2, 0xee, 0xff, 0x81, 0x35, 0x72, 0xa8, 0xd3, 0x8a, 0x33, 0xa6, 0xf8, 0x70,
+	0x01, 0xfd, 0x6a, 0x0f, 0x46, 0x1b, 0x5d, 0xba, 0xb0, 0x2b, 0xeb, 0xd2, 0x98, 0x73, 0x68, 0x7b,
+	0x25, 0xa7, 0x60, 0xaf, 0xed, 0x98, 0xd5, 0x0e, 0xfd, 0x14, 0x86, 0xf7, 0xd0, 0x6d, 0x51, 0xd3,
+	0x24, 0xc5, 0x3b, 0x5e, 0x8a, 0x17, 0x72, 0x02, 0xf6, 0x2a, 0x49, 0x31, 0x10, 0x2c, 0x43, 0x83,
+	0xb3, 0xe9, 0x5e, 0x25, 0x3c, 0xb2, 0x0c, 0x09, 0x81, 0xad, 0x88, 0x69, 0xd6, 0xa0, 0x4c, 0x3d,
+	0x96, 0xd0, 0x69, 0x29, 0x8b, 0xe6, 0xfb, 0xc9, 0x33, 0x6c, 0x1b, 0xa7, 0xe4, 0xd2, 0xfb, 0x15,
+	0x81, 0xf7, 0xf7, 0x4b, 0xfa, 0x17, 0x9b, 0xc6, 0x7f, 0x5a, 0xbc, 0xb2, 0x26, 0xb7, 0x4f, 0x37,
+	0x71, 0xa2, 0x79, 0xb9, 0xac, 0x52, 0xf0, 0xf9, 0x5b, 0x81, 0x32, 0xc5, 0x28, 0x46, 0xe9, 0xaf,
+	0xd8, 0x52, 0x26, 0xa1, 0x1f, 0xe6, 0x12, 0xfd, 0x46, 0xfa, 0x8a, 0xf4, 0x2b, 0xe4, 0x72, 0xc7,
+	0xc4, 0x7e, 0xfd, 0x31, 0x00, 0x09, 0x65, 0xca, 0x64, 0x39, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// SnapshotTransferClient is the client API for SnapshotTransfer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SnapshotTransferClient interface {
+	// Fetch streams the files of a snapshot.
+	Fetch(ctx context.Context, in *SignedFetchSnapshotRequest, opts ...grpc.CallOption) (SnapshotTransfer_FetchClient, error)
+}
+
+type snapshotTransferClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSnapshotTransferClient(cc grpc.ClientConnInterface) SnapshotTransferClient {
+	return &snapshotTransferClient{cc}
+}
+
+func (c *snapshotTransferClient) Fetch(ctx context.Context, in *SignedFetchSnapshotRequest, opts ...grpc.CallOption) (SnapshotTransfer_FetchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_SnapshotTransfer_serviceDesc.Streams[0], "/snapshotgrpc.msgs.SnapshotTransfer/Fetch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &snapshotTransferFetchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SnapshotTransfer_FetchClient interface {
+	Recv() (*SnapshotFileChunk, error)
+	grpc.ClientStream
+}
+
+type snapshotTransferFetchClient struct {
+	grpc.ClientStream
+}
+
+func (x *snapshotTransferFetchClient) Recv() (*SnapshotFileChunk, error) {
+	m := new(SnapshotFileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SnapshotTransferServer is the server API for SnapshotTransfer service.
+type SnapshotTransferServer interface {
+	// Fetch streams the files of a snapshot.
+	Fetch(*SignedFetchSnapshotRequest, SnapshotTransfer_FetchServer) error
+}
+
+// UnimplementedSnapshotTransferServer can be embedded to have forward compatible implementations.
+type UnimplementedSnapshotTransferServer struct {
+}
+
+func (*UnimplementedSnapshotTransferServer) Fetch(req *SignedFetchSnapshotRequest, srv SnapshotTransfer_FetchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Fetch not implemented")
+}
+
+func RegisterSnapshotTransferServer(s *grpc.Server, srv SnapshotTransferServer) {
+	s.RegisterService(&_SnapshotTransfer_serviceDesc, srv)
+}
+
+func _SnapshotTransfer_Fetch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignedFetchSnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SnapshotTransferServer).Fetch(m, &snapshotTransferFetchServer{stream})
+}
+
+type SnapshotTransfer_FetchServer interface {
+	Send(*SnapshotFileChunk) error
+	grpc.ServerStream
+}
+
+type snapshotTransferFetchServer struct {
+	grpc.ServerStream
+}
+
+func (x *snapshotTransferFetchServer) Send(m *SnapshotFileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _SnapshotTransfer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "snapshotgrpc.msgs.SnapshotTransfer",
+	HandlerType: (*SnapshotTransferServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Fetch",
+			Handler:       _SnapshotTransfer_Fetch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "snapshot_transfer.proto",
+}
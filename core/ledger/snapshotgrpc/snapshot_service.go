@@ -108,6 +108,10 @@ func (s *SnapshotService) QueryPendings(ctx context.Context, signedRequest *pb.S
 }
 
 func (s *SnapshotService) checkACL(resName string, signatureHdr *cb.SignatureHeader, signedRequest *pb.SignedSnapshotRequest) error {
+	return checkACL(s.ACLProvider, resName, signatureHdr, signedRequest.Request, signedRequest.Signature)
+}
+
+func checkACL(aclProvider ACLProvider, resName string, signatureHdr *cb.SignatureHeader, request, signature []byte) error {
 	if signatureHdr == nil {
 		return errors.New("missing signature header")
 	}
@@ -117,12 +121,12 @@ func (s *SnapshotService) checkACL(resName string, signatureHdr *cb.SignatureHea
 		return errors.New("client identity expired")
 	}
 
-	if err := s.ACLProvider.CheckACLNoChannel(
+	if err := aclProvider.CheckACLNoChannel(
 		resName,
 		[]*protoutil.SignedData{{
 			Identity:  signatureHdr.Creator,
-			Data:      signedRequest.Request,
-			Signature: signedRequest.Signature,
+			Data:      request,
+			Signature: signature,
 		}},
 	); err != nil {
 		return err
@@ -0,0 +1,163 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/msgs"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("snapshotgrpc")
+
+// snapshotFileChunkSize is the maximum size of the data sent in a SnapshotFileChunk
+const snapshotFileChunkSize = 1024 * 1024
+
+// TransferService implements the SnapshotTransferServer grpc interface. It streams
+// the snapshots generated by the peer to the peers of its organization, so that they
+// can join channels from these snapshots.
+type TransferService struct {
+	// SnapshotsRootDir is the root directory of the snapshots generated by the peer
+	SnapshotsRootDir string
+	HashProvider     ledger.HashProvider
+	ACLProvider      ACLProvider
+}
+
+// Fetch streams the files of the snapshot requested.
+func (s *TransferService) Fetch(signedRequest *msgs.SignedFetchSnapshotRequest, stream msgs.SnapshotTransfer_FetchServer) error {
+	request := &msgs.FetchSnapshotRequest{}
+	if err := proto.Unmarshal(signedRequest.Request, request); err != nil {
+		return errors.Wrap(err, "failed to unmarshal fetch snapshot request")
+	}
+
+	if err := checkACL(s.ACLProvider, resources.Snapshot_fetch, request.SignatureHeader, signedRequest.Request, signedRequest.Signature); err != nil {
+		return err
+	}
+
+	if request.ChannelId == "" {
+		return errors.New("missing channel ID")
+	}
+	if len(request.SnapshotHash) == 0 {
+		return errors.New("missing snapshot hash")
+	}
+
+	snapshotDir, err := s.findSnapshot(request.ChannelId, request.SnapshotHash)
+	if err != nil {
+		return err
+	}
+
+	// the snapshot is not removed as per the snapshot policy for the ledger while it is sent
+	release := kvledger.AcquireSnapshot(snapshotDir)
+	defer release()
+
+	logger.Infow("Sending snapshot", "channel", request.ChannelId, "snapshotDir", snapshotDir)
+	return sendSnapshotFiles(snapshotDir, stream)
+}
+
+// findSnapshot returns the directory of the snapshot of the channel whose signable
+// metadata file has the given hash
+func (s *TransferService) findSnapshot(channelID string, snapshotHash []byte) (string, error) {
+	snapshotsDir := kvledger.SnapshotsDirForLedger(s.SnapshotsRootDir, channelID)
+	entries, err := ioutil.ReadDir(snapshotsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", errors.Wrapf(err, "failed to list the snapshots of channel %s", channelID)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(snapshotsDir, entry.Name())
+		hash, err := signableMetadataHash(dir, s.HashProvider)
+		if err != nil {
+			logger.Debugw("Skipping snapshot", "snapshotDir", dir, "error", err)
+			continue
+		}
+		if bytes.Equal(hash, snapshotHash) {
+			return dir, nil
+		}
+	}
+
+	return "", errors.Errorf("cannot find snapshot of channel %s with hash %x", channelID, snapshotHash)
+}
+
+func sendSnapshotFiles(snapshotDir string, stream msgs.SnapshotTransfer_FetchServer) error {
+	entries, err := ioutil.ReadDir(snapshotDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the files of snapshot %s", snapshotDir)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		if err := sendSnapshotFile(filepath.Join(snapshotDir, entry.Name()), stream); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sendSnapshotFile(filePath string, stream msgs.SnapshotTransfer_FetchServer) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open snapshot file %s", filePath)
+	}
+	defer f.Close()
+
+	fileName := filepath.Base(filePath)
+	buf := make([]byte, snapshotFileChunkSize)
+	sent := false
+	for {
+		n, err := io.ReadFull(f, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return errors.Wrapf(err, "failed to read snapshot file %s", filePath)
+		}
+		// an empty file is sent as a single empty chunk
+		if n > 0 || !sent {
+			if err := stream.Send(&msgs.SnapshotFileChunk{FileName: fileName, Data: buf[:n]}); err != nil {
+				return errors.Wrapf(err, "failed to send snapshot file %s", fileName)
+			}
+			sent = true
+		}
+		if n < len(buf) {
+			return nil
+		}
+	}
+}
+
+// signableMetadataHash returns the hash of the signable metadata file of the
+// snapshot, which identifies the snapshot
+func signableMetadataHash(snapshotDir string, hashProvider ledger.HashProvider) ([]byte, error) {
+	signableMetadata, err := ioutil.ReadFile(filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName))
+	if err != nil {
+		return nil, err
+	}
+	hash, err := hashProvider.GetHash(&bccsp.SHA256Opts{})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := hash.Write(signableMetadata); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
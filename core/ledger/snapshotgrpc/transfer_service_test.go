@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshotgrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/mock"
+	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc/msgs"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/internal/pkg/identity/mocks"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type orgMembership []string
+
+func (o orgMembership) OrgPeerEndpoints() []string {
+	return o
+}
+
+func TestTransfer(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshotgrpc")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	snapshotsRootDir := filepath.Join(testDir, "snapshots")
+	snapshotFiles := map[string][]byte{
+		"large.data": bytes.Repeat([]byte("0123456789"), snapshotFileChunkSize/4),
+		"empty.data": {},
+		"small.data": []byte("small"),
+	}
+	snapshotHash := createSnapshot(t, snapshotsRootDir, "mychannel", 10, snapshotFiles)
+	otherSnapshotHash := createSnapshot(t, snapshotsRootDir, "mychannel", 20, map[string][]byte{"other.data": []byte("other")})
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	fakeACLProvider := &mock.ACLProvider{}
+	transferSvc := &TransferService{
+		SnapshotsRootDir: snapshotsRootDir,
+		HashProvider:     cryptoProvider,
+		ACLProvider:      fakeACLProvider,
+	}
+
+	server, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	require.NoError(t, err)
+	msgs.RegisterSnapshotTransferServer(server.Server(), transferSvc)
+	go server.Start()
+	defer server.Stop()
+
+	signer := &mocks.SignerSerializer{}
+	signer.SerializeReturns([]byte("creator"), nil)
+	signer.SignReturns([]byte("signature"), nil)
+
+	fetcher := &Fetcher{
+		Signer:        signer,
+		HashProvider:  cryptoProvider,
+		OrgMembership: orgMembership{"peer1:7051", server.Address()},
+		DialOptions: func() []grpc.DialOption {
+			return []grpc.DialOption{grpc.WithInsecure()}
+		},
+	}
+
+	fetch := func(channelID string, snapshotHash []byte) (string, error) {
+		snapshotDir, err := ioutil.TempDir(testDir, "fetched")
+		require.NoError(t, err)
+		return snapshotDir, fetcher.Fetch(context.Background(), server.Address(), channelID, snapshotHash, snapshotDir)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		snapshotDir, err := fetch("mychannel", snapshotHash)
+		require.NoError(t, err)
+
+		fetchedFiles, err := ioutil.ReadDir(snapshotDir)
+		require.NoError(t, err)
+		require.Len(t, fetchedFiles, len(snapshotFiles)+1)
+		for name, data := range snapshotFiles {
+			fetched, err := ioutil.ReadFile(filepath.Join(snapshotDir, name))
+			require.NoError(t, err)
+			require.Equal(t, data, fetched)
+		}
+
+		require.Equal(t, 1, fakeACLProvider.CheckACLNoChannelCallCount())
+		resName, signedData := fakeACLProvider.CheckACLNoChannelArgsForCall(0)
+		require.Equal(t, "snapshot/fetch", resName)
+		require.Equal(t, []byte("creator"), signedData.([]*protoutil.SignedData)[0].Identity)
+		require.Equal(t, []byte("signature"), signedData.([]*protoutil.SignedData)[0].Signature)
+
+		snapshotDir, err = fetch("mychannel", otherSnapshotHash)
+		require.NoError(t, err)
+		fetched, err := ioutil.ReadFile(filepath.Join(snapshotDir, "other.data"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("other"), fetched)
+	})
+
+	t.Run("UnknownPeer", func(t *testing.T) {
+		err := fetcher.Fetch(context.Background(), "peer2:7051", "mychannel", snapshotHash, testDir)
+		require.EqualError(t, err, "peer peer2:7051 is not an alive peer of the organization")
+	})
+
+	t.Run("UnknownSnapshot", func(t *testing.T) {
+		_, err := fetch("mychannel", []byte("unknown"))
+		require.EqualError(t, err, fmt.Sprintf("failed to fetch snapshot from peer %s: failed to receive snapshot file: rpc error: code = Unknown desc = cannot find snapshot of channel mychannel with hash 756e6b6e6f776e", server.Address()))
+
+		_, err = fetch("otherchannel", snapshotHash)
+		require.EqualError(t, err, fmt.Sprintf("failed to fetch snapshot from peer %s: failed to receive snapshot file: rpc error: code = Unknown desc = cannot find snapshot of channel otherchannel with hash %x", server.Address(), snapshotHash))
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		_, err := fetch("", snapshotHash)
+		require.Contains(t, err.Error(), "desc = missing channel ID")
+
+		_, err = fetch("mychannel", nil)
+		require.Contains(t, err.Error(), "desc = missing snapshot hash")
+	})
+
+	t.Run("AccessDenied", func(t *testing.T) {
+		fakeACLProvider.CheckACLNoChannelReturns(fmt.Errorf("fake-check-acl-error"))
+		defer fakeACLProvider.CheckACLNoChannelReturns(nil)
+
+		_, err := fetch("mychannel", snapshotHash)
+		require.Contains(t, err.Error(), "desc = fake-check-acl-error")
+	})
+}
+
+func TestVerifyFetchedSnapshot(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "snapshotgrpc")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	fetcher := &Fetcher{HashProvider: cryptoProvider}
+
+	snapshotHash := createSnapshot(t, testDir, "mychannel", 10, nil)
+	snapshotDir := kvledger.SnapshotDirForLedgerBlockNum(testDir, "mychannel", 10)
+
+	require.NoError(t, fetcher.verifySnapshot(snapshotDir, "mychannel", snapshotHash))
+
+	err = fetcher.verifySnapshot(snapshotDir, "mychannel", []byte("other"))
+	require.EqualError(t, err, fmt.Sprintf("hash mismatch for the fetched snapshot. Expected hash = [6f74686572], Actual hash = [%x]", snapshotHash))
+
+	err = fetcher.verifySnapshot(snapshotDir, "otherchannel", snapshotHash)
+	require.EqualError(t, err, "the fetched snapshot belongs to channel mychannel instead of channel otherchannel")
+
+	err = fetcher.verifySnapshot(testDir, "mychannel", snapshotHash)
+	require.Contains(t, err.Error(), "failed to compute the hash of the fetched snapshot")
+}
+
+type fakeFetchClient struct {
+	msgs.SnapshotTransfer_FetchClient
+	chunks []*msgs.SnapshotFileChunk
+}
+
+func (f *fakeFetchClient) Recv() (*msgs.SnapshotFileChunk, error) {
+	if len(f.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := f.chunks[0]
+	f.chunks = f.chunks[1:]
+	return chunk, nil
+}
+
+func TestReceiveSnapshotFilesErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		chunks []*msgs.SnapshotFileChunk
+		errMsg string
+	}{
+		{
+			name:   "empty file name",
+			chunks: []*msgs.SnapshotFileChunk{{FileName: ""}},
+			errMsg: `invalid snapshot file name ""`,
+		},
+		{
+			name:   "parent dir",
+			chunks: []*msgs.SnapshotFileChunk{{FileName: ".."}},
+			errMsg: `invalid snapshot file name ".."`,
+		},
+		{
+			name:   "path",
+			chunks: []*msgs.SnapshotFileChunk{{FileName: "../escape"}},
+			errMsg: `invalid snapshot file name "../escape"`,
+		},
+		{
+			name: "file received twice",
+			chunks: []*msgs.SnapshotFileChunk{
+				{FileName: "a", Data: []byte("a")},
+				{FileName: "b", Data: []byte("b")},
+				{FileName: "a", Data: []byte("a")},
+			},
+			errMsg: "snapshot file a received more than once",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshotDir, err := ioutil.TempDir("", "snapshotgrpc")
+			require.NoError(t, err)
+			defer os.RemoveAll(snapshotDir)
+
+			err = receiveSnapshotFiles(&fakeFetchClient{chunks: test.chunks}, snapshotDir)
+			require.EqualError(t, err, test.errMsg)
+		})
+	}
+}
+
+// createSnapshot creates a snapshot with the given files in the completed snapshots
+// dir of the channel, and returns the hash of its signable metadata file
+func createSnapshot(t *testing.T, snapshotsRootDir, channelID string, blockNumber uint64, files map[string][]byte) []byte {
+	snapshotDir := kvledger.SnapshotDirForLedgerBlockNum(snapshotsRootDir, channelID, blockNumber)
+	require.NoError(t, os.MkdirAll(snapshotDir, 0o755))
+
+	filesAndHashes := map[string]string{}
+	for name, data := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, name), data, 0o644))
+		filesAndHashes[name] = fmt.Sprintf("%x", sha256.Sum256(data))
+	}
+	signableMetadata, err := json.Marshal(&kvledger.SnapshotSignableMetadata{
+		ChannelName:     channelID,
+		LastBlockNumber: blockNumber,
+		FilesAndHashes:  filesAndHashes,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName), signableMetadata, 0o444))
+
+	hash := sha256.Sum256(signableMetadata)
+	return hash[:]
+}
@@ -79,6 +79,12 @@ type Config struct {
 	// hardware threads on the machine.
 	ValidatorPoolSize int
 
+	// CredentialsReloadInterval sets how often the peer checks whether its
+	// enrollment and TLS credentials have been replaced on the file system,
+	// to use the new ones without being restarted. If 0, they are only
+	// loaded at startup.
+	CredentialsReloadInterval time.Duration
+
 	// ----- Peer Delivery Client Keepalive -----
 	// DeliveryClient Keepalive settings for communication with ordering nodes.
 	DeliverClientKeepaliveOptions comm.KeepaliveOptions
@@ -111,6 +117,9 @@ type Config struct {
 	// DiscoveryAuthCachePurgeRetentionRatio set the proportion of entries remains in cache
 	// after overpopulation purge.
 	DiscoveryAuthCachePurgeRetentionRatio float64
+	// DiscoveryWatchInterval sets how often the discovery service checks for
+	// changes of what clients watch.
+	DiscoveryWatchInterval time.Duration
 
 	// ----- Limits -----
 	// Limits is used to configure some internal resource limits.
@@ -258,9 +267,12 @@ func (c *Config) load() error {
 	c.DiscoveryAuthCacheEnabled = viper.GetBool("peer.discovery.authCacheEnabled")
 	c.DiscoveryAuthCacheMaxSize = viper.GetInt("peer.discovery.authCacheMaxSize")
 	c.DiscoveryAuthCachePurgeRetentionRatio = viper.GetFloat64("peer.discovery.authCachePurgeRetentionRatio")
+	c.DiscoveryWatchInterval = viper.GetDuration("peer.discovery.watchInterval")
 	c.ChaincodeListenAddress = viper.GetString("peer.chaincodeListenAddress")
 	c.ChaincodeAddress = viper.GetString("peer.chaincodeAddress")
 
+	c.CredentialsReloadInterval = viper.GetDuration("peer.credentialsReloadInterval")
+
 	c.ValidatorPoolSize = viper.GetInt("peer.validatorPoolSize")
 	if c.ValidatorPoolSize <= 0 {
 		c.ValidatorPoolSize = runtime.NumCPU()
@@ -272,9 +272,11 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.discovery.authCacheEnabled", true)
 	viper.Set("peer.discovery.authCacheMaxSize", 1000)
 	viper.Set("peer.discovery.authCachePurgeRetentionRatio", 0.75)
+	viper.Set("peer.discovery.watchInterval", 2*time.Second)
 	viper.Set("peer.chaincodeListenAddress", "0.0.0.0:7052")
 	viper.Set("peer.chaincodeAddress", "0.0.0.0:7052")
 	viper.Set("peer.validatorPoolSize", 1)
+	viper.Set("peer.credentialsReloadInterval", time.Minute)
 	viper.Set("peer.gateway.enabled", true)
 	viper.Set("peer.gateway.endorsementTimeout", 10*time.Second)
 	viper.Set("peer.gateway.dialTimeout", 60*time.Second)
@@ -332,9 +334,11 @@ func TestGlobalConfig(t *testing.T) {
 		DiscoveryAuthCacheEnabled:             true,
 		DiscoveryAuthCacheMaxSize:             1000,
 		DiscoveryAuthCachePurgeRetentionRatio: 0.75,
+		DiscoveryWatchInterval:                2 * time.Second,
 		ChaincodeListenAddress:                "0.0.0.0:7052",
 		ChaincodeAddress:                      "0.0.0.0:7052",
 		ValidatorPoolSize:                     1,
+		CredentialsReloadInterval:             time.Minute,
 		DeliverClientKeepaliveOptions:         comm.DefaultKeepaliveOptions,
 
 		VMEndpoint:           "unix:///var/run/docker.sock",
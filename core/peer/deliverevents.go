@@ -374,12 +374,15 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 			return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
 		}
 
-		ccEvent, err := protoutil.UnmarshalChaincodeEvents(caPayload.Events)
+		ccEvents, err := protoutil.UnmarshalChaincodeEventList(caPayload.Events)
 		if err != nil {
 			return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
 		}
 
-		if ccEvent.GetChaincodeId() != "" {
+		for _, ccEvent := range ccEvents {
+			if ccEvent.GetChaincodeId() == "" {
+				continue
+			}
 			filteredAction := &peer.FilteredChaincodeAction{
 				ChaincodeEvent: &peer.ChaincodeEvent{
 					TxId:        ccEvent.TxId,
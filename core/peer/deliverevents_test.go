@@ -276,6 +276,82 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 				Assertions: require.New(t),
 			}),
 		},
+		{
+			name: "Testing deliver of the filtered block events with multiple chaincode events",
+			prepare: func(config testConfig) func(wg *sync.WaitGroup) (deliver.ChainManager, peer.Deliver_DeliverServer) {
+				return func(wg *sync.WaitGroup) (deliver.ChainManager, peer.Deliver_DeliverServer) {
+					wg.Add(2)
+					p := &peer2.Peer{}
+					chaincodeActionPayload, err := createChaincodeActionWithEvents(config.chaincodeName,
+						&peer.ChaincodeEvent{ChaincodeId: config.chaincodeName, EventName: "firstEvent", TxId: config.txID},
+						&peer.ChaincodeEvent{ChaincodeId: "othercc", EventName: "spoofedEvent", TxId: config.txID},
+						&peer.ChaincodeEvent{ChaincodeId: config.chaincodeName, EventName: config.eventName, TxId: config.txID},
+					)
+					config.NoError(err)
+
+					chainManager := createDefaultSupportMamangerMock(config, chaincodeActionPayload, nil)
+					// setup mock deliver server
+					deliverServer := &mockDeliverServer{}
+					deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+
+					deliverServer.On("Recv").Return(&common.Envelope{
+						Payload: protoutil.MarshalOrPanic(config.payload),
+					}, nil).Run(func(_ mock.Arguments) {
+						deliverServer.Mock = mock.Mock{}
+						deliverServer.On("Context").Return(peer2.NewContext(context.TODO(), p))
+						deliverServer.On("Recv").Return(&common.Envelope{}, io.EOF)
+						deliverServer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+							defer wg.Done()
+							response := args.Get(0).(*peer.DeliverResponse)
+							switch response.Type.(type) {
+							case *peer.DeliverResponse_Status:
+								config.Equal(common.Status_SUCCESS, response.GetStatus())
+							case *peer.DeliverResponse_FilteredBlock:
+								block := response.GetFilteredBlock()
+								config.Equal(1, len(block.FilteredTransactions))
+								tx := block.FilteredTransactions[0]
+								config.Equal(config.txID, tx.Txid)
+								transactionActions := tx.GetTransactionActions()
+								config.NotNil(transactionActions)
+								chaincodeActions := transactionActions.ChaincodeActions
+								// events of other chaincodes are not delivered
+								config.Equal(2, len(chaincodeActions))
+								config.Equal("firstEvent", chaincodeActions[0].ChaincodeEvent.EventName)
+								config.Equal(config.eventName, chaincodeActions[1].ChaincodeEvent.EventName)
+								for _, action := range chaincodeActions {
+									config.Equal(config.txID, action.ChaincodeEvent.TxId)
+									config.Equal(config.chaincodeName, action.ChaincodeEvent.ChaincodeId)
+								}
+							default:
+								config.FailNow("Unexpected response type")
+							}
+						}).Return(nil)
+					})
+
+					return chainManager, deliverServer
+				}
+			}(testConfig{
+				channelID:     "testChannelID",
+				eventName:     "testEvent",
+				chaincodeName: "mycc",
+				txID:          "testID",
+				payload: &common.Payload{
+					Header: &common.Header{
+						ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+							ChannelId: "testChannelID",
+							Timestamp: util.CreateUtcTimestamp(),
+						}),
+						SignatureHeader: protoutil.MarshalOrPanic(&common.SignatureHeader{}),
+					},
+					Data: protoutil.MarshalOrPanic(&orderer.SeekInfo{
+						Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+						Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+						Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+					}),
+				},
+				Assertions: require.New(t),
+			}),
+		},
 		{
 			name: "Testing deliver of the filtered block events with nil chaincode action payload",
 			prepare: func(config testConfig) func(wg *sync.WaitGroup) (deliver.ChainManager, peer.Deliver_DeliverServer) {
@@ -657,12 +733,20 @@ func createEndorsement(channelID string, txID string, chaincodeActionPayload *pe
 }
 
 func createChaincodeAction(chaincodeName string, eventName string, txID string) (*peer.ChaincodeActionPayload, error) {
-	// chaincode events
-	eventsBytes, err := proto.Marshal(&peer.ChaincodeEvent{
+	return createChaincodeActionWithEvents(chaincodeName, &peer.ChaincodeEvent{
 		ChaincodeId: chaincodeName,
 		EventName:   eventName,
 		TxId:        txID,
 	})
+}
+
+func createChaincodeActionWithEvents(chaincodeName string, events ...*peer.ChaincodeEvent) (*peer.ChaincodeActionPayload, error) {
+	// chaincode events
+	event, err := protoutil.NewChaincodeEventList(events)
+	if err != nil {
+		return nil, err
+	}
+	eventsBytes, err := proto.Marshal(event)
 	if err != nil {
 		return nil, err
 	}
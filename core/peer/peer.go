@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
@@ -141,6 +143,18 @@ func (flbs fileLedgerBlockStore) RetrieveBlocks(startBlockNumber uint64) (common
 
 func (flbs fileLedgerBlockStore) Shutdown() {}
 
+// DefaultSnapshotFetchTimeout is the time to fetch a snapshot from another peer
+// after which the fetch is aborted, unless Peer.SnapshotFetchTimeout is set.
+const DefaultSnapshotFetchTimeout = time.Hour
+
+// SnapshotFetcher fetches snapshots from other peers of the organization
+type SnapshotFetcher interface {
+	// CheckPeer returns an error if snapshots cannot be fetched from the given peer
+	CheckPeer(peerEndpoint string) error
+	// Fetch fetches the snapshot of a channel with the given hash from a peer into snapshotDir
+	Fetch(ctx context.Context, peerEndpoint, channelID string, snapshotHash []byte, snapshotDir string) error
+}
+
 // A Peer holds references to subsystems and channels associated with a Fabric peer.
 type Peer struct {
 	ServerConfig             comm.ServerConfig
@@ -150,6 +164,10 @@ type Peer struct {
 	LedgerMgr                *ledgermgmt.LedgerMgr
 	OrdererEndpointOverrides map[string]*orderers.Endpoint
 	CryptoProvider           bccsp.BCCSP
+	SnapshotFetcher          SnapshotFetcher
+	// SnapshotFetchTimeout bounds the time to fetch a snapshot from another peer.
+	// DefaultSnapshotFetchTimeout is used when it is not set.
+	SnapshotFetchTimeout time.Duration
 
 	// validationWorkersSemaphore is used to limit the number of concurrent validation
 	// go routines.
@@ -216,6 +234,51 @@ func (p *Peer) CreateChannelFromSnapshot(
 	return nil
 }
 
+// CreateChannelFromPeerSnapshot creates a channel from a snapshot that is fetched from another
+// peer of the organization. The snapshot is identified by the hash of its signable metadata.
+func (p *Peer) CreateChannelFromPeerSnapshot(
+	peerEndpoint string,
+	channelID string,
+	snapshotHash []byte,
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
+	legacyLifecycleValidation plugindispatcher.LifecycleResources,
+	newLifecycleValidation plugindispatcher.CollectionAndLifecycleResources,
+) error {
+	if p.SnapshotFetcher == nil {
+		return errors.New("fetching snapshots from peers is not supported")
+	}
+	if err := p.SnapshotFetcher.CheckPeer(peerEndpoint); err != nil {
+		return errors.WithMessagef(err, "cannot fetch snapshot from peer %s", peerEndpoint)
+	}
+
+	timeout := p.SnapshotFetchTimeout
+	if timeout <= 0 {
+		timeout = DefaultSnapshotFetchTimeout
+	}
+	fetch := func(snapshotDir string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := p.SnapshotFetcher.Fetch(ctx, peerEndpoint, channelID, snapshotHash, snapshotDir); err != nil {
+			return errors.WithMessagef(err, "failed to fetch snapshot from peer %s", peerEndpoint)
+		}
+		return nil
+	}
+	channelCallback := func(l ledger.PeerLedger, cid string) {
+		if err := p.createChannel(cid, l, deployedCCInfoProvider, legacyLifecycleValidation, newLifecycleValidation); err != nil {
+			logger.Errorf("error creating channel for %s", cid)
+			return
+		}
+		p.initChannel(cid)
+	}
+
+	err := p.LedgerMgr.CreateLedgerFromFetchedSnapshot(fetch, channelCallback)
+	if err != nil {
+		return errors.WithMessagef(err, "cannot create ledger from snapshot of peer %s", peerEndpoint)
+	}
+
+	return nil
+}
+
 // RetrievePersistedChannelConfig retrieves the persisted channel config from statedb
 func RetrievePersistedChannelConfig(ledger ledger.PeerLedger) (*common.Config, error) {
 	qe, err := ledger.NewQueryExecutor()
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -21,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/sw"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/committer/txvalidator/plugin"
 	"github.com/hyperledger/fabric/core/deliverservice"
@@ -28,7 +31,9 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/msgs"
 	ledgermocks "github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/core/peer/mock"
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/gossip"
 	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
@@ -39,6 +44,7 @@ import (
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 )
@@ -284,6 +290,105 @@ func TestCreateChannelBySnapshot(t *testing.T) {
 	require.Equal(t, 1, len(channels))
 }
 
+//go:generate counterfeiter -o mock/snapshot_fetcher.go -fake-name SnapshotFetcher . snapshotFetcher
+
+type snapshotFetcher interface {
+	SnapshotFetcher
+}
+
+func TestCreateChannelFromPeerSnapshot(t *testing.T) {
+	peerInstance, cleanup := NewTestPeer(t)
+	defer cleanup()
+
+	var initArg string
+	waitCh := make(chan struct{})
+	peerInstance.Initialize(
+		func(cid string) {
+			<-waitCh
+			initArg = cid
+		},
+		nil,
+		plugin.MapBasedMapper(map[string]validation.PluginFactory{}),
+		&ledgermocks.DeployedChaincodeInfoProvider{},
+		nil,
+		nil,
+		runtime.NumCPU(),
+	)
+
+	testChannelID := "createchannelfrompeersnapshot"
+
+	tempdir, err := ioutil.TempDir("", testChannelID)
+	require.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+
+	snapshotDir := ledgermgmttest.CreateSnapshotWithGenesisBlock(t, tempdir, testChannelID, &ConfigTxProcessor{})
+
+	t.Run("NotSupported", func(t *testing.T) {
+		err := peerInstance.CreateChannelFromPeerSnapshot("peer1:7051", testChannelID, []byte("hash"), &ledgermocks.DeployedChaincodeInfoProvider{}, nil, nil)
+		require.EqualError(t, err, "fetching snapshots from peers is not supported")
+	})
+
+	fakeSnapshotFetcher := &mock.SnapshotFetcher{}
+	peerInstance.SnapshotFetcher = fakeSnapshotFetcher
+
+	t.Run("UnknownPeer", func(t *testing.T) {
+		fakeSnapshotFetcher.CheckPeerReturns(errors.New("unknown-peer"))
+		defer fakeSnapshotFetcher.CheckPeerReturns(nil)
+
+		err := peerInstance.CreateChannelFromPeerSnapshot("peer1:7051", testChannelID, []byte("hash"), &ledgermocks.DeployedChaincodeInfoProvider{}, nil, nil)
+		require.EqualError(t, err, "cannot fetch snapshot from peer peer1:7051: unknown-peer")
+		require.False(t, peerInstance.JoinBySnaphotStatus().InProgress)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		fakeSnapshotFetcher.FetchStub = func(ctx context.Context, peerEndpoint, channelID string, snapshotHash []byte, dir string) error {
+			return testutil.CopyDir(snapshotDir, dir, true)
+		}
+
+		err := peerInstance.CreateChannelFromPeerSnapshot("peer1:7051", testChannelID, []byte("hash"), &ledgermocks.DeployedChaincodeInfoProvider{}, nil, nil)
+		require.NoError(t, err)
+		require.True(t, peerInstance.JoinBySnaphotStatus().InProgress)
+
+		// write a msg to waitCh to unblock channel init func
+		waitCh <- struct{}{}
+
+		ledgerCreationDone := func() bool {
+			return !peerInstance.JoinBySnaphotStatus().InProgress
+		}
+		require.Eventually(t, ledgerCreationDone, time.Minute, time.Second)
+
+		require.Equal(t, testChannelID, initArg)
+		require.NotNil(t, peerInstance.GetLedger(testChannelID))
+
+		require.Equal(t, 1, fakeSnapshotFetcher.FetchCallCount())
+		_, peerEndpoint, channelID, snapshotHash, _ := fakeSnapshotFetcher.FetchArgsForCall(0)
+		require.Equal(t, "peer1:7051", peerEndpoint)
+		require.Equal(t, testChannelID, channelID)
+		require.Equal(t, []byte("hash"), snapshotHash)
+	})
+
+	t.Run("FetchTimeout", func(t *testing.T) {
+		peerInstance.SnapshotFetchTimeout = 10 * time.Millisecond
+		defer func() { peerInstance.SnapshotFetchTimeout = 0 }()
+		fakeSnapshotFetcher.FetchStub = func(ctx context.Context, peerEndpoint, channelID string, snapshotHash []byte, dir string) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		err := peerInstance.CreateChannelFromPeerSnapshot("peer1:7051", "otherchannel", []byte("hash"), &ledgermocks.DeployedChaincodeInfoProvider{}, nil, nil)
+		require.NoError(t, err)
+
+		ledgerCreationDone := func() bool {
+			return !peerInstance.JoinBySnaphotStatus().InProgress
+		}
+		require.Eventually(t, ledgerCreationDone, time.Minute, 10*time.Millisecond)
+
+		status := &msgs.JoinBySnapshotStatus{}
+		require.NoError(t, protoutil.ConvertMessage(peerInstance.JoinBySnaphotStatus(), status))
+		require.Equal(t, "error fetching snapshot: failed to fetch snapshot from peer peer1:7051: context deadline exceeded", status.LastError)
+	})
+}
+
 func TestDeliverSupportManager(t *testing.T) {
 	peerInstance, cleanup := NewTestPeer(t)
 	defer cleanup()
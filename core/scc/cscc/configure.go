@@ -70,12 +70,13 @@ var cnflogger = flogging.MustGetLogger("cscc")
 
 // These are function names from Invoke first parameter
 const (
-	JoinChain            string = "JoinChain"
-	JoinChainBySnapshot  string = "JoinChainBySnapshot"
-	JoinBySnapshotStatus string = "JoinBySnapshotStatus"
-	GetConfigBlock       string = "GetConfigBlock"
-	GetChannelConfig     string = "GetChannelConfig"
-	GetChannels          string = "GetChannels"
+	JoinChain                   string = "JoinChain"
+	JoinChainBySnapshot         string = "JoinChainBySnapshot"
+	JoinChainBySnapshotFromPeer string = "JoinChainBySnapshotFromPeer"
+	JoinBySnapshotStatus        string = "JoinBySnapshotStatus"
+	GetConfigBlock              string = "GetConfigBlock"
+	GetChannelConfig            string = "GetChannelConfig"
+	GetChannels                 string = "GetChannels"
 )
 
 // Init is mostly useless from an SCC perspective
@@ -107,6 +108,10 @@ func (e *PeerConfiger) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
 	}
 
+	if fname == JoinChainBySnapshotFromPeer && len(args) < 4 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+	}
+
 	cnflogger.Debugf("Invoke function: %s", fname)
 
 	// Handle ACL:
@@ -180,6 +185,22 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 		}
 		snapshotDir := string(args[1])
 		return e.JoinChainBySnapshot(snapshotDir, e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
+	case JoinChainBySnapshotFromPeer:
+		// args[1] is the channel ID, args[2] the hash of the snapshot and args[3] the endpoint of the peer
+		if len(args[1]) == 0 {
+			return shim.Error("Cannot join the channel, no channel ID provided")
+		}
+		if len(args[2]) == 0 {
+			return shim.Error("Cannot join the channel, no snapshot hash provided")
+		}
+		if len(args[3]) == 0 {
+			return shim.Error("Cannot join the channel, no peer endpoint provided")
+		}
+		// check policy
+		if err = e.aclProvider.CheckACL(resources.Cscc_JoinChainBySnapshot, "", sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s]: [%s]", fname, err))
+		}
+		return e.JoinChainBySnapshotFromPeer(string(args[3]), string(args[1]), args[2], e.deployedCCInfoProvider, e.legacyLifecycle, e.newLifecycle)
 	case JoinBySnapshotStatus:
 		if err = e.aclProvider.CheckACL(resources.Cscc_JoinBySnapshotStatus, "", sp); err != nil {
 			return shim.Error(fmt.Sprintf("access denied for [%s]: %s", fname, err))
@@ -282,6 +303,23 @@ func (e *PeerConfiger) JoinChainBySnapshot(
 	return shim.Success(nil)
 }
 
+// JoinChainBySnapshotFromPeer will join the channel by the snapshot with the specified
+// hash, which is fetched from the specified peer of the organization.
+func (e *PeerConfiger) JoinChainBySnapshotFromPeer(
+	peerEndpoint string,
+	channelID string,
+	snapshotHash []byte,
+	deployedCCInfoProvider ledger.DeployedChaincodeInfoProvider,
+	lr plugindispatcher.LifecycleResources,
+	nr plugindispatcher.CollectionAndLifecycleResources,
+) pb.Response {
+	if err := e.peer.CreateChannelFromPeerSnapshot(peerEndpoint, channelID, snapshotHash, deployedCCInfoProvider, lr, nr); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
 // Return the current configuration block for the specified channelID. If the
 // peer doesn't belong to the channel, return error
 func (e *PeerConfiger) getConfigBlock(channelID []byte) pb.Response {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package cscc
 
 import (
+	"context"
 	"errors"
 	"io/ioutil"
 	"net"
@@ -21,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/sw"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/genesis"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/aclmgmt"
@@ -31,6 +33,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
 	"github.com/hyperledger/fabric/core/peer"
+	peermock "github.com/hyperledger/fabric/core/peer/mock"
 	"github.com/hyperledger/fabric/core/scc/cscc/mocks"
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/gossip"
@@ -440,6 +443,115 @@ func TestConfigerInvokeJoinChainBySnapshot(t *testing.T) {
 	require.Contains(t, res.Message, "access denied for [JoinChainBySnapshot]")
 }
 
+func TestConfigerInvokeJoinChainBySnapshotFromPeer(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "cscc_test_bysnapshotfrompeer")
+	require.NoError(t, err, "error in creating test dir")
+	defer os.RemoveAll(testDir)
+
+	ledgerInitializer := ledgermgmttest.NewInitializer(testDir)
+	ledgerInitializer.CustomTxProcessors = map[cb.HeaderType]ledger.CustomTxProcessor{
+		cb.HeaderType_CONFIG: &peer.ConfigTxProcessor{},
+	}
+	ledgerMgr := ledgermgmt.NewLedgerMgr(ledgerInitializer)
+	defer ledgerMgr.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+
+	cscc := newPeerConfiger(t, ledgerMgr, grpcServer, listener.Addr().String())
+
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	channelID := "testjoinchainbysnapshotfrompeer"
+	sProp := validSignedProposal()
+	sProp.Signature = sProp.ProposalBytes
+
+	// set mocked ACLProcider and Stub
+	mockACLProvider := cscc.aclProvider.(*mocks.ACLProvider)
+	mockStub := &mocks.ChaincodeStub{}
+	mockStub.GetSignedProposalReturns(sProp, nil)
+
+	// error path when the peer cannot fetch snapshots
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshotFromPeer"), []byte(channelID), []byte("hash"), []byte("peer1:7051")})
+	res := cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "fetching snapshots from peers is not supported", res.Message)
+
+	snapshotDir := ledgermgmttest.CreateSnapshotWithGenesisBlock(t, testDir, channelID, &peer.ConfigTxProcessor{})
+	fakeSnapshotFetcher := &peermock.SnapshotFetcher{}
+	fakeSnapshotFetcher.FetchStub = func(ctx context.Context, peerEndpoint, channelID string, snapshotHash []byte, dir string) error {
+		return testutil.CopyDir(snapshotDir, dir, true)
+	}
+	cscc.peer.SnapshotFetcher = fakeSnapshotFetcher
+
+	// successful path
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.OK), res.Status)
+
+	// wait until ledger creation is done
+	ledgerCreationDone := func() bool {
+		resp := cscc.joinBySnapshotStatus()
+		require.Equal(t, shim.OK, int(resp.Status))
+		status := &pb.JoinBySnapshotStatus{}
+		err := proto.Unmarshal(resp.Payload, status)
+		require.NoError(t, err)
+		return !status.InProgress
+	}
+	require.Eventually(t, ledgerCreationDone, time.Minute, time.Second)
+
+	require.Equal(t, 1, fakeSnapshotFetcher.CheckPeerCallCount())
+	require.Equal(t, "peer1:7051", fakeSnapshotFetcher.CheckPeerArgsForCall(0))
+	require.Equal(t, 1, fakeSnapshotFetcher.FetchCallCount())
+	_, peerEndpoint, fetchedChannelID, snapshotHash, _ := fakeSnapshotFetcher.FetchArgsForCall(0)
+	require.Equal(t, "peer1:7051", peerEndpoint)
+	require.Equal(t, channelID, fetchedChannelID)
+	require.Equal(t, []byte("hash"), snapshotHash)
+
+	// verify ledger is created
+	lgr := cscc.peer.GetLedger(channelID)
+	require.NotNil(t, lgr)
+	bcInfo, err := lgr.GetBlockchainInfo()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), bcInfo.Height)
+
+	// error path due to missing arguments
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshotFromPeer"), []byte(channelID), []byte("hash")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Incorrect number of arguments, 3", res.Message)
+
+	// error path due to empty arguments
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshotFromPeer"), []byte(""), []byte("hash"), []byte("peer1:7051")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Cannot join the channel, no channel ID provided", res.Message)
+
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshotFromPeer"), []byte(channelID), []byte(""), []byte("peer1:7051")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Cannot join the channel, no snapshot hash provided", res.Message)
+
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshotFromPeer"), []byte(channelID), []byte("hash"), []byte("")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "Cannot join the channel, no peer endpoint provided", res.Message)
+
+	// error path due to a peer which is not a peer of the organization
+	fakeSnapshotFetcher.CheckPeerReturns(errors.New("peer peer2:7051 is not an alive peer of the organization"))
+	mockStub.GetArgsReturns([][]byte{[]byte("JoinChainBySnapshotFromPeer"), []byte(channelID), []byte("hash"), []byte("peer2:7051")})
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "cannot fetch snapshot from peer peer2:7051: peer peer2:7051 is not an alive peer of the organization", res.Message)
+
+	// error path due to CheckACL error
+	mockACLProvider.CheckACLReturns(errors.New("Failed authorization"))
+	res = cscc.Invoke(mockStub)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Contains(t, res.Message, "access denied for [JoinChainBySnapshotFromPeer]")
+}
+
 func TestConfigerInvokeGetChannelConfig(t *testing.T) {
 	testDir, err := ioutil.TempDir("", "cscc_test_GetChannelConfig")
 	require.NoError(t, err)
@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/discovery"
 	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/discovery/msgs"
 	"github.com/hyperledger/fabric/discovery/protoext"
 	gprotoext "github.com/hyperledger/fabric/gossip/protoext"
 	"github.com/pkg/errors"
@@ -156,16 +157,9 @@ func (req *Request) addQueryMapping(queryType protoext.QueryType, key string) {
 
 // Send sends the request and returns the response, or error on failure
 func (c *Client) Send(ctx context.Context, req *Request, auth *discovery.AuthInfo) (Response, error) {
-	reqToBeSent := *req.Request
-	reqToBeSent.Authentication = auth
-	payload, err := proto.Marshal(&reqToBeSent)
+	signedReq, err := c.sign(req, auth)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed marshaling Request to bytes")
-	}
-
-	sig, err := c.signRequest(payload)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed signing Request")
+		return nil, err
 	}
 
 	conn, err := c.createConnection()
@@ -174,10 +168,7 @@ func (c *Client) Send(ctx context.Context, req *Request, auth *discovery.AuthInf
 	}
 
 	cl := discovery.NewDiscoveryClient(conn)
-	resp, err := cl.Discover(ctx, &discovery.SignedRequest{
-		Payload:   payload,
-		Signature: sig,
-	})
+	resp, err := cl.Discover(ctx, signedReq)
 	if err != nil {
 		return nil, errors.Wrap(err, "discovery service refused our Request")
 	}
@@ -187,6 +178,51 @@ func (c *Client) Send(ctx context.Context, req *Request, auth *discovery.AuthInf
 	return req.computeResponse(resp)
 }
 
+// Watch starts watching what the queries of the request watch, and returns the stream
+// of the updates of the view of the discovery service. Only peer membership queries and
+// config queries can be watched. Peers are updated when their ledger height advances by
+// at least ledgerHeightThreshold blocks, or not at all if ledgerHeightThreshold is 0.
+// The stream ends when the given context is canceled.
+func (c *Client) Watch(ctx context.Context, req *Request, auth *discovery.AuthInfo, ledgerHeightThreshold uint64) (msgs.DiscoveryWatch_WatchClient, error) {
+	signedReq, err := c.sign(req, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.createConnection()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed connecting to discovery service")
+	}
+
+	cl := msgs.NewDiscoveryWatchClient(conn)
+	stream, err := cl.Watch(ctx, &msgs.WatchRequest{
+		SignedRequest:         signedReq,
+		LedgerHeightThreshold: ledgerHeightThreshold,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "discovery service refused our watch Request")
+	}
+	return stream, nil
+}
+
+func (c *Client) sign(req *Request, auth *discovery.AuthInfo) (*discovery.SignedRequest, error) {
+	reqToBeSent := *req.Request
+	reqToBeSent.Authentication = auth
+	payload, err := proto.Marshal(&reqToBeSent)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling Request to bytes")
+	}
+
+	sig, err := c.signRequest(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed signing Request")
+	}
+	return &discovery.SignedRequest{
+		Payload:   payload,
+		Signature: sig,
+	}, nil
+}
+
 type resultOrError interface{}
 
 type response map[key]resultOrError
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	fabricdisc "github.com/hyperledger/fabric/discovery"
 	"github.com/hyperledger/fabric/discovery/endorsement"
+	"github.com/hyperledger/fabric/discovery/msgs"
 	"github.com/hyperledger/fabric/gossip/api"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	gdisc "github.com/hyperledger/fabric/gossip/discovery"
@@ -311,7 +312,7 @@ func createConnector(t *testing.T, certificate tls.Certificate, targetPort int)
 	}
 }
 
-func createDiscoveryService(sup *mockSupport) discovery.DiscoveryServer {
+func createDiscoveryService(sup *mockSupport) *fabricdisc.Service {
 	conf := fabricdisc.Config{TLS: true}
 	mdf := &ccMetadataFetcher{}
 	pe := &principalEvaluator{}
@@ -558,6 +559,65 @@ func TestClient(t *testing.T) {
 	})
 }
 
+func TestClientWatch(t *testing.T) {
+	clientCert := loadFileOrPanic(filepath.Join("testdata", "client", "cert.pem"))
+	clientKey := loadFileOrPanic(filepath.Join("testdata", "client", "key.pem"))
+	clientTLSCert, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	server := createGRPCServer(t)
+	sup := &mockSupport{}
+	service := createDiscoveryService(sup)
+	msgs.RegisterDiscoveryWatchServer(server.Server(), service)
+	go server.Start()
+	defer server.Stop()
+
+	_, portStr, _ := net.SplitHostPort(server.Address())
+	port, _ := strconv.ParseInt(portStr, 10, 64)
+	connect := createConnector(t, clientTLSCert, int(port))
+
+	signer := func(msg []byte) ([]byte, error) {
+		return msg, nil
+	}
+	authInfo := &discovery.AuthInfo{
+		ClientIdentity:    []byte{1, 2, 3},
+		ClientTlsCertHash: util.ComputeSHA256(clientTLSCert.Certificate[0]),
+	}
+	cl := NewClient(connect, signer, signerCacheSize)
+
+	sup.On("PeersOfChannel").Return(channelPeersWithChaincodes)
+	req := NewRequest()
+	req.OfChannel("mychannel").AddPeersQuery().AddConfigQuery()
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := cl.Watch(watchCtx, req, authInfo, 10)
+	require.NoError(t, err)
+
+	// The first response carries all the peers of the channel and its config
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.Updates, 9)
+	for _, update := range resp.Updates[:8] {
+		require.Equal(t, uint32(0), update.Query)
+		require.NotNil(t, update.GetPeerJoined())
+	}
+	require.Equal(t, uint32(1), resp.Updates[8].Query)
+	require.Equal(t, expectedConf.Msps, resp.Updates[8].GetConfig().Msps)
+
+	cancel()
+	_, err = stream.Recv()
+	require.Error(t, err)
+
+	t.Run("Chaincode query", func(t *testing.T) {
+		req := NewRequest()
+		req.OfChannel("mychannel").AddEndorsersQuery(interest("mycc"))
+		stream, err := cl.Watch(context.Background(), req, authInfo, 0)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Contains(t, err.Error(), "query 0 can't be watched, only peer membership and config queries can be watched")
+	})
+}
+
 func TestUnableToSign(t *testing.T) {
 	signer := func(msg []byte) ([]byte, error) {
 		return nil, errors.New("not enough entropy")
@@ -73,6 +73,10 @@ func AddCommands(cli CommandRegistrar) {
 	chaincodes := endorsers.Flag("chaincode", "Specifies the chaincode name(s)").Strings()
 	collections := endorsers.Flag("collection", "Specifies the collection name(s) as a mapping from chaincode to a comma separated list of collections").PlaceHolder("CC:C1,C2").StringMap()
 	noPrivReads := endorsers.Flag("noPrivateReads", "Specifies chaincodes that are not expected to be have private data read").PlaceHolder("CHAINCODE").Strings()
+	maxBlockLag := endorsers.Flag("maxBlockLag", "Excludes endorsers whose ledger height lags behind the highest ledger height in the channel by more than the given number of blocks").Uint64()
+	preferLocalOrg := endorsers.Flag("preferLocalOrg", "Prefers endorsers of the organization of the client if they can satisfy the endorsement policy alone").Bool()
+	excludedEndpoints := endorsers.Flag("excludeEndpoint", "Excludes the endorser with the given endpoint").PlaceHolder("HOST:PORT").Strings()
+	chaincodeVersions := endorsers.Flag("chaincodeVersion", "Requires endorsers to have the given version of a chaincode installed").PlaceHolder("CC:VERSION").StringMap()
 
 	server = endorsers.Flag("server", "Sets the endpoint of the server to connect").String()
 	channel = endorsers.Flag("channel", "Sets the channel the query is intended to").String()
@@ -81,4 +85,8 @@ func AddCommands(cli CommandRegistrar) {
 	endorserCmd.SetChaincodes(chaincodes)
 	endorserCmd.SetCollections(collections)
 	endorserCmd.SetNoPrivateReads(noPrivReads)
+	endorserCmd.SetMaxBlockLag(maxBlockLag)
+	endorserCmd.SetPreferLocalOrg(preferLocalOrg)
+	endorserCmd.SetExcludedEndpoints(excludedEndpoints)
+	endorserCmd.SetChaincodeVersions(chaincodeVersions)
 }
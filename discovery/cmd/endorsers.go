@@ -19,6 +19,8 @@ import (
 	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/cmd/common"
 	discoveryclient "github.com/hyperledger/fabric/discovery/client"
+	discoverymsgs "github.com/hyperledger/fabric/discovery/msgs"
+	discprotoext "github.com/hyperledger/fabric/discovery/protoext"
 	"github.com/hyperledger/fabric/gossip/protoext"
 	"github.com/pkg/errors"
 )
@@ -33,13 +35,17 @@ func NewEndorsersCmd(stub Stub, parser ResponseParser) *EndorsersCmd {
 
 // EndorsersCmd executes a command that retrieves endorsers for a chaincode invocation chain
 type EndorsersCmd struct {
-	stub        Stub
-	server      *string
-	channel     *string
-	chaincodes  *[]string
-	collections *map[string]string
-	noPrivReads *[]string
-	parser      ResponseParser
+	stub              Stub
+	server            *string
+	channel           *string
+	chaincodes        *[]string
+	collections       *map[string]string
+	noPrivReads       *[]string
+	maxBlockLag       *uint64
+	preferLocalOrg    *bool
+	excludedEndpoints *[]string
+	chaincodeVersions *map[string]string
+	parser            ResponseParser
 }
 
 // SetCollections sets the collections to be the given collections
@@ -52,6 +58,26 @@ func (pc *EndorsersCmd) SetNoPrivateReads(noPrivReads *[]string) {
 	pc.noPrivReads = noPrivReads
 }
 
+// SetMaxBlockLag sets the maximum number of blocks the ledger height of endorsers may lag behind
+func (pc *EndorsersCmd) SetMaxBlockLag(maxBlockLag *uint64) {
+	pc.maxBlockLag = maxBlockLag
+}
+
+// SetPreferLocalOrg sets whether endorsers of the local organization are preferred
+func (pc *EndorsersCmd) SetPreferLocalOrg(preferLocalOrg *bool) {
+	pc.preferLocalOrg = preferLocalOrg
+}
+
+// SetExcludedEndpoints sets the endpoints of peers that are excluded from being endorsers
+func (pc *EndorsersCmd) SetExcludedEndpoints(excludedEndpoints *[]string) {
+	pc.excludedEndpoints = excludedEndpoints
+}
+
+// SetChaincodeVersions sets the versions of chaincodes that endorsers are required to have installed
+func (pc *EndorsersCmd) SetChaincodeVersions(chaincodeVersions *map[string]string) {
+	pc.chaincodeVersions = chaincodeVersions
+}
+
 // SetChaincodes sets the chaincodes to be the given chaincodes
 func (pc *EndorsersCmd) SetChaincodes(chaincodes *[]string) {
 	pc.chaincodes = chaincodes
@@ -100,7 +126,12 @@ func (pc *EndorsersCmd) Execute(conf common.Config) error {
 		})
 	}
 
-	req, err := discoveryclient.NewRequest().OfChannel(channel).AddEndorsersQuery(&discovery.ChaincodeInterest{Chaincodes: ccCalls})
+	interest := &discovery.ChaincodeInterest{Chaincodes: ccCalls}
+	if err := discprotoext.SetEndorsementPreferences(interest, pc.endorsementPreferences(conf)); err != nil {
+		return errors.Wrap(err, "failed setting endorsement preferences")
+	}
+
+	req, err := discoveryclient.NewRequest().OfChannel(channel).AddEndorsersQuery(interest)
 	if err != nil {
 		return errors.Wrap(err, "failed creating request")
 	}
@@ -113,6 +144,28 @@ func (pc *EndorsersCmd) Execute(conf common.Config) error {
 	return pc.parser.ParseResponse(channel, res)
 }
 
+// endorsementPreferences returns the endorsement preferences that were set,
+// or nil if none were set
+func (pc *EndorsersCmd) endorsementPreferences(conf common.Config) *discoverymsgs.EndorsementPreferences {
+	prefs := &discoverymsgs.EndorsementPreferences{}
+	if pc.maxBlockLag != nil {
+		prefs.MaxBlockLag = *pc.maxBlockLag
+	}
+	if pc.preferLocalOrg != nil && *pc.preferLocalOrg {
+		prefs.PreferredOrg = conf.SignerConfig.MSPID
+	}
+	if pc.excludedEndpoints != nil && len(*pc.excludedEndpoints) > 0 {
+		prefs.ExcludedEndpoints = *pc.excludedEndpoints
+	}
+	if pc.chaincodeVersions != nil && len(*pc.chaincodeVersions) > 0 {
+		prefs.ChaincodeVersions = *pc.chaincodeVersions
+	}
+	if proto.Equal(prefs, &discoverymsgs.EndorsementPreferences{}) {
+		return nil
+	}
+	return prefs
+}
+
 // EndorserResponseParser parses endorsement responses from the peer
 type EndorserResponseParser struct {
 	io.Writer
@@ -13,9 +13,12 @@ import (
 	discprotos "github.com/hyperledger/fabric-protos-go/discovery"
 	"github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/cmd/common/signer"
 	. "github.com/hyperledger/fabric/discovery/client"
 	discovery "github.com/hyperledger/fabric/discovery/cmd"
 	"github.com/hyperledger/fabric/discovery/cmd/mocks"
+	discoverymsgs "github.com/hyperledger/fabric/discovery/msgs"
+	discprotoext "github.com/hyperledger/fabric/discovery/protoext"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
@@ -105,6 +108,41 @@ func TestEndorserCmd(t *testing.T) {
 		stub.AssertNumberOfCalls(t, "Send", 1)
 	})
 
+	t.Run("Endorsement query with preferences succeeds", func(t *testing.T) {
+		chaincodes := []string{"mycc"}
+		maxBlockLag := uint64(5)
+		preferLocalOrg := true
+		excludedEndpoints := []string{"peer1:7051"}
+		chaincodeVersions := map[string]string{"mycc": "1.0"}
+
+		stub := &mocks.Stub{}
+		cmd := discovery.NewEndorsersCmd(stub, parser)
+		cmd.SetChannel(&channel)
+		cmd.SetServer(&server)
+		cmd.SetChaincodes(&chaincodes)
+		cmd.SetMaxBlockLag(&maxBlockLag)
+		cmd.SetPreferLocalOrg(&preferLocalOrg)
+		cmd.SetExcludedEndpoints(&excludedEndpoints)
+		cmd.SetChaincodeVersions(&chaincodeVersions)
+		parser.On("ParseResponse", channel, mock.Anything).Return(nil).Once()
+		stub.On("Send", server, mock.Anything, mock.Anything).Return(nil, nil).Once().Run(func(arg mock.Arguments) {
+			// Ensure the preferences the CLI passed in are carried by the chaincode interest
+			req := arg.Get(2).(*Request)
+			prefs, err := discprotoext.GetEndorsementPreferences(req.Queries[0].GetCcQuery().Interests[0])
+			require.NoError(t, err)
+			require.Equal(t, &discoverymsgs.EndorsementPreferences{
+				MaxBlockLag:       5,
+				PreferredOrg:      "Org1MSP",
+				ExcludedEndpoints: []string{"peer1:7051"},
+				ChaincodeVersions: map[string]string{"mycc": "1.0"},
+			}, prefs)
+		})
+
+		err := cmd.Execute(common.Config{SignerConfig: signer.Config{MSPID: "Org1MSP"}})
+		require.NoError(t, err)
+		stub.AssertNumberOfCalls(t, "Send", 1)
+	})
+
 	t.Run("Endorsement query with collections that aren't mapped to any chaincode(s)", func(t *testing.T) {
 		chaincodes := []string{"mycc", "yourcc"}
 		collections := map[string]string{
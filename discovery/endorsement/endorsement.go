@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/common/graph"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/discovery/protoext"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	gossipdiscovery "github.com/hyperledger/fabric/gossip/discovery"
@@ -84,19 +85,25 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(channelID common.ChannelID, i
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	prefs, err := protoext.GetEndorsementPreferences(interest)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
 	channelMembersById := membersAndCC.members.ByID()
-	// Choose only the alive messages of those that have joined the channel
-	aliveMembership := ea.Peers().Intersect(membersAndCC.members)
+	// Choose only the alive messages of those that have joined the channel,
+	// and that the preferences of the client don't exclude
+	aliveMembership := ea.Peers().Intersect(membersAndCC.members).Filter(preferencesFilter(prefs, channelMembersById))
 	membersById := aliveMembership.ByID()
 	// Compute a mapping between the PKI-IDs of members to their identities
-	identitiesOfMembers := computeIdentitiesOfMembers(ea.IdentityInfo(), membersById)
+	identitySet := ea.IdentityInfo()
+	identitiesOfMembers := computeIdentitiesOfMembers(identitySet, membersById)
 	principalsSets, err := ea.computePrincipalSets(channelID, interest)
 	if err != nil {
 		logger.Warningf("Principal set computation failed: %v", err)
 		return nil, errors.WithStack(err)
 	}
 
-	return ea.computeEndorsementResponse(&context{
+	ctx := &context{
 		chaincode:           interest.Chaincodes[0].Name,
 		channel:             string(channelID),
 		principalsSets:      principalsSets,
@@ -104,7 +111,20 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(channelID common.ChannelID, i
 		aliveMembership:     aliveMembership,
 		identitiesOfMembers: identitiesOfMembers,
 		chaincodeMapping:    membersAndCC.chaincodeMapping,
-	})
+	}
+
+	if prefs != nil && prefs.PreferredOrg != "" {
+		// Recommend only the peers of the preferred organization if they can satisfy the endorsement policy alone
+		preferredCtx := *ctx
+		preferredCtx.aliveMembership = aliveMembership.Filter(orgFilter(prefs.PreferredOrg, identitySet))
+		desc, err := ea.computeEndorsementResponse(&preferredCtx)
+		if err == nil {
+			return desc, nil
+		}
+		logger.Debugf("Peers of %s cannot endorse alone for chaincode %s: %v", prefs.PreferredOrg, ctx.chaincode, err)
+	}
+
+	return ea.computeEndorsementResponse(ctx)
 }
 
 func (ea *endorsementAnalyzer) PeersAuthorizedByCriteria(channelID common.ChannelID, interest *discovery.ChaincodeInterest) (gossipdiscovery.Members, error) {
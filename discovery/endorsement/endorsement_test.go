@@ -18,6 +18,8 @@ import (
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/discovery/msgs"
+	"github.com/hyperledger/fabric/discovery/protoext"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
@@ -551,6 +553,90 @@ func TestPeersForEndorsement(t *testing.T) {
 			peerIdentityString("p6"): {},
 		}, extractPeers(desc))
 	})
+
+	t.Run("Preferences", func(t *testing.T) {
+		// Scenario XIII: The policy is p0 and p6, or p11, or p12.
+		// p11 lags 10 blocks behind the rest of the peers, and only p6 and p12
+		// have version 2.0 of another chaincode installed.
+		// The preferences of the client exclude peers before the layouts are computed.
+		chanPeers := peerSet{
+			newPeer(0).withChaincode(cc, "1.0").withLedgerHeight(100),
+			newPeer(6).withChaincode(cc, "1.0").withChaincode("other", "2.0").withLedgerHeight(100),
+			newPeer(11).withChaincode(cc, "1.0").withLedgerHeight(90),
+			newPeer(12).withChaincode(cc, "1.0").withChaincode("other", "2.0").withLedgerHeight(100),
+		}
+		pb := principalBuilder{}
+		policy := pb.newSet().addPrincipal(peerRole("p0")).addPrincipal(peerRole("p6")).
+			newSet().addPrincipal(peerRole("p11")).
+			newSet().addPrincipal(peerRole("p12")).buildPolicy()
+
+		peersForEndorsement := func(interest *discoveryprotos.ChaincodeInterest) (*discoveryprotos.EndorsementDescriptor, error) {
+			g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
+			mf := &metadataFetcher{}
+			mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"}).Once()
+			pf := &policyFetcherMock{}
+			pf.On("PoliciesByChaincode", cc).Return(policy).Once()
+			analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+			return analyzer.PeersForEndorsement(channel, interest)
+		}
+
+		endorsers := func(prefs *msgs.EndorsementPreferences) map[string]struct{} {
+			interest := &discoveryprotos.ChaincodeInterest{
+				Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc}},
+			}
+			require.NoError(t, protoext.SetEndorsementPreferences(interest, prefs))
+			desc, err := peersForEndorsement(interest)
+			require.NoError(t, err)
+			return extractPeers(desc)
+		}
+
+		allPeers := map[string]struct{}{
+			peerIdentityString("p0"):  {},
+			peerIdentityString("p6"):  {},
+			peerIdentityString("p11"): {},
+			peerIdentityString("p12"): {},
+		}
+		require.Equal(t, allPeers, endorsers(nil))
+
+		require.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"):  {},
+			peerIdentityString("p6"):  {},
+			peerIdentityString("p12"): {},
+		}, endorsers(&msgs.EndorsementPreferences{MaxBlockLag: 5}))
+		require.Equal(t, allPeers, endorsers(&msgs.EndorsementPreferences{MaxBlockLag: 10}))
+
+		require.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"):  {},
+			peerIdentityString("p6"):  {},
+			peerIdentityString("p11"): {},
+		}, endorsers(&msgs.EndorsementPreferences{ExcludedEndpoints: []string{"p12"}}))
+
+		require.Equal(t, map[string]struct{}{
+			peerIdentityString("p12"): {},
+		}, endorsers(&msgs.EndorsementPreferences{ChaincodeVersions: map[string]string{"other": "2.0"}}))
+
+		// The peers of the preferred organization are recommended alone
+		// only if they can satisfy the endorsement policy alone
+		require.Equal(t, map[string]struct{}{
+			peerIdentityString("p12"): {},
+		}, endorsers(&msgs.EndorsementPreferences{PreferredOrg: "Org12MSP"}))
+		require.Equal(t, allPeers, endorsers(&msgs.EndorsementPreferences{PreferredOrg: "Org0MSP"}))
+
+		// Preferences which exclude too many peers fail the request
+		interest := &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc}},
+		}
+		require.NoError(t, protoext.SetEndorsementPreferences(interest, &msgs.EndorsementPreferences{ExcludedEndpoints: []string{"p6", "p11", "p12"}}))
+		desc, err := peersForEndorsement(interest)
+		require.Nil(t, desc)
+		require.EqualError(t, err, "no peer combination can satisfy the endorsement policy")
+
+		// Malformed preferences fail the request
+		interest.XXX_unrecognized = []byte{0xc2, 0x06, 0x05}
+		desc, err = peersForEndorsement(interest)
+		require.Nil(t, desc)
+		require.Contains(t, err.Error(), "malformed endorsement preferences")
+	})
 }
 
 func TestPeersAuthorizedByCriteria(t *testing.T) {
@@ -856,6 +942,14 @@ func (pi *peerInfo) withChaincode(name, version string) *peerInfo {
 	return pi
 }
 
+func (pi *peerInfo) withLedgerHeight(height uint64) *peerInfo {
+	if pi.Properties == nil {
+		pi.Properties = &gossip.Properties{}
+	}
+	pi.Properties.LedgerHeight = height
+	return pi
+}
+
 type gossipMock struct {
 	mock.Mock
 }
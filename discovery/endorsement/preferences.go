@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/discovery/msgs"
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipdiscovery "github.com/hyperledger/fabric/gossip/discovery"
+)
+
+// preferencesFilter returns a memberFilter which rejects the peers that the
+// endorsement preferences of the client exclude from being recommended.
+// The ledger heights and the installed chaincodes of the peers are taken from
+// their channel membership.
+func preferencesFilter(prefs *msgs.EndorsementPreferences, channelMembersById map[string]gossipdiscovery.NetworkMember) memberFilter {
+	if prefs == nil {
+		return noopMemberFilter
+	}
+
+	excludedEndpoints := make(map[string]struct{}, len(prefs.ExcludedEndpoints))
+	for _, endpoint := range prefs.ExcludedEndpoints {
+		excludedEndpoints[endpoint] = struct{}{}
+	}
+
+	var maxHeight uint64
+	for _, member := range channelMembersById {
+		if member.Properties != nil && member.Properties.LedgerHeight > maxHeight {
+			maxHeight = member.Properties.LedgerHeight
+		}
+	}
+
+	return func(member gossipdiscovery.NetworkMember) bool {
+		if _, excluded := excludedEndpoints[member.Endpoint]; excluded {
+			logger.Debug(member, "is excluded by its endpoint")
+			return false
+		}
+
+		properties := channelMembersById[string(member.PKIid)].Properties
+		if prefs.MaxBlockLag > 0 {
+			var height uint64
+			if properties != nil {
+				height = properties.LedgerHeight
+			}
+			if height+prefs.MaxBlockLag < maxHeight {
+				logger.Debug(member, "is excluded by its ledger height", height, "which lags behind", maxHeight)
+				return false
+			}
+		}
+
+		for cc, version := range prefs.ChaincodeVersions {
+			if !hasChaincodeVersion(properties.GetChaincodes(), cc, version) {
+				logger.Debug(member, "is excluded since it doesn't have version", version, "of chaincode", cc, "installed")
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func hasChaincodeVersion(chaincodes []*gossip.Chaincode, name, version string) bool {
+	for _, cc := range chaincodes {
+		if cc.Name == name && cc.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// orgFilter returns a memberFilter which only accepts the peers of the given organization
+func orgFilter(org string, identitySet api.PeerIdentitySet) memberFilter {
+	peersOfOrg := make(map[string]struct{})
+	for _, identity := range identitySet {
+		if string(identity.Organization) == org {
+			peersOfOrg[string(identity.PKIId)] = struct{}{}
+		}
+	}
+	return func(member gossipdiscovery.NetworkMember) bool {
+		_, isPeerOfOrg := peersOfOrg[string(member.PKIid)]
+		return isPeerOfOrg
+	}
+}
@@ -0,0 +1,187 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: preferences.proto
+
+package msgs
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	discovery "github.com/hyperledger/fabric-protos-go/discovery"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ChaincodeInterest extends discovery.ChaincodeInterest with the endorsement
+// preferences of the client. It has the fields of discovery.ChaincodeInterest,
+// so that the two can be converted to each other. The added field is numbered
+// away from the fields of discovery.ChaincodeInterest.
+type ChaincodeInterest struct {
+	Chaincodes []*discovery.ChaincodeCall `protobuf:"bytes,1,rep,name=chaincodes,proto3" json:"chaincodes,omitempty"`
+	// preferences are the endorsement preferences of the client. Peers which
+	// do not know about them compute the endorsement descriptor without
+	// applying them.
+	Preferences          *EndorsementPreferences `protobuf:"bytes,104,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ChaincodeInterest) Reset()         { *m = ChaincodeInterest{} }
+func (m *ChaincodeInterest) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeInterest) ProtoMessage()    {}
+func (*ChaincodeInterest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3509cd19ef763d, []int{0}
+}
+
+func (m *ChaincodeInterest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeInterest.Unmarshal(m, b)
+}
+func (m *ChaincodeInterest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeInterest.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeInterest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeInterest.Merge(m, src)
+}
+func (m *ChaincodeInterest) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeInterest.Size(m)
+}
+func (m *ChaincodeInterest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeInterest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeInterest proto.InternalMessageInfo
+
+func (m *ChaincodeInterest) GetChaincodes() []*discovery.ChaincodeCall {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+func (m *ChaincodeInterest) GetPreferences() *EndorsementPreferences {
+	if m != nil {
+		return m.Preferences
+	}
+	return nil
+}
+
+// EndorsementPreferences are the preferences of a client about the peers
+// recommended to it for endorsement. The discovery service applies them to
+// the peers of the channel before it computes the layouts of the endorsement
+// descriptor.
+type EndorsementPreferences struct {
+	// max_block_lag excludes the peers which are behind the highest ledger
+	// height among the peers of the channel by more than max_block_lag blocks.
+	// 0 means peers are not excluded by their ledger height.
+	MaxBlockLag uint64 `protobuf:"varint,1,opt,name=max_block_lag,json=maxBlockLag,proto3" json:"max_block_lag,omitempty"`
+	// preferred_org is the MSP ID of an organization, typically the local
+	// organization of the client, whose peers are recommended alone if they
+	// are enough to satisfy the endorsement policy.
+	PreferredOrg string `protobuf:"bytes,2,opt,name=preferred_org,json=preferredOrg,proto3" json:"preferred_org,omitempty"`
+	// excluded_endpoints are the endpoints of peers not to be recommended
+	ExcludedEndpoints []string `protobuf:"bytes,3,rep,name=excluded_endpoints,json=excludedEndpoints,proto3" json:"excluded_endpoints,omitempty"`
+	// chaincode_versions maps names of chaincodes to the versions the
+	// recommended peers are required to have installed
+	ChaincodeVersions    map[string]string `protobuf:"bytes,4,rep,name=chaincode_versions,json=chaincodeVersions,proto3" json:"chaincode_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *EndorsementPreferences) Reset()         { *m = EndorsementPreferences{} }
+func (m *EndorsementPreferences) String() string { return proto.CompactTextString(m) }
+func (*EndorsementPreferences) ProtoMessage()    {}
+func (*EndorsementPreferences) Descriptor() ([]byte, []int) {
+	return fileDescriptor_2b3509cd19ef763d, []int{1}
+}
+
+func (m *EndorsementPreferences) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndorsementPreferences.Unmarshal(m, b)
+}
+func (m *EndorsementPreferences) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndorsementPreferences.Marshal(b, m, deterministic)
+}
+func (m *EndorsementPreferences) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndorsementPreferences.Merge(m, src)
+}
+func (m *EndorsementPreferences) XXX_Size() int {
+	return xxx_messageInfo_EndorsementPreferences.Size(m)
+}
+func (m *EndorsementPreferences) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndorsementPreferences.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndorsementPreferences proto.InternalMessageInfo
+
+func (m *EndorsementPreferences) GetMaxBlockLag() uint64 {
+	if m != nil {
+		return m.MaxBlockLag
+	}
+	return 0
+}
+
+func (m *EndorsementPreferences) GetPreferredOrg() string {
+	if m != nil {
+		return m.PreferredOrg
+	}
+	return ""
+}
+
+func (m *EndorsementPreferences) GetExcludedEndpoints() []string {
+	if m != nil {
+		return m.ExcludedEndpoints
+	}
+	return nil
+}
+
+func (m *EndorsementPreferences) GetChaincodeVersions() map[string]string {
+	if m != nil {
+		return m.ChaincodeVersions
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ChaincodeInterest)(nil), "discovery.msgs.ChaincodeInterest")
+	proto.RegisterType((*EndorsementPreferences)(nil), "discovery.msgs.EndorsementPreferences")
+	proto.RegisterMapType((map[string]string)(nil), "discovery.msgs.EndorsementPreferences.ChaincodeVersionsEntry")
+}
+
+func init() { proto.RegisterFile("preferences.proto", fileDescriptor_2b3509cd19ef763d) }
+
+var fileDescriptor_2b3509cd19ef763d = []byte{
+	// 355 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xc1, 0x6a, 0xe3, 0x30,
+	0x10, 0x86, 0x71, 0x9c, 0x5d, 0x88, 0xbc, 0x59, 0xd6, 0x62, 0x09, 0x22, 0x27, 0x93, 0x85, 0xc5,
+	0x87, 0x5d, 0x1b, 0xd2, 0x4b, 0x28, 0xf4, 0x92, 0x34, 0xd0, 0x42, 0xa1, 0xc5, 0x87, 0x1e, 0x7a,
+	0x31, 0xb2, 0x34, 0xb1, 0x4d, 0x64, 0xc9, 0x48, 0x4e, 0x88, 0x9f, 0xa4, 0x2f, 0xd4, 0x07, 0x2b,
+	0x71, 0x12, 0x3b, 0x2d, 0x39, 0xf4, 0x26, 0xfd, 0xf3, 0xcd, 0xf0, 0xff, 0xc3, 0x20, 0xb7, 0xd4,
+	0xb0, 0x02, 0x0d, 0x92, 0x81, 0x09, 0x4a, 0xad, 0x2a, 0x85, 0x7f, 0xf2, 0xdc, 0x30, 0xb5, 0x05,
+	0x5d, 0x07, 0x85, 0x49, 0xcd, 0x98, 0xb4, 0xff, 0xb0, 0x01, 0x98, 0x12, 0x07, 0x72, 0xf2, 0x6a,
+	0x21, 0x77, 0x91, 0xd1, 0x5c, 0x32, 0xc5, 0xe1, 0x5e, 0x56, 0xa0, 0xc1, 0x54, 0x78, 0x86, 0x10,
+	0x3b, 0x89, 0x86, 0x58, 0x9e, 0xed, 0x3b, 0x53, 0x12, 0x74, 0x43, 0xdb, 0x8e, 0x05, 0x15, 0x22,
+	0x3a, 0x63, 0xf1, 0x1d, 0x72, 0xce, 0xec, 0x90, 0xcc, 0xb3, 0x7c, 0x67, 0xfa, 0x37, 0xf8, 0xe8,
+	0x27, 0x58, 0x4a, 0xae, 0xb4, 0x81, 0x02, 0x64, 0xf5, 0xd4, 0xd1, 0xd1, 0x79, 0xeb, 0xe4, 0xad,
+	0x87, 0x46, 0x97, 0x39, 0x3c, 0x41, 0xc3, 0x82, 0xee, 0xe2, 0x44, 0x28, 0xb6, 0x8e, 0x05, 0x4d,
+	0x89, 0xe5, 0x59, 0x7e, 0x3f, 0x72, 0x0a, 0xba, 0x9b, 0xef, 0xb5, 0x07, 0x9a, 0xe2, 0x3f, 0x68,
+	0x78, 0x98, 0xa6, 0x81, 0xc7, 0x4a, 0xa7, 0xa4, 0xe7, 0x59, 0xfe, 0x20, 0xfa, 0xd1, 0x8a, 0x8f,
+	0x3a, 0xc5, 0xff, 0x11, 0x86, 0x1d, 0x13, 0x1b, 0x0e, 0x3c, 0x06, 0xc9, 0x4b, 0x95, 0xcb, 0xca,
+	0x10, 0xdb, 0xb3, 0xfd, 0x41, 0xe4, 0x9e, 0x2a, 0xcb, 0x53, 0x01, 0x0b, 0x84, 0xdb, 0xa8, 0xf1,
+	0x16, 0xb4, 0xc9, 0x95, 0x34, 0xa4, 0xdf, 0xac, 0xe7, 0xe6, 0x6b, 0x19, 0xbb, 0xd5, 0x3d, 0x1f,
+	0xfb, 0x97, 0xb2, 0xd2, 0x75, 0xe4, 0xb2, 0xcf, 0xfa, 0xf8, 0x16, 0x8d, 0x2e, 0xc3, 0xf8, 0x17,
+	0xb2, 0xd7, 0x50, 0x37, 0xa9, 0x07, 0xd1, 0xfe, 0x89, 0x7f, 0xa3, 0x6f, 0x5b, 0x2a, 0x36, 0x70,
+	0x4c, 0x79, 0xf8, 0x5c, 0xf7, 0x66, 0xd6, 0x3c, 0x78, 0xf9, 0x97, 0xe6, 0x55, 0xb6, 0x49, 0x02,
+	0xa6, 0x8a, 0x30, 0xab, 0x4b, 0xd0, 0x02, 0x78, 0x0a, 0x3a, 0x5c, 0xd1, 0x44, 0xe7, 0x2c, 0xec,
+	0x4e, 0x63, 0x6f, 0x3b, 0xf9, 0xde, 0xdc, 0xc5, 0xd5, 0xfb, 0x00, 0xb2, 0x1c, 0x0b, 0xc1, 0x56,
+	0x02, 0x00, 0x00,
+}
@@ -0,0 +1,405 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: watch.proto
+
+package msgs
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	discovery "github.com/hyperledger/fabric-protos-go/discovery"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// WatchRequest requests updates of the view of the discovery service.
+// The queries of the signed request select what is watched: a peer
+// membership query watches the peers of a channel, and a config query
+// watches the configuration of a channel.
+type WatchRequest struct {
+	SignedRequest *discovery.SignedRequest `protobuf:"bytes,1,opt,name=signed_request,json=signedRequest,proto3" json:"signed_request,omitempty"`
+	// ledger_height_threshold is the number of blocks the ledger height of
+	// a peer needs to advance by for the peer to be updated. 0 means changes
+	// of ledger heights are not watched.
+	LedgerHeightThreshold uint64   `protobuf:"varint,2,opt,name=ledger_height_threshold,json=ledgerHeightThreshold,proto3" json:"ledger_height_threshold,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c826da73fff4a2c7, []int{0}
+}
+
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchRequest.Unmarshal(m, b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchRequest.Size(m)
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetSignedRequest() *discovery.SignedRequest {
+	if m != nil {
+		return m.SignedRequest
+	}
+	return nil
+}
+
+func (m *WatchRequest) GetLedgerHeightThreshold() uint64 {
+	if m != nil {
+		return m.LedgerHeightThreshold
+	}
+	return 0
+}
+
+// WatchResponse carries the updates of the view of the discovery service
+// which occurred since the previous WatchResponse. The first WatchResponse
+// carries the view at the time the watch started.
+type WatchResponse struct {
+	Updates              []*WatchUpdate `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *WatchResponse) Reset()         { *m = WatchResponse{} }
+func (m *WatchResponse) String() string { return proto.CompactTextString(m) }
+func (*WatchResponse) ProtoMessage()    {}
+func (*WatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c826da73fff4a2c7, []int{1}
+}
+
+func (m *WatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchResponse.Unmarshal(m, b)
+}
+func (m *WatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchResponse.Marshal(b, m, deterministic)
+}
+func (m *WatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchResponse.Merge(m, src)
+}
+func (m *WatchResponse) XXX_Size() int {
+	return xxx_messageInfo_WatchResponse.Size(m)
+}
+func (m *WatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchResponse proto.InternalMessageInfo
+
+func (m *WatchResponse) GetUpdates() []*WatchUpdate {
+	if m != nil {
+		return m.Updates
+	}
+	return nil
+}
+
+// WatchUpdate is an update of what a query of the watch request watches.
+type WatchUpdate struct {
+	// query is the index of the query in the watch request
+	Query uint32 `protobuf:"varint,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Types that are valid to be assigned to Update:
+	//	*WatchUpdate_PeerJoined
+	//	*WatchUpdate_PeerUpdated
+	//	*WatchUpdate_PeerLeft
+	//	*WatchUpdate_Config
+	//	*WatchUpdate_Error
+	Update               isWatchUpdate_Update `protobuf_oneof:"update"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WatchUpdate) Reset()         { *m = WatchUpdate{} }
+func (m *WatchUpdate) String() string { return proto.CompactTextString(m) }
+func (*WatchUpdate) ProtoMessage()    {}
+func (*WatchUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c826da73fff4a2c7, []int{2}
+}
+
+func (m *WatchUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchUpdate.Unmarshal(m, b)
+}
+func (m *WatchUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchUpdate.Marshal(b, m, deterministic)
+}
+func (m *WatchUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchUpdate.Merge(m, src)
+}
+func (m *WatchUpdate) XXX_Size() int {
+	return xxx_messageInfo_WatchUpdate.Size(m)
+}
+func (m *WatchUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchUpdate proto.InternalMessageInfo
+
+func (m *WatchUpdate) GetQuery() uint32 {
+	if m != nil {
+		return m.Query
+	}
+	return 0
+}
+
+type isWatchUpdate_Update interface {
+	isWatchUpdate_Update()
+}
+
+type WatchUpdate_PeerJoined struct {
+	PeerJoined *discovery.Peer `protobuf:"bytes,2,opt,name=peer_joined,json=peerJoined,proto3,oneof"`
+}
+
+type WatchUpdate_PeerUpdated struct {
+	PeerUpdated *discovery.Peer `protobuf:"bytes,3,opt,name=peer_updated,json=peerUpdated,proto3,oneof"`
+}
+
+type WatchUpdate_PeerLeft struct {
+	PeerLeft *discovery.Peer `protobuf:"bytes,4,opt,name=peer_left,json=peerLeft,proto3,oneof"`
+}
+
+type WatchUpdate_Config struct {
+	Config *discovery.ConfigResult `protobuf:"bytes,5,opt,name=config,proto3,oneof"`
+}
+
+type WatchUpdate_Error struct {
+	Error *discovery.Error `protobuf:"bytes,6,opt,name=error,proto3,oneof"`
+}
+
+func (*WatchUpdate_PeerJoined) isWatchUpdate_Update() {}
+
+func (*WatchUpdate_PeerUpdated) isWatchUpdate_Update() {}
+
+func (*WatchUpdate_PeerLeft) isWatchUpdate_Update() {}
+
+func (*WatchUpdate_Config) isWatchUpdate_Update() {}
+
+func (*WatchUpdate_Error) isWatchUpdate_Update() {}
+
+func (m *WatchUpdate) GetUpdate() isWatchUpdate_Update {
+	if m != nil {
+		return m.Update
+	}
+	return nil
+}
+
+func (m *WatchUpdate) GetPeerJoined() *discovery.Peer {
+	if x, ok := m.GetUpdate().(*WatchUpdate_PeerJoined); ok {
+		return x.PeerJoined
+	}
+	return nil
+}
+
+func (m *WatchUpdate) GetPeerUpdated() *discovery.Peer {
+	if x, ok := m.GetUpdate().(*WatchUpdate_PeerUpdated); ok {
+		return x.PeerUpdated
+	}
+	return nil
+}
+
+func (m *WatchUpdate) GetPeerLeft() *discovery.Peer {
+	if x, ok := m.GetUpdate().(*WatchUpdate_PeerLeft); ok {
+		return x.PeerLeft
+	}
+	return nil
+}
+
+func (m *WatchUpdate) GetConfig() *discovery.ConfigResult {
+	if x, ok := m.GetUpdate().(*WatchUpdate_Config); ok {
+		return x.Config
+	}
+	return nil
+}
+
+func (m *WatchUpdate) GetError() *discovery.Error {
+	if x, ok := m.GetUpdate().(*WatchUpdate_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*WatchUpdate) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*WatchUpdate_PeerJoined)(nil),
+		(*WatchUpdate_PeerUpdated)(nil),
+		(*WatchUpdate_PeerLeft)(nil),
+		(*WatchUpdate_Config)(nil),
+		(*WatchUpdate_Error)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterType((*WatchRequest)(nil), "discovery.msgs.WatchRequest")
+	proto.RegisterType((*WatchResponse)(nil), "discovery.msgs.WatchResponse")
+	proto.RegisterType((*WatchUpdate)(nil), "discovery.msgs.WatchUpdate")
+}
+
+func init() { proto.RegisterFile("watch.proto", fileDescriptor_c826da73fff4a2c7) }
+
+var fileDescriptor_c826da73fff4a2c7 = []byte{
+	// 398 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0xcd, 0x8e, 0xd3, 0x30,
+	0x10, 0xc7, 0x9b, 0xdd, 0x4d, 0x58, 0x26, 0xdb, 0x82, 0x2c, 0xd0, 0x5a, 0x0b, 0x48, 0x55, 0x4f,
+	0x39, 0x20, 0x07, 0xc2, 0xc7, 0x15, 0xa9, 0x40, 0x15, 0x21, 0x0e, 0xc8, 0x80, 0x40, 0x5c, 0xa2,
+	0x36, 0x99, 0x7c, 0xa0, 0x34, 0x4e, 0x6d, 0x07, 0xd4, 0x27, 0xe0, 0x45, 0x78, 0x50, 0x14, 0x3b,
+	0xa5, 0x41, 0xa2, 0x37, 0x8f, 0xff, 0xbf, 0xbf, 0x67, 0x3c, 0x33, 0xe0, 0xff, 0x5c, 0xeb, 0xb4,
+	0x64, 0xad, 0x14, 0x5a, 0x90, 0x59, 0x56, 0xa9, 0x54, 0xfc, 0x40, 0xb9, 0x67, 0x5b, 0x55, 0xa8,
+	0x1b, 0xfa, 0x37, 0x0e, 0x0d, 0x90, 0x8a, 0xda, 0x92, 0x8b, 0x5f, 0x0e, 0x5c, 0x7d, 0xe9, 0x9d,
+	0x1c, 0x77, 0x1d, 0x2a, 0x4d, 0x5e, 0xc1, 0x4c, 0x55, 0x45, 0x83, 0x59, 0x22, 0xed, 0x0d, 0x75,
+	0xe6, 0x4e, 0xe0, 0x47, 0x94, 0x1d, 0xdf, 0xfc, 0x68, 0x80, 0xc1, 0xc1, 0xa7, 0x6a, 0x1c, 0x92,
+	0x97, 0x70, 0x5d, 0x63, 0x56, 0xa0, 0x4c, 0x4a, 0xac, 0x8a, 0x52, 0x27, 0xba, 0x94, 0xa8, 0x4a,
+	0x51, 0x67, 0xf4, 0x6c, 0xee, 0x04, 0x17, 0xfc, 0xbe, 0x95, 0x63, 0xa3, 0x7e, 0x3a, 0x88, 0x8b,
+	0x15, 0x4c, 0x87, 0x42, 0x54, 0x2b, 0x1a, 0x85, 0xe4, 0x05, 0xdc, 0xea, 0xda, 0x6c, 0xad, 0x51,
+	0x51, 0x67, 0x7e, 0x1e, 0xf8, 0xd1, 0x03, 0xf6, 0xef, 0xb7, 0x98, 0xe1, 0x3f, 0x1b, 0x86, 0x1f,
+	0xd8, 0xc5, 0xef, 0x33, 0xf0, 0x47, 0x02, 0xb9, 0x07, 0xee, 0xae, 0x43, 0xb9, 0x37, 0xff, 0x98,
+	0x72, 0x1b, 0x90, 0x08, 0xfc, 0x16, 0x51, 0x26, 0xdf, 0x45, 0xd5, 0xa0, 0xad, 0xcc, 0x8f, 0xee,
+	0x8c, 0x12, 0x7c, 0x40, 0x94, 0xf1, 0x84, 0x43, 0x4f, 0xbd, 0x33, 0x10, 0x79, 0x0e, 0x57, 0xc6,
+	0x63, 0x33, 0x65, 0xf4, 0xfc, 0x94, 0xc9, 0x3c, 0x6d, 0xd3, 0x67, 0x84, 0xc1, 0x6d, 0xe3, 0xaa,
+	0x31, 0xd7, 0xf4, 0xe2, 0x94, 0xe5, 0xb2, 0x67, 0xde, 0x63, 0xae, 0xc9, 0x53, 0xf0, 0x52, 0xd1,
+	0xe4, 0x55, 0x41, 0x5d, 0x03, 0x5f, 0x8f, 0xe0, 0xd7, 0x46, 0xe0, 0xa8, 0xba, 0x5a, 0xc7, 0x13,
+	0x3e, 0x80, 0x24, 0x00, 0x17, 0xa5, 0x14, 0x92, 0x7a, 0xc6, 0x71, 0x77, 0xe4, 0x78, 0xdb, 0xdf,
+	0xc7, 0x13, 0x6e, 0x81, 0xe5, 0x25, 0x78, 0xb6, 0xfa, 0xe8, 0x2b, 0xcc, 0xde, 0x1c, 0x28, 0xd3,
+	0x2e, 0xb2, 0x02, 0xd7, 0x1e, 0x1e, 0xfe, 0xb7, 0xcf, 0xc3, 0x7c, 0x6f, 0x1e, 0x9d, 0x50, 0xed,
+	0xd4, 0x9e, 0x38, 0x4b, 0xf6, 0xed, 0x71, 0x51, 0xe9, 0xb2, 0xdb, 0xb0, 0x54, 0x6c, 0xc3, 0x72,
+	0xdf, 0xa2, 0xb4, 0x13, 0x0f, 0xf3, 0xf5, 0x46, 0x56, 0x69, 0x78, 0x5c, 0xc6, 0xde, 0xbf, 0xf1,
+	0xcc, 0x26, 0x3e, 0xfb, 0x33, 0x00, 0x25, 0x5c, 0x82, 0xfc, 0xc2, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// DiscoveryWatchClient is the client API for DiscoveryWatch service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DiscoveryWatchClient interface {
+	// Watch streams updates of the view of the discovery service until the
+	// client cancels the stream or is no longer eligible for the service.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (DiscoveryWatch_WatchClient, error)
+}
+
+type discoveryWatchClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDiscoveryWatchClient(cc grpc.ClientConnInterface) DiscoveryWatchClient {
+	return &discoveryWatchClient{cc}
+}
+
+func (c *discoveryWatchClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (DiscoveryWatch_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DiscoveryWatch_serviceDesc.Streams[0], "/discovery.msgs.DiscoveryWatch/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &discoveryWatchWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DiscoveryWatch_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type discoveryWatchWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *discoveryWatchWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DiscoveryWatchServer is the server API for DiscoveryWatch service.
+type DiscoveryWatchServer interface {
+	// Watch streams updates of the view of the discovery service until the
+	// client cancels the stream or is no longer eligible for the service.
+	Watch(*WatchRequest, DiscoveryWatch_WatchServer) error
+}
+
+// UnimplementedDiscoveryWatchServer can be embedded to have forward compatible implementations.
+type UnimplementedDiscoveryWatchServer struct {
+}
+
+func (*UnimplementedDiscoveryWatchServer) Watch(req *WatchRequest, srv DiscoveryWatch_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+
+func RegisterDiscoveryWatchServer(s *grpc.Server, srv DiscoveryWatchServer) {
+	s.RegisterService(&_DiscoveryWatch_serviceDesc, srv)
+}
+
+func _DiscoveryWatch_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DiscoveryWatchServer).Watch(m, &discoveryWatchWatchServer{stream})
+}
+
+type DiscoveryWatch_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type discoveryWatchWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *discoveryWatchWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _DiscoveryWatch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "discovery.msgs.DiscoveryWatch",
+	HandlerType: (*DiscoveryWatchServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _DiscoveryWatch_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "watch.proto",
+}
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext
+
+import (
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric/discovery/msgs"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// SetEndorsementPreferences sets the endorsement preferences carried by a
+// ChaincodeInterest. Nil preferences are not encoded, so that interests of
+// clients which do not set preferences are unchanged.
+func SetEndorsementPreferences(interest *discovery.ChaincodeInterest, prefs *msgs.EndorsementPreferences) error {
+	ci := &msgs.ChaincodeInterest{}
+	if err := protoutil.ConvertMessage(interest, ci); err != nil {
+		return err
+	}
+	ci.Preferences = prefs
+	return protoutil.ConvertMessage(ci, interest)
+}
+
+// GetEndorsementPreferences returns the endorsement preferences carried by a
+// ChaincodeInterest, or nil if the interest does not carry preferences.
+func GetEndorsementPreferences(interest *discovery.ChaincodeInterest) (*msgs.EndorsementPreferences, error) {
+	if interest == nil {
+		return nil, nil
+	}
+
+	ci := &msgs.ChaincodeInterest{}
+	if err := protoutil.ConvertMessage(interest, ci); err != nil {
+		return nil, errors.WithMessage(err, "malformed endorsement preferences")
+	}
+	return ci.Preferences, nil
+}
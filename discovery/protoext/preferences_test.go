@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric/discovery/msgs"
+	"github.com/hyperledger/fabric/discovery/protoext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndorsementPreferences(t *testing.T) {
+	interest := &discovery.ChaincodeInterest{
+		Chaincodes: []*discovery.ChaincodeCall{{Name: "mycc", CollectionNames: []string{"col"}}},
+	}
+	prefs, err := protoext.GetEndorsementPreferences(interest)
+	require.NoError(t, err)
+	require.Nil(t, prefs)
+	prefs, err = protoext.GetEndorsementPreferences(nil)
+	require.NoError(t, err)
+	require.Nil(t, prefs)
+
+	plain, err := proto.Marshal(interest)
+	require.NoError(t, err)
+
+	// Nil preferences are not encoded
+	require.NoError(t, protoext.SetEndorsementPreferences(interest, nil))
+	b, err := proto.Marshal(interest)
+	require.NoError(t, err)
+	require.Equal(t, plain, b)
+
+	expected := &msgs.EndorsementPreferences{
+		MaxBlockLag:       5,
+		PreferredOrg:      "Org1MSP",
+		ExcludedEndpoints: []string{"peer0.org2:7051", "peer1.org2:7051"},
+		ChaincodeVersions: map[string]string{"mycc": "1.0", "othercc": "2.0"},
+	}
+	require.NoError(t, protoext.SetEndorsementPreferences(interest, expected))
+
+	// The preferences survive a round trip through a discovery query
+	query := &discovery.Query{
+		Channel: "mychannel",
+		Query: &discovery.Query_CcQuery{
+			CcQuery: &discovery.ChaincodeQuery{Interests: []*discovery.ChaincodeInterest{interest}},
+		},
+	}
+	b, err = proto.Marshal(query)
+	require.NoError(t, err)
+	received := &discovery.Query{}
+	require.NoError(t, proto.Unmarshal(b, received))
+	receivedInterest := received.GetCcQuery().Interests[0]
+	prefs, err = protoext.GetEndorsementPreferences(receivedInterest)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(expected, prefs))
+	require.Equal(t, "mycc", receivedInterest.Chaincodes[0].Name)
+	require.Equal(t, []string{"col"}, receivedInterest.Chaincodes[0].CollectionNames)
+
+	// Setting preferences replaces the former ones
+	require.NoError(t, protoext.SetEndorsementPreferences(interest, &msgs.EndorsementPreferences{MaxBlockLag: 3}))
+	prefs, err = protoext.GetEndorsementPreferences(interest)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&msgs.EndorsementPreferences{MaxBlockLag: 3}, prefs))
+
+	// Empty preferences are decoded as empty preferences
+	require.NoError(t, protoext.SetEndorsementPreferences(interest, &msgs.EndorsementPreferences{}))
+	prefs, err = protoext.GetEndorsementPreferences(interest)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&msgs.EndorsementPreferences{}, prefs))
+
+	// Malformed preferences are reported
+	interest.XXX_unrecognized = []byte{0xc2, 0x06, 0x05}
+	_, err = protoext.GetEndorsementPreferences(interest)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "malformed endorsement preferences")
+}
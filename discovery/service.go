@@ -11,6 +11,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric-protos-go/discovery"
 	"github.com/hyperledger/fabric/common/flogging"
@@ -37,6 +38,7 @@ type Service struct {
 	channelDispatchers map[protoext.QueryType]dispatcher
 	localDispatchers   map[protoext.QueryType]dispatcher
 	auth               *authCache
+	watchInterval      time.Duration
 	Support
 }
 
@@ -46,14 +48,16 @@ type Config struct {
 	AuthCacheEnabled             bool
 	AuthCacheMaxSize             int
 	AuthCachePurgeRetentionRatio float64
+	// WatchInterval is how often changes of what clients watch are checked for
+	WatchInterval time.Duration
 }
 
 // String returns a string representation of this Config
 func (c Config) String() string {
 	if c.AuthCacheEnabled {
-		return fmt.Sprintf("TLS: %t, authCacheMaxSize: %d, authCachePurgeRatio: %f", c.TLS, c.AuthCacheMaxSize, c.AuthCachePurgeRetentionRatio)
+		return fmt.Sprintf("TLS: %t, authCacheMaxSize: %d, authCachePurgeRatio: %f, watchInterval: %s", c.TLS, c.AuthCacheMaxSize, c.AuthCachePurgeRetentionRatio, c.WatchInterval)
 	}
-	return fmt.Sprintf("TLS: %t, auth cache disabled", c.TLS)
+	return fmt.Sprintf("TLS: %t, auth cache disabled, watchInterval: %s", c.TLS, c.WatchInterval)
 }
 
 // peerMapping maps PKI-IDs to Peers
@@ -67,7 +71,11 @@ func NewService(config Config, sup Support) *Service {
 			maxCacheSize:        config.AuthCacheMaxSize,
 			purgeRetentionRatio: config.AuthCachePurgeRetentionRatio,
 		}),
-		Support: sup,
+		watchInterval: config.WatchInterval,
+		Support:       sup,
+	}
+	if s.watchInterval <= 0 {
+		s.watchInterval = defaultWatchInterval
 	}
 	s.channelDispatchers = map[protoext.QueryType]dispatcher{
 		protoext.ConfigQueryType:         s.configQuery,
@@ -175,26 +183,42 @@ func wrapPeerResponse(peersByOrg map[string]*discovery.Peers) *discovery.QueryRe
 }
 
 func (s *Service) channelMembershipResponse(q *discovery.Query) *discovery.QueryResult {
-	chanPeers, err := s.PeersAuthorizedByCriteria(common2.ChannelID(q.Channel), q.GetPeerQuery().Filter)
+	peersByOrg, err := s.channelMembership(q)
 	if err != nil {
 		return wrapError(err)
 	}
 	membersByOrgs := make(map[string]*discovery.Peers)
-	chanPeerByID := chanPeers.ByID()
-	for org, ids2Peers := range s.computeMembership(q) {
+	for org, ids2Peers := range peersByOrg {
 		membersByOrgs[org] = &discovery.Peers{}
+		for _, peer := range ids2Peers {
+			membersByOrgs[org].Peers = append(membersByOrgs[org].Peers, peer)
+		}
+	}
+	return wrapPeerResponse(membersByOrgs)
+}
+
+// channelMembership returns the peers of the channel of the given query by
+// their organizations and PKI-IDs, along with their state info messages
+func (s *Service) channelMembership(q *discovery.Query) (map[string]peerMapping, error) {
+	chanPeers, err := s.PeersAuthorizedByCriteria(common2.ChannelID(q.Channel), q.GetPeerQuery().Filter)
+	if err != nil {
+		return nil, err
+	}
+	chanPeerByID := chanPeers.ByID()
+	peersByOrg := s.computeMembership(q)
+	for _, ids2Peers := range peersByOrg {
 		for id, peer := range ids2Peers {
 			// Check if the peer is in the channel view
 			stateInfoMsg, exists := chanPeerByID[string(id)]
 			// If the peer isn't in the channel view, skip it and don't include it in the response
 			if !exists {
+				delete(ids2Peers, id)
 				continue
 			}
 			peer.StateInfo = stateInfoMsg.Envelope
-			membersByOrgs[org].Peers = append(membersByOrgs[org].Peers, peer)
 		}
 	}
-	return wrapPeerResponse(membersByOrgs)
+	return peersByOrg, nil
 }
 
 func (s *Service) localMembershipResponse(q *discovery.Query) *discovery.QueryResult {
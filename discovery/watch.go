@@ -0,0 +1,249 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/discovery/msgs"
+	"github.com/hyperledger/fabric/discovery/protoext"
+	gprotoext "github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const defaultWatchInterval = time.Second
+
+// watchedPeer is a peer of a watched channel as it was last sent to the client
+type watchedPeer struct {
+	peer         *discovery.Peer
+	ledgerHeight uint64
+}
+
+// watchedQuery is a query of a watch request along with the view
+// of what it watches that was last sent to the client
+type watchedQuery struct {
+	index          uint32
+	query          *discovery.Query
+	peers          map[string]watchedPeer
+	configSequence uint64
+	config         *discovery.ConfigResult
+	done           bool
+}
+
+// Watch streams updates of what the queries of the given request watch,
+// until the client cancels the stream or is no longer eligible for service
+// in the channels of all queries
+func (s *Service) Watch(request *msgs.WatchRequest, stream msgs.DiscoveryWatch_WatchServer) error {
+	ctx := stream.Context()
+	addr := util.ExtractRemoteAddress(ctx)
+	req, err := validateStructure(ctx, request.SignedRequest, s.config.TLS, util.ExtractCertificateHashFromContext)
+	if err != nil {
+		logger.Warningf("Watch request from %s is malformed or invalid: %v", addr, err)
+		return err
+	}
+	if err := validateWatchQueries(req.Queries); err != nil {
+		logger.Warningf("Watch request from %s is invalid: %v", addr, err)
+		return err
+	}
+	logger.Debugf("Watching for %s: %v", addr, req)
+
+	signedData := protoutil.SignedData{
+		Data:      request.SignedRequest.Payload,
+		Signature: request.SignedRequest.Signature,
+		Identity:  req.Authentication.ClientIdentity,
+	}
+	watched := make([]*watchedQuery, len(req.Queries))
+	for i, q := range req.Queries {
+		watched[i] = &watchedQuery{
+			index: uint32(i),
+			query: q,
+			peers: make(map[string]watchedPeer),
+		}
+	}
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	for first := true; ; first = false {
+		var updates []*msgs.WatchUpdate
+		remaining := 0
+		for _, wq := range watched {
+			if wq.done {
+				continue
+			}
+			updates = append(updates, s.watchUpdates(wq, signedData, request.LedgerHeightThreshold, addr)...)
+			if !wq.done {
+				remaining++
+			}
+		}
+
+		if first || len(updates) > 0 {
+			if err := stream.Send(&msgs.WatchResponse{Updates: updates}); err != nil {
+				logger.Debugf("Failed sending watch updates to %s: %v", addr, err)
+				return err
+			}
+		}
+		if remaining == 0 {
+			logger.Debugf("No query of %s is watched anymore", addr)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchUpdates returns the updates of what the given query watches since
+// the view that was last sent to the client
+func (s *Service) watchUpdates(wq *watchedQuery, signedData protoutil.SignedData, ledgerHeightThreshold uint64, addr string) []*msgs.WatchUpdate {
+	channel := wq.query.Channel
+	if !s.ChannelExists(channel) {
+		logger.Warning("watching channel", channel, "for", addr, "but it doesn't exist")
+		return wq.stop(accessDenied.GetError())
+	}
+	if err := s.auth.EligibleForService(channel, signedData); err != nil {
+		logger.Warning("watching channel", channel, "for", addr, "but it isn't eligible:", err)
+		return wq.stop(accessDenied.GetError())
+	}
+
+	switch protoext.GetQueryType(wq.query) {
+	case protoext.PeerMembershipQueryType:
+		return s.membershipUpdates(wq, ledgerHeightThreshold)
+	default:
+		return s.configUpdates(wq)
+	}
+}
+
+func (s *Service) membershipUpdates(wq *watchedQuery, ledgerHeightThreshold uint64) []*msgs.WatchUpdate {
+	peersByOrg, err := s.channelMembership(wq.query)
+	if err != nil {
+		return wq.stop(&discovery.Error{Content: err.Error()})
+	}
+	current := make(peerMapping)
+	for _, ids2Peers := range peersByOrg {
+		for id, peer := range ids2Peers {
+			current[id] = peer
+		}
+	}
+
+	var updates []*msgs.WatchUpdate
+	for _, id := range sortedIDs(current) {
+		peer := current[id]
+		height := ledgerHeight(peer)
+		prev, known := wq.peers[id]
+		switch {
+		case !known:
+			updates = append(updates, &msgs.WatchUpdate{
+				Query:  wq.index,
+				Update: &msgs.WatchUpdate_PeerJoined{PeerJoined: peer},
+			})
+		case ledgerHeightThreshold > 0 && height >= prev.ledgerHeight+ledgerHeightThreshold:
+			updates = append(updates, &msgs.WatchUpdate{
+				Query:  wq.index,
+				Update: &msgs.WatchUpdate_PeerUpdated{PeerUpdated: peer},
+			})
+		default:
+			continue
+		}
+		wq.peers[id] = watchedPeer{peer: peer, ledgerHeight: height}
+	}
+
+	var left []string
+	for id := range wq.peers {
+		if _, exists := current[id]; !exists {
+			left = append(left, id)
+		}
+	}
+	sort.Strings(left)
+	for _, id := range left {
+		peer := wq.peers[id].peer
+		updates = append(updates, &msgs.WatchUpdate{
+			Query: wq.index,
+			Update: &msgs.WatchUpdate_PeerLeft{PeerLeft: &discovery.Peer{
+				Identity:       peer.Identity,
+				MembershipInfo: peer.MembershipInfo,
+			}},
+		})
+		delete(wq.peers, id)
+	}
+	return updates
+}
+
+func (s *Service) configUpdates(wq *watchedQuery) []*msgs.WatchUpdate {
+	sequence := s.ConfigSequence(wq.query.Channel)
+	if wq.config != nil && sequence == wq.configSequence {
+		return nil
+	}
+	conf, err := s.Config(wq.query.Channel)
+	if err != nil {
+		logger.Errorf("Failed fetching config for channel %s: %v", wq.query.Channel, err)
+		return wq.stop(&discovery.Error{Content: "failed fetching config for channel " + wq.query.Channel})
+	}
+	wq.configSequence = sequence
+	if proto.Equal(conf, wq.config) {
+		return nil
+	}
+	wq.config = conf
+	return []*msgs.WatchUpdate{{
+		Query:  wq.index,
+		Update: &msgs.WatchUpdate_Config{Config: conf},
+	}}
+}
+
+// stop stops watching the query and returns an update which tells the client why
+func (wq *watchedQuery) stop(err *discovery.Error) []*msgs.WatchUpdate {
+	wq.done = true
+	return []*msgs.WatchUpdate{{
+		Query:  wq.index,
+		Update: &msgs.WatchUpdate_Error{Error: err},
+	}}
+}
+
+func validateWatchQueries(queries []*discovery.Query) error {
+	if len(queries) == 0 {
+		return errors.New("watch request must have at least one query")
+	}
+	for i, q := range queries {
+		if q.Channel == "" {
+			return errors.Errorf("query %d has no channel", i)
+		}
+		switch protoext.GetQueryType(q) {
+		case protoext.PeerMembershipQueryType, protoext.ConfigQueryType:
+		default:
+			return errors.Errorf("query %d can't be watched, only peer membership and config queries can be watched", i)
+		}
+	}
+	return nil
+}
+
+func sortedIDs(peers peerMapping) []string {
+	ids := make([]string, 0, len(peers))
+	for id := range peers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func ledgerHeight(peer *discovery.Peer) uint64 {
+	if peer.StateInfo == nil {
+		return 0
+	}
+	msg, err := gprotoext.EnvelopeToGossipMessage(peer.StateInfo)
+	if err != nil {
+		return 0
+	}
+	return msg.GetStateInfo().GetProperties().GetLedgerHeight()
+}
@@ -0,0 +1,326 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/discovery/msgs"
+	"github.com/hyperledger/fabric/gossip/api"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	gdisc "github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type watchSupport struct {
+	sync.Mutex
+	heights  map[int]uint64
+	sequence uint64
+	config   *discovery.ConfigResult
+	eligible bool
+}
+
+func (ws *watchSupport) EligibleForService(channel string, data protoutil.SignedData) error {
+	ws.Lock()
+	defer ws.Unlock()
+	if !ws.eligible {
+		return errors.New("not eligible")
+	}
+	return nil
+}
+
+func (ws *watchSupport) ChannelExists(channel string) bool {
+	return channel == "mychannel"
+}
+
+func (ws *watchSupport) PeersOfChannel(gcommon.ChannelID) gdisc.Members {
+	panic("not implemented")
+}
+
+func (ws *watchSupport) Peers() gdisc.Members {
+	ws.Lock()
+	defer ws.Unlock()
+	var members gdisc.Members
+	for id := range ws.heights {
+		members = append(members, aliveMsg(id))
+	}
+	return members
+}
+
+func (ws *watchSupport) IdentityInfo() api.PeerIdentitySet {
+	return api.PeerIdentitySet{idInfo(0, "O1"), idInfo(1, "O2")}
+}
+
+func (ws *watchSupport) PeersForEndorsement(gcommon.ChannelID, *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error) {
+	panic("not implemented")
+}
+
+func (ws *watchSupport) PeersAuthorizedByCriteria(gcommon.ChannelID, *discovery.ChaincodeInterest) (gdisc.Members, error) {
+	ws.Lock()
+	defer ws.Unlock()
+	var members gdisc.Members
+	for id, height := range ws.heights {
+		members = append(members, stateInfoWithHeight(id, height))
+	}
+	return members, nil
+}
+
+func (ws *watchSupport) Config(channel string) (*discovery.ConfigResult, error) {
+	ws.Lock()
+	defer ws.Unlock()
+	return ws.config, nil
+}
+
+func (ws *watchSupport) ConfigSequence(channel string) uint64 {
+	ws.Lock()
+	defer ws.Unlock()
+	return ws.sequence
+}
+
+func (ws *watchSupport) update(f func()) {
+	ws.Lock()
+	defer ws.Unlock()
+	f()
+}
+
+type watchStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses chan *msgs.WatchResponse
+}
+
+func (ws *watchStream) Context() context.Context {
+	return ws.ctx
+}
+
+func (ws *watchStream) Send(resp *msgs.WatchResponse) error {
+	ws.responses <- resp
+	return nil
+}
+
+func stateInfoWithHeight(id int, height uint64) gdisc.NetworkMember {
+	pkiID := gcommon.PKIidType(fmt.Sprintf("p%d", id))
+	gm := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_StateInfo{
+			StateInfo: &gossip.StateInfo{
+				PkiId:      pkiID,
+				Properties: &gossip.Properties{LedgerHeight: height},
+			},
+		},
+	}
+	sm, _ := protoext.NoopSign(gm)
+	return gdisc.NetworkMember{
+		PKIid:      pkiID,
+		Envelope:   sm.Envelope,
+		Properties: &gossip.Properties{LedgerHeight: height},
+	}
+}
+
+func watchRequest(threshold uint64, queries ...*discovery.Query) *msgs.WatchRequest {
+	return &msgs.WatchRequest{
+		SignedRequest: toSignedRequest(&discovery.Request{
+			Authentication: &discovery.AuthInfo{ClientIdentity: []byte{1, 2, 3}},
+			Queries:        queries,
+		}),
+		LedgerHeightThreshold: threshold,
+	}
+}
+
+func TestWatch(t *testing.T) {
+	config := &discovery.ConfigResult{
+		Msps: map[string]*msp.FabricMSPConfig{"O1": {Name: "O1"}},
+	}
+	sup := &watchSupport{
+		heights:  map[int]uint64{0: 10, 1: 1},
+		sequence: 1,
+		config:   config,
+		eligible: true,
+	}
+	service := NewService(Config{WatchInterval: 10 * time.Millisecond}, sup)
+
+	peerQuery := &discovery.Query{
+		Channel: "mychannel",
+		Query:   &discovery.Query_PeerQuery{PeerQuery: &discovery.PeerMembershipQuery{}},
+	}
+	configQuery := &discovery.Query{
+		Channel: "mychannel",
+		Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchStream{ctx: ctx, responses: make(chan *msgs.WatchResponse, 100)}
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- service.Watch(watchRequest(5, peerQuery, configQuery), stream)
+	}()
+
+	nextUpdates := func() []*msgs.WatchUpdate {
+		select {
+		case resp := <-stream.responses:
+			return resp.Updates
+		case <-time.After(10 * time.Second):
+			t.Fatal("no watch response was sent")
+			return nil
+		}
+	}
+
+	// The first response carries the current view
+	updates := nextUpdates()
+	require.Len(t, updates, 3)
+	require.Equal(t, uint32(0), updates[0].Query)
+	require.Equal(t, []byte("p0"), updates[0].GetPeerJoined().Identity)
+	require.Equal(t, uint64(10), ledgerHeight(updates[0].GetPeerJoined()))
+	require.Equal(t, uint32(0), updates[1].Query)
+	require.Equal(t, []byte("p1"), updates[1].GetPeerJoined().Identity)
+	require.Equal(t, uint64(1), ledgerHeight(updates[1].GetPeerJoined()))
+	require.Equal(t, uint32(1), updates[2].Query)
+	require.True(t, proto.Equal(config, updates[2].GetConfig()))
+
+	// Ledger heights which advance by less than the threshold are not updated
+	sup.update(func() { sup.heights[1] = 3 })
+	time.Sleep(50 * time.Millisecond)
+	sup.update(func() { sup.heights[1] = 6 })
+	updates = nextUpdates()
+	require.Len(t, updates, 1)
+	require.Equal(t, []byte("p1"), updates[0].GetPeerUpdated().Identity)
+	require.Equal(t, uint64(6), ledgerHeight(updates[0].GetPeerUpdated()))
+
+	// Peers which are no longer alive in the channel leave
+	sup.update(func() { delete(sup.heights, 0) })
+	updates = nextUpdates()
+	require.Len(t, updates, 1)
+	require.Equal(t, []byte("p0"), updates[0].GetPeerLeft().Identity)
+	require.Nil(t, updates[0].GetPeerLeft().StateInfo)
+
+	// Config updates are sent only if the config changed
+	sup.update(func() { sup.sequence = 2 })
+	time.Sleep(50 * time.Millisecond)
+	updatedConfig := &discovery.ConfigResult{
+		Msps: map[string]*msp.FabricMSPConfig{"O1": {Name: "O1"}, "O2": {Name: "O2"}},
+	}
+	sup.update(func() {
+		sup.sequence = 3
+		sup.config = updatedConfig
+	})
+	updates = nextUpdates()
+	require.Len(t, updates, 1)
+	require.Equal(t, uint32(1), updates[0].Query)
+	require.True(t, proto.Equal(updatedConfig, updates[0].GetConfig()))
+
+	// Clients which are no longer eligible stop watching
+	sup.update(func() { sup.eligible = false })
+	updates = nextUpdates()
+	require.Len(t, updates, 2)
+	require.Equal(t, "access denied", updates[0].GetError().Content)
+	require.Equal(t, "access denied", updates[1].GetError().Content)
+	select {
+	case err := <-watchErr:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("watch didn't end")
+	}
+}
+
+func TestWatchCancel(t *testing.T) {
+	sup := &watchSupport{heights: map[int]uint64{0: 1}, eligible: true}
+	service := NewService(Config{WatchInterval: 10 * time.Millisecond}, sup)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchStream{ctx: ctx, responses: make(chan *msgs.WatchResponse, 100)}
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- service.Watch(watchRequest(0, &discovery.Query{
+			Channel: "mychannel",
+			Query:   &discovery.Query_PeerQuery{PeerQuery: &discovery.PeerMembershipQuery{}},
+		}), stream)
+	}()
+
+	updates := (<-stream.responses).Updates
+	require.Len(t, updates, 1)
+	require.Equal(t, []byte("p0"), updates[0].GetPeerJoined().Identity)
+
+	// Ledger heights are not watched without a threshold
+	sup.update(func() { sup.heights[0] = 100 })
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, stream.responses)
+
+	cancel()
+	select {
+	case err := <-watchErr:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("watch didn't end")
+	}
+}
+
+func TestWatchInvalidRequest(t *testing.T) {
+	sup := &watchSupport{eligible: true}
+	service := NewService(Config{}, sup)
+	stream := &watchStream{ctx: context.Background(), responses: make(chan *msgs.WatchResponse, 1)}
+
+	for _, tst := range []struct {
+		name        string
+		request     *msgs.WatchRequest
+		expectedErr string
+	}{
+		{
+			name:        "NoSignedRequest",
+			request:     &msgs.WatchRequest{},
+			expectedErr: "nil request",
+		},
+		{
+			name:        "NoQueries",
+			request:     watchRequest(0),
+			expectedErr: "watch request must have at least one query",
+		},
+		{
+			name: "NoChannel",
+			request: watchRequest(0, &discovery.Query{
+				Query: &discovery.Query_LocalPeers{LocalPeers: &discovery.LocalPeerQuery{}},
+			}),
+			expectedErr: "query 0 has no channel",
+		},
+		{
+			name: "ChaincodeQuery",
+			request: watchRequest(0, &discovery.Query{
+				Channel: "mychannel",
+				Query:   &discovery.Query_CcQuery{CcQuery: &discovery.ChaincodeQuery{}},
+			}),
+			expectedErr: "query 0 can't be watched, only peer membership and config queries can be watched",
+		},
+	} {
+		t.Run(tst.name, func(t *testing.T) {
+			err := service.Watch(tst.request, stream)
+			require.EqualError(t, err, tst.expectedErr)
+			require.Empty(t, stream.responses)
+		})
+	}
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		err := service.Watch(watchRequest(0, &discovery.Query{
+			Channel: "otherchannel",
+			Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+		}), stream)
+		require.NoError(t, err)
+		updates := (<-stream.responses).Updates
+		require.Len(t, updates, 1)
+		require.Equal(t, "access denied", updates[0].GetError().Content)
+	})
+}
@@ -50,6 +50,15 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// CloseConns closes all connections to remote peers.
+	// Connections are re-established when they are needed again.
+	CloseConns()
+
+	// UpdateIdentity replaces the identity this instance authenticates itself
+	// with to remote peers, and closes all connections so that they are
+	// re-established with the new identity
+	UpdateIdentity(peerIdentity api.PeerIdentityType)
+
 	// Stop stops the module
 	Stop()
 }
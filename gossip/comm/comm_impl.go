@@ -59,35 +59,33 @@ func NewCommInstance(s *grpc.Server, certs *common.TLSCertificates, idStore iden
 	peerIdentity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts, sa api.SecurityAdvisor,
 	commMetrics *metrics.CommMetrics, config CommConfig, dialOpts ...grpc.DialOption) (Comm, error) {
 	commInst := &commImpl{
-		sa:              sa,
-		pubSub:          util.NewPubSub(),
-		PKIID:           idStore.GetPKIidOfCert(peerIdentity),
-		idMapper:        idStore,
-		logger:          util.GetLogger(util.CommLogger, ""),
-		peerIdentity:    peerIdentity,
-		opts:            dialOpts,
-		secureDialOpts:  secureDialOpts,
-		msgPublisher:    NewChannelDemultiplexer(),
-		lock:            &sync.Mutex{},
-		deadEndpoints:   make(chan common.PKIidType, 100),
-		identityChanges: make(chan common.PKIidType, 1),
-		stopping:        int32(0),
-		exitChan:        make(chan struct{}),
-		subscriptions:   make([]chan protoext.ReceivedMessage, 0),
-		tlsCerts:        certs,
-		metrics:         commMetrics,
-		dialTimeout:     config.DialTimeout,
-		connTimeout:     config.ConnTimeout,
-		recvBuffSize:    config.RecvBuffSize,
-		sendBuffSize:    config.SendBuffSize,
-	}
-
-	connConfig := ConnConfig{
-		RecvBuffSize: config.RecvBuffSize,
-		SendBuffSize: config.SendBuffSize,
-	}
-
-	commInst.connStore = newConnStore(commInst, commInst.logger, connConfig)
+		sa:                   sa,
+		pubSub:               util.NewPubSub(),
+		PKIID:                idStore.GetPKIidOfCert(peerIdentity),
+		idMapper:             idStore,
+		logger:               util.GetLogger(util.CommLogger, ""),
+		peerIdentity:         peerIdentity,
+		opts:                 dialOpts,
+		secureDialOpts:       secureDialOpts,
+		msgPublisher:         NewChannelDemultiplexer(),
+		lock:                 &sync.Mutex{},
+		deadEndpoints:        make(chan common.PKIidType, 100),
+		identityChanges:      make(chan common.PKIidType, 1),
+		stopping:             int32(0),
+		exitChan:             make(chan struct{}),
+		subscriptions:        make([]chan protoext.ReceivedMessage, 0),
+		tlsCerts:             certs,
+		metrics:              commMetrics,
+		dialTimeout:          config.DialTimeout,
+		connTimeout:          config.ConnTimeout,
+		recvBuffSize:         config.RecvBuffSize,
+		sendBuffSize:         config.SendBuffSize,
+		compressionThreshold: config.CompressionThreshold,
+		inboundLimits:        config.InboundLimits,
+		outboundLimits:       config.OutboundLimits,
+	}
+
+	commInst.connStore = newConnStore(commInst, commInst.logger, commInst.connConfig())
 
 	proto.RegisterGossipServer(s, commInst)
 
@@ -100,32 +98,52 @@ type CommConfig struct {
 	ConnTimeout  time.Duration // Connection timeout
 	RecvBuffSize int           // Buffer size of received messages
 	SendBuffSize int           // Buffer size of sending messages
+	// CompressionThreshold is the minimum size of message payloads which are
+	// compressed when sent to peers which accept compressed messages.
+	// If 0, messages are neither compressed nor advertised to be accepted compressed.
+	CompressionThreshold int
+	InboundLimits        RateLimits // Limits of messages received from each remote peer
+	OutboundLimits       RateLimits // Limits of messages sent to each remote peer
 }
 
 type commImpl struct {
-	sa              api.SecurityAdvisor
-	tlsCerts        *common.TLSCertificates
-	pubSub          *util.PubSub
-	peerIdentity    api.PeerIdentityType
-	idMapper        identity.Mapper
-	logger          util.Logger
-	opts            []grpc.DialOption
-	secureDialOpts  func() []grpc.DialOption
-	connStore       *connectionStore
-	PKIID           []byte
-	deadEndpoints   chan common.PKIidType
-	identityChanges chan common.PKIidType
-	msgPublisher    *ChannelDeMultiplexer
-	lock            *sync.Mutex
-	exitChan        chan struct{}
-	stopWG          sync.WaitGroup
-	subscriptions   []chan protoext.ReceivedMessage
-	stopping        int32
-	metrics         *metrics.CommMetrics
-	dialTimeout     time.Duration
-	connTimeout     time.Duration
-	recvBuffSize    int
-	sendBuffSize    int
+	sa                   api.SecurityAdvisor
+	tlsCerts             *common.TLSCertificates
+	pubSub               *util.PubSub
+	identityLock         sync.RWMutex
+	peerIdentity         api.PeerIdentityType
+	idMapper             identity.Mapper
+	logger               util.Logger
+	opts                 []grpc.DialOption
+	secureDialOpts       func() []grpc.DialOption
+	connStore            *connectionStore
+	PKIID                []byte
+	deadEndpoints        chan common.PKIidType
+	identityChanges      chan common.PKIidType
+	msgPublisher         *ChannelDeMultiplexer
+	lock                 *sync.Mutex
+	exitChan             chan struct{}
+	stopWG               sync.WaitGroup
+	subscriptions        []chan protoext.ReceivedMessage
+	stopping             int32
+	metrics              *metrics.CommMetrics
+	dialTimeout          time.Duration
+	connTimeout          time.Duration
+	recvBuffSize         int
+	sendBuffSize         int
+	compressionThreshold int
+	inboundLimits        RateLimits
+	outboundLimits       RateLimits
+}
+
+func (c *commImpl) connConfig() ConnConfig {
+	return ConnConfig{
+		RecvBuffSize:         c.recvBuffSize,
+		SendBuffSize:         c.sendBuffSize,
+		CompressionThreshold: c.compressionThreshold,
+		InboundLimits:        c.inboundLimits,
+		OutboundLimits:       c.outboundLimits,
+	}
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -184,11 +202,7 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 					c.identityChanges <- expectedPKIID
 				}
 			}
-			connConfig := ConnConfig{
-				RecvBuffSize: c.recvBuffSize,
-				SendBuffSize: c.sendBuffSize,
-			}
-			conn := newConnection(cl, cc, stream, c.metrics, connConfig)
+			conn := newConnection(cl, cc, stream, c.metrics, c.connConfig())
 			conn.pkiID = pkiID
 			conn.info = connInfo
 			conn.logger = c.logger
@@ -368,6 +382,27 @@ func (c *commImpl) CloseConn(peer *RemotePeer) {
 	c.connStore.closeConnByPKIid(peer.PKIID)
 }
 
+func (c *commImpl) CloseConns() {
+	c.logger.Debug("Closing all connections")
+	c.connStore.closeAll()
+}
+
+func (c *commImpl) UpdateIdentity(peerIdentity api.PeerIdentityType) {
+	pkiID := c.idMapper.GetPKIidOfCert(peerIdentity)
+	c.identityLock.Lock()
+	c.logger.Infof("Changing PKI-ID from %s to %s", common.PKIidType(c.PKIID), pkiID)
+	c.PKIID = pkiID
+	c.peerIdentity = peerIdentity
+	c.identityLock.Unlock()
+	c.CloseConns()
+}
+
+func (c *commImpl) selfIdentity() (common.PKIidType, api.PeerIdentityType) {
+	c.identityLock.RLock()
+	defer c.identityLock.RUnlock()
+	return c.PKIID, c.peerIdentity
+}
+
 func (c *commImpl) closeSubscriptions() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -391,7 +426,8 @@ func (c *commImpl) Stop() {
 }
 
 func (c *commImpl) GetPKIid() common.PKIidType {
-	return c.PKIID
+	pkiID, _ := c.selfIdentity()
+	return pkiID
 }
 
 func extractRemoteAddress(stream stream) string {
@@ -432,7 +468,8 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator, isProbe bool
 		return nil, errors.New("no TLS certificate")
 	}
 
-	cMsg, err = c.createConnectionMsg(c.PKIID, selfCertHash, c.peerIdentity, signer, isProbe)
+	pkiID, peerIdentity := c.selfIdentity()
+	cMsg, err = c.createConnectionMsg(pkiID, selfCertHash, peerIdentity, signer, isProbe)
 	if err != nil {
 		return nil, err
 	}
@@ -470,6 +507,7 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator, isProbe bool
 			Signature:  m.Signature,
 			SignedData: m.Payload,
 		},
+		Compression: protoext.SupportsCompression(receivedMsg),
 	}
 
 	// if TLS is enabled and detected, verify remote peer
@@ -644,6 +682,9 @@ func (c *commImpl) createConnectionMsg(pkiID common.PKIidType, certHash []byte,
 			},
 		},
 	}
+	if err := protoext.SetCompressionSupport(m.GetConn(), c.compressionThreshold > 0); err != nil {
+		return nil, err
+	}
 	sMsg := &protoext.SignedGossipMessage{
 		GossipMessage: m,
 	}
@@ -34,6 +34,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/identity"
 	"github.com/hyperledger/fabric/gossip/metrics"
+	metricsmocks "github.com/hyperledger/fabric/gossip/metrics/mocks"
 	"github.com/hyperledger/fabric/gossip/protoext"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
@@ -125,6 +126,12 @@ func (*naiveSecProvider) VerifyByChannel(_ common.ChannelID, _ api.PeerIdentityT
 }
 
 func newCommInstanceOnlyWithMetrics(t *testing.T, commMetrics *metrics.CommMetrics, sec *naiveSecProvider,
+	gRPCServer *comm.GRPCServer, certs *common.TLSCertificates,
+	secureDialOpts api.PeerSecureDialOpts, dialOpts ...grpc.DialOption) Comm {
+	return newCommInstanceOnlyWithConfig(t, commMetrics, testCommConfig, sec, gRPCServer, certs, secureDialOpts, dialOpts...)
+}
+
+func newCommInstanceOnlyWithConfig(t *testing.T, commMetrics *metrics.CommMetrics, config CommConfig, sec *naiveSecProvider,
 	gRPCServer *comm.GRPCServer, certs *common.TLSCertificates,
 	secureDialOpts api.PeerSecureDialOpts, dialOpts ...grpc.DialOption) Comm {
 	_, portString, err := net.SplitHostPort(gRPCServer.Address())
@@ -135,7 +142,7 @@ func newCommInstanceOnlyWithMetrics(t *testing.T, commMetrics *metrics.CommMetri
 	identityMapper := identity.NewIdentityMapper(sec, id, noopPurgeIdentity, sec)
 
 	commInst, err := NewCommInstance(gRPCServer.Server(), certs, identityMapper, id, secureDialOpts,
-		sec, commMetrics, testCommConfig, dialOpts...)
+		sec, commMetrics, config, dialOpts...)
 	require.NoError(t, err)
 
 	go func() {
@@ -525,6 +532,32 @@ func TestGetConnectionInfo(t *testing.T) {
 	}
 }
 
+func TestUpdateIdentity(t *testing.T) {
+	comm1, port1 := newCommInstance(t, naiveSec)
+	comm2, _ := newCommInstance(t, naiveSec)
+	defer comm1.Stop()
+	defer comm2.Stop()
+	m1 := comm1.Accept(acceptAll)
+
+	receiveFrom := func(expected common.PKIidType) {
+		comm2.Send(createGossipMsg(), remotePeer(port1))
+		select {
+		case <-time.After(time.Second * 10):
+			t.Fatal("Didn't receive a message in time")
+		case msg := <-m1:
+			require.Equal(t, expected, msg.GetConnectionInfo().ID)
+			require.Equal(t, api.PeerIdentityType(expected), msg.GetConnectionInfo().Identity)
+		}
+	}
+
+	receiveFrom(comm2.GetPKIid())
+
+	// After the identity is replaced, the connection is re-established with the new identity
+	comm2.UpdateIdentity(api.PeerIdentityType("new identity"))
+	require.Equal(t, common.PKIidType("new identity"), comm2.GetPKIid())
+	receiveFrom(common.PKIidType("new identity"))
+}
+
 func TestCloseConn(t *testing.T) {
 	comm1, port1 := newCommInstance(t, naiveSec)
 	defer comm1.Stop()
@@ -922,13 +955,75 @@ func TestPresumedDead(t *testing.T) {
 	}
 }
 
+func TestCompression(t *testing.T) {
+	newComm := func(compressionThreshold int) (Comm, int, *metricsmocks.TestMetricProvider) {
+		provider := metricsmocks.TestUtilConstructMetricProvider()
+		commMetrics := metrics.NewGossipMetrics(provider.FakeProvider).CommMetrics
+		config := testCommConfig
+		config.CompressionThreshold = compressionThreshold
+		port, gRPCServer, certs, secureDialOpts, dialOpts := util.CreateGRPCLayer()
+		comm := newCommInstanceOnlyWithConfig(t, commMetrics, config, naiveSec, gRPCServer, certs, secureDialOpts, dialOpts...)
+		return comm, port, provider
+	}
+
+	comm1, port1, metrics1 := newComm(1024)
+	defer comm1.Stop()
+	comm2, port2, _ := newComm(1024)
+	defer comm2.Stop()
+	comm3, port3, metrics3 := newComm(0)
+	defer comm3.Stop()
+
+	dataMsg := func(size int) *protoext.SignedGossipMessage {
+		msg, _ := protoext.NoopSign(&proto.GossipMessage{
+			Tag:   proto.GossipMessage_CHAN_AND_ORG,
+			Nonce: uint64(rand.Int()),
+			Content: &proto.GossipMessage_DataMsg{
+				DataMsg: &proto.DataMessage{
+					Payload: &proto.Payload{SeqNum: 1, Data: bytes.Repeat([]byte{1, 2, 3, 4}, size/4)},
+				},
+			},
+		})
+		return msg
+	}
+	sendAndReceive := func(from Comm, to Comm, port int, msg *protoext.SignedGossipMessage) {
+		inc := to.Accept(func(o interface{}) bool {
+			return o.(protoext.ReceivedMessage).GetGossipMessage().Nonce == msg.Nonce
+		})
+		from.Send(msg, remotePeer(port))
+		select {
+		case m := <-inc:
+			// Received messages are decompressed, and keep their original envelope
+			require.Equal(t, msg.Envelope.Payload, m.GetGossipMessage().Envelope.Payload)
+			require.Equal(t, msg.Envelope.Signature, m.GetGossipMessage().Envelope.Signature)
+			require.Nil(t, m.GetGossipMessage().Envelope.XXX_unrecognized)
+		case <-time.After(10 * time.Second):
+			t.Fatal("message was not received")
+		}
+	}
+
+	// Large messages are compressed if both peers enable compression
+	sendAndReceive(comm1, comm2, port2, dataMsg(100000))
+	require.Equal(t, 1, metrics1.FakeCompressionRatio.ObserveCallCount())
+	require.True(t, metrics1.FakeCompressionRatio.ObserveArgsForCall(0) < 0.1)
+
+	// Small messages are not compressed
+	sendAndReceive(comm1, comm2, port2, dataMsg(100))
+	require.Equal(t, 1, metrics1.FakeCompressionRatio.ObserveCallCount())
+
+	// Messages are not compressed if either peer disables compression
+	sendAndReceive(comm1, comm3, port3, dataMsg(100000))
+	sendAndReceive(comm3, comm1, port1, dataMsg(100000))
+	require.Equal(t, 1, metrics1.FakeCompressionRatio.ObserveCallCount())
+	require.Zero(t, metrics3.FakeCompressionRatio.ObserveCallCount())
+}
+
 func TestReadFromStream(t *testing.T) {
 	stream := &gmocks.MockStream{}
 	stream.On("CloseSend").Return(nil)
 	stream.On("Recv").Return(&proto.Envelope{Payload: []byte{1}}, nil).Once()
 	stream.On("Recv").Return(nil, errors.New("stream closed")).Once()
 
-	conn := newConnection(nil, nil, stream, disabledMetrics, ConnConfig{1, 1})
+	conn := newConnection(nil, nil, stream, disabledMetrics, ConnConfig{RecvBuffSize: 1, SendBuffSize: 1})
 	conn.logger = flogging.MustGetLogger("test")
 
 	errChan := make(chan error, 2)
@@ -9,12 +9,15 @@ package comm
 import (
 	"context"
 	"sync"
+	"time"
 
+	protobuf "github.com/golang/protobuf/proto"
 	proto "github.com/hyperledger/fabric-protos-go/gossip"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/protoext"
 	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 )
@@ -186,39 +189,57 @@ func (cs *connectionStore) closeConnByPKIid(pkiID common.PKIidType) {
 	}
 }
 
+func (cs *connectionStore) closeAll() {
+	cs.Lock()
+	defer cs.Unlock()
+	for pkiID, conn := range cs.pki2Conn {
+		conn.close()
+		delete(cs.pki2Conn, pkiID)
+	}
+}
+
 func newConnection(cl proto.GossipClient, c *grpc.ClientConn, s stream, metrics *metrics.CommMetrics, config ConnConfig) *connection {
 	connection := &connection{
-		metrics:      metrics,
-		outBuff:      make(chan *msgSending, config.SendBuffSize),
-		cl:           cl,
-		conn:         c,
-		gossipStream: s,
-		stopChan:     make(chan struct{}, 1),
-		recvBuffSize: config.RecvBuffSize,
+		metrics:              metrics,
+		outBuff:              make(chan *msgSending, config.SendBuffSize),
+		cl:                   cl,
+		conn:                 c,
+		gossipStream:         s,
+		stopChan:             make(chan struct{}, 1),
+		recvBuffSize:         config.RecvBuffSize,
+		compressionThreshold: config.CompressionThreshold,
+		inThrottle:           newThrottle(config.InboundLimits),
+		outThrottle:          newThrottle(config.OutboundLimits),
 	}
 	return connection
 }
 
 // ConnConfig is the configuration required to initialize a new conn
 type ConnConfig struct {
-	RecvBuffSize int
-	SendBuffSize int
+	RecvBuffSize         int
+	SendBuffSize         int
+	CompressionThreshold int
+	InboundLimits        RateLimits
+	OutboundLimits       RateLimits
 }
 
 type connection struct {
-	recvBuffSize int
-	metrics      *metrics.CommMetrics
-	cancel       context.CancelFunc
-	info         *protoext.ConnectionInfo
-	outBuff      chan *msgSending
-	logger       util.Logger        // logger
-	pkiID        common.PKIidType   // pkiID of the remote endpoint
-	handler      handler            // function to invoke upon a message reception
-	conn         *grpc.ClientConn   // gRPC connection to remote endpoint
-	cl           proto.GossipClient // gRPC stub of remote endpoint
-	gossipStream stream             // there can only be one
-	stopChan     chan struct{}      // a method to stop the server-side gRPC call from a different go-routine
-	stopOnce     sync.Once          // once to ensure close is called only once
+	recvBuffSize         int
+	compressionThreshold int       // minimum size of payloads to compress, 0 if compression is disabled
+	inThrottle           *throttle // limits the rate of received messages, nil if unlimited
+	outThrottle          *throttle // limits the rate of sent messages, nil if unlimited
+	metrics              *metrics.CommMetrics
+	cancel               context.CancelFunc
+	info                 *protoext.ConnectionInfo
+	outBuff              chan *msgSending
+	logger               util.Logger        // logger
+	pkiID                common.PKIidType   // pkiID of the remote endpoint
+	handler              handler            // function to invoke upon a message reception
+	conn                 *grpc.ClientConn   // gRPC connection to remote endpoint
+	cl                   proto.GossipClient // gRPC stub of remote endpoint
+	gossipStream         stream             // there can only be one
+	stopChan             chan struct{}      // a method to stop the server-side gRPC call from a different go-routine
+	stopOnce             sync.Once          // once to ensure close is called only once
 }
 
 func (conn *connection) close() {
@@ -289,7 +310,12 @@ func (conn *connection) writeToStream() {
 	for {
 		select {
 		case m := <-conn.outBuff:
-			err := stream.Send(m.envelope)
+			envelope := conn.compress(m.envelope)
+			if !conn.wait(conn.outThrottle, protobuf.Size(envelope), "outbound") {
+				conn.logger.Debug("Closing writing to stream")
+				return
+			}
+			err := stream.Send(envelope)
 			if err != nil {
 				go m.onErr(err)
 				return
@@ -316,6 +342,15 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *protoex
 				return
 			}
 			conn.metrics.ReceivedMessages.Add(1)
+			if !conn.wait(conn.inThrottle, protobuf.Size(envelope), "inbound") {
+				return
+			}
+			envelope, err = protoext.DecompressEnvelope(envelope, comm.DefaultMaxRecvMsgSize)
+			if err != nil {
+				errChan <- err
+				conn.logger.Warningf("Got error, aborting: %v", err)
+				return
+			}
 			msg, err := protoext.EnvelopeToGossipMessage(envelope)
 			if err != nil {
 				errChan <- err
@@ -330,6 +365,44 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *protoex
 	}
 }
 
+// compress returns the given envelope compressed if the remote peer accepts
+// compressed envelopes, the payload is large enough and compression shrinks it,
+// and otherwise returns the envelope itself
+func (conn *connection) compress(envelope *proto.Envelope) *proto.Envelope {
+	if conn.compressionThreshold <= 0 || !conn.info.Compression || len(envelope.Payload) < conn.compressionThreshold {
+		return envelope
+	}
+	compressed, err := protoext.CompressEnvelope(envelope)
+	if err != nil {
+		conn.logger.Warningf("Failed compressing message to %s: %v", conn.info.Endpoint, err)
+		return envelope
+	}
+	conn.metrics.CompressionRatio.Observe(float64(len(compressed.Payload)) / float64(len(envelope.Payload)))
+	if len(compressed.Payload) >= len(envelope.Payload) {
+		return envelope
+	}
+	return compressed
+}
+
+// wait waits until the given throttle lets a message of the given size
+// pass in the given direction, and returns false if the connection
+// was closed while waiting
+func (conn *connection) wait(t *throttle, size int, direction string) bool {
+	d := t.delay(size)
+	if d <= 0 {
+		return true
+	}
+	conn.metrics.ThrottledMessages.With("direction", direction).Add(1)
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-conn.stopChan:
+		return false
+	}
+}
+
 type msgSending struct {
 	envelope *proto.Envelope
 	onErr    func(error)
@@ -189,6 +189,16 @@ func (mock *commMock) CloseConn(peer *comm.RemotePeer) {
 	// NOOP
 }
 
+// CloseConns closes all connections to remote peers
+func (mock *commMock) CloseConns() {
+	// NOOP
+}
+
+// UpdateIdentity replaces the identity of this instance
+func (mock *commMock) UpdateIdentity(peerIdentity api.PeerIdentityType) {
+	panic("implement me")
+}
+
 // Stop stops the module
 func (mock *commMock) Stop() {
 	logger.Debug("Stopping communication module, closing all accepting channels.")
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"time"
+)
+
+// RateLimits limits the rate of messages exchanged with a remote peer,
+// in one direction. Zero values mean no limit.
+type RateLimits struct {
+	BytesPerSecond    int // Maximum number of bytes per second
+	MessagesPerSecond int // Maximum number of messages per second
+}
+
+// rateLimiter is a token bucket which holds up to a second worth of tokens.
+// Reservations which exceed the tokens in the bucket are granted, and are
+// paid for by waiting until the bucket refills.
+type rateLimiter struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate int, now time.Time) *rateLimiter {
+	return &rateLimiter{
+		rate:   float64(rate),
+		tokens: float64(rate),
+		last:   now,
+	}
+}
+
+// reserve takes n tokens from the bucket and returns how long to wait
+// before they are paid for
+func (rl *rateLimiter) reserve(n int, now time.Time) time.Duration {
+	rl.tokens += now.Sub(rl.last).Seconds() * rl.rate
+	if rl.tokens > rl.rate {
+		rl.tokens = rl.rate
+	}
+	rl.last = now
+	rl.tokens -= float64(n)
+	if rl.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-rl.tokens / rl.rate * float64(time.Second))
+}
+
+// throttleClock is the clock of the throttles of new connections
+var throttleClock = time.Now
+
+// throttle enforces RateLimits on the messages of a single connection in a
+// single direction. It is not safe for concurrent use.
+type throttle struct {
+	bytes    *rateLimiter
+	messages *rateLimiter
+	now      func() time.Time
+}
+
+// newThrottle creates a throttle for the given limits,
+// or returns nil if there are no limits
+func newThrottle(limits RateLimits) *throttle {
+	if limits.BytesPerSecond <= 0 && limits.MessagesPerSecond <= 0 {
+		return nil
+	}
+	t := &throttle{now: throttleClock}
+	now := t.now()
+	if limits.BytesPerSecond > 0 {
+		t.bytes = newRateLimiter(limits.BytesPerSecond, now)
+	}
+	if limits.MessagesPerSecond > 0 {
+		t.messages = newRateLimiter(limits.MessagesPerSecond, now)
+	}
+	return t
+}
+
+// delay accounts for a message of the given size and returns
+// how long to wait before it may be passed on
+func (t *throttle) delay(size int) time.Duration {
+	if t == nil {
+		return 0
+	}
+	now := t.now()
+	var d time.Duration
+	if t.bytes != nil {
+		d = t.bytes.reserve(size, now)
+	}
+	if t.messages != nil {
+		if md := t.messages.reserve(1, now); md > d {
+			d = md
+		}
+	}
+	return d
+}
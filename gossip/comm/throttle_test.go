@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/metrics/mocks"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottleDelay(t *testing.T) {
+	require.Nil(t, newThrottle(RateLimits{}))
+	// A nil throttle never delays
+	var unlimited *throttle
+	require.Zero(t, unlimited.delay(1000))
+
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+
+	t.Run("Bytes", func(t *testing.T) {
+		th := newThrottle(RateLimits{BytesPerSecond: 1000})
+		th.now = clock
+		th.bytes.last = now
+
+		// A second worth of bytes passes right away
+		require.Zero(t, th.delay(600))
+		require.Zero(t, th.delay(400))
+		// Further bytes wait until they are paid for
+		require.Equal(t, 500*time.Millisecond, th.delay(500))
+		now = now.Add(500 * time.Millisecond)
+		require.Equal(t, 100*time.Millisecond, th.delay(100))
+		// Messages larger than a second worth of bytes pass after waiting
+		now = now.Add(time.Hour)
+		require.Equal(t, 2*time.Second, th.delay(3000))
+	})
+
+	t.Run("Messages", func(t *testing.T) {
+		th := newThrottle(RateLimits{MessagesPerSecond: 2})
+		th.now = clock
+		th.messages.last = now
+
+		require.Zero(t, th.delay(1000000))
+		require.Zero(t, th.delay(1000000))
+		require.Equal(t, 500*time.Millisecond, th.delay(1))
+		// Tokens don't accumulate beyond a second worth of messages
+		now = now.Add(time.Hour)
+		require.Zero(t, th.delay(1))
+		require.Zero(t, th.delay(1))
+		require.Equal(t, 500*time.Millisecond, th.delay(1))
+	})
+
+	t.Run("Both", func(t *testing.T) {
+		th := newThrottle(RateLimits{BytesPerSecond: 100, MessagesPerSecond: 10})
+		th.now = clock
+		th.bytes.last = now
+		th.messages.last = now
+
+		// The longest delay of the two limits applies
+		for i := 0; i < 10; i++ {
+			require.Zero(t, th.delay(10))
+		}
+		require.Equal(t, 100*time.Millisecond, th.delay(0))
+		require.Equal(t, time.Second, th.delay(100))
+	})
+}
+
+func TestThrottledComm(t *testing.T) {
+	newComm := func(config CommConfig) (Comm, int, *mocks.TestMetricProvider) {
+		provider := mocks.TestUtilConstructMetricProvider()
+		commMetrics := metrics.NewGossipMetrics(provider.FakeProvider).CommMetrics
+		port, gRPCServer, certs, secureDialOpts, dialOpts := util.CreateGRPCLayer()
+		comm := newCommInstanceOnlyWithConfig(t, commMetrics, config, naiveSec, gRPCServer, certs, secureDialOpts, dialOpts...)
+		return comm, port, provider
+	}
+
+	// The clock of the throttles is stopped, so the bucket never refills and
+	// exactly the messages beyond a second worth of messages are throttled
+	now := time.Now()
+	throttleClock = func() time.Time { return now }
+	defer func() { throttleClock = time.Now }()
+
+	for _, direction := range []string{"outbound", "inbound"} {
+		direction := direction
+		t.Run(direction, func(t *testing.T) {
+			limits := RateLimits{MessagesPerSecond: 5}
+			senderConfig, receiverConfig := testCommConfig, testCommConfig
+			if direction == "outbound" {
+				senderConfig.OutboundLimits = limits
+			} else {
+				receiverConfig.InboundLimits = limits
+			}
+			sender, _, senderMetrics := newComm(senderConfig)
+			defer sender.Stop()
+			receiver, port, receiverMetrics := newComm(receiverConfig)
+			defer receiver.Stop()
+			throttled := senderMetrics.FakeThrottledMessages
+			if direction == "inbound" {
+				throttled = receiverMetrics.FakeThrottledMessages
+			}
+
+			inc := receiver.Accept(acceptAll)
+			for i := 0; i < 7; i++ {
+				sender.Send(createGossipMsg(), remotePeer(port))
+				select {
+				case <-inc:
+				case <-time.After(10 * time.Second):
+					t.Fatalf("message %d was not received", i)
+				}
+			}
+
+			// Messages beyond the first second worth of messages are delayed, but not dropped
+			require.Equal(t, 2, throttled.AddCallCount())
+			require.Equal(t, []string{"direction", direction}, throttled.WithArgsForCall(0))
+		})
+	}
+}
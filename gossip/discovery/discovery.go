@@ -149,12 +149,20 @@ type Discovery interface {
 	// UpdateEndpoint updates this instance's endpoint
 	UpdateEndpoint(string)
 
+	// UpdatePKIid updates this instance's PKI-ID, after its identity has been
+	// replaced. Alive messages about its former PKI-IDs are ignored from then on.
+	UpdatePKIid(pkiID common.PKIidType)
+
 	// Stops this instance
 	Stop()
 
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// GetDeadMembership returns the members in the view
+	// which are considered dead
+	GetDeadMembership() []NetworkMember
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
@@ -52,6 +52,7 @@ type gossipDiscoveryImpl struct {
 	aliveMembership  *util.MembershipStore
 	deadMembership   *util.MembershipStore
 	selfAliveMessage *protoext.SignedGossipMessage
+	formerPKIids     map[string]struct{} // PKI-IDs this instance had before its identity was replaced
 
 	msgStore *aliveMsgStore
 
@@ -96,6 +97,7 @@ func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoServi
 		deadLastTS:       make(map[string]*timestamp),
 		aliveLastTS:      make(map[string]*timestamp),
 		id2Member:        make(map[string]*NetworkMember),
+		formerPKIids:     make(map[string]struct{}),
 		aliveMembership:  util.NewMembershipStore(),
 		deadMembership:   util.NewMembershipStore(),
 		crypt:            crypt,
@@ -131,11 +133,12 @@ func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoServi
 
 // Lookup returns a network member, or nil if not found
 func (d *gossipDiscoveryImpl) Lookup(PKIID common.PKIidType) *NetworkMember {
-	if bytes.Equal(PKIID, d.self.PKIid) {
-		return &d.self
-	}
 	d.lock.RLock()
 	defer d.lock.RUnlock()
+	if bytes.Equal(PKIID, d.self.PKIid) {
+		self := d.self
+		return &self
+	}
 	return copyNetworkMember(d.id2Member[string(PKIID)])
 }
 
@@ -568,7 +571,15 @@ func (d *gossipDiscoveryImpl) purge(id common.PKIidType) {
 
 func (d *gossipDiscoveryImpl) isSentByMe(m *protoext.SignedGossipMessage) bool {
 	pkiID := m.GetAliveMsg().Membership.PkiId
-	if !equalPKIid(pkiID, d.self.PKIid) {
+	d.lock.RLock()
+	_, isFormerPKIid := d.formerPKIids[string(pkiID)]
+	isSelf := equalPKIid(pkiID, d.self.PKIid)
+	d.lock.RUnlock()
+	if isFormerPKIid {
+		d.logger.Debug("Got alive message about our former PKI-ID,", m)
+		return true
+	}
+	if !isSelf {
 		return false
 	}
 	d.logger.Debug("Got alive message about ourselves,", m)
@@ -886,7 +897,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*protoext.SignedGos
 	defer d.lock.Unlock()
 
 	for _, am := range aliveMembers {
-		if equalPKIid(am.GetAliveMsg().Membership.PkiId, d.self.PKIid) {
+		if d.isSelfPKIid(am.GetAliveMsg().Membership.PkiId) {
 			continue
 		}
 		d.aliveLastTS[string(am.GetAliveMsg().Membership.PkiId)] = &timestamp{
@@ -900,7 +911,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*protoext.SignedGos
 	}
 
 	for _, dm := range deadMembers {
-		if equalPKIid(dm.GetAliveMsg().Membership.PkiId, d.self.PKIid) {
+		if d.isSelfPKIid(dm.GetAliveMsg().Membership.PkiId) {
 			continue
 		}
 		d.deadLastTS[string(dm.GetAliveMsg().Membership.PkiId)] = &timestamp{
@@ -962,6 +973,27 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 	return response
 }
 
+func (d *gossipDiscoveryImpl) GetDeadMembership() []NetworkMember {
+	if d.toDie() {
+		return []NetworkMember{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	response := []NetworkMember{}
+	for _, m := range d.deadMembership.ToSlice() {
+		member := m.GetAliveMsg()
+		response = append(response, NetworkMember{
+			PKIid:            member.Membership.PkiId,
+			Endpoint:         member.Membership.Endpoint,
+			Metadata:         member.Membership.Metadata,
+			InternalEndpoint: d.id2Member[string(member.Membership.PkiId)].InternalEndpoint,
+			Envelope:         m.Envelope,
+		})
+	}
+	return response
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -979,6 +1011,31 @@ func (d *gossipDiscoveryImpl) UpdateEndpoint(endpoint string) {
 	d.self.Endpoint = endpoint
 }
 
+// UpdatePKIid updates this instance's PKI-ID
+func (d *gossipDiscoveryImpl) UpdatePKIid(pkiID common.PKIidType) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if equalPKIid(pkiID, d.self.PKIid) {
+		return
+	}
+	d.logger.Infof("Changing PKI-ID from %s to %s", d.self.PKIid, pkiID)
+	d.formerPKIids[string(d.self.PKIid)] = struct{}{}
+	delete(d.formerPKIids, string(pkiID))
+	d.self.PKIid = pkiID
+	// Membership responses should not carry an alive message with the former PKI-ID
+	d.selfAliveMessage = nil
+}
+
+// isSelfPKIid returns whether the given PKI-ID is the current or a former
+// PKI-ID of this instance. It should be called with the lock held.
+func (d *gossipDiscoveryImpl) isSelfPKIid(pkiID common.PKIidType) bool {
+	if equalPKIid(pkiID, d.self.PKIid) {
+		return true
+	}
+	_, isFormerPKIid := d.formerPKIids[string(pkiID)]
+	return isFormerPKIid
+}
+
 func (d *gossipDiscoveryImpl) Self() NetworkMember {
 	var env *proto.Envelope
 	msg, _ := d.aliveMsgAndInternalEndpoint()
@@ -901,6 +901,14 @@ func TestExpiration(t *testing.T) {
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
 
+	for _, inst := range instances[:len(instances)-2] {
+		var deadEndpoints []string
+		for _, member := range inst.GetDeadMembership() {
+			deadEndpoints = append(deadEndpoints, member.Endpoint)
+		}
+		require.ElementsMatch(t, []string{instances[nodeNum-1].Self().Endpoint, instances[nodeNum-2].Self().Endpoint}, deadEndpoints)
+	}
+
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
 		if i+2 == nodeNum {
@@ -1219,6 +1227,42 @@ func TestCertificateChange(t *testing.T) {
 	waitUntilOrFailBlocking(t, p3.Stop)
 }
 
+func TestUpdatePKIid(t *testing.T) {
+	bootPeers := []string{bootPeer(42621), bootPeer(42622), bootPeer(42623)}
+	p1 := createDiscoveryInstance(42621, "d1", bootPeers)
+	p2 := createDiscoveryInstance(42622, "d2", bootPeers)
+	p3 := createDiscoveryInstance(42623, "d3", bootPeers)
+	defer stopInstances(t, []*gossipInstance{p1, p2, p3})
+
+	// Wait for membership establishment
+	assertMembership(t, []*gossipInstance{p1, p2, p3}, 2)
+
+	formerPKIid := p1.Self().PKIid
+	newPKIid := common.PKIidType("new PKI-ID")
+	p1.UpdatePKIid(newPKIid)
+	require.Equal(t, newPKIid, p1.Self().PKIid)
+	require.Equal(t, "localhost:42621", p1.Lookup(newPKIid).Endpoint)
+	require.Nil(t, p1.Lookup(formerPKIid))
+
+	hasMember := func(inst *gossipInstance, pkiID common.PKIidType) bool {
+		for _, member := range inst.GetMembership() {
+			if bytes.Equal(member.PKIid, pkiID) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// The other peers learn about the new PKI-ID, and the former PKI-ID expires
+	waitUntilOrFail(t, func() bool {
+		return hasMember(p2, newPKIid) && hasMember(p3, newPKIid) &&
+			!hasMember(p2, formerPKIid) && !hasMember(p3, formerPKIid)
+	})
+	// The peer never considers alive messages about its former PKI-ID to be about another peer
+	require.Len(t, p1.GetMembership(), 2)
+	require.False(t, hasMember(p1, formerPKIid))
+}
+
 func TestMsgStoreExpiration(t *testing.T) {
 	// Starts 4 instances, wait for membership to build, stop 2 instances
 	// Check that membership in 2 running instances become 2
@@ -35,6 +35,10 @@ func (mi *msgImpl) IsDeclaration() bool {
 	return mi.msg.GetLeadershipMsg().IsDeclaration
 }
 
+func (mi *msgImpl) Priority() uint32 {
+	return protoext.LeadershipPriority(mi.msg.GetLeadershipMsg())
+}
+
 type peerImpl struct {
 	member discovery.NetworkMember
 }
@@ -127,7 +131,7 @@ func (ai *adapterImpl) Accept() <-chan Msg {
 	return msgCh
 }
 
-func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
+func (ai *adapterImpl) CreateMessage(isDeclaration bool, priority uint32) Msg {
 	ai.seqNum++
 	seqNum := ai.seqNum
 
@@ -139,6 +143,9 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 			SeqNum: seqNum,
 		},
 	}
+	if err := protoext.SetLeadershipPriority(leadershipMsg, priority); err != nil {
+		ai.logger.Warningf("Failed setting leadership priority: %+v", err)
+	}
 
 	msg := &proto.GossipMessage{
 		Nonce:   0,
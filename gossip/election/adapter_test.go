@@ -54,7 +54,7 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 
 	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"),
 		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
-	msg := adapter.CreateMessage(true)
+	msg := adapter.CreateMessage(true, 0)
 
 	if !protoext.IsLeadershipMsg(msg.(*msgImpl).msg) {
 		t.Error("Newly created message should be LeadershipMsg")
@@ -64,7 +64,7 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 		t.Error("Newly created msg should be Declaration msg")
 	}
 
-	msg = adapter.CreateMessage(false)
+	msg = adapter.CreateMessage(false, 0)
 
 	if !protoext.IsLeadershipMsg(msg.(*msgImpl).msg) {
 		t.Error("Newly created message should be LeadershipMsg")
@@ -73,6 +73,16 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	if !msg.IsProposal() || msg.IsDeclaration() {
 		t.Error("Newly created msg should be Proposal msg")
 	}
+
+	if msg.Priority() != 0 {
+		t.Error("Newly created msg should have the default priority")
+	}
+
+	msg = adapter.CreateMessage(true, 7)
+
+	if msg.Priority() != 7 {
+		t.Error("Newly created msg should advertise the given priority")
+	}
 }
 
 func TestAdapterImpl_Peers(t *testing.T) {
@@ -143,7 +153,7 @@ func TestAdapterImpl_Gossip(t *testing.T) {
 
 	sender := adapters[fmt.Sprintf("Peer%d", 0)]
 
-	sender.Gossip(sender.CreateMessage(true))
+	sender.Gossip(sender.CreateMessage(true, 0))
 
 	totalMsg := 0
 
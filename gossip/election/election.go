@@ -18,13 +18,19 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers break symmetry by comparing priorities, and then IDs.
+//   A peer with a higher priority is a better candidate for being a leader,
+//   and among peers with the same priority the peer with the lowest ID is
+//   the best candidate
 // - Each peer is either a leader or a follower,
 //   and the aim is to have exactly 1 leader if the membership view
 //   is the same for all peers
 // - If the network is partitioned into 2 or more sets, the number of leaders
 //   is the number of network partitions, but when the partition heals,
 //   only 1 leader should be left eventually
+// - A follower with a higher priority than the leader takes over the
+//   leadership, so that the leadership fails back to preferred peers
+//   once they are available again
 // - Peers communicate by gossiping leadership proposal or declaration messages
 
 // The Algorithm, in pseudo code:
@@ -50,9 +56,12 @@ import (
 //		If you are the leader:
 //			Broadcast leadership declaration
 //			If a leadership declaration was received from
-// 			a peer with a lower ID,
+// 			a better candidate,
 //			become a follower
 //		Else, you're a follower:
+//			If a leadership declaration was received from
+//			a peer with a lower priority:
+//				become the leader
 //			If haven't received a leadership declaration within
 // 			a time threshold:
 //				set leaderKnown to false
@@ -63,9 +72,12 @@ import (
 //	If received a leadership declaration:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
+// 	If a proposal message from a better candidate
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// A peer which relinquished the leadership neither proposes itself,
+// nor takes over the leadership, until it resumes.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -76,8 +88,9 @@ type LeaderElectionAdapter interface {
 	// Accept returns a channel that emits messages
 	Accept() <-chan Msg
 
-	// CreateProposalMessage
-	CreateMessage(isDeclaration bool) Msg
+	// CreateMessage creates a leadership proposal or declaration message
+	// which advertises the given priority
+	CreateMessage(isDeclaration bool, priority uint32) Msg
 
 	// Peers returns a list of peers considered alive
 	Peers() []Peer
@@ -93,12 +106,27 @@ type LeaderElectionService interface {
 	// IsLeader returns whether this peer is a leader or not
 	IsLeader() bool
 
+	// Leader returns the ID of the peer considered to be the leader,
+	// or nil if no leader is known
+	Leader() []byte
+
 	// Stop stops the LeaderElectionService
 	Stop()
 
 	// Yield relinquishes the leadership until a new leader is elected,
 	// or a timeout expires
 	Yield()
+
+	// Relinquish relinquishes the leadership and keeps the peer from
+	// becoming a leader until Resume is called
+	Relinquish()
+
+	// Resume lets the peer become a leader again after it relinquished
+	// the leadership
+	Resume()
+
+	// IsRelinquished returns whether the peer relinquished the leadership
+	IsRelinquished() bool
 }
 
 type peerID []byte
@@ -124,6 +152,8 @@ type Msg interface {
 	IsProposal() bool
 	// IsDeclaration returns whether this message is a leadership declaration
 	IsDeclaration() bool
+	// Priority returns the leader election priority of the peer sent the message
+	Priority() uint32
 }
 
 func noopCallback(_ bool) {
@@ -141,6 +171,9 @@ type ElectionConfig struct {
 	MembershipSampleInterval time.Duration
 	LeaderAliveThreshold     time.Duration
 	LeaderElectionDuration   time.Duration
+	// Priority is the leader election priority of the peer.
+	// Peers with a higher priority are preferred as leaders.
+	Priority uint32
 }
 
 // NewLeaderElectionService returns a new LeaderElectionService
@@ -154,6 +187,7 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 		adapter:       adapter,
 		stopChan:      make(chan struct{}),
 		interruptChan: make(chan struct{}, 1),
+		takeOverChan:  make(chan struct{}, 1),
 		logger:        util.GetLogger(util.ElectionLogger, ""),
 		callback:      noopCallback,
 		config:        config,
@@ -174,10 +208,14 @@ type leaderElectionSvcImpl struct {
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
+	takeOverChan  chan struct{}
 	stopWG        sync.WaitGroup
 	isLeader      int32
 	leaderExists  int32
+	leaderID      atomic.Value
 	yield         int32
+	lastYield     int64
+	relinquished  int32
 	sleeping      bool
 	adapter       LeaderElectionAdapter
 	logger        util.Logger
@@ -222,15 +260,23 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	defer le.Unlock()
 
 	if msg.IsProposal() {
-		le.proposals.Add(string(msg.SenderID()))
+		le.proposals.Add(candidate{id: string(msg.SenderID()), priority: msg.Priority()})
 	} else if msg.IsDeclaration() {
 		atomic.StoreInt32(&le.leaderExists, int32(1))
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.isBetterCandidate(msg.SenderID(), msg.Priority()) && le.IsLeader() {
 			le.stopBeingLeader()
 		}
+		if !le.IsLeader() {
+			le.leaderID.Store(msg.SenderID())
+			// The leader has a lower priority than us, so we take over
+			// the leadership from it
+			if msg.Priority() < le.config.Priority && le.canTakeOver() && len(le.takeOverChan) == 0 {
+				le.takeOverChan <- struct{}{}
+			}
+		}
 	} else {
 		// We shouldn't get here
 		le.logger.Error("Got a message that's not a proposal and not a declaration")
@@ -286,9 +332,9 @@ func (le *leaderElectionSvcImpl) run() {
 func (le *leaderElectionSvcImpl) leaderElection() {
 	le.logger.Debug(le.id, ": Entering")
 	defer le.logger.Debug(le.id, ": Exiting")
-	// If we're yielding to other peers, do not participate
-	// in leader election
-	if le.isYielding() {
+	// If we're yielding to other peers or relinquished the leadership,
+	// do not participate in leader election
+	if le.isYielding() || le.IsRelinquished() {
 		return
 	}
 	// Propose ourselves as a leader
@@ -302,15 +348,15 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 		return
 	}
 
-	if le.isYielding() {
-		le.logger.Debug(le.id, ": Aborting leader election because yielding")
+	if le.isYielding() || le.IsRelinquished() {
+		le.logger.Debug(le.id, ": Aborting leader election because yielding or relinquished")
 		return
 	}
 	// Leader doesn't exist, let's see if there is a better candidate than us
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		c := o.(candidate)
+		if le.isBetterCandidate(peerID(c.id), c.priority) {
 			return
 		}
 	}
@@ -320,11 +366,38 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	atomic.StoreInt32(&le.leaderExists, int32(1))
 }
 
+// candidate is a peer which proposed itself as a leader
+type candidate struct {
+	id       string
+	priority uint32
+}
+
+// isBetterCandidate returns whether the peer of the given ID and priority
+// is a better candidate than us for being a leader
+func (le *leaderElectionSvcImpl) isBetterCandidate(id peerID, priority uint32) bool {
+	if priority != le.config.Priority {
+		return priority > le.config.Priority
+	}
+	return bytes.Compare(id, le.id) < 0
+}
+
+// canTakeOver returns whether we may take over the leadership from
+// a leader with a lower priority
+func (le *leaderElectionSvcImpl) canTakeOver() bool {
+	if le.isYielding() || le.IsRelinquished() {
+		return false
+	}
+	// Do not take back the leadership right after yielding it,
+	// since the reason we yielded might still hold
+	lastYield := time.Unix(0, atomic.LoadInt64(&le.lastYield))
+	return time.Since(lastYield) >= le.config.LeaderAliveThreshold*6
+}
+
 // propose sends a leadership proposal message to remote peers
 func (le *leaderElectionSvcImpl) propose() {
 	le.logger.Debug(le.id, ": Entering")
 	le.logger.Debug(le.id, ": Exiting")
-	leadershipProposal := le.adapter.CreateMessage(false)
+	leadershipProposal := le.adapter.CreateMessage(false, le.config.Priority)
 	le.adapter.Gossip(leadershipProposal)
 }
 
@@ -337,12 +410,26 @@ func (le *leaderElectionSvcImpl) follower() {
 	le.adapter.ReportMetrics(false)
 	select {
 	case <-time.After(le.config.LeaderAliveThreshold):
+	case <-le.takeOverChan:
+		le.takeOver()
 	case <-le.stopChan:
 	}
 }
 
+// takeOver makes us the leader instead of a leader with a lower priority
+func (le *leaderElectionSvcImpl) takeOver() {
+	le.Lock()
+	defer le.Unlock()
+	if le.IsLeader() || !le.canTakeOver() {
+		return
+	}
+	le.logger.Info(le.id, ": Taking over the leadership from a peer with a lower priority")
+	le.beLeader()
+	atomic.StoreInt32(&le.leaderExists, int32(1))
+}
+
 func (le *leaderElectionSvcImpl) leader() {
-	leaderDeclaration := le.adapter.CreateMessage(true)
+	leaderDeclaration := le.adapter.CreateMessage(true, le.config.Priority)
 	le.adapter.Gossip(leaderDeclaration)
 	le.adapter.ReportMetrics(true)
 	le.waitForInterrupt(le.config.LeaderAliveThreshold / 2)
@@ -394,8 +481,22 @@ func (le *leaderElectionSvcImpl) IsLeader() bool {
 	return isLeader
 }
 
+// Leader returns the ID of the peer considered to be the leader,
+// or nil if no leader is known
+func (le *leaderElectionSvcImpl) Leader() []byte {
+	leader, _ := le.leaderID.Load().(peerID)
+	if leader == nil {
+		return nil
+	}
+	if !bytes.Equal(leader, le.id) && !le.isAlive(leader) {
+		return nil
+	}
+	return leader
+}
+
 func (le *leaderElectionSvcImpl) beLeader() {
 	le.logger.Info(le.id, ": Becoming a leader")
+	le.leaderID.Store(le.id)
 	atomic.StoreInt32(&le.isLeader, int32(1))
 	le.callback(true)
 }
@@ -403,6 +504,7 @@ func (le *leaderElectionSvcImpl) beLeader() {
 func (le *leaderElectionSvcImpl) stopBeingLeader() {
 	le.logger.Info(le.id, "Stopped being a leader")
 	atomic.StoreInt32(&le.isLeader, int32(0))
+	le.leaderID.Store(peerID(nil))
 	le.callback(false)
 }
 
@@ -437,6 +539,7 @@ func (le *leaderElectionSvcImpl) Yield() {
 	}
 	// Turn on the yield flag
 	atomic.StoreInt32(&le.yield, int32(1))
+	atomic.StoreInt64(&le.lastYield, time.Now().UnixNano())
 	// Stop being a leader
 	le.stopBeingLeader()
 	// Clear the leader exists flag since it could be that we are the leader
@@ -447,6 +550,48 @@ func (le *leaderElectionSvcImpl) Yield() {
 	})
 }
 
+// IsRelinquished returns whether the peer relinquished the leadership
+func (le *leaderElectionSvcImpl) IsRelinquished() bool {
+	return atomic.LoadInt32(&le.relinquished) == int32(1)
+}
+
+// Relinquish relinquishes the leadership and keeps the peer from
+// becoming a leader until Resume is called
+func (le *leaderElectionSvcImpl) Relinquish() {
+	le.Lock()
+	defer le.Unlock()
+	le.logger.Info(le.id, ": Relinquishing the leadership")
+	atomic.StoreInt32(&le.relinquished, int32(1))
+	le.drainTakeOverChannel()
+	if !le.IsLeader() {
+		return
+	}
+	le.stopBeingLeader()
+	// Clear the leader exists flag since we were the leader
+	atomic.StoreInt32(&le.leaderExists, int32(0))
+	// Wake up the leader routine so that it stops declaring leadership
+	if le.sleeping && len(le.interruptChan) == 0 {
+		le.interruptChan <- struct{}{}
+	}
+}
+
+// Resume lets the peer become a leader again after it relinquished
+// the leadership
+func (le *leaderElectionSvcImpl) Resume() {
+	le.Lock()
+	defer le.Unlock()
+	if atomic.CompareAndSwapInt32(&le.relinquished, int32(1), int32(0)) {
+		le.logger.Info(le.id, ": Resuming participation in leader election")
+	}
+}
+
+// drainTakeOverChannel clears the takeOverChan if needed
+func (le *leaderElectionSvcImpl) drainTakeOverChannel() {
+	if len(le.takeOverChan) == 1 {
+		<-le.takeOverChan
+	}
+}
+
 // Stop stops the LeaderElectionService
 func (le *leaderElectionSvcImpl) Stop() {
 	select {
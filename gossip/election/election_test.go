@@ -35,6 +35,7 @@ func init() {
 type msg struct {
 	sender   string
 	proposal bool
+	priority uint32
 }
 
 func (m *msg) SenderID() peerID {
@@ -49,6 +50,10 @@ func (m *msg) IsDeclaration() bool {
 	return !m.proposal
 }
 
+func (m *msg) Priority() uint32 {
+	return m.priority
+}
+
 type peer struct {
 	mockedMethods map[string]struct{}
 	mock.Mock
@@ -101,8 +106,8 @@ func (p *peer) Accept() <-chan Msg {
 	return (<-chan Msg)(p.msgChan)
 }
 
-func (p *peer) CreateMessage(isDeclaration bool) Msg {
-	return &msg{proposal: !isDeclaration, sender: p.id}
+func (p *peer) CreateMessage(isDeclaration bool, priority uint32) Msg {
+	return &msg{proposal: !isDeclaration, sender: p.id, priority: priority}
 }
 
 func (p *peer) Peers() []Peer {
@@ -159,6 +164,10 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 }
 
 func createPeerWithCostumeMetrics(id int, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
+	return createPeerWithPriority(id, 0, peerMap, l, f)
+}
+
+func createPeerWithPriority(id int, priority uint32, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
 	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
@@ -168,6 +177,7 @@ func createPeerWithCostumeMetrics(id int, peerMap map[string]*peer, l *sync.RWMu
 		MembershipSampleInterval: testMembershipSampleInterval,
 		LeaderAliveThreshold:     testLeaderAliveThreshold,
 		LeaderElectionDuration:   testLeaderElectionDuration,
+		Priority:                 priority,
 	}
 	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback, config)
 	l.Lock()
@@ -250,6 +260,10 @@ func TestInitPeersAtSameTime(t *testing.T) {
 	require.True(t, isP0leader, "p0 isn't a leader. Leaders are: %v", leaders)
 	require.Len(t, leaders, 1, "More than 1 leader elected")
 	waitForBoolFunc(t, peers[len(peers)-1].isLeaderFromCallback, true, "Leadership callback result is wrong for ", peers[len(peers)-1].id)
+	for _, p := range peers {
+		p := p
+		waitForBoolFunc(t, func() bool { return string(p.Leader()) == "p0" }, true, "p0 isn't the leader known to ", p.id)
+	}
 }
 
 func TestInitPeersStartAtIntervals(t *testing.T) {
@@ -457,6 +471,97 @@ func TestPartition(t *testing.T) {
 	}
 }
 
+func TestPriority(t *testing.T) {
+	// Scenario: Peers are spawned at the same time, and one of them
+	// has a higher priority than the others
+	// expected outcome: the peer with the highest priority is the leader
+	// although its ID is the highest
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	var peers []*peer
+	for _, id := range []int{0, 1, 2} {
+		peers = append(peers, createPeer(id, peerMap, l))
+	}
+	peers = append(peers, createPeerWithPriority(3, 1, peerMap, l, func(mock.Arguments) {}))
+	leaders := waitForLeaderElection(t, peers)
+	require.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	require.Equal(t, "p3", leaders[0])
+	for _, p := range peers {
+		p := p
+		waitForBoolFunc(t, func() bool { return string(p.Leader()) == "p3" }, true, "p3 isn't the leader known to ", p.id)
+	}
+}
+
+func TestPriorityFailback(t *testing.T) {
+	// Scenario: Peers with the default priority spawn and a leader is elected.
+	// Then, a peer with a higher priority spawns.
+	// expected outcome: the peer with the higher priority takes over the leadership,
+	// and the previous leader becomes a follower
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	peers := []*peer{createPeer(0, peerMap, l), createPeer(1, peerMap, l)}
+	leaders := waitForLeaderElection(t, peers)
+	require.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	require.Equal(t, "p0", leaders[0])
+
+	peers = append(peers, createPeerWithPriority(2, 5, peerMap, l, func(mock.Arguments) {}))
+	waitForBoolFunc(t, peers[2].IsLeader, true, "p2 didn't take over the leadership")
+	waitForBoolFunc(t, peers[0].IsLeader, false, "p0 didn't step down")
+	waitForBoolFunc(t, peers[0].isLeaderFromCallback, false, "Leadership callback result is wrong for p0")
+	leaders = waitForLeaderElection(t, peers)
+	require.Equal(t, []string{"p2"}, leaders)
+}
+
+func TestRelinquish(t *testing.T) {
+	// Scenario: Peers spawn and the peer with the highest priority is elected.
+	// Then, it relinquishes its leadership.
+	// Expected outcome:
+	// (1) A new leader is elected, and the peer doesn't take back its leadership
+	// (2) Once the peer resumes, it takes back its leadership
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	peers := []*peer{
+		createPeerWithPriority(2, 1, peerMap, l, func(mock.Arguments) {}),
+		createPeer(0, peerMap, l),
+		createPeer(1, peerMap, l),
+	}
+	leaders := waitForLeaderElection(t, peers)
+	require.Len(t, leaders, 1, "Only 1 leader should have been elected")
+	require.Equal(t, "p2", leaders[0])
+
+	peers[0].Relinquish()
+	require.True(t, peers[0].IsRelinquished())
+	require.False(t, peers[0].IsLeader())
+	require.False(t, peers[0].isLeaderFromCallback())
+
+	ensureP2isNotAleader := func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] != "p2"
+	}
+	waitForBoolFunc(t, ensureP2isNotAleader, true)
+	time.Sleep(testLeaderAliveThreshold * 2)
+	waitForBoolFunc(t, ensureP2isNotAleader, true)
+
+	peers[0].Resume()
+	require.False(t, peers[0].IsRelinquished())
+	waitForBoolFunc(t, peers[0].IsLeader, true, "p2 didn't take back its leadership")
+	waitForBoolFunc(t, func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] == "p2"
+	}, true)
+}
+
+func TestIsBetterCandidate(t *testing.T) {
+	le := &leaderElectionSvcImpl{
+		id:     peerID("p1"),
+		config: ElectionConfig{Priority: 5},
+	}
+	require.True(t, le.isBetterCandidate(peerID("p0"), 5))
+	require.False(t, le.isBetterCandidate(peerID("p2"), 5))
+	require.True(t, le.isBetterCandidate(peerID("p2"), 6))
+	require.False(t, le.isBetterCandidate(peerID("p0"), 4))
+}
+
 func Test_peerIDString(t *testing.T) {
 	tests := []struct {
 		input    peerID
@@ -132,6 +132,20 @@ func (cs *certStore) createIdentityMessage() (*protoext.SignedGossipMessage, err
 	return sMsg, errors.WithStack(err)
 }
 
+// updateSelfIdentity disseminates the given identity of this peer
+// instead of its former identity
+func (cs *certStore) updateSelfIdentity(selfIdentity api.PeerIdentityType) error {
+	formerPKIID := cs.idMapper.GetPKIidOfCert(cs.selfIdentity)
+	cs.selfIdentity = selfIdentity
+	selfIDMsg, err := cs.createIdentityMessage()
+	if err != nil {
+		return errors.WithMessage(err, "failed creating self identity message")
+	}
+	cs.pull.Add(selfIDMsg)
+	cs.pull.Remove(string(formerPKIID))
+	return nil
+}
+
 func (cs *certStore) suspectPeers(isSuspected api.PeerSuspector) {
 	cs.idMapper.SuspectPeers(isSuspected)
 }
@@ -60,6 +60,9 @@ type GossipChannel interface {
 	// GetPeers returns a list of peers with metadata as published by them
 	GetPeers() []discovery.NetworkMember
 
+	// Status returns the state of the channel as known by the peer
+	Status() *Status
+
 	// PeerFilter receives a SubChannelSelectionCriteria and returns a RoutingFilter that selects
 	// only peer identities that match the given criteria
 	PeerFilter(api.SubChannelSelectionCriteria) filter.RoutingFilter
@@ -75,6 +78,10 @@ type GossipChannel interface {
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode)
 
+	// UpdatePKIid updates the PKI-ID the peer publishes its state info
+	// with in the channel, after its identity has been replaced
+	UpdatePKIid(pkiID common.PKIidType)
+
 	// IsOrgInChannel returns whether the given organization is in the channel
 	IsOrgInChannel(membersOrg api.OrgIdentityType) bool
 
@@ -99,6 +106,16 @@ type GossipChannel interface {
 	Stop()
 }
 
+// Status is the state of a channel as known by the peer
+type Status struct {
+	// Members are the alive members of the channel with the properties
+	// published in their StateInfo messages, including members which
+	// left the channel
+	Members []discovery.NetworkMember
+	// BlocksInStore is the number of blocks held for dissemination
+	BlocksInStore int
+}
+
 // Adapter enables the gossipChannel
 // to communicate with gossipServiceImpl.
 type Adapter interface {
@@ -142,7 +159,7 @@ type gossipChannel struct {
 	sync.RWMutex
 	shouldGossipStateInfo     int32
 	mcs                       api.MessageCryptoService
-	pkiID                     common.PKIidType
+	pkiID                     atomic.Value
 	selfOrg                   api.OrgIdentityType
 	stopChan                  chan struct{}
 	selfStateInfoMsg          *proto.GossipMessage
@@ -191,7 +208,6 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 	gc := &gossipChannel{
 		incTime:                   uint64(time.Now().UnixNano()),
 		selfOrg:                   org,
-		pkiID:                     pkiID,
 		mcs:                       mcs,
 		Adapter:                   adapter,
 		stopChan:                  make(chan struct{}, 1),
@@ -208,6 +224,7 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 		gc.logger = logger
 	}
 
+	gc.pkiID.Store(pkiID)
 	gc.memFilter = &membershipFilter{adapter: gc.Adapter, gossipChannel: gc}
 
 	comparator := protoext.NewGossipMessageComparator(adapter.GetConf().MaxBlockCountToStore)
@@ -232,7 +249,7 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 	verifyStateInfoMsg := func(msg *protoext.SignedGossipMessage, orgs ...api.OrgIdentityType) bool {
 		si := msg.GetStateInfo()
 		// No point in verifying ourselves
-		if bytes.Equal(gc.pkiID, si.PkiId) {
+		if bytes.Equal(gc.selfPKIid(), si.PkiId) {
 			return true
 		}
 		peerIdentity := adapter.GetIdentityByPKIID(si.PkiId)
@@ -387,6 +404,26 @@ func (gc *gossipChannel) GetPeers() []discovery.NetworkMember {
 	return members
 }
 
+// Status returns the state of the channel as known by the peer
+func (gc *gossipChannel) Status() *Status {
+	status := &Status{
+		BlocksInStore: gc.blockMsgStore.Size(),
+	}
+	for _, member := range gc.GetMembership() {
+		if !gc.EligibleForChannel(member) {
+			continue
+		}
+		stateInf := gc.stateInfoMsgStore.MsgByID(member.PKIid)
+		if stateInf == nil {
+			continue
+		}
+		member.Properties = stateInf.GetStateInfo().Properties
+		member.Envelope = stateInf.Envelope
+		status.Members = append(status.Members, member)
+	}
+	return status
+}
+
 func (gc *gossipChannel) requestStateInfo() {
 	req, err := gc.createStateInfoRequest()
 	if err != nil {
@@ -907,7 +944,7 @@ func (gc *gossipChannel) createStateInfoRequest() (*protoext.SignedGossipMessage
 		Nonce: 0,
 		Content: &proto.GossipMessage_StateInfoPullReq{
 			StateInfoPullReq: &proto.StateInfoPullRequest{
-				Channel_MAC: GenerateMAC(gc.pkiID, gc.chainID),
+				Channel_MAC: GenerateMAC(gc.selfPKIid(), gc.chainID),
 			},
 		},
 	})
@@ -953,6 +990,33 @@ func (gc *gossipChannel) UpdateChaincodes(chaincodes []*proto.Chaincode) {
 	atomic.StoreInt32(&gc.shouldGossipStateInfo, int32(1))
 }
 
+// UpdatePKIid updates the PKI-ID the peer publishes its state info
+// with in the channel, after its identity has been replaced
+func (gc *gossipChannel) UpdatePKIid(pkiID common.PKIidType) {
+	// Publish the signed state info message right away, so that other
+	// peers don't have to wait to learn about the new PKI-ID
+	defer gc.publishSignedStateInfoMessage()
+
+	gc.Lock()
+	defer gc.Unlock()
+
+	gc.pkiID.Store(pkiID)
+	var ledgerHeight uint64 = 1
+	var chaincodes []*proto.Chaincode
+	var leftChannel bool
+	if prevMsg := gc.selfStateInfoMsg; prevMsg != nil {
+		ledgerHeight = prevMsg.GetStateInfo().Properties.LedgerHeight
+		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
+		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
+	}
+	gc.updateProperties(ledgerHeight, chaincodes, leftChannel)
+	atomic.StoreInt32(&gc.shouldGossipStateInfo, int32(1))
+}
+
+func (gc *gossipChannel) selfPKIid() common.PKIidType {
+	return gc.pkiID.Load().(common.PKIidType)
+}
+
 // UpdateStateInfo updates this channel's StateInfo message
 // that is periodically published
 func (gc *gossipChannel) updateStateInfo(msg *proto.GossipMessage) {
@@ -961,9 +1025,10 @@ func (gc *gossipChannel) updateStateInfo(msg *proto.GossipMessage) {
 }
 
 func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*proto.Chaincode, leftChannel bool) {
+	pkiID := gc.selfPKIid()
 	stateInfMsg := &proto.StateInfo{
-		Channel_MAC: GenerateMAC(gc.pkiID, gc.chainID),
-		PkiId:       gc.pkiID,
+		Channel_MAC: GenerateMAC(pkiID, gc.chainID),
+		PkiId:       pkiID,
 		Timestamp: &proto.PeerTime{
 			IncNum: gc.incTime,
 			SeqNum: uint64(time.Now().UnixNano()),
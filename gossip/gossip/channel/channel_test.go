@@ -333,6 +333,29 @@ func TestSelf(t *testing.T) {
 	require.Equal(t, gMsg.GetStateInfo().PkiId, []byte("1"))
 }
 
+func TestUpdatePKIid(t *testing.T) {
+	cs := &cryptoService{}
+	jcm := &joinChanMsg{
+		members2AnchorPeers: map[string][]api.AnchorPeer{
+			string(orgInChannelA): {},
+		},
+	}
+	adapter := new(gossipAdapterMock)
+	configureAdapter(adapter)
+	adapter.On("Gossip", mock.Anything)
+	gc := NewGossipChannel(common.PKIidType("1"), orgInChannelA, cs, channelA, adapter, jcm, disabledMetrics, nil)
+	defer gc.Stop()
+	gc.UpdateLedgerHeight(5)
+
+	gc.UpdatePKIid(common.PKIidType("2"))
+	// The state info is published right away with the new PKI-ID, and the same properties
+	adapter.AssertCalled(t, "Gossip", gc.Self())
+	stateInfo := gc.Self().GetStateInfo()
+	require.Equal(t, []byte("2"), stateInfo.PkiId)
+	require.Equal(t, GenerateMAC(common.PKIidType("2"), channelA), stateInfo.Channel_MAC)
+	require.Equal(t, uint64(5), stateInfo.Properties.LedgerHeight)
+}
+
 func TestMsgStoreNotExpire(t *testing.T) {
 	cs := &cryptoService{}
 
@@ -474,6 +497,13 @@ func TestLeaveChannel(t *testing.T) {
 	require.Len(t, gc.GetPeers(), 1)
 	// Ensure peer in org1 remained and peer in org2 is skipped
 	require.Equal(t, pkiIDInOrg1, gc.GetPeers()[0].PKIid)
+	// Ensure the status of the channel still includes the peer in org2
+	status := gc.Status()
+	require.Equal(t, 1, status.BlocksInStore)
+	require.Len(t, status.Members, 2)
+	for _, member := range status.Members {
+		require.Equal(t, bytes.Equal(pkiIDinOrg2, member.PKIid), member.Properties.LeftChannel)
+	}
 	var digestSendTime int32
 	var DigestSentWg sync.WaitGroup
 	DigestSentWg.Add(1)
@@ -112,6 +112,14 @@ func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, channelID co
 	}
 }
 
+func (cs *channelState) updatePKIid(pkiID common.PKIidType) {
+	cs.RLock()
+	defer cs.RUnlock()
+	for _, gc := range cs.channels {
+		gc.UpdatePKIid(pkiID)
+	}
+}
+
 type gossipAdapterImpl struct {
 	*Node
 	discovery.Discovery
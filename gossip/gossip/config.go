@@ -81,6 +81,13 @@ type Config struct {
 	RecvBuffSize int
 	// SendBuffSize is the buffer size of sending message.
 	SendBuffSize int
+	// CompressionThreshold is the minimum size of message payloads which are compressed
+	// when sent to peers which also enable compression. 0 disables compression.
+	CompressionThreshold int
+	// InboundRateLimits limits the rate of messages received from each remote peer.
+	InboundRateLimits comm.RateLimits
+	// OutboundRateLimits limits the rate of messages sent to each remote peer.
+	OutboundRateLimits comm.RateLimits
 
 	// MsgExpirationTimeout indicate leadership message expiration timeout.
 	MsgExpirationTimeout time.Duration
@@ -141,6 +148,15 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 	c.ConnTimeout = util.GetDurationOrDefault("peer.gossip.connTimeout", comm.DefConnTimeout)
 	c.RecvBuffSize = util.GetIntOrDefault("peer.gossip.recvBuffSize", comm.DefRecvBuffSize)
 	c.SendBuffSize = util.GetIntOrDefault("peer.gossip.sendBuffSize", comm.DefSendBuffSize)
+	c.CompressionThreshold = viper.GetInt("peer.gossip.compression.threshold")
+	c.InboundRateLimits = comm.RateLimits{
+		BytesPerSecond:    viper.GetInt("peer.gossip.rateLimits.inbound.bytesPerSecond"),
+		MessagesPerSecond: viper.GetInt("peer.gossip.rateLimits.inbound.messagesPerSecond"),
+	}
+	c.OutboundRateLimits = comm.RateLimits{
+		BytesPerSecond:    viper.GetInt("peer.gossip.rateLimits.outbound.bytesPerSecond"),
+		MessagesPerSecond: viper.GetInt("peer.gossip.rateLimits.outbound.messagesPerSecond"),
+	}
 	c.MsgExpirationTimeout = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold) * 10
 	c.AliveTimeInterval = util.GetDurationOrDefault("peer.gossip.aliveTimeInterval", discovery.DefAliveTimeInterval)
 	c.AliveExpirationTimeout = util.GetDurationOrDefault("peer.gossip.aliveExpirationTimeout", 5*c.AliveTimeInterval)
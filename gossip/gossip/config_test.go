@@ -50,6 +50,11 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.connTimeout", "16s")
 	viper.Set("peer.gossip.recvBuffSize", 17)
 	viper.Set("peer.gossip.sendBuffSize", 18)
+	viper.Set("peer.gossip.compression.threshold", 23)
+	viper.Set("peer.gossip.rateLimits.inbound.bytesPerSecond", 24)
+	viper.Set("peer.gossip.rateLimits.inbound.messagesPerSecond", 25)
+	viper.Set("peer.gossip.rateLimits.outbound.bytesPerSecond", 26)
+	viper.Set("peer.gossip.rateLimits.outbound.messagesPerSecond", 27)
 	viper.Set("peer.gossip.election.leaderAliveThreshold", "19s")
 	viper.Set("peer.gossip.aliveTimeInterval", "20s")
 	viper.Set("peer.gossip.aliveExpirationTimeout", "21s")
@@ -91,6 +96,9 @@ func TestGlobalConfig(t *testing.T) {
 		ConnTimeout:                  16 * time.Second,
 		RecvBuffSize:                 17,
 		SendBuffSize:                 18,
+		CompressionThreshold:         23,
+		InboundRateLimits:            comm.RateLimits{BytesPerSecond: 24, MessagesPerSecond: 25},
+		OutboundRateLimits:           comm.RateLimits{BytesPerSecond: 26, MessagesPerSecond: 27},
 		MsgExpirationTimeout:         19 * time.Second * 10, // LeaderAliveThreshold * 10
 		AliveTimeInterval:            20 * time.Second,
 		AliveExpirationTimeout:       21 * time.Second,
@@ -8,6 +8,7 @@ package gossip
 
 import (
 	"bytes"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"reflect"
@@ -43,6 +44,7 @@ type channelRoutingFilterFactory func(channel.GossipChannel) filter.RoutingFilte
 
 // Node is a member of a gossip network
 type Node struct {
+	identityLock          sync.Mutex
 	selfIdentity          api.PeerIdentityType
 	includeIdentityPeriod time.Time
 	certStore             *certStore
@@ -101,10 +103,13 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	}, sa)
 
 	commConfig := comm.CommConfig{
-		DialTimeout:  conf.DialTimeout,
-		ConnTimeout:  conf.ConnTimeout,
-		RecvBuffSize: conf.RecvBuffSize,
-		SendBuffSize: conf.SendBuffSize,
+		DialTimeout:          conf.DialTimeout,
+		ConnTimeout:          conf.ConnTimeout,
+		RecvBuffSize:         conf.RecvBuffSize,
+		SendBuffSize:         conf.SendBuffSize,
+		CompressionThreshold: conf.CompressionThreshold,
+		InboundLimits:        conf.InboundRateLimits,
+		OutboundLimits:       conf.OutboundRateLimits,
 	}
 	g.comm, err = comm.NewCommInstance(s, conf.TLSCerts, g.idMapper, selfIdentity, secureDialOpts, sa,
 		gossipMetrics.CommMetrics, commConfig)
@@ -712,6 +717,11 @@ func (g *Node) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
 }
 
+// DeadPeers returns the NetworkMembers considered dead
+func (g *Node) DeadPeers() []discovery.NetworkMember {
+	return g.disc.GetDeadMembership()
+}
+
 // PeersOfChannel returns the NetworkMembers considered alive
 // and also subscribed to the channel given
 func (g *Node) PeersOfChannel(channel common.ChannelID) []discovery.NetworkMember {
@@ -724,6 +734,16 @@ func (g *Node) PeersOfChannel(channel common.ChannelID) []discovery.NetworkMembe
 	return gc.GetPeers()
 }
 
+// ChannelStatus returns the state of the given channel as known by
+// the peer, or nil if the peer is not in the channel
+func (g *Node) ChannelStatus(chain common.ChannelID) *channel.Status {
+	gc := g.chanState.getGossipChannelByChainID(chain)
+	if gc == nil {
+		return nil
+	}
+	return gc.Status()
+}
+
 // SelfMembershipInfo returns the peer's membership information
 func (g *Node) SelfMembershipInfo() discovery.NetworkMember {
 	return g.disc.Self()
@@ -772,6 +792,52 @@ func (g *Node) UpdateMetadata(md []byte) {
 	g.disc.UpdateMetadata(md)
 }
 
+// UpdateIdentity replaces the identity of this peer with the given identity,
+// which has to be of the same organization. Messages are sent with the PKI-ID
+// of the new identity from now on, and the new identity is disseminated to
+// other peers. Connections to other peers are re-established with it.
+func (g *Node) UpdateIdentity(selfIdentity api.PeerIdentityType) error {
+	g.identityLock.Lock()
+	defer g.identityLock.Unlock()
+
+	if org := g.secAdvisor.OrgByPeerIdentity(selfIdentity); !bytes.Equal(org, g.selfOrg) {
+		return errors.Errorf("identity is of organization %s, but the peer is of organization %s", string(org), string(g.selfOrg))
+	}
+	formerPKIID := g.comm.GetPKIid()
+	pkiID, err := g.idMapper.SetSelfIdentity(selfIdentity)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(pkiID, formerPKIID) {
+		return nil
+	}
+	if err := g.certStore.updateSelfIdentity(selfIdentity); err != nil {
+		return err
+	}
+	g.selfIdentity = selfIdentity
+	// Include the new identity in alive messages, so that peers which learn
+	// about the new PKI-ID don't need to pull the identity separately
+	g.disSecAdap.updateIdentity(selfIdentity, time.Now().Add(g.conf.PublishCertPeriod))
+	g.disc.UpdatePKIid(pkiID)
+	g.comm.UpdateIdentity(selfIdentity)
+	g.chanState.updatePKIid(pkiID)
+	g.logger.Infof("Updated identity, PKI-ID changed from %s to %s", formerPKIID, pkiID)
+	return nil
+}
+
+// UpdateTLSCertificates replaces the TLS certificates the peer uses in
+// gossip connections, and closes all connections so that they are
+// re-established with the new certificates
+func (g *Node) UpdateTLSCertificates(serverCert, clientCert tls.Certificate) {
+	if g.conf.TLSCerts == nil {
+		return
+	}
+	g.conf.TLSCerts.TLSServerCert.Store(&serverCert)
+	g.conf.TLSCerts.TLSClientCert.Store(&clientCert)
+	g.comm.CloseConns()
+	g.logger.Info("Updated TLS certificates")
+}
+
 // UpdateLedgerHeight updates the ledger height the peer
 // publishes to other peers in the channel
 func (g *Node) UpdateLedgerHeight(height uint64, channelID common.ChannelID) {
@@ -979,6 +1045,7 @@ func (da *discoveryAdapter) CloseConn(peer *discovery.NetworkMember) {
 }
 
 type discoverySecurityAdapter struct {
+	lock                  sync.RWMutex
 	identity              api.PeerIdentityType
 	includeIdentityPeriod time.Time
 	idMapper              identity.Mapper
@@ -1000,6 +1067,15 @@ func (g *Node) newDiscoverySecurityAdapter() *discoverySecurityAdapter {
 	}
 }
 
+// updateIdentity makes alive messages carry the given identity, until the
+// given time passes
+func (sa *discoverySecurityAdapter) updateIdentity(identity api.PeerIdentityType, includeIdentityPeriod time.Time) {
+	sa.lock.Lock()
+	defer sa.lock.Unlock()
+	sa.identity = identity
+	sa.includeIdentityPeriod = includeIdentityPeriod
+}
+
 // validateAliveMsg validates that an Alive message is authentic
 func (sa *discoverySecurityAdapter) ValidateAliveMsg(m *protoext.SignedGossipMessage) bool {
 	am := m.GetAliveMsg()
@@ -1039,9 +1115,11 @@ func (sa *discoverySecurityAdapter) SignMessage(m *pg.GossipMessage, internalEnd
 	signer := func(msg []byte) ([]byte, error) {
 		return sa.mcs.Sign(msg)
 	}
+	sa.lock.RLock()
 	if protoext.IsAliveMsg(m) && time.Now().Before(sa.includeIdentityPeriod) {
 		m.GetAliveMsg().Identity = sa.identity
 	}
+	sa.lock.RUnlock()
 	sMsg := &protoext.SignedGossipMessage{
 		GossipMessage: m,
 	}
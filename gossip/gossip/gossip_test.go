@@ -1382,6 +1382,68 @@ func TestSendByCriteria(t *testing.T) {
 	require.Equal(t, uint32(1), atomic.LoadUint32(&messagesSent))
 }
 
+func TestUpdateIdentity(t *testing.T) {
+	// Scenario: spawn 3 peers in a channel, and replace the identity of the last one.
+	// Eventually, the rest of the peers should know the peer only by its new PKI-ID,
+	// both in the membership and in the channel, and know its new identity.
+
+	port0, grpc0, certs0, secDialOpts0, _ := util.CreateGRPCLayer()
+	boot := newGossipInstanceWithGRPC(0, port0, grpc0, certs0, secDialOpts0, 100)
+	p1 := newGossipInstanceCreateGRPC(1, 100, port0)
+	p2 := newGossipInstanceCreateGRPC(2, 100, port0)
+	peers := []*gossipGRPC{boot, p1, p2}
+	defer stopPeers(peers)
+	for _, p := range peers {
+		p.JoinChan(&joinChanMsg{}, common.ChannelID("A"))
+		p.UpdateLedgerHeight(1, common.ChannelID("A"))
+	}
+
+	hasPeer := func(members []discovery.NetworkMember, pkiID common.PKIidType) bool {
+		for _, member := range members {
+			if bytes.Equal(member.PKIid, pkiID) {
+				return true
+			}
+		}
+		return false
+	}
+	formerPKIID := p2.SelfMembershipInfo().PKIid
+	waitUntilOrFail(t, func() bool {
+		return hasPeer(boot.PeersOfChannel(common.ChannelID("A")), formerPKIID) &&
+			hasPeer(p1.PeersOfChannel(common.ChannelID("A")), formerPKIID)
+	}, "waiting for all peers to form the membership of the channel")
+
+	// Identities which can't be used are rejected
+	p2.Node.mcs.(*naiveCryptoService).revoke(common.PKIidType("revoked identity"))
+	err := p2.UpdateIdentity(api.PeerIdentityType("revoked identity"))
+	require.EqualError(t, err, "failed putting our own identity into the identity mapper: revoked")
+	require.Equal(t, formerPKIID, p2.SelfMembershipInfo().PKIid)
+
+	newPKIID := common.PKIidType("new identity")
+	require.NoError(t, p2.UpdateIdentity(api.PeerIdentityType("new identity")))
+	require.Equal(t, newPKIID, p2.SelfMembershipInfo().PKIid)
+	require.Equal(t, []byte(newPKIID), p2.SelfChannelInfo(common.ChannelID("A")).GetStateInfo().PkiId)
+
+	knowsNewIdentity := func(p *gossipGRPC) bool {
+		for _, identity := range p.IdentityInfo() {
+			if bytes.Equal(identity.PKIId, newPKIID) {
+				return true
+			}
+		}
+		return false
+	}
+	waitUntilOrFail(t, func() bool {
+		for _, p := range []*gossipGRPC{boot, p1} {
+			if !hasPeer(p.PeersOfChannel(common.ChannelID("A")), newPKIID) || !knowsNewIdentity(p) {
+				return false
+			}
+			if hasPeer(p.Peers(), formerPKIID) {
+				return false
+			}
+		}
+		return true
+	}, "waiting for the peers to learn about the new identity")
+}
+
 func TestIdentityExpiration(t *testing.T) {
 	// Scenario: spawn 5 peers and make the MessageCryptoService revoke one of the first 4.
 	// The last peer's certificate expires after a few seconds.
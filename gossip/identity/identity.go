@@ -45,6 +45,11 @@ type Mapper interface {
 	// SuspectPeers re-validates all peers that match the given predicate
 	SuspectPeers(isSuspected api.PeerSuspector)
 
+	// SetSelfIdentity replaces the identity of this peer with the given identity,
+	// and returns its PKI-ID. The former identity of this peer is no longer
+	// exempt from being purged once it is no longer used.
+	SetSelfIdentity(selfIdentity api.PeerIdentityType) (common.PKIidType, error)
+
 	// IdentityInfo returns information known peer identities
 	IdentityInfo() api.PeerIdentitySet
 
@@ -192,6 +197,19 @@ func (is *identityMapperImpl) SuspectPeers(isSuspected api.PeerSuspector) {
 	}
 }
 
+// SetSelfIdentity replaces the identity of this peer with the given identity,
+// and returns its PKI-ID
+func (is *identityMapperImpl) SetSelfIdentity(selfIdentity api.PeerIdentityType) (common.PKIidType, error) {
+	selfPKIID := is.mcs.GetPKIidOfCert(selfIdentity)
+	if err := is.Put(selfPKIID, selfIdentity); err != nil {
+		return nil, errors.WithMessage(err, "failed putting our own identity into the identity mapper")
+	}
+	is.Lock()
+	defer is.Unlock()
+	is.selfPKIID = string(selfPKIID)
+	return selfPKIID, nil
+}
+
 // validateIdentities returns a list of identities that have been revoked, expired or haven't been
 // used for a long time
 func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector) []*storedIdentity {
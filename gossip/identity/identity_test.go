@@ -238,6 +238,37 @@ func TestListInvalidIdentities(t *testing.T) {
 	require.NotNil(t, cert)
 }
 
+func TestSetSelfIdentity(t *testing.T) {
+	deletedIdentities := make(chan string, 1)
+	SetIdentityUsageThreshold(time.Millisecond * 500)
+	defer SetIdentityUsageThreshold(time.Hour)
+	idStore := NewIdentityMapper(msgCryptoService, dummyID, func(_ common.PKIidType, identity api.PeerIdentityType) {
+		deletedIdentities <- string(identity)
+	}, msgCryptoService)
+	defer idStore.Stop()
+
+	// Identities which can't be put into the mapper are rejected
+	_, err := idStore.SetSelfIdentity(nil)
+	require.EqualError(t, err, "failed putting our own identity into the identity mapper: PKIID is nil")
+
+	pkiID, err := idStore.SetSelfIdentity(api.PeerIdentityType("yacovm"))
+	require.NoError(t, err)
+	require.Equal(t, common.PKIidType("yacovm"), pkiID)
+	cert, err := idStore.Get(pkiID)
+	require.NoError(t, err)
+	require.Equal(t, api.PeerIdentityType("yacovm"), cert)
+
+	// The former identity is purged once it is no longer used, but the new one isn't
+	select {
+	case <-time.After(time.Second * 10):
+		t.Fatal("Didn't detect a deleted identity, expected the former identity to be deleted")
+	case deleted := <-deletedIdentities:
+		require.Equal(t, string(dummyID), deleted)
+	}
+	_, err = idStore.Get(pkiID)
+	require.NoError(t, err)
+}
+
 func TestExpiration(t *testing.T) {
 	deletedIdentities := make(chan string, 1)
 	SetIdentityUsageThreshold(time.Second * 500)
@@ -93,16 +93,20 @@ var LeaderDeclerationOpts = metrics.GaugeOpts{
 
 // CommMetrics encapsulates gossip communication related metrics
 type CommMetrics struct {
-	SentMessages     metrics.Counter
-	BufferOverflow   metrics.Counter
-	ReceivedMessages metrics.Counter
+	SentMessages      metrics.Counter
+	BufferOverflow    metrics.Counter
+	ReceivedMessages  metrics.Counter
+	CompressionRatio  metrics.Histogram
+	ThrottledMessages metrics.Counter
 }
 
 func newCommMetrics(p metrics.Provider) *CommMetrics {
 	return &CommMetrics{
-		SentMessages:     p.NewCounter(SentMessagesOpts),
-		BufferOverflow:   p.NewCounter(BufferOverflowOpts),
-		ReceivedMessages: p.NewCounter(ReceivedMessagesOpts),
+		SentMessages:      p.NewCounter(SentMessagesOpts),
+		BufferOverflow:    p.NewCounter(BufferOverflowOpts),
+		ReceivedMessages:  p.NewCounter(ReceivedMessagesOpts),
+		CompressionRatio:  p.NewHistogram(CompressionRatioOpts),
+		ThrottledMessages: p.NewCounter(ThrottledMessagesOpts),
 	}
 }
 
@@ -130,6 +134,24 @@ var (
 		Help:         "Number of messages received",
 		StatsdFormat: "%{#fqname}",
 	}
+
+	CompressionRatioOpts = metrics.HistogramOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "compression_ratio",
+		Help:         "Ratio between the compressed and the original size of compressed messages",
+		Buckets:      []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1},
+		StatsdFormat: "%{#fqname}",
+	}
+
+	ThrottledMessagesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "messages_throttled",
+		Help:         "Number of messages delayed by the per-peer rate limits",
+		LabelNames:   []string{"direction"},
+		StatsdFormat: "%{#fqname}.%{direction}",
+	}
 )
 
 // MembershipMetrics encapsulates gossip channel membership related metrics
@@ -163,6 +185,8 @@ type PrivdataMetrics struct {
 	ReconciliationDuration         metrics.Histogram
 	PullDuration                   metrics.Histogram
 	RetrieveDuration               metrics.Histogram
+	DisseminationRetryQueueSize    metrics.Gauge
+	DisseminationRetries           metrics.Counter
 }
 
 func newPrivdataMetrics(p metrics.Provider) *PrivdataMetrics {
@@ -176,6 +200,8 @@ func newPrivdataMetrics(p metrics.Provider) *PrivdataMetrics {
 		ReconciliationDuration:         p.NewHistogram(ReconciliationDurationOpts),
 		PullDuration:                   p.NewHistogram(PullDurationOpts),
 		RetrieveDuration:               p.NewHistogram(RetrieveDurationOpts),
+		DisseminationRetryQueueSize:    p.NewGauge(DisseminationRetryQueueSizeOpts),
+		DisseminationRetries:           p.NewCounter(DisseminationRetriesOpts),
 	}
 }
 
@@ -260,4 +286,22 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	DisseminationRetryQueueSizeOpts = metrics.GaugeOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "dissemination_retry_queue_size",
+		Help:         "Number of private data elements awaiting to be pushed again to eligible peers",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	DisseminationRetriesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "dissemination_retries",
+		Help:         "Number of times private data elements were pushed again to eligible peers which acknowledged them",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
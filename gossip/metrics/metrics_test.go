@@ -41,6 +41,8 @@ func TestMetrics(t *testing.T) {
 	require.NotNil(t, gossipMetrics.CommMetrics.SentMessages)
 	require.NotNil(t, gossipMetrics.CommMetrics.ReceivedMessages)
 	require.NotNil(t, gossipMetrics.CommMetrics.BufferOverflow)
+	require.NotNil(t, gossipMetrics.CommMetrics.CompressionRatio)
+	require.NotNil(t, gossipMetrics.CommMetrics.ThrottledMessages)
 
 	require.NotNil(t, gossipMetrics.MembershipMetrics)
 	require.NotNil(t, gossipMetrics.MembershipMetrics.Total)
@@ -55,4 +57,6 @@ func TestMetrics(t *testing.T) {
 	require.NotNil(t, gossipMetrics.PrivdataMetrics.ReconciliationDuration)
 	require.NotNil(t, gossipMetrics.PrivdataMetrics.PullDuration)
 	require.NotNil(t, gossipMetrics.PrivdataMetrics.RetrieveDuration)
+	require.NotNil(t, gossipMetrics.PrivdataMetrics.DisseminationRetryQueueSize)
+	require.NotNil(t, gossipMetrics.PrivdataMetrics.DisseminationRetries)
 }
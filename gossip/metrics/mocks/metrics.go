@@ -21,9 +21,11 @@ type TestMetricProvider struct {
 
 	FakeDeclarationGauge *metricsfakes.Gauge
 
-	FakeSentMessages     *metricsfakes.Counter
-	FakeBufferOverflow   *metricsfakes.Counter
-	FakeReceivedMessages *metricsfakes.Counter
+	FakeSentMessages      *metricsfakes.Counter
+	FakeBufferOverflow    *metricsfakes.Counter
+	FakeReceivedMessages  *metricsfakes.Counter
+	FakeCompressionRatio  *metricsfakes.Histogram
+	FakeThrottledMessages *metricsfakes.Counter
 
 	FakeTotalGauge *metricsfakes.Gauge
 
@@ -36,6 +38,8 @@ type TestMetricProvider struct {
 	FakeReconciliationDuration         *metricsfakes.Histogram
 	FakePullDuration                   *metricsfakes.Histogram
 	FakeRetrieveDuration               *metricsfakes.Histogram
+	FakeDisseminationRetryQueueSize    *metricsfakes.Gauge
+	FakeDisseminationRetries           *metricsfakes.Counter
 }
 
 func TestUtilConstructMetricProvider() *TestMetricProvider {
@@ -50,6 +54,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeSentMessages := testUtilConstructCounter()
 	fakeBufferOverflow := testUtilConstructCounter()
 	fakeReceivedMessages := testUtilConstructCounter()
+	fakeCompressionRatio := testUtilConstructHist()
+	fakeThrottledMessages := testUtilConstructCounter()
 
 	fakeTotalGauge := testUtilConstructGauge()
 
@@ -62,6 +68,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeReconciliationDuration := testUtilConstructHist()
 	fakePullDuration := testUtilConstructHist()
 	fakeRetrieveDuration := testUtilConstructHist()
+	fakeDisseminationRetryQueueSize := testUtilConstructGauge()
+	fakeDisseminationRetries := testUtilConstructCounter()
 
 	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
 		switch opts.Name {
@@ -71,6 +79,10 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeSentMessages
 		case gmetrics.ReceivedMessagesOpts.Name:
 			return fakeReceivedMessages
+		case gmetrics.ThrottledMessagesOpts.Name:
+			return fakeThrottledMessages
+		case gmetrics.DisseminationRetriesOpts.Name:
+			return fakeDisseminationRetries
 		}
 		return nil
 	}
@@ -78,6 +90,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		switch opts.Name {
 		case gmetrics.CommitDurationOpts.Name:
 			return fakeCommitDurationHist
+		case gmetrics.CompressionRatioOpts.Name:
+			return fakeCompressionRatio
 		case gmetrics.ValidationDurationOpts.Name:
 			return fakeValidationDuration
 		case gmetrics.ListMissingPrivateDataDurationOpts.Name:
@@ -109,6 +123,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeDeclarationGauge
 		case gmetrics.TotalOpts.Name:
 			return fakeTotalGauge
+		case gmetrics.DisseminationRetryQueueSizeOpts.Name:
+			return fakeDisseminationRetryQueueSize
 		}
 		return nil
 	}
@@ -122,6 +138,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeSentMessages,
 		fakeBufferOverflow,
 		fakeReceivedMessages,
+		fakeCompressionRatio,
+		fakeThrottledMessages,
 		fakeTotalGauge,
 		fakeValidationDuration,
 		fakeListMissingPrivateDataDuration,
@@ -132,6 +150,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeReconciliationDuration,
 		fakePullDuration,
 		fakeRetrieveDuration,
+		fakeDisseminationRetryQueueSize,
+		fakeDisseminationRetries,
 	}
 }
 
@@ -0,0 +1,268 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: extensions.proto
+
+package msgs
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	gossip "github.com/hyperledger/fabric-protos-go/gossip"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// LeadershipMessage extends gossip.LeadershipMessage
+type LeadershipMessage struct {
+	PkiId         []byte           `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	Timestamp     *gossip.PeerTime `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IsDeclaration bool             `protobuf:"varint,3,opt,name=is_declaration,json=isDeclaration,proto3" json:"is_declaration,omitempty"`
+	// priority is the leader election priority of the sender. Peers which
+	// do not know about it treat the sender as a peer with the default
+	// priority, 0.
+	Priority             uint32   `protobuf:"varint,101,opt,name=priority,proto3" json:"priority,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeadershipMessage) Reset()         { *m = LeadershipMessage{} }
+func (m *LeadershipMessage) String() string { return proto.CompactTextString(m) }
+func (*LeadershipMessage) ProtoMessage()    {}
+func (*LeadershipMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80048569110312b4, []int{0}
+}
+
+func (m *LeadershipMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeadershipMessage.Unmarshal(m, b)
+}
+func (m *LeadershipMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeadershipMessage.Marshal(b, m, deterministic)
+}
+func (m *LeadershipMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeadershipMessage.Merge(m, src)
+}
+func (m *LeadershipMessage) XXX_Size() int {
+	return xxx_messageInfo_LeadershipMessage.Size(m)
+}
+func (m *LeadershipMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeadershipMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeadershipMessage proto.InternalMessageInfo
+
+func (m *LeadershipMessage) GetPkiId() []byte {
+	if m != nil {
+		return m.PkiId
+	}
+	return nil
+}
+
+func (m *LeadershipMessage) GetTimestamp() *gossip.PeerTime {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *LeadershipMessage) GetIsDeclaration() bool {
+	if m != nil {
+		return m.IsDeclaration
+	}
+	return false
+}
+
+func (m *LeadershipMessage) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+// ConnEstablish extends gossip.ConnEstablish
+type ConnEstablish struct {
+	PkiId       []byte `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	Identity    []byte `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	TlsCertHash []byte `protobuf:"bytes,3,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
+	Probe       bool   `protobuf:"varint,4,opt,name=probe,proto3" json:"probe,omitempty"`
+	// accepted_compression is the bitmask of the compression algorithms
+	// of envelopes the sender accepts. Peers which do not know about it
+	// advertise no algorithm, and are never sent compressed envelopes.
+	AcceptedCompression  uint32   `protobuf:"varint,102,opt,name=accepted_compression,json=acceptedCompression,proto3" json:"accepted_compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnEstablish) Reset()         { *m = ConnEstablish{} }
+func (m *ConnEstablish) String() string { return proto.CompactTextString(m) }
+func (*ConnEstablish) ProtoMessage()    {}
+func (*ConnEstablish) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80048569110312b4, []int{1}
+}
+
+func (m *ConnEstablish) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnEstablish.Unmarshal(m, b)
+}
+func (m *ConnEstablish) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnEstablish.Marshal(b, m, deterministic)
+}
+func (m *ConnEstablish) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnEstablish.Merge(m, src)
+}
+func (m *ConnEstablish) XXX_Size() int {
+	return xxx_messageInfo_ConnEstablish.Size(m)
+}
+func (m *ConnEstablish) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnEstablish.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnEstablish proto.InternalMessageInfo
+
+func (m *ConnEstablish) GetPkiId() []byte {
+	if m != nil {
+		return m.PkiId
+	}
+	return nil
+}
+
+func (m *ConnEstablish) GetIdentity() []byte {
+	if m != nil {
+		return m.Identity
+	}
+	return nil
+}
+
+func (m *ConnEstablish) GetTlsCertHash() []byte {
+	if m != nil {
+		return m.TlsCertHash
+	}
+	return nil
+}
+
+func (m *ConnEstablish) GetProbe() bool {
+	if m != nil {
+		return m.Probe
+	}
+	return false
+}
+
+func (m *ConnEstablish) GetAcceptedCompression() uint32 {
+	if m != nil {
+		return m.AcceptedCompression
+	}
+	return 0
+}
+
+// Envelope extends gossip.Envelope
+type Envelope struct {
+	Payload        []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature      []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	SecretEnvelope *gossip.SecretEnvelope `protobuf:"bytes,3,opt,name=secret_envelope,json=secretEnvelope,proto3" json:"secret_envelope,omitempty"`
+	// compression is the algorithm the payload is compressed with, or 0 if
+	// the payload is not compressed. The signature is over the uncompressed
+	// payload.
+	Compression          uint32   `protobuf:"varint,103,opt,name=compression,proto3" json:"compression,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Envelope) Reset()         { *m = Envelope{} }
+func (m *Envelope) String() string { return proto.CompactTextString(m) }
+func (*Envelope) ProtoMessage()    {}
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return fileDescriptor_80048569110312b4, []int{2}
+}
+
+func (m *Envelope) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Envelope.Unmarshal(m, b)
+}
+func (m *Envelope) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Envelope.Marshal(b, m, deterministic)
+}
+func (m *Envelope) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Envelope.Merge(m, src)
+}
+func (m *Envelope) XXX_Size() int {
+	return xxx_messageInfo_Envelope.Size(m)
+}
+func (m *Envelope) XXX_DiscardUnknown() {
+	xxx_messageInfo_Envelope.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Envelope proto.InternalMessageInfo
+
+func (m *Envelope) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *Envelope) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *Envelope) GetSecretEnvelope() *gossip.SecretEnvelope {
+	if m != nil {
+		return m.SecretEnvelope
+	}
+	return nil
+}
+
+func (m *Envelope) GetCompression() uint32 {
+	if m != nil {
+		return m.Compression
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*LeadershipMessage)(nil), "gossip.msgs.LeadershipMessage")
+	proto.RegisterType((*ConnEstablish)(nil), "gossip.msgs.ConnEstablish")
+	proto.RegisterType((*Envelope)(nil), "gossip.msgs.Envelope")
+}
+
+func init() { proto.RegisterFile("extensions.proto", fileDescriptor_80048569110312b4) }
+
+var fileDescriptor_80048569110312b4 = []byte{
+	// 394 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0x51, 0x6b, 0xd4, 0x40,
+	0x10, 0xc7, 0x49, 0xb5, 0xf5, 0x3a, 0xe9, 0xd5, 0xba, 0x9e, 0x12, 0x0e, 0x1f, 0xc2, 0x81, 0x70,
+	0x22, 0xe4, 0xb0, 0x7e, 0x00, 0xc1, 0xb3, 0xa0, 0xa0, 0x20, 0xd1, 0x27, 0x5f, 0xc2, 0x26, 0x99,
+	0x6e, 0x86, 0x26, 0xbb, 0xcb, 0xce, 0x54, 0xbc, 0x0f, 0xe3, 0xa3, 0xcf, 0x7e, 0x45, 0xe9, 0xde,
+	0xa5, 0x57, 0x1f, 0xfa, 0xf8, 0x9b, 0xff, 0x30, 0xf3, 0x63, 0x18, 0x38, 0xc3, 0x5f, 0x82, 0x96,
+	0xc9, 0x59, 0x2e, 0x7c, 0x70, 0xe2, 0x54, 0x6a, 0x1c, 0x33, 0xf9, 0x62, 0x60, 0xc3, 0xf3, 0xd9,
+	0x16, 0x56, 0x03, 0x32, 0x6b, 0x83, 0xdb, 0x96, 0xc5, 0xef, 0x04, 0x9e, 0x7c, 0x46, 0xdd, 0x62,
+	0xe0, 0x8e, 0xfc, 0x97, 0x6d, 0xa6, 0x9e, 0xc1, 0x91, 0xbf, 0xa2, 0x8a, 0xda, 0x2c, 0xc9, 0x93,
+	0xe5, 0x49, 0x79, 0xe8, 0xaf, 0xe8, 0x53, 0xab, 0x0a, 0x38, 0x16, 0x1a, 0x90, 0x45, 0x0f, 0x3e,
+	0x3b, 0xc8, 0x93, 0x65, 0x7a, 0x7e, 0x56, 0xec, 0x76, 0x7c, 0x45, 0x0c, 0xdf, 0x69, 0xc0, 0x72,
+	0xdf, 0xa2, 0x5e, 0xc2, 0x29, 0x71, 0xd5, 0x62, 0xd3, 0xeb, 0xa0, 0x85, 0x9c, 0xcd, 0x1e, 0xe4,
+	0xc9, 0x72, 0x52, 0x4e, 0x89, 0x3f, 0xec, 0x8b, 0x6a, 0x0e, 0x13, 0x1f, 0xc8, 0x05, 0x92, 0x4d,
+	0x86, 0x79, 0xb2, 0x9c, 0x96, 0xb7, 0xbc, 0xf8, 0x9b, 0xc0, 0x74, 0xed, 0xac, 0xbd, 0x60, 0xd1,
+	0x75, 0x4f, 0xdc, 0xdd, 0xe7, 0x36, 0x87, 0x09, 0xb5, 0x68, 0xe5, 0x66, 0xc8, 0x41, 0x0c, 0x6e,
+	0x59, 0x2d, 0x60, 0x2a, 0x3d, 0x57, 0x0d, 0x06, 0xa9, 0x3a, 0xcd, 0x5d, 0xd4, 0x38, 0x29, 0x53,
+	0xe9, 0x79, 0x8d, 0x41, 0x3e, 0x6a, 0xee, 0xd4, 0x0c, 0x0e, 0x7d, 0x70, 0x35, 0x66, 0x0f, 0xa3,
+	0xe2, 0x16, 0xd4, 0x1b, 0x98, 0xe9, 0xa6, 0x41, 0x2f, 0xd8, 0x56, 0x8d, 0x1b, 0x7c, 0x40, 0xbe,
+	0x39, 0x70, 0x76, 0x19, 0x35, 0x9f, 0x8e, 0xd9, 0x7a, 0x1f, 0x2d, 0xfe, 0x24, 0x30, 0xb9, 0xb0,
+	0x3f, 0xb1, 0x77, 0x1e, 0x55, 0x06, 0x8f, 0xbc, 0xde, 0xf4, 0x4e, 0x8f, 0xb6, 0x23, 0xaa, 0x17,
+	0x70, 0xcc, 0x64, 0xac, 0x96, 0xeb, 0x80, 0x3b, 0xe1, 0x7d, 0x41, 0xbd, 0x83, 0xc7, 0x8c, 0x4d,
+	0x40, 0xa9, 0x70, 0x37, 0x2a, 0x3a, 0xa7, 0xe7, 0xcf, 0xc7, 0x7b, 0x7f, 0x8b, 0xf1, 0xb8, 0xa8,
+	0x3c, 0xe5, 0xff, 0x58, 0xe5, 0x90, 0xde, 0xf5, 0x35, 0xd1, 0xf7, 0x6e, 0xe9, 0xfd, 0xeb, 0x1f,
+	0xaf, 0x0c, 0x49, 0x77, 0x5d, 0x17, 0x8d, 0x1b, 0x56, 0xdd, 0xc6, 0x63, 0xe8, 0xb1, 0x35, 0x18,
+	0x56, 0x97, 0xba, 0x0e, 0xd4, 0xac, 0xc6, 0x7f, 0x61, 0xc3, 0xf5, 0x51, 0xfc, 0x96, 0xb7, 0xff,
+	0x06, 0x00, 0x25, 0x1d, 0x5d, 0x7e, 0x64, 0x02, 0x00, 0x00,
+}
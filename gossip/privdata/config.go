@@ -17,6 +17,8 @@ const (
 	reconcileSleepIntervalDefault         = time.Minute
 	reconcileBatchSizeDefault             = 10
 	implicitCollectionMaxPeerCountDefault = 1
+	disseminationRetryIntervalDefault     = 10 * time.Second
+	disseminationRetryQueueSizeDefault    = 1000
 )
 
 // PrivdataConfig is the struct that defines the Gossip Privdata configurations.
@@ -30,6 +32,13 @@ type PrivdataConfig struct {
 	ReconciliationEnabled bool
 	// ImplicitCollectionDisseminationPolicy specifies the dissemination  policy for the peer's own implicit collection.
 	ImplicitCollDisseminationPolicy ImplicitCollectionDisseminationPolicy
+	// DisseminationRetryEnabled is a flag that indicates whether private data pushed at endorsement time is pushed again
+	// to eligible peers which did not acknowledge it, until it is purged from the transient store.
+	DisseminationRetryEnabled bool
+	// DisseminationRetryInterval determines the time between two attempts to push private data again.
+	DisseminationRetryInterval time.Duration
+	// DisseminationRetryQueueSize determines the maximum number of private data elements awaiting to be pushed again.
+	DisseminationRetryQueueSize int
 }
 
 // ImplicitCollectionDisseminationPolicy specifies the dissemination  policy for the peer's own implicit collection.
@@ -84,4 +93,16 @@ func (c *PrivdataConfig) loadPrivDataConfig() {
 
 	c.ImplicitCollDisseminationPolicy.RequiredPeerCount = requiredPeerCount
 	c.ImplicitCollDisseminationPolicy.MaxPeerCount = maxPeerCount
+
+	c.DisseminationRetryEnabled = viper.GetBool("peer.gossip.pvtData.disseminationRetryEnabled")
+
+	c.DisseminationRetryInterval = viper.GetDuration("peer.gossip.pvtData.disseminationRetryInterval")
+	if c.DisseminationRetryInterval == 0 {
+		c.DisseminationRetryInterval = disseminationRetryIntervalDefault
+	}
+
+	c.DisseminationRetryQueueSize = viper.GetInt("peer.gossip.pvtData.disseminationRetryQueueSize")
+	if c.DisseminationRetryQueueSize == 0 {
+		c.DisseminationRetryQueueSize = disseminationRetryQueueSizeDefault
+	}
 }
@@ -23,6 +23,9 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.pvtData.reconciliationEnabled", true)
 	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount", 2)
 	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount", 3)
+	viper.Set("peer.gossip.pvtData.disseminationRetryEnabled", true)
+	viper.Set("peer.gossip.pvtData.disseminationRetryInterval", "5s")
+	viper.Set("peer.gossip.pvtData.disseminationRetryQueueSize", 100)
 
 	coreConfig := privdata.GlobalConfig()
 
@@ -34,6 +37,9 @@ func TestGlobalConfig(t *testing.T) {
 			RequiredPeerCount: 2,
 			MaxPeerCount:      3,
 		},
+		DisseminationRetryEnabled:   true,
+		DisseminationRetryInterval:  5 * time.Second,
+		DisseminationRetryQueueSize: 100,
 	}
 
 	require.Equal(t, coreConfig, expectedConfig)
@@ -52,6 +58,9 @@ func TestGlobalConfigDefaults(t *testing.T) {
 			RequiredPeerCount: 0,
 			MaxPeerCount:      1,
 		},
+		DisseminationRetryEnabled:   false,
+		DisseminationRetryInterval:  10 * time.Second,
+		DisseminationRetryQueueSize: 1000,
 	}
 
 	require.Equal(t, coreConfig, expectedConfig)
@@ -0,0 +1,241 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"bytes"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	protosgossip "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	gossipgossip "github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// DisseminationRetryConfig is the configuration of the retry of the dissemination
+// of private data to the eligible peers which did not acknowledge it at endorsement time
+type DisseminationRetryConfig struct {
+	// Enabled indicates whether private data is pushed again to the eligible peers
+	// which did not acknowledge it
+	Enabled bool
+	// Interval is the time between two attempts to push private data again
+	Interval time.Duration
+	// QueueSize is the maximum number of private data elements awaiting to be
+	// pushed again, the oldest elements are dropped when it is exceeded
+	QueueSize int
+	// TransientBlockRetention is the number of blocks private data is retained for
+	// in the transient store. Private data is not pushed again to peers whose ledger
+	// height exceeds the height the private data was simulated at by more than the
+	// retention, as they purged it or committed the transaction already.
+	TransientBlockRetention uint64
+}
+
+// retryEntry is the private data of a collection of an endorsed transaction
+// which is pushed again to eligible peers until enough of them acknowledged it
+type retryEntry struct {
+	msg       *protoext.SignedGossipMessage
+	colAP     privdata.CollectionAccessPolicy
+	colFilter privdata.Filter
+	blkHt     uint64
+	// acked holds the PKI-IDs of the peers which acknowledged the private data
+	acked map[string]struct{}
+	// pending is the number of additional peers the private data is pushed to
+	pending int
+}
+
+func (d *distributorImpl) enqueueRetries(disseminationPlan []*dissemination, acked []bool) {
+	var entries []*retryEntry
+	seen := map[*retryEntry]struct{}{}
+	for i, dis := range disseminationPlan {
+		if dis.retry == nil {
+			continue
+		}
+		if _, exists := seen[dis.retry]; !exists {
+			seen[dis.retry] = struct{}{}
+			entries = append(entries, dis.retry)
+		}
+		if acked[i] {
+			dis.retry.acked[string(dis.pkiID)] = struct{}{}
+		}
+	}
+
+	d.retryLock.Lock()
+	defer d.retryLock.Unlock()
+	for _, entry := range entries {
+		entry.pending = entry.colAP.MaximumPeerCount() - len(entry.acked)
+		if entry.pending <= 0 {
+			continue
+		}
+		m := entry.msg.GetPrivateData().Payload
+		d.logger.Debugf("Private RWSet for TxID [%s] namespace [%s] collection [%s] will be pushed to %d more peer(s)", m.TxId, m.Namespace, m.CollectionName, entry.pending)
+		d.retryQueue = append(d.retryQueue, entry)
+	}
+	if overflow := len(d.retryQueue) - d.retryConfig.QueueSize; overflow > 0 {
+		d.logger.Warningf("Dissemination retry queue is full, dropping the %d oldest private data element(s)", overflow)
+		d.retryQueue = d.retryQueue[overflow:]
+	}
+	d.metrics.DisseminationRetryQueueSize.With("channel", d.chainID).Set(float64(len(d.retryQueue)))
+}
+
+func (d *distributorImpl) retryDisseminations() {
+	ticker := time.NewTicker(d.retryConfig.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stopChan:
+			return
+		case <-ticker.C:
+			d.retryPendingDisseminations()
+		}
+	}
+}
+
+// retryPendingDisseminations pushes the private data of the dissemination retry
+// queue again to eligible peers which did not acknowledge it yet, and removes the
+// private data which was acknowledged by enough peers or expired from the queue
+func (d *distributorImpl) retryPendingDisseminations() {
+	d.retryLock.Lock()
+	entries := make([]*retryEntry, len(d.retryQueue))
+	copy(entries, d.retryQueue)
+	d.retryLock.Unlock()
+	if len(entries) == 0 {
+		return
+	}
+
+	peers := d.gossipAdapter.PeersOfChannel(gossipCommon.ChannelID(d.chainID))
+	var maxHeight uint64
+	for _, peer := range peers {
+		if height := ledgerHeight(peer); height > maxHeight {
+			maxHeight = height
+		}
+	}
+
+	done := map[*retryEntry]struct{}{}
+	for _, entry := range entries {
+		select {
+		case <-d.stopChan:
+			return
+		default:
+		}
+		m := entry.msg.GetPrivateData().Payload
+		if maxHeight > entry.blkHt+d.retryConfig.TransientBlockRetention {
+			d.logger.Debugf("Private RWSet for TxID [%s] namespace [%s] collection [%s] expired, no longer pushing it", m.TxId, m.Namespace, m.CollectionName)
+			done[entry] = struct{}{}
+			continue
+		}
+		if d.retryDissemination(entry, peers) {
+			done[entry] = struct{}{}
+		}
+	}
+
+	d.retryLock.Lock()
+	defer d.retryLock.Unlock()
+	retryQueue := d.retryQueue[:0]
+	for _, entry := range d.retryQueue {
+		if _, exists := done[entry]; !exists {
+			retryQueue = append(retryQueue, entry)
+		}
+	}
+	d.retryQueue = retryQueue
+	d.metrics.DisseminationRetryQueueSize.With("channel", d.chainID).Set(float64(len(d.retryQueue)))
+}
+
+// retryDissemination pushes the private data of an entry to eligible peers which did
+// not acknowledge it yet, and returns whether it was acknowledged by enough peers
+func (d *distributorImpl) retryDissemination(entry *retryEntry, peers []discovery.NetworkMember) bool {
+	m := entry.msg.GetPrivateData().Payload
+	routingFilter, err := d.gossipAdapter.PeerFilter(gossipCommon.ChannelID(d.chainID), func(signature api.PeerSignature) bool {
+		return entry.colFilter(protoutil.SignedData{
+			Data:      signature.Message,
+			Signature: signature.Signature,
+			Identity:  []byte(signature.PeerIdentity),
+		})
+	})
+	if err != nil {
+		d.logger.Warning("Failed to retrieve peer routing filter for channel", d.chainID, ":", err)
+		return false
+	}
+
+	var eligiblePeers []discovery.NetworkMember
+	for _, peer := range peers {
+		if _, acked := entry.acked[string(peer.PKIid)]; acked {
+			continue
+		}
+		if ledgerHeight(peer) > entry.blkHt+d.retryConfig.TransientBlockRetention {
+			continue
+		}
+		if routingFilter(peer) {
+			eligiblePeers = append(eligiblePeers, peer)
+		}
+	}
+
+	var candidates []api.PeerIdentityInfo
+	for _, identities := range d.identitiesOfEligiblePeersByOrg(eligiblePeers, entry.colAP) {
+		candidates = append(candidates, identities...)
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > entry.pending {
+		candidates = candidates[:entry.pending]
+	}
+
+	acked := make([]bool, len(candidates))
+	var wg sync.WaitGroup
+	wg.Add(len(candidates))
+	for i, candidate := range candidates {
+		go func(i int, pkiID gossipCommon.PKIidType) {
+			defer wg.Done()
+			err := d.SendByCriteria(&protoext.SignedGossipMessage{
+				Envelope:      proto.Clone(entry.msg.Envelope).(*protosgossip.Envelope),
+				GossipMessage: proto.Clone(entry.msg.GossipMessage).(*protosgossip.GossipMessage),
+			}, gossipgossip.SendCriteria{
+				Timeout:  d.pushAckTimeout,
+				Channel:  gossipCommon.ChannelID(d.chainID),
+				MaxPeers: 1,
+				MinAck:   1,
+				IsEligible: func(member discovery.NetworkMember) bool {
+					return bytes.Equal(member.PKIid, pkiID)
+				},
+			})
+			if err != nil {
+				d.logger.Debug("Failed pushing private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, "again:", err)
+				return
+			}
+			acked[i] = true
+		}(i, candidate.PKIId)
+	}
+	wg.Wait()
+
+	for i, candidate := range candidates {
+		if !acked[i] {
+			continue
+		}
+		entry.acked[string(candidate.PKIId)] = struct{}{}
+		entry.pending--
+		d.metrics.DisseminationRetries.With("channel", d.chainID).Add(1)
+	}
+	d.logger.Debugf("Pushed private RWSet for TxID [%s] namespace [%s] collection [%s] again, %d more peer(s) pending", m.TxId, m.Namespace, m.CollectionName, entry.pending)
+	return entry.pending <= 0
+}
+
+func ledgerHeight(peer discovery.NetworkMember) uint64 {
+	if peer.Properties == nil {
+		return 0
+	}
+	return peer.Properties.LedgerHeight
+}
@@ -0,0 +1,358 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-protos-go/transientstore"
+	"github.com/hyperledger/fabric/gossip/api"
+	gcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/filter"
+	gossip2 "github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/metrics/mocks"
+	mocks2 "github.com/hyperledger/fabric/gossip/privdata/mocks"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// retryGossipMock acknowledges private data sent to the peers of the channel which are up
+type retryGossipMock struct {
+	lock  sync.Mutex
+	peers []discovery.NetworkMember
+	down  map[string]bool
+	// received holds the private data payloads received by each peer
+	received map[string][]*proto.PrivatePayload
+	// acks, if not nil, delays the acknowledgements until it is closed
+	acks chan struct{}
+}
+
+func (g *retryGossipMock) SendByCriteria(msg *protoext.SignedGossipMessage, criteria gossip2.SendCriteria) error {
+	if g.acks != nil && criteria.MinAck > 0 {
+		<-g.acks
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var peers []discovery.NetworkMember
+	for _, peer := range g.peers {
+		if criteria.IsEligible(peer) {
+			peers = append(peers, peer)
+		}
+	}
+	if len(peers) < criteria.MinAck {
+		return errors.Errorf("requested to send to at least %d peers, but know only of %d suitable peers", criteria.MinAck, len(peers))
+	}
+	acks := 0
+	for _, peer := range peers {
+		if g.down[string(peer.PKIid)] {
+			continue
+		}
+		acks++
+		g.received[string(peer.PKIid)] = append(g.received[string(peer.PKIid)], msg.GetPrivateData().Payload)
+	}
+	if acks < criteria.MinAck {
+		return errors.New("timed out waiting for acknowledgements")
+	}
+	return nil
+}
+
+func (g *retryGossipMock) PeerFilter(channel gcommon.ChannelID, messagePredicate api.SubChannelSelectionCriteria) (filter.RoutingFilter, error) {
+	return func(member discovery.NetworkMember) bool {
+		return messagePredicate(api.PeerSignature{PeerIdentity: api.PeerIdentityType(member.PKIid)})
+	}, nil
+}
+
+func (g *retryGossipMock) IdentityInfo() api.PeerIdentitySet {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var identities api.PeerIdentitySet
+	for _, peer := range g.peers {
+		identities = append(identities, api.PeerIdentityInfo{
+			PKIId:        peer.PKIid,
+			Identity:     api.PeerIdentityType(peer.PKIid),
+			Organization: api.OrgIdentityType("org" + string(peer.PKIid)),
+		})
+	}
+	return identities
+}
+
+func (g *retryGossipMock) PeersOfChannel(gcommon.ChannelID) []discovery.NetworkMember {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	peers := make([]discovery.NetworkMember, len(g.peers))
+	copy(peers, g.peers)
+	return peers
+}
+
+func (g *retryGossipMock) setPeers(peers ...discovery.NetworkMember) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.peers = peers
+}
+
+func (g *retryGossipMock) setDown(pkiID string, down bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.down[pkiID] = down
+}
+
+func (g *retryGossipMock) receivedTxIDs(pkiID string) []string {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var txIDs []string
+	for _, payload := range g.received[pkiID] {
+		txIDs = append(txIDs, payload.TxId)
+	}
+	return txIDs
+}
+
+func retryTestPeer(pkiID string, ledgerHeight uint64) discovery.NetworkMember {
+	return discovery.NetworkMember{
+		PKIid:      gcommon.PKIidType(pkiID),
+		Properties: &proto.Properties{LedgerHeight: ledgerHeight},
+	}
+}
+
+func TestDisseminationRetry(t *testing.T) {
+	channelID := "test"
+	colConfig := &peer.CollectionConfig{
+		Payload: &peer.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &peer.StaticCollectionConfig{
+				Name: "c1",
+			},
+		},
+	}
+
+	setup := func(retryConfig DisseminationRetryConfig) (*distributorImpl, *retryGossipMock, *mocks.TestMetricProvider) {
+		g := &retryGossipMock{
+			down:     map[string]bool{},
+			received: map[string][]*proto.PrivatePayload{},
+		}
+		g.setPeers(retryTestPeer("1", 10), retryTestPeer("2", 10))
+
+		// Peers 1, 2 and 3 of organizations org1, org2 and org3 are eligible
+		// and the private data is sent to 2 of them
+		policyMock := &mocks2.CollectionAccessPolicy{}
+		Setup(policyMock, 0, 2, func(signedData protoutil.SignedData) bool {
+			return string(signedData.Identity) != "4"
+		}, map[string]struct{}{
+			"org1": {},
+			"org2": {},
+			"org3": {},
+		}, false)
+		accessFactoryMock := &mocks2.CollectionAccessFactory{}
+		accessFactoryMock.On("AccessPolicy", colConfig, channelID).Return(policyMock, nil)
+
+		testMetricProvider := mocks.TestUtilConstructMetricProvider()
+		metrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).PrivdataMetrics
+
+		d := NewDistributor(channelID, g, accessFactoryMock, metrics, time.Second, retryConfig).(*distributorImpl)
+		t.Cleanup(d.Stop)
+		return d, g, testMetricProvider
+	}
+
+	distribute := func(t *testing.T, d *distributorImpl, txID string, blkHt uint64) {
+		pvtData := (&pvtDataFactory{}).addRWSet().addNSRWSet("ns1", "c1").create()
+		err := d.Distribute(txID, &transientstore.TxPvtReadWriteSetWithConfigInfo{
+			PvtRwset: pvtData[0].WriteSet,
+			CollectionConfigs: map[string]*peer.CollectionConfigPackage{
+				"ns1": {
+					Config: []*peer.CollectionConfig{colConfig},
+				},
+			},
+		}, blkHt)
+		require.NoError(t, err)
+		d.backgroundPushes.Wait()
+	}
+
+	queueSize := func(d *distributorImpl) int {
+		d.retryLock.Lock()
+		defer d.retryLock.Unlock()
+		return len(d.retryQueue)
+	}
+
+	retryConfig := DisseminationRetryConfig{
+		Enabled:                 true,
+		Interval:                time.Hour,
+		QueueSize:               10,
+		TransientBlockRetention: 100,
+	}
+
+	t.Run("AllPeersAcknowledged", func(t *testing.T) {
+		d, g, _ := setup(retryConfig)
+		distribute(t, d, "tx1", 10)
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("1"))
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("2"))
+		require.Zero(t, queueSize(d))
+	})
+
+	t.Run("PeerDown", func(t *testing.T) {
+		d, g, testMetricProvider := setup(retryConfig)
+		g.setDown("2", true)
+		distribute(t, d, "tx1", 10)
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("1"))
+		require.Empty(t, g.receivedTxIDs("2"))
+		require.Equal(t, 1, queueSize(d))
+		require.Equal(t, float64(1), testMetricProvider.FakeDisseminationRetryQueueSize.SetArgsForCall(0))
+
+		// The peer is still down
+		d.retryPendingDisseminations()
+		require.Empty(t, g.receivedTxIDs("2"))
+		require.Equal(t, 1, queueSize(d))
+
+		// The peer is up again, the private data is pushed to it only
+		g.setDown("2", false)
+		d.retryPendingDisseminations()
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("1"))
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("2"))
+		require.Zero(t, queueSize(d))
+		require.Equal(t, 1, testMetricProvider.FakeDisseminationRetries.AddCallCount())
+		require.Equal(t, []string{"channel", channelID}, testMetricProvider.FakeDisseminationRetries.WithArgsForCall(0))
+
+		d.retryPendingDisseminations()
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("2"))
+	})
+
+	t.Run("EligiblePeerJoins", func(t *testing.T) {
+		d, g, _ := setup(retryConfig)
+		// Peer 2 is not alive at endorsement time
+		g.setPeers(retryTestPeer("1", 10))
+		distribute(t, d, "tx1", 10)
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("1"))
+		require.Equal(t, 1, queueSize(d))
+
+		// Peer 4 is not eligible and peer 5 is not a member of the collection orgs
+		g.setPeers(retryTestPeer("1", 10), retryTestPeer("4", 10), retryTestPeer("5", 10))
+		d.retryPendingDisseminations()
+		require.Empty(t, g.receivedTxIDs("4"))
+		require.Empty(t, g.receivedTxIDs("5"))
+		require.Equal(t, 1, queueSize(d))
+
+		// Peer 3 is eligible, it receives the private data
+		g.setPeers(retryTestPeer("1", 10), retryTestPeer("3", 10), retryTestPeer("4", 10))
+		d.retryPendingDisseminations()
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("1"))
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("3"))
+		require.Zero(t, queueSize(d))
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		d, g, _ := setup(retryConfig)
+		g.setDown("2", true)
+		distribute(t, d, "tx1", 10)
+		require.Equal(t, 1, queueSize(d))
+
+		// Peer 2 is beyond the transient store retention
+		g.setDown("2", false)
+		g.setPeers(retryTestPeer("1", 10), retryTestPeer("2", 111))
+		d.retryPendingDisseminations()
+		require.Empty(t, g.receivedTxIDs("2"))
+		require.Zero(t, queueSize(d))
+	})
+
+	t.Run("QueueSize", func(t *testing.T) {
+		config := retryConfig
+		config.QueueSize = 2
+		d, g, _ := setup(config)
+		g.setDown("2", true)
+		distribute(t, d, "tx1", 10)
+		distribute(t, d, "tx2", 10)
+		distribute(t, d, "tx3", 10)
+		require.Equal(t, 2, queueSize(d))
+
+		g.setDown("2", false)
+		d.retryPendingDisseminations()
+		require.Equal(t, []string{"tx2", "tx3"}, g.receivedTxIDs("2"))
+	})
+
+	t.Run("RequiredPeerFailure", func(t *testing.T) {
+		d, g, _ := setup(retryConfig)
+		g.setPeers(retryTestPeer("1", 10))
+		g.setDown("1", true)
+		policyMock := &mocks2.CollectionAccessPolicy{}
+		Setup(policyMock, 1, 2, func(protoutil.SignedData) bool { return true }, map[string]struct{}{"org1": {}}, false)
+		accessFactoryMock := &mocks2.CollectionAccessFactory{}
+		accessFactoryMock.On("AccessPolicy", colConfig, channelID).Return(policyMock, nil)
+		d.CollectionAccessFactory = accessFactoryMock
+
+		pvtData := (&pvtDataFactory{}).addRWSet().addNSRWSet("ns1", "c1").create()
+		err := d.Distribute("tx1", &transientstore.TxPvtReadWriteSetWithConfigInfo{
+			PvtRwset: pvtData[0].WriteSet,
+			CollectionConfigs: map[string]*peer.CollectionConfigPackage{
+				"ns1": {
+					Config: []*peer.CollectionConfig{colConfig},
+				},
+			},
+		}, 10)
+		require.EqualError(t, err, "Failed disseminating 1 out of 1 private dissemination plans")
+		require.Zero(t, queueSize(d))
+	})
+
+	t.Run("AcknowledgedInBackground", func(t *testing.T) {
+		d, g, _ := setup(retryConfig)
+		g.acks = make(chan struct{})
+		g.setDown("2", true)
+
+		// No acknowledgement is required, so the distribution completes
+		// without waiting for them
+		pvtData := (&pvtDataFactory{}).addRWSet().addNSRWSet("ns1", "c1").create()
+		err := d.Distribute("tx1", &transientstore.TxPvtReadWriteSetWithConfigInfo{
+			PvtRwset: pvtData[0].WriteSet,
+			CollectionConfigs: map[string]*peer.CollectionConfigPackage{
+				"ns1": {
+					Config: []*peer.CollectionConfig{colConfig},
+				},
+			},
+		}, 10)
+		require.NoError(t, err)
+		require.Empty(t, g.receivedTxIDs("1"))
+		require.Zero(t, queueSize(d))
+
+		close(g.acks)
+		d.backgroundPushes.Wait()
+		require.Equal(t, []string{"tx1"}, g.receivedTxIDs("1"))
+		require.Equal(t, 1, queueSize(d))
+	})
+
+	t.Run("Background", func(t *testing.T) {
+		config := retryConfig
+		config.Interval = 10 * time.Millisecond
+		d, g, _ := setup(config)
+		g.setDown("2", true)
+		distribute(t, d, "tx1", 10)
+		g.setDown("2", false)
+		require.Eventually(t, func() bool {
+			return len(g.receivedTxIDs("2")) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Eventually(t, func() bool {
+			return queueSize(d) == 0
+		}, 5*time.Second, 10*time.Millisecond)
+
+		d.Stop()
+		d.Stop()
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		d, g, _ := setup(DisseminationRetryConfig{})
+		g.setDown("2", true)
+		distribute(t, d, "tx1", 10)
+		require.Zero(t, queueSize(d))
+		d.Stop()
+	})
+}
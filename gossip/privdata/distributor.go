@@ -54,6 +54,9 @@ type gossipAdapter interface {
 type PvtDataDistributor interface {
 	// Distribute broadcast reliably private data read write set based on policies
 	Distribute(txID string, privData *transientstore.TxPvtReadWriteSetWithConfigInfo, blkHt uint64) error
+
+	// Stop stops pushing private data again to peers which did not acknowledge it
+	Stop()
 }
 
 // IdentityDeserializerFactory is a factory interface to create
@@ -80,6 +83,15 @@ type distributorImpl struct {
 	pushAckTimeout time.Duration
 	logger         util.Logger
 	metrics        *metrics.PrivdataMetrics
+
+	retryConfig DisseminationRetryConfig
+	retryLock   sync.Mutex
+	retryQueue  []*retryEntry
+	// backgroundPushes tracks the disseminations whose pushes await
+	// acknowledgements in the background before being queued for retry
+	backgroundPushes sync.WaitGroup
+	stopChan         chan struct{}
+	stopOnce         sync.Once
 }
 
 //go:generate mockery -dir . -name CollectionAccessFactory -case underscore -output ./mocks/
@@ -123,17 +135,32 @@ func NewCollectionAccessFactory(factory IdentityDeserializerFactory) CollectionA
 }
 
 // NewDistributor a constructor for private data distributor capable to send
-// private read write sets for underlying collection
+// private read write sets for underlying collection. When the retry of the
+// dissemination is enabled, private data is pushed again in the background to
+// eligible peers which did not acknowledge it, until Stop is called.
 func NewDistributor(chainID string, gossip gossipAdapter, factory CollectionAccessFactory,
-	metrics *metrics.PrivdataMetrics, pushAckTimeout time.Duration) PvtDataDistributor {
-	return &distributorImpl{
+	metrics *metrics.PrivdataMetrics, pushAckTimeout time.Duration, retryConfig DisseminationRetryConfig) PvtDataDistributor {
+	d := &distributorImpl{
 		chainID:                 chainID,
 		gossipAdapter:           gossip,
 		CollectionAccessFactory: factory,
 		pushAckTimeout:          pushAckTimeout,
 		logger:                  logger.With("channel", chainID),
 		metrics:                 metrics,
+		retryConfig:             retryConfig,
+		stopChan:                make(chan struct{}),
 	}
+	if retryConfig.Enabled {
+		go d.retryDisseminations()
+	}
+	return d
+}
+
+// Stop stops pushing private data again to peers which did not acknowledge it
+func (d *distributorImpl) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopChan)
+	})
 }
 
 // Distribute broadcast reliably private data read write set based on policies
@@ -148,6 +175,11 @@ func (d *distributorImpl) Distribute(txID string, privData *transientstore.TxPvt
 type dissemination struct {
 	msg      *protoext.SignedGossipMessage
 	criteria gossipgossip.SendCriteria
+	// pkiID is the PKI-ID of the peer the private data is sent to
+	pkiID gossipCommon.PKIidType
+	// retry is the entry of the dissemination retry queue of the private
+	// data, it is nil when the retry of the dissemination is disabled
+	retry *retryEntry
 }
 
 func (d *distributorImpl) computeDisseminationPlan(txID string,
@@ -193,6 +225,18 @@ func (d *distributorImpl) computeDisseminationPlan(txID string,
 			if err != nil {
 				return nil, errors.WithMessagef(err, "could not build private data dissemination plan for chaincode %s and collection %s", namespace, collectionName)
 			}
+			if d.retryConfig.Enabled {
+				entry := &retryEntry{
+					msg:       pvtDataMsg,
+					colAP:     colAP,
+					colFilter: colFilter,
+					blkHt:     blkHt,
+					acked:     map[string]struct{}{},
+				}
+				for _, dis := range dPlan {
+					dis.retry = entry
+				}
+			}
 			disseminationPlan = append(disseminationPlan, dPlan...)
 		}
 	}
@@ -283,6 +327,7 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 			}
 			disseminationPlan = append(disseminationPlan, &dissemination{
 				criteria: sc,
+				pkiID:    peer2SendPerOrg.PKIId,
 				msg: &protoext.SignedGossipMessage{
 					Envelope:      proto.Clone(pvtDataMsg.Envelope).(*protosgossip.Envelope),
 					GossipMessage: proto.Clone(pvtDataMsg.GossipMessage).(*protosgossip.GossipMessage),
@@ -336,6 +381,7 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 		}
 		disseminationPlan = append(disseminationPlan, &dissemination{
 			criteria: sc,
+			pkiID:    peer2Send.PKIId,
 			msg: &protoext.SignedGossipMessage{
 				Envelope:      proto.Clone(pvtDataMsg.Envelope).(*protosgossip.Envelope),
 				GossipMessage: proto.Clone(pvtDataMsg.GossipMessage).(*protosgossip.GossipMessage),
@@ -385,28 +431,55 @@ func (d *distributorImpl) eligiblePeersOfChannel(routingFilter filter.RoutingFil
 	return eligiblePeers
 }
 
+// disseminate sends the private data according to the dissemination plan, and
+// returns once the pushes which require an acknowledgement completed. When the
+// retry of the dissemination is enabled, the pushes which do not require an
+// acknowledgement await one in the background instead of being sent without
+// waiting for it, so that the private data is pushed again only to the peers
+// which did not acknowledge it.
 func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error {
 	var failures uint32
-	var wg sync.WaitGroup
-	wg.Add(len(disseminationPlan))
+	var wg, background sync.WaitGroup
+	acked := make([]bool, len(disseminationPlan))
 	start := time.Now()
-	for _, dis := range disseminationPlan {
-		go func(dis *dissemination) {
-			defer wg.Done()
+	for i, dis := range disseminationPlan {
+		criteria := dis.criteria
+		pending := &wg
+		if dis.retry != nil && criteria.MinAck == 0 {
+			criteria.MinAck = 1
+			pending = &background
+		}
+		pending.Add(1)
+		go func(i int, dis *dissemination, criteria gossipgossip.SendCriteria, pending *sync.WaitGroup) {
+			defer pending.Done()
 			defer d.reportSendDuration(start)
-			err := d.SendByCriteria(dis.msg, dis.criteria)
-			if err != nil {
-				atomic.AddUint32(&failures, 1)
-				m := dis.msg.GetPrivateData().Payload
-				d.logger.Error("Failed disseminating private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err)
+			err := d.SendByCriteria(dis.msg, criteria)
+			if err == nil {
+				acked[i] = true
+				return
+			}
+			m := dis.msg.GetPrivateData().Payload
+			if dis.retry != nil && dis.criteria.MinAck == 0 {
+				d.logger.Debug("Private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, "was not acknowledged, it will be pushed again:", err)
+				return
 			}
-		}(dis)
+			atomic.AddUint32(&failures, 1)
+			d.logger.Error("Failed disseminating private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err)
+		}(i, dis, criteria, pending)
 	}
 	wg.Wait()
 	failureCount := atomic.LoadUint32(&failures)
 	if failureCount != 0 {
 		return errors.Errorf("Failed disseminating %d out of %d private dissemination plans", failureCount, len(disseminationPlan))
 	}
+	if d.retryConfig.Enabled {
+		d.backgroundPushes.Add(1)
+		go func() {
+			defer d.backgroundPushes.Done()
+			background.Wait()
+			d.enqueueRetries(disseminationPlan, acked)
+		}()
+	}
 	return nil
 }
 
@@ -13,6 +13,7 @@ import (
 	"math"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	protosgossip "github.com/hyperledger/fabric-protos-go/gossip"
@@ -68,7 +69,15 @@ type gossip interface {
 	Accept(acceptor common.MessageAcceptor, passThrough bool) (<-chan *protosgossip.GossipMessage, <-chan protoext.ReceivedMessage)
 }
 
+// PullTracker reports the private data being pulled from other peers
+type PullTracker interface {
+	// PendingPulls returns the number of private data elements being pulled from other peers
+	PendingPulls() int
+}
+
 type puller struct {
+	// pending is the number of private data elements being pulled, accessed atomically
+	pending       int64
 	logger        util.Logger
 	metrics       *metrics.PrivdataMetrics
 	pubSub        *util.PubSub
@@ -241,7 +250,16 @@ func (p *puller) FetchReconciledItems(dig2collectionConfig privdatacommon.Dig2Co
 	return p.fetchPrivateData(dig2Filter)
 }
 
+// PendingPulls returns the number of private data elements being pulled from other peers
+func (p *puller) PendingPulls() int {
+	return int(atomic.LoadInt64(&p.pending))
+}
+
 func (p *puller) fetchPrivateData(dig2Filter digestToFilterMapping) (*privdatacommon.FetchedPvtDataContainer, error) {
+	pending := int64(len(dig2Filter))
+	atomic.AddInt64(&p.pending, pending)
+	defer atomic.AddInt64(&p.pending, -pending)
+
 	// Get a list of peers per channel
 	allFilters := dig2Filter.flattenFilterValues()
 	members := p.waitForMembership()
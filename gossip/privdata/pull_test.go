@@ -358,7 +358,11 @@ func TestPullerFromOnly1Peer(t *testing.T) {
 		}: p2TransientStore,
 	}
 
-	p2.PrivateDataRetriever.(*dataRetrieverMock).On("CollectionRWSet", mock.MatchedBy(protoMatcher(dig)), uint64(0)).Return(store, true, nil)
+	// the element is pending while p2 serves it
+	var pendingPulls int
+	p2.PrivateDataRetriever.(*dataRetrieverMock).On("CollectionRWSet", mock.MatchedBy(protoMatcher(dig)), uint64(0)).Run(func(_ mock.Arguments) {
+		pendingPulls = p1.PendingPulls()
+	}).Return(store, true, nil)
 
 	factoryMock3 := &mocks.CollectionAccessFactory{}
 	policyMock3 := &mocks.CollectionAccessPolicy{}
@@ -380,6 +384,8 @@ func TestPullerFromOnly1Peer(t *testing.T) {
 	fetched := []util.PrivateRWSet{rws1, rws2}
 	require.NoError(t, err)
 	require.Equal(t, p2TransientStore.RWSet, fetched)
+	require.Equal(t, 1, pendingPulls)
+	require.Zero(t, p1.PendingPulls())
 }
 
 func TestPullerDataNotAvailable(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -53,6 +54,54 @@ type PvtDataReconciler interface {
 	Start()
 	// Stop function stops reconciler
 	Stop()
+	// Reconcile enqueues the reconciliation of the missing private data of a range of blocks,
+	// which takes precedence over the scheduled reconciliation of the most recent missing data
+	Reconcile(request ReconcileRequest) (*ReconcileProgress, error)
+	// Progress returns the progress of the enqueued reconciliation requests
+	Progress() []ReconcileProgress
+}
+
+// maxReconcileRequests is the maximum number of reconciliation requests whose
+// progress is retained, the progress of the oldest finished requests is dropped
+const maxReconcileRequests = 100
+
+// ReconcileRequest requests the reconciliation of the missing private data of
+// the blocks within [StartBlock, EndBlock], optionally limited to a namespace
+// or to a collection of a namespace
+type ReconcileRequest struct {
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+	Namespace  string `json:"namespace,omitempty"`
+	Collection string `json:"collection,omitempty"`
+}
+
+// ReconcileState is the state of a reconciliation request
+type ReconcileState string
+
+const (
+	ReconcileQueued    ReconcileState = "queued"
+	ReconcileRunning   ReconcileState = "running"
+	ReconcileCompleted ReconcileState = "completed"
+	ReconcileFailed    ReconcileState = "failed"
+)
+
+// ReconcileProgress is the progress of a reconciliation request. The blocks
+// of the range are reconciled from the end of the range towards its start.
+type ReconcileProgress struct {
+	ID int `json:"id"`
+	ReconcileRequest
+	State ReconcileState `json:"state"`
+	// NextBlock is the block the reconciliation proceeds from, the blocks
+	// above it within the range have been processed
+	NextBlock uint64 `json:"next_block"`
+	// Blocks is the number of processed blocks that missed private data
+	Blocks int `json:"blocks"`
+	// Reconciled is the number of private data elements that were reconciled
+	Reconciled int `json:"reconciled"`
+	// Unreconciled is the number of private data elements that could not be
+	// fetched from other peers
+	Unreconciled int    `json:"unreconciled"`
+	Error        string `json:"error,omitempty"`
 }
 
 type Reconciler struct {
@@ -66,6 +115,11 @@ type Reconciler struct {
 	stopOnce               sync.Once
 	ReconciliationFetcher
 	committer.Committer
+
+	requestLock   sync.Mutex
+	requests      []*ReconcileProgress
+	lastRequestID int
+	requestChan   chan struct{}
 }
 
 // NoOpReconciler non functional reconciler to be used
@@ -81,6 +135,14 @@ func (*NoOpReconciler) Stop() {
 	// do nothing
 }
 
+func (*NoOpReconciler) Reconcile(ReconcileRequest) (*ReconcileProgress, error) {
+	return nil, errors.New("private data reconciliation is disabled")
+}
+
+func (*NoOpReconciler) Progress() []ReconcileProgress {
+	return nil
+}
+
 // NewReconciler creates a new instance of reconciler
 func NewReconciler(channel string, metrics *metrics.PrivdataMetrics, c committer.Committer,
 	fetcher ReconciliationFetcher, config *PrivdataConfig) *Reconciler {
@@ -95,6 +157,7 @@ func NewReconciler(channel string, metrics *metrics.PrivdataMetrics, c committer
 		Committer:              c,
 		ReconciliationFetcher:  fetcher,
 		stopChan:               make(chan struct{}),
+		requestChan:            make(chan struct{}, 1),
 	}
 }
 
@@ -115,6 +178,8 @@ func (r *Reconciler) run() {
 		select {
 		case <-r.stopChan:
 			return
+		case <-r.requestChan:
+			r.processRequests()
 		case <-time.After(r.ReconcileSleepInterval):
 			r.logger.Debug("Start reconcile missing private info")
 			if err := r.reconcile(); err != nil {
@@ -157,28 +222,314 @@ func (r *Reconciler) reconcile() error {
 
 		r.logger.Debug("got from ledger", len(missingPvtDataInfo), "blocks with missing private data, trying to reconcile...")
 
-		dig2collectionCfg, minB, maxB := r.getDig2CollectionConfig(missingPvtDataInfo)
-		fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+		reconciled, _, minB, maxB, err := r.reconcileMissingPvtData(missingPvtDataInfo)
 		if err != nil {
-			r.logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
 			return err
 		}
-
-		pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
-		unreconciled := constructUnreconciledMissingData(dig2collectionCfg, fetchedData.AvailableElements)
-		pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit, unreconciled)
-		if err != nil {
-			return errors.Wrap(err, "failed to commit private data")
-		}
-		r.logMismatched(pvtdataHashMismatch)
 		if minB < minBlock {
 			minBlock = minB
 		}
 		if maxB > maxBlock {
 			maxBlock = maxB
 		}
-		totalReconciled += len(fetchedData.AvailableElements)
+		totalReconciled += reconciled
+	}
+}
+
+// reconcileMissingPvtData fetches the given missing private data from other peers and commits it.
+// It returns the number of reconciled and unreconciled items and the range of the blocks.
+func (r *Reconciler) reconcileMissingPvtData(missingPvtDataInfo ledger.MissingPvtDataInfo) (int, int, uint64, uint64, error) {
+	dig2collectionCfg, minBlock, maxBlock := r.getDig2CollectionConfig(missingPvtDataInfo)
+	fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+	if err != nil {
+		r.logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
+		return 0, 0, 0, 0, err
+	}
+
+	pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
+	unreconciled := constructUnreconciledMissingData(dig2collectionCfg, fetchedData.AvailableElements)
+	pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit, unreconciled)
+	if err != nil {
+		return 0, 0, 0, 0, errors.Wrap(err, "failed to commit private data")
+	}
+	r.logMismatched(pvtdataHashMismatch)
+
+	numUnreconciled := 0
+	for _, blockPvtDataInfo := range unreconciled {
+		for _, collectionPvtDataInfo := range blockPvtDataInfo {
+			numUnreconciled += len(collectionPvtDataInfo)
+		}
+	}
+	return len(fetchedData.AvailableElements), numUnreconciled, minBlock, maxBlock, nil
+}
+
+// Reconcile enqueues the reconciliation of the missing private data of a range of blocks
+func (r *Reconciler) Reconcile(request ReconcileRequest) (*ReconcileProgress, error) {
+	if request.StartBlock > request.EndBlock {
+		return nil, errors.Errorf("invalid block range [%d, %d]", request.StartBlock, request.EndBlock)
+	}
+	if request.Collection != "" && request.Namespace == "" {
+		return nil, errors.Errorf("collection %s requires a namespace", request.Collection)
+	}
+
+	r.requestLock.Lock()
+	r.lastRequestID++
+	progress := &ReconcileProgress{
+		ID:               r.lastRequestID,
+		ReconcileRequest: request,
+		State:            ReconcileQueued,
+		NextBlock:        request.EndBlock,
+	}
+	r.requests = append(r.requests, progress)
+	r.pruneRequests()
+	queued := *progress
+	r.requestLock.Unlock()
+
+	r.logger.Infof("Enqueued reconciliation request %d of missing private data of blocks [%d, %d]", queued.ID, request.StartBlock, request.EndBlock)
+	select {
+	case r.requestChan <- struct{}{}:
+	default:
+	}
+	return &queued, nil
+}
+
+// Progress returns the progress of the enqueued reconciliation requests
+func (r *Reconciler) Progress() []ReconcileProgress {
+	r.requestLock.Lock()
+	defer r.requestLock.Unlock()
+
+	progress := make([]ReconcileProgress, 0, len(r.requests))
+	for _, p := range r.requests {
+		progress = append(progress, *p)
+	}
+	return progress
+}
+
+// pruneRequests drops the oldest finished requests when more than maxReconcileRequests
+// requests are retained. It must be called while holding the requestLock.
+func (r *Reconciler) pruneRequests() {
+	excess := len(r.requests) - maxReconcileRequests
+	if excess <= 0 {
+		return
+	}
+	retained := r.requests[:0]
+	for _, p := range r.requests {
+		if excess > 0 && (p.State == ReconcileCompleted || p.State == ReconcileFailed) {
+			excess--
+			continue
+		}
+		retained = append(retained, p)
+	}
+	r.requests = retained
+}
+
+// nextRequest marks the oldest queued request as running and returns it
+func (r *Reconciler) nextRequest() *ReconcileProgress {
+	r.requestLock.Lock()
+	defer r.requestLock.Unlock()
+	for _, p := range r.requests {
+		if p.State == ReconcileQueued {
+			p.State = ReconcileRunning
+			return p
+		}
 	}
+	return nil
+}
+
+func (r *Reconciler) updateProgress(p *ReconcileProgress, update func(p *ReconcileProgress)) {
+	r.requestLock.Lock()
+	defer r.requestLock.Unlock()
+	update(p)
+}
+
+// processRequests processes the queued reconciliation requests in order
+func (r *Reconciler) processRequests() {
+	for {
+		p := r.nextRequest()
+		if p == nil {
+			return
+		}
+		r.logger.Infof("Reconciling missing private data of blocks [%d, %d] for request %d", p.StartBlock, p.EndBlock, p.ID)
+		start := time.Now()
+		err := r.reconcileRange(p)
+		r.reportReconciliationDuration(start)
+		var finished ReconcileProgress
+		r.updateProgress(p, func(p *ReconcileProgress) {
+			p.State = ReconcileCompleted
+			if err != nil {
+				p.State = ReconcileFailed
+				p.Error = err.Error()
+			}
+			finished = *p
+		})
+		if err != nil {
+			r.logger.Errorf("Failed to reconcile missing private data for request %d: %s", finished.ID, err)
+			continue
+		}
+		r.logger.Infof("Reconciliation request %d finished, reconciled %d private data elements, %d private data elements are still missing", finished.ID, finished.Reconciled, finished.Unreconciled)
+	}
+}
+
+// reconcileRange reconciles the missing private data of the block range of a request,
+// batch by batch, from the end of the range towards its start
+func (r *Reconciler) reconcileRange(p *ReconcileProgress) error {
+	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	if err != nil {
+		return errors.WithMessage(err, "failed to get missing private data tracker")
+	}
+	if missingPvtDataTracker == nil {
+		return errors.New("got nil as MissingPvtDataTracker")
+	}
+
+	endBlock := p.EndBlock
+	for {
+		select {
+		case <-r.stopChan:
+			return errors.New("reconciler stopped")
+		default:
+		}
+
+		missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForBlockRange(p.StartBlock, endBlock, r.ReconcileBatchSize)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get missing private data of blocks [%d, %d]", p.StartBlock, endBlock)
+		}
+		if len(missingPvtDataInfo) == 0 {
+			r.updateProgress(p, func(p *ReconcileProgress) { p.NextBlock = p.StartBlock })
+			return nil
+		}
+
+		minBlock := uint64(math.MaxUint64)
+		for blockNum := range missingPvtDataInfo {
+			if blockNum < minBlock {
+				minBlock = blockNum
+			}
+		}
+
+		requestedPvtDataInfo := filterMissingPvtDataInfo(missingPvtDataInfo, p.Namespace, p.Collection)
+		reconciled, unreconciled := 0, 0
+		if len(requestedPvtDataInfo) > 0 {
+			reconciled, unreconciled, _, _, err = r.reconcileMissingPvtData(requestedPvtDataInfo)
+			if err != nil {
+				return err
+			}
+		}
+
+		r.updateProgress(p, func(p *ReconcileProgress) {
+			p.NextBlock = minBlock
+			p.Blocks += len(requestedPvtDataInfo)
+			p.Reconciled += reconciled
+			p.Unreconciled += unreconciled
+		})
+
+		if minBlock <= p.StartBlock {
+			return nil
+		}
+		endBlock = minBlock - 1
+	}
+}
+
+// filterMissingPvtDataInfo returns the missing private data of a namespace, or of a collection of a
+// namespace. If no namespace is specified, all the missing private data is returned.
+func filterMissingPvtDataInfo(missingPvtDataInfo ledger.MissingPvtDataInfo, namespace, collection string) ledger.MissingPvtDataInfo {
+	if namespace == "" {
+		return missingPvtDataInfo
+	}
+
+	filtered := make(ledger.MissingPvtDataInfo)
+	for blockNum, blockPvtDataInfo := range missingPvtDataInfo {
+		for seqInBlock, collectionPvtDataInfo := range blockPvtDataInfo {
+			for _, pvtDataInfo := range collectionPvtDataInfo {
+				if pvtDataInfo.Namespace != namespace || (collection != "" && pvtDataInfo.Collection != collection) {
+					continue
+				}
+				filtered.Add(blockNum, seqInBlock, pvtDataInfo.Namespace, pvtDataInfo.Collection)
+			}
+		}
+	}
+	return filtered
+}
+
+// MissingPvtDataCount counts the missing private data of a collection
+type MissingPvtDataCount struct {
+	Namespace  string `json:"namespace"`
+	Collection string `json:"collection"`
+	// Blocks is the number of blocks which miss private data of the collection
+	Blocks int `json:"blocks"`
+	// Transactions is the number of transactions which miss private data of the collection
+	Transactions int    `json:"transactions"`
+	LowestBlock  uint64 `json:"lowest_block"`
+	HighestBlock uint64 `json:"highest_block"`
+}
+
+// CountMissingPvtData counts the outstanding missing private data of the eligible collections,
+// by namespace and collection, optionally limited to a namespace or to a collection of a namespace.
+// The missing private data is retrieved from the tracker batchSize blocks at a time.
+func CountMissingPvtData(missingPvtDataTracker ledger.MissingPvtDataTracker, batchSize int, namespace, collection string) ([]MissingPvtDataCount, error) {
+	type nsColl struct {
+		namespace, collection string
+	}
+	counts := map[nsColl]*MissingPvtDataCount{}
+
+	endBlock := uint64(math.MaxUint64)
+	for {
+		missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForBlockRange(0, endBlock, batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(missingPvtDataInfo) == 0 {
+			break
+		}
+
+		minBlock := uint64(math.MaxUint64)
+		for blockNum, blockPvtDataInfo := range missingPvtDataInfo {
+			if blockNum < minBlock {
+				minBlock = blockNum
+			}
+			blockCounted := map[nsColl]bool{}
+			for _, collectionPvtDataInfo := range filterMissingPvtDataInfo(ledger.MissingPvtDataInfo{blockNum: blockPvtDataInfo}, namespace, collection)[blockNum] {
+				for _, pvtDataInfo := range collectionPvtDataInfo {
+					key := nsColl{namespace: pvtDataInfo.Namespace, collection: pvtDataInfo.Collection}
+					count, exists := counts[key]
+					if !exists {
+						count = &MissingPvtDataCount{
+							Namespace:   pvtDataInfo.Namespace,
+							Collection:  pvtDataInfo.Collection,
+							LowestBlock: blockNum,
+						}
+						counts[key] = count
+					}
+					count.Transactions++
+					if !blockCounted[key] {
+						blockCounted[key] = true
+						count.Blocks++
+					}
+					if blockNum < count.LowestBlock {
+						count.LowestBlock = blockNum
+					}
+					if blockNum > count.HighestBlock {
+						count.HighestBlock = blockNum
+					}
+				}
+			}
+		}
+
+		if minBlock == 0 {
+			break
+		}
+		endBlock = minBlock - 1
+	}
+
+	result := make([]MissingPvtDataCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Collection < result[j].Collection
+	})
+	return result, nil
 }
 
 func (r *Reconciler) reportReconciliationDuration(startTime time.Time) {
@@ -8,6 +8,7 @@ package privdata
 
 import (
 	"errors"
+	"math"
 	"sync"
 	"testing"
 	"time"
@@ -708,3 +709,220 @@ func TestConstructUnreconciledMissingData(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileRequest(t *testing.T) {
+	// Scenario: the missing private data of the range [2, 5] is requested to be reconciled in batches of 2 blocks.
+	// blocks 5 and 4 are processed in the first batch and blocks 3 and 2 in the second one. Only the data of
+	// ns1/col1 is requested, and the private data of block 2 cannot be fetched from other peers.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingInfo := func(blockNums ...uint64) ledger.MissingPvtDataInfo {
+		missingInfo := ledger.MissingPvtDataInfo{}
+		for _, blockNum := range blockNums {
+			missingInfo.Add(blockNum, 1, "ns1", "col1")
+			missingInfo.Add(blockNum, 2, "ns1", "col2")
+		}
+		return missingInfo
+	}
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(5), 2).Return(missingInfo(4, 5), nil)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(2), uint64(3), 2).Return(missingInfo(2, 3), nil)
+
+	collectionConfigInfo := ledger.CollectionConfigInfo{
+		CollectionConfig: &peer.CollectionConfigPackage{
+			Config: []*peer.CollectionConfig{
+				{Payload: &peer.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &peer.StaticCollectionConfig{
+						Name: "col1",
+					},
+				}},
+			},
+		},
+		CommittingBlockNum: 1,
+	}
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(&collectionConfigInfo, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+
+	var requestedBlocks []uint64
+	fetcher.On("FetchReconciledItems", mock.Anything).Return(func(dig2CollectionConfig privdatacommon.Dig2CollectionConfig) *privdatacommon.FetchedPvtDataContainer {
+		result := &privdatacommon.FetchedPvtDataContainer{}
+		for digest := range dig2CollectionConfig {
+			require.Equal(t, "ns1", digest.Namespace)
+			require.Equal(t, "col1", digest.Collection)
+			requestedBlocks = append(requestedBlocks, digest.BlockSeq)
+			if digest.BlockSeq == 2 {
+				continue
+			}
+			result.AvailableElements = append(result.AvailableElements, &gossip2.PvtDataElement{
+				Digest: &gossip2.PvtDataDigest{
+					TxId:       digest.TxId,
+					BlockSeq:   digest.BlockSeq,
+					Collection: digest.Collection,
+					Namespace:  digest.Namespace,
+					SeqInBlock: digest.SeqInBlock,
+				},
+				Payload: [][]byte{[]byte("rws-pre-image")},
+			})
+		}
+		return result
+	}, nil)
+
+	var unreconciled []ledger.MissingPvtDataInfo
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		unreconciled = append(unreconciled, args.Get(1).(ledger.MissingPvtDataInfo))
+	}).Return(nil, nil)
+
+	r := NewReconciler("mychannel", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: time.Minute, ReconcileBatchSize: 2, ReconciliationEnabled: true})
+
+	progress, err := r.Reconcile(ReconcileRequest{StartBlock: 2, EndBlock: 5, Namespace: "ns1", Collection: "col1"})
+	require.NoError(t, err)
+	require.Equal(t, &ReconcileProgress{
+		ID:               1,
+		ReconcileRequest: ReconcileRequest{StartBlock: 2, EndBlock: 5, Namespace: "ns1", Collection: "col1"},
+		State:            ReconcileQueued,
+		NextBlock:        5,
+	}, progress)
+	require.Equal(t, []ReconcileProgress{*progress}, r.Progress())
+
+	r.processRequests()
+
+	require.ElementsMatch(t, []uint64{2, 3, 4, 5}, requestedBlocks)
+	require.Equal(t, []ledger.MissingPvtDataInfo{nil, {2: {1: {{Namespace: "ns1", Collection: "col1"}}}}}, unreconciled)
+	require.Equal(t, []ReconcileProgress{
+		{
+			ID:               1,
+			ReconcileRequest: ReconcileRequest{StartBlock: 2, EndBlock: 5, Namespace: "ns1", Collection: "col1"},
+			State:            ReconcileCompleted,
+			NextBlock:        2,
+			Blocks:           4,
+			Reconciled:       3,
+			Unreconciled:     1,
+		},
+	}, r.Progress())
+}
+
+func TestReconcileRequestWithScheduler(t *testing.T) {
+	// Scenario: a reconciliation request is processed by a started reconciler
+	// without waiting for the scheduled reconciliation
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(0), uint64(10), 1).Return(nil, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+
+	r := NewReconciler("mychannel", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: time.Hour, ReconcileBatchSize: 1, ReconciliationEnabled: true})
+	r.Start()
+	defer r.Stop()
+
+	_, err := r.Reconcile(ReconcileRequest{StartBlock: 0, EndBlock: 10})
+	require.NoError(t, err)
+
+	requestCompleted := func() bool {
+		return r.Progress()[0].State == ReconcileCompleted
+	}
+	require.Eventually(t, requestCompleted, 10*time.Second, 10*time.Millisecond)
+	fetcher.AssertNotCalled(t, "FetchReconciledItems", mock.Anything)
+}
+
+func TestReconcileRequestFailures(t *testing.T) {
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	r := NewReconciler("mychannel", metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: time.Minute, ReconcileBatchSize: 1, ReconciliationEnabled: true})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := r.Reconcile(ReconcileRequest{StartBlock: 5, EndBlock: 4})
+		require.EqualError(t, err, "invalid block range [5, 4]")
+
+		_, err = r.Reconcile(ReconcileRequest{StartBlock: 1, EndBlock: 4, Collection: "col1"})
+		require.EqualError(t, err, "collection col1 requires a namespace")
+		require.Empty(t, r.Progress())
+	})
+
+	t.Run("failures while reconciling", func(t *testing.T) {
+		committer.On("GetMissingPvtDataTracker").Return(nil, errors.New("failed to obtain missing pvt data tracker")).Once()
+		committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+		missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("leveldb error"))
+
+		_, err := r.Reconcile(ReconcileRequest{StartBlock: 1, EndBlock: 4})
+		require.NoError(t, err)
+		_, err = r.Reconcile(ReconcileRequest{StartBlock: 1, EndBlock: 4})
+		require.NoError(t, err)
+		r.processRequests()
+
+		progress := r.Progress()
+		require.Len(t, progress, 2)
+		require.Equal(t, ReconcileFailed, progress[0].State)
+		require.Equal(t, "failed to get missing private data tracker: failed to obtain missing pvt data tracker", progress[0].Error)
+		require.Equal(t, ReconcileFailed, progress[1].State)
+		require.Equal(t, "failed to get missing private data of blocks [1, 4]: leveldb error", progress[1].Error)
+	})
+
+	t.Run("finished requests are pruned", func(t *testing.T) {
+		for i := 0; i < maxReconcileRequests; i++ {
+			_, err := r.Reconcile(ReconcileRequest{StartBlock: 1, EndBlock: 4})
+			require.NoError(t, err)
+		}
+		progress := r.Progress()
+		require.Len(t, progress, maxReconcileRequests)
+		require.Equal(t, 3, progress[0].ID)
+		require.Equal(t, maxReconcileRequests+2, progress[maxReconcileRequests-1].ID)
+	})
+
+	t.Run("reconciliation disabled", func(t *testing.T) {
+		_, err := (&NoOpReconciler{}).Reconcile(ReconcileRequest{StartBlock: 1, EndBlock: 4})
+		require.EqualError(t, err, "private data reconciliation is disabled")
+		require.Empty(t, (&NoOpReconciler{}).Progress())
+	})
+}
+
+func TestCountMissingPvtData(t *testing.T) {
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	firstBatch := ledger.MissingPvtDataInfo{}
+	firstBatch.Add(9, 1, "ns1", "col1")
+	firstBatch.Add(9, 2, "ns1", "col1")
+	firstBatch.Add(9, 2, "ns2", "col1")
+	firstBatch.Add(7, 1, "ns1", "col2")
+	secondBatch := ledger.MissingPvtDataInfo{}
+	secondBatch.Add(3, 1, "ns1", "col1")
+	secondBatch.Add(1, 4, "ns2", "col1")
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(0), uint64(math.MaxUint64), 2).Return(firstBatch, nil)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(0), uint64(6), 2).Return(secondBatch, nil)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(0), uint64(0), 2).Return(nil, nil)
+
+	counts, err := CountMissingPvtData(missingPvtDataTracker, 2, "", "")
+	require.NoError(t, err)
+	require.Equal(t, []MissingPvtDataCount{
+		{Namespace: "ns1", Collection: "col1", Blocks: 2, Transactions: 3, LowestBlock: 3, HighestBlock: 9},
+		{Namespace: "ns1", Collection: "col2", Blocks: 1, Transactions: 1, LowestBlock: 7, HighestBlock: 7},
+		{Namespace: "ns2", Collection: "col1", Blocks: 2, Transactions: 2, LowestBlock: 1, HighestBlock: 9},
+	}, counts)
+
+	counts, err = CountMissingPvtData(missingPvtDataTracker, 2, "ns1", "")
+	require.NoError(t, err)
+	require.Len(t, counts, 2)
+
+	counts, err = CountMissingPvtData(missingPvtDataTracker, 2, "ns1", "col2")
+	require.NoError(t, err)
+	require.Equal(t, []MissingPvtDataCount{
+		{Namespace: "ns1", Collection: "col2", Blocks: 1, Transactions: 1, LowestBlock: 7, HighestBlock: 7},
+	}, counts)
+
+	counts, err = CountMissingPvtData(missingPvtDataTracker, 2, "ns3", "")
+	require.NoError(t, err)
+	require.Empty(t, counts)
+
+	failingTracker := &mocks.MissingPvtDataTracker{}
+	failingTracker.On("GetMissingPvtDataInfoForBlockRange", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("leveldb error"))
+	_, err = CountMissingPvtData(failingTracker, 2, "", "")
+	require.EqualError(t, err, "leveldb error")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/msgs"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// GzipCompression is the gzip compression algorithm. Peers advertise the
+// algorithms they accept as a bitmask of the algorithms.
+const GzipCompression = 1
+
+// SetCompressionSupport sets whether a ConnEstablish message advertises that
+// its sender accepts gzip compressed envelopes.
+func SetCompressionSupport(m *gossip.ConnEstablish, supported bool) error {
+	ce := &msgs.ConnEstablish{}
+	if err := protoutil.ConvertMessage(m, ce); err != nil {
+		return err
+	}
+	ce.AcceptedCompression = 0
+	if supported {
+		ce.AcceptedCompression = GzipCompression
+	}
+	return protoutil.ConvertMessage(ce, m)
+}
+
+// SupportsCompression returns whether a ConnEstablish message advertises that
+// its sender accepts gzip compressed envelopes.
+func SupportsCompression(m *gossip.ConnEstablish) bool {
+	// the compression support is an unknown field of the ConnEstablish message
+	if m == nil || len(m.XXX_unrecognized) == 0 {
+		return false
+	}
+	ce := &msgs.ConnEstablish{}
+	if err := protoutil.ConvertMessage(m, ce); err != nil {
+		return false
+	}
+	return ce.AcceptedCompression&GzipCompression != 0
+}
+
+// CompressEnvelope returns a copy of the given envelope with its payload
+// compressed with gzip. The signature and secret envelope are kept as they
+// are, since the signature is over the uncompressed payload.
+func CompressEnvelope(e *gossip.Envelope) (*gossip.Envelope, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(e.Payload); err != nil {
+		return nil, errors.Wrap(err, "failed compressing payload")
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed compressing payload")
+	}
+	compressed := &gossip.Envelope{}
+	err := protoutil.ConvertMessage(&msgs.Envelope{
+		Payload:        buf.Bytes(),
+		Signature:      e.Signature,
+		SecretEnvelope: e.SecretEnvelope,
+		Compression:    GzipCompression,
+	}, compressed)
+	if err != nil {
+		return nil, err
+	}
+	return compressed, nil
+}
+
+// DecompressEnvelope returns a copy of the given envelope with its payload
+// decompressed, or the envelope itself if its payload is not compressed.
+// Payloads which decompress to more than maxSize bytes are rejected.
+func DecompressEnvelope(e *gossip.Envelope, maxSize int) (*gossip.Envelope, error) {
+	// the compression algorithm is an unknown field of the Envelope message,
+	// so envelopes without unknown fields are not converted
+	if len(e.XXX_unrecognized) == 0 {
+		return e, nil
+	}
+	env := &msgs.Envelope{}
+	if err := protoutil.ConvertMessage(e, env); err != nil {
+		return nil, err
+	}
+	if env.Compression == 0 {
+		return e, nil
+	}
+	if env.Compression != GzipCompression {
+		return nil, errors.Errorf("unknown compression algorithm %d", env.Compression)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(env.Payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decompressing payload")
+	}
+	payload, err := ioutil.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decompressing payload")
+	}
+	if len(payload) > maxSize {
+		return nil, errors.Errorf("decompressed payload is larger than %d bytes", maxSize)
+	}
+	return &gossip.Envelope{
+		Payload:        payload,
+		Signature:      env.Signature,
+		SecretEnvelope: env.SecretEnvelope,
+	}, nil
+}
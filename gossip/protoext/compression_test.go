@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/msgs"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionSupport(t *testing.T) {
+	ce := &gossip.ConnEstablish{PkiId: []byte{1, 2, 3}}
+	require.False(t, protoext.SupportsCompression(ce))
+	require.False(t, protoext.SupportsCompression(nil))
+
+	plain, err := proto.Marshal(ce)
+	require.NoError(t, err)
+
+	// Lack of support is not encoded
+	require.NoError(t, protoext.SetCompressionSupport(ce, false))
+	b, err := proto.Marshal(ce)
+	require.NoError(t, err)
+	require.Equal(t, plain, b)
+
+	// Support survives a round trip through a gossip message
+	require.NoError(t, protoext.SetCompressionSupport(ce, true))
+	msg := &gossip.GossipMessage{
+		Content: &gossip.GossipMessage_Conn{Conn: ce},
+	}
+	b, err = proto.Marshal(msg)
+	require.NoError(t, err)
+	received := &gossip.GossipMessage{}
+	require.NoError(t, proto.Unmarshal(b, received))
+	require.True(t, protoext.SupportsCompression(received.GetConn()))
+	require.Equal(t, []byte{1, 2, 3}, received.GetConn().PkiId)
+
+	// Unknown algorithms are not supported
+	require.NoError(t, protoutil.ConvertMessage(&msgs.ConnEstablish{AcceptedCompression: 2}, ce))
+	require.False(t, protoext.SupportsCompression(ce))
+}
+
+func TestCompressEnvelope(t *testing.T) {
+	env := &gossip.Envelope{
+		Payload:        bytes.Repeat([]byte{1, 2, 3, 4}, 1000),
+		Signature:      []byte{5, 6},
+		SecretEnvelope: &gossip.SecretEnvelope{Payload: []byte{7}},
+	}
+
+	// Envelopes which aren't compressed are returned as they are
+	decompressed, err := protoext.DecompressEnvelope(env, len(env.Payload))
+	require.NoError(t, err)
+	require.True(t, env == decompressed)
+
+	compressed, err := protoext.CompressEnvelope(env)
+	require.NoError(t, err)
+	require.Less(t, len(compressed.Payload), len(env.Payload))
+	require.Equal(t, env.Signature, compressed.Signature)
+	require.True(t, proto.Equal(env.SecretEnvelope, compressed.SecretEnvelope))
+
+	// Compressed envelopes survive a round trip through the wire
+	b, err := proto.Marshal(compressed)
+	require.NoError(t, err)
+	received := &gossip.Envelope{}
+	require.NoError(t, proto.Unmarshal(b, received))
+	decompressed, err = protoext.DecompressEnvelope(received, len(env.Payload))
+	require.NoError(t, err)
+	require.True(t, proto.Equal(env, decompressed))
+	require.Nil(t, decompressed.XXX_unrecognized)
+
+	// Payloads which decompress to more than the maximum size are rejected
+	_, err = protoext.DecompressEnvelope(received, len(env.Payload)-1)
+	require.EqualError(t, err, "decompressed payload is larger than 3999 bytes")
+
+	// Unknown compression algorithms are rejected
+	require.NoError(t, protoutil.ConvertMessage(&msgs.Envelope{Payload: received.Payload, Compression: 2}, received))
+	_, err = protoext.DecompressEnvelope(received, len(env.Payload))
+	require.EqualError(t, err, "unknown compression algorithm 2")
+
+	// Corrupt payloads are rejected
+	require.NoError(t, protoutil.ConvertMessage(&msgs.Envelope{Payload: []byte{1, 2, 3}, Compression: protoext.GzipCompression}, received))
+	_, err = protoext.DecompressEnvelope(received, len(env.Payload))
+	require.EqualError(t, err, "failed decompressing payload: unexpected EOF")
+}
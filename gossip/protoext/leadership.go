@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext
+
+import (
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/msgs"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// SetLeadershipPriority sets the leader election priority advertised by a
+// LeadershipMessage. The default priority, 0, is not encoded, so that
+// messages of peers which do not set a priority are unchanged.
+func SetLeadershipPriority(m *gossip.LeadershipMessage, priority uint32) error {
+	lm := &msgs.LeadershipMessage{}
+	if err := protoutil.ConvertMessage(m, lm); err != nil {
+		return err
+	}
+	lm.Priority = priority
+	return protoutil.ConvertMessage(lm, m)
+}
+
+// LeadershipPriority returns the leader election priority advertised by a
+// LeadershipMessage, or 0 if the message does not advertise a priority or
+// the priority cannot be decoded.
+func LeadershipPriority(m *gossip.LeadershipMessage) uint32 {
+	if m == nil {
+		return 0
+	}
+	lm := &msgs.LeadershipMessage{}
+	if err := protoutil.ConvertMessage(m, lm); err != nil {
+		return 0
+	}
+	return lm.Priority
+}
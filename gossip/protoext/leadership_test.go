@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeadershipPriority(t *testing.T) {
+	lm := &gossip.LeadershipMessage{
+		PkiId:         []byte{1, 2, 3},
+		IsDeclaration: true,
+		Timestamp:     &gossip.PeerTime{IncNum: 1, SeqNum: 2},
+	}
+	require.Zero(t, protoext.LeadershipPriority(lm))
+	require.Zero(t, protoext.LeadershipPriority(nil))
+
+	plain, err := proto.Marshal(lm)
+	require.NoError(t, err)
+
+	// The default priority is not encoded
+	require.NoError(t, protoext.SetLeadershipPriority(lm, 0))
+	b, err := proto.Marshal(lm)
+	require.NoError(t, err)
+	require.Equal(t, plain, b)
+
+	require.NoError(t, protoext.SetLeadershipPriority(lm, 10))
+	require.Equal(t, uint32(10), protoext.LeadershipPriority(lm))
+
+	// The priority survives a round trip through a gossip message
+	msg := &gossip.GossipMessage{
+		Tag:     gossip.GossipMessage_CHAN_AND_ORG,
+		Content: &gossip.GossipMessage_LeadershipMsg{LeadershipMsg: lm},
+	}
+	b, err = proto.Marshal(msg)
+	require.NoError(t, err)
+	received := &gossip.GossipMessage{}
+	require.NoError(t, proto.Unmarshal(b, received))
+	require.Equal(t, uint32(10), protoext.LeadershipPriority(received.GetLeadershipMsg()))
+	require.Equal(t, []byte{1, 2, 3}, received.GetLeadershipMsg().PkiId)
+	require.True(t, received.GetLeadershipMsg().IsDeclaration)
+
+	// Resetting the priority to the default removes it
+	require.NoError(t, protoext.SetLeadershipPriority(lm, 0))
+	require.Zero(t, protoext.LeadershipPriority(lm))
+
+	// A malformed priority is ignored
+	lm.XXX_unrecognized = []byte{0xa8, 0x06, 0x80}
+	require.Zero(t, protoext.LeadershipPriority(lm))
+}
@@ -44,10 +44,11 @@ type ReceivedMessage interface {
 // ConnectionInfo represents information about
 // the remote peer that sent a certain ReceivedMessage
 type ConnectionInfo struct {
-	ID       common.PKIidType
-	Auth     *AuthInfo
-	Identity api.PeerIdentityType
-	Endpoint string
+	ID          common.PKIidType
+	Auth        *AuthInfo
+	Identity    api.PeerIdentityType
+	Endpoint    string
+	Compression bool // whether the remote peer accepts compressed envelopes
 }
 
 // String returns a string representation of this ConnectionInfo
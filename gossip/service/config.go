@@ -40,6 +40,9 @@ type ServiceConfig struct {
 	// ElectionLeaderElectionDuration is the time passes since last declaration message before peer decides to perform
 	// leader election (unit: second).
 	ElectionLeaderElectionDuration time.Duration
+	// ElectionPriority is the priority of the peer in leader election. Peers with a higher
+	// priority are preferred as leaders, and take over the leadership from peers with a lower priority.
+	ElectionPriority uint32
 	// PvtDataPullRetryThreshold determines the maximum duration of time private data corresponding for
 	// a given block.
 	PvtDataPullRetryThreshold time.Duration
@@ -75,6 +78,9 @@ func (c *ServiceConfig) loadGossipConfig() {
 	c.ElectionMembershipSampleInterval = util.GetDurationOrDefault("peer.gossip.election.membershipSampleInterval", election.DefMembershipSampleInterval)
 	c.ElectionLeaderAliveThreshold = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold)
 	c.ElectionLeaderElectionDuration = util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", election.DefLeaderElectionDuration)
+	if priority := viper.GetInt("peer.gossip.election.priority"); priority > 0 {
+		c.ElectionPriority = uint32(priority)
+	}
 
 	c.PvtDataPushAckTimeout = viper.GetDuration("peer.gossip.pvtData.pushAckTimeout")
 	c.PvtDataPullRetryThreshold = viper.GetDuration("peer.gossip.pvtData.pullRetryThreshold")
@@ -28,6 +28,7 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.orgLeader", true)
 	viper.Set("peer.gossip.election.leaderAliveThreshold", "10m")
 	viper.Set("peer.gossip.election.leaderElectionDuration", "5s")
+	viper.Set("peer.gossip.election.priority", 10)
 	viper.Set("peer.gossip.pvtData.btlPullMargin", 15)
 	viper.Set("peer.gossip.pvtData.transientstoreMaxBlockRetention", 1000)
 	viper.Set("peer.gossip.pvtData.skipPullingInvalidTransactionsDuringCommit", false)
@@ -46,6 +47,7 @@ func TestGlobalConfig(t *testing.T) {
 		ElectionLeaderElectionDuration:             5 * time.Second,
 		ElectionStartupGracePeriod:                 election.DefStartupGracePeriod,
 		ElectionMembershipSampleInterval:           election.DefMembershipSampleInterval,
+		ElectionPriority:                           10,
 		BtlPullMargin:                              15,
 		TransientstoreMaxBlockRetention:            uint64(1000),
 		SkipPullingInvalidTransactionsDuringCommit: false,
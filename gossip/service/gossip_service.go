@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"crypto/tls"
 	"fmt"
 	"sync"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/filter"
 	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
 	gossipmetrics "github.com/hyperledger/fabric/gossip/metrics"
 	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/gossip/protoext"
@@ -60,6 +62,13 @@ type gossipSvc interface {
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChannelID) []discovery.NetworkMember
 
+	// DeadPeers returns the NetworkMembers considered dead
+	DeadPeers() []discovery.NetworkMember
+
+	// ChannelStatus returns the state of the given channel as known
+	// by the peer, or nil if the peer is not in the channel
+	ChannelStatus(common.ChannelID) *channel.Status
+
 	// UpdateMetadata updates the self metadata of the discovery layer
 	// the peer publishes to other peers
 	UpdateMetadata(metadata []byte)
@@ -101,6 +110,14 @@ type gossipSvc interface {
 	// IdentityInfo returns information known peer identities
 	IdentityInfo() api.PeerIdentitySet
 
+	// UpdateIdentity replaces the identity of this peer with the given identity,
+	// which has to be of the same organization
+	UpdateIdentity(selfIdentity api.PeerIdentityType) error
+
+	// UpdateTLSCertificates replaces the TLS certificates the peer uses in
+	// gossip connections
+	UpdateTLSCertificates(serverCert, clientCert tls.Certificate)
+
 	// IsInMyOrg checks whether a network member is in this peer's org
 	IsInMyOrg(member discovery.NetworkMember) bool
 
@@ -150,11 +167,13 @@ type privateHandler struct {
 	coordinator gossipprivdata.Coordinator
 	distributor gossipprivdata.PvtDataDistributor
 	reconciler  gossipprivdata.PvtDataReconciler
+	puller      gossipprivdata.PullTracker
 }
 
 func (p privateHandler) close() {
 	p.coordinator.Close()
 	p.reconciler.Stop()
+	p.distributor.Stop()
 }
 
 // GossipService handles the interaction between gossip service and peer
@@ -362,11 +381,18 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 	}
 
 	pushAckTimeout := g.serviceConfig.PvtDataPushAckTimeout
+	disseminationRetryConfig := gossipprivdata.DisseminationRetryConfig{
+		Enabled:                 g.privdataConfig.DisseminationRetryEnabled,
+		Interval:                g.privdataConfig.DisseminationRetryInterval,
+		QueueSize:               g.privdataConfig.DisseminationRetryQueueSize,
+		TransientBlockRetention: g.serviceConfig.TransientstoreMaxBlockRetention,
+	}
 	g.privateHandlers[channelID] = privateHandler{
 		support:     support,
 		coordinator: coordinator,
-		distributor: gossipprivdata.NewDistributor(channelID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, pushAckTimeout),
+		distributor: gossipprivdata.NewDistributor(channelID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, pushAckTimeout, disseminationRetryConfig),
 		reconciler:  reconciler,
+		puller:      fetcher,
 	}
 	g.privateHandlers[channelID].reconciler.Start()
 
@@ -464,6 +490,26 @@ func (g *GossipService) AddPayload(channelID string, payload *gproto.Payload) er
 	return g.chains[channelID].AddPayload(payload)
 }
 
+// OrgPeerEndpoints returns the endpoints of the alive peers of the
+// organization of the peer, both external and internal
+func (g *GossipService) OrgPeerEndpoints() []string {
+	var endpoints []string
+	seen := map[string]struct{}{}
+	for _, member := range g.gossipSvc.Peers() {
+		if !g.gossipSvc.IsInMyOrg(member) {
+			continue
+		}
+		for _, endpoint := range []string{member.Endpoint, member.InternalEndpoint} {
+			if _, exists := seen[endpoint]; endpoint == "" || exists {
+				continue
+			}
+			seen[endpoint] = struct{}{}
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
 // Stop stops the gossip component
 func (g *GossipService) Stop() {
 	g.lock.Lock()
@@ -494,6 +540,7 @@ func (g *GossipService) newLeaderElectionComponent(channelID string, callback fu
 		MembershipSampleInterval: g.serviceConfig.ElectionMembershipSampleInterval,
 		LeaderAliveThreshold:     g.serviceConfig.ElectionLeaderAliveThreshold,
 		LeaderElectionDuration:   g.serviceConfig.ElectionLeaderElectionDuration,
+		Priority:                 g.serviceConfig.ElectionPriority,
 	}
 	return election.NewLeaderElectionService(adapter, string(PKIid), callback, config)
 }
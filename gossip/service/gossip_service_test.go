@@ -220,6 +220,50 @@ func TestLeaderElectionWithDeliverClient(t *testing.T) {
 
 	require.Equal(t, 1, startsNum, "Only for one peer delivery client should start")
 
+	// Ensure all peers know the elected leader
+	require.Eventually(t, func() bool {
+		leaders := map[string]struct{}{}
+		for i := 0; i < n; i++ {
+			orgLeader := gossips[i].Status().Channels[0].OrgLeader
+			if orgLeader == nil {
+				return false
+			}
+			leaders[orgLeader.PKIID] = struct{}{}
+		}
+		return len(leaders) == 1
+	}, time.Second*30, time.Second)
+
+	// Relinquish the leadership and ensure another peer takes over
+	leader := -1
+	for i := 0; i < n; i++ {
+		if services[i].IsLeader() {
+			leader = i
+		}
+	}
+	require.NoError(t, gossips[leader].RelinquishLeadership(channelName))
+	require.Equal(t, []Leadership{{Channel: channelName, Relinquished: true}}, gossips[leader].Leadership())
+	require.False(t, gossips[leader].deliveryService[channelName].(*mockDeliverService).running[channelName])
+	require.True(t, waitForLeaderElection(services, time.Second*30, time.Second*2), "One leader should be selected")
+	require.False(t, services[leader].IsLeader())
+	require.NoError(t, gossips[leader].ResumeLeadership(channelName))
+	require.Equal(t, []Leadership{{Channel: channelName}}, gossips[leader].Leadership())
+
+	require.EqualError(t, gossips[leader].RelinquishLeadership("chanB"), "leader election is not used for channel chanB")
+	require.EqualError(t, gossips[leader].ResumeLeadership("chanB"), "leader election is not used for channel chanB")
+
+	// Replace the identity of the current leader, and ensure leader election restarts with the new identity
+	for i := 0; i < n; i++ {
+		if services[i].IsLeader() {
+			leader = i
+		}
+	}
+	require.NoError(t, gossips[leader].UpdateIdentity(api.PeerIdentityType("new identity")))
+	require.Equal(t, gossipcommon.PKIidType("new identity"), gossips[leader].SelfMembershipInfo().PKIid)
+	require.False(t, gossips[leader].deliveryService[channelName].(*mockDeliverService).running[channelName])
+	require.NotEqual(t, services[leader].LeaderElectionService, gossips[leader].leaderElection[channelName])
+	services[leader].LeaderElectionService = gossips[leader].leaderElection[channelName]
+	require.True(t, waitForLeaderElection(services, time.Second*30, time.Second*2), "One leader should be selected")
+
 	stopPeers(gossips)
 }
 
@@ -284,6 +328,28 @@ func TestWithStaticDeliverClientLeader(t *testing.T) {
 		require.True(t, gossips[i].deliveryService[channelName].(*mockDeliverService).running[channelName], "Block deliverer not started for peer %d", i)
 	}
 
+	for i := 0; i < n; i++ {
+		status := gossips[i].Status()
+		require.Equal(t, gossips[i].gossipSvc.SelfMembershipInfo().PKIid.String(), status.Self.PKIID)
+		require.Len(t, status.Alive, n-1)
+		require.Len(t, status.Channels, 2)
+		require.Equal(t, "chanA", status.Channels[0].Channel)
+		require.Len(t, status.Channels[0].Members, n-1)
+		require.Equal(t, "chanB", status.Channels[1].Channel)
+		for _, channelStatus := range status.Channels {
+			require.True(t, channelStatus.IsLeader)
+			require.Equal(t, status.Self, *channelStatus.OrgLeader)
+			require.Zero(t, channelStatus.PendingPvtDataPulls)
+			require.Zero(t, channelStatus.PendingReconciliations)
+		}
+
+		var expectedEndpoints []string
+		for _, member := range status.Alive {
+			expectedEndpoints = append(expectedEndpoints, member.Endpoint, member.InternalEndpoint)
+		}
+		require.ElementsMatch(t, expectedEndpoints, gossips[i].OrgPeerEndpoints())
+	}
+
 	stopPeers(gossips)
 }
 
@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/pkg/errors"
+)
+
+// UpdateIdentity replaces the identity of the peer in gossip with the given
+// identity, which has to be of the same organization, for instance after its
+// enrollment certificate was renewed. Leader election is restarted in all
+// channels, since the peers of an organization are elected by their PKI-IDs.
+func (g *GossipService) UpdateIdentity(selfIdentity api.PeerIdentityType) error {
+	if err := g.gossipSvc.UpdateIdentity(selfIdentity); err != nil {
+		return errors.WithMessage(err, "failed updating the identity of the peer in gossip")
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.peerIdentity = selfIdentity
+	for channelID, le := range g.leaderElection {
+		le.Stop()
+		callback := g.onStatusChangeFactory(channelID, g.privateHandlers[channelID].support.Committer)
+		if le.IsLeader() {
+			// The peer is elected again with its new PKI-ID, or another peer takes over
+			callback(false)
+		}
+		newLE := g.newLeaderElectionComponent(channelID, callback, g.metrics.ElectionMetrics)
+		if le.IsRelinquished() {
+			newLE.Relinquish()
+		}
+		g.leaderElection[channelID] = newLE
+		logger.Infof("Restarted leader election for channel %s with the new identity", channelID)
+	}
+	return nil
+}
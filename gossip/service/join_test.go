@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"crypto/tls"
 	"sync"
 	"testing"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/filter"
 	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
 	"github.com/hyperledger/fabric/gossip/protoext"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/msp"
@@ -69,6 +71,14 @@ func (*gossipMock) PeersOfChannel(common.ChannelID) []discovery.NetworkMember {
 	panic("implement me")
 }
 
+func (*gossipMock) DeadPeers() []discovery.NetworkMember {
+	panic("implement me")
+}
+
+func (*gossipMock) ChannelStatus(common.ChannelID) *channel.Status {
+	panic("implement me")
+}
+
 func (*gossipMock) UpdateMetadata(metadata []byte) {
 	panic("implement me")
 }
@@ -105,6 +115,14 @@ func (g *gossipMock) IdentityInfo() api.PeerIdentitySet {
 	panic("implement me")
 }
 
+func (*gossipMock) UpdateIdentity(selfIdentity api.PeerIdentityType) error {
+	panic("implement me")
+}
+
+func (*gossipMock) UpdateTLSCertificates(serverCert, clientCert tls.Certificate) {
+	panic("implement me")
+}
+
 func (*gossipMock) IsInMyOrg(member discovery.NetworkMember) bool {
 	panic("implement me")
 }
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Leadership is the state of the leader election of the peer in a channel
+type Leadership struct {
+	Channel string `json:"channel"`
+	// Priority is the leader election priority of the peer
+	Priority uint32 `json:"priority"`
+	// IsLeader is whether the peer is the leader of its organization
+	IsLeader bool `json:"is_leader"`
+	// Relinquished is whether the peer relinquished the leadership and
+	// does not become a leader until it resumes
+	Relinquished bool `json:"relinquished"`
+}
+
+// Leadership returns the state of the leader election of the peer in
+// the channels it participates in leader election for
+func (g *GossipService) Leadership() []Leadership {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	leadership := []Leadership{}
+	for channelID, le := range g.leaderElection {
+		leadership = append(leadership, Leadership{
+			Channel:      channelID,
+			Priority:     g.serviceConfig.ElectionPriority,
+			IsLeader:     le.IsLeader(),
+			Relinquished: le.IsRelinquished(),
+		})
+	}
+	sort.Slice(leadership, func(i, j int) bool {
+		return leadership[i].Channel < leadership[j].Channel
+	})
+	return leadership
+}
+
+// RelinquishLeadership makes the peer relinquish the leadership of its
+// organization in a channel and keeps it from becoming a leader until
+// ResumeLeadership is called, for instance for the peer to be maintained
+func (g *GossipService) RelinquishLeadership(channelID string) error {
+	g.lock.RLock()
+	le, exists := g.leaderElection[channelID]
+	g.lock.RUnlock()
+	if !exists {
+		return errors.Errorf("leader election is not used for channel %s", channelID)
+	}
+
+	logger.Infof("Relinquishing leadership for channel %s", channelID)
+	le.Relinquish()
+	return nil
+}
+
+// ResumeLeadership lets the peer become the leader of its organization
+// in a channel again after it relinquished the leadership
+func (g *GossipService) ResumeLeadership(channelID string) error {
+	g.lock.RLock()
+	le, exists := g.leaderElection[channelID]
+	g.lock.RUnlock()
+	if !exists {
+		return errors.Errorf("leader election is not used for channel %s", channelID)
+	}
+
+	logger.Infof("Resuming leadership for channel %s", channelID)
+	le.Resume()
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// LeadershipManager manages the leader election of the peer
+type LeadershipManager interface {
+	Leadership() []Leadership
+	RelinquishLeadership(channelID string) error
+	ResumeLeadership(channelID string) error
+}
+
+// LeadershipRequest requests the peer to relinquish the leadership
+// of its organization in a channel, or to resume it
+type LeadershipRequest struct {
+	Channel      string `json:"channel"`
+	Relinquished bool   `json:"relinquished"`
+}
+
+// LeadershipHandler serves the state of the leader election of the peer,
+// and lets administrators relinquish and resume the leadership of the peer
+type LeadershipHandler struct {
+	LeadershipManager LeadershipManager
+	Logger            *flogging.FabricLogger
+}
+
+func NewLeadershipHandler(leadershipManager LeadershipManager) *LeadershipHandler {
+	return &LeadershipHandler{
+		LeadershipManager: leadershipManager,
+		Logger:            flogging.MustGetLogger("gossip.service"),
+	}
+}
+
+func (h *LeadershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPut:
+		var leadershipRequest LeadershipRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&leadershipRequest); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: err.Error()})
+			return
+		}
+		req.Body.Close()
+
+		if leadershipRequest.Channel == "" {
+			h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: "channel is required"})
+			return
+		}
+
+		var err error
+		if leadershipRequest.Relinquished {
+			err = h.LeadershipManager.RelinquishLeadership(leadershipRequest.Channel)
+		} else {
+			err = h.LeadershipManager.ResumeLeadership(leadershipRequest.Channel)
+		}
+		if err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: err.Error()})
+			return
+		}
+		resp.WriteHeader(http.StatusNoContent)
+
+	case http.MethodGet:
+		h.sendResponse(resp, http.StatusOK, h.LeadershipManager.Leadership())
+
+	default:
+		h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: fmt.Sprintf("invalid request method: %s", req.Method)})
+	}
+}
+
+func (h *LeadershipHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	encoder.SetIndent("", "  ")
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
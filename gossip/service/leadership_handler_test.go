@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type leadershipManagerMock struct {
+	relinquished map[string]bool
+}
+
+func (l *leadershipManagerMock) Leadership() []Leadership {
+	return []Leadership{
+		{Channel: "mychannel", Priority: 5, IsLeader: !l.relinquished["mychannel"], Relinquished: l.relinquished["mychannel"]},
+	}
+}
+
+func (l *leadershipManagerMock) RelinquishLeadership(channelID string) error {
+	if channelID != "mychannel" {
+		return errors.Errorf("leader election is not used for channel %s", channelID)
+	}
+	l.relinquished[channelID] = true
+	return nil
+}
+
+func (l *leadershipManagerMock) ResumeLeadership(channelID string) error {
+	if channelID != "mychannel" {
+		return errors.Errorf("leader election is not used for channel %s", channelID)
+	}
+	l.relinquished[channelID] = false
+	return nil
+}
+
+func TestLeadershipHandler(t *testing.T) {
+	leadershipManager := &leadershipManagerMock{relinquished: map[string]bool{}}
+	handler := NewLeadershipHandler(leadershipManager)
+
+	get := func() string {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/leadership", nil))
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+		return resp.Body.String()
+	}
+
+	put := func(body string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, "/gossip/leadership", strings.NewReader(body)))
+		return resp
+	}
+
+	require.JSONEq(t, `[{"channel": "mychannel", "priority": 5, "is_leader": true, "relinquished": false}]`, get())
+
+	t.Run("Relinquish", func(t *testing.T) {
+		resp := put(`{"channel": "mychannel", "relinquished": true}`)
+		require.Equal(t, http.StatusNoContent, resp.Code)
+		require.JSONEq(t, `[{"channel": "mychannel", "priority": 5, "is_leader": false, "relinquished": true}]`, get())
+
+		resp = put(`{"channel": "mychannel", "relinquished": false}`)
+		require.Equal(t, http.StatusNoContent, resp.Code)
+		require.JSONEq(t, `[{"channel": "mychannel", "priority": 5, "is_leader": true, "relinquished": false}]`, get())
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		resp := put(`{"channel": "otherchannel", "relinquished": true}`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "leader election is not used for channel otherchannel"}`, resp.Body.String())
+	})
+
+	t.Run("MissingChannel", func(t *testing.T) {
+		resp := put(`{"relinquished": true}`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "channel is required"}`, resp.Body.String())
+	})
+
+	t.Run("MalformedRequest", func(t *testing.T) {
+		resp := put(`goo`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "invalid character 'g' looking for beginning of value"}`, resp.Body.String())
+	})
+
+	t.Run("InvalidMethod", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/leadership", nil))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "invalid request method: POST"}`, resp.Body.String())
+	})
+}
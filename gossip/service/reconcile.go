@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/pkg/errors"
+)
+
+// ReconcilePvtData enqueues the reconciliation of the missing private data of
+// a range of blocks of a channel, and returns the progress of the request
+func (g *GossipService) ReconcilePvtData(channelID string, request gossipprivdata.ReconcileRequest) (*gossipprivdata.ReconcileProgress, error) {
+	handler, err := g.privateHandler(channelID)
+	if err != nil {
+		return nil, err
+	}
+	return handler.reconciler.Reconcile(request)
+}
+
+// PvtDataReconciliation returns the progress of the reconciliation requests of a channel
+func (g *GossipService) PvtDataReconciliation(channelID string) ([]gossipprivdata.ReconcileProgress, error) {
+	handler, err := g.privateHandler(channelID)
+	if err != nil {
+		return nil, err
+	}
+	progress := handler.reconciler.Progress()
+	if progress == nil {
+		progress = []gossipprivdata.ReconcileProgress{}
+	}
+	return progress, nil
+}
+
+// MissingPvtData counts the outstanding missing private data of the eligible collections of a
+// channel by namespace and collection, optionally limited to a namespace or to a collection
+func (g *GossipService) MissingPvtData(channelID, namespace, collection string) ([]gossipprivdata.MissingPvtDataCount, error) {
+	handler, err := g.privateHandler(channelID)
+	if err != nil {
+		return nil, err
+	}
+	missingPvtDataTracker, err := handler.support.Committer.GetMissingPvtDataTracker()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get missing private data tracker")
+	}
+	return gossipprivdata.CountMissingPvtData(missingPvtDataTracker, g.privdataConfig.ReconcileBatchSize, namespace, collection)
+}
+
+func (g *GossipService) privateHandler(channelID string) (privateHandler, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	handler, exists := g.privateHandlers[channelID]
+	if !exists {
+		return privateHandler{}, errors.Errorf("channel %s is not initialized", channelID)
+	}
+	return handler, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+)
+
+// PvtDataReconciliationManager manages the reconciliation of the missing private data of the peer
+type PvtDataReconciliationManager interface {
+	ReconcilePvtData(channelID string, request gossipprivdata.ReconcileRequest) (*gossipprivdata.ReconcileProgress, error)
+	PvtDataReconciliation(channelID string) ([]gossipprivdata.ReconcileProgress, error)
+	MissingPvtData(channelID, namespace, collection string) ([]gossipprivdata.MissingPvtDataCount, error)
+}
+
+// ReconcileRequest requests the reconciliation of the missing private data
+// of a range of blocks of a channel
+type ReconcileRequest struct {
+	Channel string `json:"channel"`
+	gossipprivdata.ReconcileRequest
+}
+
+// ReconcileHandler lets administrators enqueue the reconciliation of the missing
+// private data of a range of blocks of a channel, and serves the progress of the
+// reconciliation requests of a channel
+type ReconcileHandler struct {
+	ReconciliationManager PvtDataReconciliationManager
+	Logger                *flogging.FabricLogger
+}
+
+func NewReconcileHandler(reconciliationManager PvtDataReconciliationManager) *ReconcileHandler {
+	return &ReconcileHandler{
+		ReconciliationManager: reconciliationManager,
+		Logger:                flogging.MustGetLogger("gossip.service"),
+	}
+}
+
+func (h *ReconcileHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		var reconcileRequest ReconcileRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&reconcileRequest); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: err.Error()})
+			return
+		}
+		req.Body.Close()
+
+		if reconcileRequest.Channel == "" {
+			h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: "channel is required"})
+			return
+		}
+
+		progress, err := h.ReconciliationManager.ReconcilePvtData(reconcileRequest.Channel, reconcileRequest.ReconcileRequest)
+		if err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: err.Error()})
+			return
+		}
+		h.sendResponse(resp, http.StatusAccepted, progress)
+
+	case http.MethodGet:
+		channelID := req.URL.Query().Get("channel")
+		if channelID == "" {
+			h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: "channel is required"})
+			return
+		}
+
+		progress, err := h.ReconciliationManager.PvtDataReconciliation(channelID)
+		if err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: err.Error()})
+			return
+		}
+		h.sendResponse(resp, http.StatusOK, progress)
+
+	default:
+		h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: fmt.Sprintf("invalid request method: %s", req.Method)})
+	}
+}
+
+func (h *ReconcileHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	encoder.SetIndent("", "  ")
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+// MissingPvtDataHandler serves the outstanding missing private data of a channel,
+// counted by namespace and collection
+type MissingPvtDataHandler struct {
+	ReconciliationManager PvtDataReconciliationManager
+	Logger                *flogging.FabricLogger
+}
+
+func NewMissingPvtDataHandler(reconciliationManager PvtDataReconciliationManager) *MissingPvtDataHandler {
+	return &MissingPvtDataHandler{
+		ReconciliationManager: reconciliationManager,
+		Logger:                flogging.MustGetLogger("gossip.service"),
+	}
+}
+
+func (h *MissingPvtDataHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: fmt.Sprintf("invalid request method: %s", req.Method)})
+		return
+	}
+
+	query := req.URL.Query()
+	channelID := query.Get("channel")
+	if channelID == "" {
+		h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: "channel is required"})
+		return
+	}
+
+	counts, err := h.ReconciliationManager.MissingPvtData(channelID, query.Get("namespace"), query.Get("collection"))
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: err.Error()})
+		return
+	}
+	h.sendResponse(resp, http.StatusOK, counts)
+}
+
+func (h *MissingPvtDataHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	encoder.SetIndent("", "  ")
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type reconciliationManagerMock struct {
+	requests []gossipprivdata.ReconcileRequest
+}
+
+func (r *reconciliationManagerMock) ReconcilePvtData(channelID string, request gossipprivdata.ReconcileRequest) (*gossipprivdata.ReconcileProgress, error) {
+	if channelID != "mychannel" {
+		return nil, errors.Errorf("channel %s is not initialized", channelID)
+	}
+	if request.StartBlock > request.EndBlock {
+		return nil, errors.Errorf("invalid block range [%d, %d]", request.StartBlock, request.EndBlock)
+	}
+	r.requests = append(r.requests, request)
+	return &gossipprivdata.ReconcileProgress{
+		ID:               len(r.requests),
+		ReconcileRequest: request,
+		State:            gossipprivdata.ReconcileQueued,
+		NextBlock:        request.EndBlock,
+	}, nil
+}
+
+func (r *reconciliationManagerMock) PvtDataReconciliation(channelID string) ([]gossipprivdata.ReconcileProgress, error) {
+	if channelID != "mychannel" {
+		return nil, errors.Errorf("channel %s is not initialized", channelID)
+	}
+	progress := []gossipprivdata.ReconcileProgress{}
+	for i, request := range r.requests {
+		progress = append(progress, gossipprivdata.ReconcileProgress{
+			ID:               i + 1,
+			ReconcileRequest: request,
+			State:            gossipprivdata.ReconcileCompleted,
+			NextBlock:        request.StartBlock,
+			Blocks:           2,
+			Reconciled:       3,
+		})
+	}
+	return progress, nil
+}
+
+func (r *reconciliationManagerMock) MissingPvtData(channelID, namespace, collection string) ([]gossipprivdata.MissingPvtDataCount, error) {
+	if channelID != "mychannel" {
+		return nil, errors.Errorf("channel %s is not initialized", channelID)
+	}
+	counts := []gossipprivdata.MissingPvtDataCount{}
+	for _, count := range []gossipprivdata.MissingPvtDataCount{
+		{Namespace: "ns-1", Collection: "coll-1", Blocks: 2, Transactions: 3, LowestBlock: 4, HighestBlock: 7},
+		{Namespace: "ns-2", Collection: "coll-2", Blocks: 1, Transactions: 1, LowestBlock: 5, HighestBlock: 5},
+	} {
+		if (namespace == "" || namespace == count.Namespace) && (collection == "" || collection == count.Collection) {
+			counts = append(counts, count)
+		}
+	}
+	return counts, nil
+}
+
+func TestReconcileHandler(t *testing.T) {
+	reconciliationManager := &reconciliationManagerMock{}
+	handler := NewReconcileHandler(reconciliationManager)
+
+	get := func(channelID string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/reconcile?channel="+channelID, nil))
+		return resp
+	}
+
+	post := func(body string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/reconcile", strings.NewReader(body)))
+		return resp
+	}
+
+	resp := get("mychannel")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	require.JSONEq(t, `[]`, resp.Body.String())
+
+	t.Run("Reconcile", func(t *testing.T) {
+		resp := post(`{"channel": "mychannel", "start_block": 5, "end_block": 10, "namespace": "ns-1", "collection": "coll-1"}`)
+		require.Equal(t, http.StatusAccepted, resp.Code)
+		require.JSONEq(t, `{
+			"id": 1, "start_block": 5, "end_block": 10, "namespace": "ns-1", "collection": "coll-1",
+			"state": "queued", "next_block": 10, "blocks": 0, "reconciled": 0, "unreconciled": 0
+		}`, resp.Body.String())
+		require.Equal(t, []gossipprivdata.ReconcileRequest{
+			{StartBlock: 5, EndBlock: 10, Namespace: "ns-1", Collection: "coll-1"},
+		}, reconciliationManager.requests)
+
+		resp = get("mychannel")
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.JSONEq(t, `[{
+			"id": 1, "start_block": 5, "end_block": 10, "namespace": "ns-1", "collection": "coll-1",
+			"state": "completed", "next_block": 5, "blocks": 2, "reconciled": 3, "unreconciled": 0
+		}]`, resp.Body.String())
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		resp := post(`{"channel": "mychannel", "start_block": 10, "end_block": 5}`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "invalid block range [10, 5]"}`, resp.Body.String())
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		resp := post(`{"channel": "otherchannel", "start_block": 5, "end_block": 10}`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "channel otherchannel is not initialized"}`, resp.Body.String())
+
+		resp = get("otherchannel")
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "channel otherchannel is not initialized"}`, resp.Body.String())
+	})
+
+	t.Run("MissingChannel", func(t *testing.T) {
+		resp := post(`{"start_block": 5, "end_block": 10}`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "channel is required"}`, resp.Body.String())
+
+		resp = get("")
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "channel is required"}`, resp.Body.String())
+	})
+
+	t.Run("MalformedRequest", func(t *testing.T) {
+		resp := post(`goo`)
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "invalid character 'g' looking for beginning of value"}`, resp.Body.String())
+	})
+
+	t.Run("InvalidMethod", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodDelete, "/gossip/reconcile", nil))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "invalid request method: DELETE"}`, resp.Body.String())
+	})
+}
+
+func TestMissingPvtDataHandler(t *testing.T) {
+	handler := NewMissingPvtDataHandler(&reconciliationManagerMock{})
+
+	get := func(query string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/missingpvtdata?"+query, nil))
+		return resp
+	}
+
+	resp := get("channel=mychannel")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	require.JSONEq(t, `[
+		{"namespace": "ns-1", "collection": "coll-1", "blocks": 2, "transactions": 3, "lowest_block": 4, "highest_block": 7},
+		{"namespace": "ns-2", "collection": "coll-2", "blocks": 1, "transactions": 1, "lowest_block": 5, "highest_block": 5}
+	]`, resp.Body.String())
+
+	resp = get("channel=mychannel&namespace=ns-2")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.JSONEq(t, `[
+		{"namespace": "ns-2", "collection": "coll-2", "blocks": 1, "transactions": 1, "lowest_block": 5, "highest_block": 5}
+	]`, resp.Body.String())
+
+	resp = get("channel=mychannel&namespace=ns-1&collection=coll-2")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.JSONEq(t, `[]`, resp.Body.String())
+
+	resp = get("channel=otherchannel")
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	require.JSONEq(t, `{"error": "channel otherchannel is not initialized"}`, resp.Body.String())
+
+	resp = get("")
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	require.JSONEq(t, `{"error": "channel is required"}`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/missingpvtdata", nil))
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	require.JSONEq(t, `{"error": "invalid request method: POST"}`, resp.Body.String())
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/gossip/privdata/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPvtDataReconciliation(t *testing.T) {
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+	missingInfo := ledger.MissingPvtDataInfo{}
+	missingInfo.Add(5, 1, "ns-1", "coll-1")
+	missingInfo.Add(5, 2, "ns-1", "coll-1")
+	missingInfo.Add(4, 1, "ns-2", "coll-2")
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(0), uint64(math.MaxUint64), 10).Return(missingInfo, nil)
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForBlockRange", uint64(0), uint64(3), 10).Return(ledger.MissingPvtDataInfo{}, nil)
+
+	committer := &mocks.Committer{}
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil).Once()
+	committer.On("GetMissingPvtDataTracker").Return(nil, errors.New("no tracker"))
+
+	g := &GossipService{
+		privdataConfig: &gossipprivdata.PrivdataConfig{ReconcileBatchSize: 10},
+		privateHandlers: map[string]privateHandler{
+			"mychannel": {
+				support:    Support{Committer: committer},
+				reconciler: &gossipprivdata.NoOpReconciler{},
+			},
+		},
+	}
+
+	_, err := g.ReconcilePvtData("mychannel", gossipprivdata.ReconcileRequest{StartBlock: 1, EndBlock: 5})
+	require.EqualError(t, err, "private data reconciliation is disabled")
+	_, err = g.ReconcilePvtData("otherchannel", gossipprivdata.ReconcileRequest{StartBlock: 1, EndBlock: 5})
+	require.EqualError(t, err, "channel otherchannel is not initialized")
+
+	progress, err := g.PvtDataReconciliation("mychannel")
+	require.NoError(t, err)
+	require.Empty(t, progress)
+	require.NotNil(t, progress)
+	_, err = g.PvtDataReconciliation("otherchannel")
+	require.EqualError(t, err, "channel otherchannel is not initialized")
+
+	counts, err := g.MissingPvtData("mychannel", "ns-1", "")
+	require.NoError(t, err)
+	require.Equal(t, []gossipprivdata.MissingPvtDataCount{
+		{Namespace: "ns-1", Collection: "coll-1", Blocks: 1, Transactions: 2, LowestBlock: 5, HighestBlock: 5},
+	}, counts)
+
+	_, err = g.MissingPvtData("mychannel", "", "")
+	require.EqualError(t, err, "failed to get missing private data tracker: no tracker")
+	_, err = g.MissingPvtData("otherchannel", "", "")
+	require.EqualError(t, err, "channel otherchannel is not initialized")
+}
+
+type progressReconciler struct {
+	gossipprivdata.NoOpReconciler
+	progress []gossipprivdata.ReconcileProgress
+}
+
+func (r *progressReconciler) Progress() []gossipprivdata.ReconcileProgress {
+	return r.progress
+}
+
+type pendingPulls int
+
+func (p pendingPulls) PendingPulls() int {
+	return int(p)
+}
+
+func TestPendingPvtData(t *testing.T) {
+	handler := privateHandler{
+		reconciler: &progressReconciler{
+			progress: []gossipprivdata.ReconcileProgress{
+				{ID: 1, State: gossipprivdata.ReconcileCompleted},
+				{ID: 2, State: gossipprivdata.ReconcileFailed},
+				{ID: 3, State: gossipprivdata.ReconcileRunning},
+				{ID: 4, State: gossipprivdata.ReconcileQueued},
+				{ID: 5, State: gossipprivdata.ReconcileQueued},
+			},
+		},
+		puller: pendingPulls(7),
+	}
+	pulls, reconciliations := handler.pendingPvtData()
+	require.Equal(t, 7, pulls)
+	require.Equal(t, 3, reconciliations)
+
+	handler = privateHandler{reconciler: &gossipprivdata.NoOpReconciler{}}
+	pulls, reconciliations = handler.pendingPvtData()
+	require.Zero(t, pulls)
+	require.Zero(t, reconciliations)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+)
+
+// Status is the state of the gossip layer of the peer
+type Status struct {
+	Self     Member          `json:"self"`
+	Alive    []Member        `json:"alive_members"`
+	Dead     []Member        `json:"dead_members"`
+	Channels []ChannelStatus `json:"channels"`
+}
+
+// Member is a member of the gossip network
+type Member struct {
+	PKIID            string `json:"pki_id"`
+	MSPID            string `json:"mspid,omitempty"`
+	Endpoint         string `json:"endpoint"`
+	InternalEndpoint string `json:"internal_endpoint,omitempty"`
+}
+
+// ChannelMember is a member of a channel along with the properties
+// published in its StateInfo message
+type ChannelMember struct {
+	Member
+	LedgerHeight uint64      `json:"ledger_height"`
+	LeftChannel  bool        `json:"left_channel"`
+	Chaincodes   []Chaincode `json:"chaincodes,omitempty"`
+}
+
+// Chaincode is a chaincode installed on a member of a channel
+type Chaincode struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ChannelStatus is the state of a channel as known by the gossip layer
+// of the peer
+type ChannelStatus struct {
+	Channel string         `json:"channel"`
+	Self    *ChannelMember `json:"self,omitempty"`
+	// Members are the other members of the channel, including
+	// members which left the channel
+	Members []ChannelMember `json:"members"`
+	// IsLeader is whether the peer pulls blocks from the ordering
+	// service for its organization
+	IsLeader bool `json:"is_leader"`
+	// OrgLeader is the peer the peer considers to be the leader of
+	// its organization, if any
+	OrgLeader *Member `json:"org_leader,omitempty"`
+	// BufferedBlocks is the number of blocks waiting to be committed
+	BufferedBlocks int `json:"buffered_blocks"`
+	// PullStoreBlocks is the number of blocks held for dissemination
+	// to other peers by pull
+	PullStoreBlocks int `json:"pull_store_blocks"`
+	// PendingPvtDataPulls is the number of private data elements
+	// being pulled from other peers
+	PendingPvtDataPulls int `json:"pending_pvtdata_pulls"`
+	// PendingReconciliations is the number of reconciliation requests
+	// of missing private data which are queued or running
+	PendingReconciliations int `json:"pending_reconciliations"`
+}
+
+// Status returns the state of the gossip layer of the peer
+func (g *GossipService) Status() *Status {
+	identities := g.gossipSvc.IdentityInfo().ByID()
+	self := g.gossipSvc.SelfMembershipInfo()
+	selfOrg := g.secAdv.OrgByPeerIdentity(g.peerIdentity)
+
+	toMember := func(member discovery.NetworkMember) Member {
+		m := Member{
+			PKIID:            member.PKIid.String(),
+			Endpoint:         member.Endpoint,
+			InternalEndpoint: member.InternalEndpoint,
+		}
+		if bytes.Equal(member.PKIid, self.PKIid) {
+			m.MSPID = string(selfOrg)
+		} else if identity, exists := identities[string(member.PKIid)]; exists {
+			m.MSPID = string(identity.Organization)
+		}
+		return m
+	}
+
+	alive := g.gossipSvc.Peers()
+	status := &Status{
+		Self:     toMember(self),
+		Alive:    membersOf(alive, toMember),
+		Dead:     membersOf(g.gossipSvc.DeadPeers(), toMember),
+		Channels: []ChannelStatus{},
+	}
+
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	var channelIDs []string
+	for channelID := range g.chains {
+		channelIDs = append(channelIDs, channelID)
+	}
+	sort.Strings(channelIDs)
+
+	for _, channelID := range channelIDs {
+		channelStatus := ChannelStatus{
+			Channel:        channelID,
+			Members:        []ChannelMember{},
+			BufferedBlocks: g.chains[channelID].BufferedPayloads(),
+		}
+
+		if stateInfo := g.gossipSvc.SelfChannelInfo(common.ChannelID(channelID)); stateInfo != nil {
+			selfState := self
+			selfState.Properties = stateInfo.GetStateInfo().Properties
+			selfMember := toChannelMember(selfState, toMember)
+			channelStatus.Self = &selfMember
+		}
+
+		if gossipStatus := g.gossipSvc.ChannelStatus(common.ChannelID(channelID)); gossipStatus != nil {
+			for _, member := range gossipStatus.Members {
+				channelStatus.Members = append(channelStatus.Members, toChannelMember(member, toMember))
+			}
+			sort.Slice(channelStatus.Members, func(i, j int) bool {
+				return channelStatus.Members[i].Endpoint < channelStatus.Members[j].Endpoint
+			})
+			channelStatus.PullStoreBlocks = gossipStatus.BlocksInStore
+		}
+
+		if handler, exists := g.privateHandlers[channelID]; exists {
+			channelStatus.PendingPvtDataPulls, channelStatus.PendingReconciliations = handler.pendingPvtData()
+		}
+
+		var leader common.PKIidType
+		if le, exists := g.leaderElection[channelID]; exists {
+			channelStatus.IsLeader = le.IsLeader()
+			leader = le.Leader()
+		} else if g.serviceConfig.OrgLeader {
+			channelStatus.IsLeader = true
+			leader = self.PKIid
+		}
+		if leader != nil {
+			channelStatus.OrgLeader = orgLeader(leader, self, alive, toMember)
+		}
+
+		status.Channels = append(status.Channels, channelStatus)
+	}
+
+	return status
+}
+
+// pendingPvtData returns the number of private data elements being pulled
+// and the number of pending reconciliation requests of the channel
+func (p privateHandler) pendingPvtData() (pulls int, reconciliations int) {
+	if p.puller != nil {
+		pulls = p.puller.PendingPulls()
+	}
+	for _, progress := range p.reconciler.Progress() {
+		if progress.State == gossipprivdata.ReconcileQueued || progress.State == gossipprivdata.ReconcileRunning {
+			reconciliations++
+		}
+	}
+	return pulls, reconciliations
+}
+
+func membersOf(networkMembers []discovery.NetworkMember, toMember func(discovery.NetworkMember) Member) []Member {
+	members := []Member{}
+	for _, member := range networkMembers {
+		members = append(members, toMember(member))
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Endpoint < members[j].Endpoint
+	})
+	return members
+}
+
+func toChannelMember(member discovery.NetworkMember, toMember func(discovery.NetworkMember) Member) ChannelMember {
+	channelMember := ChannelMember{
+		Member: toMember(member),
+	}
+	if member.Properties == nil {
+		return channelMember
+	}
+	channelMember.LedgerHeight = member.Properties.LedgerHeight
+	channelMember.LeftChannel = member.Properties.LeftChannel
+	for _, cc := range member.Properties.Chaincodes {
+		channelMember.Chaincodes = append(channelMember.Chaincodes, Chaincode{
+			Name:    cc.Name,
+			Version: cc.Version,
+		})
+	}
+	return channelMember
+}
+
+func orgLeader(leader common.PKIidType, self discovery.NetworkMember, alive []discovery.NetworkMember, toMember func(discovery.NetworkMember) Member) *Member {
+	for _, member := range append([]discovery.NetworkMember{self}, alive...) {
+		if bytes.Equal(member.PKIid, leader) {
+			m := toMember(member)
+			return &m
+		}
+	}
+	return &Member{PKIID: leader.String()}
+}
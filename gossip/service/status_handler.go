@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// StatusProvider provides the state of the gossip layer of the peer
+type StatusProvider interface {
+	Status() *Status
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// StatusHandler serves the state of the gossip layer of the peer
+// as a JSON document
+type StatusHandler struct {
+	StatusProvider StatusProvider
+	Logger         *flogging.FabricLogger
+}
+
+func NewStatusHandler(statusProvider StatusProvider) *StatusHandler {
+	return &StatusHandler{
+		StatusProvider: statusProvider,
+		Logger:         flogging.MustGetLogger("gossip.service"),
+	}
+}
+
+func (h *StatusHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendResponse(resp, http.StatusBadRequest, &ErrorResponse{Error: fmt.Sprintf("invalid request method: %s", req.Method)})
+		return
+	}
+
+	h.sendResponse(resp, http.StatusOK, h.StatusProvider.Status())
+}
+
+func (h *StatusHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	encoder.SetIndent("", "  ")
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type statusProviderMock struct {
+	status *Status
+}
+
+func (s *statusProviderMock) Status() *Status {
+	return s.status
+}
+
+func TestStatusHandler(t *testing.T) {
+	handler := NewStatusHandler(&statusProviderMock{
+		status: &Status{
+			Self:  Member{PKIID: "01", MSPID: "Org1MSP", Endpoint: "peer0:7051"},
+			Alive: []Member{{PKIID: "02", MSPID: "Org1MSP", Endpoint: "peer1:7051"}},
+			Dead:  []Member{},
+			Channels: []ChannelStatus{
+				{
+					Channel: "mychannel",
+					Self: &ChannelMember{
+						Member:       Member{PKIID: "01", MSPID: "Org1MSP", Endpoint: "peer0:7051"},
+						LedgerHeight: 5,
+						Chaincodes:   []Chaincode{{Name: "mycc", Version: "1.0"}},
+					},
+					Members: []ChannelMember{
+						{
+							Member:       Member{PKIID: "02", MSPID: "Org1MSP", Endpoint: "peer1:7051"},
+							LedgerHeight: 4,
+							LeftChannel:  true,
+						},
+					},
+					IsLeader:               true,
+					OrgLeader:              &Member{PKIID: "01", MSPID: "Org1MSP", Endpoint: "peer0:7051"},
+					BufferedBlocks:         2,
+					PullStoreBlocks:        3,
+					PendingPvtDataPulls:    4,
+					PendingReconciliations: 1,
+				},
+			},
+		},
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/status", nil))
+
+		require.Equal(t, http.StatusOK, resp.Code)
+		require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+		require.JSONEq(t, `{
+			"self": {"pki_id": "01", "mspid": "Org1MSP", "endpoint": "peer0:7051"},
+			"alive_members": [{"pki_id": "02", "mspid": "Org1MSP", "endpoint": "peer1:7051"}],
+			"dead_members": [],
+			"channels": [{
+				"channel": "mychannel",
+				"self": {"pki_id": "01", "mspid": "Org1MSP", "endpoint": "peer0:7051", "ledger_height": 5, "left_channel": false, "chaincodes": [{"name": "mycc", "version": "1.0"}]},
+				"members": [{"pki_id": "02", "mspid": "Org1MSP", "endpoint": "peer1:7051", "ledger_height": 4, "left_channel": true}],
+				"is_leader": true,
+				"org_leader": {"pki_id": "01", "mspid": "Org1MSP", "endpoint": "peer0:7051"},
+				"buffered_blocks": 2,
+				"pull_store_blocks": 3,
+				"pending_pvtdata_pulls": 4,
+				"pending_reconciliations": 1
+			}]
+		}`, resp.Body.String())
+	})
+
+	t.Run("InvalidMethod", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/status", nil))
+
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "invalid request method: POST"}`, resp.Body.String())
+	})
+}
@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// bulkSyncRequest is a request for the blocks in the range [start...end]
+// sent during a bulk transfer
+type bulkSyncRequest struct {
+	start, end uint64
+	nonce      uint64
+	sentAt     time.Time
+	tries      int
+	// payloads are the verified blocks of the request, ordered by sequence number
+	payloads []*proto.Payload
+}
+
+// verifiedResponse is the outcome of the verification of a response to a bulkSyncRequest
+type verifiedResponse struct {
+	req      *bulkSyncRequest
+	payloads []*proto.Payload
+	err      error
+}
+
+// shouldBulkSync returns whether the peer is far enough behind the other peers
+// to transfer the missing blocks in bulk
+func (s *GossipStateProviderImpl) shouldBulkSync(ourHeight, maxHeight uint64) bool {
+	if s.config.StateBulkSyncThreshold == 0 || s.config.StateBulkSyncBatchSize == 0 {
+		return false
+	}
+	return maxHeight-ourHeight > s.config.StateBulkSyncThreshold
+}
+
+// bulkSync transfers the blocks in the range [start...end] from a single peer
+// which has all of them. Requests for consecutive ranges of blocks are kept in
+// flight, and the blocks of each response are verified in parallel while the
+// blocks of former responses are committed. Returns the number of blocks
+// handed over to be committed, and an error if not all of them could be.
+func (s *GossipStateProviderImpl) bulkSync(start, end uint64) (uint64, error) {
+	atomic.StoreInt32(&s.stateTransferActive, 1)
+	defer atomic.StoreInt32(&s.stateTransferActive, 0)
+
+	peer, err := s.selectPeerToRequestFrom(end + 1)
+	if err != nil {
+		return 0, err
+	}
+	s.logger.Infof("[%s] Transferring blocks [%d...%d] in bulk from peer %s", s.chainID, start, end, peer.Endpoint)
+
+	maxInFlight := s.config.StateBulkSyncMaxInFlight
+	if maxInFlight < 1 {
+		maxInFlight = 1
+	}
+
+	var (
+		transferred uint64
+		next        = start
+		// inFlight holds the requests not yet committed, ordered by range
+		inFlight []*bulkSyncRequest
+		// awaiting holds the requests awaiting a response, by nonce
+		awaiting = make(map[uint64]*bulkSyncRequest)
+		verified = make(chan verifiedResponse, maxInFlight)
+		// verificationSlots bounds the number of blocks verified at the same time
+		verificationSlots = make(chan struct{}, runtime.NumCPU())
+		// done releases the verifications still running when the transfer ends
+		done = make(chan struct{})
+	)
+	defer close(done)
+
+	send := func(req *bulkSyncRequest) {
+		msg := s.stateRequestMessage(req.start, req.end)
+		req.nonce = msg.Nonce
+		req.sentAt = time.Now()
+		req.tries++
+		awaiting[req.nonce] = req
+		s.logger.Debugf("[%s] Requesting blocks [%d...%d] from peer %s", s.chainID, req.start, req.end, peer.Endpoint)
+		s.mediator.Send(msg, peer)
+	}
+
+	retry := func(req *bulkSyncRequest, cause error) error {
+		if req.tries > s.config.StateMaxRetries {
+			return errors.WithMessagef(cause, "failed transferring blocks [%d...%d] from peer %s after %d tries",
+				req.start, req.end, peer.Endpoint, req.tries)
+		}
+		s.logger.Debugf("[%s] Requesting blocks [%d...%d] again: %s", s.chainID, req.start, req.end, cause)
+		send(req)
+		return nil
+	}
+
+	for {
+		// Hand over the verified blocks in order. Adding them blocks while the
+		// payloads buffer is full, so no further blocks are requested until the
+		// committer catches up.
+		for len(inFlight) > 0 && inFlight[0].payloads != nil {
+			for _, payload := range inFlight[0].payloads {
+				if err := s.addPayload(payload, blocking); err != nil {
+					s.logger.Warningf("Block [%d] received from bulk transfer wasn't added to payload buffer: %v", payload.SeqNum, err)
+				}
+			}
+			transferred += uint64(len(inFlight[0].payloads))
+			inFlight = inFlight[1:]
+		}
+
+		for next <= end && len(inFlight) < maxInFlight {
+			req := &bulkSyncRequest{start: next, end: min(end, next+s.config.StateBulkSyncBatchSize-1)}
+			inFlight = append(inFlight, req)
+			send(req)
+			next = req.end + 1
+		}
+
+		if len(inFlight) == 0 {
+			s.logger.Infof("[%s] Transferred blocks [%d...%d] in bulk from peer %s", s.chainID, start, end, peer.Endpoint)
+			return transferred, nil
+		}
+
+		select {
+		case <-s.stopCh:
+			return transferred, errors.New("state provider has been stopped")
+		case msg, stillOpen := <-s.stateResponseCh:
+			if !stillOpen {
+				return transferred, errors.New("state provider has been stopped")
+			}
+			req, exists := awaiting[msg.GetGossipMessage().Nonce]
+			if !exists {
+				continue
+			}
+			delete(awaiting, req.nonce)
+			go func() {
+				payloads, err := s.verifyBulkResponse(req, msg, verificationSlots)
+				select {
+				case verified <- verifiedResponse{req: req, payloads: payloads, err: err}:
+				case <-done:
+				}
+			}()
+		case res := <-verified:
+			if res.err != nil {
+				s.logger.Warningf("Failed verifying blocks [%d...%d] received from peer %s: %s", res.req.start, res.req.end, peer.Endpoint, res.err)
+				if err := retry(res.req, res.err); err != nil {
+					return transferred, err
+				}
+				continue
+			}
+			if received := uint64(len(res.payloads)); received < res.req.end-res.req.start+1 {
+				// The remaining blocks of the request are requested again
+				rest := &bulkSyncRequest{start: res.req.start + received, end: res.req.end}
+				res.req.end = rest.start - 1
+				for i, req := range inFlight {
+					if req == res.req {
+						inFlight = append(inFlight[:i+1], append([]*bulkSyncRequest{rest}, inFlight[i+1:]...)...)
+						break
+					}
+				}
+				send(rest)
+			}
+			res.req.payloads = res.payloads
+		case <-time.After(s.config.StateResponseTimeout):
+			for _, req := range awaiting {
+				if time.Since(req.sentAt) < s.config.StateResponseTimeout {
+					continue
+				}
+				delete(awaiting, req.nonce)
+				if err := retry(req, errors.New("timed out waiting for a response")); err != nil {
+					return transferred, err
+				}
+			}
+		}
+	}
+}
+
+// verifyBulkResponse verifies in parallel the blocks in a response to a bulk
+// request, and returns them ordered by sequence number
+func (s *GossipStateProviderImpl) verifyBulkResponse(req *bulkSyncRequest, msg protoext.ReceivedMessage, slots chan struct{}) ([]*proto.Payload, error) {
+	payloads := msg.GetGossipMessage().GetStateResponse().GetPayloads()
+	sort.Slice(payloads, func(i, j int) bool {
+		return payloads[i].SeqNum < payloads[j].SeqNum
+	})
+	// The peer was selected because it has all the requested blocks, but it may
+	// respond with only the first ones to keep the response within size limits
+	if len(payloads) == 0 || uint64(len(payloads)) > req.end-req.start+1 {
+		return nil, errors.Errorf("expected up to %d blocks but got %d", req.end-req.start+1, len(payloads))
+	}
+	for i, payload := range payloads {
+		if payload.SeqNum != req.start+uint64(i) {
+			return nil, errors.Errorf("expected block [%d] but got block [%d]", req.start+uint64(i), payload.SeqNum)
+		}
+	}
+
+	errs := make([]error, len(payloads))
+	var wg sync.WaitGroup
+	wg.Add(len(payloads))
+	for i, payload := range payloads {
+		slots <- struct{}{}
+		go func(i int, payload *proto.Payload) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			block, err := protoutil.UnmarshalBlock(payload.Data)
+			if err != nil {
+				errs[i] = errors.WithMessagef(err, "failed unmarshalling block [%d]", payload.SeqNum)
+				return
+			}
+			if err := s.mediator.VerifyBlock(common2.ChannelID(s.chainID), payload.SeqNum, block); err != nil {
+				errs[i] = errors.WithMessagef(err, "failed verifying block [%d]", payload.SeqNum)
+			}
+		}(i, payload)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return payloads, nil
+}
+
+// maxResponseBatchSize returns the largest number of blocks served
+// in a single state transfer response
+func (s *GossipStateProviderImpl) maxResponseBatchSize() uint64 {
+	if s.config.StateBulkSyncBatchSize > s.config.StateBatchSize {
+		return s.config.StateBulkSyncBatchSize
+	}
+	return s.config.StateBatchSize
+}
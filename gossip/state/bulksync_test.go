@@ -0,0 +1,347 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	pcomm "github.com/hyperledger/fabric-protos-go/common"
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	tspb "github.com/hyperledger/fabric-protos-go/transientstore"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/state/mocks"
+	gossiputil "github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// inMemoryLedger commits blocks by keeping track of its height
+type inMemoryLedger struct {
+	lock   sync.Mutex
+	height uint64
+}
+
+func (l *inMemoryLedger) StoreBlock(block *pcomm.Block, _ gossiputil.PvtDataCollections) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if block.Header.Number != l.height {
+		return errors.Errorf("expected block [%d] but got block [%d]", l.height, block.Header.Number)
+	}
+	l.height++
+	return nil
+}
+
+func (l *inMemoryLedger) StorePvtData(string, *tspb.TxPvtReadWriteSetWithConfigInfo, uint64) error {
+	return nil
+}
+
+func (l *inMemoryLedger) GetPvtDataAndBlockByNum(seqNum uint64, _ protoutil.SignedData) (*pcomm.Block, gossiputil.PvtDataCollections, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if seqNum >= l.height {
+		return nil, nil, errors.Errorf("block [%d] not found", seqNum)
+	}
+	block := protoutil.NewBlock(seqNum, []byte{})
+	block.Data.Data = [][]byte{make([]byte, 1024)}
+	return block, nil, nil
+}
+
+func (l *inMemoryLedger) LedgerHeight() (uint64, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.height, nil
+}
+
+func (l *inMemoryLedger) Close() {}
+
+// bulkSyncSetup is a state provider whose requests for blocks are
+// sent to the requests channel, to be answered by the test
+type bulkSyncSetup struct {
+	ledger       *inMemoryLedger
+	requests     chan *proto.GossipMessage
+	msgsFromPeer chan protoext.ReceivedMessage
+	provider     GossipStateProvider
+
+	lock         sync.Mutex
+	bulkRequests []*proto.RemoteStateRequest
+	bulkPeers    []string
+}
+
+func newBulkSyncSetup(config *StateConfig, membership []discovery.NetworkMember) *bulkSyncSetup {
+	setup := &bulkSyncSetup{
+		ledger:       &inMemoryLedger{height: 1},
+		requests:     make(chan *proto.GossipMessage, 100),
+		msgsFromPeer: make(chan protoext.ReceivedMessage),
+	}
+
+	g := &mocks.GossipMock{}
+	g.On("PeersOfChannel", mock.Anything).Return(membership)
+	g.On("Accept", mock.Anything, false).Return(make(<-chan *proto.GossipMessage), nil)
+	g.On("Accept", mock.Anything, true).Return(nil, setup.msgsFromPeer)
+	g.On("Send", mock.Anything, mock.Anything).Run(func(arguments mock.Arguments) {
+		msg := arguments.Get(0).(*proto.GossipMessage)
+		req := msg.GetStateRequest()
+		if req.EndSeqNum-req.StartSeqNum+1 == config.StateBulkSyncBatchSize {
+			setup.lock.Lock()
+			setup.bulkRequests = append(setup.bulkRequests, req)
+			setup.bulkPeers = append(setup.bulkPeers, arguments.Get(1).([]*comm.RemotePeer)[0].Endpoint)
+			setup.lock.Unlock()
+		}
+		setup.requests <- msg
+	})
+
+	servicesAdapater := &ServicesMediator{GossipAdapter: g, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
+	stateMetrics := metrics.NewGossipMetrics(&disabled.Provider{}).StateMetrics
+	logger := flogging.MustGetLogger(gossiputil.StateLogger)
+	setup.provider = NewGossipStateProvider(logger, "testchannelid", servicesAdapater, setup.ledger, stateMetrics, blocking, config)
+	return setup
+}
+
+// respond responds to the given state request with the blocks
+// in the range [start...end] of the request, besides skipped blocks
+func (setup *bulkSyncSetup) respond(msg *proto.GossipMessage, skip map[uint64]bool) {
+	setup.respondWithUpTo(msg, skip, 0)
+}
+
+// respondWithUpTo responds like respond, but with at most limit blocks, if not 0
+func (setup *bulkSyncSetup) respondWithUpTo(msg *proto.GossipMessage, skip map[uint64]bool, limit int) {
+	res := &proto.GossipMessage{
+		Nonce:   msg.Nonce,
+		Channel: []byte("testchannelid"),
+		Content: &proto.GossipMessage_StateResponse{
+			StateResponse: &proto.RemoteStateResponse{},
+		},
+	}
+	req := msg.GetStateRequest()
+	for seq := req.StartSeqNum; seq <= req.EndSeqNum; seq++ {
+		if skip[seq] {
+			continue
+		}
+		if limit > 0 && len(res.GetStateResponse().Payloads) == limit {
+			break
+		}
+		b, _ := pb.Marshal(protoutil.NewBlock(seq, []byte{}))
+		res.GetStateResponse().Payloads = append(res.GetStateResponse().Payloads, &proto.Payload{
+			SeqNum: seq,
+			Data:   b,
+		})
+	}
+	sMsg, _ := protoext.NoopSign(res)
+	setup.msgsFromPeer <- &comm.ReceivedMessageImpl{
+		SignedGossipMessage: sMsg,
+	}
+}
+
+func bulkSyncConfig() *StateConfig {
+	return &StateConfig{
+		StateCheckInterval:       100 * time.Millisecond,
+		StateResponseTimeout:     time.Second,
+		StateBatchSize:           DefStateBatchSize,
+		StateMaxRetries:          DefStateMaxRetries,
+		StateBlockBufferSize:     DefStateBlockBufferSize,
+		StateChannelSize:         DefStateChannelSize,
+		StateEnabled:             true,
+		StateBulkSyncThreshold:   50,
+		StateBulkSyncBatchSize:   20,
+		StateBulkSyncMaxInFlight: 3,
+	}
+}
+
+func TestBulkSync(t *testing.T) {
+	// Scenario: the peer is 300 blocks behind peer a, which is above the
+	// threshold, so it requests them in bulk from peer a only, and not from
+	// peer b which doesn't have all of them. The first response lacks a block
+	// and is requested again.
+	membership := []discovery.NetworkMember{
+		{PKIid: common.PKIidType("a"), Endpoint: "a", Properties: &proto.Properties{LedgerHeight: 301}},
+		{PKIid: common.PKIidType("b"), Endpoint: "b", Properties: &proto.Properties{LedgerHeight: 150}},
+	}
+	setup := newBulkSyncSetup(bulkSyncConfig(), membership)
+	defer setup.provider.Stop()
+
+	var outstanding, maxOutstanding int32
+	go func() {
+		incomplete := true
+		for msg := range setup.requests {
+			current := atomic.AddInt32(&outstanding, 1)
+			if current > atomic.LoadInt32(&maxOutstanding) {
+				atomic.StoreInt32(&maxOutstanding, current)
+			}
+			msg := msg
+			skip := map[uint64]bool{}
+			if incomplete && msg.GetStateRequest().StartSeqNum == 1 {
+				skip[10] = true
+				incomplete = false
+			}
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&outstanding, -1)
+				setup.respond(msg, skip)
+			}()
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		height, _ := setup.ledger.LedgerHeight()
+		return height == 301
+	}, 30*time.Second, 100*time.Millisecond)
+
+	setup.lock.Lock()
+	defer setup.lock.Unlock()
+	// 15 requests of 20 blocks, and the one requested again
+	require.Len(t, setup.bulkRequests, 16)
+	requestsPerStart := map[uint64]int{}
+	for i, req := range setup.bulkRequests {
+		require.Equal(t, "a", setup.bulkPeers[i])
+		requestsPerStart[req.StartSeqNum]++
+	}
+	for start := uint64(1); start <= 300; start += 20 {
+		require.Contains(t, requestsPerStart, start)
+	}
+	require.Equal(t, 2, requestsPerStart[1])
+	require.LessOrEqual(t, atomic.LoadInt32(&maxOutstanding), int32(3))
+}
+
+func TestBulkSyncFallback(t *testing.T) {
+	// Scenario: the peer is 100 blocks behind peer a, which is above the
+	// threshold, but peer a doesn't serve requests for that many blocks.
+	// The peer falls back to requesting the blocks in small batches.
+	membership := []discovery.NetworkMember{
+		{PKIid: common.PKIidType("a"), Endpoint: "a", Properties: &proto.Properties{LedgerHeight: 101}},
+	}
+	config := bulkSyncConfig()
+	config.StateResponseTimeout = 200 * time.Millisecond
+	config.StateMaxRetries = 1
+	setup := newBulkSyncSetup(config, membership)
+	defer setup.provider.Stop()
+
+	go func() {
+		for msg := range setup.requests {
+			req := msg.GetStateRequest()
+			if err := (&stateRequestValidator{}).validate(req, DefStateBatchSize); err != nil {
+				continue
+			}
+			go setup.respond(msg, nil)
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		height, _ := setup.ledger.LedgerHeight()
+		return height == 101
+	}, 30*time.Second, 100*time.Millisecond)
+
+	setup.lock.Lock()
+	defer setup.lock.Unlock()
+	require.NotEmpty(t, setup.bulkRequests)
+}
+
+func TestBulkSyncPartialResponses(t *testing.T) {
+	// Scenario: peer a responds to the bulk requests with only the first 7
+	// blocks of each, as if the rest didn't fit in the response. The remaining
+	// blocks of each request are requested again.
+	membership := []discovery.NetworkMember{
+		{PKIid: common.PKIidType("a"), Endpoint: "a", Properties: &proto.Properties{LedgerHeight: 101}},
+	}
+	setup := newBulkSyncSetup(bulkSyncConfig(), membership)
+	defer setup.provider.Stop()
+
+	var requests int32
+	go func() {
+		for msg := range setup.requests {
+			atomic.AddInt32(&requests, 1)
+			go setup.respondWithUpTo(msg, nil, 7)
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		height, _ := setup.ledger.LedgerHeight()
+		return height == 101
+	}, 30*time.Second, 100*time.Millisecond)
+
+	setup.lock.Lock()
+	defer setup.lock.Unlock()
+	require.Len(t, setup.bulkRequests, 5)
+	// each request of 20 blocks is served in 3 responses
+	require.Equal(t, int32(15), atomic.LoadInt32(&requests))
+}
+
+func TestHandleStateRequestSizeLimit(t *testing.T) {
+	ledger := &inMemoryLedger{height: 10}
+	block, _, err := ledger.GetPvtDataAndBlockByNum(1, protoutil.SignedData{})
+	require.NoError(t, err)
+	blockSize := pb.Size(block)
+
+	s := &GossipStateProviderImpl{
+		logger:           flogging.MustGetLogger(gossiputil.StateLogger),
+		chainID:          "testchannelid",
+		ledger:           ledger,
+		requestValidator: &stateRequestValidator{},
+		config:           bulkSyncConfig(),
+	}
+	respond := func(start, end uint64) []*proto.Payload {
+		msg, err := protoext.NoopSign(&proto.GossipMessage{
+			Nonce:   1,
+			Channel: []byte("testchannelid"),
+			Content: &proto.GossipMessage_StateRequest{StateRequest: &proto.RemoteStateRequest{
+				StartSeqNum: start,
+				EndSeqNum:   end,
+			}},
+		})
+		require.NoError(t, err)
+		requestMsg := &receivedMessageMock{}
+		requestMsg.On("GetGossipMessage").Return(msg)
+		requestMsg.On("GetConnectionInfo").Return(&protoext.ConnectionInfo{Auth: &protoext.AuthInfo{}})
+		var response *proto.GossipMessage
+		requestMsg.On("Respond", mock.Anything).Run(func(args mock.Arguments) {
+			response = args.Get(0).(*proto.GossipMessage)
+		})
+		s.handleStateRequest(requestMsg)
+		require.NotNil(t, response)
+		return response.GetStateResponse().Payloads
+	}
+
+	s.maxResponseSize = defMaxStateResponseSize
+	require.Len(t, respond(1, 8), 8)
+
+	// only the first blocks that fit are served
+	s.maxResponseSize = 3*blockSize + blockSize/2
+	payloads := respond(1, 8)
+	require.Len(t, payloads, 3)
+	for i, payload := range payloads {
+		require.Equal(t, uint64(1+i), payload.SeqNum)
+	}
+
+	// the first block is served even if it doesn't fit
+	s.maxResponseSize = blockSize / 2
+	require.Len(t, respond(1, 8), 1)
+}
+
+func TestShouldBulkSync(t *testing.T) {
+	s := &GossipStateProviderImpl{config: bulkSyncConfig()}
+	require.False(t, s.shouldBulkSync(1, 51))
+	require.True(t, s.shouldBulkSync(1, 52))
+	require.Equal(t, uint64(20), s.maxResponseBatchSize())
+
+	s.config.StateBulkSyncThreshold = 0
+	require.False(t, s.shouldBulkSync(1, 1000))
+	require.Equal(t, uint64(20), s.maxResponseBatchSize())
+
+	s.config.StateBulkSyncBatchSize = 0
+	require.Equal(t, uint64(DefStateBatchSize), s.maxResponseBatchSize())
+}
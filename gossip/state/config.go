@@ -20,6 +20,10 @@ const (
 	DefStateBlockBufferSize = 20
 	DefStateChannelSize     = 100
 	DefStateEnabled         = false
+
+	DefStateBulkSyncThreshold   = 0
+	DefStateBulkSyncBatchSize   = 100
+	DefStateBulkSyncMaxInFlight = 4
 )
 
 type StateConfig struct {
@@ -30,8 +34,22 @@ type StateConfig struct {
 	StateBlockBufferSize int
 	StateChannelSize     int
 	StateEnabled         bool
-	UseLeaderElection    bool
-	OrgLeader            bool
+	// StateBulkSyncThreshold is the number of blocks the peer has to be
+	// behind the other peers for state transfer to stream blocks in bulk
+	// from a single peer, instead of requesting them in small batches.
+	// If 0, which is the default, blocks are never transferred in bulk.
+	// Bulk transfer should be enabled only once all the peers of the
+	// channel serve bulk requests.
+	StateBulkSyncThreshold uint64
+	// StateBulkSyncBatchSize is the number of blocks requested at once
+	// when transferring blocks in bulk. It is also the largest number of
+	// blocks the peer serves in a single state transfer response.
+	StateBulkSyncBatchSize uint64
+	// StateBulkSyncMaxInFlight is the number of bulk requests that may be
+	// awaiting a response at the same time.
+	StateBulkSyncMaxInFlight int
+	UseLeaderElection        bool
+	OrgLeader                bool
 }
 
 func GlobalConfig() *StateConfig {
@@ -69,6 +87,18 @@ func (c *StateConfig) loadStateConfig() {
 	if viper.IsSet("peer.gossip.state.enabled") {
 		c.StateEnabled = viper.GetBool("peer.gossip.state.enabled")
 	}
+	c.StateBulkSyncThreshold = DefStateBulkSyncThreshold
+	if viper.IsSet("peer.gossip.state.bulkSync.threshold") {
+		c.StateBulkSyncThreshold = uint64(viper.GetInt("peer.gossip.state.bulkSync.threshold"))
+	}
+	c.StateBulkSyncBatchSize = DefStateBulkSyncBatchSize
+	if viper.IsSet("peer.gossip.state.bulkSync.batchSize") {
+		c.StateBulkSyncBatchSize = uint64(viper.GetInt("peer.gossip.state.bulkSync.batchSize"))
+	}
+	c.StateBulkSyncMaxInFlight = DefStateBulkSyncMaxInFlight
+	if viper.IsSet("peer.gossip.state.bulkSync.maxInFlight") {
+		c.StateBulkSyncMaxInFlight = viper.GetInt("peer.gossip.state.bulkSync.maxInFlight")
+	}
 	// The below two configuration parameters are used for straggler() which warns
 	// if our peer is lagging behind the rest and has no way to catch up.
 	c.UseLeaderElection = viper.GetBool("peer.gossip.useLeaderElection")
@@ -24,6 +24,9 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.state.blockBufferSize", 5)
 	viper.Set("peer.gossip.state.channelSize", 6)
 	viper.Set("peer.gossip.state.enabled", true)
+	viper.Set("peer.gossip.state.bulkSync.threshold", 7)
+	viper.Set("peer.gossip.state.bulkSync.batchSize", 8)
+	viper.Set("peer.gossip.state.bulkSync.maxInFlight", 9)
 
 	coreConfig := state.GlobalConfig()
 
@@ -35,6 +38,10 @@ func TestGlobalConfig(t *testing.T) {
 		StateBlockBufferSize: 5,
 		StateChannelSize:     6,
 		StateEnabled:         true,
+
+		StateBulkSyncThreshold:   7,
+		StateBulkSyncBatchSize:   8,
+		StateBulkSyncMaxInFlight: 9,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
@@ -53,6 +60,10 @@ func TestGlobalConfigDefaults(t *testing.T) {
 		StateBlockBufferSize: 20,
 		StateChannelSize:     100,
 		StateEnabled:         false,
+
+		StateBulkSyncThreshold:   0,
+		StateBulkSyncBatchSize:   100,
+		StateBulkSyncMaxInFlight: 4,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
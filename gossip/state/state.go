@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/protoext"
 	"github.com/hyperledger/fabric/gossip/util"
+	corecomm "github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
@@ -36,6 +37,10 @@ import (
 type GossipStateProvider interface {
 	AddPayload(payload *proto.Payload) error
 
+	// BufferedPayloads returns the number of payloads
+	// waiting to be committed
+	BufferedPayloads() int
+
 	// Stop terminates state transfer object
 	Stop()
 }
@@ -160,8 +165,15 @@ type GossipStateProviderImpl struct {
 	blockingMode bool
 
 	config *StateConfig
+
+	// maxResponseSize bounds the total size of the blocks in a state response
+	maxResponseSize int
 }
 
+// defMaxStateResponseSize leaves room for the rest of a state response
+// within the default size limit of a gRPC message
+const defMaxStateResponseSize = corecomm.DefaultMaxSendMsgSize - 1024*1024
+
 // stateRequestValidator facilitates validation of the state request messages
 type stateRequestValidator struct{}
 
@@ -253,6 +265,7 @@ func NewGossipStateProvider(
 		requestValidator:    &stateRequestValidator{},
 		blockingMode:        blockingMode,
 		config:              config,
+		maxResponseSize:     defMaxStateResponseSize,
 	}
 
 	logger.Infof("Updating metadata information for channel %s, "+
@@ -421,7 +434,7 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg protoext.ReceivedMessag
 	}
 	request := msg.GetGossipMessage().GetStateRequest()
 
-	if err := s.requestValidator.validate(request, s.config.StateBatchSize); err != nil {
+	if err := s.requestValidator.validate(request, s.maxResponseBatchSize()); err != nil {
 		s.logger.Errorf("State request validation failed, %s. Ignoring request...", err)
 		return
 	}
@@ -439,6 +452,7 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg protoext.ReceivedMessag
 	endSeqNum := min(currentHeight, request.EndSeqNum)
 
 	response := &proto.RemoteStateResponse{Payloads: make([]*proto.Payload, 0)}
+	responseSize := 0
 	for seqNum := request.StartSeqNum; seqNum <= endSeqNum; seqNum++ {
 		s.logger.Debug("Reading block ", seqNum, " with private data from the coordinator service")
 		connInfo := msg.GetConnectionInfo()
@@ -474,6 +488,18 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg protoext.ReceivedMessag
 			}
 		}
 
+		// The blocks that don't fit in the response are requested again by the peer
+		payloadSize := len(blockBytes)
+		for _, b := range pvtBytes {
+			payloadSize += len(b)
+		}
+		if len(response.Payloads) > 0 && responseSize+payloadSize > s.maxResponseSize {
+			s.logger.Debugf("Responding with blocks [%d...%d] of [%d...%d] to fit within %d bytes",
+				request.StartSeqNum, seqNum-1, request.StartSeqNum, endSeqNum, s.maxResponseSize)
+			break
+		}
+		responseSize += payloadSize
+
 		// Appending result to the response
 		response.Payloads = append(response.Payloads, &proto.Payload{
 			SeqNum:      seqNum,
@@ -604,6 +630,21 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 				continue
 			}
 
+			if s.shouldBulkSync(ourHeight, maxHeight) {
+				transferred, err := s.bulkSync(ourHeight, maxHeight-1)
+				if err == nil {
+					continue
+				}
+				if transferred > 0 {
+					// The transfer made progress, so it is resumed
+					// from another peer in the next round
+					s.logger.Warningf("Bulk transfer of blocks stopped after %d blocks: %s", transferred, err)
+					continue
+				}
+				s.logger.Warningf("Bulk transfer of blocks [%d...%d] failed, requesting them in batches of %d blocks instead: %s",
+					ourHeight, maxHeight-1, s.config.StateBatchSize, err)
+			}
+
 			s.requestBlocksInRange(uint64(ourHeight), uint64(maxHeight)-1)
 		}
 	}
@@ -744,6 +785,11 @@ func (s *GossipStateProviderImpl) AddPayload(payload *proto.Payload) error {
 	return s.addPayload(payload, s.blockingMode)
 }
 
+// BufferedPayloads returns the number of payloads waiting to be committed.
+func (s *GossipStateProviderImpl) BufferedPayloads() int {
+	return s.payloads.Size()
+}
+
 // addPayload adds new payload into state. It may (or may not) block according to the
 // given parameter. If it gets a block while in blocking mode - it would wait until
 // the block is sent into the payloads buffer.
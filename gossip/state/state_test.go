@@ -723,6 +723,7 @@ func TestOverPopulation(t *testing.T) {
 	// Ensure we don't store too many blocks in memory
 	sp := p.s
 	require.True(t, sp.payloads.Size() < defMaxBlockDistance)
+	require.Equal(t, sp.payloads.Size(), sp.BufferedPayloads())
 }
 
 func TestBlockingEnqueue(t *testing.T) {
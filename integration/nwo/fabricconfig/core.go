@@ -29,28 +29,29 @@ type Logging struct {
 }
 
 type Peer struct {
-	ID                     string          `yaml:"id,omitempty"`
-	NetworkID              string          `yaml:"networkId,omitempty"`
-	ListenAddress          string          `yaml:"listenAddress,omitempty"`
-	ChaincodeListenAddress string          `yaml:"ChaincodeListenAddress,omitempty"`
-	ChaincodeAddress       string          `yaml:"chaincodeAddress,omitempty"`
-	Address                string          `yaml:"address,omitempty"`
-	AddressAutoDetect      bool            `yaml:"addressAutoDetect"`
-	Keepalive              *Keepalive      `yaml:"keepalive,omitempty"`
-	Gossip                 *Gossip         `yaml:"gossip,omitempty"`
-	Events                 *Events         `yaml:"events,omitempty"`
-	TLS                    *TLS            `yaml:"tls,omitempty"`
-	Authentication         *Authentication `yaml:"authentication,omitempty"`
-	FileSystemPath         string          `yaml:"fileSystemPath,omitempty"`
-	BCCSP                  *BCCSP          `yaml:"BCCSP,omitempty"`
-	MSPConfigPath          string          `yaml:"mspConfigPath,omitempty"`
-	LocalMSPID             string          `yaml:"localMspId,omitempty"`
-	Deliveryclient         *DeliveryClient `yaml:"deliveryclient,omitempty"`
-	LocalMspType           string          `yaml:"localMspType,omitempty"`
-	Handlers               *Handlers       `yaml:"handlers,omitempty"`
-	ValidatorPoolSize      int             `yaml:"validatorPoolSize,omitempty"`
-	Discovery              *Discovery      `yaml:"discovery,omitempty"`
-	Limits                 *Limits         `yaml:"limits,omitempty"`
+	ID                        string          `yaml:"id,omitempty"`
+	NetworkID                 string          `yaml:"networkId,omitempty"`
+	ListenAddress             string          `yaml:"listenAddress,omitempty"`
+	ChaincodeListenAddress    string          `yaml:"ChaincodeListenAddress,omitempty"`
+	ChaincodeAddress          string          `yaml:"chaincodeAddress,omitempty"`
+	Address                   string          `yaml:"address,omitempty"`
+	AddressAutoDetect         bool            `yaml:"addressAutoDetect"`
+	Keepalive                 *Keepalive      `yaml:"keepalive,omitempty"`
+	Gossip                    *Gossip         `yaml:"gossip,omitempty"`
+	Events                    *Events         `yaml:"events,omitempty"`
+	TLS                       *TLS            `yaml:"tls,omitempty"`
+	Authentication            *Authentication `yaml:"authentication,omitempty"`
+	FileSystemPath            string          `yaml:"fileSystemPath,omitempty"`
+	BCCSP                     *BCCSP          `yaml:"BCCSP,omitempty"`
+	MSPConfigPath             string          `yaml:"mspConfigPath,omitempty"`
+	LocalMSPID                string          `yaml:"localMspId,omitempty"`
+	Deliveryclient            *DeliveryClient `yaml:"deliveryclient,omitempty"`
+	LocalMspType              string          `yaml:"localMspType,omitempty"`
+	CredentialsReloadInterval time.Duration   `yaml:"credentialsReloadInterval,omitempty"`
+	Handlers                  *Handlers       `yaml:"handlers,omitempty"`
+	ValidatorPoolSize         int             `yaml:"validatorPoolSize,omitempty"`
+	Discovery                 *Discovery      `yaml:"discovery,omitempty"`
+	Limits                    *Limits         `yaml:"limits,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
 }
@@ -67,38 +68,54 @@ type ClientKeepalive struct {
 }
 
 type Gossip struct {
-	Bootstrap                  string          `yaml:"bootstrap,omitempty"`
-	UseLeaderElection          bool            `yaml:"useLeaderElection"`
-	OrgLeader                  bool            `yaml:"orgLeader"`
-	MembershipTrackerInterval  time.Duration   `yaml:"membershipTrackerInterval,omitempty"`
-	Endpoint                   string          `yaml:"endpoint,omitempty"`
-	MaxBlockCountToStore       int             `yaml:"maxBlockCountToStore,omitempty"`
-	MaxPropagationBurstLatency time.Duration   `yaml:"maxPropagationBurstLatency,omitempty"`
-	MaxPropagationBurstSize    int             `yaml:"maxPropagationBurstSize,omitempty"`
-	PropagateIterations        int             `yaml:"propagateIterations,omitempty"`
-	PropagatePeerNum           int             `yaml:"propagatePeerNum,omitempty"`
-	PullInterval               time.Duration   `yaml:"pullInterval,omitempty"`
-	PullPeerNum                int             `yaml:"pullPeerNum,omitempty"`
-	RequestStateInfoInterval   time.Duration   `yaml:"requestStateInfoInterval,omitempty"`
-	PublishStateInfoInterval   time.Duration   `yaml:"publishStateInfoInterval,omitempty"`
-	StateInfoRetentionInterval time.Duration   `yaml:"stateInfoRetentionInterval,omitempty"`
-	PublishCertPeriod          time.Duration   `yaml:"publishCertPeriod,omitempty"`
-	DialTimeout                time.Duration   `yaml:"dialTimeout,omitempty"`
-	ConnTimeout                time.Duration   `yaml:"connTimeout,omitempty"`
-	RecvBuffSize               int             `yaml:"recvBuffSize,omitempty"`
-	SendBuffSize               int             `yaml:"sendBuffSize,omitempty"`
-	DigestWaitTime             time.Duration   `yaml:"digestWaitTime,omitempty"`
-	RequestWaitTime            time.Duration   `yaml:"requestWaitTime,omitempty"`
-	ResponseWaitTime           time.Duration   `yaml:"responseWaitTime,omitempty"`
-	AliveTimeInterval          time.Duration   `yaml:"aliveTimeInterval,omitempty"`
-	AliveExpirationTimeout     time.Duration   `yaml:"aliveExpirationTimeout,omitempty"`
-	ReconnectInterval          time.Duration   `yaml:"reconnectInterval,omitempty"`
-	MsgExpirationFactor        int             `yaml:"msgExpirationFactor,omitempty"`
-	MaxConnectionAttempts      int             `yaml:"maxConnectionAttempts,omitempty"`
-	ExternalEndpoint           string          `yaml:"externalEndpoint,omitempty"`
-	Election                   *GossipElection `yaml:"election,omitempty"`
-	PvtData                    *GossipPvtData  `yaml:"pvtData,omitempty"`
-	State                      *GossipState    `yaml:"state,omitempty"`
+	Bootstrap                  string             `yaml:"bootstrap,omitempty"`
+	UseLeaderElection          bool               `yaml:"useLeaderElection"`
+	OrgLeader                  bool               `yaml:"orgLeader"`
+	MembershipTrackerInterval  time.Duration      `yaml:"membershipTrackerInterval,omitempty"`
+	Endpoint                   string             `yaml:"endpoint,omitempty"`
+	MaxBlockCountToStore       int                `yaml:"maxBlockCountToStore,omitempty"`
+	MaxPropagationBurstLatency time.Duration      `yaml:"maxPropagationBurstLatency,omitempty"`
+	MaxPropagationBurstSize    int                `yaml:"maxPropagationBurstSize,omitempty"`
+	PropagateIterations        int                `yaml:"propagateIterations,omitempty"`
+	PropagatePeerNum           int                `yaml:"propagatePeerNum,omitempty"`
+	PullInterval               time.Duration      `yaml:"pullInterval,omitempty"`
+	PullPeerNum                int                `yaml:"pullPeerNum,omitempty"`
+	RequestStateInfoInterval   time.Duration      `yaml:"requestStateInfoInterval,omitempty"`
+	PublishStateInfoInterval   time.Duration      `yaml:"publishStateInfoInterval,omitempty"`
+	StateInfoRetentionInterval time.Duration      `yaml:"stateInfoRetentionInterval,omitempty"`
+	PublishCertPeriod          time.Duration      `yaml:"publishCertPeriod,omitempty"`
+	DialTimeout                time.Duration      `yaml:"dialTimeout,omitempty"`
+	ConnTimeout                time.Duration      `yaml:"connTimeout,omitempty"`
+	RecvBuffSize               int                `yaml:"recvBuffSize,omitempty"`
+	SendBuffSize               int                `yaml:"sendBuffSize,omitempty"`
+	Compression                *GossipCompression `yaml:"compression,omitempty"`
+	RateLimits                 *GossipRateLimits  `yaml:"rateLimits,omitempty"`
+	DigestWaitTime             time.Duration      `yaml:"digestWaitTime,omitempty"`
+	RequestWaitTime            time.Duration      `yaml:"requestWaitTime,omitempty"`
+	ResponseWaitTime           time.Duration      `yaml:"responseWaitTime,omitempty"`
+	AliveTimeInterval          time.Duration      `yaml:"aliveTimeInterval,omitempty"`
+	AliveExpirationTimeout     time.Duration      `yaml:"aliveExpirationTimeout,omitempty"`
+	ReconnectInterval          time.Duration      `yaml:"reconnectInterval,omitempty"`
+	MsgExpirationFactor        int                `yaml:"msgExpirationFactor,omitempty"`
+	MaxConnectionAttempts      int                `yaml:"maxConnectionAttempts,omitempty"`
+	ExternalEndpoint           string             `yaml:"externalEndpoint,omitempty"`
+	Election                   *GossipElection    `yaml:"election,omitempty"`
+	PvtData                    *GossipPvtData     `yaml:"pvtData,omitempty"`
+	State                      *GossipState       `yaml:"state,omitempty"`
+}
+
+type GossipCompression struct {
+	Threshold int `yaml:"threshold,omitempty"`
+}
+
+type GossipRateLimits struct {
+	Inbound  *GossipRateLimit `yaml:"inbound,omitempty"`
+	Outbound *GossipRateLimit `yaml:"outbound,omitempty"`
+}
+
+type GossipRateLimit struct {
+	BytesPerSecond    int `yaml:"bytesPerSecond,omitempty"`
+	MessagesPerSecond int `yaml:"messagesPerSecond,omitempty"`
 }
 
 type GossipElection struct {
@@ -106,6 +123,7 @@ type GossipElection struct {
 	MembershipSampleInterval time.Duration `yaml:"membershipSampleInterval,omitempty"`
 	LeaderAliveThreshold     time.Duration `yaml:"leaderAliveThreshold,omitempty"`
 	LeaderElectionDuration   time.Duration `yaml:"leaderElectionDuration,omitempty"`
+	Priority                 uint32        `yaml:"priority,omitempty"`
 }
 
 type GossipPvtData struct {
@@ -118,6 +136,9 @@ type GossipPvtData struct {
 	ReconciliationEnabled                      bool                            `yaml:"reconciliationEnabled"`
 	SkipPullingInvalidTransactionsDuringCommit bool                            `yaml:"skipPullingInvalidTransactionsDuringCommit"`
 	ImplicitCollDisseminationPolicy            ImplicitCollDisseminationPolicy `yaml:"implicitCollectionDisseminationPolicy"`
+	DisseminationRetryEnabled                  bool                            `yaml:"disseminationRetryEnabled"`
+	DisseminationRetryInterval                 time.Duration                   `yaml:"disseminationRetryInterval,omitempty"`
+	DisseminationRetryQueueSize                int                             `yaml:"disseminationRetryQueueSize,omitempty"`
 }
 
 type ImplicitCollDisseminationPolicy struct {
@@ -127,12 +148,20 @@ type ImplicitCollDisseminationPolicy struct {
 }
 
 type GossipState struct {
-	Enabled         bool          `yaml:"enabled"`
-	CheckInterval   time.Duration `yaml:"checkInterval,omitempty"`
-	ResponseTimeout time.Duration `yaml:"responseTimeout,omitempty"`
-	BatchSize       int           `yaml:"batchSize,omitempty"`
-	BlockBufferSize int           `yaml:"blockBufferSize,omitempty"`
-	MaxRetries      int           `yaml:"maxRetries,omitempty"`
+	Enabled         bool            `yaml:"enabled"`
+	CheckInterval   time.Duration   `yaml:"checkInterval,omitempty"`
+	ResponseTimeout time.Duration   `yaml:"responseTimeout,omitempty"`
+	BatchSize       int             `yaml:"batchSize,omitempty"`
+	BlockBufferSize int             `yaml:"blockBufferSize,omitempty"`
+	MaxRetries      int             `yaml:"maxRetries,omitempty"`
+	BulkSync        *GossipBulkSync `yaml:"bulkSync,omitempty"`
+}
+
+type GossipBulkSync struct {
+	// do not tag omitempty in order to override Threshold default with 0
+	Threshold   int `yaml:"threshold"`
+	BatchSize   int `yaml:"batchSize,omitempty"`
+	MaxInFlight int `yaml:"maxInFlight,omitempty"`
 }
 
 type Events struct {
@@ -225,11 +254,12 @@ type Handler struct {
 type HandlerMap map[string]Handler
 
 type Discovery struct {
-	Enabled                      bool    `yaml:"enabled"`
-	AuthCacheEnabled             bool    `yaml:"authCacheEnabled"`
-	AuthCacheMaxSize             int     `yaml:"authCacheMaxSize,omitempty"`
-	AuthCachePurgeRetentionRatio float64 `yaml:"authCachePurgeRetentionRatio"`
-	OrgMembersAllowedAccess      bool    `yaml:"orgMembersAllowedAccess"`
+	Enabled                      bool          `yaml:"enabled"`
+	AuthCacheEnabled             bool          `yaml:"authCacheEnabled"`
+	AuthCacheMaxSize             int           `yaml:"authCacheMaxSize,omitempty"`
+	AuthCachePurgeRetentionRatio float64       `yaml:"authCachePurgeRetentionRatio"`
+	OrgMembersAllowedAccess      bool          `yaml:"orgMembersAllowedAccess"`
+	WatchInterval                time.Duration `yaml:"watchInterval,omitempty"`
 }
 
 type Limits struct {
@@ -250,6 +280,8 @@ type Docker struct {
 	TLS          *TLS               `yaml:"tls,omitempty"`
 	AttachStdout bool               `yaml:"attachStdout"`
 	HostConfig   *docker.HostConfig `yaml:"hostConfig,omitempty"`
+
+	ChaincodeHostConfig map[string]interface{} `yaml:"chaincodeHostConfig,omitempty"`
 }
 
 type Chaincode struct {
@@ -47,6 +47,15 @@ peer:
     connTimeout: 2s
     recvBuffSize: 20
     sendBuffSize: 200
+    compression:
+      threshold: 0
+    rateLimits:
+      inbound:
+        bytesPerSecond: 0
+        messagesPerSecond: 0
+      outbound:
+        bytesPerSecond: 0
+        messagesPerSecond: 0
     digestWaitTime: 1s
     requestWaitTime: 1500ms
     responseWaitTime: 2s
@@ -70,6 +79,9 @@ peer:
       implicitCollectionDisseminationPolicy:
         requiredPeerCount: 0
         maxPeerCount: 1
+      disseminationRetryEnabled: false
+      disseminationRetryInterval: 10s
+      disseminationRetryQueueSize: 1000
     state:
        enabled: false
        checkInterval: 10s
@@ -77,6 +89,10 @@ peer:
        batchSize: 10
        blockBufferSize: 20
        maxRetries: 3
+       bulkSync:
+         threshold: 0
+         batchSize: 100
+         maxInFlight: 4
   events:
     address: 127.0.0.1:{{ .PeerPort Peer "Events" }}
     buffersize: 100
@@ -115,6 +131,7 @@ peer:
   deliveryclient:
     reconnectTotalTimeThreshold: 3600s
   localMspType: bccsp
+  credentialsReloadInterval: 0s
   profile:
     enabled:     false
     listenAddress: 127.0.0.1:{{ .PeerPort Peer "ProfilePort" }}
@@ -137,6 +154,7 @@ peer:
     authCacheMaxSize: 1000
     authCachePurgeRetentionRatio: 0.75
     orgMembersAllowedAccess: false
+    watchInterval: 1s
   limits:
     concurrency:
       endorserService: 100
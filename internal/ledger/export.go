@@ -0,0 +1,244 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ExportFormatJSONLines exports one json object per line
+	ExportFormatJSONLines = "jsonl"
+	// ExportFormatCSV exports one csv row per record, preceded by a header row
+	ExportFormatCSV = "csv"
+
+	// ValueEncodingUTF8 exports the values as strings. Invalid UTF-8 bytes are replaced with the unicode replacement character
+	ValueEncodingUTF8 = "utf8"
+	// ValueEncodingBase64 exports the values base64 encoded
+	ValueEncodingBase64 = "base64"
+
+	publicStateRecordType  = "public"
+	pvtStateHashRecordType = "private_hash"
+)
+
+// exportCSVHeader matches the json field names of the exportRecord
+var exportCSVHeader = []string{"type", "namespace", "collection", "key", "value", "block_num", "tx_num", "metadata"}
+
+// ExportOptions controls the records exported by ExportSnapshot and their representation
+type ExportOptions struct {
+	// Format is either ExportFormatJSONLines or ExportFormatCSV
+	Format string
+	// ValueEncoding is either ValueEncodingUTF8 or ValueEncodingBase64. It applies to the values in the public state.
+	// The key hashes and the value hashes in the private data hashes are always exported hex encoded
+	ValueEncoding string
+	// Namespaces, if not empty, limits the export to the given namespaces
+	Namespaces []string
+	// KeyPrefix, if not empty, limits the export of the public state to the keys that begin with the prefix.
+	// As the keys in the private data hashes are hashes, the private data hashes are not exported when a
+	// key prefix is specified
+	KeyPrefix string
+	// ExcludePvtStateHashes excludes the private data hashes from the export
+	ExcludePvtStateHashes bool
+}
+
+// exportRecord represents a record of the public state or the private data hashes in the exported output.
+// The metadata is always base64 encoded as it is a serialized proto message
+type exportRecord struct {
+	Type       string `json:"type"`
+	Namespace  string `json:"namespace"`
+	Collection string `json:"collection,omitempty"`
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+	BlockNum   uint64 `json:"block_num"`
+	TxNum      uint64 `json:"tx_num"`
+	Metadata   string `json:"metadata,omitempty"`
+}
+
+// ExportSnapshot streams the public state and the private data hashes present in a ledger snapshot to the writer,
+// in the format specified in the options, and returns the number of exported records. The records are exported
+// in the order in which they are present in the snapshot files, i.e., the public state sorted by namespaces and
+// keys followed by the private data hashes sorted by namespaces, collections, and key hashes
+func ExportSnapshot(snapshotDir string, w io.Writer, opts *ExportOptions) (int, error) {
+	if err := opts.validate(); err != nil {
+		return 0, err
+	}
+	// the signable metadata file is loaded to make sure that the dir is a snapshot dir
+	if _, _, err := loadSignableMetadata(snapshotDir); err != nil {
+		return 0, err
+	}
+	bufWriter := bufio.NewWriter(w)
+	recordWriter := newExportRecordWriter(bufWriter, opts.Format)
+
+	namespaces := map[string]struct{}{}
+	for _, ns := range opts.Namespaces {
+		namespaces[ns] = struct{}{}
+	}
+	includeNamespace := func(ns string) bool {
+		if len(namespaces) == 0 {
+			return true
+		}
+		_, ok := namespaces[ns]
+		return ok
+	}
+
+	count := 0
+	if err := scanStateRecords(
+		snapshotDir,
+		privacyenabledstate.PubStateDataFileName,
+		privacyenabledstate.PubStateMetadataFileName,
+		func(namespace string, record *privacyenabledstate.SnapshotRecord) error {
+			if !includeNamespace(namespace) || !bytes.HasPrefix(record.Key, []byte(opts.KeyPrefix)) {
+				return nil
+			}
+			r, err := newExportRecord(publicStateRecordType, namespace, "", record)
+			if err != nil {
+				return err
+			}
+			r.Key = string(record.Key)
+			r.Value = opts.encodeValue(record.Value)
+			count++
+			return recordWriter.write(r)
+		},
+	); err != nil {
+		return 0, err
+	}
+
+	if !opts.ExcludePvtStateHashes && opts.KeyPrefix == "" {
+		if err := scanStateRecords(
+			snapshotDir,
+			privacyenabledstate.PvtStateHashesFileName,
+			privacyenabledstate.PvtStateHashesMetadataFileName,
+			func(hashedDataNs string, record *privacyenabledstate.SnapshotRecord) error {
+				ns, coll, err := decodeHashedDataNs(hashedDataNs)
+				if err != nil {
+					return err
+				}
+				if !includeNamespace(ns) {
+					return nil
+				}
+				r, err := newExportRecord(pvtStateHashRecordType, ns, coll, record)
+				if err != nil {
+					return err
+				}
+				r.Key = hex.EncodeToString(record.Key)
+				r.Value = hex.EncodeToString(record.Value)
+				count++
+				return recordWriter.write(r)
+			},
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := recordWriter.flush(); err != nil {
+		return 0, err
+	}
+	if err := bufWriter.Flush(); err != nil {
+		return 0, errors.Wrap(err, "error while writing the exported records")
+	}
+	return count, nil
+}
+
+func (o *ExportOptions) validate() error {
+	switch o.Format {
+	case ExportFormatJSONLines, ExportFormatCSV:
+	default:
+		return errors.Errorf("unsupported export format [%s]", o.Format)
+	}
+	switch o.ValueEncoding {
+	case ValueEncodingUTF8, ValueEncodingBase64:
+	default:
+		return errors.Errorf("unsupported value encoding [%s]", o.ValueEncoding)
+	}
+	return nil
+}
+
+func (o *ExportOptions) encodeValue(value []byte) string {
+	if o.ValueEncoding == ValueEncodingBase64 {
+		return base64.StdEncoding.EncodeToString(value)
+	}
+	return string(bytes.ToValidUTF8(value, []byte("\uFFFD")))
+}
+
+func newExportRecord(recordType, namespace, collection string, record *privacyenabledstate.SnapshotRecord) (*exportRecord, error) {
+	blockNum, txNum, err := heightFromBytes(record.Version)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error while decoding the version of the key [%x] in the namespace [%s]", record.Key, namespace)
+	}
+	r := &exportRecord{
+		Type:       recordType,
+		Namespace:  namespace,
+		Collection: collection,
+		BlockNum:   blockNum,
+		TxNum:      txNum,
+	}
+	if len(record.Metadata) > 0 {
+		r.Metadata = base64.StdEncoding.EncodeToString(record.Metadata)
+	}
+	return r, nil
+}
+
+// exportRecordWriter writes the exported records in either json lines or csv format
+type exportRecordWriter struct {
+	jsonEncoder   *json.Encoder
+	csvWriter     *csv.Writer
+	headerWritten bool
+}
+
+func newExportRecordWriter(w io.Writer, format string) *exportRecordWriter {
+	if format == ExportFormatCSV {
+		return &exportRecordWriter{csvWriter: csv.NewWriter(w)}
+	}
+	jsonEncoder := json.NewEncoder(w)
+	jsonEncoder.SetEscapeHTML(false)
+	return &exportRecordWriter{jsonEncoder: jsonEncoder}
+}
+
+func (w *exportRecordWriter) write(r *exportRecord) error {
+	if w.jsonEncoder != nil {
+		return errors.Wrap(w.jsonEncoder.Encode(r), "error while writing the exported records")
+	}
+	if err := w.writeCSVHeader(); err != nil {
+		return err
+	}
+	return errors.Wrap(
+		w.csvWriter.Write([]string{
+			r.Type, r.Namespace, r.Collection, r.Key, r.Value,
+			strconv.FormatUint(r.BlockNum, 10), strconv.FormatUint(r.TxNum, 10), r.Metadata,
+		}),
+		"error while writing the exported records",
+	)
+}
+
+func (w *exportRecordWriter) writeCSVHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return errors.Wrap(w.csvWriter.Write(exportCSVHeader), "error while writing the exported records")
+}
+
+func (w *exportRecordWriter) flush() error {
+	if w.csvWriter == nil {
+		return nil
+	}
+	if err := w.writeCSVHeader(); err != nil {
+		return err
+	}
+	w.csvWriter.Flush()
+	return errors.Wrap(w.csvWriter.Error(), "error while writing the exported records")
+}
@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSnapshot(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "exportsnapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	records := sampleTestSnapshotRecords()
+	records.pubState[0].Metadata = []byte("md1")
+	records.pubState[1].Value = []byte{0xff, 'v', '2'}
+	snapshotDir := createTestSnapshot(t, testDir, records)
+
+	keyHash := func(i int) string { return hex.EncodeToString(records.pvtStateHashes[i].Key) }
+	valueHash := func(s string) string {
+		h := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(h[:])
+	}
+
+	allRecords := []*exportRecord{
+		{Type: "public", Namespace: "ns1", Key: "k1", Value: "v1", BlockNum: 1, TxNum: 1, Metadata: "bWQx"},
+		{Type: "public", Namespace: "ns1", Key: "k2", Value: "�v2", BlockNum: 1, TxNum: 2},
+		{Type: "public", Namespace: "ns2", Key: "k1", Value: "v3", BlockNum: 2, TxNum: 1},
+		{Type: "private_hash", Namespace: "ns1", Collection: "coll1", Key: keyHash(0), Value: valueHash("v1"), BlockNum: 3, TxNum: 1},
+		{Type: "private_hash", Namespace: "ns1", Collection: "coll1", Key: keyHash(1), Value: valueHash("v2"), BlockNum: 3, TxNum: 2},
+		{Type: "private_hash", Namespace: "ns3", Collection: "coll1", Key: keyHash(2), Value: valueHash("v3"), BlockNum: 4, TxNum: 1},
+	}
+
+	testCases := []struct {
+		name            string
+		opts            *ExportOptions
+		expectedRecords []*exportRecord
+	}{
+		{
+			name:            "all-records",
+			opts:            &ExportOptions{Format: ExportFormatJSONLines, ValueEncoding: ValueEncodingUTF8},
+			expectedRecords: allRecords,
+		},
+		{
+			name:            "namespace-filter",
+			opts:            &ExportOptions{Format: ExportFormatJSONLines, ValueEncoding: ValueEncodingUTF8, Namespaces: []string{"ns1"}},
+			expectedRecords: []*exportRecord{allRecords[0], allRecords[1], allRecords[3], allRecords[4]},
+		},
+		{
+			name:            "key-prefix-filter",
+			opts:            &ExportOptions{Format: ExportFormatJSONLines, ValueEncoding: ValueEncodingUTF8, KeyPrefix: "k2"},
+			expectedRecords: []*exportRecord{allRecords[1]},
+		},
+		{
+			name:            "exclude-pvt-state-hashes",
+			opts:            &ExportOptions{Format: ExportFormatJSONLines, ValueEncoding: ValueEncodingUTF8, ExcludePvtStateHashes: true},
+			expectedRecords: allRecords[:3],
+		},
+		{
+			name: "base64-values",
+			opts: &ExportOptions{Format: ExportFormatJSONLines, ValueEncoding: ValueEncodingBase64, Namespaces: []string{"ns2"}},
+			expectedRecords: []*exportRecord{
+				{Type: "public", Namespace: "ns2", Key: "k1", Value: "djM=", BlockNum: 2, TxNum: 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			count, err := ExportSnapshot(snapshotDir, buf, tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, len(tc.expectedRecords), count)
+
+			exportedRecords := []*exportRecord{}
+			decoder := json.NewDecoder(buf)
+			for decoder.More() {
+				r := &exportRecord{}
+				require.NoError(t, decoder.Decode(r))
+				exportedRecords = append(exportedRecords, r)
+			}
+			require.Equal(t, tc.expectedRecords, exportedRecords)
+		})
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		count, err := ExportSnapshot(snapshotDir, buf, &ExportOptions{
+			Format:        ExportFormatCSV,
+			ValueEncoding: ValueEncodingUTF8,
+			Namespaces:    []string{"ns1"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+
+		rows, err := csv.NewReader(buf).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t,
+			[][]string{
+				{"type", "namespace", "collection", "key", "value", "block_num", "tx_num", "metadata"},
+				{"public", "ns1", "", "k1", "v1", "1", "1", "bWQx"},
+				{"public", "ns1", "", "k2", "�v2", "1", "2", ""},
+				{"private_hash", "ns1", "coll1", keyHash(0), valueHash("v1"), "3", "1", ""},
+				{"private_hash", "ns1", "coll1", keyHash(1), valueHash("v2"), "3", "2", ""},
+			},
+			rows,
+		)
+	})
+
+	t.Run("csv-no-records", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		count, err := ExportSnapshot(snapshotDir, buf, &ExportOptions{
+			Format:        ExportFormatCSV,
+			ValueEncoding: ValueEncodingUTF8,
+			Namespaces:    []string{"non-existent-ns"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+		require.Equal(t, strings.Join(exportCSVHeader, ",")+"\n", buf.String())
+	})
+
+	t.Run("invalid-options", func(t *testing.T) {
+		_, err := ExportSnapshot(snapshotDir, &bytes.Buffer{}, &ExportOptions{Format: "xml", ValueEncoding: ValueEncodingUTF8})
+		require.EqualError(t, err, "unsupported export format [xml]")
+
+		_, err = ExportSnapshot(snapshotDir, &bytes.Buffer{}, &ExportOptions{Format: ExportFormatCSV, ValueEncoding: "hex"})
+		require.EqualError(t, err, "unsupported value encoding [hex]")
+	})
+
+	t.Run("not-a-snapshot-dir", func(t *testing.T) {
+		_, err := ExportSnapshot(testDir, &bytes.Buffer{}, &ExportOptions{Format: ExportFormatCSV, ValueEncoding: ValueEncodingUTF8})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while reading the file")
+	})
+
+	t.Run("malformed-version", func(t *testing.T) {
+		records := sampleTestSnapshotRecords()
+		records.pubState[0].SnapshotRecord = &privacyenabledstate.SnapshotRecord{Key: []byte("k1"), Version: []byte{0xff}}
+		snapshotDir := createTestSnapshot(t, testDir, records)
+		_, err := ExportSnapshot(snapshotDir, &bytes.Buffer{}, &ExportOptions{Format: ExportFormatJSONLines, ValueEncoding: ValueEncodingUTF8})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while decoding the version of the key [6b31] in the namespace [ns1]")
+	})
+}
@@ -0,0 +1,331 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotAdditionalMetadataFileName = "_snapshot_additional_metadata.json"
+	// the txids files are generated by the block store (see common/ledger/blkstorage/blockindex.go)
+	txIDsDataFileName     = "txids.data"
+	txIDsMetadataFileName = "txids.metadata"
+	txIDsFileFormat       = byte(1)
+	// the hashed data namespaces are named as <namespace>$$h<collection> (see privacyenabledstate/db.go)
+	hashedDataNsJoiner = "$$h"
+)
+
+// SnapshotSummary summarizes the contents of a ledger snapshot
+type SnapshotSummary struct {
+	ChannelName        string              `json:"channel_name"`
+	LastBlockNumber    uint64              `json:"last_block_number"`
+	LastBlockHashInHex string              `json:"last_block_hash"`
+	StateDBType        string              `json:"state_db_type"`
+	TxIDCount          uint64              `json:"txid_count"`
+	Namespaces         []*NamespaceSummary `json:"namespaces"`
+}
+
+// NamespaceSummary summarizes the public state and the private data hashes of a namespace in a ledger snapshot
+type NamespaceSummary struct {
+	Namespace            string            `json:"namespace"`
+	KeyCount             uint64            `json:"key_count"`
+	CollectionHashCounts map[string]uint64 `json:"collection_hash_counts,omitempty"`
+}
+
+// VerifySnapshot verifies the integrity of a ledger snapshot. It recomputes the hashes of the snapshot files
+// and matches them with the hashes present in the signable metadata file, matches the hash of the signable
+// metadata file with the snapshot hash present in the additional metadata file, and checks that the txids and
+// the records in the public state and the private data hashes files are well-formed
+func VerifySnapshot(snapshotDir string) error {
+	signableMetadataBytes, signableMetadata, err := loadSignableMetadata(snapshotDir)
+	if err != nil {
+		return err
+	}
+
+	additionalMetadataFilePath := filepath.Join(snapshotDir, snapshotAdditionalMetadataFileName)
+	additionalMetadataBytes, err := ioutil.ReadFile(additionalMetadataFilePath)
+	if err != nil {
+		return errors.Wrapf(err, "error while reading the file [%s]", additionalMetadataFilePath)
+	}
+	additionalMetadata := &struct {
+		SnapshotHashInHex string `json:"snapshot_hash"`
+	}{}
+	if err := json.Unmarshal(additionalMetadataBytes, additionalMetadata); err != nil {
+		return errors.Wrapf(err, "error while unmarshalling the file [%s]", additionalMetadataFilePath)
+	}
+	signableMetadataHash := sha256.Sum256(signableMetadataBytes)
+	if hashInHex := hex.EncodeToString(signableMetadataHash[:]); hashInHex != additionalMetadata.SnapshotHashInHex {
+		return errors.Errorf("hash mismatch for file [%s]. Expected hash = [%s], Actual hash = [%s]",
+			kvledger.SnapshotSignableMetadataFileName, additionalMetadata.SnapshotHashInHex, hashInHex,
+		)
+	}
+
+	files := make([]string, 0, len(signableMetadata.FilesAndHashes))
+	for f := range signableMetadata.FilesAndHashes {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		if err := verifyFileHash(snapshotDir, f, signableMetadata.FilesAndHashes[f]); err != nil {
+			return err
+		}
+	}
+
+	if _, err := readTxIDCount(snapshotDir); err != nil {
+		return err
+	}
+	if err := scanStateRecords(
+		snapshotDir,
+		privacyenabledstate.PubStateDataFileName,
+		privacyenabledstate.PubStateMetadataFileName,
+		verifyPubStateRecord,
+	); err != nil {
+		return err
+	}
+	return scanStateRecords(
+		snapshotDir,
+		privacyenabledstate.PvtStateHashesFileName,
+		privacyenabledstate.PvtStateHashesMetadataFileName,
+		verifyPvtStateHashRecord,
+	)
+}
+
+// InspectSnapshot returns a summary of the contents of a ledger snapshot
+func InspectSnapshot(snapshotDir string) (*SnapshotSummary, error) {
+	_, signableMetadata, err := loadSignableMetadata(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+	txIDCount, err := readTxIDCount(snapshotDir)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string]*NamespaceSummary{}
+	namespaceSummary := func(ns string) *NamespaceSummary {
+		s, ok := namespaces[ns]
+		if !ok {
+			s = &NamespaceSummary{Namespace: ns}
+			namespaces[ns] = s
+		}
+		return s
+	}
+
+	if err := scanStateRecords(
+		snapshotDir,
+		privacyenabledstate.PubStateDataFileName,
+		privacyenabledstate.PubStateMetadataFileName,
+		func(namespace string, _ *privacyenabledstate.SnapshotRecord) error {
+			namespaceSummary(namespace).KeyCount++
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	if err := scanStateRecords(
+		snapshotDir,
+		privacyenabledstate.PvtStateHashesFileName,
+		privacyenabledstate.PvtStateHashesMetadataFileName,
+		func(hashedDataNs string, _ *privacyenabledstate.SnapshotRecord) error {
+			ns, coll, err := decodeHashedDataNs(hashedDataNs)
+			if err != nil {
+				return err
+			}
+			s := namespaceSummary(ns)
+			if s.CollectionHashCounts == nil {
+				s.CollectionHashCounts = map[string]uint64{}
+			}
+			s.CollectionHashCounts[coll]++
+			return nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	summary := &SnapshotSummary{
+		ChannelName:        signableMetadata.ChannelName,
+		LastBlockNumber:    signableMetadata.LastBlockNumber,
+		LastBlockHashInHex: signableMetadata.LastBlockHashInHex,
+		StateDBType:        signableMetadata.StateDBType,
+		TxIDCount:          txIDCount,
+		Namespaces:         []*NamespaceSummary{},
+	}
+	for _, s := range namespaces {
+		summary.Namespaces = append(summary.Namespaces, s)
+	}
+	sort.Slice(summary.Namespaces, func(i, j int) bool {
+		return summary.Namespaces[i].Namespace < summary.Namespaces[j].Namespace
+	})
+	return summary, nil
+}
+
+func loadSignableMetadata(snapshotDir string) ([]byte, *kvledger.SnapshotSignableMetadata, error) {
+	signableMetadataFilePath := filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName)
+	signableMetadataBytes, err := ioutil.ReadFile(signableMetadataFilePath)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error while reading the file [%s]", signableMetadataFilePath)
+	}
+	signableMetadata := &kvledger.SnapshotSignableMetadata{}
+	if err := json.Unmarshal(signableMetadataBytes, signableMetadata); err != nil {
+		return nil, nil, errors.Wrapf(err, "error while unmarshalling the file [%s]", signableMetadataFilePath)
+	}
+	return signableMetadataBytes, signableMetadata, nil
+}
+
+// verifyFileHash matches the sha256 hash of the file with the expected hash. The snapshot files are hashed
+// with sha256 by the peer (see core/ledger/kvledger/kv_ledger.go)
+func verifyFileHash(dir, file string, expectedHashInHex string) error {
+	filePath := filepath.Join(dir, file)
+	f, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "error while opening the file [%s]", filePath)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, bufio.NewReader(f)); err != nil {
+		return errors.Wrapf(err, "error while reading the file [%s]", filePath)
+	}
+	hashInHex := hex.EncodeToString(hash.Sum(nil))
+	if hashInHex != expectedHashInHex {
+		return errors.Errorf("hash mismatch for file [%s]. Expected hash = [%s], Actual hash = [%s]",
+			file, expectedHashInHex, hashInHex,
+		)
+	}
+	return nil
+}
+
+// scanStateRecords invokes the process function for each record in a pair of state data and metadata files.
+// It returns an error if the records are not in the increasing order of the namespaces and keys, which is the
+// order in which the peer exports them. A missing data file is treated as a file with no records
+func scanStateRecords(
+	snapshotDir, dataFileName, metadataFileName string,
+	process func(namespace string, record *privacyenabledstate.SnapshotRecord) error,
+) error {
+	exists, _, err := fileutil.FileExists(filepath.Join(snapshotDir, dataFileName))
+	if err != nil || !exists {
+		return err
+	}
+	reader, err := privacyenabledstate.NewSnapshotReader(snapshotDir, dataFileName, metadataFileName)
+	if err != nil {
+		return errors.WithMessagef(err, "error while reading the file [%s]", dataFileName)
+	}
+	defer reader.Close()
+
+	var prev *nsKey
+	for {
+		namespace, record, err := reader.Next()
+		if err != nil {
+			return errors.WithMessagef(err, "error while reading the file [%s]", dataFileName)
+		}
+		if record == nil {
+			return nil
+		}
+		curr := &nsKey{namespace: namespace, key: record.Key}
+		if prev != nil && nsKeyCompare(prev, curr) >= 0 {
+			return errors.Errorf("record for namespace [%s] and key [%x] in the file [%s] is out of order",
+				namespace, record.Key, dataFileName,
+			)
+		}
+		if err := process(namespace, record); err != nil {
+			return errors.WithMessagef(err, "invalid record for namespace [%s] and key [%x] in the file [%s]",
+				namespace, record.Key, dataFileName,
+			)
+		}
+		prev = curr
+	}
+}
+
+func verifyPubStateRecord(namespace string, record *privacyenabledstate.SnapshotRecord) error {
+	if strings.Contains(namespace, "$$") {
+		return errors.New("private data namespace in public state")
+	}
+	return verifyRecordVersion(record)
+}
+
+func verifyPvtStateHashRecord(hashedDataNs string, record *privacyenabledstate.SnapshotRecord) error {
+	if _, _, err := decodeHashedDataNs(hashedDataNs); err != nil {
+		return err
+	}
+	if len(record.Key) != sha256.Size {
+		return errors.Errorf("unexpected key hash length [%d]", len(record.Key))
+	}
+	if len(record.Value) != sha256.Size {
+		return errors.Errorf("unexpected value hash length [%d]", len(record.Value))
+	}
+	return verifyRecordVersion(record)
+}
+
+func verifyRecordVersion(record *privacyenabledstate.SnapshotRecord) error {
+	if len(record.Version) == 0 {
+		return errors.New("missing version")
+	}
+	if _, _, err := heightFromBytes(record.Version); err != nil {
+		return errors.WithMessage(err, "malformed version")
+	}
+	return nil
+}
+
+func decodeHashedDataNs(hashedDataNs string) (string, string, error) {
+	strs := strings.SplitN(hashedDataNs, hashedDataNsJoiner, 2)
+	if len(strs) != 2 || strs[0] == "" || strs[1] == "" {
+		return "", "", errors.Errorf("not a valid hashed data namespace [%s]", hashedDataNs)
+	}
+	return strs[0], strs[1], nil
+}
+
+// readTxIDCount returns the number of txids in the snapshot, after checking that the txids data file
+// contains as many txids as recorded in the txids metadata file
+func readTxIDCount(snapshotDir string) (uint64, error) {
+	exists, _, err := fileutil.FileExists(filepath.Join(snapshotDir, txIDsMetadataFileName))
+	if err != nil || !exists {
+		return 0, err
+	}
+	metadataFile, err := snapshot.OpenFile(filepath.Join(snapshotDir, txIDsMetadataFileName), txIDsFileFormat)
+	if err != nil {
+		return 0, errors.WithMessagef(err, "error while opening the file [%s]", txIDsMetadataFileName)
+	}
+	defer metadataFile.Close()
+	numTxIDs, err := metadataFile.DecodeUVarInt()
+	if err != nil {
+		return 0, errors.WithMessagef(err, "error while reading the file [%s]", txIDsMetadataFileName)
+	}
+
+	dataFile, err := snapshot.OpenFile(filepath.Join(snapshotDir, txIDsDataFileName), txIDsFileFormat)
+	if err != nil {
+		return 0, errors.WithMessagef(err, "error while opening the file [%s]", txIDsDataFileName)
+	}
+	defer dataFile.Close()
+	for i := uint64(0); i < numTxIDs; i++ {
+		if _, err := dataFile.DecodeString(); err != nil {
+			return 0, errors.WithMessagef(err, "error while reading txid [%d] of [%d] from the file [%s]", i+1, numTxIDs, txIDsDataFileName)
+		}
+	}
+	if _, err := dataFile.DecodeBytes(); errors.Cause(err) != io.EOF {
+		return 0, errors.Errorf("the file [%s] contains more txids than [%d] recorded in the file [%s]",
+			txIDsDataFileName, numTxIDs, txIDsMetadataFileName,
+		)
+	}
+	return numTxIDs, nil
+}
@@ -0,0 +1,283 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/snapshot"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySnapshot(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "verifysnapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	t.Run("valid-snapshot", func(t *testing.T) {
+		snapshotDir := createTestSnapshot(t, testDir, sampleTestSnapshotRecords())
+		require.NoError(t, VerifySnapshot(snapshotDir))
+	})
+
+	t.Run("valid-snapshot-without-state", func(t *testing.T) {
+		snapshotDir := createTestSnapshot(t, testDir, &testSnapshotRecords{txIDs: []string{"txid1"}})
+		require.NoError(t, VerifySnapshot(snapshotDir))
+	})
+
+	t.Run("missing-metadata-file", func(t *testing.T) {
+		snapshotDir := createTestSnapshot(t, testDir, sampleTestSnapshotRecords())
+		require.NoError(t, os.Remove(filepath.Join(snapshotDir, snapshotAdditionalMetadataFileName)))
+		err := VerifySnapshot(snapshotDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while reading the file")
+	})
+
+	t.Run("tampered-signable-metadata", func(t *testing.T) {
+		snapshotDir := createTestSnapshot(t, testDir, sampleTestSnapshotRecords())
+		signableMetadataFilePath := filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName)
+		require.NoError(t, os.Chmod(signableMetadataFilePath, 0o644))
+		require.NoError(t, ioutil.WriteFile(signableMetadataFilePath, []byte(`{"channel_name":"mychannel"}`), 0o644))
+		err := VerifySnapshot(snapshotDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "hash mismatch for file [_snapshot_signable_metadata.json]")
+	})
+
+	t.Run("tampered-data-file", func(t *testing.T) {
+		snapshotDir := createTestSnapshot(t, testDir, sampleTestSnapshotRecords())
+		dataFilePath := filepath.Join(snapshotDir, privacyenabledstate.PubStateDataFileName)
+		require.NoError(t, os.Chmod(dataFilePath, 0o644))
+		f, err := os.OpenFile(dataFilePath, os.O_APPEND|os.O_WRONLY, 0o644)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("extra-bytes"))
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		err = VerifySnapshot(snapshotDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "hash mismatch for file [public_state.data]")
+	})
+
+	t.Run("out-of-order-records", func(t *testing.T) {
+		records := sampleTestSnapshotRecords()
+		records.pubState[0], records.pubState[1] = records.pubState[1], records.pubState[0]
+		snapshotDir := createTestSnapshot(t, testDir, records)
+		err := VerifySnapshot(snapshotDir)
+		require.EqualError(t, err, "record for namespace [ns1] and key [6b31] in the file [public_state.data] is out of order")
+	})
+
+	t.Run("malformed-version", func(t *testing.T) {
+		records := sampleTestSnapshotRecords()
+		records.pubState[0].Version = nil
+		snapshotDir := createTestSnapshot(t, testDir, records)
+		err := VerifySnapshot(snapshotDir)
+		require.EqualError(t, err, "invalid record for namespace [ns1] and key [6b31] in the file [public_state.data]: missing version")
+	})
+
+	t.Run("private-data-namespace-in-public-state", func(t *testing.T) {
+		records := sampleTestSnapshotRecords()
+		records.pubState[2].namespace = "ns2$$pcoll1"
+		snapshotDir := createTestSnapshot(t, testDir, records)
+		err := VerifySnapshot(snapshotDir)
+		require.EqualError(t, err, "invalid record for namespace [ns2$$pcoll1] and key [6b31] in the file [public_state.data]: private data namespace in public state")
+	})
+
+	t.Run("malformed-hashed-data-namespace", func(t *testing.T) {
+		records := sampleTestSnapshotRecords()
+		records.pvtStateHashes[2].namespace = "ns2"
+		snapshotDir := createTestSnapshot(t, testDir, records)
+		err := VerifySnapshot(snapshotDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not a valid hashed data namespace [ns2]")
+	})
+
+	t.Run("malformed-key-hash", func(t *testing.T) {
+		records := sampleTestSnapshotRecords()
+		records.pvtStateHashes[0].Key = []byte("short-key-hash")
+		snapshotDir := createTestSnapshot(t, testDir, records)
+		err := VerifySnapshot(snapshotDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unexpected key hash length [14]")
+	})
+
+	t.Run("txids-count-mismatch", func(t *testing.T) {
+		records := sampleTestSnapshotRecords()
+		records.numTxIDsInMetadata = 1
+		snapshotDir := createTestSnapshot(t, testDir, records)
+		err := VerifySnapshot(snapshotDir)
+		require.EqualError(t, err, "the file [txids.data] contains more txids than [1] recorded in the file [txids.metadata]")
+
+		records.numTxIDsInMetadata = 5
+		snapshotDir = createTestSnapshot(t, testDir, records)
+		err = VerifySnapshot(snapshotDir)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "error while reading txid [4] of [5] from the file [txids.data]")
+	})
+}
+
+func TestInspectSnapshot(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "inspectsnapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	snapshotDir := createTestSnapshot(t, testDir, sampleTestSnapshotRecords())
+	summary, err := InspectSnapshot(snapshotDir)
+	require.NoError(t, err)
+	require.Equal(t,
+		&SnapshotSummary{
+			ChannelName:        "mychannel",
+			LastBlockNumber:    10,
+			LastBlockHashInHex: "lastBlockHash",
+			StateDBType:        "SimpleKeyValueDB",
+			TxIDCount:          3,
+			Namespaces: []*NamespaceSummary{
+				{
+					Namespace: "ns1",
+					KeyCount:  2,
+					CollectionHashCounts: map[string]uint64{
+						"coll1": 2,
+					},
+				},
+				{
+					Namespace: "ns2",
+					KeyCount:  1,
+				},
+				{
+					Namespace: "ns3",
+					CollectionHashCounts: map[string]uint64{
+						"coll1": 1,
+					},
+				},
+			},
+		},
+		summary,
+	)
+
+	emptySnapshotDir := createTestSnapshot(t, testDir, &testSnapshotRecords{})
+	summary, err = InspectSnapshot(emptySnapshotDir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), summary.TxIDCount)
+	require.Empty(t, summary.Namespaces)
+
+	_, err = InspectSnapshot(filepath.Join(testDir, "non-existent-dir"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error while reading the file")
+}
+
+type testSnapshotRecord struct {
+	namespace string
+	*privacyenabledstate.SnapshotRecord
+}
+
+type testSnapshotRecords struct {
+	pubState           []*testSnapshotRecord
+	pvtStateHashes     []*testSnapshotRecord
+	txIDs              []string
+	numTxIDsInMetadata int
+}
+
+func sampleTestSnapshotRecords() *testSnapshotRecords {
+	hash := func(s string) []byte {
+		h := sha256.Sum256([]byte(s))
+		return h[:]
+	}
+	keyHash1, keyHash2 := hash("k1"), hash("k2")
+	if hex.EncodeToString(keyHash1) > hex.EncodeToString(keyHash2) {
+		keyHash1, keyHash2 = keyHash2, keyHash1
+	}
+	return &testSnapshotRecords{
+		pubState: []*testSnapshotRecord{
+			{"ns1", &privacyenabledstate.SnapshotRecord{Key: []byte("k1"), Value: []byte("v1"), Version: toBytes(1, 1)}},
+			{"ns1", &privacyenabledstate.SnapshotRecord{Key: []byte("k2"), Value: []byte("v2"), Version: toBytes(1, 2)}},
+			{"ns2", &privacyenabledstate.SnapshotRecord{Key: []byte("k1"), Value: []byte("v3"), Version: toBytes(2, 1)}},
+		},
+		pvtStateHashes: []*testSnapshotRecord{
+			{"ns1$$hcoll1", &privacyenabledstate.SnapshotRecord{Key: keyHash1, Value: hash("v1"), Version: toBytes(3, 1)}},
+			{"ns1$$hcoll1", &privacyenabledstate.SnapshotRecord{Key: keyHash2, Value: hash("v2"), Version: toBytes(3, 2)}},
+			{"ns3$$hcoll1", &privacyenabledstate.SnapshotRecord{Key: keyHash1, Value: hash("v3"), Version: toBytes(4, 1)}},
+		},
+		txIDs: []string{"txid1", "txid2", "txid3"},
+	}
+}
+
+// createTestSnapshot creates a snapshot dir under the testDir with the files for the supplied records,
+// the signable metadata file that contains the hashes of the files, and the additional metadata file
+func createTestSnapshot(t *testing.T, testDir string, records *testSnapshotRecords) string {
+	snapshotDir, err := ioutil.TempDir(testDir, "snapshot")
+	require.NoError(t, err)
+	filesAndHashes := map[string]string{}
+
+	writeStateFiles := func(dataFileName, metadataFileName string, records []*testSnapshotRecord) {
+		if len(records) == 0 {
+			return
+		}
+		writer, err := privacyenabledstate.NewSnapshotWriter(snapshotDir, dataFileName, metadataFileName, testNewHashFunc)
+		require.NoError(t, err)
+		defer writer.Close()
+		for _, r := range records {
+			require.NoError(t, writer.AddData(r.namespace, r.SnapshotRecord))
+		}
+		dataHash, metadataHash, err := writer.Done()
+		require.NoError(t, err)
+		filesAndHashes[dataFileName] = hex.EncodeToString(dataHash)
+		filesAndHashes[metadataFileName] = hex.EncodeToString(metadataHash)
+	}
+	writeStateFiles(privacyenabledstate.PubStateDataFileName, privacyenabledstate.PubStateMetadataFileName, records.pubState)
+	writeStateFiles(privacyenabledstate.PvtStateHashesFileName, privacyenabledstate.PvtStateHashesMetadataFileName, records.pvtStateHashes)
+
+	if len(records.txIDs) > 0 {
+		dataFile, err := snapshot.CreateFile(filepath.Join(snapshotDir, txIDsDataFileName), txIDsFileFormat, testNewHashFunc)
+		require.NoError(t, err)
+		defer dataFile.Close()
+		for _, txID := range records.txIDs {
+			require.NoError(t, dataFile.EncodeString(txID))
+		}
+		dataHash, err := dataFile.Done()
+		require.NoError(t, err)
+
+		numTxIDs := records.numTxIDsInMetadata
+		if numTxIDs == 0 {
+			numTxIDs = len(records.txIDs)
+		}
+		metadataFile, err := snapshot.CreateFile(filepath.Join(snapshotDir, txIDsMetadataFileName), txIDsFileFormat, testNewHashFunc)
+		require.NoError(t, err)
+		defer metadataFile.Close()
+		require.NoError(t, metadataFile.EncodeUVarint(uint64(numTxIDs)))
+		metadataHash, err := metadataFile.Done()
+		require.NoError(t, err)
+
+		filesAndHashes[txIDsDataFileName] = hex.EncodeToString(dataHash)
+		filesAndHashes[txIDsMetadataFileName] = hex.EncodeToString(metadataHash)
+	}
+
+	signableMetadata := &kvledger.SnapshotSignableMetadata{
+		ChannelName:            "mychannel",
+		LastBlockNumber:        10,
+		LastBlockHashInHex:     "lastBlockHash",
+		PreviousBlockHashInHex: "previousBlockHash",
+		FilesAndHashes:         filesAndHashes,
+		StateDBType:            "SimpleKeyValueDB",
+	}
+	signableMetadataBytes, err := signableMetadata.ToJSON()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, kvledger.SnapshotSignableMetadataFileName), signableMetadataBytes, 0o444))
+
+	signableMetadataHash := sha256.Sum256(signableMetadataBytes)
+	additionalMetadataBytes, err := json.Marshal(map[string]string{
+		"snapshot_hash":          hex.EncodeToString(signableMetadataHash[:]),
+		"last_block_commit_hash": "lastBlockCommitHash",
+	})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(snapshotDir, snapshotAdditionalMetadataFileName), additionalMetadataBytes, 0o444))
+	return snapshotDir
+}
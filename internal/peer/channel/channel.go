@@ -37,6 +37,8 @@ var (
 
 	// joinbysnapshot related variables
 	snapshotPath string
+	fromPeer     string
+	snapshotHash string
 
 	// create related variables
 	channelID     string
@@ -82,6 +84,8 @@ func resetFlags() {
 
 	flags.StringVarP(&genesisBlockPath, "blockpath", "b", common.UndefinedParamValue, "Path to file containing genesis block")
 	flags.StringVarP(&snapshotPath, "snapshotpath", "", common.UndefinedParamValue, "Path to the snapshot directory")
+	flags.StringVarP(&fromPeer, "from-peer", "", common.UndefinedParamValue, "Endpoint of a peer of the organization to fetch the snapshot from, instead of using a snapshot directory")
+	flags.StringVarP(&snapshotHash, "snapshot-hash", "", common.UndefinedParamValue, "Hex encoded hash of the signable metadata of the snapshot to fetch with --from-peer")
 	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "In case of a newChain command, the channel ID to create. It must be all lower case, less than 250 characters long and match the regular expression: [a-z][a-z0-9.-]*")
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package channel
 
 import (
+	"encoding/hex"
 	"errors"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
@@ -20,13 +21,16 @@ func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
 	joinbysnapshotCmd := &cobra.Command{
 		Use:   "joinbysnapshot",
 		Short: "Joins the peer to a channel by the specified snapshot",
-		Long:  "Joins the peer to a channel by the specified snapshot",
+		Long:  "Joins the peer to a channel by the specified snapshot, either from a snapshot directory on the peer or from a snapshot fetched from another peer of the organization",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return joinBySnapshot(cmd, args, cf)
 		},
 	}
 	flagList := []string{
 		"snapshotpath",
+		"from-peer",
+		"channelID",
+		"snapshot-hash",
 	}
 	attachFlags(joinbysnapshotCmd, flagList)
 
@@ -34,14 +38,14 @@ func joinBySnapshotCmd(cf *ChannelCmdFactory) *cobra.Command {
 }
 
 func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
-	if snapshotPath == common.UndefinedParamValue {
-		return errors.New("the required parameter 'snapshotpath' is empty. Rerun the command with --snapshotpath flag")
+	input, err := joinBySnapshotInput()
+	if err != nil {
+		return err
 	}
 
 	// Parsing of the command line is done so silence cmd usage
 	cmd.SilenceUsage = true
 
-	var err error
 	if cf == nil {
 		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
 		if err != nil {
@@ -52,7 +56,7 @@ func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) er
 	spec := &pb.ChaincodeSpec{
 		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
 		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
-		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(snapshotPath)}},
+		Input:       input,
 	}
 
 	if err = executeJoin(cf, spec); err != nil {
@@ -62,3 +66,35 @@ func joinBySnapshot(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) er
 	logger.Info(`The joinbysnapshot operation is in progress. Use "peer channel joinbysnapshotstatus" to check the status.`)
 	return nil
 }
+
+// joinBySnapshotInput returns the input of the cscc invocation which joins the
+// peer by a snapshot directory, or by a snapshot fetched from another peer
+func joinBySnapshotInput() (*pb.ChaincodeInput, error) {
+	if fromPeer == common.UndefinedParamValue {
+		if snapshotPath == common.UndefinedParamValue {
+			return nil, errors.New("the required parameter 'snapshotpath' is empty. Rerun the command with --snapshotpath flag")
+		}
+		return &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChainBySnapshot), []byte(snapshotPath)}}, nil
+	}
+
+	if snapshotPath != common.UndefinedParamValue {
+		return nil, errors.New("the parameters 'snapshotpath' and 'from-peer' are mutually exclusive")
+	}
+	if channelID == common.UndefinedParamValue {
+		return nil, errors.New("the required parameter 'channelID' is empty. Rerun the command with -c flag")
+	}
+	if snapshotHash == common.UndefinedParamValue {
+		return nil, errors.New("the required parameter 'snapshot-hash' is empty. Rerun the command with --snapshot-hash flag")
+	}
+	hash, err := hex.DecodeString(snapshotHash)
+	if err != nil {
+		return nil, errors.New("the parameter 'snapshot-hash' must be hex encoded")
+	}
+
+	return &pb.ChaincodeInput{Args: [][]byte{
+		[]byte(cscc.JoinChainBySnapshotFromPeer),
+		[]byte(channelID),
+		hash,
+		[]byte(fromPeer),
+	}}, nil
+}
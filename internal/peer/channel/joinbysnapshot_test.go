@@ -49,6 +49,41 @@ func TestJoinBySnapshot(t *testing.T) {
 	cmd.SetArgs([]string{})
 	require.EqualError(t, cmd.Execute(), "the required parameter 'snapshotpath' is empty. Rerun the command with --snapshotpath flag")
 
+	// successful test fetching the snapshot from another peer
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--from-peer", "peer1:7051", "-c", "mychannel", "--snapshot-hash", "0a0b"})
+	require.NoError(t, cmd.Execute())
+
+	// error due to both snapshotpath and from-peer
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--from-peer", "peer1:7051", "--snapshotpath", "snapshot_path"})
+	require.EqualError(t, cmd.Execute(), "the parameters 'snapshotpath' and 'from-peer' are mutually exclusive")
+
+	// error due to missing channelID with from-peer
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--from-peer", "peer1:7051", "--snapshot-hash", "0a0b"})
+	require.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -c flag")
+
+	// error due to missing snapshot-hash with from-peer
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--from-peer", "peer1:7051", "-c", "mychannel"})
+	require.EqualError(t, cmd.Execute(), "the required parameter 'snapshot-hash' is empty. Rerun the command with --snapshot-hash flag")
+
+	// error due to invalid snapshot-hash
+	resetFlags()
+	cmd = joinBySnapshotCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"--from-peer", "peer1:7051", "-c", "mychannel", "--snapshot-hash", "xyz"})
+	require.EqualError(t, cmd.Execute(), "the parameter 'snapshot-hash' must be hex encoded")
+
 	// error due to EndoserClient returning bad response
 	mockResponse.Response = &pb.Response{Status: 500}
 	resetFlags()
@@ -70,3 +105,21 @@ func TestJoinBySnapshot(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "endorser client failed to connect to")
 }
+
+func TestJoinBySnapshotInput(t *testing.T) {
+	defer resetFlags()
+
+	resetFlags()
+	snapshotPath = "snapshot_path"
+	input, err := joinBySnapshotInput()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("JoinChainBySnapshot"), []byte("snapshot_path")}, input.Args)
+
+	resetFlags()
+	fromPeer = "peer1:7051"
+	channelID = "mychannel"
+	snapshotHash = "0a0b"
+	input, err = joinBySnapshotInput()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("JoinChainBySnapshotFromPeer"), []byte("mychannel"), {0x0a, 0x0b}, []byte("peer1:7051")}, input.Args)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	common2 "github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/msgs"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/spf13/cobra"
@@ -55,11 +56,14 @@ func joinBySnapshotStatus(cf *ChannelCmdFactory) error {
 	} else {
 		fmt.Println("No joinbysnapshot operation is in progress")
 	}
+	if status.LastError != "" {
+		fmt.Printf("The last joinbysnapshot operation failed: %s\n", status.LastError)
+	}
 
 	return nil
 }
 
-func (cc *endorserClient) joinBySnapshotStatus() (*pb.JoinBySnapshotStatus, error) {
+func (cc *endorserClient) joinBySnapshotStatus() (*msgs.JoinBySnapshotStatus, error) {
 	var err error
 
 	invocation := &pb.ChaincodeInvocationSpec{
@@ -92,7 +96,7 @@ func (cc *endorserClient) joinBySnapshotStatus() (*pb.JoinBySnapshotStatus, erro
 		return nil, fmt.Errorf("received bad response, status %d: %s", proposalResp.Response.Status, proposalResp.Response.Message)
 	}
 
-	joinbysnapshotStatus := &pb.JoinBySnapshotStatus{}
+	joinbysnapshotStatus := &msgs.JoinBySnapshotStatus{}
 	err = proto.Unmarshal(proposalResp.Response.Payload, joinbysnapshotStatus)
 	if err != nil {
 		return nil, fmt.Errorf("cannot query joinbysnapshot status, due to %s", err)
@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/msgs"
 	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/spf13/viper"
@@ -26,7 +27,7 @@ func TestJoinBySnapshotStatus(t *testing.T) {
 	signer, err := common.GetDefaultSigner()
 	require.NoError(t, err)
 
-	joinBySnapshotStatus := &pb.JoinBySnapshotStatus{InProgress: false, BootstrappingSnapshotDir: ""}
+	joinBySnapshotStatus := &msgs.JoinBySnapshotStatus{InProgress: false, BootstrappingSnapshotDir: ""}
 	mockResponse := &pb.ProposalResponse{
 		Response:    &pb.Response{Status: 200, Payload: protoutil.MarshalOrPanic(joinBySnapshotStatus)},
 		Endorsement: &pb.Endorsement{},
@@ -50,12 +51,23 @@ func TestJoinBySnapshotStatus(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, proto.Equal(joinBySnapshotStatus, status))
 
-	joinBySnapshotStatus = &pb.JoinBySnapshotStatus{InProgress: true, BootstrappingSnapshotDir: "mock_snapshot_directory"}
+	joinBySnapshotStatus = &msgs.JoinBySnapshotStatus{InProgress: true, BootstrappingSnapshotDir: "mock_snapshot_directory"}
 	mockResponse.Response.Payload = protoutil.MarshalOrPanic(joinBySnapshotStatus)
 	status, err = client.joinBySnapshotStatus()
 	require.NoError(t, err)
 	require.True(t, proto.Equal(joinBySnapshotStatus, status))
 
+	// the failure of the last operation is reported
+	joinBySnapshotStatus = &msgs.JoinBySnapshotStatus{LastError: "error fetching snapshot: timeout"}
+	mockResponse.Response.Payload = protoutil.MarshalOrPanic(joinBySnapshotStatus)
+	status, err = client.joinBySnapshotStatus()
+	require.NoError(t, err)
+	require.True(t, proto.Equal(joinBySnapshotStatus, status))
+	resetFlags()
+	cmd = joinBySnapshotStatusCmd(mockCF)
+	AddFlags(cmd)
+	require.NoError(t, cmd.Execute())
+
 	// negative test due to EndoserClient returning bad response
 	mockResponse.Response = &pb.Response{Status: 500, Message: "mock_bad_response"}
 	resetFlags()
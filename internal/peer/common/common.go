@@ -339,11 +339,21 @@ func InitCmd(cmd *cobra.Command, args []string) {
 	})
 
 	// chaincode packaging does not require material from the local MSP
-	if cmd.CommandPath() == "peer lifecycle chaincode package" {
+	// unless the package is signed
+	sign, _ := cmd.Flags().GetBool("sign")
+	if cmd.CommandPath() == "peer lifecycle chaincode package" && !sign {
 		mainLogger.Debug("peer lifecycle chaincode package does not need to init crypto")
 		return
 	}
 
+	// chaincode logs and the gossip status are retrieved from the operations
+	// endpoint, which does not use the local MSP
+	switch cmd.CommandPath() {
+	case "peer lifecycle chaincode logs", "peer node gossip-status", "peer node leadership", "peer node reconcile":
+		mainLogger.Debugf("%s does not need to init crypto", cmd.CommandPath())
+		return
+	}
+
 	// Init the MSP
 	mspMgrConfigDir := config.GetPath("peer.mspConfigPath")
 	mspID := viper.GetString("peer.localMspId")
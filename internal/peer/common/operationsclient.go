@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// OperationsClientConfig holds the information needed to connect to the
+// operations endpoint of a peer.
+type OperationsClientConfig struct {
+	Address      string
+	TLSEnabled   bool
+	RootCertFile string
+	CertFile     string
+	KeyFile      string
+}
+
+// AddOperationsFlags adds the flags used to connect to the operations
+// endpoint of a peer to the flag set.
+func AddOperationsFlags(flags *pflag.FlagSet, config *OperationsClientConfig) {
+	flags.StringVarP(&config.Address, "operations-address", "", "",
+		"The address of the peer's operations endpoint. Defaults to operations.listenAddress from the peer configuration")
+	flags.BoolVarP(&config.TLSEnabled, "operations-tls", "", false,
+		"Use TLS when communicating with the peer's operations endpoint. Defaults to operations.tls.enabled from the peer configuration")
+	flags.StringVarP(&config.RootCertFile, "operations-cafile", "", "",
+		"Path to file containing PEM-encoded trusted certificate(s) for the peer's operations endpoint")
+	flags.StringVarP(&config.CertFile, "operations-certfile", "", "",
+		"Path to file containing PEM-encoded X509 public key to use for mutual TLS communication with the peer's operations endpoint")
+	flags.StringVarP(&config.KeyFile, "operations-keyfile", "", "",
+		"Path to file containing PEM-encoded private key to use for mutual TLS communication with the peer's operations endpoint")
+}
+
+// OperationsClient issues requests to the operations endpoint of a peer.
+type OperationsClient struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewOperationsClient creates an OperationsClient from the configuration.
+// When no address is configured, the operations endpoint address and TLS
+// setting of the peer configuration are used.
+func NewOperationsClient(config OperationsClientConfig) (*OperationsClient, error) {
+	if config.Address == "" {
+		config.Address = viper.GetString("operations.listenAddress")
+		config.TLSEnabled = config.TLSEnabled || viper.GetBool("operations.tls.enabled")
+	}
+	if config.Address == "" {
+		return nil, errors.New("the operations endpoint address must be specified")
+	}
+
+	if !config.TLSEnabled {
+		return &OperationsClient{
+			BaseURL: "http://" + config.Address,
+			Client:  &http.Client{},
+		}, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if config.RootCertFile != "" {
+		caPEM, err := ioutil.ReadFile(config.RootCertFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read operations root certificate file '%s'", config.RootCertFile)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificates found in operations root certificate file '%s'", config.RootCertFile)
+		}
+	}
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load operations client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &OperationsClient{
+		BaseURL: "https://" + config.Address,
+		Client: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+// Get issues a GET request for the path of the operations endpoint. The
+// response is returned when the request succeeds. Otherwise the error
+// reported by the operations endpoint is returned.
+func (o *OperationsClient) Get(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	target := strings.TrimSuffix(o.BaseURL, "/") + path
+	if len(query) != 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create operations request")
+	}
+	return o.do(req)
+}
+
+// Put issues a PUT request for the path of the operations endpoint with
+// the JSON encoding of the payload as body. The response is returned when
+// the request succeeds. Otherwise the error reported by the operations
+// endpoint is returned.
+func (o *OperationsClient) Put(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	return o.send(ctx, http.MethodPut, path, payload)
+}
+
+// Post issues a POST request for the path of the operations endpoint with
+// the JSON encoding of the payload as body. The response is returned when
+// the request succeeds. Otherwise the error reported by the operations
+// endpoint is returned.
+func (o *OperationsClient) Post(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	return o.send(ctx, http.MethodPost, path, payload)
+}
+
+func (o *OperationsClient) send(ctx context.Context, method, path string, payload interface{}) (*http.Response, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal operations request")
+	}
+	target := strings.TrimSuffix(o.BaseURL, "/") + path
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create operations request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return o.do(req)
+}
+
+func (o *OperationsClient) do(req *http.Request) (*http.Response, error) {
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "operations request failed")
+	}
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "operations request failed with status %d", resp.StatusCode)
+	}
+	errorResponse := &struct {
+		Error string `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, errorResponse); err != nil || errorResponse.Error == "" {
+		return nil, errors.Errorf("operations request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil, errors.Errorf("operations request failed with status %d: %s", resp.StatusCode, errorResponse.Error)
+}
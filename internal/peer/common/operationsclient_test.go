@@ -0,0 +1,211 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+package common_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationsClientGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.Write([]byte(r.URL.Query().Get("key")))
+		case "/json-error":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not here"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("broken\n"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := common.NewOperationsClient(common.OperationsClientConfig{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.URL, client.BaseURL)
+
+	resp, err := client.Get(context.Background(), "/ok", url.Values{"key": []string{"value"}})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "value", string(body))
+
+	_, err = client.Get(context.Background(), "/json-error", nil)
+	require.EqualError(t, err, "operations request failed with status 404: not here")
+
+	_, err = client.Get(context.Background(), "/other", nil)
+	require.EqualError(t, err, "operations request failed with status 500: broken")
+}
+
+func TestOperationsClientPut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"not a put"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/ok":
+			body, _ := ioutil.ReadAll(r.Body)
+			w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+			w.Write(body)
+		case "/no-content":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"bad request"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := common.NewOperationsClient(common.OperationsClientConfig{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+	require.NoError(t, err)
+
+	resp, err := client.Put(context.Background(), "/ok", map[string]string{"key": "value"})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"key":"value"}`, string(body))
+
+	resp, err = client.Put(context.Background(), "/no-content", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	_, err = client.Put(context.Background(), "/other", nil)
+	require.EqualError(t, err, "operations request failed with status 400: bad request")
+
+	_, err = client.Put(context.Background(), "/ok", func() {})
+	require.EqualError(t, err, "failed to marshal operations request: json: unsupported type: func()")
+}
+
+func TestOperationsClientPost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"not a post"}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client, err := common.NewOperationsClient(common.OperationsClientConfig{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+	require.NoError(t, err)
+
+	resp, err := client.Post(context.Background(), "/ok", map[string]string{"key": "value"})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"key":"value"}`, string(body))
+
+	_, err = client.Put(context.Background(), "/ok", nil)
+	require.EqualError(t, err, "operations request failed with status 400: not a post")
+}
+
+func TestOperationsClientTLS(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverCert, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientCert, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Len(t, r.TLS.PeerCertificates, 1)
+	}))
+	cert, err := tls.X509KeyPair(serverCert.Cert, serverCert.Key)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(ca.CertBytes())
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, data, 0o600))
+		return path
+	}
+
+	client, err := common.NewOperationsClient(common.OperationsClientConfig{
+		Address:      strings.TrimPrefix(server.URL, "https://"),
+		TLSEnabled:   true,
+		RootCertFile: writeFile("ca.pem", ca.CertBytes()),
+		CertFile:     writeFile("client.pem", clientCert.Cert),
+		KeyFile:      writeFile("client.key", clientCert.Key),
+	})
+	require.NoError(t, err)
+	require.Equal(t, server.URL, client.BaseURL)
+
+	resp, err := client.Get(context.Background(), "/", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = common.NewOperationsClient(common.OperationsClientConfig{
+		Address:      "127.0.0.1:9443",
+		TLSEnabled:   true,
+		RootCertFile: writeFile("empty.pem", []byte("no certs")),
+	})
+	require.EqualError(t, err, "no certificates found in operations root certificate file '"+filepath.Join(dir, "empty.pem")+"'")
+
+	_, err = common.NewOperationsClient(common.OperationsClientConfig{
+		Address:    "127.0.0.1:9443",
+		TLSEnabled: true,
+		CertFile:   filepath.Join(dir, "missing.pem"),
+		KeyFile:    filepath.Join(dir, "missing.key"),
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to load operations client certificate")
+}
+
+func TestOperationsClientDefaults(t *testing.T) {
+	defer viper.Reset()
+
+	_, err := common.NewOperationsClient(common.OperationsClientConfig{})
+	require.EqualError(t, err, "the operations endpoint address must be specified")
+
+	viper.Set("operations.listenAddress", "127.0.0.1:9443")
+	viper.Set("operations.tls.enabled", true)
+	client, err := common.NewOperationsClient(common.OperationsClientConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "https://127.0.0.1:9443", client.BaseURL)
+}
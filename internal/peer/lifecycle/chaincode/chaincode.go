@@ -34,14 +34,18 @@ func Cmd(cryptoProvider bccsp.BCCSP) *cobra.Command {
 	addFlags(chaincodeCmd)
 
 	chaincodeCmd.AddCommand(PackageCmd(nil))
+	chaincodeCmd.AddCommand(SignPackageCmd(nil))
 	chaincodeCmd.AddCommand(InstallCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(QueryInstalledCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(GetInstalledPackageCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(UninstallCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(ApproveForMyOrgCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(QueryApprovedCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(CheckCommitReadinessCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(CommitCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(QueryCommittedCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(DiffCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(LogsCmd(nil))
 
 	return chaincodeCmd
 }
@@ -70,12 +74,19 @@ var (
 	initRequired          bool
 	output                string
 	outputDirectory       string
+	force                 bool
+	signPackage           bool
+	followLogs            bool
+	definitionHistory     bool
+	fromSequence          int
+	toSequence            int
+	operationsConfig      common.OperationsClientConfig
 )
 
 var chaincodeCmd = &cobra.Command{
 	Use:   "chaincode",
-	Short: "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted",
-	Long:  "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted",
+	Short: "Perform chaincode operations: package|signpackage|install|queryinstalled|getinstalledpackage|uninstall|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted|diff|logs",
+	Long:  "Perform chaincode operations: package|signpackage|install|queryinstalled|getinstalledpackage|uninstall|approveformyorg|queryapproved|checkcommitreadiness|commit|querycommitted|diff|logs",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -118,6 +129,13 @@ func ResetFlags() {
 	flags.IntVarP(&sequence, "sequence", "", 0, "The sequence number of the chaincode definition for the channel")
 	flags.BoolVarP(&initRequired, "init-required", "", false, "Whether the chaincode requires invoking 'init'")
 	flags.StringVarP(&output, "output", "O", "", "The output format for query results. Default is human-readable plain-text. json is currently the only supported format.")
+	flags.BoolVarP(&force, "force", "", false, "Uninstall the chaincode package even if it is referenced by a chaincode definition on a channel joined by the peer")
+	flags.BoolVarP(&signPackage, "sign", "", false, "Sign the chaincode package with the local MSP identity")
+	flags.BoolVarP(&followLogs, "follow", "f", false, "Continue to write the output of the chaincode as it is written")
+	flags.BoolVarP(&definitionHistory, "history", "", false, "Query every chaincode definition committed for the chaincode")
+	flags.IntVarP(&fromSequence, "from-seq", "", 0, "The sequence number of the chaincode definition to compare from")
+	flags.IntVarP(&toSequence, "to-seq", "", 0, "The sequence number of the chaincode definition to compare to. Default is the current sequence.")
+	common.AddOperationsFlags(flags, &operationsConfig)
 	flags.StringVarP(&outputDirectory, "output-directory", "", "", "The output directory to use when writing a chaincode install package to disk. Default is the current working directory.")
 }
 
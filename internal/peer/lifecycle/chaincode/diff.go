@@ -0,0 +1,286 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/msgs"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// DefinitionDiffer holds the dependencies needed to compare
+// two committed chaincode definitions
+type DefinitionDiffer struct {
+	Command        *cobra.Command
+	Input          *DiffInput
+	EndorserClient EndorserClient
+	Signer         Signer
+	Writer         io.Writer
+}
+
+// DiffInput holds all of the input parameters for comparing
+// two committed chaincode definitions. A ToSequence of zero
+// refers to the current definition.
+type DiffInput struct {
+	ChannelID    string
+	Name         string
+	FromSequence int64
+	ToSequence   int64
+}
+
+// Validate the input for comparing two committed chaincode definitions
+func (d *DiffInput) Validate() error {
+	if d.ChannelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+
+	if d.Name == "" {
+		return errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
+	}
+
+	if d.FromSequence <= 0 {
+		return errors.New("The required parameter 'from-seq' is empty. Rerun the command with --from-seq flag")
+	}
+
+	if d.ToSequence < 0 {
+		return errors.Errorf("invalid to-seq %d", d.ToSequence)
+	}
+
+	return nil
+}
+
+// DiffCmd returns the cobra command for comparing two
+// committed chaincode definitions
+func DiffCmd(d *DefinitionDiffer, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	chaincodeDiffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare two committed chaincode definitions by sequence on a peer.",
+		Long:  "Compare the chaincode definitions committed at two sequences for a chaincode on a channel, using the definition history of a peer.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if d == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					ChannelID:             channelID,
+					PeerAddresses:         peerAddresses,
+					TLSRootCertFiles:      tlsRootCertFiles,
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+
+				d = &DefinitionDiffer{
+					Command:        cmd,
+					EndorserClient: cc.EndorserClients[0],
+					Input: &DiffInput{
+						ChannelID:    channelID,
+						Name:         chaincodeName,
+						FromSequence: int64(fromSequence),
+						ToSequence:   int64(toSequence),
+					},
+					Signer: cc.Signer,
+					Writer: os.Stdout,
+				}
+			}
+			return d.Diff()
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"name",
+		"from-seq",
+		"to-seq",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeDiffCmd, flagList)
+
+	return chaincodeDiffCmd
+}
+
+// Diff writes the differences between the chaincode
+// definitions committed at the two sequences
+func (d *DefinitionDiffer) Diff() error {
+	if d.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		d.Command.SilenceUsage = true
+	}
+
+	if err := d.Input.Validate(); err != nil {
+		return err
+	}
+
+	querier := &CommittedQuerier{
+		Input: &CommittedQueryInput{
+			ChannelID: d.Input.ChannelID,
+			Name:      d.Input.Name,
+			History:   true,
+		},
+		EndorserClient: d.EndorserClient,
+		Signer:         d.Signer,
+	}
+	history, err := querier.QueryHistory()
+	if err != nil {
+		return err
+	}
+
+	definitions := history.ChaincodeDefinitions
+	if len(definitions) == 0 {
+		return errors.Errorf("no chaincode definitions committed for chaincode '%s' on channel '%s'", d.Input.Name, d.Input.ChannelID)
+	}
+
+	toSequence := d.Input.ToSequence
+	if toSequence == 0 {
+		toSequence = definitions[len(definitions)-1].Sequence
+	}
+
+	from, err := d.findDefinition(definitions, d.Input.FromSequence)
+	if err != nil {
+		return err
+	}
+	to, err := d.findDefinition(definitions, toSequence)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(d.Writer, "Differences between sequence %d (block %d, transaction %s) and sequence %d (block %d, transaction %s) of chaincode '%s' on channel '%s':\n",
+		from.Sequence, from.BlockNumber, from.TxId, to.Sequence, to.BlockNumber, to.TxId, d.Input.Name, d.Input.ChannelID)
+
+	differences := definitionDifferences(from, to)
+	if len(differences) == 0 {
+		fmt.Fprintln(d.Writer, "No differences")
+		return nil
+	}
+	for _, difference := range differences {
+		fmt.Fprintln(d.Writer, difference)
+	}
+
+	return nil
+}
+
+func (d *DefinitionDiffer) findDefinition(definitions []*msgs.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition, sequence int64) (*msgs.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition, error) {
+	for _, definition := range definitions {
+		if definition.Sequence == sequence {
+			return definition, nil
+		}
+	}
+	return nil, errors.Errorf("no chaincode definition committed at sequence %d for chaincode '%s' on channel '%s'", sequence, d.Input.Name, d.Input.ChannelID)
+}
+
+func definitionDifferences(from, to *msgs.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition) []string {
+	var differences []string
+	addDifference := func(field string, fromValue, toValue interface{}) {
+		if fromValue != toValue {
+			differences = append(differences, fmt.Sprintf("%s: %v -> %v", field, fromValue, toValue))
+		}
+	}
+
+	addDifference("Version", from.Version, to.Version)
+	addDifference("Endorsement Plugin", from.EndorsementPlugin, to.EndorsementPlugin)
+	addDifference("Validation Plugin", from.ValidationPlugin, to.ValidationPlugin)
+	addDifference("Endorsement Policy", endorsementPolicyString(from.ValidationParameter), endorsementPolicyString(to.ValidationParameter))
+	addDifference("Init Required", from.InitRequired, to.InitRequired)
+	differences = append(differences, collectionDifferences(from.Collections, to.Collections)...)
+	addDifference("Package ID", packageIDString(from.PackageId), packageIDString(to.PackageId))
+
+	orgs := map[string]struct{}{}
+	for org := range from.Approvals {
+		orgs[org] = struct{}{}
+	}
+	for org := range to.Approvals {
+		orgs[org] = struct{}{}
+	}
+	sortedOrgs := make([]string, 0, len(orgs))
+	for org := range orgs {
+		sortedOrgs = append(sortedOrgs, org)
+	}
+	sort.Strings(sortedOrgs)
+	for _, org := range sortedOrgs {
+		addDifference(fmt.Sprintf("Approval of %s", org), from.Approvals[org], to.Approvals[org])
+	}
+
+	return differences
+}
+
+func collectionDifferences(from, to *pb.CollectionConfigPackage) []string {
+	collections := func(ccp *pb.CollectionConfigPackage) map[string]*pb.StaticCollectionConfig {
+		result := map[string]*pb.StaticCollectionConfig{}
+		for _, config := range ccp.GetConfig() {
+			if static := config.GetStaticCollectionConfig(); static != nil {
+				result[static.Name] = static
+			}
+		}
+		return result
+	}
+	fromCollections, toCollections := collections(from), collections(to)
+
+	names := map[string]struct{}{}
+	for name := range fromCollections {
+		names[name] = struct{}{}
+	}
+	for name := range toCollections {
+		names[name] = struct{}{}
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	var differences []string
+	for _, name := range sortedNames {
+		fromCollection, inFrom := fromCollections[name]
+		toCollection, inTo := toCollections[name]
+		switch {
+		case !inFrom:
+			differences = append(differences, fmt.Sprintf("Collection '%s': added", name))
+		case !inTo:
+			differences = append(differences, fmt.Sprintf("Collection '%s': removed", name))
+		case !proto.Equal(fromCollection, toCollection):
+			differences = append(differences, fmt.Sprintf("Collection '%s': changed", name))
+		}
+	}
+	return differences
+}
+
+func endorsementPolicyString(validationParameter []byte) string {
+	policy := &pb.ApplicationPolicy{}
+	if err := proto.Unmarshal(validationParameter, policy); err != nil {
+		return fmt.Sprintf("%x", validationParameter)
+	}
+
+	switch p := policy.Type.(type) {
+	case *pb.ApplicationPolicy_ChannelConfigPolicyReference:
+		return p.ChannelConfigPolicyReference
+	case *pb.ApplicationPolicy_SignaturePolicy:
+		return proto.CompactTextString(p.SignaturePolicy)
+	default:
+		return fmt.Sprintf("%x", validationParameter)
+	}
+}
+
+func packageIDString(packageID string) string {
+	if packageID == "" {
+		return "<none>"
+	}
+	return packageID
+}
@@ -0,0 +1,274 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/msgs"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Diff", func() {
+	Describe("DefinitionDiffer", func() {
+		var (
+			mockResult         *msgs.QueryChaincodeDefinitionHistoryResult
+			mockEndorserClient *mock.EndorserClient
+			mockSigner         *mock.Signer
+			input              *chaincode.DiffInput
+			definitionDiffer   *chaincode.DefinitionDiffer
+		)
+
+		BeforeEach(func() {
+			collection := func(name string, requiredPeerCount int32) *pb.CollectionConfig {
+				return &pb.CollectionConfig{
+					Payload: &pb.CollectionConfig_StaticCollectionConfig{
+						StaticCollectionConfig: &pb.StaticCollectionConfig{
+							Name:              name,
+							RequiredPeerCount: requiredPeerCount,
+						},
+					},
+				}
+			}
+
+			mockResult = &msgs.QueryChaincodeDefinitionHistoryResult{
+				ChaincodeDefinitions: []*msgs.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{
+					{
+						Sequence:          1,
+						Version:           "a-version",
+						EndorsementPlugin: "e-plugin",
+						ValidationPlugin:  "v-plugin",
+						ValidationParameter: protoutil.MarshalOrPanic(&pb.ApplicationPolicy{
+							Type: &pb.ApplicationPolicy_ChannelConfigPolicyReference{
+								ChannelConfigPolicyReference: "/Channel/Application/Endorsement",
+							},
+						}),
+						Collections: &pb.CollectionConfigPackage{
+							Config: []*pb.CollectionConfig{
+								collection("removed", 1),
+								collection("changed", 1),
+								collection("unchanged", 1),
+							},
+						},
+						Approvals: map[string]bool{
+							"org1": true,
+							"org2": false,
+						},
+						BlockNumber: 5,
+						TxId:        "tx-1",
+					},
+					{
+						Sequence:          2,
+						Version:           "a-version",
+						EndorsementPlugin: "e-plugin",
+						ValidationPlugin:  "v-plugin",
+						ValidationParameter: protoutil.MarshalOrPanic(&pb.ApplicationPolicy{
+							Type: &pb.ApplicationPolicy_ChannelConfigPolicyReference{
+								ChannelConfigPolicyReference: "/Channel/Application/Endorsement",
+							},
+						}),
+						Approvals: map[string]bool{
+							"org1": true,
+							"org2": false,
+						},
+						BlockNumber: 7,
+						TxId:        "tx-2",
+					},
+					{
+						Sequence:          3,
+						Version:           "another-version",
+						EndorsementPlugin: "e-plugin",
+						ValidationPlugin:  "v-plugin",
+						ValidationParameter: protoutil.MarshalOrPanic(&pb.ApplicationPolicy{
+							Type: &pb.ApplicationPolicy_ChannelConfigPolicyReference{
+								ChannelConfigPolicyReference: "/Channel/Application/Writers",
+							},
+						}),
+						InitRequired: true,
+						Collections: &pb.CollectionConfigPackage{
+							Config: []*pb.CollectionConfig{
+								collection("added", 1),
+								collection("changed", 2),
+								collection("unchanged", 1),
+							},
+						},
+						Approvals: map[string]bool{
+							"org1": true,
+							"org2": true,
+						},
+						PackageId:   "a-package-id",
+						BlockNumber: 9,
+						TxId:        "tx-3",
+					},
+				},
+			}
+
+			mockEndorserClient = &mock.EndorserClient{}
+			mockEndorserClient.ProcessProposalStub = func(context.Context, *pb.SignedProposal, ...grpc.CallOption) (*pb.ProposalResponse, error) {
+				return &pb.ProposalResponse{
+					Response: &pb.Response{
+						Status:  200,
+						Payload: protoutil.MarshalOrPanic(mockResult),
+					},
+				}, nil
+			}
+
+			mockSigner = &mock.Signer{}
+
+			input = &chaincode.DiffInput{
+				ChannelID:    "test-channel",
+				Name:         "test-cc",
+				FromSequence: 1,
+			}
+
+			definitionDiffer = &chaincode.DefinitionDiffer{
+				Input:          input,
+				EndorserClient: mockEndorserClient,
+				Signer:         mockSigner,
+				Writer:         gbytes.NewBuffer(),
+			}
+		})
+
+		It("writes the differences with the current definition", func() {
+			err := definitionDiffer.Diff()
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QDifferences between sequence 1 (block 5, transaction tx-1) and sequence 3 (block 9, transaction tx-3) of chaincode 'test-cc' on channel 'test-channel':\E\n`))
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QVersion: a-version -> another-version\E\n`))
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QEndorsement Policy: /Channel/Application/Endorsement -> /Channel/Application/Writers\E\n`))
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QInit Required: false -> true\E\n`))
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QCollection 'added': added\E\n`))
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QCollection 'changed': changed\E\n`))
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QCollection 'removed': removed\E\n`))
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QPackage ID: <none> -> a-package-id\E\n`))
+			Eventually(definitionDiffer.Writer).Should(gbytes.Say(`\QApproval of org2: false -> true\E\n`))
+			Expect(definitionDiffer.Writer.(*gbytes.Buffer).Contents()).NotTo(ContainSubstring("unchanged"))
+
+			Expect(mockEndorserClient.ProcessProposalCallCount()).To(Equal(1))
+			_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(0)
+			proposal := &pb.Proposal{}
+			Expect(proto.Unmarshal(signedProposal.ProposalBytes, proposal)).To(Succeed())
+			payload := &pb.ChaincodeProposalPayload{}
+			Expect(proto.Unmarshal(proposal.Payload, payload)).To(Succeed())
+			cis := &pb.ChaincodeInvocationSpec{}
+			Expect(proto.Unmarshal(payload.Input, cis)).To(Succeed())
+			Expect(cis.ChaincodeSpec.Input.Args[0]).To(Equal([]byte("QueryChaincodeDefinitionHistory")))
+			args := &msgs.QueryChaincodeDefinitionHistoryArgs{}
+			Expect(proto.Unmarshal(cis.ChaincodeSpec.Input.Args[1], args)).To(Succeed())
+			Expect(args.Name).To(Equal("test-cc"))
+		})
+
+		Context("when the definitions do not differ", func() {
+			BeforeEach(func() {
+				input.FromSequence = 2
+				input.ToSequence = 2
+			})
+
+			It("writes that there are no differences", func() {
+				err := definitionDiffer.Diff()
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(definitionDiffer.Writer).Should(gbytes.Say("No differences\n"))
+			})
+		})
+
+		Context("when no definition was committed at a sequence", func() {
+			BeforeEach(func() {
+				input.ToSequence = 4
+			})
+
+			It("returns an error", func() {
+				err := definitionDiffer.Diff()
+				Expect(err).To(MatchError("no chaincode definition committed at sequence 4 for chaincode 'test-cc' on channel 'test-channel'"))
+			})
+		})
+
+		Context("when no definitions were committed", func() {
+			BeforeEach(func() {
+				mockResult.ChaincodeDefinitions = nil
+			})
+
+			It("returns an error", func() {
+				err := definitionDiffer.Diff()
+				Expect(err).To(MatchError("no chaincode definitions committed for chaincode 'test-cc' on channel 'test-channel'"))
+			})
+		})
+
+		Context("when the from sequence is not provided", func() {
+			BeforeEach(func() {
+				input.FromSequence = 0
+			})
+
+			It("returns an error", func() {
+				err := definitionDiffer.Diff()
+				Expect(err).To(MatchError("The required parameter 'from-seq' is empty. Rerun the command with --from-seq flag"))
+			})
+		})
+
+		Context("when the chaincode name is not provided", func() {
+			BeforeEach(func() {
+				input.Name = ""
+			})
+
+			It("returns an error", func() {
+				err := definitionDiffer.Diff()
+				Expect(err).To(MatchError("The required parameter 'name' is empty. Rerun the command with -n flag"))
+			})
+		})
+
+		Context("when the endorser fails to endorse the proposal", func() {
+			BeforeEach(func() {
+				mockEndorserClient.ProcessProposalStub = nil
+				mockEndorserClient.ProcessProposalReturns(nil, errors.New("latte"))
+			})
+
+			It("returns an error", func() {
+				err := definitionDiffer.Diff()
+				Expect(err).To(MatchError("failed to endorse proposal: latte"))
+			})
+		})
+	})
+
+	Describe("DiffCmd", func() {
+		var diffCmd *cobra.Command
+
+		BeforeEach(func() {
+			cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+			Expect(err).NotTo(HaveOccurred())
+			diffCmd = chaincode.DiffCmd(nil, cryptoProvider)
+			diffCmd.SilenceErrors = true
+			diffCmd.SilenceUsage = true
+			diffCmd.SetArgs([]string{
+				"--channelID=testchannel",
+				"--name=testcc",
+				"--from-seq=1",
+				"--to-seq=2",
+				"--peerAddresses=test1",
+				"--peerAddresses=test2",
+			})
+		})
+
+		AfterEach(func() {
+			chaincode.ResetFlags()
+		})
+
+		It("returns an error when more than one peer address is provided", func() {
+			err := diffCmd.Execute()
+			Expect(err).To(MatchError("failed to validate peer connection parameters: 'diff' command supports one peer. 2 peers provided"))
+		})
+	})
+})
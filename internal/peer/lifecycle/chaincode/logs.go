@@ -0,0 +1,162 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container/cclogs"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// LogsFetcher holds the dependencies needed to retrieve the output
+// captured for a chaincode from the operations endpoint of a peer.
+type LogsFetcher struct {
+	Command *cobra.Command
+	Input   *LogsInput
+	Client  *common.OperationsClient
+	Writer  io.Writer
+	// RetryInterval is the time to wait before reissuing a followed
+	// request which returned no output.
+	RetryInterval time.Duration
+}
+
+// LogsInput holds all of the input parameters for retrieving
+// the output captured for a chaincode.
+type LogsInput struct {
+	PackageID string
+	Follow    bool
+}
+
+// Validate checks that the required parameters are provided.
+func (l *LogsInput) Validate() error {
+	if l.PackageID == "" {
+		return errors.New("The required parameter 'package-id' is empty. Rerun the command with --package-id flag")
+	}
+
+	return nil
+}
+
+// LogsCmd returns the cobra command for retrieving the output captured
+// for a chaincode.
+func LogsCmd(l *LogsFetcher) *cobra.Command {
+	chaincodeLogsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Retrieve the output of a chaincode from a peer's operations endpoint.",
+		Long:  "Retrieve the output captured for a chaincode package from a peer's operations endpoint. Chaincode log capture must be enabled on the peer.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if l == nil {
+				client, err := common.NewOperationsClient(operationsConfig)
+				if err != nil {
+					return err
+				}
+
+				l = &LogsFetcher{
+					Command: cmd,
+					Input: &LogsInput{
+						PackageID: packageID,
+						Follow:    followLogs,
+					},
+					Client:        client,
+					Writer:        os.Stdout,
+					RetryInterval: time.Second,
+				}
+			}
+			return l.Fetch(context.Background())
+		},
+	}
+
+	flagList := []string{
+		"package-id",
+		"follow",
+		"operations-address",
+		"operations-tls",
+		"operations-cafile",
+		"operations-certfile",
+		"operations-keyfile",
+	}
+	attachFlags(chaincodeLogsCmd, flagList)
+
+	return chaincodeLogsCmd
+}
+
+// Fetch writes the output captured for the chaincode. When following the
+// output, the request is reissued from the last line received whenever the
+// peer ends the response, until the context is done.
+func (l *LogsFetcher) Fetch(ctx context.Context) error {
+	if l.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		l.Command.SilenceUsage = true
+	}
+
+	if err := l.Input.Validate(); err != nil {
+		return err
+	}
+
+	var since uint64
+	for {
+		query := url.Values{}
+		query.Set("packageID", l.Input.PackageID)
+		query.Set("since", strconv.FormatUint(since, 10))
+		query.Set("follow", strconv.FormatBool(l.Input.Follow))
+
+		resp, err := l.Client.Get(ctx, "/chaincode/logs", query)
+		if err != nil && ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return errors.WithMessage(err, "failed to retrieve chaincode logs")
+		}
+		last, err := l.writeLines(resp.Body, since)
+		resp.Body.Close()
+
+		if !l.Input.Follow {
+			if err != nil {
+				return errors.WithMessage(err, "failed to read chaincode logs")
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			logger.Debugf("chaincode logs interrupted, reconnecting: %s", err)
+		}
+		if last == since {
+			select {
+			case <-time.After(l.RetryInterval):
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		since = last
+	}
+}
+
+func (l *LogsFetcher) writeLines(body io.Reader, since uint64) (uint64, error) {
+	decoder := json.NewDecoder(body)
+	for {
+		var line cclogs.Line
+		if err := decoder.Decode(&line); err != nil {
+			if err == io.EOF {
+				return since, nil
+			}
+			return since, err
+		}
+		fmt.Fprintln(l.Writer, line.Text)
+		since = line.Seq
+	}
+}
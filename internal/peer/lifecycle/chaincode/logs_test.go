@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container/cclogs"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/spf13/cobra"
+)
+
+var _ = Describe("Logs", func() {
+	Describe("LogsFetcher", func() {
+		var (
+			store       *cclogs.Store
+			server      *httptest.Server
+			requests    chan string
+			input       *chaincode.LogsInput
+			output      *gbytes.Buffer
+			logsFetcher *chaincode.LogsFetcher
+		)
+
+		BeforeEach(func() {
+			store = cclogs.NewStore(10, 0)
+			store.Append("cc:1", "one")
+			store.Append("cc:1", "two")
+
+			requests = make(chan string, 10)
+			handler := cclogs.NewHandler(store)
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests <- r.URL.RawQuery
+				handler.ServeHTTP(w, r)
+			}))
+
+			input = &chaincode.LogsInput{
+				PackageID: "cc:1",
+			}
+			output = gbytes.NewBuffer()
+			logsFetcher = &chaincode.LogsFetcher{
+				Input: input,
+				Client: &common.OperationsClient{
+					BaseURL: server.URL,
+					Client:  server.Client(),
+				},
+				Writer:        output,
+				RetryInterval: 10 * time.Millisecond,
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("writes the output captured for the chaincode", func() {
+			err := logsFetcher.Fetch(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output.Contents())).To(Equal("one\ntwo\n"))
+			Expect(requests).To(Receive(Equal("follow=false&packageID=cc%3A1&since=0")))
+		})
+
+		Context("when following the output", func() {
+			BeforeEach(func() {
+				input.Follow = true
+			})
+
+			It("writes the output as it is written until the context is done", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				errCh := make(chan error, 1)
+				go func() { errCh <- logsFetcher.Fetch(ctx) }()
+
+				Eventually(output).Should(gbytes.Say("one\ntwo\n"))
+				store.Append("cc:1", "three")
+				Eventually(output).Should(gbytes.Say("three\n"))
+
+				cancel()
+				Eventually(errCh).Should(Receive(BeNil()))
+			})
+
+			It("resumes from the last line received when the response ends", func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					select {
+					case requests <- r.URL.RawQuery:
+					default:
+					}
+					r.URL.RawQuery = strings.Replace(r.URL.RawQuery, "follow=true", "follow=false", 1)
+					cclogs.NewHandler(store).ServeHTTP(w, r)
+				})
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				errCh := make(chan error, 1)
+				go func() { errCh <- logsFetcher.Fetch(ctx) }()
+
+				Eventually(output).Should(gbytes.Say("one\ntwo\n"))
+				Eventually(requests).Should(Receive(ContainSubstring("since=2")))
+				store.Append("cc:1", "three")
+				Eventually(output).Should(gbytes.Say("three\n"))
+
+				cancel()
+				Eventually(errCh).Should(Receive(BeNil()))
+				Expect(string(output.Contents())).To(Equal("one\ntwo\nthree\n"))
+			})
+		})
+
+		Context("when the response cannot be decoded", func() {
+			BeforeEach(func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte(`{"seq":1,"text":"one"}` + "\n" + `{"seq":`))
+				})
+			})
+
+			It("returns an error", func() {
+				err := logsFetcher.Fetch(context.Background())
+				Expect(err).To(MatchError("failed to read chaincode logs: unexpected EOF"))
+				Expect(string(output.Contents())).To(Equal("one\n"))
+			})
+		})
+
+		Context("when the package ID is not provided", func() {
+			BeforeEach(func() {
+				input.PackageID = ""
+			})
+
+			It("returns an error", func() {
+				err := logsFetcher.Fetch(context.Background())
+				Expect(err).To(MatchError("The required parameter 'package-id' is empty. Rerun the command with --package-id flag"))
+			})
+		})
+
+		Context("when no output has been captured for the chaincode", func() {
+			BeforeEach(func() {
+				input.PackageID = "cc:2"
+			})
+
+			It("returns an error", func() {
+				err := logsFetcher.Fetch(context.Background())
+				Expect(err).To(MatchError("failed to retrieve chaincode logs: operations request failed with status 404: no output captured for chaincode 'cc:2'"))
+			})
+		})
+	})
+
+	Describe("LogsCmd", func() {
+		var logsCmd *cobra.Command
+
+		BeforeEach(func() {
+			logsCmd = chaincode.LogsCmd(nil)
+			logsCmd.SilenceErrors = true
+			logsCmd.SilenceUsage = true
+			logsCmd.SetArgs([]string{
+				"--package-id=cc:1",
+				"--operations-address=127.0.0.1:0",
+				"--operations-tls",
+				"--operations-cafile=missing.pem",
+			})
+		})
+
+		AfterEach(func() {
+			chaincode.ResetFlags()
+		})
+
+		It("returns an error when the operations client cannot be created", func() {
+			err := logsCmd.Execute()
+			Expect(err).To(MatchError(ContainSubstring("failed to read operations root certificate file 'missing.pem'")))
+		})
+	})
+})
@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/hyperledger/fabric/internal/peer/packaging"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -34,6 +35,7 @@ type Packager struct {
 	Input            *PackageInput
 	PlatformRegistry PlatformRegistry
 	Writer           Writer
+	Signer           Signer
 }
 
 // PackageInput holds the input parameters for packaging a
@@ -43,6 +45,7 @@ type PackageInput struct {
 	Path       string
 	Type       string
 	Label      string
+	Sign       bool
 }
 
 // Validate checks for the required inputs
@@ -71,7 +74,7 @@ func PackageCmd(p *Packager) *cobra.Command {
 	chaincodePackageCmd := &cobra.Command{
 		Use:       "package [outputfile]",
 		Short:     "Package a chaincode",
-		Long:      "Package a chaincode and write the package to a file.",
+		Long:      "Package a chaincode and write the package to a file, optionally signing it with the local MSP identity.",
 		ValidArgs: []string{"1"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if p == nil {
@@ -81,6 +84,13 @@ func PackageCmd(p *Packager) *cobra.Command {
 					PlatformRegistry: pr,
 					Writer:           &persistence.FilesystemIO{},
 				}
+				if signPackage {
+					signer, err := common.GetDefaultSignerFnc()
+					if err != nil {
+						return err
+					}
+					p.Signer = signer
+				}
 			}
 			p.Command = cmd
 
@@ -94,6 +104,7 @@ func PackageCmd(p *Packager) *cobra.Command {
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
+		"sign",
 	}
 	attachFlags(chaincodePackageCmd, flagList)
 
@@ -121,6 +132,7 @@ func (p *Packager) setInput(outputFile string) {
 		Path:       chaincodePath,
 		Type:       chaincodeLang,
 		Label:      packageLabel,
+		Sign:       signPackage,
 	}
 }
 
@@ -137,6 +149,13 @@ func (p *Packager) Package() error {
 		return err
 	}
 
+	if p.Input.Sign {
+		pkgTarGzBytes, err = addPackageSignature(pkgTarGzBytes, p.Signer)
+		if err != nil {
+			return err
+		}
+	}
+
 	dir, name := filepath.Split(p.Input.OutputFile)
 	// if p.Input.OutputFile is only file name, dir becomes an empty string that creates problem
 	// while invoking 'WriteFile' function below. So, irrespective, translate dir into absolute path
@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
 	"github.com/pkg/errors"
@@ -80,6 +81,50 @@ var _ = Describe("Package", func() {
 			Expect(metadata).To(MatchJSON(`{"path":"normalizedPath","type":"testType","label":"testLabel"}`))
 		})
 
+		Context("when the package is signed", func() {
+			var mockSigner *mock.Signer
+
+			BeforeEach(func() {
+				mockSigner = &mock.Signer{}
+				mockSigner.SerializeReturns([]byte("identity"), nil)
+				mockSigner.SignReturns([]byte("signature"), nil)
+				packager.Signer = mockSigner
+				input.Sign = true
+			})
+
+			It("writes a package signed by the signer", func() {
+				err := packager.Package()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockWriter.WriteFileCallCount()).To(Equal(1))
+				_, _, signedPkgBytes := mockWriter.WriteFileArgsForCall(0)
+				signedPkg, err := persistence.ParseSignedChaincodePackage(signedPkgBytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(signedPkg.Signatures).To(Equal([]*persistence.PackageSignature{
+					{Identity: []byte("identity"), Signature: []byte("signature")},
+				}))
+
+				Expect(mockSigner.SignCallCount()).To(Equal(1))
+				Expect(mockSigner.SignArgsForCall(0)).To(Equal(signedPkg.Hash()))
+
+				metadata, err := readMetadataFromBytes(signedPkg.Package)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata).To(MatchJSON(`{"path":"normalizedPath","type":"testType","label":"testLabel"}`))
+			})
+
+			Context("when signing the package fails", func() {
+				BeforeEach(func() {
+					mockSigner.SignReturns(nil, errors.New("macchiato"))
+				})
+
+				It("returns an error", func() {
+					err := packager.Package()
+					Expect(err).To(MatchError("failed to sign chaincode package: macchiato"))
+					Expect(mockWriter.WriteFileCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the path is not provided", func() {
 			BeforeEach(func() {
 				input.Path = ""
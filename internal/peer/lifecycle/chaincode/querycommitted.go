@@ -19,6 +19,7 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/msgs"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -38,6 +39,7 @@ type CommittedQuerier struct {
 type CommittedQueryInput struct {
 	ChannelID    string
 	Name         string
+	History      bool
 	OutputFormat string
 }
 
@@ -48,7 +50,7 @@ func QueryCommittedCmd(c *CommittedQuerier, cryptoProvider bccsp.BCCSP) *cobra.C
 	chaincodeQueryCommittedCmd := &cobra.Command{
 		Use:   "querycommitted",
 		Short: "Query the committed chaincode definitions by channel on a peer.",
-		Long:  "Query the committed chaincode definitions by channel on a peer. Optional: provide a chaincode name to query a specific definition.",
+		Long:  "Query the committed chaincode definitions by channel on a peer. Optional: provide a chaincode name to query a specific definition, and the history flag to query every definition committed for it.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if c == nil {
 				ccInput := &ClientConnectionsInput{
@@ -69,6 +71,7 @@ func QueryCommittedCmd(c *CommittedQuerier, cryptoProvider bccsp.BCCSP) *cobra.C
 				cqInput := &CommittedQueryInput{
 					ChannelID:    channelID,
 					Name:         chaincodeName,
+					History:      definitionHistory,
 					OutputFormat: output,
 				}
 
@@ -91,6 +94,7 @@ func QueryCommittedCmd(c *CommittedQuerier, cryptoProvider bccsp.BCCSP) *cobra.C
 		"tlsRootCertFiles",
 		"connectionProfile",
 		"output",
+		"history",
 	}
 	attachFlags(chaincodeQueryCommittedCmd, flagList)
 
@@ -105,45 +109,74 @@ func (c *CommittedQuerier) Query() error {
 		c.Command.SilenceUsage = true
 	}
 
-	err := c.validateInput()
+	proposalResponse, err := c.query()
 	if err != nil {
 		return err
 	}
 
+	if strings.ToLower(c.Input.OutputFormat) == "json" {
+		return c.printResponseAsJSON(proposalResponse)
+	}
+	return c.printResponse(proposalResponse)
+}
+
+// QueryHistory returns every chaincode definition committed
+// for a given channel and chaincode name. The input must
+// request the history.
+func (c *CommittedQuerier) QueryHistory() (*msgs.QueryChaincodeDefinitionHistoryResult, error) {
+	proposalResponse, err := c.query()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &msgs.QueryChaincodeDefinitionHistoryResult{}
+	err = proto.Unmarshal(proposalResponse.Response.Payload, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+	}
+	return result, nil
+}
+
+func (c *CommittedQuerier) query() (*pb.ProposalResponse, error) {
+	err := c.validateInput()
+	if err != nil {
+		return nil, err
+	}
+
 	proposal, err := c.createProposal()
 	if err != nil {
-		return errors.WithMessage(err, "failed to create proposal")
+		return nil, errors.WithMessage(err, "failed to create proposal")
 	}
 
 	signedProposal, err := signProposal(proposal, c.Signer)
 	if err != nil {
-		return errors.WithMessage(err, "failed to create signed proposal")
+		return nil, errors.WithMessage(err, "failed to create signed proposal")
 	}
 
 	proposalResponse, err := c.EndorserClient.ProcessProposal(context.Background(), signedProposal)
 	if err != nil {
-		return errors.WithMessage(err, "failed to endorse proposal")
+		return nil, errors.WithMessage(err, "failed to endorse proposal")
 	}
 
 	if proposalResponse == nil {
-		return errors.New("received nil proposal response")
+		return nil, errors.New("received nil proposal response")
 	}
 
 	if proposalResponse.Response == nil {
-		return errors.New("received proposal response with nil response")
+		return nil, errors.New("received proposal response with nil response")
 	}
 
 	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
-		return errors.Errorf("query failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+		return nil, errors.Errorf("query failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
 	}
 
-	if strings.ToLower(c.Input.OutputFormat) == "json" {
-		return c.printResponseAsJSON(proposalResponse)
-	}
-	return c.printResponse(proposalResponse)
+	return proposalResponse, nil
 }
 
 func (c *CommittedQuerier) printResponseAsJSON(proposalResponse *pb.ProposalResponse) error {
+	if c.Input.History {
+		return printResponseAsJSON(proposalResponse, &msgs.QueryChaincodeDefinitionHistoryResult{}, c.Writer)
+	}
 	if c.Input.Name != "" {
 		return printResponseAsJSON(proposalResponse, &lb.QueryChaincodeDefinitionResult{}, c.Writer)
 	}
@@ -153,6 +186,25 @@ func (c *CommittedQuerier) printResponseAsJSON(proposalResponse *pb.ProposalResp
 // printResponse prints the information included in the response
 // from the server as human readable plain-text.
 func (c *CommittedQuerier) printResponse(proposalResponse *pb.ProposalResponse) error {
+	if c.Input.History {
+		result := &msgs.QueryChaincodeDefinitionHistoryResult{}
+		err := proto.Unmarshal(proposalResponse.Response.Payload, result)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+		}
+		fmt.Fprintf(c.Writer, "Committed chaincode definition history for chaincode '%s' on channel '%s':\n", c.Input.Name, c.Input.ChannelID)
+		for _, cd := range result.ChaincodeDefinitions {
+			c.printSingleChaincodeDefinition(cd)
+			fmt.Fprintf(c.Writer, ", Block: %d, Transaction ID: %s", cd.BlockNumber, cd.TxId)
+			if cd.PackageId != "" {
+				fmt.Fprintf(c.Writer, ", Package ID: %s", cd.PackageId)
+			}
+			c.printApprovals(cd)
+		}
+
+		return nil
+	}
+
 	if c.Input.Name != "" {
 		result := &lb.QueryChaincodeDefinitionResult{}
 		err := proto.Unmarshal(proposalResponse.Response.Payload, result)
@@ -191,9 +243,13 @@ func (c *CommittedQuerier) printSingleChaincodeDefinition(cd ChaincodeDefinition
 	fmt.Fprintf(c.Writer, "Version: %s, Sequence: %d, Endorsement Plugin: %s, Validation Plugin: %s", cd.GetVersion(), cd.GetSequence(), cd.GetEndorsementPlugin(), cd.GetValidationPlugin())
 }
 
-func (c *CommittedQuerier) printApprovals(qcdr *lb.QueryChaincodeDefinitionResult) {
+type ApprovedChaincodeDefinition interface {
+	GetApprovals() map[string]bool
+}
+
+func (c *CommittedQuerier) printApprovals(cd ApprovedChaincodeDefinition) {
 	orgs := []string{}
-	approved := qcdr.GetApprovals()
+	approved := cd.GetApprovals()
 	for org := range approved {
 		orgs = append(orgs, org)
 	}
@@ -213,6 +269,10 @@ func (c *CommittedQuerier) validateInput() error {
 		return errors.New("channel name must be specified")
 	}
 
+	if c.Input.History && c.Input.Name == "" {
+		return errors.New("chaincode name must be specified when querying the definition history")
+	}
+
 	return nil
 }
 
@@ -220,12 +280,18 @@ func (c *CommittedQuerier) createProposal() (*pb.Proposal, error) {
 	var function string
 	var args proto.Message
 
-	if c.Input.Name != "" {
+	switch {
+	case c.Input.History:
+		function = "QueryChaincodeDefinitionHistory"
+		args = &msgs.QueryChaincodeDefinitionHistoryArgs{
+			Name: c.Input.Name,
+		}
+	case c.Input.Name != "":
 		function = "QueryChaincodeDefinition"
 		args = &lb.QueryChaincodeDefinitionArgs{
 			Name: c.Input.Name,
 		}
-	} else {
+	default:
 		function = "QueryChaincodeDefinitions"
 		args = &lb.QueryChaincodeDefinitionsArgs{}
 	}
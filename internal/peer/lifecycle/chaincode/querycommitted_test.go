@@ -14,6 +14,7 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/msgs"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
 	"github.com/pkg/errors"
@@ -197,6 +198,95 @@ var _ = Describe("QueryCommitted", func() {
 			})
 		})
 
+		Context("when the definition history is requested", func() {
+			BeforeEach(func() {
+				input.Name = "test-cc"
+				input.History = true
+
+				mockResult := &msgs.QueryChaincodeDefinitionHistoryResult{
+					ChaincodeDefinitions: []*msgs.QueryChaincodeDefinitionHistoryResult_ChaincodeDefinition{
+						{
+							Sequence:          1,
+							Version:           "a-version",
+							EndorsementPlugin: "e-plugin",
+							ValidationPlugin:  "v-plugin",
+							Approvals: map[string]bool{
+								"whatkindoforgisthis": true,
+								"nowaydoiapprove":     false,
+							},
+							BlockNumber: 5,
+							TxId:        "tx-1",
+						},
+						{
+							Sequence:          2,
+							Version:           "another-version",
+							EndorsementPlugin: "e-plugin",
+							ValidationPlugin:  "v-plugin",
+							Approvals: map[string]bool{
+								"whatkindoforgisthis": true,
+								"nowaydoiapprove":     true,
+							},
+							PackageId:   "a-package-id",
+							BlockNumber: 9,
+							TxId:        "tx-2",
+						},
+					},
+				}
+
+				mockResultBytes, err := proto.Marshal(mockResult)
+				Expect(err).NotTo(HaveOccurred())
+				mockProposalResponse = &pb.ProposalResponse{
+					Response: &pb.Response{
+						Status:  200,
+						Payload: mockResultBytes,
+					},
+				}
+
+				mockEndorserClient.ProcessProposalReturns(mockProposalResponse, nil)
+			})
+
+			It("queries the definition history and writes the output as human readable plain-text", func() {
+				err := committedQuerier.Query()
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(committedQuerier.Writer).Should(gbytes.Say("Committed chaincode definition history for chaincode 'test-cc' on channel 'test-channel':\n"))
+				Eventually(committedQuerier.Writer).Should(gbytes.Say(`\QVersion: a-version, Sequence: 1, Endorsement Plugin: e-plugin, Validation Plugin: v-plugin, Block: 5, Transaction ID: tx-1, Approvals: [nowaydoiapprove: false, whatkindoforgisthis: true]\E`))
+				Eventually(committedQuerier.Writer).Should(gbytes.Say(`\QVersion: another-version, Sequence: 2, Endorsement Plugin: e-plugin, Validation Plugin: v-plugin, Block: 9, Transaction ID: tx-2, Package ID: a-package-id, Approvals: [nowaydoiapprove: true, whatkindoforgisthis: true]\E`))
+
+				Expect(mockEndorserClient.ProcessProposalCallCount()).To(Equal(1))
+				_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(0)
+				proposal := &pb.Proposal{}
+				Expect(proto.Unmarshal(signedProposal.ProposalBytes, proposal)).To(Succeed())
+				payload := &pb.ChaincodeProposalPayload{}
+				Expect(proto.Unmarshal(proposal.Payload, payload)).To(Succeed())
+				cis := &pb.ChaincodeInvocationSpec{}
+				Expect(proto.Unmarshal(payload.Input, cis)).To(Succeed())
+				Expect(cis.ChaincodeSpec.Input.Args[0]).To(Equal([]byte("QueryChaincodeDefinitionHistory")))
+			})
+
+			Context("when JSON-formatted output is requested", func() {
+				BeforeEach(func() {
+					committedQuerier.Input.OutputFormat = "json"
+				})
+
+				It("queries the definition history and writes the output as JSON", func() {
+					err := committedQuerier.Query()
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(committedQuerier.Writer).Should(gbytes.Say(`"tx_id": "tx-2"`))
+				})
+			})
+
+			Context("when the chaincode name is not provided", func() {
+				BeforeEach(func() {
+					input.Name = ""
+				})
+
+				It("returns an error", func() {
+					err := committedQuerier.Query()
+					Expect(err).To(MatchError("chaincode name must be specified when querying the definition history"))
+				})
+			})
+		})
+
 		Context("when the channel is not provided", func() {
 			BeforeEach(func() {
 				committedQuerier.Input.ChannelID = ""
@@ -0,0 +1,155 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// PackageSigner holds the dependencies needed to sign
+// a chaincode package and write it
+type PackageSigner struct {
+	Command *cobra.Command
+	Input   *SignPackageInput
+	Reader  Reader
+	Writer  Writer
+	Signer  Signer
+}
+
+// SignPackageInput holds the input parameters for signing
+// a chaincode package
+type SignPackageInput struct {
+	InputFile  string
+	OutputFile string
+}
+
+// Validate checks for the required inputs
+func (s *SignPackageInput) Validate() error {
+	if s.InputFile == "" {
+		return errors.New("chaincode package file must be specified")
+	}
+	if s.OutputFile == "" {
+		return errors.New("output file must be specified")
+	}
+
+	return nil
+}
+
+// SignPackageCmd returns the cobra command for signing a chaincode package
+func SignPackageCmd(s *PackageSigner) *cobra.Command {
+	chaincodeSignPackageCmd := &cobra.Command{
+		Use:   "signpackage [inputfile] [outputfile]",
+		Short: "Sign a chaincode package",
+		Long:  "Add a signature by the local MSP identity to a chaincode package and write the signed package to a file. A package may be signed by several identities.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if s == nil {
+				signer, err := common.GetDefaultSignerFnc()
+				if err != nil {
+					return err
+				}
+
+				s = &PackageSigner{
+					Reader: &persistence.FilesystemIO{},
+					Writer: &persistence.FilesystemIO{},
+					Signer: signer,
+				}
+			}
+			s.Command = cmd
+
+			return s.SignPackage(args)
+		},
+	}
+
+	return chaincodeSignPackageCmd
+}
+
+// SignPackage signs a chaincode package.
+func (s *PackageSigner) SignPackage(args []string) error {
+	if s.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		s.Command.SilenceUsage = true
+	}
+
+	if len(args) != 2 {
+		return errors.New("invalid number of args. expected the input and output files")
+	}
+	s.Input = &SignPackageInput{
+		InputFile:  args[0],
+		OutputFile: args[1],
+	}
+
+	return s.Sign()
+}
+
+// Sign reads the chaincode package, adds the signature of the
+// signer to it and writes the signed package to disk
+func (s *PackageSigner) Sign() error {
+	err := s.Input.Validate()
+	if err != nil {
+		return err
+	}
+
+	pkgBytes, err := s.Reader.ReadFile(s.Input.InputFile)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to read chaincode package at '%s'", s.Input.InputFile)
+	}
+
+	signedPkgBytes, err := addPackageSignature(pkgBytes, s.Signer)
+	if err != nil {
+		return err
+	}
+
+	dir, name := filepath.Split(s.Input.OutputFile)
+	if dir, err = filepath.Abs(dir); err != nil {
+		return err
+	}
+	err = s.Writer.WriteFile(dir, name, signedPkgBytes)
+	if err != nil {
+		err = errors.Wrapf(err, "error writing signed chaincode package to %s", s.Input.OutputFile)
+		logger.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// addPackageSignature adds a signature by the signer over the hash of the
+// chaincode package, which may already be signed, and returns the signed
+// chaincode package.
+func addPackageSignature(pkgBytes []byte, signer Signer) ([]byte, error) {
+	signedPkg, err := persistence.ParseSignedChaincodePackage(pkgBytes)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse chaincode package")
+	}
+
+	identity, err := signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize signer")
+	}
+
+	signature, err := signer.Sign(signedPkg.Hash())
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to sign chaincode package")
+	}
+
+	signedPkg.Signatures = append(signedPkg.Signatures, &persistence.PackageSignature{
+		Identity:  identity,
+		Signature: signature,
+	})
+
+	signedPkgBytes, err := signedPkg.Bytes()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create signed chaincode package")
+	}
+
+	return signedPkgBytes, nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SignPackage", func() {
+	Describe("PackageSigner", func() {
+		var (
+			pkgBytes      []byte
+			mockReader    *mock.Reader
+			mockWriter    *mock.Writer
+			mockSigner    *mock.Signer
+			input         *chaincode.SignPackageInput
+			packageSigner *chaincode.PackageSigner
+		)
+
+		BeforeEach(func() {
+			pkgBytes = []byte("pkg-bytes")
+
+			mockReader = &mock.Reader{}
+			mockReader.ReadFileReturns(pkgBytes, nil)
+			mockWriter = &mock.Writer{}
+			mockSigner = &mock.Signer{}
+			mockSigner.SerializeReturns([]byte("identity"), nil)
+			mockSigner.SignReturns([]byte("signature"), nil)
+
+			input = &chaincode.SignPackageInput{
+				InputFile:  "pkgFile",
+				OutputFile: "testDir/signedPackage",
+			}
+
+			packageSigner = &chaincode.PackageSigner{
+				Input:  input,
+				Reader: mockReader,
+				Writer: mockWriter,
+				Signer: mockSigner,
+			}
+		})
+
+		It("signs the chaincode package", func() {
+			err := packageSigner.Sign()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockReader.ReadFileCallCount()).To(Equal(1))
+			Expect(mockReader.ReadFileArgsForCall(0)).To(Equal("pkgFile"))
+
+			Expect(mockWriter.WriteFileCallCount()).To(Equal(1))
+			dir, name, signedPkgBytes := mockWriter.WriteFileArgsForCall(0)
+			wd, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dir).To(Equal(filepath.Join(wd, "testDir")))
+			Expect(name).To(Equal("signedPackage"))
+
+			signedPkg, err := persistence.ParseSignedChaincodePackage(signedPkgBytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(signedPkg.Package).To(Equal(pkgBytes))
+			Expect(signedPkg.Signatures).To(Equal([]*persistence.PackageSignature{
+				{Identity: []byte("identity"), Signature: []byte("signature")},
+			}))
+			Expect(mockSigner.SignArgsForCall(0)).To(Equal(signedPkg.Hash()))
+		})
+
+		Context("when the package is already signed", func() {
+			BeforeEach(func() {
+				signedPkg := &persistence.SignedChaincodePackage{
+					Package: pkgBytes,
+					Signatures: []*persistence.PackageSignature{
+						{Identity: []byte("other-identity"), Signature: []byte("other-signature")},
+					},
+				}
+				signedPkgBytes, err := signedPkg.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				mockReader.ReadFileReturns(signedPkgBytes, nil)
+			})
+
+			It("adds the signature to the existing signatures", func() {
+				err := packageSigner.Sign()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, signedPkgBytes := mockWriter.WriteFileArgsForCall(0)
+				signedPkg, err := persistence.ParseSignedChaincodePackage(signedPkgBytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(signedPkg.Package).To(Equal(pkgBytes))
+				Expect(signedPkg.Signatures).To(Equal([]*persistence.PackageSignature{
+					{Identity: []byte("other-identity"), Signature: []byte("other-signature")},
+					{Identity: []byte("identity"), Signature: []byte("signature")},
+				}))
+			})
+		})
+
+		Context("when the input file is not provided", func() {
+			BeforeEach(func() {
+				input.InputFile = ""
+			})
+
+			It("returns an error", func() {
+				err := packageSigner.Sign()
+				Expect(err).To(MatchError("chaincode package file must be specified"))
+			})
+		})
+
+		Context("when the output file is not provided", func() {
+			BeforeEach(func() {
+				input.OutputFile = ""
+			})
+
+			It("returns an error", func() {
+				err := packageSigner.Sign()
+				Expect(err).To(MatchError("output file must be specified"))
+			})
+		})
+
+		Context("when the package file cannot be read", func() {
+			BeforeEach(func() {
+				mockReader.ReadFileReturns(nil, errors.New("coffee"))
+			})
+
+			It("returns an error", func() {
+				err := packageSigner.Sign()
+				Expect(err).To(MatchError("failed to read chaincode package at 'pkgFile': coffee"))
+			})
+		})
+
+		Context("when the signer cannot be serialized", func() {
+			BeforeEach(func() {
+				mockSigner.SerializeReturns(nil, errors.New("cafe"))
+			})
+
+			It("returns an error", func() {
+				err := packageSigner.Sign()
+				Expect(err).To(MatchError("failed to serialize signer: cafe"))
+			})
+		})
+
+		Context("when signing the package fails", func() {
+			BeforeEach(func() {
+				mockSigner.SignReturns(nil, errors.New("latte"))
+			})
+
+			It("returns an error", func() {
+				err := packageSigner.Sign()
+				Expect(err).To(MatchError("failed to sign chaincode package: latte"))
+			})
+		})
+
+		Context("when writing the file fails", func() {
+			BeforeEach(func() {
+				mockWriter.WriteFileReturns(errors.New("espresso"))
+			})
+
+			It("returns an error", func() {
+				err := packageSigner.Sign()
+				Expect(err).To(MatchError("error writing signed chaincode package to testDir/signedPackage: espresso"))
+			})
+		})
+	})
+
+	Describe("SignPackageCmd", func() {
+		var signPackageCmd *cobra.Command
+
+		BeforeEach(func() {
+			signPackageCmd = chaincode.SignPackageCmd(&chaincode.PackageSigner{})
+			signPackageCmd.SilenceErrors = true
+			signPackageCmd.SilenceUsage = true
+		})
+
+		Context("when the wrong number of arguments is provided", func() {
+			BeforeEach(func() {
+				signPackageCmd.SetArgs([]string{"pkgFile"})
+			})
+
+			It("returns an error", func() {
+				err := signPackageCmd.Execute()
+				Expect(err).To(MatchError("invalid number of args. expected the input and output files"))
+			})
+		})
+	})
+})
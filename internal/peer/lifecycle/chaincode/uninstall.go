@@ -0,0 +1,215 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/msgs"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Uninstaller holds the dependencies needed to uninstall
+// a chaincode package from a peer.
+type Uninstaller struct {
+	Command        *cobra.Command
+	Input          *UninstallInput
+	EndorserClient EndorserClient
+	Signer         Signer
+	Writer         io.Writer
+}
+
+// UninstallInput holds all of the input parameters for
+// uninstalling a chaincode package from a peer.
+type UninstallInput struct {
+	PackageID string
+	Force     bool
+}
+
+// Validate checks that the required parameters are provided.
+func (u *UninstallInput) Validate() error {
+	if u.PackageID == "" {
+		return errors.New("The required parameter 'package-id' is empty. Rerun the command with --package-id flag")
+	}
+
+	return nil
+}
+
+// UninstallCmd returns the cobra command for uninstalling a
+// chaincode package from a peer.
+func UninstallCmd(u *Uninstaller, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	chaincodeUninstallCmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Uninstall a chaincode package from a peer.",
+		Long: "Uninstall a chaincode package from a peer. The chaincode package and its build output are removed " +
+			"and any running instance of the chaincode is stopped. A chaincode package which is referenced by a " +
+			"chaincode definition on a channel joined by the peer is only uninstalled when --force is specified.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if u == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					PeerAddresses:         peerAddresses,
+					TLSRootCertFiles:      tlsRootCertFiles,
+					ConnectionProfilePath: connectionProfilePath,
+					TargetPeer:            targetPeer,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+
+				uninstallInput := &UninstallInput{
+					PackageID: packageID,
+					Force:     force,
+				}
+
+				// uninstall only supports one peer connection,
+				// which is why we only wire in the first endorser
+				// client
+				u = &Uninstaller{
+					Command:        cmd,
+					EndorserClient: cc.EndorserClients[0],
+					Input:          uninstallInput,
+					Signer:         cc.Signer,
+					Writer:         os.Stdout,
+				}
+			}
+			return u.Uninstall()
+		},
+	}
+
+	flagList := []string{
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+		"targetPeer",
+		"package-id",
+		"force",
+	}
+	attachFlags(chaincodeUninstallCmd, flagList)
+
+	return chaincodeUninstallCmd
+}
+
+// Uninstall uninstalls a chaincode package from a peer.
+func (u *Uninstaller) Uninstall() error {
+	if u.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		u.Command.SilenceUsage = true
+	}
+
+	if err := u.Input.Validate(); err != nil {
+		return err
+	}
+
+	proposal, err := u.createProposal()
+	if err != nil {
+		return errors.WithMessage(err, "failed to create proposal")
+	}
+
+	signedProposal, err := signProposal(proposal, u.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create signed proposal")
+	}
+
+	proposalResponse, err := u.EndorserClient.ProcessProposal(context.Background(), signedProposal)
+	if err != nil {
+		return errors.WithMessage(err, "failed to endorse proposal")
+	}
+
+	if proposalResponse == nil {
+		return errors.New("received nil proposal response")
+	}
+
+	if proposalResponse.Response == nil {
+		return errors.New("received proposal response with nil response")
+	}
+
+	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("chaincode uninstall failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+
+	return u.printResponse(proposalResponse)
+}
+
+// printResponse prints the information included in the response
+// from the server.
+func (u *Uninstaller) printResponse(proposalResponse *pb.ProposalResponse) error {
+	result := &msgs.UninstallChaincodeResult{}
+	err := proto.Unmarshal(proposalResponse.Response.Payload, result)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+	}
+
+	fmt.Fprintf(u.Writer, "Uninstalled chaincode package with package ID: %s\n", u.Input.PackageID)
+	if len(result.References) == 0 {
+		return nil
+	}
+
+	channels := make([]string, 0, len(result.References))
+	for channel := range result.References {
+		channels = append(channels, channel)
+	}
+	sort.Strings(channels)
+
+	fmt.Fprintln(u.Writer, "The package was referenced by the following chaincode definitions:")
+	for _, channel := range channels {
+		for _, chaincode := range result.References[channel].Chaincodes {
+			fmt.Fprintf(u.Writer, "Channel: %s, Name: %s, Version: %s\n", channel, chaincode.Name, chaincode.Version)
+		}
+	}
+	return nil
+}
+
+func (u *Uninstaller) createProposal() (*pb.Proposal, error) {
+	args := &msgs.UninstallChaincodeArgs{
+		PackageId: u.Input.PackageID,
+		Force:     u.Input.Force,
+	}
+
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal args")
+	}
+
+	ccInput := &pb.ChaincodeInput{
+		Args: [][]byte{[]byte("UninstallChaincode"), argsBytes},
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: lifecycleName},
+			Input:       ccInput,
+		},
+	}
+
+	signerSerialized, err := u.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	proposal, _, err := protoutil.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", cis, signerSerialized)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create ChaincodeInvocationSpec proposal")
+	}
+
+	return proposal, nil
+}
@@ -0,0 +1,28 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/spf13/cobra"
+)
+
+func compressBlockfilesCmd() *cobra.Command {
+	return nodeCompressBlockfilesCmd
+}
+
+var nodeCompressBlockfilesCmd = &cobra.Command{
+	Use:   "compress-blockfiles",
+	Short: "Compresses the block files of all channels.",
+	Long: "Rewrites the existing uncompressed block files of all channels in the compressed format. The block store index" +
+		" is rebuilt upon peer restart. To keep the block files created hereafter compressed as well, set" +
+		" ledger.blockchain.compressBlockFiles to true. When the command is executed, the peer must be offline.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config := ledgerConfig()
+		return kvledger.CompressBlockStore(config.RootFSPath)
+	},
+}
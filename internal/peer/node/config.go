@@ -65,6 +65,15 @@ func ledgerConfig() *ledger.Config {
 		},
 		SnapshotsConfig: &ledger.SnapshotsConfig{
 			RootDir: snapshotsRootDir,
+			DefaultPolicy: &ledger.SnapshotPolicy{
+				BlockInterval: uint64(viper.GetInt64("ledger.snapshots.policy.blockInterval")),
+				TimeInterval:  viper.GetDuration("ledger.snapshots.policy.timeInterval"),
+				MaxRetained:   viper.GetInt("ledger.snapshots.policy.maxRetained"),
+			},
+			ChannelPolicies: snapshotChannelPolicies(),
+		},
+		BlockStoreConfig: &ledger.BlockStoreConfig{
+			CompressBlockFiles: viper.GetBool("ledger.blockchain.compressBlockFiles"),
 		},
 	}
 
@@ -85,3 +94,32 @@ func ledgerConfig() *ledger.Config {
 	}
 	return conf
 }
+
+type snapshotChannelPolicy struct {
+	Channel       string
+	BlockInterval uint64
+	TimeInterval  time.Duration
+	MaxRetained   int
+}
+
+func snapshotChannelPolicies() map[string]*ledger.SnapshotPolicy {
+	var channelPolicies []snapshotChannelPolicy
+	if err := viper.UnmarshalKey("ledger.snapshots.channelPolicies", &channelPolicies); err != nil {
+		logger.Panicf("Invalid ledger.snapshots.channelPolicies configuration: %s", err)
+	}
+	if len(channelPolicies) == 0 {
+		return nil
+	}
+	policies := map[string]*ledger.SnapshotPolicy{}
+	for _, p := range channelPolicies {
+		if p.Channel == "" {
+			logger.Panicf("Invalid ledger.snapshots.channelPolicies configuration: channel attribute missing in one or more policies")
+		}
+		policies[p.Channel] = &ledger.SnapshotPolicy{
+			BlockInterval: p.BlockInterval,
+			TimeInterval:  p.TimeInterval,
+			MaxRetained:   p.MaxRetained,
+		}
+	}
+	return policies
+}
@@ -44,7 +44,11 @@ func TestLedgerConfig(t *testing.T) {
 					Enabled: false,
 				},
 				SnapshotsConfig: &ledger.SnapshotsConfig{
-					RootDir: "/peerfs/snapshots",
+					RootDir:       "/peerfs/snapshots",
+					DefaultPolicy: &ledger.SnapshotPolicy{},
+				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					CompressBlockFiles: false,
 				},
 			},
 		},
@@ -90,7 +94,11 @@ func TestLedgerConfig(t *testing.T) {
 					Enabled: false,
 				},
 				SnapshotsConfig: &ledger.SnapshotsConfig{
-					RootDir: "/peerfs/snapshots",
+					RootDir:       "/peerfs/snapshots",
+					DefaultPolicy: &ledger.SnapshotPolicy{},
+				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					CompressBlockFiles: false,
 				},
 			},
 		},
@@ -115,6 +123,14 @@ func TestLedgerConfig(t *testing.T) {
 				"ledger.pvtdataStore.deprioritizedDataReconcilerInterval": "180m",
 				"ledger.history.enableHistoryDatabase":                    true,
 				"ledger.snapshots.rootDir":                                "/peerfs/customLocationForsnapshots",
+				"ledger.blockchain.compressBlockFiles":                    true,
+				"ledger.snapshots.policy.blockInterval":                   1000,
+				"ledger.snapshots.policy.timeInterval":                    "24h",
+				"ledger.snapshots.policy.maxRetained":                     5,
+				"ledger.snapshots.channelPolicies": []map[string]interface{}{
+					{"channel": "ch1", "blockInterval": 500, "maxRetained": 2},
+					{"channel": "ch2", "timeInterval": "1h"},
+				},
 			},
 			expected: &ledger.Config{
 				RootFSPath: "/peerfs/ledgersData",
@@ -145,6 +161,18 @@ func TestLedgerConfig(t *testing.T) {
 				},
 				SnapshotsConfig: &ledger.SnapshotsConfig{
 					RootDir: "/peerfs/customLocationForsnapshots",
+					DefaultPolicy: &ledger.SnapshotPolicy{
+						BlockInterval: 1000,
+						TimeInterval:  24 * time.Hour,
+						MaxRetained:   5,
+					},
+					ChannelPolicies: map[string]*ledger.SnapshotPolicy{
+						"ch1": {BlockInterval: 500, MaxRetained: 2},
+						"ch2": {TimeInterval: time.Hour},
+					},
+				},
+				BlockStoreConfig: &ledger.BlockStoreConfig{
+					CompressBlockFiles: true,
 				},
 			},
 		},
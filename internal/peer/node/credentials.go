@@ -0,0 +1,383 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/peer"
+	gossipservice "github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// reloadableSigner signs with a signing identity which can be replaced
+// while the peer is running
+type reloadableSigner struct {
+	lock   sync.RWMutex
+	signer msp.SigningIdentity
+}
+
+func newReloadableSigner(signer msp.SigningIdentity) *reloadableSigner {
+	return &reloadableSigner{signer: signer}
+}
+
+// Sign signs the message with the current signing identity
+func (rs *reloadableSigner) Sign(msg []byte) ([]byte, error) {
+	return rs.current().Sign(msg)
+}
+
+// Serialize serializes the current signing identity
+func (rs *reloadableSigner) Serialize() ([]byte, error) {
+	return rs.current().Serialize()
+}
+
+func (rs *reloadableSigner) current() msp.SigningIdentity {
+	rs.lock.RLock()
+	defer rs.lock.RUnlock()
+	return rs.signer
+}
+
+func (rs *reloadableSigner) set(signer msp.SigningIdentity) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+	rs.signer = signer
+}
+
+// expirationTracker warns before the certificates of the peer expire, and
+// tracks the certificates again when they are reloaded
+type expirationTracker struct {
+	lock            sync.Mutex
+	tls             bool
+	serverCert      []byte
+	clientCertChain [][]byte
+	identity        []byte
+	timers          []*time.Timer
+
+	info     crypto.MessageFunc
+	warn     crypto.MessageFunc
+	now      func() time.Time
+	schedule crypto.Scheduler
+}
+
+func newExpirationTracker(tls bool, serverCert []byte, clientCertChain [][]byte, identity []byte, info, warn crypto.MessageFunc) *expirationTracker {
+	et := &expirationTracker{
+		tls:             tls,
+		serverCert:      serverCert,
+		clientCertChain: clientCertChain,
+		identity:        identity,
+		info:            info,
+		warn:            warn,
+		now:             time.Now,
+		schedule:        time.AfterFunc,
+	}
+	et.track()
+	return et
+}
+
+func (et *expirationTracker) updateIdentity(identity []byte) {
+	et.lock.Lock()
+	et.identity = identity
+	et.lock.Unlock()
+	et.track()
+}
+
+func (et *expirationTracker) updateTLS(serverCert, clientCert tls.Certificate) {
+	et.lock.Lock()
+	if len(serverCert.Certificate) > 0 {
+		et.serverCert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: serverCert.Certificate[0]})
+	}
+	et.clientCertChain = clientCert.Certificate
+	et.lock.Unlock()
+	et.track()
+}
+
+// track cancels the warnings scheduled for the former certificates
+// and schedules the ones of the current certificates
+func (et *expirationTracker) track() {
+	et.lock.Lock()
+	defer et.lock.Unlock()
+	for _, timer := range et.timers {
+		timer.Stop()
+	}
+	et.timers = nil
+	crypto.TrackExpiration(
+		et.tls,
+		et.serverCert,
+		et.clientCertChain,
+		et.identity,
+		et.info,
+		et.warn,
+		et.now(),
+		func(d time.Duration, f func()) *time.Timer {
+			timer := et.schedule(d, f)
+			et.timers = append(et.timers, timer)
+			return timer
+		},
+	)
+}
+
+func (et *expirationTracker) stop() {
+	et.lock.Lock()
+	defer et.lock.Unlock()
+	for _, timer := range et.timers {
+		timer.Stop()
+	}
+	et.timers = nil
+}
+
+// loadSigningIdentity loads the default signing identity of an MSP from the
+// given directory, into a new MSP instance
+func loadSigningIdentity(mspDir, mspID, mspType string, bccspConfig *factory.FactoryOpts, cryptoProvider bccsp.BCCSP) (msp.SigningIdentity, error) {
+	conf, err := msp.GetLocalMspConfigWithType(mspDir, bccspConfig, mspID, mspType)
+	if err != nil {
+		return nil, err
+	}
+	newOpts, found := msp.Options[mspType]
+	if !found {
+		return nil, errors.Errorf("msp type %s unknown", mspType)
+	}
+	mspInst, err := msp.New(newOpts, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+	if err := mspInst.Setup(conf); err != nil {
+		return nil, errors.WithMessagef(err, "error when setting up MSP from directory %s", mspDir)
+	}
+	return mspInst.GetDefaultSigningIdentity()
+}
+
+// credentialsWatcher periodically checks whether the enrollment or the TLS
+// credentials of the peer have been replaced on the file system, for instance
+// because they were renewed, and makes the peer use the new credentials
+// without being restarted.
+type credentialsWatcher struct {
+	interval time.Duration
+	stopChan chan struct{}
+
+	identityPaths  []string
+	signer         *reloadableSigner
+	loadIdentity   func() (msp.SigningIdentity, error)
+	updateIdentity func(identity []byte) error
+	identityDigest []byte
+
+	tlsPaths  []string
+	loadTLS   func() (serverCert, clientCert tls.Certificate, err error)
+	updateTLS func(serverCert, clientCert tls.Certificate)
+	tlsDigest []byte
+}
+
+// newCredentialsWatcher creates a credentialsWatcher for the enrollment
+// credentials in the local MSP directory of the peer and, if TLS is enabled,
+// for its TLS credentials
+func newCredentialsWatcher(
+	interval time.Duration,
+	signer *reloadableSigner,
+	gossipService *gossipservice.GossipService,
+	peerServer *comm.GRPCServer,
+	credSupport *comm.CredentialSupport,
+	membershipInfoProvider *privdata.MembershipProvider,
+	expiration *expirationTracker,
+) *credentialsWatcher {
+	mspDir := config.GetPath("peer.mspConfigPath")
+	mspID := viper.GetString("peer.localMspId")
+	mspType := viper.GetString("peer.localMspType")
+	if mspType == "" {
+		mspType = msp.ProviderTypeToString(msp.FABRIC)
+	}
+	keyStore := config.GetPath("peer.BCCSP.SW.FileKeyStore.KeyStore")
+	if keyStore == "" {
+		keyStore = filepath.Join(mspDir, "keystore")
+	}
+
+	cw := &credentialsWatcher{
+		interval: interval,
+		stopChan: make(chan struct{}),
+		identityPaths: []string{
+			filepath.Join(mspDir, "signcerts"),
+			keyStore,
+		},
+		signer: signer,
+		loadIdentity: func() (msp.SigningIdentity, error) {
+			bccspConfig := factory.GetDefaultOpts()
+			if err := viper.UnmarshalKey("peer.BCCSP", &bccspConfig); err != nil {
+				return nil, errors.WithMessage(err, "could not decode peer BCCSP configuration")
+			}
+			return loadSigningIdentity(mspDir, mspID, mspType, bccspConfig, factory.GetDefault())
+		},
+		updateIdentity: func(identity []byte) error {
+			if err := gossipService.UpdateIdentity(identity); err != nil {
+				return err
+			}
+			// The signer already signs with the new identity
+			membershipInfoProvider.SetSelfSignedData(createSelfSignedData(signer))
+			expiration.updateIdentity(identity)
+			return nil
+		},
+	}
+
+	if peerServer.TLSEnabled() {
+		cw.tlsPaths = []string{
+			config.GetPath("peer.tls.cert.file"),
+			config.GetPath("peer.tls.key.file"),
+			config.GetPath("peer.tls.clientCert.file"),
+			config.GetPath("peer.tls.clientKey.file"),
+		}
+		cw.loadTLS = func() (tls.Certificate, tls.Certificate, error) {
+			serverCert, err := tls.LoadX509KeyPair(config.GetPath("peer.tls.cert.file"), config.GetPath("peer.tls.key.file"))
+			if err != nil {
+				return tls.Certificate{}, tls.Certificate{}, errors.Wrap(err, "failed loading the TLS server certificate")
+			}
+			clientCert, err := peer.GetClientCertificate()
+			if err != nil {
+				return tls.Certificate{}, tls.Certificate{}, errors.WithMessage(err, "failed loading the TLS client certificate")
+			}
+			return serverCert, clientCert, nil
+		}
+		cw.updateTLS = func(serverCert, clientCert tls.Certificate) {
+			peerServer.SetServerCertificate(serverCert)
+			credSupport.SetClientCertificate(clientCert)
+			gossipService.UpdateTLSCertificates(serverCert, clientCert)
+			expiration.updateTLS(serverCert, clientCert)
+		}
+	}
+
+	cw.identityDigest, _ = digestFiles(cw.identityPaths)
+	cw.tlsDigest, _ = digestFiles(cw.tlsPaths)
+	return cw
+}
+
+// run checks for replaced credentials until stop is called
+func (cw *credentialsWatcher) run() {
+	ticker := time.NewTicker(cw.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-cw.stopChan:
+			return
+		case <-ticker.C:
+			cw.reloadIdentity()
+			cw.reloadTLS()
+		}
+	}
+}
+
+func (cw *credentialsWatcher) stop() {
+	close(cw.stopChan)
+}
+
+func (cw *credentialsWatcher) reloadIdentity() {
+	digest, changed := cw.changed(cw.identityPaths, cw.identityDigest)
+	if !changed {
+		return
+	}
+	// Files may be replaced one by one, so failures are retried until all of them are
+	identity, err := cw.loadIdentity()
+	if err != nil {
+		logger.Warningf("Failed loading the signing identity of the peer: %s", err)
+		return
+	}
+	cw.identityDigest = digest
+
+	serializedIdentity, err := identity.Serialize()
+	if err != nil {
+		logger.Errorf("Failed serializing the signing identity of the peer: %s", err)
+		return
+	}
+	formerSigner := cw.signer.current()
+	formerIdentity, err := formerSigner.Serialize()
+	if err == nil && bytes.Equal(formerIdentity, serializedIdentity) {
+		return
+	}
+
+	cw.signer.set(identity)
+	if err := cw.updateIdentity(serializedIdentity); err != nil {
+		cw.signer.set(formerSigner)
+		logger.Errorf("Failed updating the signing identity of the peer: %s", err)
+		return
+	}
+	logger.Infof("Reloaded the signing identity of the peer, which expires at %s", identity.ExpiresAt())
+}
+
+func (cw *credentialsWatcher) reloadTLS() {
+	digest, changed := cw.changed(cw.tlsPaths, cw.tlsDigest)
+	if !changed {
+		return
+	}
+	serverCert, clientCert, err := cw.loadTLS()
+	if err != nil {
+		logger.Warningf("Failed loading the TLS certificates of the peer: %s", err)
+		return
+	}
+	cw.tlsDigest = digest
+	cw.updateTLS(serverCert, clientCert)
+	logger.Info("Reloaded the TLS certificates of the peer")
+}
+
+func (cw *credentialsWatcher) changed(paths []string, formerDigest []byte) ([]byte, bool) {
+	if len(paths) == 0 {
+		return nil, false
+	}
+	digest, err := digestFiles(paths)
+	if err != nil {
+		logger.Warningf("Failed reading credentials of the peer: %s", err)
+		return nil, false
+	}
+	return digest, !bytes.Equal(digest, formerDigest)
+}
+
+// digestFiles returns a digest of the names and contents of the given files,
+// and of the files in the given directories
+func digestFiles(paths []string) ([]byte, error) {
+	h := sha256.New()
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files := []string{path}
+		if fi.IsDir() {
+			infos, err := ioutil.ReadDir(path)
+			if err != nil {
+				return nil, err
+			}
+			files = nil
+			for _, info := range infos {
+				if !info.IsDir() {
+					files = append(files, filepath.Join(path, info.Name()))
+				}
+			}
+		}
+		for _, file := range files {
+			content, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			h.Write([]byte(file))
+			h.Write(content)
+		}
+	}
+	return h.Sum(nil), nil
+}
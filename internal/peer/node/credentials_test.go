@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type serializedSigningIdentity struct {
+	msp.SigningIdentity
+	serialized []byte
+}
+
+func (s *serializedSigningIdentity) Serialize() ([]byte, error) {
+	return s.serialized, nil
+}
+
+func (s *serializedSigningIdentity) Sign(msg []byte) ([]byte, error) {
+	return append([]byte("signed by "+string(s.serialized)+": "), msg...), nil
+}
+
+func (s *serializedSigningIdentity) ExpiresAt() time.Time {
+	return time.Time{}
+}
+
+func TestLoadSigningIdentity(t *testing.T) {
+	mspDir := configtest.GetDevMspDir()
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(mspDir, "keystore"), true)
+	require.NoError(t, err)
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(ks)
+	require.NoError(t, err)
+
+	signer, err := loadSigningIdentity(mspDir, "SampleOrg", "bccsp", factory.GetDefaultOpts(), cryptoProvider)
+	require.NoError(t, err)
+	require.Equal(t, "SampleOrg", signer.GetMSPIdentifier())
+
+	_, err = loadSigningIdentity(mspDir, "SampleOrg", "unknown", factory.GetDefaultOpts(), cryptoProvider)
+	require.Error(t, err)
+
+	_, err = loadSigningIdentity(filepath.Join(mspDir, "missing"), "SampleOrg", "bccsp", factory.GetDefaultOpts(), cryptoProvider)
+	require.Error(t, err)
+}
+
+func TestReloadableSigner(t *testing.T) {
+	signer := newReloadableSigner(&serializedSigningIdentity{serialized: []byte("A")})
+	serialized, err := signer.Serialize()
+	require.NoError(t, err)
+	require.Equal(t, []byte("A"), serialized)
+
+	signer.set(&serializedSigningIdentity{serialized: []byte("B")})
+	serialized, err = signer.Serialize()
+	require.NoError(t, err)
+	require.Equal(t, []byte("B"), serialized)
+	signature, err := signer.Sign([]byte("msg"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("signed by B: msg"), signature)
+}
+
+func TestDigestFiles(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "credentials")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	certDir := filepath.Join(tempDir, "signcerts")
+	require.NoError(t, os.Mkdir(certDir, 0o755))
+	keyFile := filepath.Join(tempDir, "key.pem")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(certDir, "cert.pem"), []byte("cert"), 0o600))
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte("key"), 0o600))
+
+	paths := []string{certDir, keyFile, filepath.Join(tempDir, "missing")}
+	digest, err := digestFiles(paths)
+	require.NoError(t, err)
+
+	sameDigest, err := digestFiles(paths)
+	require.NoError(t, err)
+	require.Equal(t, digest, sameDigest)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(certDir, "cert.pem"), []byte("renewed cert"), 0o600))
+	newDigest, err := digestFiles(paths)
+	require.NoError(t, err)
+	require.NotEqual(t, digest, newDigest)
+}
+
+func TestCredentialsWatcher(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "credentials")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	certFile := filepath.Join(tempDir, "cert.pem")
+	tlsCertFile := filepath.Join(tempDir, "tls.crt")
+	require.NoError(t, ioutil.WriteFile(certFile, []byte("cert"), 0o600))
+	require.NoError(t, ioutil.WriteFile(tlsCertFile, []byte("tls cert"), 0o600))
+
+	identityUpdates := make(chan []byte, 10)
+	tlsUpdates := make(chan tls.Certificate, 10)
+	var loadIdentityErr, updateIdentityErr error
+
+	signer := newReloadableSigner(&serializedSigningIdentity{serialized: []byte("cert")})
+	cw := &credentialsWatcher{
+		interval:      10 * time.Millisecond,
+		stopChan:      make(chan struct{}),
+		identityPaths: []string{certFile},
+		signer:        signer,
+		loadIdentity: func() (msp.SigningIdentity, error) {
+			if loadIdentityErr != nil {
+				return nil, loadIdentityErr
+			}
+			content, err := ioutil.ReadFile(certFile)
+			if err != nil {
+				return nil, err
+			}
+			return &serializedSigningIdentity{serialized: content}, nil
+		},
+		updateIdentity: func(identity []byte) error {
+			if updateIdentityErr != nil {
+				return updateIdentityErr
+			}
+			identityUpdates <- identity
+			return nil
+		},
+		tlsPaths: []string{tlsCertFile},
+		loadTLS: func() (tls.Certificate, tls.Certificate, error) {
+			content, err := ioutil.ReadFile(tlsCertFile)
+			if err != nil {
+				return tls.Certificate{}, tls.Certificate{}, err
+			}
+			cert := tls.Certificate{Certificate: [][]byte{content}}
+			return cert, cert, nil
+		},
+		updateTLS: func(serverCert, clientCert tls.Certificate) {
+			tlsUpdates <- serverCert
+		},
+	}
+	cw.identityDigest, _ = digestFiles(cw.identityPaths)
+	cw.tlsDigest, _ = digestFiles(cw.tlsPaths)
+
+	t.Run("unchanged credentials", func(t *testing.T) {
+		cw.reloadIdentity()
+		cw.reloadTLS()
+		require.Empty(t, identityUpdates)
+		require.Empty(t, tlsUpdates)
+	})
+
+	t.Run("failure loading the identity", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(certFile, []byte("partially written cert"), 0o600))
+		loadIdentityErr = errors.New("no matching key")
+		cw.reloadIdentity()
+		require.Empty(t, identityUpdates)
+
+		// The identity is loaded again at the next check
+		loadIdentityErr = nil
+		cw.reloadIdentity()
+		require.Equal(t, []byte("partially written cert"), <-identityUpdates)
+	})
+
+	t.Run("failure updating the identity", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(certFile, []byte("cert of another org"), 0o600))
+		updateIdentityErr = errors.New("identity of another organization")
+		cw.reloadIdentity()
+		require.Empty(t, identityUpdates)
+		serialized, err := signer.Serialize()
+		require.NoError(t, err)
+		require.Equal(t, []byte("partially written cert"), serialized)
+		updateIdentityErr = nil
+	})
+
+	t.Run("renewed credentials", func(t *testing.T) {
+		require.NoError(t, ioutil.WriteFile(certFile, []byte("renewed cert"), 0o600))
+		require.NoError(t, ioutil.WriteFile(tlsCertFile, []byte("renewed tls cert"), 0o600))
+		go cw.run()
+		defer cw.stop()
+
+		select {
+		case identity := <-identityUpdates:
+			require.Equal(t, []byte("renewed cert"), identity)
+		case <-time.After(5 * time.Second):
+			t.Fatal("identity was not reloaded")
+		}
+		select {
+		case cert := <-tlsUpdates:
+			require.Equal(t, [][]byte{[]byte("renewed tls cert")}, cert.Certificate)
+		case <-time.After(5 * time.Second):
+			t.Fatal("TLS certificates were not reloaded")
+		}
+		serialized, err := signer.Serialize()
+		require.NoError(t, err)
+		require.Equal(t, []byte("renewed cert"), serialized)
+	})
+}
+
+func TestExpirationTracker(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	enrollmentCert, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+	renewedCert, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+	serverCert, err := ca.NewServerCertKeyPair("localhost")
+	require.NoError(t, err)
+	serializedIdentity := func(cert []byte) []byte {
+		return protoutil.MarshalOrPanic(&mspproto.SerializedIdentity{Mspid: "SampleOrg", IdBytes: cert})
+	}
+
+	var warnings []string
+	et := &expirationTracker{
+		identity: serializedIdentity(enrollmentCert.Cert),
+		info:     func(string, ...interface{}) {},
+		warn: func(format string, args ...interface{}) {
+			warnings = append(warnings, fmt.Sprintf(format, args...))
+		},
+		now: func() time.Time {
+			return enrollmentCert.TLSCert.NotAfter.Add(-8 * 24 * time.Hour)
+		},
+		schedule: func(d time.Duration, f func()) *time.Timer {
+			require.True(t, d > 0, "unexpected duration %s", d)
+			return time.AfterFunc(time.Hour, f)
+		},
+	}
+	et.track()
+	require.Len(t, et.timers, 1)
+	enrollmentTimer := et.timers[0]
+
+	// the warning for the former enrollment certificate is canceled
+	et.updateIdentity(serializedIdentity(renewedCert.Cert))
+	require.Len(t, et.timers, 1)
+	require.False(t, enrollmentTimer.Stop())
+	require.NotSame(t, enrollmentTimer, et.timers[0])
+
+	et.tls = true
+	serverCertBlock, _ := pem.Decode(serverCert.Cert)
+	et.updateTLS(tls.Certificate{Certificate: [][]byte{serverCertBlock.Bytes}}, tls.Certificate{})
+	require.Equal(t, serverCert.Cert, et.serverCert)
+	require.Len(t, et.timers, 2)
+
+	et.stop()
+	require.Empty(t, et.timers)
+	require.Empty(t, warnings)
+}
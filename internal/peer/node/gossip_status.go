@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var operationsConfig common.OperationsClientConfig
+
+func gossipStatusCmd() *cobra.Command {
+	nodeGossipStatusCmd.ResetFlags()
+	common.AddOperationsFlags(nodeGossipStatusCmd.Flags(), &operationsConfig)
+
+	return nodeGossipStatusCmd
+}
+
+var nodeGossipStatusCmd = &cobra.Command{
+	Use:   "gossip-status",
+	Short: "Retrieves the state of the gossip layer of a peer.",
+	Long: "Retrieves the membership, the channel state information, the organization leaders, the block queues" +
+		" and the pending private data pulls and reconciliations known by the gossip layer of a running peer from its operations endpoint, and writes them as JSON.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := common.NewOperationsClient(operationsConfig)
+		if err != nil {
+			return err
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		return gossipStatus(context.Background(), client, os.Stdout)
+	},
+}
+
+func gossipStatus(ctx context.Context, client *common.OperationsClient, w io.Writer) error {
+	resp, err := client.Get(ctx, "/gossip/status", nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to retrieve gossip status")
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(err, "failed to read gossip status")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGossipStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gossip/status" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"self":{"pki_id":"01","endpoint":"peer0:7051"}}`))
+	}))
+	defer server.Close()
+
+	client, err := common.NewOperationsClient(common.OperationsClientConfig{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = gossipStatus(context.Background(), client, buf)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"self":{"pki_id":"01","endpoint":"peer0:7051"}}`, buf.String())
+
+	client.BaseURL += "/missing"
+	err = gossipStatus(context.Background(), client, buf)
+	require.EqualError(t, err, "failed to retrieve gossip status: operations request failed with status 404: not found")
+}
+
+func TestGossipStatusCmd(t *testing.T) {
+	cmd := gossipStatusCmd()
+	cmd.SetArgs([]string{})
+	err := cmd.Execute()
+	require.EqualError(t, err, "the operations endpoint address must be specified")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	relinquishLeadership bool
+	resumeLeadership     bool
+)
+
+func leadershipCmd() *cobra.Command {
+	nodeLeadershipCmd.ResetFlags()
+	flags := nodeLeadershipCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to relinquish or resume the leadership for.")
+	flags.BoolVarP(&relinquishLeadership, "relinquish", "", false, "Relinquish the leadership of the peer's organization in the channel.")
+	flags.BoolVarP(&resumeLeadership, "resume", "", false, "Let the peer become the leader of its organization in the channel again.")
+	common.AddOperationsFlags(flags, &operationsConfig)
+
+	return nodeLeadershipCmd
+}
+
+var nodeLeadershipCmd = &cobra.Command{
+	Use:   "leadership",
+	Short: "Retrieves or changes the leader election state of a peer.",
+	Long: "Retrieves the leader election state of a running peer for the channels it uses leader election for, and writes it as JSON." +
+		" With --relinquish, the peer relinquishes the leadership of its organization in a channel and does not become a leader" +
+		" until --resume is used, for instance for the peer to be maintained.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if relinquishLeadership && resumeLeadership {
+			return errors.New("only one of --relinquish and --resume may be specified")
+		}
+		if (relinquishLeadership || resumeLeadership) && channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+
+		client, err := common.NewOperationsClient(operationsConfig)
+		if err != nil {
+			return err
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		if !relinquishLeadership && !resumeLeadership {
+			return leadership(context.Background(), client, os.Stdout)
+		}
+		return setLeadership(context.Background(), client, channelID, relinquishLeadership)
+	},
+}
+
+func leadership(ctx context.Context, client *common.OperationsClient, w io.Writer) error {
+	resp, err := client.Get(ctx, "/gossip/leadership", nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to retrieve leadership")
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(err, "failed to read leadership")
+	}
+	return nil
+}
+
+func setLeadership(ctx context.Context, client *common.OperationsClient, channelID string, relinquished bool) error {
+	resp, err := client.Put(ctx, "/gossip/leadership", &service.LeadershipRequest{
+		Channel:      channelID,
+		Relinquished: relinquished,
+	})
+	if err != nil {
+		if relinquished {
+			return errors.WithMessagef(err, "failed to relinquish leadership for channel %s", channelID)
+		}
+		return errors.WithMessagef(err, "failed to resume leadership for channel %s", channelID)
+	}
+	resp.Body.Close()
+	return nil
+}
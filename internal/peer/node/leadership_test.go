@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeadership(t *testing.T) {
+	var requests []service.LeadershipRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gossip/leadership" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[{"channel":"mychannel","priority":5,"is_leader":true,"relinquished":false}]`))
+		case http.MethodPut:
+			var req service.LeadershipRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Channel != "mychannel" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"leader election is not used for channel ` + req.Channel + `"}`))
+				return
+			}
+			requests = append(requests, req)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client, err := common.NewOperationsClient(common.OperationsClientConfig{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = leadership(context.Background(), client, buf)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"channel":"mychannel","priority":5,"is_leader":true,"relinquished":false}]`, buf.String())
+
+	err = setLeadership(context.Background(), client, "mychannel", true)
+	require.NoError(t, err)
+	err = setLeadership(context.Background(), client, "mychannel", false)
+	require.NoError(t, err)
+	require.Equal(t, []service.LeadershipRequest{
+		{Channel: "mychannel", Relinquished: true},
+		{Channel: "mychannel", Relinquished: false},
+	}, requests)
+
+	err = setLeadership(context.Background(), client, "otherchannel", true)
+	require.EqualError(t, err, "failed to relinquish leadership for channel otherchannel: operations request failed with status 400: leader election is not used for channel otherchannel")
+	err = setLeadership(context.Background(), client, "otherchannel", false)
+	require.EqualError(t, err, "failed to resume leadership for channel otherchannel: operations request failed with status 400: leader election is not used for channel otherchannel")
+
+	client.BaseURL += "/missing"
+	err = leadership(context.Background(), client, buf)
+	require.EqualError(t, err, "failed to retrieve leadership: operations request failed with status 404: not found")
+}
+
+func TestLeadershipCmd(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "NoOperationsAddress",
+			args:        []string{},
+			expectedErr: "the operations endpoint address must be specified",
+		},
+		{
+			name:        "RelinquishAndResume",
+			args:        []string{"-c", "mychannel", "--relinquish", "--resume"},
+			expectedErr: "only one of --relinquish and --resume may be specified",
+		},
+		{
+			name:        "MissingChannel",
+			args:        []string{"--relinquish"},
+			expectedErr: "Must supply channel ID",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cmd := leadershipCmd()
+			cmd.SetArgs(testCase.args)
+			err := cmd.Execute()
+			require.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -15,7 +15,7 @@ import (
 
 const (
 	nodeFuncName = "node"
-	nodeCmdDes   = "Operate a peer node: start|reset|rollback|pause|resume|rebuild-dbs|upgrade-dbs."
+	nodeCmdDes   = "Operate a peer node: start|reset|rollback|prune|pause|resume|rebuild-dbs|upgrade-dbs|compress-blockfiles|gossip-status|leadership|reconcile."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -25,10 +25,15 @@ func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(startCmd())
 	nodeCmd.AddCommand(resetCmd())
 	nodeCmd.AddCommand(rollbackCmd())
+	nodeCmd.AddCommand(pruneCmd())
 	nodeCmd.AddCommand(pauseCmd())
 	nodeCmd.AddCommand(resumeCmd())
 	nodeCmd.AddCommand(rebuildDBsCmd())
 	nodeCmd.AddCommand(upgradeDBsCmd())
+	nodeCmd.AddCommand(compressBlockfilesCmd())
+	nodeCmd.AddCommand(gossipStatusCmd())
+	nodeCmd.AddCommand(leadershipCmd())
+	nodeCmd.AddCommand(reconcileCmd())
 	return nodeCmd
 }
 
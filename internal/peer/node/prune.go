@@ -0,0 +1,39 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func pruneCmd() *cobra.Command {
+	nodePruneCmd.ResetFlags()
+	flags := nodePruneCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to prune.")
+	flags.Uint64VarP(&blockNumber, "blockNumber", "b", 0, "Block number of the snapshot up to which the blocks are pruned.")
+
+	return nodePruneCmd
+}
+
+var nodePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Prunes the blocks of a channel below a snapshot.",
+	Long: "Removes the blocks of a channel up to and including the last block of a snapshot generated by this peer." +
+		" When the command is executed, the peer must be offline. After the command completes, the channel is in the" +
+		" same state as a channel that was joined by the snapshot and the pruned blocks cannot be retrieved from this peer.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+
+		config := ledgerConfig()
+		return kvledger.PruneBlockStore(config.RootFSPath, config.SnapshotsConfig.RootDir, channelID, blockNumber)
+	},
+}
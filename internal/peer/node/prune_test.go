@@ -0,0 +1,31 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneCmd(t *testing.T) {
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		cmd := pruneCmd()
+		args := []string{}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		require.Equal(t, "Must supply channel ID", err.Error())
+	})
+
+	t.Run("when the snapshot for the specified channelID does not exist", func(t *testing.T) {
+		cmd := pruneCmd()
+		args := []string{"-c", "ch1", "-b", "10"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		require.Contains(t, err.Error(), "error while loading metadata of the snapshot at block number [10] for channel [ch1]")
+	})
+}
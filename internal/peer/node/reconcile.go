@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reconcileFromBlock  uint64
+	reconcileToBlock    uint64
+	reconcileNamespace  string
+	reconcileCollection string
+	reconcileStatus     bool
+	missingPvtData      bool
+)
+
+func reconcileCmd() *cobra.Command {
+	nodeReconcileCmd.ResetFlags()
+	flags := nodeReconcileCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to reconcile the missing private data for.")
+	flags.Uint64VarP(&reconcileFromBlock, "from", "", 0, "First block of the range of blocks to reconcile the missing private data of.")
+	flags.Uint64VarP(&reconcileToBlock, "to", "", 0, "Last block of the range of blocks to reconcile the missing private data of.")
+	flags.StringVarP(&reconcileNamespace, "namespace", "", "", "Only reconcile or list the missing private data of this namespace (chaincode).")
+	flags.StringVarP(&reconcileCollection, "collection", "", "", "Only reconcile or list the missing private data of this collection of the namespace.")
+	flags.BoolVarP(&reconcileStatus, "status", "", false, "Retrieve the progress of the reconciliation requests of the channel instead of enqueuing a request.")
+	flags.BoolVarP(&missingPvtData, "missing", "", false, "List the outstanding missing private data of the channel by namespace and collection instead of enqueuing a request.")
+	common.AddOperationsFlags(flags, &operationsConfig)
+
+	return nodeReconcileCmd
+}
+
+var nodeReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Reconciles the missing private data of a range of blocks.",
+	Long: "Enqueues the reconciliation of the missing private data of the blocks within --from and --to of a channel on a running peer," +
+		" ahead of the periodic reconciliation, and writes the progress of the request as JSON. With --status, retrieves the progress" +
+		" of the reconciliation requests of the channel. With --missing, lists the outstanding missing private data of the channel" +
+		" by namespace and collection.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		if reconcileStatus && missingPvtData {
+			return errors.New("only one of --status and --missing may be specified")
+		}
+		if reconcileCollection != "" && reconcileNamespace == "" {
+			return errors.New("--collection requires --namespace")
+		}
+		if !reconcileStatus && !missingPvtData {
+			if !cmd.Flags().Changed("to") {
+				return errors.New("Must supply the last block to reconcile with --to")
+			}
+			if reconcileFromBlock > reconcileToBlock {
+				return errors.Errorf("invalid block range [%d, %d]", reconcileFromBlock, reconcileToBlock)
+			}
+		}
+
+		client, err := common.NewOperationsClient(operationsConfig)
+		if err != nil {
+			return err
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		switch {
+		case reconcileStatus:
+			return reconcileProgress(context.Background(), client, channelID, os.Stdout)
+		case missingPvtData:
+			return listMissingPvtData(context.Background(), client, channelID, reconcileNamespace, reconcileCollection, os.Stdout)
+		default:
+			return reconcile(context.Background(), client, channelID, gossipprivdata.ReconcileRequest{
+				StartBlock: reconcileFromBlock,
+				EndBlock:   reconcileToBlock,
+				Namespace:  reconcileNamespace,
+				Collection: reconcileCollection,
+			}, os.Stdout)
+		}
+	},
+}
+
+func reconcile(ctx context.Context, client *common.OperationsClient, channelID string, request gossipprivdata.ReconcileRequest, w io.Writer) error {
+	resp, err := client.Post(ctx, "/gossip/reconcile", &service.ReconcileRequest{
+		Channel:          channelID,
+		ReconcileRequest: request,
+	})
+	if err != nil {
+		return errors.WithMessagef(err, "failed to reconcile missing private data for channel %s", channelID)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(err, "failed to read reconciliation progress")
+	}
+	return nil
+}
+
+func reconcileProgress(ctx context.Context, client *common.OperationsClient, channelID string, w io.Writer) error {
+	resp, err := client.Get(ctx, "/gossip/reconcile", url.Values{"channel": []string{channelID}})
+	if err != nil {
+		return errors.WithMessagef(err, "failed to retrieve reconciliation progress for channel %s", channelID)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(err, "failed to read reconciliation progress")
+	}
+	return nil
+}
+
+func listMissingPvtData(ctx context.Context, client *common.OperationsClient, channelID, namespace, collection string, w io.Writer) error {
+	query := url.Values{"channel": []string{channelID}}
+	if namespace != "" {
+		query.Set("namespace", namespace)
+	}
+	if collection != "" {
+		query.Set("collection", collection)
+	}
+	resp, err := client.Get(ctx, "/gossip/missingpvtdata", query)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to retrieve missing private data for channel %s", channelID)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrap(err, "failed to read missing private data")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	var requests []service.ReconcileRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		channelID := r.URL.Query().Get("channel")
+		if r.Method == http.MethodPost {
+			var req service.ReconcileRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			channelID = req.Channel
+			requests = append(requests, req)
+		}
+		if channelID != "mychannel" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"channel ` + channelID + ` is not initialized"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/gossip/reconcile" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":1,"start_block":5,"end_block":10,"state":"queued","next_block":10}`))
+		case r.URL.Path == "/gossip/reconcile" && r.Method == http.MethodGet:
+			w.Write([]byte(`[{"id":1,"start_block":5,"end_block":10,"state":"completed","next_block":5,"blocks":2,"reconciled":3}]`))
+		case r.URL.Path == "/gossip/missingpvtdata":
+			w.Write([]byte(`[{"namespace":"` + r.URL.Query().Get("namespace") + `","collection":"` + r.URL.Query().Get("collection") + `","blocks":1}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := common.NewOperationsClient(common.OperationsClientConfig{
+		Address: strings.TrimPrefix(server.URL, "http://"),
+	})
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	err = reconcile(context.Background(), client, "mychannel", gossipprivdata.ReconcileRequest{StartBlock: 5, EndBlock: 10, Namespace: "ns", Collection: "coll"}, buf)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"id":1,"start_block":5,"end_block":10,"state":"queued","next_block":10}`, buf.String())
+	require.Equal(t, []service.ReconcileRequest{
+		{
+			Channel:          "mychannel",
+			ReconcileRequest: gossipprivdata.ReconcileRequest{StartBlock: 5, EndBlock: 10, Namespace: "ns", Collection: "coll"},
+		},
+	}, requests)
+
+	buf.Reset()
+	err = reconcileProgress(context.Background(), client, "mychannel", buf)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"id":1,"start_block":5,"end_block":10,"state":"completed","next_block":5,"blocks":2,"reconciled":3}]`, buf.String())
+
+	buf.Reset()
+	err = listMissingPvtData(context.Background(), client, "mychannel", "ns", "coll", buf)
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"namespace":"ns","collection":"coll","blocks":1}]`, buf.String())
+
+	err = reconcile(context.Background(), client, "otherchannel", gossipprivdata.ReconcileRequest{EndBlock: 10}, buf)
+	require.EqualError(t, err, "failed to reconcile missing private data for channel otherchannel: operations request failed with status 400: channel otherchannel is not initialized")
+	err = reconcileProgress(context.Background(), client, "otherchannel", buf)
+	require.EqualError(t, err, "failed to retrieve reconciliation progress for channel otherchannel: operations request failed with status 400: channel otherchannel is not initialized")
+	err = listMissingPvtData(context.Background(), client, "otherchannel", "", "", buf)
+	require.EqualError(t, err, "failed to retrieve missing private data for channel otherchannel: operations request failed with status 400: channel otherchannel is not initialized")
+}
+
+func TestReconcileCmd(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "MissingChannel",
+			args:        []string{"--from", "5", "--to", "10"},
+			expectedErr: "Must supply channel ID",
+		},
+		{
+			name:        "StatusAndMissing",
+			args:        []string{"-c", "mychannel", "--status", "--missing"},
+			expectedErr: "only one of --status and --missing may be specified",
+		},
+		{
+			name:        "CollectionWithoutNamespace",
+			args:        []string{"-c", "mychannel", "--to", "10", "--collection", "coll"},
+			expectedErr: "--collection requires --namespace",
+		},
+		{
+			name:        "MissingToBlock",
+			args:        []string{"-c", "mychannel", "--from", "5"},
+			expectedErr: "Must supply the last block to reconcile with --to",
+		},
+		{
+			name:        "InvalidRange",
+			args:        []string{"-c", "mychannel", "--from", "10", "--to", "5"},
+			expectedErr: "invalid block range [10, 5]",
+		},
+		{
+			name:        "NoOperationsAddress",
+			args:        []string{"-c", "mychannel", "--status"},
+			expectedErr: "the operations endpoint address must be specified",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cmd := reconcileCmd()
+			cmd.SetArgs(testCase.args)
+			err := cmd.Execute()
+			require.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
@@ -26,10 +26,10 @@ import (
 	discprotos "github.com/hyperledger/fabric-protos-go/discovery"
 	gatewayprotos "github.com/hyperledger/fabric-protos-go/gateway"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ccdef "github.com/hyperledger/fabric/common/chaincode"
-	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/fabhttp"
@@ -54,6 +54,7 @@ import (
 	"github.com/hyperledger/fabric/core/common/privdata"
 	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/cclogs"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
 	"github.com/hyperledger/fabric/core/container/externalbuilder"
 	"github.com/hyperledger/fabric/core/deliverservice"
@@ -69,6 +70,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
+	snapshotmsgs "github.com/hyperledger/fabric/core/ledger/snapshotgrpc/msgs"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/policy"
@@ -79,6 +81,7 @@ import (
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/discovery"
 	"github.com/hyperledger/fabric/discovery/endorsement"
+	discmsgs "github.com/hyperledger/fabric/discovery/msgs"
 	discsupport "github.com/hyperledger/fabric/discovery/support"
 	discacl "github.com/hyperledger/fabric/discovery/support/acl"
 	ccsupport "github.com/hyperledger/fabric/discovery/support/chaincode"
@@ -93,6 +96,7 @@ import (
 	"github.com/hyperledger/fabric/internal/peer/version"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/gateway"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protoutil"
@@ -153,12 +157,20 @@ func (e externalVMAdapter) Build(
 	return i, err
 }
 
+func (e externalVMAdapter) RemoveBuild(ccid string) error {
+	return e.detector.RemoveBuild(ccid)
+}
+
 type disabledDockerBuilder struct{}
 
 func (disabledDockerBuilder) Build(string, *persistence.ChaincodePackageMetadata, io.Reader) (container.Instance, error) {
 	return nil, errors.New("docker build is disabled")
 }
 
+func (disabledDockerBuilder) RemoveBuild(string) error {
+	return nil
+}
+
 type endorserChannelAdapter struct {
 	peer *peer.Peer
 }
@@ -173,6 +185,19 @@ func (e endorserChannelAdapter) Channel(channelID string) *endorser.Channel {
 	return nil
 }
 
+type txBlockFinderAdapter struct {
+	peer *peer.Peer
+}
+
+func (t txBlockFinderAdapter) TxBlockNumber(channelID, txID string) (uint64, error) {
+	l := t.peer.GetLedger(channelID)
+	if l == nil {
+		return 0, errors.Errorf("cannot find ledger for channel %s", channelID)
+	}
+	_, blockNumber, err := l.GetTxValidationCodeByTxID(txID)
+	return blockNumber, err
+}
+
 type custodianLauncherAdapter struct {
 	launcher      chaincode.Launcher
 	streamHandler extcc.StreamHandler
@@ -288,6 +313,7 @@ func serve(args []string) error {
 		StoreProvider:            transientStoreProvider,
 		CryptoProvider:           factory.GetDefault(),
 		OrdererEndpointOverrides: deliverServiceConfig.OrdererEndpointOverrides,
+		SnapshotFetchTimeout:     viper.GetDuration("ledger.snapshots.fetchTimeout"),
 	}
 
 	identityDeserializerFactory := func(channelName string) msp.IdentityDeserializer {
@@ -308,24 +334,28 @@ func serve(args []string) error {
 	if err != nil {
 		logger.Panicf("Failed to serialize the signing identity: %v", err)
 	}
+	// The signing identity may be replaced while the peer is running if
+	// the credentials are reloaded
+	signer := newReloadableSigner(signingIdentity)
 
+	// The self signed data and the tracked certificates are replaced
+	// when the credentials are reloaded
 	membershipInfoProvider := privdata.NewMembershipInfoProvider(
 		mspID,
-		createSelfSignedData(signingIdentity),
+		createSelfSignedData(signer),
 		identityDeserializerFactory,
 	)
 
 	expirationLogger := flogging.MustGetLogger("certmonitor")
-	crypto.TrackExpiration(
+	expiration := newExpirationTracker(
 		serverConfig.SecOpts.UseTLS,
 		serverConfig.SecOpts.Certificate,
 		cs.GetClientCertificate().Certificate,
 		signingIdentityBytes,
 		expirationLogger.Infof,
 		expirationLogger.Warnf, // This can be used to piggyback a metric event in the future
-		time.Now(),
-		time.AfterFunc,
 	)
+	defer expiration.stop()
 
 	policyMgr := policies.PolicyManagerGetterFunc(peerInstance.GetPolicyManager)
 
@@ -452,7 +482,7 @@ func serve(args []string) error {
 		policyMgr,
 		metricsProvider,
 		peerServer,
-		signingIdentity,
+		signer,
 		cs,
 		coreConfig.PeerAddress,
 		deliverServiceConfig,
@@ -463,7 +493,31 @@ func serve(args []string) error {
 	}
 	defer gossipService.Stop()
 
+	if coreConfig.CredentialsReloadInterval > 0 {
+		credentialsWatcher := newCredentialsWatcher(
+			coreConfig.CredentialsReloadInterval,
+			signer,
+			gossipService,
+			peerServer,
+			cs,
+			membershipInfoProvider,
+			expiration,
+		)
+		go credentialsWatcher.run()
+		defer credentialsWatcher.stop()
+	}
+
 	peerInstance.GossipService = gossipService
+	peerInstance.SnapshotFetcher = &snapshotgrpc.Fetcher{
+		Signer:        signer,
+		HashProvider:  factory.GetDefault(),
+		OrgMembership: gossipService,
+		DialOptions:   secureDialOpts(cs),
+	}
+	opsSystem.RegisterHandler("/gossip/status", gossipservice.NewStatusHandler(gossipService), coreConfig.OperationsTLSEnabled)
+	opsSystem.RegisterHandler("/gossip/leadership", gossipservice.NewLeadershipHandler(gossipService), coreConfig.OperationsTLSEnabled)
+	opsSystem.RegisterHandler("/gossip/reconcile", gossipservice.NewReconcileHandler(gossipService), coreConfig.OperationsTLSEnabled)
+	opsSystem.RegisterHandler("/gossip/missingpvtdata", gossipservice.NewMissingPvtDataHandler(gossipService), coreConfig.OperationsTLSEnabled)
 
 	if err := lifecycleCache.InitializeLocalChaincodes(); err != nil {
 		return errors.WithMessage(err, "could not initialize local chaincodes")
@@ -527,6 +581,12 @@ func serve(args []string) error {
 
 	chaincodeConfig := chaincode.GlobalConfig()
 
+	var chaincodeLogs *cclogs.Store
+	if chaincodeConfig.LogCapture.Enabled {
+		chaincodeLogs = cclogs.NewStore(chaincodeConfig.LogCapture.MaxLines, chaincodeConfig.LogCapture.MaxBytes)
+		opsSystem.RegisterHandler("/chaincode/logs", cclogs.NewHandler(chaincodeLogs), coreConfig.OperationsTLSEnabled)
+	}
+
 	var dockerBuilder container.DockerBuilder
 	if coreConfig.VMEndpoint != "" {
 		client, err := createDockerClient(coreConfig)
@@ -555,7 +615,14 @@ func serve(args []string) error {
 				"CORE_CHAINCODE_LOGGING_SHIM=" + chaincodeConfig.ShimLogLevel,
 				"CORE_CHAINCODE_LOGGING_FORMAT=" + chaincodeConfig.LogFormat,
 			},
-			MSPID: mspID,
+			MSPID:                mspID,
+			ChaincodeHostConfigs: getChaincodeHostConfigs(),
+		}
+		if chaincodeLogs != nil {
+			dockerVM.ChaincodeLogs = chaincodeLogs
+		}
+		if err := dockerVM.Validate(); err != nil {
+			return errors.WithMessage(err, "invalid docker configuration")
 		}
 		if err := opsSystem.RegisterChecker("docker", dockerVM); err != nil {
 			logger.Panicf("failed to register docker health check: %s", err)
@@ -572,6 +639,11 @@ func serve(args []string) error {
 		Builders:    externalbuilder.CreateBuilders(coreConfig.ExternalBuilders, mspID),
 		DurablePath: externalBuilderOutput,
 	}
+	if chaincodeLogs != nil {
+		for _, builder := range externalVM.Builders {
+			builder.ChaincodeLogs = chaincodeLogs
+		}
+	}
 
 	buildRegistry := &container.BuildRegistry{}
 
@@ -624,11 +696,20 @@ func serve(args []string) error {
 		ContainerRouter: containerRouter,
 	}
 
+	packageVerifier, err := getPackageVerifier(factory.GetDefault())
+	if err != nil {
+		return errors.WithMessage(err, "failed to initialize chaincode install policy")
+	}
+
 	lifecycleFunctions := &lifecycle.ExternalFunctions{
 		Resources:                 lifecycleResources,
 		InstallListener:           lifecycleCache,
+		UninstallListener:         lifecycleCache,
 		InstalledChaincodesLister: lifecycleCache,
+		ChannelStates:             &lifecycle.LedgerChannelStates{ChannelLedgers: peerInstance, OrgMSPID: mspID},
 		ChaincodeBuilder:          containerRouter,
+		BuildRemover:              containerRouter,
+		PackageVerifier:           packageVerifier,
 		BuildRegistry:             buildRegistry,
 	}
 
@@ -638,14 +719,16 @@ func serve(args []string) error {
 		},
 		DeployedCCInfoProvider: lifecycleValidatorCommitter,
 		QueryExecutorProvider:  lifecycleTxQueryExecutorGetter,
+		TxBlockFinder:          txBlockFinderAdapter{peer: peerInstance},
 		Functions:              lifecycleFunctions,
 		OrgMSPID:               mspID,
 		ChannelConfigSource:    peerInstance,
 		ACLProvider:            aclProvider,
 	}
 
+	launchMetrics := chaincode.NewLaunchMetrics(opsSystem.Provider)
 	chaincodeLauncher := &chaincode.RuntimeLauncher{
-		Metrics:           chaincode.NewLaunchMetrics(opsSystem.Provider),
+		Metrics:           launchMetrics,
 		Registry:          chaincodeHandlerRegistry,
 		Runtime:           containerRuntime,
 		StartupTimeout:    chaincodeConfig.StartupTimeout,
@@ -653,6 +736,7 @@ func serve(args []string) error {
 		CACert:            ca.CertBytes(),
 		PeerAddress:       ccEndpoint,
 		ConnectionHandler: &extcc.ExternalChaincodeRuntime{},
+		Instances:         chaincodeConfig.Instances,
 	}
 
 	// Keep TestQueries working
@@ -660,6 +744,17 @@ func serve(args []string) error {
 		chaincodeLauncher.CertGenerator = nil
 	}
 
+	if chaincodeConfig.Restart.Enabled && !userRunsCC {
+		restartTracker := &chaincode.RestartTracker{
+			InitialBackoff: chaincodeConfig.Restart.InitialBackoff,
+			MaxBackoff:     chaincodeConfig.Restart.MaxBackoff,
+		}
+		if err := opsSystem.RegisterChecker("chaincode", restartTracker); err != nil {
+			logger.Panicf("failed to register chaincode health check: %s", err)
+		}
+		chaincodeLauncher.RestartTracker = restartTracker
+	}
+
 	chaincodeSupport := &chaincode.ChaincodeSupport{
 		ACLProvider:            aclProvider,
 		AppConfig:              peerInstance,
@@ -670,8 +765,10 @@ func serve(args []string) error {
 		HandlerMetrics:         chaincode.NewHandlerMetrics(opsSystem.Provider),
 		Keepalive:              chaincodeConfig.Keepalive,
 		Launcher:               chaincodeLauncher,
+		LaunchMetrics:          launchMetrics,
 		Lifecycle:              chaincodeEndorsementInfo,
 		Peer:                   peerInstance,
+		ResourceLimits:         &chaincodeConfig.ResourceLimits,
 		Runtime:                containerRuntime,
 		BuiltinSCCs:            builtinSCCs,
 		TotalQueryLimit:        chaincodeConfig.TotalQueryLimit,
@@ -683,6 +780,17 @@ func serve(args []string) error {
 		streamHandler: chaincodeSupport,
 	}
 	go chaincodeCustodian.Work(buildRegistry, containerRouter, custodianLauncher)
+	lifecycleFunctions.ChaincodeLauncher = custodianLauncher
+
+	if chaincodeConfig.IdleTimeout > 0 && !userRunsCC {
+		idleMonitor := &chaincode.IdleMonitor{
+			Registry:    chaincodeHandlerRegistry,
+			Stopper:     chaincodeLauncher,
+			IdleTimeout: chaincodeConfig.IdleTimeout,
+			Metrics:     launchMetrics,
+		}
+		go idleMonitor.Run()
+	}
 
 	ccSupSrv := pb.ChaincodeSupportServer(chaincodeSupport)
 	if tlsEnabled {
@@ -715,7 +823,7 @@ func serve(args []string) error {
 
 	authFilters := reg.Lookup(library.Auth).([]authHandler.Filter)
 	endorserSupport := &endorser.SupportImpl{
-		SignerSerializer: signingIdentity,
+		SignerSerializer: signer,
 		Peer:             peerInstance,
 		ChaincodeSupport: chaincodeSupport,
 		ACLProvider:      aclProvider,
@@ -815,6 +923,7 @@ func serve(args []string) error {
 		)
 		logger.Info("Discovery service activated")
 		discprotos.RegisterDiscoveryServer(peerServer.Server(), discoveryService)
+		discmsgs.RegisterDiscoveryWatchServer(peerServer.Server(), discoveryService)
 	}
 
 	if coreConfig.GatewayOptions.Enabled {
@@ -898,6 +1007,14 @@ func serve(args []string) error {
 	snapshotSvc := &snapshotgrpc.SnapshotService{LedgerGetter: peerInstance, ACLProvider: aclProvider}
 	pb.RegisterSnapshotServer(peerServer.Server(), snapshotSvc)
 
+	// register the snapshot transfer server, which serves snapshots to other peers of the organization
+	transferSvc := &snapshotgrpc.TransferService{
+		SnapshotsRootDir: ledgerConfig().SnapshotsConfig.RootDir,
+		HashProvider:     factory.GetDefault(),
+		ACLProvider:      aclProvider,
+	}
+	snapshotmsgs.RegisterSnapshotTransferServer(peerServer.Server(), transferSvc)
+
 	go func() {
 		var grpcErr error
 		if grpcErr = peerServer.Start(); grpcErr != nil {
@@ -937,7 +1054,7 @@ func localPolicy(policyObject proto.Message) policies.Policy {
 	return policy
 }
 
-func createSelfSignedData(sID msp.SigningIdentity) protoutil.SignedData {
+func createSelfSignedData(sID protoutil.Signer) protoutil.SignedData {
 	msg := make([]byte, 32)
 	sig, err := sID.Sign(msg)
 	if err != nil {
@@ -990,6 +1107,7 @@ func createDiscoveryService(
 		AuthCacheEnabled:             coreConfig.DiscoveryAuthCacheEnabled,
 		AuthCacheMaxSize:             coreConfig.DiscoveryAuthCacheMaxSize,
 		AuthCachePurgeRetentionRatio: coreConfig.DiscoveryAuthCachePurgeRetentionRatio,
+		WatchInterval:                coreConfig.DiscoveryWatchInterval,
 	}, support)
 }
 
@@ -1180,7 +1298,7 @@ func initGossipService(
 	policyMgr policies.ChannelPolicyManagerGetter,
 	metricsProvider metrics.Provider,
 	peerServer *comm.GRPCServer,
-	signer msp.SigningIdentity,
+	signer identity.SignerSerializer,
 	credSupport *comm.CredentialSupport,
 	peerAddress string,
 	deliverServiceConfig *deliverservice.DeliverServiceConfig,
@@ -1278,6 +1396,12 @@ func getDockerHostConfig() *docker.HostConfig {
 	memorySwappiness := getInt64("MemorySwappiness")
 	oomKillDisable := viper.GetBool(dockerKey("OomKillDisable"))
 
+	var pidsLimit *int64
+	if viper.IsSet(dockerKey("PidsLimit")) {
+		limit := getInt64("PidsLimit")
+		pidsLimit = &limit
+	}
+
 	return &docker.HostConfig{
 		CapAdd:  viper.GetStringSlice(dockerKey("CapAdd")),
 		CapDrop: viper.GetStringSlice(dockerKey("CapDrop")),
@@ -1305,7 +1429,63 @@ func getDockerHostConfig() *docker.HostConfig {
 		CPUQuota:         getInt64("CpuQuota"),
 		CPUPeriod:        getInt64("CpuPeriod"),
 		BlkioWeight:      getInt64("BlkioWeight"),
+		PidsLimit:        pidsLimit,
+	}
+}
+
+func getChaincodeHostConfigs() map[string]dockercontroller.HostConfigOverride {
+	var overrides map[string]dockercontroller.HostConfigOverride
+	err := viper.UnmarshalKey("vm.docker.chaincodeHostConfig", &overrides)
+	if err != nil {
+		logger.Panicf("unable to parse Docker chaincodeHostConfig: %s", err)
+	}
+	return overrides
+}
+
+// getPackageVerifier returns the verifier for the signatures over chaincode
+// packages which are installed, or nil when the chaincode install policy is
+// not configured.
+func getPackageVerifier(cryptoProvider bccsp.BCCSP) (lifecycle.PackageVerifier, error) {
+	policy := viper.GetString("chaincode.installPolicy.policy")
+	if policy == "" {
+		return nil, nil
+	}
+
+	var signers []struct {
+		MSPID         string `mapstructure:"mspID"`
+		MSPConfigPath string `mapstructure:"mspConfigPath"`
+	}
+	if err := viper.UnmarshalKey("chaincode.installPolicy.signers", &signers); err != nil {
+		return nil, errors.Wrap(err, "could not decode chaincode install policy signers")
 	}
+	if len(signers) == 0 {
+		return nil, errors.New("no signers configured for chaincode install policy")
+	}
+
+	configDir := filepath.Dir(viper.ConfigFileUsed())
+	msps := make([]msp.MSP, 0, len(signers))
+	for _, signer := range signers {
+		mspConfigPath := coreconfig.TranslatePath(configDir, signer.MSPConfigPath)
+		mspConfig, err := msp.GetVerifyingMspConfig(mspConfigPath, signer.MSPID, msp.ProviderTypeToString(msp.FABRIC))
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not load MSP configuration for signer '%s'", signer.MSPID)
+		}
+		signerMSP, err := msp.New(msp.Options[msp.ProviderTypeToString(msp.FABRIC)], cryptoProvider)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not create MSP for signer '%s'", signer.MSPID)
+		}
+		if err := signerMSP.Setup(mspConfig); err != nil {
+			return nil, errors.WithMessagef(err, "could not set up MSP for signer '%s'", signer.MSPID)
+		}
+		msps = append(msps, signerMSP)
+	}
+
+	mspManager := msp.NewMSPManager()
+	if err := mspManager.Setup(msps); err != nil {
+		return nil, errors.WithMessage(err, "could not set up MSP manager for chaincode install policy signers")
+	}
+
+	return lifecycle.NewPackageSignatureVerifier(policy, mspManager)
 }
 
 //go:generate counterfeiter -o mock/get_ledger.go -fake-name GetLedger . getLedger
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/core/handlers/library"
 	"github.com/hyperledger/fabric/core/testutil"
 	"github.com/hyperledger/fabric/internal/peer/node/mock"
@@ -166,6 +167,68 @@ func TestGetDockerHostConfig(t *testing.T) {
 	require.Equal(t, int64(0), hostConfig.CPUShares)
 }
 
+func TestGetChaincodeHostConfigs(t *testing.T) {
+	testutil.SetupTestConfig()
+	require.Empty(t, getChaincodeHostConfigs())
+
+	viper.Set("vm.docker.chaincodeHostConfig", map[string]interface{}{
+		"MyCC": map[string]interface{}{
+			"Memory":         536870912,
+			"CpuQuota":       50000,
+			"ReadonlyRootfs": true,
+			"CapDrop":        []string{"ALL"},
+		},
+	})
+	defer viper.Set("vm.docker.chaincodeHostConfig", nil)
+
+	overrides := getChaincodeHostConfigs()
+	require.Len(t, overrides, 1)
+	override := overrides["mycc"]
+	require.Equal(t, int64(536870912), *override.Memory)
+	require.Equal(t, int64(50000), *override.CPUQuota)
+	require.Nil(t, override.CPUPeriod)
+	require.Nil(t, override.PidsLimit)
+	require.True(t, *override.ReadonlyRootfs)
+	require.Equal(t, []string{"ALL"}, override.CapDrop)
+}
+
+func TestGetPackageVerifier(t *testing.T) {
+	testutil.SetupTestConfig()
+	defer viper.Set("chaincode.installPolicy", nil)
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	verifier, err := getPackageVerifier(cryptoProvider)
+	require.NoError(t, err)
+	require.Nil(t, verifier)
+
+	viper.Set("chaincode.installPolicy.policy", "OR('SampleOrg.member')")
+	_, err = getPackageVerifier(cryptoProvider)
+	require.EqualError(t, err, "no signers configured for chaincode install policy")
+
+	viper.Set("chaincode.installPolicy.signers", []map[string]interface{}{
+		{"mspID": "SampleOrg", "mspConfigPath": "msp"},
+	})
+	verifier, err = getPackageVerifier(cryptoProvider)
+	require.NoError(t, err)
+	require.NotNil(t, verifier)
+
+	viper.Set("chaincode.installPolicy.signers", []map[string]interface{}{
+		{"mspID": "SampleOrg", "mspConfigPath": "missing-msp"},
+	})
+	_, err = getPackageVerifier(cryptoProvider)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not load MSP configuration for signer 'SampleOrg'")
+
+	viper.Set("chaincode.installPolicy.policy", "bad-policy")
+	viper.Set("chaincode.installPolicy.signers", []map[string]interface{}{
+		{"mspID": "SampleOrg", "mspConfigPath": "msp"},
+	})
+	_, err = getPackageVerifier(cryptoProvider)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid chaincode install policy 'bad-policy'")
+}
+
 func TestResetLoop(t *testing.T) {
 	peerLedger := &mock.PeerLedger{}
 	peerLedger.GetBlockchainInfoReturnsOnCall(
@@ -9,9 +9,9 @@ package commit
 import (
 	"sync"
 
-	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protoutil"
 )
 
 type BlockChaincodeEvents struct {
@@ -69,20 +69,22 @@ func getEventsByChaincodeName(blockEvent *ledger.CommitNotification) map[string]
 
 	for _, txInfo := range blockEvent.TxsInfo {
 		if txInfo.ChaincodeEventData != nil {
-			event := &peer.ChaincodeEvent{}
-			if err := proto.Unmarshal(txInfo.ChaincodeEventData, event); err != nil {
+			txEvents, err := protoutil.UnmarshalChaincodeEventList(txInfo.ChaincodeEventData)
+			if err != nil {
 				continue
 			}
 
-			events := results[event.ChaincodeId]
-			if events == nil {
-				events = &BlockChaincodeEvents{
-					BlockNumber: blockEvent.BlockNumber,
+			for _, event := range txEvents {
+				events := results[event.ChaincodeId]
+				if events == nil {
+					events = &BlockChaincodeEvents{
+						BlockNumber: blockEvent.BlockNumber,
+					}
+					results[event.ChaincodeId] = events
 				}
-				results[event.ChaincodeId] = events
-			}
 
-			events.Events = append(events.Events, event)
+				events.Events = append(events.Events, event)
+			}
 		}
 	}
 
@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/internal/pkg/gateway/commit/mocks"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
@@ -92,6 +93,39 @@ func TestEventer(t *testing.T) {
 			assertEqualChaincodeEvents(t, expectedEvents, actual.Events)
 		})
 
+		t.Run("delivers each of multiple events set by a transaction", func(t *testing.T) {
+			commitSend := make(chan *ledger.CommitNotification, 1)
+			notifier := newTestNotifier(commitSend)
+			defer notifier.close()
+			eventer := NewEventer(notifier)
+
+			eventReceive, err := eventer.ChaincodeEvents(context.Background(), "CHANNEL_NAME", "CHAINCODE_NAME")
+			require.NoError(t, err)
+
+			firstEvent := newTestChaincodeEvent("CHAINCODE_NAME")
+			firstEvent.EventName = "FIRST_EVENT"
+			lastEvent := newTestChaincodeEvent("CHAINCODE_NAME")
+			eventList, err := protoutil.NewChaincodeEventList([]*peer.ChaincodeEvent{firstEvent, lastEvent})
+			require.NoError(t, err)
+
+			commitSend <- &ledger.CommitNotification{
+				BlockNumber: 1,
+				TxsInfo: []*ledger.CommitNotificationTxInfo{
+					{
+						ChaincodeEventData: assertMarshallProto(t, eventList),
+					},
+				},
+			}
+			actual := <-eventReceive
+
+			expectedEvents := []*peer.ChaincodeEvent{
+				firstEvent,
+				lastEvent,
+			}
+			require.Equal(t, uint64(1), actual.BlockNumber, "block number")
+			assertEqualChaincodeEvents(t, expectedEvents, actual.Events)
+		})
+
 		t.Run("delivers events for multiple blocks", func(t *testing.T) {
 			commitSend := make(chan *ledger.CommitNotification, 1)
 			notifier := newTestNotifier(commitSend)
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ccevents lets chaincode emit several events in a transaction.
+//
+// The shim sends a single event per transaction: the last one set. An
+// Emitter therefore sends the events of a transaction which emits more than
+// one event as a list, carried by an event named ListEventName. Peers of
+// channels which support multiple chaincode events per transaction unpack
+// the list, and deliver each of its events. Peers of other channels only
+// deliver the last event of the transaction.
+package ccevents
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/pkg/ccevents/msgs"
+	"github.com/pkg/errors"
+)
+
+// ListEventName is the name of the event which carries the events of a
+// transaction that emitted more than one event. Chaincode can't emit events
+// with this name.
+const ListEventName = "_fabric_events"
+
+// Emitter emits the events of a transaction
+type Emitter struct {
+	stub   shim.ChaincodeStubInterface
+	events []*peer.ChaincodeEvent
+}
+
+// NewEmitter returns an Emitter which emits events through the given stub.
+// An Emitter is meant to be used for a single transaction.
+func NewEmitter(stub shim.ChaincodeStubInterface) *Emitter {
+	return &Emitter{stub: stub}
+}
+
+// Emit adds an event to the events emitted in the transaction. A transaction
+// which emits a single event sets it as is, so that it is delivered as any
+// other event.
+func (e *Emitter) Emit(name string, payload []byte) error {
+	if name == "" {
+		return errors.New("event name can not be empty string")
+	}
+	if name == ListEventName {
+		return errors.Errorf("event name %s is reserved", ListEventName)
+	}
+
+	e.events = append(e.events, &peer.ChaincodeEvent{EventName: name, Payload: payload})
+	if len(e.events) == 1 {
+		return e.stub.SetEvent(name, payload)
+	}
+
+	list, err := proto.Marshal(&msgs.ChaincodeEventList{Events: e.events})
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling events")
+	}
+	return e.stub.SetEvent(ListEventName, list)
+}
+
+// Unpack returns the events carried by an event set by a chaincode. An event
+// named ListEventName carries the events of its list, and any other event
+// carries itself.
+func Unpack(event *peer.ChaincodeEvent) ([]*peer.ChaincodeEvent, error) {
+	if event == nil {
+		return nil, nil
+	}
+	if event.EventName != ListEventName {
+		return []*peer.ChaincodeEvent{event}, nil
+	}
+
+	list := &msgs.ChaincodeEventList{}
+	if err := proto.Unmarshal(event.Payload, list); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling events")
+	}
+	if len(list.Events) == 0 {
+		return nil, errors.New("empty list of events")
+	}
+	for _, listed := range list.Events {
+		if listed.EventName == "" || listed.EventName == ListEventName {
+			return nil, errors.Errorf("invalid event name '%s'", listed.EventName)
+		}
+	}
+	return list.Events, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccevents_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/pkg/ccevents"
+	"github.com/hyperledger/fabric/pkg/ccevents/msgs"
+	"github.com/stretchr/testify/require"
+)
+
+// stub keeps the last event set, as the shim does
+type stub struct {
+	shim.ChaincodeStubInterface
+	event *peer.ChaincodeEvent
+}
+
+func (s *stub) SetEvent(name string, payload []byte) error {
+	s.event = &peer.ChaincodeEvent{EventName: name, Payload: payload}
+	return nil
+}
+
+func TestEmitter(t *testing.T) {
+	s := &stub{}
+	e := ccevents.NewEmitter(s)
+
+	// A single event is set as is
+	require.NoError(t, e.Emit("first", []byte("payload1")))
+	require.Equal(t, &peer.ChaincodeEvent{EventName: "first", Payload: []byte("payload1")}, s.event)
+	events, err := ccevents.Unpack(s.event)
+	require.NoError(t, err)
+	require.Equal(t, []*peer.ChaincodeEvent{s.event}, events)
+
+	// Several events are set as a list
+	require.NoError(t, e.Emit("second", nil))
+	require.NoError(t, e.Emit("third", []byte("payload3")))
+	require.Equal(t, ccevents.ListEventName, s.event.EventName)
+	events, err = ccevents.Unpack(s.event)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	for i, name := range []string{"first", "second", "third"} {
+		require.Equal(t, name, events[i].EventName)
+	}
+	require.Equal(t, []byte("payload3"), events[2].Payload)
+
+	require.EqualError(t, e.Emit("", nil), "event name can not be empty string")
+	require.EqualError(t, e.Emit(ccevents.ListEventName, nil), "event name _fabric_events is reserved")
+}
+
+func TestUnpack(t *testing.T) {
+	events, err := ccevents.Unpack(nil)
+	require.NoError(t, err)
+	require.Nil(t, events)
+
+	_, err = ccevents.Unpack(&peer.ChaincodeEvent{EventName: ccevents.ListEventName, Payload: []byte{0x0a}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed unmarshaling events")
+
+	_, err = ccevents.Unpack(&peer.ChaincodeEvent{EventName: ccevents.ListEventName})
+	require.EqualError(t, err, "empty list of events")
+
+	list, err := proto.Marshal(&msgs.ChaincodeEventList{Events: []*peer.ChaincodeEvent{{EventName: ccevents.ListEventName}}})
+	require.NoError(t, err)
+	_, err = ccevents.Unpack(&peer.ChaincodeEvent{EventName: ccevents.ListEventName, Payload: list})
+	require.EqualError(t, err, "invalid event name '_fabric_events'")
+}
@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: ccevents.proto
+
+package msgs
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	peer "github.com/hyperledger/fabric-protos-go/peer"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ChaincodeEvent extends protos.ChaincodeEvent with the events a chaincode
+// set in a transaction which set more than one event. It has the fields of
+// protos.ChaincodeEvent, so that the two can be converted to each other. Its
+// regular fields hold the last event of the transaction, which is all that
+// consumers which do not know about the added field see.
+type ChaincodeEvent struct {
+	ChaincodeId string `protobuf:"bytes,1,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
+	TxId        string `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	EventName   string `protobuf:"bytes,3,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	Payload     []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// events are the events set in the transaction, in the order they were
+	// set. The field is numbered away from the fields of protos.ChaincodeEvent.
+	Events               []*peer.ChaincodeEvent `protobuf:"bytes,100,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ChaincodeEvent) Reset()         { *m = ChaincodeEvent{} }
+func (m *ChaincodeEvent) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEvent) ProtoMessage()    {}
+func (*ChaincodeEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_117efb46f13d4aad, []int{0}
+}
+
+func (m *ChaincodeEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEvent.Unmarshal(m, b)
+}
+func (m *ChaincodeEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEvent.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEvent.Merge(m, src)
+}
+func (m *ChaincodeEvent) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEvent.Size(m)
+}
+func (m *ChaincodeEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEvent proto.InternalMessageInfo
+
+func (m *ChaincodeEvent) GetChaincodeId() string {
+	if m != nil {
+		return m.ChaincodeId
+	}
+	return ""
+}
+
+func (m *ChaincodeEvent) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *ChaincodeEvent) GetEventName() string {
+	if m != nil {
+		return m.EventName
+	}
+	return ""
+}
+
+func (m *ChaincodeEvent) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *ChaincodeEvent) GetEvents() []*peer.ChaincodeEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+// ChaincodeEventList is the payload of the event a chaincode sets to send
+// several events in a transaction, since the shim sends a single event per
+// transaction.
+type ChaincodeEventList struct {
+	Events               []*peer.ChaincodeEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *ChaincodeEventList) Reset()         { *m = ChaincodeEventList{} }
+func (m *ChaincodeEventList) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventList) ProtoMessage()    {}
+func (*ChaincodeEventList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_117efb46f13d4aad, []int{1}
+}
+
+func (m *ChaincodeEventList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventList.Unmarshal(m, b)
+}
+func (m *ChaincodeEventList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventList.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeEventList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventList.Merge(m, src)
+}
+func (m *ChaincodeEventList) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventList.Size(m)
+}
+func (m *ChaincodeEventList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventList proto.InternalMessageInfo
+
+func (m *ChaincodeEventList) GetEvents() []*peer.ChaincodeEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ChaincodeEvent)(nil), "ccevents.msgs.ChaincodeEvent")
+	proto.RegisterType((*ChaincodeEventList)(nil), "ccevents.msgs.ChaincodeEventList")
+}
+
+func init() { proto.RegisterFile("ccevents.proto", fileDescriptor_117efb46f13d4aad) }
+
+var fileDescriptor_117efb46f13d4aad = []byte{
+	// 241 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0xcd, 0x4a, 0x03, 0x31,
+	0x14, 0x85, 0x89, 0xad, 0x95, 0xde, 0xd6, 0x2e, 0x22, 0x48, 0x28, 0x08, 0x63, 0x57, 0xb3, 0x9a,
+	0xa0, 0xbe, 0x81, 0x3f, 0x8b, 0x82, 0xb8, 0x98, 0xa5, 0x9b, 0x92, 0x49, 0xae, 0x33, 0xc1, 0x66,
+	0x12, 0x92, 0x28, 0xed, 0x63, 0xf9, 0x86, 0x32, 0x19, 0xa7, 0xda, 0x9d, 0xcb, 0x7b, 0xbf, 0xc3,
+	0xc7, 0xe1, 0xc0, 0x42, 0x4a, 0xfc, 0xc4, 0x36, 0x86, 0xc2, 0x79, 0x1b, 0x2d, 0x3d, 0x3f, 0xdc,
+	0x26, 0xd4, 0x61, 0xb9, 0x74, 0x88, 0x9e, 0xcb, 0x46, 0xe8, 0x56, 0x5a, 0x85, 0x9b, 0xc4, 0xfa,
+	0xe8, 0xea, 0x8b, 0xc0, 0xe2, 0x61, 0x20, 0x4f, 0x1d, 0xa0, 0xd7, 0x30, 0xff, 0xcd, 0x6a, 0xc5,
+	0x48, 0x46, 0xf2, 0x69, 0x39, 0x3b, 0xfc, 0xd6, 0x8a, 0x5e, 0xc0, 0x69, 0xdc, 0x75, 0xec, 0x24,
+	0xb1, 0x71, 0xdc, 0xad, 0x15, 0xbd, 0x02, 0x48, 0xe6, 0x4d, 0x2b, 0x0c, 0xb2, 0x51, 0x22, 0xd3,
+	0xf4, 0x79, 0x11, 0x06, 0x29, 0x83, 0x33, 0x27, 0xf6, 0x5b, 0x2b, 0x14, 0x1b, 0x67, 0x24, 0x9f,
+	0x97, 0xc3, 0x49, 0x0b, 0x98, 0xf4, 0x75, 0x99, 0xca, 0x46, 0xf9, 0xec, 0xf6, 0xb2, 0xef, 0x16,
+	0x8a, 0xe3, 0x62, 0xe5, 0x4f, 0x6a, 0xf5, 0x08, 0xf4, 0x98, 0x3c, 0xeb, 0x10, 0xff, 0x58, 0xc8,
+	0x7f, 0x2c, 0xf7, 0x37, 0xaf, 0xbc, 0xd6, 0xb1, 0xf9, 0xa8, 0x0a, 0x69, 0x0d, 0x6f, 0xf6, 0x0e,
+	0xfd, 0x16, 0x55, 0x8d, 0x9e, 0xbf, 0x89, 0xca, 0x6b, 0xc9, 0xdd, 0x7b, 0xcd, 0x87, 0x21, 0x79,
+	0x37, 0x64, 0x35, 0x49, 0xc6, 0xbb, 0xef, 0x01, 0x00, 0xa0, 0x56, 0xfd, 0xe4, 0x70, 0x01, 0x00,
+	0x00,
+}
@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoutil
+
+import (
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/pkg/ccevents/msgs"
+	"github.com/pkg/errors"
+)
+
+// NewChaincodeEventList returns a ChaincodeEvent which carries the events.
+// A single event is returned as is, so that transactions which set one event
+// are unchanged. Otherwise, the regular fields of the returned ChaincodeEvent
+// hold the last event and the list of all events is carried in an additional
+// field.
+func NewChaincodeEventList(events []*peer.ChaincodeEvent) (*peer.ChaincodeEvent, error) {
+	switch len(events) {
+	case 0:
+		return nil, nil
+	case 1:
+		return events[0], nil
+	}
+
+	last := events[len(events)-1]
+	event := &peer.ChaincodeEvent{}
+	err := ConvertMessage(&msgs.ChaincodeEvent{
+		ChaincodeId: last.ChaincodeId,
+		TxId:        last.TxId,
+		EventName:   last.EventName,
+		Payload:     last.Payload,
+		Events:      events,
+	}, event)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error encoding ChaincodeEvent list")
+	}
+	return event, nil
+}
+
+// GetChaincodeEventList returns the events carried by a ChaincodeEvent. A
+// ChaincodeEvent which does not carry a list of events carries itself.
+func GetChaincodeEventList(event *peer.ChaincodeEvent) ([]*peer.ChaincodeEvent, error) {
+	if event == nil {
+		return nil, nil
+	}
+
+	list := &msgs.ChaincodeEvent{}
+	if err := ConvertMessage(event, list); err != nil {
+		return nil, errors.WithMessage(err, "malformed ChaincodeEvent list")
+	}
+	if len(list.Events) == 0 {
+		return []*peer.ChaincodeEvent{event}, nil
+	}
+	return list.Events, nil
+}
+
+// UnmarshalChaincodeEventList unmarshals the events bytes of a chaincode
+// action to the events carried by them. Listed events which do not belong to
+// the chaincode and transaction of the ChaincodeEvent carrying them are
+// discarded.
+func UnmarshalChaincodeEventList(eBytes []byte) ([]*peer.ChaincodeEvent, error) {
+	event, err := UnmarshalChaincodeEvents(eBytes)
+	if err != nil {
+		return nil, err
+	}
+	events, err := GetChaincodeEventList(event)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*peer.ChaincodeEvent
+	for _, e := range events {
+		if e.ChaincodeId == event.ChaincodeId && e.TxId == event.TxId {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
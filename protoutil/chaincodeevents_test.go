@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoutil_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaincodeEventList(t *testing.T) {
+	events := []*pb.ChaincodeEvent{
+		{ChaincodeId: "mycc", TxId: "tx1", EventName: "first", Payload: []byte("payload1")},
+		{ChaincodeId: "mycc", TxId: "tx1", EventName: "second"},
+		{ChaincodeId: "mycc", TxId: "tx1", EventName: "third", Payload: []byte("payload3")},
+	}
+
+	event, err := protoutil.NewChaincodeEventList(events)
+	require.NoError(t, err)
+
+	eventBytes, err := proto.Marshal(event)
+	require.NoError(t, err)
+
+	// consumers which do not know about the list see the last event
+	legacy := &pb.ChaincodeEvent{}
+	require.NoError(t, proto.Unmarshal(eventBytes, legacy))
+	require.Equal(t, "mycc", legacy.ChaincodeId)
+	require.Equal(t, "tx1", legacy.TxId)
+	require.Equal(t, "third", legacy.EventName)
+	require.Equal(t, []byte("payload3"), legacy.Payload)
+
+	listed, err := protoutil.GetChaincodeEventList(legacy)
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	for i := range events {
+		require.True(t, proto.Equal(events[i], listed[i]))
+	}
+
+	remarshaled, err := proto.Marshal(legacy)
+	require.NoError(t, err)
+	require.Equal(t, eventBytes, remarshaled)
+}
+
+func TestChaincodeEventListSingleEvent(t *testing.T) {
+	event, err := protoutil.NewChaincodeEventList(nil)
+	require.NoError(t, err)
+	require.Nil(t, event)
+
+	single := &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx1", EventName: "only"}
+	event, err = protoutil.NewChaincodeEventList([]*pb.ChaincodeEvent{single})
+	require.NoError(t, err)
+	require.Same(t, single, event)
+
+	listed, err := protoutil.GetChaincodeEventList(single)
+	require.NoError(t, err)
+	require.Equal(t, []*pb.ChaincodeEvent{single}, listed)
+
+	listed, err = protoutil.GetChaincodeEventList(nil)
+	require.NoError(t, err)
+	require.Nil(t, listed)
+}
+
+func TestGetChaincodeEventListMalformed(t *testing.T) {
+	for _, unrecognized := range [][]byte{
+		{0xa2, 0x06, 0x05, 0x01},
+		{0xa3},
+		{0xa7, 0x06},
+		{0xa2, 0x06, 0x01, 0xff},
+	} {
+		_, err := protoutil.GetChaincodeEventList(&pb.ChaincodeEvent{XXX_unrecognized: unrecognized})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "malformed ChaincodeEvent list")
+	}
+}
+
+func TestUnmarshalChaincodeEventList(t *testing.T) {
+	event, err := protoutil.NewChaincodeEventList([]*pb.ChaincodeEvent{
+		{ChaincodeId: "mycc", TxId: "tx1", EventName: "first"},
+		{ChaincodeId: "othercc", TxId: "tx1", EventName: "spoofed"},
+		{ChaincodeId: "mycc", TxId: "tx1", EventName: "last"},
+	})
+	require.NoError(t, err)
+	eventBytes, err := proto.Marshal(event)
+	require.NoError(t, err)
+
+	events, err := protoutil.UnmarshalChaincodeEventList(eventBytes)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, "first", events[0].EventName)
+	require.Equal(t, "last", events[1].EventName)
+
+	_, err = protoutil.UnmarshalChaincodeEventList([]byte("garbage"))
+	require.Error(t, err)
+}
@@ -33,6 +33,18 @@ func Marshal(pb proto.Message) ([]byte, error) {
 	return proto.Marshal(pb)
 }
 
+// ConvertMessage converts a protobuf message to a message whose definition
+// shares its wire format, such as a message which extends it with additional
+// fields. Fields of src which dst does not define are kept as unknown fields
+// of dst, so that they survive a conversion back.
+func ConvertMessage(src, dst proto.Message) error {
+	b, err := proto.Marshal(src)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling %T", src)
+	}
+	return errors.Wrapf(proto.Unmarshal(b, dst), "error unmarshalling %T", dst)
+}
+
 // CreateNonceOrPanic generates a nonce using the common/crypto package
 // and panics if this operation fails.
 func CreateNonceOrPanic() []byte {
@@ -459,3 +459,20 @@ func TestGetRandomNonce(t *testing.T) {
 	require.NoErrorf(t, err, "error getting random bytes")
 	require.Len(t, key1, crypto.NonceSize)
 }
+
+func TestConvertMessage(t *testing.T) {
+	env := &cb.Envelope{Payload: []byte("payload"), Signature: []byte("signature")}
+
+	// The signature is unknown to BlockData, and survives a conversion back
+	data := &cb.BlockData{}
+	require.NoError(t, ConvertMessage(env, data))
+	require.Equal(t, [][]byte{[]byte("payload")}, data.Data)
+
+	converted := &cb.Envelope{}
+	require.NoError(t, ConvertMessage(data, converted))
+	require.True(t, proto.Equal(env, converted))
+
+	err := ConvertMessage(&cb.ChannelHeader{ChannelId: "\xff"}, &cb.ChannelHeader{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error marshaling *common.ChannelHeader")
+}